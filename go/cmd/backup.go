@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"ai-scheduler/internal/backup"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runBackup çalışan scheduler'ın durumunu arşiv olarak indirir, çıkış kodunu döndürür
+func runBackup(args []string, config *types.Config) int {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	server := flags.String("server", defaultServerURL(&config.Server), "çalışan scheduler'ın adresi")
+	out := flags.String("out", "", "arşivi bu dosyaya yaz (boşsa ai-scheduler-backup-<zaman>.tar.gz)")
+	timeout := flags.Duration("timeout", 5*time.Minute, "istek zaman aşımı")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	path := *out
+	if path == "" {
+		path = fmt.Sprintf("ai-scheduler-backup-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Get(*server + "/api/v1/admin/backup")
+	if err != nil {
+		logrus.Errorf("Yedek alınamadı: %v", err)
+		return 1
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logrus.Errorf("Yedek alınamadı: scheduler hata döndürdü: %d", resp.StatusCode)
+		return 1
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		logrus.Errorf("Yedek dosyası oluşturulamadı: %v", err)
+		return 1
+	}
+	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logrus.Errorf("Yedek yazılamadı: %v", err)
+		return 1
+	}
+
+	// Yarım kalan indirme başarılı sayılmaz
+	archive, err := readArchive(path)
+	if err != nil {
+		logrus.Errorf("Yedek doğrulanamadı: %v", err)
+		return 1
+	}
+
+	logrus.Infof("Yedek: %d kayıt, %d örnek", archive.Manifest.Entries, archive.Manifest.Samples)
+	logrus.Infof("Yedek %s dosyasına yazıldı", path)
+	return 0
+}
+
+// runRestore arşivi çalışan scheduler'a geri yükler, çıkış kodunu döndürür
+func runRestore(args []string, config *types.Config) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	server := flags.String("server", defaultServerURL(&config.Server), "çalışan scheduler'ın adresi")
+	applyConfig := flags.Bool("config", true, "arşivdeki scheduler konfigürasyonunu da uygula")
+	timeout := flags.Duration("timeout", 5*time.Minute, "istek zaman aşımı")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Kullanım: ai-scheduler restore [-server URL] [-config=false] <arşiv>")
+		return 2
+	}
+
+	// Bozuk arşiv gönderilmeden önce yerelde doğrulanır
+	if _, err := readArchive(flags.Arg(0)); err != nil {
+		logrus.Errorf("Yedek okunamadı: %v", err)
+		return 1
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		logrus.Errorf("Yedek dosyası açılamadı: %v", err)
+		return 1
+	}
+	defer file.Close()
+
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Post(*server+"/api/v1/admin/restore?config="+strconv.FormatBool(*applyConfig), "application/gzip", file)
+	if err != nil {
+		logrus.Errorf("Yedek geri yüklenemedi: %v", err)
+		return 1
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		backup.RestoreResult
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		logrus.Errorf("Yanıt parse edilemedi: %v", err)
+		return 1
+	}
+	if resp.StatusCode != http.StatusOK {
+		logrus.Errorf("Yedek geri yüklenemedi: %s", result.Error)
+		return 1
+	}
+
+	logrus.Infof("Yedek geri yüklendi: %d kayıt, %d örnek, %d geçmiş dilimi, konfigürasyon uygulandı: %t",
+		result.Entries, result.Samples, result.HistorySamples, result.ConfigApplied)
+	return 0
+}
+
+// readArchive arşiv dosyasını okur
+func readArchive(path string) (*backup.Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return backup.Read(file)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"ai-scheduler/internal/bench"
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/journal"
+	"ai-scheduler/internal/simulator"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// runBench sentetik küme üzerinde tahmin benchmark'ı çalıştırır ve raporu yazdırır, çıkış kodunu döndürür
+func runBench(args []string, config *types.Config) int {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	requests := flags.Int("requests", 10000, "toplam tahmin isteği")
+	concurrency := flags.Int("concurrency", runtime.NumCPU(), "eşzamanlı worker sayısı")
+	duration := flags.Duration("duration", 0, "benchmark süresi (0: istek sayısı kadar)")
+	nodes := flags.Int("nodes", config.Development.Synthetic.Nodes, "sentetik node sayısı")
+	podsPerNode := flags.Int("pods-per-node", config.Development.Synthetic.PodsPerNode, "node başına sentetik pod")
+	pending := flags.Int("pending", 0, "bekleyen pod sayısı, verilirse iş yükü bu pod'lardan oluşur ve tahminler assume edilir")
+	forgetEvery := flags.Int("forget-every", 0, "her N. tahminin assume kaydını geri al (0: hiç)")
+	latencyBudget := flags.Duration("latency-budget", config.Scheduler.LatencyBudget, "tahmin başına filtreleme+skorlama süre hedefi")
+	assumeTTL := flags.Duration("assume-ttl", config.Scheduler.AssumeTTL, "assume kayıtlarının süresi (0: assume yok)")
+	churn := flags.Bool("churn", false, "benchmark sırasında sentetik kümeyi ilerlet")
+	journalFile := flags.String("journal", "", "karar günlüğü, verilirse iş yükü günlükteki pod'lardan karar sırasıyla oluşur")
+	ranked := flags.Bool("ranked", false, "tahminleri artımlı skorlamanın hazır sıralamasından yap")
+	scoreCacheTTL := flags.Duration("score-cache-ttl", config.Scheduler.ScoreCacheTTL, "skor cache süresi (0: her tahminde skorla)")
+	out := flags.String("out", "", "sonucu JSON olarak bu dosyaya yaz (sonraki çalıştırmada -baseline ile karşılaştırmak için)")
+	baseline := flags.String("baseline", "", "önceki sonuç dosyası, verilirse önce/sonra farkı yazdırılır")
+	cpuProfile := flags.String("cpuprofile", "", "CPU profilini bu dosyaya yaz")
+	memProfile := flags.String("memprofile", "", "allocation profilini bu dosyaya yaz")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// Tahmin başına loglar ölçümü bozmasın
+	logrus.SetLevel(logrus.WarnLevel)
+
+	clusterConfig := config.Development.Synthetic
+	clusterConfig.Nodes = *nodes
+	clusterConfig.PodsPerNode = *podsPerNode
+	clusterConfig.PendingPods = *pending
+	cluster := simulator.NewCluster(&clusterConfig)
+
+	var workload []*corev1.Pod
+	if *journalFile != "" {
+		pods, err := journal.Workload(*journalFile)
+		if err != nil {
+			logrus.Errorf("Günlük iş yükü okunamadı: %v", err)
+			return 1
+		}
+		cluster.AddPods(pods)
+		workload = pods
+	}
+
+	schedulerConfig := config.Scheduler
+	schedulerConfig.ScoreCacheTTL = *scoreCacheTTL
+	schedulerConfig.LatencyBudget = *latencyBudget
+	schedulerConfig.AssumeTTL = *assumeTTL
+	if *ranked {
+		schedulerConfig.IncrementalScoring = true
+	}
+
+	if *cpuProfile != "" {
+		file, err := os.Create(*cpuProfile)
+		if err != nil {
+			logrus.Errorf("CPU profil dosyası oluşturulamadı: %v", err)
+			return 1
+		}
+		defer file.Close()
+		if err := pprof.StartCPUProfile(file); err != nil {
+			logrus.Errorf("CPU profili başlatılamadı: %v", err)
+			return 1
+		}
+	}
+	if *memProfile != "" {
+		runtime.MemProfileRate = 4096
+	}
+
+	result, err := bench.Run(context.Background(), cluster, &schedulerConfig, features.NewGate(config.Features), bench.Options{
+		Requests:    *requests,
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Churn:       *churn,
+		Ranked:      *ranked,
+		Pending:     *pending > 0 || workload != nil,
+		ForgetEvery: *forgetEvery,
+		Workload:    workload,
+	})
+	if *cpuProfile != "" {
+		pprof.StopCPUProfile()
+	}
+	if err != nil {
+		logrus.Errorf("Benchmark başarısız: %v", err)
+		return 1
+	}
+
+	if *memProfile != "" {
+		if err := writeHeapProfile(*memProfile); err != nil {
+			logrus.Errorf("Allocation profili yazılamadı: %v", err)
+			return 1
+		}
+	}
+
+	fmt.Printf("Küme:        %d node, %d pod\n", result.Nodes, result.Pods)
+	fmt.Printf("İstek:       %d (%d hata), %d worker, %s\n", result.Requests, result.Errors, result.Concurrency, result.Elapsed.Round(time.Millisecond))
+	fmt.Printf("Throughput:  %.1f tahmin/sn\n", result.Throughput)
+	fmt.Printf("Gecikme:     p50=%s p90=%s p99=%s max=%s\n", result.P50, result.P90, result.P99, result.Max)
+	fmt.Printf("Allocation:  %.0f alloc/tahmin, %.0f B/tahmin, %d GC\n", result.AllocsPerOp, result.BytesPerOp, result.GCCycles)
+	if result.LatencyBudget > 0 {
+		fmt.Printf("Bütçe:       %s, %d tahmin aştı\n", result.LatencyBudget, result.BudgetExceeded)
+	}
+	if *pending > 0 || workload != nil {
+		fmt.Printf("Assume:      %d kayıt bekliyor, %d forget\n", result.Assumed, result.Forgotten)
+	}
+
+	if *baseline != "" {
+		before, err := bench.LoadResult(*baseline)
+		if err != nil {
+			logrus.Errorf("Baseline okunamadı: %v", err)
+			return 1
+		}
+		fmt.Println()
+		fmt.Println("Baseline karşılaştırması (önce -> sonra):")
+		for _, delta := range bench.Compare(before, result) {
+			fmt.Printf("  %-12s %14s -> %-14s %+.1f%%\n", delta.Name, delta.Before, delta.After, delta.Change)
+		}
+	}
+
+	if *out != "" {
+		if err := bench.SaveResult(*out, result); err != nil {
+			logrus.Errorf("Sonuç yazılamadı: %v", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// writeHeapProfile allocation profilini dosyaya yazar
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return pprof.Lookup("allocs").WriteTo(file, 0)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	appconfig "ai-scheduler/internal/config"
+	"ai-scheduler/internal/doctor"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runDoctor scheduler'ın bağımlılıklarını ve konfigürasyonunu denetler, başarısız denetim varsa 1 döndürür
+func runDoctor(args []string, config *types.Config) int {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "raporu JSON olarak yaz")
+	timeout := flags.Duration("timeout", 30*time.Second, "tüm denetimler için zaman aşımı")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	k8sClient, err := types.NewK8sClient(&config.Kubernetes)
+	if err != nil {
+		logrus.Errorf("Kubernetes client oluşturulamadı: %v", err)
+		return 1
+	}
+	secretStore := appconfig.NewSecretStore(k8sClient, &config.Secrets)
+	if err := secretStore.Refresh(ctx); err != nil {
+		logrus.Warnf("Secret'lar yüklenemedi, inline konfigürasyon kullanılacak: %v", err)
+	}
+
+	report := doctor.New(config, k8sClient, secretStore).Run(ctx)
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			logrus.Errorf("Rapor yazılamadı: %v", err)
+			return 1
+		}
+	} else {
+		for _, check := range report.Checks {
+			fmt.Printf("[%s] %-16s %s\n", strings.ToUpper(check.Status), check.Name, check.Message)
+		}
+	}
+
+	if !report.OK {
+		return 1
+	}
+	return 0
+}
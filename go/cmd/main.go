@@ -2,16 +2,39 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// Saat dilimi veritabanı binary'ye gömülür, minimal imajlarda da scheduler.temporal.timezone çözülebilir
+	_ "time/tzdata"
 
+	"ai-scheduler/internal/admission"
 	"ai-scheduler/internal/api"
 	"ai-scheduler/internal/collector"
+	appconfig "ai-scheduler/internal/config"
+	"ai-scheduler/internal/doctor"
+	"ai-scheduler/internal/eventbus"
+	"ai-scheduler/internal/extmetrics"
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/federation"
+	"ai-scheduler/internal/handoff"
+	"ai-scheduler/internal/hints"
+	"ai-scheduler/internal/informer"
+	"ai-scheduler/internal/journal"
+	"ai-scheduler/internal/mqtt"
+	"ai-scheduler/internal/otellog"
+	"ai-scheduler/internal/platform"
+	"ai-scheduler/internal/report"
 	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/simulator"
+	"ai-scheduler/internal/store"
+	"ai-scheduler/internal/telemetry"
+	"ai-scheduler/internal/trace"
 	"ai-scheduler/internal/types"
 
 	"github.com/gin-gonic/gin"
@@ -19,6 +42,9 @@ import (
 	"github.com/spf13/viper"
 )
 
+// shutdownTimeout kapanışta isteklerin bitmesi ve kalıcı durumun yazılması için tanınan toplam süre
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// Konfigürasyon yükleme
 	viper.SetConfigName("config")
@@ -39,8 +65,26 @@ func main() {
 	// Logger ayarları
 	setupLogging(&config.Logging)
 
+	// Alt komutlar
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			os.Exit(runReplay(os.Args[2:], &config))
+		case "bench":
+			os.Exit(runBench(os.Args[2:], &config))
+		case "report":
+			os.Exit(runReport(os.Args[2:], &config))
+		case "backup":
+			os.Exit(runBackup(os.Args[2:], &config))
+		case "restore":
+			os.Exit(runRestore(os.Args[2:], &config))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:], &config))
+		}
+	}
+
 	// Kubernetes client oluşturma
-	k8sClient, err := types.NewK8sClient()
+	k8sClient, err := types.NewK8sClient(&config.Kubernetes)
 	if err != nil {
 		logrus.Fatalf("Kubernetes client oluşturulamadı: %v", err)
 	}
@@ -50,17 +94,239 @@ func main() {
 		logrus.Warn("Kubernetes client bulunamadı, mock mode'da çalışıyor")
 	}
 
-	// Veri toplayıcı başlatma
-	collector := collector.NewDataCollector(k8sClient, &config.Metrics)
-	go collector.Start(context.Background())
+	// Arka plan döngüleri ve HTTP istekleri bu context'ten türer, kapanışta iptal edilir
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// ConfigMap konfigürasyon kaynağı (opsiyonel)
+	var configSource *appconfig.ConfigMapSource
+	if config.Kubernetes.ConfigMap.Enabled {
+		configSource = appconfig.NewConfigMapSource(k8sClient, &config.Kubernetes.ConfigMap, viper.AllSettings())
+		cmConfig, err := configSource.Load(runCtx)
+		if err != nil {
+			logrus.Warnf("ConfigMap konfigürasyonu yüklenemedi, dosya konfigürasyonu kullanılıyor: %v", err)
+		} else {
+			config = *cmConfig
+			setupLogging(&config.Logging)
+		}
+	}
+
+	// Secret kaynaklı kimlik bilgileri
+	secretStore := appconfig.NewSecretStore(k8sClient, &config.Secrets)
+	if err := secretStore.Refresh(runCtx); err != nil {
+		logrus.Warnf("Secret'lar yüklenemedi, inline konfigürasyon kullanılacak: %v", err)
+	}
+	go secretStore.Start(runCtx)
 
-	// AI Scheduler başlatma
+	// Feature flag'ler
+	featureGate := features.NewGate(config.Features)
+
+	// Veri toplayıcı ve AI Scheduler oluşturma
+	collector := collector.NewDataCollector(k8sClient, &config.Metrics)
+	collector.SetCredentials(secretStore)
+	// Replikalar node geçmişini paylaşacaksa pod metrik cache'i Redis'e yazan cache'le değiştirilir (opsiyonel)
+	var sharedCache *store.RedisPodCache
+	if config.Metrics.SharedCache.Enabled {
+		sharedCache, err = store.NewRedisPodCache(&config.Metrics.SharedCache, config.Metrics.CollectionInterval)
+		if err != nil {
+			logrus.Warnf("Paylaşılan pod metrik cache'i açılamadı, yerel cache kullanılıyor: %v", err)
+		} else {
+			collector.SetPodCache(sharedCache)
+			go sharedCache.Start(runCtx)
+		}
+	}
 	aiScheduler := scheduler.NewAIScheduler(k8sClient, collector, &config.Scheduler)
-	go aiScheduler.Start(context.Background())
+	aiScheduler.SetCredentials(secretStore)
+	aiScheduler.SetFeatureGate(featureGate)
+	platformResolver := platform.NewResolver(&config.Scheduler.Platform)
+	aiScheduler.SetPlatformResolver(platformResolver)
+	capacityWebhook := hints.NewWebhookSink(&config.Scheduler.CapacityHints, secretStore)
+	aiScheduler.AddCapacityHintSink(capacityWebhook)
+	capacityPlanner := report.NewCapacityPlanner(collector, &config.Reports.Capacity)
+	rightsizing := report.NewRightsizingRecommender(collector, &config.Reports.Rightsizing)
+	externalMetrics := extmetrics.NewProvider(aiScheduler, &config.Monitoring.ExternalMetrics)
+	federator := federation.NewFederator(aiScheduler, &config.Federation)
+	limiter := admission.NewLimiter(&config.Scheduler.Admission)
 
-	// HTTP API başlatma
+	// Metrik örnekleri ve kararlar Kafka/NATS'e, kararlar ayrıca OTLP log kaydı olarak yayınlanır (opsiyonel)
+	eventBus := eventbus.NewBus(&config.EventBus)
+	decisionLogs := otellog.NewExporter(&config.OTLPLogs)
+	aiScheduler.SetEventPublisher(types.EventPublishers{eventBus, decisionLogs})
+	go eventBus.Start(runCtx)
+	go decisionLogs.Start(runCtx)
+
+	// Mock modda gerçek küme yerine sentetik küme kullanılır
+	if config.Development.MockData {
+		cluster := simulator.NewCluster(&config.Development.Synthetic)
+		go cluster.Start(runCtx)
+		collector.SetClusterSource(cluster)
+		aiScheduler.SetClusterSource(cluster)
+		logrus.Infof("Sentetik küme mock mode'da çalışıyor (%d node)", len(cluster.Nodes()))
+	} else if config.Kubernetes.InformerCache && k8sClient.Clientset != nil {
+		startInformerCache(runCtx, k8sClient, collector, aiScheduler, config.Kubernetes.APITimeout)
+	}
+
+	// Trace kaydı (opsiyonel)
+	var recorder *trace.FileRecorder
+	if config.Development.Trace.Enabled {
+		recorder, err = trace.NewFileRecorder(config.Development.Trace.File)
+		if err != nil {
+			logrus.Fatalf("Trace kaydı başlatılamadı: %v", err)
+		}
+		aiScheduler.SetRecorder(recorder)
+		logrus.Infof("Trace kaydı %s dosyasına yazılıyor", config.Development.Trace.File)
+	}
+
+	// Ortak geçmiş deposu, pod metrik cache'i veya karar geçmişi kalıcılığı ya da depoya yazan karar günlüğü açıksa açılır
+	var historyStore store.HistoryStore
+	journalInStore := config.Scheduler.Journal.Enabled && config.Scheduler.Journal.Backend == journal.BackendHistoryStore
+	if config.Metrics.Persistence.Enabled || config.Scheduler.DecisionStore.Enabled || journalInStore {
+		historyStore, err = store.Open(&config.HistoryStore)
+		if err != nil {
+			logrus.Fatalf("Geçmiş deposu açılamadı: %v", err)
+		}
+		collector.SetHistoryStore(historyStore)
+		aiScheduler.SetHistoryStore(historyStore)
+		logrus.Infof("Geçmiş deposu açıldı (backend: %s)", config.HistoryStore.Backend)
+	}
+
+	// Karar günlüğü (opsiyonel), açılırken önceki kayıtlar replay edilir
+	var decisionJournal interface {
+		scheduler.Journal
+		Close() error
+	}
+	var restored int
+	if config.Scheduler.Journal.Enabled {
+		replay := func(entry *scheduler.JournalEntry) {
+			aiScheduler.RestoreJournal(entry)
+			restored++
+		}
+		if !config.Scheduler.Journal.Restore {
+			replay = nil
+		}
+		if journalInStore {
+			decisionJournal, err = journal.OpenStore(historyStore, &config.Scheduler.Journal, replay)
+			if err != nil {
+				logrus.Fatalf("Karar günlüğü açılamadı: %v", err)
+			}
+			logrus.Infof("Karar günlüğü geçmiş deposuna yazılıyor (%d kayıt geri yüklendi)", restored)
+		} else {
+			fileJournal, err := journal.Open(&config.Scheduler.Journal, replay)
+			if err != nil {
+				logrus.Fatalf("Karar günlüğü açılamadı: %v", err)
+			}
+			decisionJournal = fileJournal
+			logrus.Infof("Karar günlüğü %s dosyasına yazılıyor (%d kayıt geri yüklendi)", config.Scheduler.Journal.File, restored)
+		}
+		aiScheduler.SetJournal(decisionJournal)
+	}
+
+	// Rolling upgrade'de çalışan replikanın durumu istek almadan önce devralınır
+	handedOff := false
+	if config.Handoff.Enabled && restored == 0 {
+		// Paylaşılan cache zaten Redis'teki geçmişi içerdiği için peer'ın örnekleri yüklenmez
+		podCache := collector.GetPodCache()
+		if sharedCache != nil {
+			podCache = nil
+		}
+		result, err := handoff.Pull(runCtx, &config.Handoff, aiScheduler, podCache, collector.GetNodeHistory())
+		if err != nil {
+			logrus.Warnf("Replika durumu devralınamadı, boş cache'lerle başlanıyor: %v", err)
+		} else {
+			handedOff = true
+			logrus.Infof("Replika %s'in durumu devralındı: %d kayıt, %d örnek, %d geçmiş dilimi (oluşturulma: %s)",
+				result.Peer, result.Entries, result.Samples, result.HistorySamples, result.CreatedAt.Format(time.RFC3339))
+		}
+	}
+
+	// Karar geçmişi snapshot'tan yüklenir (günlük replay'i veya replika devri kararları zaten yeniden kurduysa yüklenmez)
+	if restored == 0 && !handedOff {
+		decisions, err := aiScheduler.RestoreDecisions(runCtx)
+		if err != nil {
+			logrus.Warnf("Karar geçmişi geçmiş deposundan yüklenemedi, boş geçmişle başlanıyor: %v", err)
+		} else if decisions > 0 {
+			logrus.Infof("Karar geçmişi geçmiş deposundan yüklendi: %d karar", decisions)
+		}
+	}
+
+	// Pod metrik cache'i geçmiş deposundaki snapshot'tan yüklenir (replikadan devralınan durum daha güncel, paylaşılan
+	// cache ise geçmişi Redis'ten aldığı için yüklenmez)
+	if !handedOff && sharedCache == nil {
+		samples, err := collector.RestorePodCache(runCtx)
+		if err != nil {
+			logrus.Warnf("Pod metrik cache'i geçmiş deposundan yüklenemedi, boş cache'le başlanıyor: %v", err)
+		} else if samples > 0 {
+			logrus.Infof("Pod metrik cache'i geçmiş deposundan yüklendi: %d örnek", samples)
+		}
+	}
+
+	go collector.Start(runCtx)
+	go aiScheduler.Start(runCtx)
+
+	// Edge node'ların MQTT telemetrisi dış sinyal olarak alınır (opsiyonel)
+	edgeTelemetry := mqtt.NewBridge(collector, &config.Metrics.MQTT)
+	go edgeTelemetry.Start(runCtx)
+
+	// ConfigMap değişikliklerini canlı uygula
+	if configSource != nil {
+		go configSource.Watch(runCtx, func(newConfig *types.Config) {
+			setupLogging(&newConfig.Logging)
+			collector.UpdateConfig(&newConfig.Metrics)
+			aiScheduler.UpdateConfig(&newConfig.Scheduler)
+			platformResolver.UpdateConfig(&newConfig.Scheduler.Platform)
+			capacityWebhook.UpdateConfig(&newConfig.Scheduler.CapacityHints)
+			capacityPlanner.UpdateConfig(&newConfig.Reports.Capacity)
+			rightsizing.UpdateConfig(&newConfig.Reports.Rightsizing)
+			externalMetrics.UpdateConfig(&newConfig.Monitoring.ExternalMetrics)
+			federator.UpdateConfig(&newConfig.Federation)
+			limiter.UpdateConfig(&newConfig.Scheduler.Admission)
+			eventBus.UpdateConfig(&newConfig.EventBus)
+			decisionLogs.UpdateConfig(&newConfig.OTLPLogs)
+			edgeTelemetry.UpdateConfig(&newConfig.Metrics.MQTT)
+			featureGate.Load(newConfig.Features)
+		})
+	}
+
+	// HTTP API başlatma. Extender bind sadece kube-scheduler'ın istemci sertifikası doğrulanabiliyorsa sunulur
+	clientCerts := false
+	var bindVerifier *api.ClientCertVerifier
+	if extender := config.Server.Extender; extender.Enabled && extender.Bind {
+		verifier, err := extenderVerifier(&config)
+		if err != nil {
+			logrus.Errorf("Extender bind sunulmuyor: %v", err)
+		} else {
+			bindVerifier = verifier
+			clientCerts = true
+		}
+	}
 	router := gin.Default()
-	api.SetupRoutes(router, aiScheduler, collector)
+	api.SetupRoutes(router, aiScheduler, collector, featureGate, capacityPlanner, rightsizing, federator, limiter, doctor.New(&config, k8sClient, secretStore), config.Server, bindVerifier)
+
+	// Prometheus metrikleri
+	if config.Monitoring.Prometheus {
+		telemetry.RegisterPodCache(collector.GetPodCache())
+		aiScheduler.AddCapacityHintSink(telemetry.RegisterCapacityHints())
+		telemetry.RegisterDegradation(aiScheduler)
+		telemetry.RegisterTemplateFilterCache(aiScheduler)
+		telemetry.RegisterBinding(aiScheduler)
+		telemetry.RegisterAudit(aiScheduler)
+		telemetry.RegisterRemediation(aiScheduler)
+		errorCounter := telemetry.RegisterErrors()
+		aiScheduler.AddErrorSink(errorCounter)
+		collector.AddErrorSink(errorCounter)
+		router.GET("/metrics", gin.WrapH(telemetry.Handler()))
+	}
+
+	// HPA'lar için external metrics API, sadece aggregator'ın istemci sertifikası doğrulanabiliyorsa sunulur
+	if config.Monitoring.ExternalMetrics.Enabled {
+		aggregator, err := aggregatorVerifier(runCtx, k8sClient, &config)
+		if err != nil {
+			logrus.Errorf("External metrics API sunulmuyor: %v", err)
+		} else {
+			api.SetupExternalMetricsRoutes(router, externalMetrics, aggregator)
+			clientCerts = true
+		}
+	}
 
 	// Server ayarları
 	addr := fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port)
@@ -69,12 +335,19 @@ func main() {
 		Handler:      router,
 		ReadTimeout:  config.Server.ReadTimeout,
 		WriteTimeout: config.Server.WriteTimeout,
+		BaseContext:  func(net.Listener) context.Context { return runCtx },
 	}
 
 	// Graceful shutdown
 	go func() {
 		logrus.Infof("Server %s portunda başlatılıyor", addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if config.Server.TLS.Enabled {
+			err = listenAndServeTLS(srv, &config.Server.TLS, secretStore, clientCerts)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logrus.Fatalf("Server başlatılamadı: %v", err)
 		}
 	}()
@@ -85,16 +358,145 @@ func main() {
 	<-quit
 	logrus.Info("Server kapatılıyor...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Süre isteklerin boşaltılması ve son snapshot'ların yazılması için ortaktır, pod'un sonlandırma süresini aşmaz
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
+		stop()
 		logrus.Fatal("Server zorla kapatıldı:", err)
 	}
+	// Yeni istek kalmadı, arka plan döngüleri ve süren AI çağrıları durdurulur
+	stop()
+
+	if recorder != nil {
+		if err := recorder.Close(); err != nil {
+			logrus.Warnf("Trace dosyası kapatılamadı: %v", err)
+		}
+	}
+	if sharedCache != nil {
+		if err := sharedCache.Close(); err != nil {
+			logrus.Warnf("Paylaşılan pod metrik cache'i kapatılamadı: %v", err)
+		}
+	}
+	if err := collector.SavePodCache(ctx); err != nil {
+		logrus.Warnf("Pod metrik cache'i kaydedilemedi: %v", err)
+	}
+	if err := aiScheduler.SaveDecisions(ctx); err != nil {
+		logrus.Warnf("Karar geçmişi kaydedilemedi: %v", err)
+	}
+	if decisionJournal != nil {
+		if err := decisionJournal.Close(); err != nil {
+			logrus.Warnf("Karar günlüğü kapatılamadı: %v", err)
+		}
+	}
+	if historyStore != nil {
+		if err := historyStore.Close(); err != nil {
+			logrus.Warnf("Geçmiş deposu kapatılamadı: %v", err)
+		}
+	}
 
 	logrus.Info("Server başarıyla kapatıldı")
 }
 
+// startInformerCache node/pod informer cache'ini başlatır ve senkronize olursa metrik toplayıcıya ve scheduler'a
+// bağlar. Toplayıcı her turda LIST yapmak yerine cache'i okur ve watch olaylarını izler
+func startInformerCache(runCtx context.Context, k8sClient *types.K8sClient, dataCollector *collector.DataCollector, aiScheduler *scheduler.AIScheduler, syncTimeout time.Duration) {
+	metricsClient, err := types.NewMetricsClient(k8sClient)
+	if err != nil {
+		logrus.Warnf("Metrics client oluşturulamadı, placeholder değerler kullanılacak: %v", err)
+	}
+
+	clusterCache, err := informer.NewClusterCache(runCtx, k8sClient, metricsClient)
+	if err != nil {
+		logrus.Warnf("Informer cache oluşturulamadı, API'den okunacak: %v", err)
+		return
+	}
+	k8sClient.InformerFactory().Start(runCtx.Done())
+
+	if syncTimeout == 0 {
+		syncTimeout = 30 * time.Second // Default değer
+	}
+	ctx, cancel := context.WithTimeout(runCtx, syncTimeout)
+	defer cancel()
+	if !clusterCache.WaitForSync(ctx) {
+		logrus.Warn("Informer cache senkronize olamadı, API'den okunacak")
+		return
+	}
+
+	dataCollector.SetClusterSource(clusterCache)
+	aiScheduler.SetClusterSource(clusterCache)
+	logrus.Info("Node ve pod informer cache'i hazır")
+}
+
+// runReplay trace dosyasını tekrar oynatır ve kaydedilen kararlarla karşılaştırır, çıkış kodunu döndürür
+func runReplay(args []string, config *types.Config) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Kullanım: ai-scheduler replay <trace-dosyası>")
+		return 2
+	}
+
+	report, err := trace.Replay(args[0], &config.Scheduler, &config.Metrics)
+	if err != nil {
+		logrus.Errorf("Replay başarısız: %v", err)
+		return 1
+	}
+
+	for _, mismatch := range report.Mismatches {
+		logrus.Warnf("[%s] %s/%s: kaydedilen %s (%.4f), replay %s (%.4f) %s",
+			mismatch.Time.Format(time.RFC3339), mismatch.Namespace, mismatch.PodName,
+			mismatch.RecordedNode, mismatch.RecordedScore, mismatch.ReplayedNode, mismatch.ReplayedScore, mismatch.Error)
+	}
+	logrus.Infof("Replay tamamlandı: %d olay, %d tahmin, %d eşleşme, %d fark",
+		report.Events, report.Predictions, report.Matches, len(report.Mismatches))
+
+	if len(report.Mismatches) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// listenAndServeTLS HTTPS server'ı başlatır, Secret'taki sertifika dosyadan önceliklidir. clientCerts açıksa
+// istemci sertifikası istenir, doğrulaması route'larda yapılır
+func listenAndServeTLS(srv *http.Server, tlsConfig *types.TLSConfig, secretStore *appconfig.SecretStore, clientCerts bool) error {
+	srv.TLSConfig = &tls.Config{}
+	if clientCerts {
+		srv.TLSConfig.ClientAuth = tls.RequestClientCert
+	}
+	if secretStore.HasCertificate() {
+		// GetCertificate her el sıkışmada çağrılır, böylece rotasyon yeniden başlatma gerektirmez
+		srv.TLSConfig.GetCertificate = secretStore.GetCertificate
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+}
+
+// aggregatorVerifier external metrics API'sine gelen isteklerdeki aggregator istemci sertifikasının doğrulayıcısını
+// kurar. Sertifika sadece TLS üzerinden gelebildiği için server.tls kapalıysa hata döner
+func aggregatorVerifier(ctx context.Context, k8sClient *types.K8sClient, config *types.Config) (*api.ClientCertVerifier, error) {
+	if !config.Server.TLS.Enabled {
+		return nil, fmt.Errorf("aggregator istemci sertifikasını doğrulamak için server.tls açık olmalı")
+	}
+	caPEM, names, err := extmetrics.AggregatorClientCA(ctx, k8sClient, &config.Monitoring.ExternalMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewClientCertVerifier(caPEM, names)
+}
+
+// extenderVerifier /extender/bind isteklerindeki kube-scheduler istemci sertifikasının doğrulayıcısını kurar
+func extenderVerifier(config *types.Config) (*api.ClientCertVerifier, error) {
+	extender := config.Server.Extender
+	if !config.Server.TLS.Enabled || extender.ClientCAFile == "" {
+		return nil, fmt.Errorf("server.tls açık ve server.extender.client_ca_file tanımlı olmalı")
+	}
+	caPEM, err := os.ReadFile(extender.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("extender CA dosyası okunamadı: %v", err)
+	}
+	return api.NewClientCertVerifier(caPEM, extender.AllowedNames)
+}
+
 // setupLogging logging ayarlarını yapılandırır
 func setupLogging(logConfig *types.LoggingConfig) {
 	// Log level
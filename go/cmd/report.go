@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"ai-scheduler/internal/report"
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runReport çalışan scheduler'dan rapor alır ve dosyaya/stdout'a yazar, çıkış kodunu döndürür
+func runReport(args []string, config *types.Config) int {
+	if len(args) >= 1 {
+		switch args[0] {
+		case "capacity":
+			return runCapacityReport(args[1:], config)
+		case "comparison":
+			return runComparisonReport(args[1:], config)
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Kullanım: ai-scheduler report capacity [-server URL] [-format json|csv] [-out dosya]")
+	fmt.Fprintln(os.Stderr, "          ai-scheduler report comparison [-server URL] [-out dosya]")
+	return 2
+}
+
+// runCapacityReport kapasite raporunu yazar
+func runCapacityReport(args []string, config *types.Config) int {
+	flags := flag.NewFlagSet("report capacity", flag.ContinueOnError)
+	server := flags.String("server", defaultServerURL(&config.Server), "çalışan scheduler'ın adresi")
+	format := flags.String("format", "json", "çıktı formatı (json veya csv)")
+	out := flags.String("out", "", "raporu bu dosyaya yaz (boşsa stdout)")
+	timeout := flags.Duration("timeout", 30*time.Second, "istek zaman aşımı")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	capacityReport, err := fetchCapacityReport(*server, *timeout)
+	if err != nil {
+		logrus.Errorf("Kapasite raporu alınamadı: %v", err)
+		return 1
+	}
+
+	w, closeOutput, err := reportOutput(*out)
+	if err != nil {
+		logrus.Errorf("Rapor dosyası oluşturulamadı: %v", err)
+		return 1
+	}
+	defer closeOutput()
+
+	if err := report.WriteCapacityReport(w, capacityReport, *format); err != nil {
+		logrus.Errorf("Rapor yazılamadı: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// runComparisonReport varsayılan scheduler karşılaştırma raporunu JSON olarak yazar
+func runComparisonReport(args []string, config *types.Config) int {
+	flags := flag.NewFlagSet("report comparison", flag.ContinueOnError)
+	server := flags.String("server", defaultServerURL(&config.Server), "çalışan scheduler'ın adresi")
+	out := flags.String("out", "", "raporu bu dosyaya yaz (boşsa stdout)")
+	timeout := flags.Duration("timeout", 30*time.Second, "istek zaman aşımı")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Get(*server + "/api/v1/comparison")
+	if err != nil {
+		logrus.Errorf("Karşılaştırma raporu alınamadı: %v", err)
+		return 1
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logrus.Errorf("Karşılaştırma raporu alınamadı: scheduler hata döndürdü: %d", resp.StatusCode)
+		return 1
+	}
+	var comparisonReport scheduler.ComparisonReport
+	if err := json.NewDecoder(resp.Body).Decode(&comparisonReport); err != nil {
+		logrus.Errorf("Karşılaştırma raporu parse edilemedi: %v", err)
+		return 1
+	}
+
+	w, closeOutput, err := reportOutput(*out)
+	if err != nil {
+		logrus.Errorf("Rapor dosyası oluşturulamadı: %v", err)
+		return 1
+	}
+	defer closeOutput()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(comparisonReport); err != nil {
+		logrus.Errorf("Rapor yazılamadı: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// reportOutput raporun yazılacağı dosyayı açar, yol boşsa stdout döner
+func reportOutput(path string) (io.Writer, func(), error) {
+	if path == "" {
+		return os.Stdout, func() {}, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, func() { file.Close() }, nil
+}
+
+// fetchCapacityReport kapasite raporunu scheduler API'sinden alır
+func fetchCapacityReport(server string, timeout time.Duration) (*report.CapacityReport, error) {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(server + "/api/v1/reports/capacity")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scheduler hata döndürdü: %d", resp.StatusCode)
+	}
+
+	var capacityReport report.CapacityReport
+	if err := json.NewDecoder(resp.Body).Decode(&capacityReport); err != nil {
+		return nil, fmt.Errorf("rapor parse edilemedi: %v", err)
+	}
+	return &capacityReport, nil
+}
+
+// defaultServerURL konfigürasyondaki server ayarlarından yerel adresi üretir
+func defaultServerURL(serverConfig *types.ServerConfig) string {
+	scheme := "http"
+	if serverConfig.TLS.Enabled {
+		scheme = "https"
+	}
+	host := serverConfig.Host
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, host, serverConfig.Port)
+}
@@ -0,0 +1,337 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// Limiter varsayılanları
+const (
+	defaultMaxInFlight  = 16
+	defaultMaxQueue     = 256
+	defaultQueueTimeout = 10 * time.Second
+	holdSmoothing       = 0.2 // Ortalama işlem süresinin EWMA katsayısı
+	minRetryAfter       = time.Second
+)
+
+// ErrSaturated kuyruk dolu veya bekleme süresi doldu, istek daha sonra tekrar denenmelidir
+var ErrSaturated = errors.New("tahmin kapasitesi dolu")
+
+// Stats limiter'ın anlık durumu
+type Stats struct {
+	Enabled     bool           `json:"enabled"`
+	InFlight    int            `json:"in_flight"`
+	MaxInFlight int            `json:"max_in_flight"`
+	Queued      int            `json:"queued"`
+	MaxQueue    int            `json:"max_queue"`
+	Namespaces  map[string]int `json:"namespaces,omitempty"` // Namespace başına bekleyen istek
+	Priorities  map[int32]int  `json:"priorities,omitempty"` // Pod önceliği başına bekleyen istek
+	Admitted    uint64         `json:"admitted"`
+	Rejected    uint64         `json:"rejected"`
+	TimedOut    uint64         `json:"timed_out"`
+	AverageHold time.Duration  `json:"average_hold_ns"`
+	RetryAfter  time.Duration  `json:"retry_after_ns"`
+}
+
+// waiter kuyrukta bekleyen istek, slot verildiğinde ready kapatılır
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// priorityClass aynı pod önceliğindeki bekleyenler, namespace başına kuyruklanır
+type priorityClass struct {
+	priority int32
+	queues   map[string][]*waiter
+	order    []string // Bekleyeni olan namespace'ler, sıradaki başta
+}
+
+// Limiter tahmin ve binding işlerini eşzamanlılık sınırıyla kabul eder. Sınır doluysa istekler sınırlı bir kuyrukta
+// bekler; boşalan slot önce en yüksek pod önceliğindeki (PriorityClass) bekleyenlere, aynı öncelikte namespace'ler
+// arasında sırayla verilir, böylece pod fırtınası yapan namespace diğerlerini aç bırakmaz. Kuyruk da doluysa veya
+// bekleme süresi dolarsa ErrSaturated döner
+type Limiter struct {
+	config   *types.AdmissionConfig
+	configMu sync.RWMutex
+
+	mutex      sync.Mutex
+	inFlight   int
+	classes    []*priorityClass // Bekleyeni olan öncelikler, yüksekten düşüğe
+	namespaces map[string]int   // Namespace başına bekleyen istek
+	queued     int
+	hold       float64 // Ortalama slot tutma süresi (saniye)
+
+	admitted uint64
+	rejected uint64
+	timedOut uint64
+}
+
+// NewLimiter yeni tahmin limiter'ı oluşturur
+func NewLimiter(admissionConfig *types.AdmissionConfig) *Limiter {
+	cfg := *admissionConfig
+	return &Limiter{config: &cfg, namespaces: make(map[string]int)}
+}
+
+// UpdateConfig limiter konfigürasyonunu çalışma anında değiştirir, sınır artarsa bekleyenlere slot verilir
+func (l *Limiter) UpdateConfig(admissionConfig *types.AdmissionConfig) {
+	cfg := *admissionConfig
+
+	l.configMu.Lock()
+	l.config = &cfg
+	l.configMu.Unlock()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	settings := l.settings()
+	if !settings.Enabled {
+		// Kapatılınca bekleyenlerin hepsi kabul edilir
+		settings.MaxInFlight = math.MaxInt
+	}
+	l.dispatchLocked(settings)
+}
+
+// settings varsayılanları uygulanmış konfigürasyonu döndürür
+func (l *Limiter) settings() types.AdmissionConfig {
+	l.configMu.RLock()
+	cfg := *l.config
+	l.configMu.RUnlock()
+
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = defaultMaxInFlight
+	}
+	if cfg.MaxQueue < 0 {
+		cfg.MaxQueue = 0
+	} else if cfg.MaxQueue == 0 {
+		cfg.MaxQueue = defaultMaxQueue
+	}
+	if cfg.QueueTimeout <= 0 {
+		cfg.QueueTimeout = defaultQueueTimeout
+	}
+	return cfg
+}
+
+// Acquire namespace'teki priority öncelikli pod için slot alır ve işlem bitince çağrılacak release fonksiyonunu
+// döndürür. Slot yoksa kuyrukta bekler; kuyruk doluysa, namespace payını aştıysa veya süre dolarsa ErrSaturated döner
+func (l *Limiter) Acquire(ctx context.Context, namespace string, priority int32) (func(), error) {
+	cfg := l.settings()
+	if !cfg.Enabled {
+		return func() {}, nil
+	}
+
+	l.mutex.Lock()
+	if l.inFlight < cfg.MaxInFlight && l.queued == 0 {
+		l.inFlight++
+		l.admitted++
+		l.mutex.Unlock()
+		return l.releaser(time.Now()), nil
+	}
+	if l.queued >= cfg.MaxQueue || (cfg.MaxQueuePerNamespace > 0 && l.namespaces[namespace] >= cfg.MaxQueuePerNamespace) {
+		l.rejected++
+		l.mutex.Unlock()
+		return nil, ErrSaturated
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	class := l.classLocked(priority)
+	if len(class.queues[namespace]) == 0 {
+		class.order = append(class.order, namespace)
+	}
+	class.queues[namespace] = append(class.queues[namespace], w)
+	l.namespaces[namespace]++
+	l.queued++
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(cfg.QueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-w.ready:
+		return l.releaser(time.Now()), nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Süre dolarken slot verilmiş olabilir, o durumda istek kabul edilir
+	if w.granted {
+		return l.releaser(time.Now()), nil
+	}
+	l.removeLocked(namespace, priority, w)
+	l.timedOut++
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, ErrSaturated
+}
+
+// releaser slotu bir kez bırakan fonksiyonu döndürür, tutma süresi ortalamaya eklenir
+func (l *Limiter) releaser(start time.Time) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			held := time.Since(start).Seconds()
+
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+
+			if l.hold == 0 {
+				l.hold = held
+			} else {
+				l.hold += holdSmoothing * (held - l.hold)
+			}
+			l.inFlight--
+			l.dispatchLocked(l.settings())
+		})
+	}
+}
+
+// classLocked önceliğin bekleyen sınıfını döndürür, yoksa sıralamayı koruyarak ekler. mutex tutulurken çağrılmalıdır
+func (l *Limiter) classLocked(priority int32) *priorityClass {
+	i := sort.Search(len(l.classes), func(i int) bool { return l.classes[i].priority <= priority })
+	if i < len(l.classes) && l.classes[i].priority == priority {
+		return l.classes[i]
+	}
+	class := &priorityClass{priority: priority, queues: make(map[string][]*waiter)}
+	l.classes = append(l.classes, nil)
+	copy(l.classes[i+1:], l.classes[i:])
+	l.classes[i] = class
+	return class
+}
+
+// dispatchLocked boş slotları en yüksek öncelikten başlayarak, aynı öncelikte namespace'ler arasında sırayla
+// bekleyenlere verir. mutex tutulurken çağrılmalıdır
+func (l *Limiter) dispatchLocked(cfg types.AdmissionConfig) {
+	for l.inFlight < cfg.MaxInFlight && len(l.classes) > 0 {
+		class := l.classes[0]
+		namespace := class.order[0]
+		queue := class.queues[namespace]
+		w := queue[0]
+
+		class.queues[namespace] = queue[1:]
+		class.order = class.order[1:]
+		if len(class.queues[namespace]) == 0 {
+			delete(class.queues, namespace)
+		} else {
+			// Namespace'in sırası sınıf içinde sona geçer
+			class.order = append(class.order, namespace)
+		}
+		if len(class.order) == 0 {
+			l.classes = l.classes[1:]
+		}
+
+		l.dequeuedLocked(namespace)
+		l.inFlight++
+		l.admitted++
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+// dequeuedLocked kuyruktan çıkan isteği sayaçlardan düşer. mutex tutulurken çağrılmalıdır
+func (l *Limiter) dequeuedLocked(namespace string) {
+	l.queued--
+	if l.namespaces[namespace]--; l.namespaces[namespace] <= 0 {
+		delete(l.namespaces, namespace)
+	}
+}
+
+// removeLocked vazgeçen bekleyeni kuyruktan çıkarır. mutex tutulurken çağrılmalıdır
+func (l *Limiter) removeLocked(namespace string, priority int32, w *waiter) {
+	index := -1
+	for i := range l.classes {
+		if l.classes[i].priority == priority {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return
+	}
+	class := l.classes[index]
+
+	queue := class.queues[namespace]
+	for i := range queue {
+		if queue[i] != w {
+			continue
+		}
+		class.queues[namespace] = append(queue[:i:i], queue[i+1:]...)
+		l.dequeuedLocked(namespace)
+		break
+	}
+	if len(class.queues[namespace]) > 0 {
+		return
+	}
+	delete(class.queues, namespace)
+	for i := range class.order {
+		if class.order[i] == namespace {
+			class.order = append(class.order[:i:i], class.order[i+1:]...)
+			break
+		}
+	}
+	if len(class.order) == 0 {
+		l.classes = append(l.classes[:index:index], l.classes[index+1:]...)
+	}
+}
+
+// RetryAfter kuyruktakilerin işlenmesi için tahmini bekleme süresini döndürür (en az 1 saniye)
+func (l *Limiter) RetryAfter() time.Duration {
+	cfg := l.settings()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.retryAfterLocked(&cfg)
+}
+
+// retryAfterLocked kuyruk uzunluğu ve ortalama tutma süresinden bekleme süresini tahmin eder
+func (l *Limiter) retryAfterLocked(cfg *types.AdmissionConfig) time.Duration {
+	waves := math.Ceil(float64(l.queued+1) / float64(cfg.MaxInFlight))
+	retryAfter := time.Duration(waves * l.hold * float64(time.Second))
+	if retryAfter < minRetryAfter {
+		return minRetryAfter
+	}
+	return retryAfter
+}
+
+// Stats limiter'ın anlık durumunu döndürür
+func (l *Limiter) Stats() Stats {
+	cfg := l.settings()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	stats := Stats{
+		Enabled:     cfg.Enabled,
+		InFlight:    l.inFlight,
+		MaxInFlight: cfg.MaxInFlight,
+		Queued:      l.queued,
+		MaxQueue:    cfg.MaxQueue,
+		Admitted:    l.admitted,
+		Rejected:    l.rejected,
+		TimedOut:    l.timedOut,
+		AverageHold: time.Duration(l.hold * float64(time.Second)),
+		RetryAfter:  l.retryAfterLocked(&cfg),
+	}
+	if len(l.namespaces) > 0 {
+		stats.Namespaces = make(map[string]int, len(l.namespaces))
+		for namespace, count := range l.namespaces {
+			stats.Namespaces[namespace] = count
+		}
+		stats.Priorities = make(map[int32]int, len(l.classes))
+		for _, class := range l.classes {
+			for _, queue := range class.queues {
+				stats.Priorities[class.priority] += len(queue)
+			}
+		}
+	}
+	return stats
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ai-scheduler/internal/backup"
+	"ai-scheduler/internal/collector"
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// listFeatures feature flag durumlarını döndürür
+func listFeatures(featureGate *features.Gate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"features": featureGate.Snapshot(),
+		})
+	}
+}
+
+// setFeature feature flag'i çalışma anında açar veya kapatır
+func setFeature(featureGate *features.Gate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Enabled *bool `json:"enabled" binding:"required"`
+		}
+
+		if !bindJSON(c, &request) {
+			return
+		}
+
+		name := c.Param("name")
+		if err := featureGate.Set(name, *request.Enabled); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"name":    name,
+			"enabled": *request.Enabled,
+		})
+	}
+}
+
+// resetFeature çalışma anındaki override'ı kaldırır
+func resetFeature(featureGate *features.Gate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if err := featureGate.Reset(name); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"name":    name,
+			"enabled": featureGate.Enabled(name),
+		})
+	}
+}
+
+// getMode scheduler çalışma modunu döndürür
+func getMode(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.Mode())
+	}
+}
+
+// getPolicy etkin zamanlanmış politikayı döndürür
+func getPolicy(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.Policy())
+	}
+}
+
+// setMode sadece gözlem modunu açar veya kapatır
+func setMode(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			ObserveOnly *bool  `json:"observe_only" binding:"required"`
+			Reason      string `json:"reason" binding:"max=256"`
+		}
+
+		if !bindJSON(c, &request) {
+			return
+		}
+
+		reason := request.Reason
+		if reason == "" {
+			reason = "admin API"
+		}
+		aiScheduler.SetObserveOnly(*request.ObserveOnly, reason)
+
+		c.JSON(http.StatusOK, aiScheduler.Mode())
+	}
+}
+
+// getBackup cache, karar geçmişi ve scheduler konfigürasyonunu tek tar.gz arşivi olarak döndürür
+func getBackup(aiScheduler *scheduler.AIScheduler, collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+		archive := backup.Create(aiScheduler, collector.GetPodCache(), collector.GetNodeHistory(), now)
+
+		c.Header("Content-Type", "application/gzip")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=ai-scheduler-backup-%s.tar.gz", now.UTC().Format("20060102-150405")))
+		c.Status(http.StatusOK)
+		if err := backup.Write(c.Writer, archive); err != nil {
+			// Yanıt başladığı için hata durum koduyla bildirilemez
+			logrus.Errorf("Yedek yazılamadı: %v", err)
+		}
+	}
+}
+
+// restoreBackup gövdedeki yedek arşivini scheduler'a geri yükler. config=false verilirse konfigürasyon uygulanmaz
+func restoreBackup(aiScheduler *scheduler.AIScheduler, collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		applyConfig := true
+		if value := c.Query("config"); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz config: " + value})
+				return
+			}
+			applyConfig = parsed
+		}
+
+		archive, err := backup.Read(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result := backup.Restore(aiScheduler, collector.GetPodCache(), collector.GetNodeHistory(), archive, applyConfig)
+		logrus.Infof("Yedek geri yüklendi: %d kayıt, %d örnek, %d geçmiş dilimi (oluşturulma: %s)",
+			result.Entries, result.Samples, result.HistorySamples, archive.Manifest.CreatedAt.Format(time.RFC3339))
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// getScoringTuning skorlama ağırlığı ayarının durumunu ve değişiklik geçmişini döndürür
+func getScoringTuning(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.ScoringTuning())
+	}
+}
+
+// revertScoringWeights ağırlık değişikliğini geri alır, id verilmezse son değişiklik geri alınır
+func revertScoringWeights(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			ID     uint64 `json:"id"`
+			Reason string `json:"reason" binding:"max=256"`
+		}
+
+		// Gövde boş olabilir
+		if c.Request.ContentLength != 0 {
+			if !bindJSON(c, &request) {
+				return
+			}
+		}
+
+		change, err := aiScheduler.RevertWeights(request.ID, request.Reason)
+		if err != nil {
+			// Geri alınacak değişiklik yok veya geçmişte bulunamadı
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, change)
+	}
+}
+
+// listCooldowns cooldown'daki node'ları döndürür
+func listCooldowns(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cooldowns := aiScheduler.Cooldowns()
+		c.JSON(http.StatusOK, gin.H{
+			"count":     len(cooldowns),
+			"cooldowns": cooldowns,
+		})
+	}
+}
+
+// clearCooldown node'un cooldown'unu süresi dolmadan kaldırır
+func clearCooldown(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		node := c.Param("node")
+		if err := aiScheduler.ClearCooldown(node); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"node":    node,
+			"cleared": true,
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyContextKey doğrulanan anahtarın gin context'indeki adı
+const apiKeyContextKey = "api_key"
+
+// apiKey doğrulanan API anahtarının yetkileri
+type apiKey struct {
+	name  string
+	token []byte
+	// scope nil değilse anahtar yalnız bu namespace'leri görür
+	scope *types.NamespaceFilter
+	admin bool
+}
+
+// compileKeys token'ı boş olmayan anahtarları derler
+func compileKeys(cfg types.AuthConfig) []apiKey {
+	keys := make([]apiKey, 0, len(cfg.Keys))
+	for _, key := range cfg.Keys {
+		if key.Token == "" {
+			continue
+		}
+		compiled := apiKey{name: key.Name, token: []byte(key.Token), admin: key.Admin}
+		if len(key.Namespaces) > 0 {
+			compiled.scope = &types.NamespaceFilter{Include: key.Namespaces}
+			compiled.admin = false
+		}
+		keys = append(keys, compiled)
+	}
+	return keys
+}
+
+// authenticate auth açıksa Bearer token'ı tanımlı anahtarlarla doğrular ve anahtarı context'e yazar
+func authenticate(cfg types.AuthConfig) gin.HandlerFunc {
+	keys := compileKeys(cfg)
+	return func(c *gin.Context) {
+		if !cfg.Enabled {
+			c.Next()
+			return
+		}
+
+		presented, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if ok {
+			for i := range keys {
+				if subtle.ConstantTimeCompare([]byte(presented), keys[i].token) == 1 {
+					c.Set(apiKeyContextKey, &keys[i])
+					c.Next()
+					return
+				}
+			}
+		}
+		c.Header("WWW-Authenticate", `Bearer realm="ai-scheduler"`)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "geçersiz veya eksik API anahtarı"})
+	}
+}
+
+// requestKey isteğin doğrulanan anahtarını döndürür, auth kapalıysa nil
+func requestKey(c *gin.Context) *apiKey {
+	value, ok := c.Get(apiKeyContextKey)
+	if !ok {
+		return nil
+	}
+	return value.(*apiKey)
+}
+
+// requestScope isteğin namespace kapsamını döndürür, anahtar tüm kümeyi görüyorsa nil
+func requestScope(c *gin.Context) *types.NamespaceFilter {
+	if key := requestKey(c); key != nil {
+		return key.scope
+	}
+	return nil
+}
+
+// requireCluster küme geneli endpoint'leri namespace kapsamlı anahtarlara kapatır
+func requireCluster() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := requestKey(c); key != nil && key.scope != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API anahtarı " + key.name + " küme geneli endpoint'lere erişemez"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireAdmin yönetim endpoint'lerini admin anahtarlara açar
+func requireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := requestKey(c); key != nil && !key.admin {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API anahtarı " + key.name + " yönetim yetkisine sahip değil"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// authorizeNamespace anahtar namespace'i göremiyorsa 403 yazar ve false döner
+func authorizeNamespace(c *gin.Context, namespace string) bool {
+	key := requestKey(c)
+	if key == nil || key.scope == nil || key.scope.Matches(namespace) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "API anahtarı " + key.name + " namespace " + namespace + " için yetkili değil"})
+	return false
+}
+
+// visibleNamespace namespace isteğin kapsamındaysa true döner
+func visibleNamespace(scope *types.NamespaceFilter, namespace string) bool {
+	return scope == nil || scope.Matches(namespace)
+}
+
+// visibleDecisions kapsamdaki son limit kararı döndürür, limit 0 ise tümünü
+func visibleDecisions(decisions []scheduler.Decision, scope *types.NamespaceFilter, limit int) []scheduler.Decision {
+	visible := make([]scheduler.Decision, 0)
+	for _, decision := range decisions {
+		if limit > 0 && len(visible) == limit {
+			break
+		}
+		if visibleNamespace(scope, decision.Namespace) {
+			visible = append(visible, decision)
+		}
+	}
+	return visible
+}
+
+// visibleOutcomes kapsamdaki son limit sonuç kaydını döndürür, limit 0 ise tümünü
+func visibleOutcomes(records []scheduler.OutcomeRecord, scope *types.NamespaceFilter, limit int) []scheduler.OutcomeRecord {
+	if scope == nil && limit == 0 {
+		return records
+	}
+	visible := make([]scheduler.OutcomeRecord, 0)
+	for _, record := range records {
+		if limit > 0 && len(visible) == limit {
+			break
+		}
+		if visibleNamespace(scope, record.Namespace) {
+			visible = append(visible, record)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientCertVerifier istemci sertifikasını route'a özgü CA ve izinli ortak adlarla (CN) doğrular. Server el
+// sıkışmada sertifikayı sadece ister (tls.RequestClientCert), doğrulama route'ta yapılır; böylece farklı CA'lara
+// güvenen route'lar (ör: aggregator'ın front-proxy CA'sı, kube-scheduler'ın CA'sı) aynı portu paylaşır
+type ClientCertVerifier struct {
+	roots *x509.CertPool
+	names map[string]bool
+}
+
+// NewClientCertVerifier PEM CA paketinden doğrulayıcı oluşturur. allowedNames boşsa CA'nın imzaladığı her
+// istemci sertifikası kabul edilir
+func NewClientCertVerifier(caPEM []byte, allowedNames []string) (*ClientCertVerifier, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("CA paketinde geçerli sertifika yok")
+	}
+	names := make(map[string]bool, len(allowedNames))
+	for _, name := range allowedNames {
+		names[name] = true
+	}
+	return &ClientCertVerifier{roots: roots, names: names}, nil
+}
+
+// Verify bağlantının istemci sertifikasını CA'ya, istemci kimlik doğrulaması kullanımına ve izinli adlara göre
+// doğrular
+func (v *ClientCertVerifier) Verify(state *tls.ConnectionState) error {
+	if state == nil {
+		return errors.New("istemci sertifikası için TLS gerekli")
+	}
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("istemci sertifikası gönderilmedi")
+	}
+
+	leaf := state.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("istemci sertifikası doğrulanamadı: %v", err)
+	}
+	if len(v.names) > 0 && !v.names[leaf.Subject.CommonName] {
+		return fmt.Errorf("istemci sertifikası adı izinli değil: %q", leaf.Subject.CommonName)
+	}
+	return nil
+}
+
+// requireClientCert doğrulanmış istemci sertifikası olmayan istekleri 401 ile reddeder, cevap gövdesini body üretir
+func requireClientCert(verifier *ClientCertVerifier, body func(status int, err error) interface{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := verifier.Verify(c.Request.TLS); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, body(http.StatusUnauthorized, err))
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatus hatanın sınıfına göre HTTP durum kodunu döndürür
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, scheduler.ErrExtenderNoPod):
+		return http.StatusBadRequest
+	case errors.Is(err, scheduler.ErrNamespaceOutOfScope):
+		return http.StatusForbidden
+	case errors.Is(err, types.ErrNodeNotFound), errors.Is(err, types.ErrPodNotFound),
+		errors.Is(err, scheduler.ErrWorkloadNotFound), errors.Is(err, scheduler.ErrTemplateNotFound),
+		errors.Is(err, scheduler.ErrTemplateHistoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, types.ErrNoFeasibleNode), errors.Is(err, scheduler.ErrInsufficientHistory):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, types.ErrAIUnavailable), errors.Is(err, types.ErrMetricsStale),
+		errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError hatayı sınıfının durum koduyla ve sınıf etiketiyle yazar
+func writeError(c *gin.Context, err error) {
+	c.JSON(errorStatus(err), gin.H{"error": err.Error(), "class": types.ErrorClass(err)})
+}
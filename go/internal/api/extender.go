@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"ai-scheduler/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// extenderFilter kube-scheduler extender filter isteğini cevaplar. Hatalar protokol gereği cevabın Error alanında
+// döner
+func extenderFilter(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var args scheduler.ExtenderArgs
+		if err := c.ShouldBindJSON(&args); err != nil {
+			c.JSON(http.StatusBadRequest, scheduler.ExtenderFilterResult{Error: "geçersiz istek gövdesi: " + err.Error()})
+			return
+		}
+
+		result := aiScheduler.ExtenderFilter(&args)
+		if result.Error != "" {
+			logrus.Warnf("Extender filter başarısız: %s", result.Error)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// extenderPrioritize kube-scheduler extender prioritize isteğini cevaplar. Protokolde hata alanı olmadığından
+// hatalar HTTP hata koduyla döner
+func extenderPrioritize(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var args scheduler.ExtenderArgs
+		if err := c.ShouldBindJSON(&args); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz istek gövdesi: " + err.Error()})
+			return
+		}
+
+		priorities, err := aiScheduler.ExtenderPrioritize(c.Request.Context(), &args)
+		if err != nil {
+			logrus.Warnf("Extender prioritize başarısız: %v", err)
+			writeError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, priorities)
+	}
+}
+
+// extenderBind kube-scheduler extender bind isteğini cevaplar (kube-scheduler'da bindVerb tanımlıysa)
+func extenderBind(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var args scheduler.ExtenderBindingArgs
+		if err := c.ShouldBindJSON(&args); err != nil {
+			c.JSON(http.StatusBadRequest, scheduler.ExtenderBindingResult{Error: "geçersiz istek gövdesi: " + err.Error()})
+			return
+		}
+
+		var result scheduler.ExtenderBindingResult
+		if err := aiScheduler.ExtenderBind(c.Request.Context(), &args); err != nil {
+			logrus.Warnf("Extender bind başarısız: %v", err)
+			result.Error = err.Error()
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"ai-scheduler/internal/extmetrics"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SetupExternalMetricsRoutes Kubernetes external metrics API'sini (external.metrics.k8s.io/v1beta1) ayarlar.
+// APIService ile kube-aggregator'a kaydedildiğinde HPA'lar scheduler sinyallerine göre ölçeklenebilir. İstekler
+// aggregator'ın front-proxy istemci sertifikasıyla doğrulanır
+func SetupExternalMetricsRoutes(router *gin.Engine, provider *extmetrics.Provider, aggregator *ClientCertVerifier) {
+	group := router.Group("/apis/"+extmetrics.GroupVersion, requireClientCert(aggregator, func(status int, err error) interface{} {
+		return apiStatus(status, metav1.StatusReasonUnauthorized, err.Error())
+	}))
+	{
+		group.GET("", listExternalMetrics(provider))
+		group.GET("/namespaces/:namespace/:metric", getExternalMetric(provider))
+	}
+}
+
+// listExternalMetrics API discovery için sunulan metrikleri döndürür
+func listExternalMetrics(provider *extmetrics.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, provider.APIResources())
+	}
+}
+
+// getExternalMetric metriğin labelSelector'a uyan değerlerini döndürür
+func getExternalMetric(provider *extmetrics.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		selector, err := labels.Parse(c.Query("labelSelector"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, apiStatus(http.StatusBadRequest, metav1.StatusReasonBadRequest, "geçersiz labelSelector: "+err.Error()))
+			return
+		}
+
+		values, err := provider.GetExternalMetric(c.Param("metric"), selector)
+		if errors.Is(err, extmetrics.ErrUnknownMetric) {
+			c.JSON(http.StatusNotFound, apiStatus(http.StatusNotFound, metav1.StatusReasonNotFound, err.Error()))
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, apiStatus(http.StatusInternalServerError, metav1.StatusReasonInternalError, err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, values)
+	}
+}
+
+// apiStatus aggregated API istemcilerinin (ör: HPA'nın external metrics istemcisi) beklediği metav1.Status hata gövdesi
+func apiStatus(code int, reason metav1.StatusReason, message string) *metav1.Status {
+	return &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	}
+}
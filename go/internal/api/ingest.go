@@ -0,0 +1,199 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/collector"
+	"ai-scheduler/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ingestRequest dış ajanın node sinyalleri
+type ingestRequest struct {
+	Source    string     `json:"source" binding:"required,max=253"`
+	Timestamp *time.Time `json:"timestamp"` // Boşsa alım anı kullanılır
+	Nodes     []struct {
+		NodeName string             `json:"node_name" binding:"required,k8s_name"`
+		Signals  map[string]float64 `json:"signals" binding:"required"`
+	} `json:"nodes" binding:"required,dive"`
+}
+
+// ingestRejection kabul edilmeyen node veya sinyal
+type ingestRejection struct {
+	NodeName string `json:"node_name"`
+	Signal   string `json:"signal,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// requireIngestToken dış metrik alımını açık olmasına ve Bearer token'ın kabul edilen token'lardan biri olmasına bağlar
+func requireIngestToken(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !collector.IngestConfig().Enabled {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "dış metrik alımı kapalı"})
+			return
+		}
+		tokens := collector.IngestTokens()
+		if len(tokens) == 0 {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "dış metrik alımı için token tanımlı değil"})
+			return
+		}
+
+		presented, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if ok {
+			for _, token := range tokens {
+				if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+					c.Next()
+					return
+				}
+			}
+		}
+		c.Header("WWW-Authenticate", `Bearer realm="ai-scheduler"`)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "geçersiz veya eksik token"})
+	}
+}
+
+// ingestMetrics dış ajanların node sinyallerini cache'e yazar, sinyaller sonraki kararlarda AI özelliklerine eklenir.
+// Bilinmeyen node'lar ve geçersiz sinyaller reddedilir, kalanlar kabul edilir
+func ingestMetrics(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request ingestRequest
+		if !bindJSON(c, &request) {
+			return
+		}
+
+		store := collector.GetExternalSignals()
+		now := time.Now()
+		at := now
+		if request.Timestamp != nil && request.Timestamp.Before(now) {
+			at = *request.Timestamp
+		}
+		if now.Sub(at) > store.TTL() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "zaman damgası sinyal geçerlilik süresinden (" + store.TTL().String() + ") eski"})
+			return
+		}
+
+		nodes, err := collector.ListNodes(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		known := make(map[string]bool, len(nodes))
+		for _, node := range nodes {
+			known[node.Name] = true
+		}
+
+		accepted := 0
+		rejected := []ingestRejection{}
+		for _, node := range request.Nodes {
+			if !known[node.NodeName] {
+				rejected = append(rejected, ingestRejection{NodeName: node.NodeName, Reason: "node bulunamadı"})
+				continue
+			}
+
+			// Geçersiz sinyaller tek tek ayıklanır, node'un geçerli sinyalleri yine de kabul edilir
+			valid := make(map[string]float64, len(node.Signals))
+			for name, value := range node.Signals {
+				if err := types.ValidateExternalSignal(name, value); err != nil {
+					rejected = append(rejected, ingestRejection{NodeName: node.NodeName, Signal: name, Reason: err.Error()})
+					continue
+				}
+				valid[name] = value
+			}
+
+			overLimit, err := store.Put(node.NodeName, request.Source, valid, at)
+			if err != nil {
+				rejected = append(rejected, ingestRejection{NodeName: node.NodeName, Reason: err.Error()})
+				continue
+			}
+			for _, name := range overLimit {
+				rejected = append(rejected, ingestRejection{NodeName: node.NodeName, Signal: name, Reason: "node için sinyal sınırı aşıldı"})
+			}
+			accepted += len(valid) - len(overLimit)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"accepted": accepted,
+			"rejected": rejected,
+		})
+	}
+}
+
+// getIngestedMetrics süresi dolmamış dış sinyalleri node bazında döndürür
+func getIngestedMetrics(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store := collector.GetExternalSignals()
+		c.JSON(http.StatusOK, gin.H{
+			"ttl":   store.TTL().String(),
+			"nodes": store.Nodes(time.Now()),
+		})
+	}
+}
+
+// latencyIngestRequest probe ajanının kendi node'undan diğer node'lara ölçtüğü gecikmeler
+type latencyIngestRequest struct {
+	SourceNode   string     `json:"source_node" binding:"required,k8s_name"`
+	Timestamp    *time.Time `json:"timestamp"` // Boşsa alım anı kullanılır
+	Measurements []struct {
+		TargetNode string  `json:"target_node" binding:"required,k8s_name"`
+		RTTMs      float64 `json:"rtt_ms"`
+	} `json:"measurements" binding:"required,dive"`
+}
+
+// ingestLatency probe DaemonSet'inin node'lar arası gecikme ölçümlerini matrise yazar.
+// Bilinmeyen node'lar ve geçersiz ölçümler reddedilir, kalanlar kabul edilir
+func ingestLatency(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request latencyIngestRequest
+		if !bindJSON(c, &request) {
+			return
+		}
+
+		matrix := collector.GetNodeLatency()
+		now := time.Now()
+		at := now
+		if request.Timestamp != nil && request.Timestamp.Before(now) {
+			at = *request.Timestamp
+		}
+		if now.Sub(at) > matrix.MaxAge() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "zaman damgası ölçüm geçerlilik süresinden (" + matrix.MaxAge().String() + ") eski"})
+			return
+		}
+
+		nodes, err := collector.ListNodes(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		known := make(map[string]bool, len(nodes))
+		for _, node := range nodes {
+			known[node.Name] = true
+		}
+		if !known[request.SourceNode] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "kaynak node bulunamadı: " + request.SourceNode})
+			return
+		}
+
+		accepted := 0
+		rejected := []ingestRejection{}
+		for _, measurement := range request.Measurements {
+			if !known[measurement.TargetNode] {
+				rejected = append(rejected, ingestRejection{NodeName: measurement.TargetNode, Reason: "node bulunamadı"})
+				continue
+			}
+			if err := matrix.Observe(request.SourceNode, measurement.TargetNode, measurement.RTTMs, at); err != nil {
+				rejected = append(rejected, ingestRejection{NodeName: measurement.TargetNode, Reason: err.Error()})
+				continue
+			}
+			accepted++
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"accepted": accepted,
+			"rejected": rejected,
+		})
+	}
+}
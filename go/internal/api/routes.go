@@ -1,16 +1,32 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"ai-scheduler/internal/admission"
 	"ai-scheduler/internal/collector"
+	"ai-scheduler/internal/doctor"
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/federation"
+	"ai-scheduler/internal/report"
 	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
 // SetupRoutes API route'larını ayarlar
-func SetupRoutes(router *gin.Engine, aiScheduler *scheduler.AIScheduler, collector *collector.DataCollector) {
+func SetupRoutes(router *gin.Engine, aiScheduler *scheduler.AIScheduler, collector *collector.DataCollector, featureGate *features.Gate, capacityPlanner *report.CapacityPlanner, rightsizing *report.RightsizingRecommender, federator *federation.Federator, limiter *admission.Limiter, doc *doctor.Doctor, server types.ServerConfig, bindVerifier *ClientCertVerifier) {
+	registerValidators()
+	router.Use(requestTimeout(server.RequestTimeout))
+
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -19,36 +35,158 @@ func SetupRoutes(router *gin.Engine, aiScheduler *scheduler.AIScheduler, collect
 		})
 	})
 
+	// Readiness probe, ?verbose ile tüm denetimlerin sonucu döner
+	router.GET("/readyz", readiness(doc))
+
+	// kube-scheduler extender webhook'ları API anahtarı gönderemeyen kube-scheduler için doğrulamasızdır, pod bağlayan
+	// bind ise sadece istemci sertifikası doğrulayıcısı varsa ve doğrulanmış sertifikayla sunulur
+	if server.Extender.Enabled {
+		extender := router.Group("/extender")
+		{
+			extender.POST("/filter", extenderFilter(aiScheduler))
+			extender.POST("/prioritize", extenderPrioritize(aiScheduler))
+			if server.Extender.Bind && bindVerifier != nil {
+				extender.POST("/bind", requireClientCert(bindVerifier, func(_ int, err error) interface{} {
+					return scheduler.ExtenderBindingResult{Error: err.Error()}
+				}), extenderBind(aiScheduler))
+			}
+		}
+	}
+
+	// Dış metrik alımı API anahtarıyla değil kendi token'ıyla doğrulanır
+	ingest := router.Group("/api/v1/ingest")
+	{
+		ingest.POST("/metrics", requireIngestToken(collector), ingestMetrics(collector))
+		ingest.POST("/latency", requireIngestToken(collector), ingestLatency(collector))
+	}
+
 	// API v1 group
-	v1 := router.Group("/api/v1")
+	v1 := router.Group("/api/v1", authenticate(server.Auth))
+	{
+		// Namespace kapsamlı anahtarların erişebildiği endpoint'ler, kapsam handler'da denetlenir
+		v1.POST("/predict", predictNode(aiScheduler, limiter))
+		v1.POST("/predict/batch", predictBatch(aiScheduler, limiter))
+		v1.POST("/predict/explain-exclusions", explainExclusions(aiScheduler))
+		v1.DELETE("/assumptions/:namespace/:pod", forgetPod(aiScheduler))
+		v1.GET("/decisions", getDecisions(aiScheduler))
+		v1.GET("/pending", getPendingPods(aiScheduler))
+		v1.GET("/workloads/:namespace/:name/stats", getWorkloadStats(aiScheduler))
+		v1.GET("/outcomes", getOutcomes(aiScheduler))
+		v1.GET("/outcomes/export", exportOutcomes(aiScheduler))
+		v1.GET("/recommendations/rightsizing", getRightsizing(rightsizing))
+		v1.GET("/model/status", getModelStatus(aiScheduler))
+		v1.GET("/nodes/scores", getNodeScores(aiScheduler))
+	}
+
+	// Küme geneli endpoint'ler
+	cluster := v1.Group("", requireCluster())
 	{
 		// Scheduler endpoints
-		v1.POST("/predict", predictNode(aiScheduler))
-		v1.GET("/nodes", getNodes(aiScheduler))
-		v1.GET("/metrics", getMetrics(collector))
+		cluster.GET("/nodes", getNodes(aiScheduler))
+		cluster.GET("/nodes/compare", compareNodes(aiScheduler))
+		cluster.GET("/nodes/:name/forecast", getNodeForecast(aiScheduler))
+		cluster.GET("/nodes/:name/evictions", adviseEvictions(aiScheduler))
+		cluster.POST("/nodes/:name/drain-plan", planDrain(aiScheduler))
+		cluster.GET("/metrics", getMetrics(collector))
+		cluster.GET("/ingest/metrics", getIngestedMetrics(collector))
+		cluster.GET("/stats/teams", getTeamUsage(aiScheduler))
+		cluster.GET("/stats/heatmap", getHeatmap(collector))
+		cluster.GET("/stats/sli", getAppSLI(collector))
+		cluster.GET("/stats/network", getNetworkHealth(collector))
+		cluster.GET("/stats/latency", getNodeLatency(collector))
+		cluster.GET("/stats/storage", getStoragePressure(collector))
+		cluster.GET("/stats/restart-storm", getRestartStorm(collector))
+		cluster.GET("/stats/admission", getAdmissionStats(limiter))
+		cluster.GET("/stats/fragmentation", getFragmentation(aiScheduler))
+		cluster.GET("/stats/cron-bursts", getCronBursts(aiScheduler))
+		cluster.POST("/decisions/diff", diffDecisions(aiScheduler))
+		cluster.GET("/timeline", getTimeline(aiScheduler))
+		cluster.GET("/config/profile", getProfile(aiScheduler))
+		cluster.GET("/comparison", getComparison(aiScheduler))
+		cluster.GET("/comparison/records", getComparisonRecords(aiScheduler))
+		cluster.GET("/audit", getAudit(aiScheduler))
+		cluster.GET("/templates", getTemplateHistories(aiScheduler))
+		cluster.GET("/templates/:fingerprint", getTemplateHistory(aiScheduler))
 
-		// AI model endpoints
-		v1.POST("/model/train", trainModel(aiScheduler))
-		v1.GET("/model/status", getModelStatus(aiScheduler))
+		// Rapor endpoints
+		cluster.GET("/reports/capacity", getCapacityReport(capacityPlanner))
+		cluster.GET("/recommendations/noisy-neighbors", getNoisyNeighbors(collector))
+		cluster.GET("/recommendations/taints", getTaintProposals(aiScheduler))
+
+		// Kümeler arası yerleşim
+		cluster.POST("/federation/predict", predictFederation(federator, limiter))
+		cluster.POST("/federation/place", placeWorkload(aiScheduler))
+	}
+
+	// Konfigürasyonu veya modeli değiştiren endpoint'ler
+	v1.PUT("/config/profile", requireAdmin(), setProfile(aiScheduler))
+	v1.POST("/model/train", requireAdmin(), trainModel(aiScheduler))
+
+	// Admin endpoints
+	admin := v1.Group("/admin", requireAdmin())
+	{
+		admin.GET("/features", listFeatures(featureGate))
+		admin.PUT("/features/:name", setFeature(featureGate))
+		admin.DELETE("/features/:name", resetFeature(featureGate))
+		admin.GET("/mode", getMode(aiScheduler))
+		admin.PUT("/mode", setMode(aiScheduler))
+		admin.GET("/policy", getPolicy(aiScheduler))
+		admin.GET("/scoring/tuning", getScoringTuning(aiScheduler))
+		admin.POST("/scoring/tuning/revert", revertScoringWeights(aiScheduler))
+		admin.GET("/cooldowns", listCooldowns(aiScheduler))
+		admin.DELETE("/cooldowns/:node", clearCooldown(aiScheduler))
+		admin.GET("/backup", getBackup(aiScheduler, collector))
+		admin.POST("/restore", restoreBackup(aiScheduler, collector))
+	}
+}
+
+// readiness doctor denetimlerinden (kısa süre cache'lenmiş) biri başarısızsa 503 döndürür
+func readiness(doc *doctor.Doctor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := doc.Cached(c.Request.Context())
+		status := http.StatusOK
+		if !report.OK {
+			status = http.StatusServiceUnavailable
+		}
+		if _, verbose := c.GetQuery("verbose"); verbose {
+			c.JSON(status, report)
+			return
+		}
+		c.JSON(status, gin.H{"ready": report.OK})
 	}
 }
 
 // predictNode node tahmini yapar
-func predictNode(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+func predictNode(aiScheduler *scheduler.AIScheduler, limiter *admission.Limiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var request struct {
-			PodName   string `json:"pod_name" binding:"required"`
-			Namespace string `json:"namespace" binding:"required"`
+			PodName   string `json:"pod_name" binding:"required,k8s_name"`
+			Namespace string `json:"namespace" binding:"required,k8s_namespace"`
+			// LatencyCritical tahmini istek anında skorlamadan hazır sıralamadan ister
+			LatencyCritical bool `json:"latency_critical"`
 		}
 
-		if err := c.ShouldBindJSON(&request); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !bindJSON(c, &request) {
 			return
 		}
+		if !authorizeNamespace(c, request.Namespace) {
+			return
+		}
+
+		release, ok := acquireSlot(c, limiter, request.Namespace, aiScheduler.PodPriority(request.Namespace, request.PodName))
+		if !ok {
+			return
+		}
+		defer release()
+
+		predict := aiScheduler.PredictBestNode
+		if request.LatencyCritical {
+			predict = aiScheduler.PredictFromRanking
+		}
 
-		nodeScore, err := aiScheduler.PredictBestNode(request.PodName, request.Namespace)
+		nodeScore, err := predict(c.Request.Context(), request.PodName, request.Namespace)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
 			return
 		}
 
@@ -58,6 +196,496 @@ func predictNode(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
 	}
 }
 
+// explainExclusions pod için filtrede elenen her node'u ve onu eleyen kısıtı döndürür
+func explainExclusions(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			PodName   string `json:"pod_name" binding:"required,k8s_name"`
+			Namespace string `json:"namespace" binding:"required,k8s_namespace"`
+		}
+
+		if !bindJSON(c, &request) {
+			return
+		}
+		if !authorizeNamespace(c, request.Namespace) {
+			return
+		}
+
+		report, err := aiScheduler.ExplainExclusions(c.Request.Context(), request.PodName, request.Namespace)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// predictBatch pod'ların yerleşimini birlikte çözer. Toplu istek tek slot kullanır ve ilk pod'un namespace'ine
+// sayılır
+func predictBatch(aiScheduler *scheduler.AIScheduler, limiter *admission.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Pods []scheduler.BatchPod `json:"pods" binding:"required,dive"`
+		}
+
+		if !bindJSON(c, &request) {
+			return
+		}
+		if len(request.Pods) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": scheduler.ErrEmptyBatch.Error()})
+			return
+		}
+		for _, pod := range request.Pods {
+			if !authorizeNamespace(c, pod.Namespace) {
+				return
+			}
+		}
+
+		// Toplu istek, içindeki en yüksek öncelikli pod'un sırasıyla bekler
+		priority := aiScheduler.PodPriority(request.Pods[0].Namespace, request.Pods[0].PodName)
+		for _, pod := range request.Pods[1:] {
+			if other := aiScheduler.PodPriority(pod.Namespace, pod.PodName); other > priority {
+				priority = other
+			}
+		}
+		release, ok := acquireSlot(c, limiter, request.Pods[0].Namespace, priority)
+		if !ok {
+			return
+		}
+		defer release()
+
+		result, err := aiScheduler.PredictBatch(c.Request.Context(), request.Pods)
+		if errors.Is(err, scheduler.ErrBatchTooLarge) || errors.Is(err, scheduler.ErrEmptyBatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// acquireSlot tahmin için limiter'dan pod önceliğiyle slot alır. Kapasite doluysa 429 ve Retry-After, istemci
+// vazgeçtiyse 503 yazar ve false döner
+func acquireSlot(c *gin.Context, limiter *admission.Limiter, namespace string, priority int32) (func(), bool) {
+	release, err := limiter.Acquire(c.Request.Context(), namespace, priority)
+	if errors.Is(err, admission.ErrSaturated) {
+		retryAfter := limiter.RetryAfter()
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retry_after_seconds": math.Ceil(retryAfter.Seconds())})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return release, true
+}
+
+// getFragmentation node ve küme bazında bekleyen pod şekillerine sığmayan kapasiteyi döndürür
+func getFragmentation(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := aiScheduler.Fragmentation()
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// getCronBursts öğrenilen CronJob tetiklenme düzenlerini döndürür
+func getCronBursts(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"cronjobs": aiScheduler.CronBursts()})
+	}
+}
+
+// getAdmissionStats tahmin limiter'ının eşzamanlılık ve kuyruk durumunu döndürür
+func getAdmissionStats(limiter *admission.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, limiter.Stats())
+	}
+}
+
+// forgetPod pod'un assume kaydını geri alır (ör: binding başarısız oldu)
+func forgetPod(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authorizeNamespace(c, c.Param("namespace")) {
+			return
+		}
+		if !aiScheduler.ForgetPod(c.Param("namespace"), c.Param("pod")) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "assume kaydı bulunamadı"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "forgotten",
+		})
+	}
+}
+
+// getDecisions son kararları en yeniden eskiye döndürür (?limit=100)
+func getDecisions(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 100
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz limit: " + value})
+				return
+			}
+			limit = parsed
+		}
+
+		decisions := aiScheduler.Decisions(limit)
+		if scope := requestScope(c); scope != nil {
+			// Kapsam dışı kararlar limite sayılmaz
+			decisions = visibleDecisions(aiScheduler.Decisions(0), scope, limit)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"decisions": decisions,
+		})
+	}
+}
+
+// diffDecisions son kararları önerilen strateji, ağırlık, profil veya politikalarla yeniden skorlar ve kaç
+// yerleşimin değişeceğini döndürür; konfigürasyon değişmez
+func diffDecisions(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			scheduler.ConfigProposal
+			// Limit yeniden skorlanacak son karar sayısı (varsayılan 100, en fazla 1000)
+			Limit int `json:"limit" binding:"gte=0"`
+		}
+
+		if !bindJSON(c, &request) {
+			return
+		}
+
+		diff, err := aiScheduler.DiffDecisions(request.ConfigProposal, request.Limit)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, scheduler.ErrInvalidProposal) || errors.Is(err, scheduler.ErrUnknownProfile) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+// getTimeline kararları, sonuçları, node koşulu değişikliklerini, toplama boşluklarını ve model değişikliklerini
+// tek zaman sırasında döndürür (?since=&until=&kind=decision,model&node=&namespace=&limit=)
+func getTimeline(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := scheduler.TimelineQuery{
+			Node:      c.Query("node"),
+			Namespace: c.Query("namespace"),
+			Limit:     500,
+		}
+		for name, target := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+			if value := c.Query(name); value != "" {
+				parsed, err := parseTimestamp(value)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz " + name + ": " + value})
+					return
+				}
+				*target = parsed
+			}
+		}
+		if value := c.Query("kind"); value != "" {
+			query.Kinds = strings.Split(value, ",")
+		}
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz limit: " + value})
+				return
+			}
+			query.Limit = parsed
+		}
+
+		events, err := aiScheduler.Timeline(query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"count":  len(events),
+			"events": events,
+		})
+	}
+}
+
+// getProfile tanımlı skorlama profillerini, etkin profili, namespace bağlarını ve değişiklik geçmişini döndürür
+func getProfile(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.Profiles())
+	}
+}
+
+// setProfile etkin skorlama profilini ve namespace bağlarını değiştirir. dry_run ile değişiklik uygulanmaz,
+// node sıralamalarının nasıl değişeceği döndürülür
+func setProfile(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			// Profile boş string temel strateji ve ağırlıklara döner, verilmezse etkin profil değişmez
+			Profile *string `json:"profile"`
+			// Namespaces verilirse namespace bağlarının tamamının yerine geçer
+			Namespaces map[string]string `json:"namespaces" binding:"omitempty,dive,keys,k8s_namespace,endkeys"`
+			Reason     string            `json:"reason" binding:"max=256"`
+			DryRun     bool              `json:"dry_run"`
+		}
+
+		if !bindJSON(c, &request) {
+			return
+		}
+		if request.Profile == nil && request.Namespaces == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "profile veya namespaces verilmeli"})
+			return
+		}
+
+		update := scheduler.ProfileUpdate{Active: request.Profile, Namespaces: request.Namespaces, Reason: request.Reason}
+		if request.DryRun || c.Query("dry_run") == "true" {
+			preview, err := aiScheduler.PreviewProfile(update)
+			if err != nil {
+				c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, preview)
+			return
+		}
+
+		if _, err := aiScheduler.SetProfile(update); err != nil {
+			c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, aiScheduler.Profiles())
+	}
+}
+
+// profileErrorStatus profil isteği hatasının HTTP durum kodu
+func profileErrorStatus(err error) int {
+	if errors.Is(err, scheduler.ErrUnknownProfile) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// getPendingPods bu scheduler'ın yerleştirmesini bekleyen pod'ları işlenme sırasıyla (öncelik, sonra namespace'ler
+// arasında sırayla) döndürür (?namespace=)
+func getPendingPods(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		namespace := c.Query("namespace")
+		if namespace != "" && !authorizeNamespace(c, namespace) {
+			return
+		}
+
+		pods, err := aiScheduler.PendingPods(namespace)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		if scope := requestScope(c); scope != nil {
+			visible := pods[:0]
+			for _, pod := range pods {
+				if scope.Matches(pod.Namespace) {
+					visible = append(visible, pod)
+				}
+			}
+			pods = visible
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"count": len(pods),
+			"pods":  pods,
+		})
+	}
+}
+
+// getWorkloadStats iş yükünün karar geçmişindeki yerleşim istatistiklerini döndürür (?kind=Deployment)
+func getWorkloadStats(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authorizeNamespace(c, c.Param("namespace")) {
+			return
+		}
+
+		stats, err := aiScheduler.WorkloadStats(c.Param("namespace"), c.Param("name"), c.Query("kind"))
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, stats)
+	}
+}
+
+// getOutcomes karar sonucu doğruluk metriklerini ve son etiketli kayıtları döndürür
+func getOutcomes(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 100
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz limit: " + value})
+				return
+			}
+			limit = parsed
+		}
+
+		scope := requestScope(c)
+		if scope != nil {
+			// Doğruluk metrikleri küme genelidir, kapsamlı anahtarlar yalnız kendi kayıtlarını görür
+			c.JSON(http.StatusOK, gin.H{
+				"records": visibleOutcomes(aiScheduler.Outcomes(0), scope, limit),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"stats":   aiScheduler.OutcomeStats(),
+			"records": aiScheduler.Outcomes(limit),
+		})
+	}
+}
+
+// getComparison varsayılan scheduler ile AI seçimlerinin karşılaştırma raporunu döndürür
+func getComparison(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.ComparisonReport())
+	}
+}
+
+// getAudit karar denetiminin durumunu ve AI ile heuristik arasındaki uyuşma oranını döndürür
+func getAudit(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.AuditReport())
+	}
+}
+
+// getTemplateHistories pod şablonlarının sonuç geçmişlerini, şüpheli node özelliği olanlar başta olmak üzere döndürür
+func getTemplateHistories(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 100
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz limit: " + value})
+				return
+			}
+			limit = parsed
+		}
+
+		c.JSON(http.StatusOK, gin.H{"templates": aiScheduler.TemplateHistories(limit)})
+	}
+}
+
+// getTemplateHistory şablon özetinin node ve node özelliği bazında sonuç geçmişini döndürür
+func getTemplateHistory(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		history, err := aiScheduler.TemplateHistory(c.Param("fingerprint"))
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, history)
+	}
+}
+
+// getComparisonRecords son sonuçlanan karşılaştırma kayıtlarını döndürür
+func getComparisonRecords(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 100
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz limit: " + value})
+				return
+			}
+			limit = parsed
+		}
+
+		c.JSON(http.StatusOK, gin.H{"records": aiScheduler.ComparisonRecords(limit)})
+	}
+}
+
+// exportOutcomes tüm etiketli kayıtları eğitim verisi olarak satır başına bir JSON (NDJSON) döndürür, eskiden yeniye
+func exportOutcomes(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		records := visibleOutcomes(aiScheduler.Outcomes(0), requestScope(c), 0)
+
+		c.Status(http.StatusOK)
+		c.Header("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(c.Writer)
+		for i := len(records) - 1; i >= 0; i-- {
+			if err := encoder.Encode(&records[i]); err != nil {
+				logrus.Warnf("Sonuç kaydı yazılamadı: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// getCapacityReport node havuzu/zone bazında kapasite planlama raporunu döndürür
+func getCapacityReport(capacityPlanner *report.CapacityPlanner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		capacityReport, err := capacityPlanner.Generate(c.Request.Context(), time.Now())
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, capacityReport)
+	}
+}
+
+// getRightsizing iş yükü bazında pod istek önerilerini döndürür (?namespace=team-*)
+func getRightsizing(rightsizing *report.RightsizingRecommender) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var namespaces types.NamespaceFilter
+		if scope := requestScope(c); scope != nil {
+			namespaces = *scope
+		}
+		if namespace := c.Query("namespace"); namespace != "" {
+			if !authorizeNamespace(c, namespace) {
+				return
+			}
+			namespaces.Include = []string{namespace}
+		}
+
+		c.JSON(http.StatusOK, rightsizing.Recommend(namespaces, time.Now()))
+	}
+}
+
+// getNoisyNeighbors patlamaları komşu pod'ları bozan iş yüklerini döndürür (?namespace=team-*)
+func getNoisyNeighbors(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var namespaces types.NamespaceFilter
+		if namespace := c.Query("namespace"); namespace != "" {
+			namespaces.Include = []string{namespace}
+		}
+
+		c.JSON(http.StatusOK, report.NoisyNeighbors(collector, namespaces, time.Now()))
+	}
+}
+
+// getTaintProposals kararlılığı uzun süre eşiğin altında kalan node'lar için taint önerilerini döndürür
+func getTaintProposals(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, aiScheduler.TaintProposals())
+	}
+}
+
 // getNodes node listesini döndürür
 func getNodes(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -68,6 +696,186 @@ func getNodes(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
 	}
 }
 
+// adviseEvictions kararsız node'dan tahliyesi en güvenli pod'ları ve tahmini yeni node'larını döndürür (?limit=10)
+func adviseEvictions(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 0
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz limit: " + value})
+				return
+			}
+			limit = parsed
+		}
+
+		plan, err := aiScheduler.AdviseEvictions(c.Param("name"), limit)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, plan)
+	}
+}
+
+// planDrain node'un drain simülasyonunu döndürür
+func planDrain(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		plan, err := aiScheduler.PlanDrain(c.Param("name"))
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, plan)
+	}
+}
+
+// getNodeForecast node'un CPU/memory kullanım tahminini döndürür (?horizon=6h)
+func getNodeForecast(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var horizon time.Duration
+		if value := c.Query("horizon"); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz horizon: " + err.Error()})
+				return
+			}
+			horizon = parsed
+		}
+
+		nodeForecast, err := aiScheduler.ForecastNode(c.Request.Context(), c.Param("name"), horizon)
+		switch {
+		case errors.Is(err, scheduler.ErrForecastHorizon):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, nodeForecast)
+	}
+}
+
+// getNodeScores pod şablonundan oluşturulacak replika için tüm uygun node'ların sıralamasını binding yapmadan döndürür
+func getNodeScores(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		template, err := scheduler.ParseTemplateRef(c.Query("podTemplate"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if !authorizeNamespace(c, template.Namespace) {
+			return
+		}
+
+		ranking, err := aiScheduler.NodeScores(c.Request.Context(), template)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, ranking)
+	}
+}
+
+// compareNodes node skorlarını bileşenleriyle yan yana döndürür, at verilirse skorlar o an için yeniden hesaplanır
+func compareNodes(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var nodeNames []string
+		for _, name := range strings.Split(c.Query("nodes"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				nodeNames = append(nodeNames, name)
+			}
+		}
+
+		var at time.Time
+		if value := c.Query("at"); value != "" {
+			parsed, err := parseTimestamp(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz at: " + err.Error()})
+				return
+			}
+			at = parsed
+		}
+
+		comparison, err := aiScheduler.CompareNodes(nodeNames, at)
+		switch {
+		case errors.Is(err, scheduler.ErrCompareNodes), errors.Is(err, scheduler.ErrCompareTime):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, comparison)
+	}
+}
+
+// parseTimestamp RFC3339 ya da unix saniye biçimindeki zamanı çözer
+func parseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// predictFederation iş yükü için hedef küme ve node'u seçer
+func predictFederation(federator *federation.Federator, limiter *admission.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var spec scheduler.WorkloadSpec
+		if !bindJSON(c, &spec) {
+			return
+		}
+
+		release, ok := acquireSlot(c, limiter, spec.Namespace, 0)
+		if !ok {
+			return
+		}
+		defer release()
+
+		decision, err := federator.Predict(c.Request.Context(), &spec)
+		switch {
+		case errors.Is(err, scheduler.ErrInvalidWorkload):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, federation.ErrNoCluster):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "candidates": decision.Candidates})
+			return
+		case err != nil:
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, decision)
+	}
+}
+
+// placeWorkload iş yükünün bu kümedeki yerleşim özetini döndürür (federasyon üyeleri tarafından çağrılır)
+func placeWorkload(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var spec scheduler.WorkloadSpec
+		if !bindJSON(c, &spec) {
+			return
+		}
+
+		placement, err := aiScheduler.PlaceWorkload(&spec)
+		switch {
+		case errors.Is(err, scheduler.ErrInvalidWorkload):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, placement)
+	}
+}
+
 // getMetrics metrikleri döndürür
 func getMetrics(collector *collector.DataCollector) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -88,9 +896,104 @@ func getMetrics(collector *collector.DataCollector) gin.HandlerFunc {
 	}
 }
 
+// getTeamUsage takım bazlı kaynak tüketimini ve payları döndürür
+func getTeamUsage(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"teams": aiScheduler.TeamUsage(),
+		})
+	}
+}
+
+// getAppSLI node bazında uygulama gecikmesi ve hata oranı ortalamalarını döndürür
+func getAppSLI(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"nodes": collector.GetAppSLI().Nodes(),
+		})
+	}
+}
+
+// getNetworkHealth servis mesh telemetrisinden hesaplanan node ağ sağlığını döndürür
+func getNetworkHealth(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		nodes, median, updated := collector.GetMeshHealth().Nodes()
+		c.JSON(http.StatusOK, gin.H{
+			"nodes":             nodes,
+			"median_latency_ms": median,
+			"updated_at":        updated,
+		})
+	}
+}
+
+// getNodeLatency node'lar arası ölçülen gecikme matrisini döndürür
+func getNodeLatency(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matrix := collector.GetNodeLatency()
+		c.JSON(http.StatusOK, gin.H{
+			"max_age": matrix.MaxAge().String(),
+			"pairs":   matrix.Snapshot(time.Now()),
+		})
+	}
+}
+
+// getStoragePressure node disklerinin IO doygunluğunu döndürür
+func getStoragePressure(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		nodes, updated := collector.GetStoragePressure().Nodes()
+		c.JSON(http.StatusOK, gin.H{
+			"nodes":      nodes,
+			"updated_at": updated,
+		})
+	}
+}
+
+// getRestartStorm restart fırtınası tespitinin durumunu döndürür
+func getRestartStorm(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, collector.GetRestartStorm().Status(time.Now()))
+	}
+}
+
+// getHeatmap node × zaman kullanım/başarısızlık matrisini döndürür (?metric=cpu&range=7d&bucket=1h)
+func getHeatmap(collector *collector.DataCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := report.HeatmapQuery{Metric: c.Query("metric")}
+		for name, target := range map[string]*time.Duration{"range": &query.Range, "bucket": &query.Bucket} {
+			value := c.Query(name)
+			if value == "" {
+				continue
+			}
+			parsed, err := report.ParseRange(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "geçersiz " + name + ": " + value})
+				return
+			}
+			*target = parsed
+		}
+
+		heatmap, err := report.BuildHeatmap(c.Request.Context(), collector, query, time.Now())
+		if errors.Is(err, report.ErrInvalidHeatmapQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, heatmap)
+	}
+}
+
 // trainModel AI modelini eğitir
 func trainModel(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if aiScheduler.HeuristicOnly() {
+			c.JSON(http.StatusConflict, gin.H{"error": scheduler.ErrHeuristicMode.Error()})
+			return
+		}
+
 		// Model eğitimi implementasyonu
 		c.JSON(http.StatusOK, gin.H{
 			"status": "training_started",
@@ -101,9 +1004,24 @@ func trainModel(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
 // getModelStatus model durumunu döndürür
 func getModelStatus(aiScheduler *scheduler.AIScheduler) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		degradation := aiScheduler.Degradation()
+		if aiScheduler.HeuristicOnly() {
+			c.JSON(http.StatusOK, gin.H{
+				"status":      "disabled",
+				"mode":        scheduler.ModeHeuristic,
+				"degradation": degradation,
+			})
+			return
+		}
+
+		status := "ready"
+		if degradation.Tier != scheduler.TierFullAI {
+			status = "degraded"
+		}
 		c.JSON(http.StatusOK, gin.H{
-			"status":  "ready",
-			"version": "1.0.0",
+			"status":      status,
+			"version":     "1.0.0",
+			"degradation": degradation,
 		})
 	}
 }
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestTimeout isteğin context'ine timeout süresi ekler, 0 ise istek yalnız istemci kapanınca iptal edilir.
+// Süre handler'ı kesmez; tahmin, metrik ve AI çağrıları context'i izleyerek erken döner
+func requestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
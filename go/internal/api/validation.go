@@ -0,0 +1,182 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxRequestBodyBytes JSON istek gövdesinin en büyük boyutu (yedek geri yükleme hariç)
+const maxRequestBodyBytes = 1 << 20
+
+// registerValidators binding doğrulayıcısına Kubernetes ad kurallarını ekler ve alan hatalarında JSON adlarını
+// kullanmasını sağlar
+var registerValidators = sync.OnceFunc(func() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	// k8s_name pod/node adı (DNS-1123 subdomain), k8s_namespace namespace adı (DNS-1123 label)
+	engine.RegisterValidation("k8s_name", func(fl validator.FieldLevel) bool {
+		return len(validation.IsDNS1123Subdomain(fl.Field().String())) == 0
+	})
+	engine.RegisterValidation("k8s_namespace", func(fl validator.FieldLevel) bool {
+		return len(validation.IsDNS1123Label(fl.Field().String())) == 0
+	})
+})
+
+// fieldError istek gövdesindeki geçersiz alan
+type fieldError struct {
+	// Field alanın JSON yolu (ör: pods[2].namespace)
+	Field  string `json:"field,omitempty"`
+	Rule   string `json:"rule"`
+	Param  string `json:"param,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// bindJSON istek gövdesini en fazla maxRequestBodyBytes okuyarak obj'ye çözer ve binding kurallarıyla doğrular.
+// Bilinmeyen alanlar, birden fazla JSON değeri ve tip uyuşmazlıkları reddedilir. Hata durumunda 400 (büyük
+// gövdede 413) ve alan hatalarını yazar, false döner
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	if c.Request.Body == nil {
+		writeBindError(c, http.StatusBadRequest, "istek gövdesi boş", nil)
+		return false
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodyBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeBindError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("istek gövdesi %d bayttan büyük", tooLarge.Limit), nil)
+		return false
+	}
+	if err != nil {
+		writeBindError(c, http.StatusBadRequest, fmt.Sprintf("istek gövdesi okunamadı: %v", err), nil)
+		return false
+	}
+
+	if err := decodeStrict(body, obj); err != nil {
+		writeBindError(c, http.StatusBadRequest, "geçersiz JSON", decodeFieldErrors(err))
+		return false
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		var invalid validator.ValidationErrors
+		if errors.As(err, &invalid) {
+			writeBindError(c, http.StatusBadRequest, "geçersiz istek gövdesi", validationFieldErrors(invalid, obj))
+			return false
+		}
+		writeBindError(c, http.StatusBadRequest, err.Error(), nil)
+		return false
+	}
+	return true
+}
+
+// decodeStrict gövdeyi bilinmeyen alanlara izin vermeden tek bir JSON değeri olarak çözer
+func decodeStrict(body []byte, obj interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errors.New("istek gövdesi boş")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("gövdede birden fazla JSON değeri var")
+	}
+	return nil
+}
+
+// decodeFieldErrors JSON çözme hatasını alan hatasına çevirir, alana bağlanamayan hatalar mesajla döner
+func decodeFieldErrors(err error) []fieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []fieldError{{
+			Field:  typeErr.Field,
+			Rule:   "type",
+			Param:  typeErr.Type.String(),
+			Reason: fmt.Sprintf("%s bekleniyor, %s verildi", typeErr.Type, typeErr.Value),
+		}}
+	}
+	// encoding/json bilinmeyen alanlar için ayrı hata tipi döndürmez
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return []fieldError{{Field: strings.Trim(name, `"`), Rule: "unknown", Reason: "bilinmeyen alan"}}
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return []fieldError{{Rule: "syntax", Reason: fmt.Sprintf("%d. baytta: %v", syntaxErr.Offset, syntaxErr)}}
+	}
+	return []fieldError{{Rule: "syntax", Reason: err.Error()}}
+}
+
+// validationFieldErrors binding kuralı ihlallerini JSON yollu alan hatalarına çevirir
+func validationFieldErrors(invalid validator.ValidationErrors, obj interface{}) []fieldError {
+	// Namespace isimli kök tiplerde tipin adıyla başlar (ör: WorkloadSpec.namespace), anonim tiplerde başlamaz
+	root := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	fields := make([]fieldError, 0, len(invalid))
+	for _, err := range invalid {
+		field := err.Namespace()
+		if root != "" {
+			field = strings.TrimPrefix(field, root+".")
+		}
+		fields = append(fields, fieldError{
+			Field:  field,
+			Rule:   err.Tag(),
+			Param:  err.Param(),
+			Reason: ruleReason(err),
+		})
+	}
+	return fields
+}
+
+// ruleReason kural ihlalinin açıklaması
+func ruleReason(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required":
+		return "zorunlu alan"
+	case "min", "gte":
+		if err.Kind() == reflect.Slice || err.Kind() == reflect.Map || err.Kind() == reflect.String {
+			return "en az " + err.Param() + " eleman/karakter olmalı"
+		}
+		return "en az " + err.Param() + " olmalı"
+	case "max", "lte":
+		if err.Kind() == reflect.Slice || err.Kind() == reflect.Map || err.Kind() == reflect.String {
+			return "en fazla " + err.Param() + " eleman/karakter olmalı"
+		}
+		return "en fazla " + err.Param() + " olmalı"
+	case "k8s_name":
+		return "geçerli bir Kubernetes adı değil (küçük harf, rakam, '-' ve '.')"
+	case "k8s_namespace":
+		return "geçerli bir namespace adı değil (en fazla 63 karakter, küçük harf, rakam ve '-')"
+	default:
+		return fmt.Sprintf("%s kuralını sağlamıyor", err.Tag())
+	}
+}
+
+// writeBindError doğrulama hatasını yazar
+func writeBindError(c *gin.Context, status int, message string, fields []fieldError) {
+	response := gin.H{"error": message}
+	if len(fields) > 0 {
+		response["fields"] = fields
+	}
+	c.JSON(status, response)
+}
@@ -0,0 +1,249 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+)
+
+// FormatVersion arşiv formatının sürümü, farklı sürümdeki arşivler geri yüklenmez
+const FormatVersion = 1
+
+// Arşivdeki dosyalar
+const (
+	manifestFile = "manifest.json"
+	configFile   = "scheduler.json"
+	stateFile    = "state.jsonl"
+	samplesFile  = "pod_metrics.jsonl"
+	historyFile  = "node_history.jsonl" // Sonradan eklendi, olmayan arşivler boş geçmişle geri yüklenir
+)
+
+// Manifest arşivin içeriği
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Entries   int       `json:"entries"`
+	Samples   int       `json:"samples"`
+	Nodes     int       `json:"nodes"`
+}
+
+// Archive scheduler durumunun yedeği: scheduler konfigürasyonu (politikalar dahil), karar geçmişi, sonuçlar ve
+// assume kayıtları (günlük kayıtları olarak), pod metrik cache'i ve node kullanım geçmişi
+type Archive struct {
+	Manifest Manifest
+	Config   *types.SchedulerConfig
+	Entries  []scheduler.JournalEntry
+	Samples  []types.PodMetrics
+	History  []types.NodeHistorySeries
+}
+
+// RestoreResult geri yüklemenin özeti
+type RestoreResult struct {
+	Entries        int  `json:"entries"`
+	Samples        int  `json:"samples"`
+	HistorySamples int  `json:"history_samples"`
+	ConfigApplied  bool `json:"config_applied"`
+}
+
+// Create çalışan scheduler'ın durumundan arşiv oluşturur
+func Create(aiScheduler *scheduler.AIScheduler, podCache types.PodCache, history *types.NodeMetricsHistory, now time.Time) *Archive {
+	config := aiScheduler.BaseConfig()
+	archive := &Archive{
+		Config:  &config,
+		Entries: aiScheduler.StateEntries(),
+		Samples: podCache.Samples(),
+		History: history.Export(),
+	}
+	archive.Manifest = Manifest{
+		Version:   FormatVersion,
+		CreatedAt: now,
+		Entries:   len(archive.Entries),
+		Samples:   len(archive.Samples),
+		Nodes:     len(archive.History),
+	}
+	return archive
+}
+
+// Restore arşivi scheduler'a, pod metrik cache'ine ve node kullanım geçmişine uygular. Mevcut durum silinmez: çalışan
+// scheduler'a uygulandığında örnekler ve geçmiş dilimleri mevcutlardan eskiyse zaman sırasıyla başa eklenir,
+// kararlar geçmişe zamanlarına göre yerleşir. applyConfig true ise arşivdeki konfigürasyon çalışma anında uygulanır. podCache nil ise örnekler yüklenmez (ör: cache
+// replikalar arasında paylaşılıyorsa)
+func Restore(aiScheduler *scheduler.AIScheduler, podCache types.PodCache, history *types.NodeMetricsHistory, archive *Archive, applyConfig bool) RestoreResult {
+	result := RestoreResult{Entries: len(archive.Entries)}
+	if applyConfig && archive.Config != nil {
+		aiScheduler.UpdateConfig(archive.Config)
+		result.ConfigApplied = true
+	}
+	if podCache != nil {
+		result.Samples = podCache.Import(archive.Samples)
+	}
+	for i := range archive.Entries {
+		aiScheduler.RestoreJournal(&archive.Entries[i])
+	}
+	result.HistorySamples = history.Import(archive.History)
+	return result
+}
+
+// Write arşivi tar.gz olarak yazar
+func Write(w io.Writer, archive *Archive) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	files := []struct {
+		name  string
+		value interface{}
+		lines bool
+	}{
+		{manifestFile, archive.Manifest, false},
+		{configFile, archive.Config, false},
+		{stateFile, archive.Entries, true},
+		{samplesFile, archive.Samples, true},
+		{historyFile, archive.History, true},
+	}
+	for _, file := range files {
+		data, err := encode(file.value, file.lines)
+		if err != nil {
+			return fmt.Errorf("%s kodlanamadı: %v", file.name, err)
+		}
+		header := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(data)), ModTime: archive.Manifest.CreatedAt}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("arşiv yazılamadı: %v", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("arşiv yazılamadı: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("arşiv yazılamadı: %v", err)
+	}
+	return gz.Close()
+}
+
+// encode değeri JSON'a çevirir, lines true ise slice elemanları satır başına bir JSON olarak yazılır
+func encode(value interface{}, lines bool) ([]byte, error) {
+	if !lines {
+		return json.MarshalIndent(value, "", "  ")
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	switch items := value.(type) {
+	case []scheduler.JournalEntry:
+		for i := range items {
+			if err := encoder.Encode(&items[i]); err != nil {
+				return nil, err
+			}
+		}
+	case []types.PodMetrics:
+		for i := range items {
+			if err := encoder.Encode(&items[i]); err != nil {
+				return nil, err
+			}
+		}
+	case []types.NodeHistorySeries:
+		for i := range items {
+			if err := encoder.Encode(&items[i]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("desteklenmeyen tip: %T", value)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Read tar.gz arşivini okur, manifest yoksa veya sürüm farklıysa hata döner
+func Read(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("arşiv açılamadı: %v", err)
+	}
+	defer gz.Close()
+
+	archive := &Archive{}
+	manifestFound := false
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("arşiv okunamadı: %v", err)
+		}
+
+		switch header.Name {
+		case manifestFile:
+			if err := json.NewDecoder(tr).Decode(&archive.Manifest); err != nil {
+				return nil, fmt.Errorf("%s parse edilemedi: %v", header.Name, err)
+			}
+			manifestFound = true
+		case configFile:
+			if err := json.NewDecoder(tr).Decode(&archive.Config); err != nil {
+				return nil, fmt.Errorf("%s parse edilemedi: %v", header.Name, err)
+			}
+		case stateFile:
+			err = decodeLines(tr, func(data []byte) error {
+				var entry scheduler.JournalEntry
+				if err := json.Unmarshal(data, &entry); err != nil {
+					return err
+				}
+				archive.Entries = append(archive.Entries, entry)
+				return nil
+			})
+		case samplesFile:
+			err = decodeLines(tr, func(data []byte) error {
+				var sample types.PodMetrics
+				if err := json.Unmarshal(data, &sample); err != nil {
+					return err
+				}
+				archive.Samples = append(archive.Samples, sample)
+				return nil
+			})
+		case historyFile:
+			err = decodeLines(tr, func(data []byte) error {
+				var series types.NodeHistorySeries
+				if err := json.Unmarshal(data, &series); err != nil {
+					return err
+				}
+				archive.History = append(archive.History, series)
+				return nil
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s parse edilemedi: %v", header.Name, err)
+		}
+	}
+
+	if !manifestFound {
+		return nil, fmt.Errorf("arşivde %s yok", manifestFile)
+	}
+	if archive.Manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("desteklenmeyen arşiv sürümü: %d (beklenen %d)", archive.Manifest.Version, FormatVersion)
+	}
+	return archive, nil
+}
+
+// decodeLines satır başına bir JSON okur
+func decodeLines(r io.Reader, fn func(data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
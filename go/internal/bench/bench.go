@@ -0,0 +1,305 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/simulator"
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Options benchmark iş yükü ayarları
+type Options struct {
+	Requests    int           // Toplam tahmin isteği, Duration verilirse üst sınır
+	Concurrency int           // Eşzamanlı istek gönderen worker sayısı
+	Duration    time.Duration // 0 değilse benchmark bu süre sonunda durur
+	Churn       bool          // Benchmark sırasında sentetik küme ilerletilir
+	Ranked      bool          // Tahminler artımlı skorlamanın hazır sıralamasından yapılır
+	Pending     bool          // İş yükü sadece bekleyen pod'lardan oluşur, tahminler pod'ları assume eder
+	ForgetEvery int           // 0 değilse her N. tahminin assume kaydı geri alınır (başarısız binding)
+	// Workload verilirse iş yükü kümedeki pod'lar yerine bu pod'lardan verilen sırayla oluşur (ör: karar günlüğü).
+	// Pod'lar kümede bulunmalıdır
+	Workload []*corev1.Pod
+}
+
+// Result benchmark sonucu
+type Result struct {
+	Nodes       int           `json:"nodes"`
+	Pods        int           `json:"pods"`
+	Requests    int           `json:"requests"`
+	Errors      int           `json:"errors"`
+	Concurrency int           `json:"concurrency"`
+	Elapsed     time.Duration `json:"elapsed"`
+	Throughput  float64       `json:"throughput_per_sec"`
+	P50         time.Duration `json:"p50"`
+	P90         time.Duration `json:"p90"`
+	P99         time.Duration `json:"p99"`
+	Max         time.Duration `json:"max"`
+	AllocsPerOp float64       `json:"allocs_per_op"`
+	BytesPerOp  float64       `json:"bytes_per_op"`
+	GCCycles    uint32        `json:"gc_cycles"`
+
+	LatencyBudget  time.Duration `json:"latency_budget,omitempty"`
+	BudgetExceeded uint64        `json:"budget_exceeded"`
+	Assumed        int           `json:"assumed"`
+	Forgotten      int64         `json:"forgotten"`
+}
+
+// Run sentetik küme üzerinde tahmin iş yükünü çalıştırır ve ölçümleri döndürür
+func Run(ctx context.Context, cluster *simulator.Cluster, schedulerConfig *types.SchedulerConfig, gate *features.Gate, opts Options) (*Result, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Requests <= 0 && opts.Duration <= 0 {
+		return nil, fmt.Errorf("istek sayısı veya süre belirtilmeli")
+	}
+
+	// Kapsam içindeki pod'lar iş yükünü oluşturur, küme pod'ları sıralı döndürdüğü için sonuç tekrarlanabilir
+	candidates := cluster.Pods()
+	if opts.Workload != nil {
+		candidates = opts.Workload
+	}
+	var workload []*corev1.Pod
+	for _, pod := range candidates {
+		if opts.Pending && pod.Spec.NodeName != "" {
+			continue
+		}
+		if schedulerConfig.Namespaces.Matches(pod.Namespace) {
+			workload = append(workload, pod)
+		}
+	}
+	if len(workload) == 0 {
+		return nil, fmt.Errorf("sentetik kümede kapsam içinde pod yok")
+	}
+
+	collector := newCollector(cluster)
+	aiScheduler := scheduler.NewAIScheduler(nil, collector, schedulerConfig)
+	aiScheduler.SetClusterSource(cluster)
+	aiScheduler.SetFeatureGate(gate)
+
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+	if opts.Churn {
+		go cluster.Start(ctx)
+	}
+
+	predict := aiScheduler.PredictBestNode
+	if opts.Ranked {
+		predict = aiScheduler.PredictFromRanking
+	}
+
+	var next int64
+	var failures int64
+	var forgotten int64
+	latencies := make([][]time.Duration, opts.Concurrency)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := atomic.AddInt64(&next, 1) - 1
+				if opts.Requests > 0 && i >= int64(opts.Requests) {
+					return
+				}
+
+				pod := workload[i%int64(len(workload))]
+				requestStart := time.Now()
+				// Süre dolarken kesilen istekler hata sayılmaz
+				_, err := predict(ctx, pod.Name, pod.Namespace)
+				if err != nil && !errors.Is(err, types.ErrNoFeasibleNode) && ctx.Err() == nil {
+					atomic.AddInt64(&failures, 1)
+				}
+				latencies[worker] = append(latencies[worker], time.Since(requestStart))
+
+				// Başarısız binding: assume kaydı diğer worker'ların tahminleriyle eşzamanlı geri alınır
+				if opts.ForgetEvery > 0 && i%int64(opts.ForgetEvery) == 0 && aiScheduler.ForgetPod(pod.Namespace, pod.Name) {
+					atomic.AddInt64(&forgotten, 1)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	var all []time.Duration
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	result := &Result{
+		Nodes:       len(cluster.Nodes()),
+		Pods:        len(workload),
+		Requests:    len(all),
+		Errors:      int(failures),
+		Concurrency: opts.Concurrency,
+		Elapsed:     elapsed,
+		GCCycles:    after.NumGC - before.NumGC,
+
+		LatencyBudget:  schedulerConfig.LatencyBudget,
+		BudgetExceeded: aiScheduler.LatencyBudgetExceeded(),
+		Assumed:        aiScheduler.AssumedPods(),
+		Forgotten:      forgotten,
+	}
+	if len(all) > 0 {
+		result.Throughput = float64(len(all)) / elapsed.Seconds()
+		result.P50 = percentile(all, 0.50)
+		result.P90 = percentile(all, 0.90)
+		result.P99 = percentile(all, 0.99)
+		result.Max = all[len(all)-1]
+		result.AllocsPerOp = float64(after.Mallocs-before.Mallocs) / float64(len(all))
+		result.BytesPerOp = float64(after.TotalAlloc-before.TotalAlloc) / float64(len(all))
+	}
+
+	return result, nil
+}
+
+// percentile sıralı gecikmelerden verilen yüzdeliği döndürür
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	index := int(float64(len(sorted)-1) * p)
+	return sorted[index]
+}
+
+// collector sentetik kümeden doldurulan, scheduler'ın ihtiyaç duyduğu metrik kaynağı
+type collector struct {
+	podCache *types.PodMetricsCache
+	usage    *types.NamespaceUsageTracker
+}
+
+// newCollector sentetik kümenin anlık durumundan pod cache ve namespace tüketimini doldurur
+func newCollector(cluster *simulator.Cluster) *collector {
+	c := &collector{
+		podCache: types.NewPodMetricsCache(),
+		usage:    types.NewNamespaceUsageTracker(),
+	}
+
+	var clusterCPU, clusterMemory float64
+	for _, node := range cluster.Nodes() {
+		clusterCPU += float64(node.Status.Allocatable.Cpu().MilliValue()) / 1000.0
+		clusterMemory += float64(node.Status.Allocatable.Memory().Value()) / (1024 * 1024 * 1024)
+	}
+	c.usage.SetClusterCapacity(clusterCPU, clusterMemory)
+
+	now := time.Now()
+	usage := make(map[string]types.NamespaceUsage)
+	for _, pod := range cluster.Pods() {
+		restartCount := 0
+		for _, container := range pod.Status.ContainerStatuses {
+			restartCount += int(container.RestartCount)
+		}
+		c.podCache.UpdateCache(types.PodMetrics{
+			PodName:      pod.Name,
+			NodeName:     pod.Spec.NodeName,
+			Namespace:    pod.Namespace,
+			Status:       string(pod.Status.Phase),
+			RestartCount: restartCount,
+			CreatedAt:    pod.CreationTimestamp.Time,
+			Timestamp:    now,
+		})
+
+		if pod.Spec.NodeName != "" {
+			cpuRequest, memRequest := types.PodResourceRequests(pod)
+			nsUsage := usage[pod.Namespace]
+			nsUsage.Namespace = pod.Namespace
+			nsUsage.Pods++
+			nsUsage.CPURequest += cpuRequest
+			nsUsage.MemoryRequest += memRequest
+			usage[pod.Namespace] = nsUsage
+		}
+	}
+	c.usage.Update(usage)
+
+	return c
+}
+
+// GetMetricsChannel benchmark'ta metrik akışı kullanılmaz
+func (c *collector) GetMetricsChannel() <-chan interface{} {
+	return nil
+}
+
+// GetPodCache PodMetricsCache'i döndürür
+func (c *collector) GetPodCache() types.PodCache {
+	return c.podCache
+}
+
+// GetNamespaceUsage namespace tüketim takipçisini döndürür
+func (c *collector) GetNamespaceUsage() *types.NamespaceUsageTracker {
+	return c.usage
+}
+
+// GetNodeHistory benchmark'ta node kullanım geçmişi tutulmaz
+func (c *collector) GetNodeHistory() *types.NodeMetricsHistory {
+	return nil
+}
+
+// GetStartupLatency benchmark'ta başlatma süreleri tutulmaz
+func (c *collector) GetStartupLatency() *types.StartupLatencyTracker {
+	return nil
+}
+
+// GetClusterEvents benchmark'ta küme olayları tutulmaz
+func (c *collector) GetClusterEvents() *types.ClusterEventLog {
+	return nil
+}
+
+// GetNodeFlaps benchmark'ta node geçişleri tutulmaz
+func (c *collector) GetNodeFlaps() *types.NodeFlapTracker {
+	return nil
+}
+
+// GetNodeReadiness benchmark'ta Ready geçmişi tutulmaz
+func (c *collector) GetNodeReadiness() *types.NodeReadinessTracker {
+	return nil
+}
+
+// GetExternalSignals benchmark'ta dış sinyal alınmaz
+func (c *collector) GetExternalSignals() *types.ExternalSignalStore {
+	return nil
+}
+
+// GetAppSLI benchmark'ta uygulama SLI'ları toplanmaz
+func (c *collector) GetAppSLI() *types.AppSLITracker {
+	return nil
+}
+
+// GetMeshHealth benchmark'ta mesh telemetrisi okunmaz
+func (c *collector) GetMeshHealth() *types.MeshHealthTracker {
+	return nil
+}
+
+// GetNodeLatency benchmark'ta node'lar arası gecikme ölçülmez
+func (c *collector) GetNodeLatency() *types.NodeLatencyMatrix {
+	return nil
+}
+
+// GetStoragePressure benchmark'ta disk IO metrikleri okunmaz
+func (c *collector) GetStoragePressure() *types.StoragePressureTracker {
+	return nil
+}
+
+// GetNeighborUsage benchmark'ta pod kullanım penceresi tutulmaz
+func (c *collector) GetNeighborUsage() *types.NeighborUsageTracker {
+	return nil
+}
@@ -0,0 +1,79 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Delta iki benchmark sonucu arasındaki bir ölçümün değişimi
+type Delta struct {
+	Name   string
+	Before string
+	After  string
+	Change float64 // yüzde
+}
+
+// SaveResult sonucu JSON olarak dosyaya yazar
+func SaveResult(path string, result *Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("sonuç JSON'a çevrilemedi: %v", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadResult önceki sonucu dosyadan okur
+func LoadResult(path string) (*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("sonuç dosyası parse edilemedi: %v", err)
+	}
+	return &result, nil
+}
+
+// Compare iki sonucun throughput, gecikme ve allocation farklarını döndürür
+func Compare(before, after *Result) []Delta {
+	return []Delta{
+		floatDelta("throughput", before.Throughput, after.Throughput, "%.1f/sn"),
+		durationDelta("p50", before.P50, after.P50),
+		durationDelta("p90", before.P90, after.P90),
+		durationDelta("p99", before.P99, after.P99),
+		floatDelta("alloc/op", before.AllocsPerOp, after.AllocsPerOp, "%.0f"),
+		floatDelta("B/op", before.BytesPerOp, after.BytesPerOp, "%.0f"),
+	}
+}
+
+// floatDelta sayısal ölçüm farkı
+func floatDelta(name string, before, after float64, format string) Delta {
+	return Delta{
+		Name:   name,
+		Before: fmt.Sprintf(format, before),
+		After:  fmt.Sprintf(format, after),
+		Change: percentChange(before, after),
+	}
+}
+
+// durationDelta süre ölçümü farkı
+func durationDelta(name string, before, after time.Duration) Delta {
+	return Delta{
+		Name:   name,
+		Before: before.String(),
+		After:  after.String(),
+		Change: percentChange(float64(before), float64(after)),
+	}
+}
+
+// percentChange yüzde değişim, önceki değer 0 ise 0
+func percentChange(before, after float64) float64 {
+	if before == 0 {
+		return 0
+	}
+	return (after - before) / before * 100
+}
@@ -2,21 +2,66 @@ package collector
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"ai-scheduler/internal/store"
 	"ai-scheduler/internal/types"
 
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// IngestCredentialProvider dış metrik alımı token'ını sağlar (ör: Secret deposu)
+type IngestCredentialProvider interface {
+	IngestToken() string
+}
+
 // DataCollector veri toplayıcı
 type DataCollector struct {
 	k8sClient     *types.K8sClient
 	metricsClient *types.MetricsClient
 	config        *types.MetricsConfig
-	podCache      *types.PodMetricsCache
+	configMu      sync.RWMutex
+	podCache      types.PodCache
+	nodeHistory   *types.NodeMetricsHistory
+	workloads     *types.WorkloadUsageTracker
+	startup       *types.StartupLatencyTracker
+	neighbors     *types.NeighborUsageTracker
+	flaps         *types.NodeFlapTracker
+	readiness     *types.NodeReadinessTracker
+	events        *types.ClusterEventLog
+	usage         *types.NamespaceUsageTracker
+	external      *types.ExternalSignalStore
+	appSLI        *types.AppSLITracker
+	mesh          *types.MeshHealthTracker
+	latency       *types.NodeLatencyMatrix
+	storage       *types.StoragePressureTracker
+	storms        *types.RestartStormDetector
+	httpClient    *http.Client
+	credentials   IngestCredentialProvider
+	source        types.ClusterSource
+	errorSinks    types.ErrorSinks
 	metrics       chan interface{}
+	// chaosNodes son node toplamasında chaos deneyi altında işaretli node'lar (sadece toplama döngüsünden erişilir)
+	chaosNodes map[string]bool
+	// podMu pod örneklerinin toplama turundan ve pod olaylarından cache'e zaman sırasıyla yazılmasını sağlar,
+	// observed'ı da korur
+	podMu sync.Mutex
+	// observed son toplama turunda veya pod olayından cache'e yazılan pod durumları (sadece küme kaynağı olay
+	// bildiriyorsa tutulur)
+	observed map[string]podObservation
+	watching bool
+	// persistMu snapshot yazımlarını sıralar ve historyStore'u korur; snapshotAt son snapshot'taki cache güncelleme
+	// anı (unix nano)
+	persistMu    sync.Mutex
+	historyStore store.HistoryStore
+	snapshotAt   atomic.Int64
 }
 
 // NewDataCollector yeni veri toplayıcı oluşturur
@@ -26,40 +71,242 @@ func NewDataCollector(k8sClient *types.K8sClient, metricsConfig *types.MetricsCo
 		logrus.Warnf("Metrics client oluşturulamadı, placeholder değerler kullanılacak: %v", err)
 	}
 
+	podCache := types.NewPodMetricsCache()
+	podCache.SetMemoryBudget(int64(metricsConfig.CacheMaxMemoryMB) * 1024 * 1024)
+
+	events := types.NewClusterEventLog(&metricsConfig.ClusterEvents)
+	storms := types.NewRestartStormDetector(&metricsConfig.RestartStorm)
+	storms.OnStorm(func(start, until time.Time, affected, nodes int) {
+		logrus.Warnf("Restart fırtınası: %d/%d node'da pod restart'ı, kararlılık güncellemeleri %s'e kadar donduruldu",
+			affected, nodes, until.Format(time.RFC3339))
+		events.ObserveRestartStorm(start, until, affected, nodes)
+	})
+
 	return &DataCollector{
 		k8sClient:     k8sClient,
 		metricsClient: metricsClient,
 		config:        metricsConfig,
-		podCache:      types.NewPodMetricsCache(),
+		podCache:      podCache,
+		nodeHistory:   types.NewNodeMetricsHistory(&metricsConfig.History),
+		workloads:     types.NewWorkloadUsageTracker(&metricsConfig.WorkloadUsage),
+		startup:       types.NewStartupLatencyTracker(&metricsConfig.StartupLatency),
+		neighbors:     types.NewNeighborUsageTracker(&metricsConfig.NoisyNeighbor),
+		flaps:         types.NewNodeFlapTracker(&metricsConfig.NodeFlaps),
+		readiness:     types.NewNodeReadinessTracker(),
+		events:        events,
+		usage:         types.NewNamespaceUsageTracker(),
+		external:      types.NewExternalSignalStore(&metricsConfig.Ingest),
+		appSLI:        types.NewAppSLITracker(&metricsConfig.SLI),
+		mesh:          types.NewMeshHealthTracker(),
+		latency:       types.NewNodeLatencyMatrix(&metricsConfig.LatencyMatrix),
+		storage:       types.NewStoragePressureTracker(),
+		storms:        storms,
+		httpClient:    &http.Client{},
 		metrics:       make(chan interface{}, 1000),
 	}
 }
 
 // Start veri toplamayı başlatır
 func (dc *DataCollector) Start(ctx context.Context) {
-	interval := dc.config.CollectionInterval
-	if interval == 0 {
-		interval = 30 * time.Second // Default değer
-	}
+	interval := dc.collectionInterval()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	go dc.sliLoop(ctx)
+	go dc.meshLoop(ctx)
+	go dc.latencyLoop(ctx)
+	go dc.storageLoop(ctx)
+	go dc.persistenceLoop(ctx)
+
+	// Olay bildiren kaynaklarda node geçişleri ve silinen pod'ların son durumu turları beklemeden kaydedilir
+	dc.watch()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			dc.collectNodeMetrics()
-			dc.collectPodMetrics()
+			dc.collectNodeMetrics(ctx)
+			dc.collectPodMetrics(ctx)
+			dc.external.Prune(time.Now())
+			dc.latency.Prune(time.Now())
+
+			// Konfigürasyon değiştiyse toplama aralığını güncelle
+			if next := dc.collectionInterval(); next != interval {
+				logrus.Infof("Metrik toplama aralığı %s -> %s", interval, next)
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// UpdateConfig metrics konfigürasyonunu çalışma anında değiştirir
+func (dc *DataCollector) UpdateConfig(metricsConfig *types.MetricsConfig) {
+	cfg := *metricsConfig
+
+	dc.configMu.Lock()
+	dc.config = &cfg
+	dc.configMu.Unlock()
+
+	dc.podCache.SetMemoryBudget(int64(cfg.CacheMaxMemoryMB) * 1024 * 1024)
+	dc.nodeHistory.Configure(&cfg.History)
+	dc.workloads.Configure(&cfg.WorkloadUsage)
+	dc.startup.Configure(&cfg.StartupLatency)
+	dc.neighbors.Configure(&cfg.NoisyNeighbor)
+	dc.flaps.Configure(&cfg.NodeFlaps)
+	dc.events.Configure(&cfg.ClusterEvents)
+	dc.storms.Configure(&cfg.RestartStorm)
+	dc.external.Configure(&cfg.Ingest)
+	dc.appSLI.Configure(&cfg.SLI)
+	dc.latency.Configure(&cfg.LatencyMatrix)
+}
+
+// SetCredentials dış metrik alımı token sağlayıcısını ayarlar
+func (dc *DataCollector) SetCredentials(provider IngestCredentialProvider) {
+	dc.configMu.Lock()
+	defer dc.configMu.Unlock()
+
+	dc.credentials = provider
+}
+
+// IngestConfig dış metrik alımı ayarlarını döndürür
+func (dc *DataCollector) IngestConfig() types.IngestConfig {
+	dc.configMu.RLock()
+	defer dc.configMu.RUnlock()
+
+	return dc.config.Ingest
+}
+
+// AddErrorSink metrik toplama hatalarının alıcısını ekler (ör: Prometheus)
+func (dc *DataCollector) AddErrorSink(sink types.ErrorSink) {
+	dc.errorSinks.Add(sink)
+}
+
+// IngestTokens dış metrik alımında kabul edilen token'ları döndürür (konfigürasyondaki ve Secret'taki)
+func (dc *DataCollector) IngestTokens() []string {
+	dc.configMu.RLock()
+	defer dc.configMu.RUnlock()
+
+	var tokens []string
+	if dc.config.Ingest.Token != "" {
+		tokens = append(tokens, dc.config.Ingest.Token)
+	}
+	if dc.credentials != nil {
+		if token := dc.credentials.IngestToken(); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// namespaceFilter gözlemlenen namespace kapsamını döndürür
+func (dc *DataCollector) namespaceFilter() types.NamespaceFilter {
+	dc.configMu.RLock()
+	defer dc.configMu.RUnlock()
+
+	return dc.config.Namespaces
+}
+
+// chaosConfig chaos deneyi işaretlerini döndürür
+func (dc *DataCollector) chaosConfig() types.ChaosConfig {
+	dc.configMu.RLock()
+	defer dc.configMu.RUnlock()
+
+	return dc.config.Chaos
+}
+
+// collectionInterval geçerli toplama aralığını döndürür
+func (dc *DataCollector) collectionInterval() time.Duration {
+	dc.configMu.RLock()
+	defer dc.configMu.RUnlock()
+
+	if dc.config.CollectionInterval == 0 {
+		return 30 * time.Second // Default değer
+	}
+	return dc.config.CollectionInterval
+}
+
+// SetClusterSource Kubernetes API yerine kullanılacak küme kaynağını ayarlar (ör: informer cache, sentetik küme).
+// Start'tan önce çağrılmalıdır
+func (dc *DataCollector) SetClusterSource(source types.ClusterSource) {
+	dc.source = source
+}
+
+// hasCluster veri toplanabilecek bir küme kaynağı varsa true döner
+func (dc *DataCollector) hasCluster() bool {
+	return dc.source != nil || (dc.k8sClient != nil && dc.k8sClient.GetClientset() != nil)
+}
+
+// listNodes node listesini küme kaynağından veya Kubernetes API'den alır
+func (dc *DataCollector) listNodes(ctx context.Context) ([]*corev1.Node, error) {
+	if dc.source != nil {
+		return dc.source.Nodes(), nil
+	}
+
+	nodes, err := dc.k8sClient.GetClientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*corev1.Node, len(nodes.Items))
+	for i := range nodes.Items {
+		result[i] = &nodes.Items[i]
+	}
+	return result, nil
+}
+
+// listPods pod listesini küme kaynağından veya Kubernetes API'den alır
+func (dc *DataCollector) listPods(ctx context.Context) ([]*corev1.Pod, error) {
+	if dc.source != nil {
+		return dc.source.Pods(), nil
+	}
+
+	pods, err := dc.k8sClient.GetClientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		result[i] = &pods.Items[i]
+	}
+	return result, nil
+}
+
+// nodeUsage node'un CPU ve memory kullanımını küme kaynağından veya Metrics API'den alır
+func (dc *DataCollector) nodeUsage(ctx context.Context, nodeName string) (float64, float64, error) {
+	if dc.source != nil {
+		cpuUsage, memUsage, ok := dc.source.NodeUsage(nodeName)
+		if !ok {
+			return 0, 0, fmt.Errorf("node %s için kullanım verisi yok: %w", nodeName, types.ErrMetricsStale)
 		}
+		return cpuUsage, memUsage, nil
+	}
+
+	if dc.metricsClient == nil {
+		return 0, 0, fmt.Errorf("metrics client kullanılamıyor: %w", types.ErrMetricsStale)
 	}
+	cpuUsage, memUsage, err := dc.metricsClient.GetNodeMetrics(ctx, nodeName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Metrics API: %v: %w", err, types.ErrMetricsStale)
+	}
+	return cpuUsage, memUsage, nil
+}
+
+// podUsage pod kullanımlarını küme kaynağından veya Metrics API'den tek seferde alır
+func (dc *DataCollector) podUsage(ctx context.Context) (map[string][]types.ContainerUsage, error) {
+	if lister, ok := dc.source.(types.PodUsageLister); ok {
+		return lister.ListPodUsage()
+	}
+	return dc.metricsClient.ListPodUsage(ctx)
 }
 
 // collectNodeMetrics node metriklerini toplar
-func (dc *DataCollector) collectNodeMetrics() {
+func (dc *DataCollector) collectNodeMetrics(ctx context.Context) {
 	// Kubernetes client kontrolü
-	if dc.k8sClient == nil || dc.k8sClient.GetClientset() == nil {
+	if !dc.hasCluster() {
 		logrus.Debug("Kubernetes client yok, mock node metrics kullanılıyor")
 		// Mock node metrics
 		mockMetrics := types.NodeMetrics{
@@ -73,46 +320,83 @@ func (dc *DataCollector) collectNodeMetrics() {
 		return
 	}
 
-	nodes, err := dc.k8sClient.GetClientset().CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	nodes, err := dc.listNodes(ctx)
 	if err != nil {
 		logrus.Errorf("Node listesi alınamadı: %v", err)
 		return
 	}
 
-	for _, node := range nodes.Items {
+	var clusterCPU, clusterMemory float64
+	now := time.Now()
+	dc.events.ObserveCollection(now, dc.collectionInterval())
+	chaos := dc.chaosConfig()
+	chaosNodes := make(map[string]bool)
+	seen := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		seen[node.Name] = true
+		// Koşul değişiklikleri zaman çizelgesi için kaydedilir
+		dc.events.ObserveNode(node, now)
+
+		// Ready geçişleri flap cezası ve Ready geçmişi için kaydedilir, chaos deneyindeki node'un geçişleri
+		// sayılmaz (deney süresince node deney öncesi durumunda sayılır)
+		if chaos.Matches(node.Labels, node.Annotations) {
+			chaosNodes[node.Name] = true
+			dc.flaps.Sync(node, now)
+		} else {
+			dc.flaps.Observe(node, now)
+			dc.readiness.Observe(node, now)
+		}
+
+		// Küme kapasitesi (fairness hesapları için)
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			clusterCPU += float64(cpu.MilliValue()) / 1000.0
+		}
+		if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			clusterMemory += float64(memory.Value()) / (1024 * 1024 * 1024) // GB
+		}
+
 		// Node metrikleri hesaplama
+		os := types.NodePlatform(node).OS
 		metrics := types.NodeMetrics{
 			NodeName:  node.Name,
+			OS:        os,
 			PodCount:  len(node.Status.Allocatable),
 			Timestamp: time.Now(),
 		}
 
 		// Gerçek CPU ve Memory kullanımını al
-		if dc.metricsClient != nil {
-			cpuUsage, memUsage, err := dc.metricsClient.GetNodeMetrics(node.Name)
-			if err != nil {
-				logrus.Warnf("Node %s için metrikler alınamadı: %v", node.Name, err)
-				// Fallback: placeholder değerler
-				metrics.CPUUsage = 0.0
-				metrics.MemoryUsage = 0.0
-			} else {
-				metrics.CPUUsage = cpuUsage
-				metrics.MemoryUsage = memUsage
-			}
-		} else {
-			// Metrics client yoksa placeholder değerler
+		cpuUsage, memUsage, err := dc.nodeUsage(ctx, node.Name)
+		if err != nil {
+			dc.errorSinks.ObserveError(types.ErrorComponentCollector, err)
+			logrus.Warnf("Node %s için metrikler alınamadı: %v", node.Name, err)
+			// Fallback: placeholder değerler
 			metrics.CPUUsage = 0.0
 			metrics.MemoryUsage = 0.0
+		} else {
+			// Windows'ta node bellek kullanımı commit edilmiş belleği de içerebildiğinden fiziksel kapasiteyi aşabilir,
+			// oranların 1'i geçmemesi için ayrılabilir belleğe kırpılır
+			if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; os == types.OSWindows && ok && !memory.IsZero() {
+				memUsage = math.Min(memUsage, float64(memory.Value())/(1024*1024*1024))
+			}
+			metrics.CPUUsage = cpuUsage
+			metrics.MemoryUsage = memUsage
+			dc.nodeHistory.Record(metrics)
 		}
 
 		dc.metrics <- metrics
 	}
+
+	dc.usage.SetClusterCapacity(clusterCPU, clusterMemory)
+	dc.flaps.Expire(now)
+	dc.readiness.Expire(now)
+	dc.events.Expire(now, seen)
+	dc.chaosNodes = chaosNodes
 }
 
 // collectPodMetrics pod metriklerini toplar
-func (dc *DataCollector) collectPodMetrics() {
+func (dc *DataCollector) collectPodMetrics(ctx context.Context) {
 	// Kubernetes client kontrolü
-	if dc.k8sClient == nil || dc.k8sClient.GetClientset() == nil {
+	if !dc.hasCluster() {
 		logrus.Debug("Kubernetes client yok, mock pod metrics kullanılıyor")
 		// Mock pod metrics
 		mockMetrics := types.PodMetrics{
@@ -129,34 +413,133 @@ func (dc *DataCollector) collectPodMetrics() {
 		return
 	}
 
-	pods, err := dc.k8sClient.GetClientset().CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	pods, err := dc.listPods(ctx)
 	if err != nil {
 		logrus.Errorf("Pod listesi alınamadı: %v", err)
 		return
 	}
 
-	for _, pod := range pods.Items {
-		restartCount := 0
-		for _, container := range pod.Status.ContainerStatuses {
-			restartCount += int(container.RestartCount)
+	dc.podMu.Lock()
+	defer dc.podMu.Unlock()
+
+	// Pod kullanımı alınamazsa (ör: metrics-server yok) rightsizing geçmişi bu turda güncellenmez
+	podUsage, err := dc.podUsage(ctx)
+	if err != nil {
+		logrus.Debugf("Pod kullanımları alınamadı: %v", err)
+	}
+
+	namespaces := dc.namespaceFilter()
+	chaos := dc.chaosConfig()
+	usage := make(map[string]types.NamespaceUsage)
+	neighborSamples := make([]types.PodUsageSample, 0, len(podUsage))
+	var observed map[string]podObservation
+	if dc.watching {
+		observed = make(map[string]podObservation, len(pods))
+	}
+	now := time.Now()
+
+	// Restart fırtınası örnekler yazılmadan tespit edilir, fırtına turunun restart'ları da kararlılığa sayılmaz
+	restartSamples := make([]types.PodRestartSample, 0, len(pods))
+	for _, pod := range pods {
+		if namespaces.Matches(pod.Namespace) {
+			restartSamples = append(restartSamples, types.PodRestartSample{
+				Key:      pod.Namespace + "/" + pod.Name,
+				NodeName: pod.Spec.NodeName,
+				Restarts: podRestartCount(pod),
+				Failed:   pod.Status.Phase == corev1.PodFailed,
+			})
 		}
+	}
+	dc.storms.Observe(restartSamples, now)
 
-		metrics := types.PodMetrics{
-			PodName:      pod.Name,
-			NodeName:     pod.Spec.NodeName,
-			Namespace:    pod.Namespace,
-			Status:       string(pod.Status.Phase),
-			RestartCount: restartCount,
-			CreatedAt:    pod.CreationTimestamp.Time,
-			Timestamp:    time.Now(),
+	for _, pod := range pods {
+		// Gözlem kapsamı dışındaki namespace'leri atla
+		if !namespaces.Matches(pod.Namespace) {
+			continue
+		}
+
+		// Node'a yerleşmiş ve sonlanmamış pod'ların istekleri namespace tüketimine sayılır
+		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			cpuRequest, memRequest := types.PodResourceRequests(pod)
+			nsUsage := usage[pod.Namespace]
+			nsUsage.Namespace = pod.Namespace
+			nsUsage.Pods++
+			nsUsage.CPURequest += cpuRequest
+			nsUsage.MemoryRequest += memRequest
+			usage[pod.Namespace] = nsUsage
 		}
 
+		metrics := dc.podMetrics(pod, dc.chaosNodes[pod.Spec.NodeName], chaos, now)
+
 		// PodMetrics'i cache'e kaydet
 		dc.podCache.UpdateCache(metrics)
+		if observed != nil {
+			observed[pod.Namespace+"/"+pod.Name] = podObservation{phase: pod.Status.Phase, restarts: podRestartCount(pod)}
+		}
+
+		// Pod'un ilk başlatma süresi node ortalamasına bir kez eklenir
+		dc.startup.Record(pod, now)
+
+		// Çalışan pod'ların kullanımı iş yükü geçmişine ve noisy neighbor penceresine eklenir
+		if containers, ok := podUsage[pod.Namespace+"/"+pod.Name]; ok && pod.Status.Phase == corev1.PodRunning {
+			dc.workloads.Record(pod, containers, now)
+
+			sample := types.PodUsageSample{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				NodeName:  pod.Spec.NodeName,
+				Workload:  types.WorkloadOf(pod),
+				Restarts:  metrics.RestartCount,
+			}
+			for i := range containers {
+				sample.CPU += containers[i].CPU
+			}
+			neighborSamples = append(neighborSamples, sample)
+		}
 
 		// Metrics channel'a gönder
 		dc.metrics <- metrics
 	}
+
+	if observed != nil {
+		dc.observed = observed
+	}
+	dc.usage.Update(usage)
+	dc.workloads.Expire(now)
+	dc.startup.Expire(now)
+	if podUsage != nil {
+		dc.neighbors.Observe(neighborSamples)
+	}
+}
+
+// podMetrics pod'un cache örneğini oluşturur. Fırtınalarda artan restart'lar düşülür, fırtınada Failed olan pod'un
+// hatası sayılmaz; chaosNode pod'un node'u chaos deneyi altındaysa true'dur
+func (dc *DataCollector) podMetrics(pod *corev1.Pod, chaosNode bool, chaos types.ChaosConfig, now time.Time) types.PodMetrics {
+	stableRestarts, excused := dc.storms.Adjust(pod.Namespace+"/"+pod.Name, podRestartCount(pod))
+	return types.PodMetrics{
+		PodName:      pod.Name,
+		NodeName:     pod.Spec.NodeName,
+		Namespace:    pod.Namespace,
+		Status:       string(pod.Status.Phase),
+		RestartCount: stableRestarts,
+		CreatedAt:    pod.CreationTimestamp.Time,
+		Timestamp:    now,
+		Chaos:        excused || chaosNode || chaos.Matches(pod.Labels, pod.Annotations),
+	}
+}
+
+// podRestartCount pod'un container'larının toplam restart sayısını döndürür
+func podRestartCount(pod *corev1.Pod) int {
+	restartCount := 0
+	for _, container := range pod.Status.ContainerStatuses {
+		restartCount += int(container.RestartCount)
+	}
+	return restartCount
+}
+
+// GetRestartStorm restart fırtınası dedektörünü döndürür
+func (dc *DataCollector) GetRestartStorm() *types.RestartStormDetector {
+	return dc.storms
 }
 
 // GetMetricsChannel metrik kanalını döndürür
@@ -164,7 +547,99 @@ func (dc *DataCollector) GetMetricsChannel() <-chan interface{} {
 	return dc.metrics
 }
 
-// GetPodCache PodMetricsCache'i döndürür
-func (dc *DataCollector) GetPodCache() *types.PodMetricsCache {
+// GetPodCache pod metrik cache'ini döndürür
+func (dc *DataCollector) GetPodCache() types.PodCache {
 	return dc.podCache
 }
+
+// SetPodCache pod metrik cache'ini değiştirir (ör: replikalar arasında paylaşılan cache). Scheduler cache'i
+// oluşturulurken aldığı için toplama başlamadan ve scheduler oluşturulmadan önce çağrılmalıdır
+func (dc *DataCollector) SetPodCache(podCache types.PodCache) {
+	dc.configMu.RLock()
+	budget := int64(dc.config.CacheMaxMemoryMB) * 1024 * 1024
+	dc.configMu.RUnlock()
+
+	podCache.SetMemoryBudget(budget)
+	dc.podCache = podCache
+}
+
+// GetNodeHistory node kullanım geçmişini döndürür
+func (dc *DataCollector) GetNodeHistory() *types.NodeMetricsHistory {
+	return dc.nodeHistory
+}
+
+// GetWorkloadUsage iş yükü kullanım geçmişini döndürür
+func (dc *DataCollector) GetWorkloadUsage() *types.WorkloadUsageTracker {
+	return dc.workloads
+}
+
+// GetStartupLatency node bazında pod başlatma süresi ortalamalarını döndürür
+func (dc *DataCollector) GetStartupLatency() *types.StartupLatencyTracker {
+	return dc.startup
+}
+
+// GetNeighborUsage noisy neighbor tespiti için node bazında pod kullanım penceresini döndürür
+func (dc *DataCollector) GetNeighborUsage() *types.NeighborUsageTracker {
+	return dc.neighbors
+}
+
+// GetNodeFlaps node'ların NotReady↔Ready geçiş geçmişini döndürür
+func (dc *DataCollector) GetNodeFlaps() *types.NodeFlapTracker {
+	return dc.flaps
+}
+
+// GetNodeReadiness node'ların son 7 günlük Ready/NotReady geçmişini döndürür
+func (dc *DataCollector) GetNodeReadiness() *types.NodeReadinessTracker {
+	return dc.readiness
+}
+
+// GetClusterEvents node koşulu değişiklikleri ve toplama boşlukları günlüğünü döndürür
+func (dc *DataCollector) GetClusterEvents() *types.ClusterEventLog {
+	return dc.events
+}
+
+// GetExternalSignals dış ajanların gönderdiği node sinyallerini döndürür
+func (dc *DataCollector) GetExternalSignals() *types.ExternalSignalStore {
+	return dc.external
+}
+
+// GetAppSLI node bazında uygulama SLI ölçümlerini döndürür
+func (dc *DataCollector) GetAppSLI() *types.AppSLITracker {
+	return dc.appSLI
+}
+
+// GetMeshHealth servis mesh telemetrisinden hesaplanan node ağ sağlığını döndürür
+func (dc *DataCollector) GetMeshHealth() *types.MeshHealthTracker {
+	return dc.mesh
+}
+
+// GetNodeLatency node'lar arası ölçülen gecikme matrisini döndürür
+func (dc *DataCollector) GetNodeLatency() *types.NodeLatencyMatrix {
+	return dc.latency
+}
+
+// GetStoragePressure node disklerinin IO doygunluğunu döndürür
+func (dc *DataCollector) GetStoragePressure() *types.StoragePressureTracker {
+	return dc.storage
+}
+
+// ListNodes node listesini küme kaynağından veya Kubernetes API'den döndürür
+func (dc *DataCollector) ListNodes(ctx context.Context) ([]*corev1.Node, error) {
+	if !dc.hasCluster() {
+		return nil, nil
+	}
+	return dc.listNodes(ctx)
+}
+
+// ListPods pod listesini küme kaynağından veya Kubernetes API'den döndürür
+func (dc *DataCollector) ListPods(ctx context.Context) ([]*corev1.Pod, error) {
+	if !dc.hasCluster() {
+		return nil, nil
+	}
+	return dc.listPods(ctx)
+}
+
+// GetNamespaceUsage namespace tüketim takipçisini döndürür
+func (dc *DataCollector) GetNamespaceUsage() *types.NamespaceUsageTracker {
+	return dc.usage
+}
@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	"ai-scheduler/internal/promql"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Gecikme matrisi sorgusunun varsayılanları
+const (
+	defaultLatencyInterval    = time.Minute
+	defaultLatencyTimeout     = 5 * time.Second
+	defaultLatencySourceLabel = "source_node"
+	defaultLatencyTargetLabel = "destination_node"
+)
+
+// latencyMatrixConfig varsayılanları uygulanmış gecikme matrisi ayarlarını döndürür
+func (dc *DataCollector) latencyMatrixConfig() types.LatencyMatrixConfig {
+	dc.configMu.RLock()
+	cfg := dc.config.LatencyMatrix
+	dc.configMu.RUnlock()
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultLatencyInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultLatencyTimeout
+	}
+	if cfg.SourceLabel == "" {
+		cfg.SourceLabel = defaultLatencySourceLabel
+	}
+	if cfg.TargetLabel == "" {
+		cfg.TargetLabel = defaultLatencyTargetLabel
+	}
+	return cfg
+}
+
+// latencyLoop sorgu tanımlıysa node'lar arası gecikmeleri Prometheus'tan metrik toplamadan bağımsız aralıkla okur
+func (dc *DataCollector) latencyLoop(ctx context.Context) {
+	interval := dc.latencyMatrixConfig().Interval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := dc.latencyMatrixConfig()
+			if cfg.PrometheusURL != "" && cfg.Query != "" {
+				if err := dc.collectNodeLatency(ctx, &cfg); err != nil {
+					// Eski ölçümler geçerlilik süresi dolana kadar kullanılmaya devam eder
+					logrus.Warnf("Node'lar arası gecikmeler okunamadı: %v", err)
+				}
+			}
+
+			if cfg.Interval != interval {
+				interval = cfg.Interval
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// collectNodeLatency gecikme sorgusunu çalıştırır ve kaynak/hedef node label'lı serileri matrise yazar
+func (dc *DataCollector) collectNodeLatency(ctx context.Context, cfg *types.LatencyMatrixConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	samples, err := promql.NewClient(cfg.PrometheusURL, cfg.Timeout).QueryVector(ctx, cfg.Query)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	observed := 0
+	for _, sample := range samples {
+		from, to := sample.Labels[cfg.SourceLabel], sample.Labels[cfg.TargetLabel]
+		if err := dc.latency.Observe(from, to, sample.Value, now); err != nil {
+			logrus.Debugf("Gecikme serisi atlandı (%s -> %s): %v", from, to, err)
+			continue
+		}
+		observed++
+	}
+	logrus.Debugf("Prometheus'tan %d/%d node çifti gecikmesi okundu", observed, len(samples))
+	return nil
+}
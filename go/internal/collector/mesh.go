@@ -0,0 +1,178 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/promql"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Desteklenen servis mesh'leri
+const (
+	MeshIstio   = "istio"
+	MeshLinkerd = "linkerd"
+)
+
+// Mesh toplamanın varsayılanları
+const (
+	defaultMeshInterval       = time.Minute
+	defaultMeshTimeout        = 5 * time.Second
+	defaultMeshWindow         = 5 * time.Minute
+	defaultMeshPodLabel       = "pod"
+	defaultMeshNamespaceLabel = "namespace"
+)
+
+// meshQueries mesh'in gelen trafik sorguları. $by pod ve namespace label'larıyla, $window rate penceresiyle değiştirilir
+type meshQueries struct {
+	requests string
+	errors   string
+	latency  string
+}
+
+// meshProviders mesh türüne göre sorgular; sadece hedef tarafın (sunucu sidecar'ı) raporladığı gelen trafik sayılır
+var meshProviders = map[string]meshQueries{
+	MeshIstio: {
+		requests: `sum by ($by) (rate(istio_requests_total{reporter="destination"}[$window]))`,
+		errors:   `sum by ($by) (rate(istio_requests_total{reporter="destination",response_code=~"5.."}[$window]))`,
+		latency:  `histogram_quantile(0.99, sum by ($by, le) (rate(istio_request_duration_milliseconds_bucket{reporter="destination"}[$window])))`,
+	},
+	MeshLinkerd: {
+		requests: `sum by ($by) (rate(response_total{direction="inbound"}[$window]))`,
+		errors:   `sum by ($by) (rate(response_total{direction="inbound",classification="failure"}[$window]))`,
+		latency:  `histogram_quantile(0.99, sum by ($by, le) (rate(response_latency_ms_bucket{direction="inbound"}[$window])))`,
+	},
+}
+
+// meshPod mesh metriklerinde pod'u tanımlayan label değerleri
+type meshPod struct {
+	namespace string
+	name      string
+}
+
+// meshConfig varsayılanları uygulanmış mesh ayarlarını döndürür
+func (dc *DataCollector) meshConfig() types.MeshConfig {
+	dc.configMu.RLock()
+	cfg := dc.config.Mesh
+	dc.configMu.RUnlock()
+
+	if cfg.Provider == "" {
+		cfg.Provider = MeshIstio
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultMeshInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultMeshTimeout
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultMeshWindow
+	}
+	if cfg.PodLabel == "" {
+		cfg.PodLabel = defaultMeshPodLabel
+	}
+	if cfg.NamespaceLabel == "" {
+		cfg.NamespaceLabel = defaultMeshNamespaceLabel
+	}
+	return cfg
+}
+
+// meshLoop mesh telemetrisini metrik toplamadan bağımsız aralıkla okur
+func (dc *DataCollector) meshLoop(ctx context.Context) {
+	interval := dc.meshConfig().Interval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := dc.meshConfig()
+			if !cfg.Enabled {
+				dc.mesh.Clear()
+			} else if err := dc.collectMeshHealth(ctx, &cfg); err != nil {
+				// Eski sonuçlarla ceza vermek yerine ağ sağlığı bilinmiyor kabul edilir
+				logrus.Warnf("Mesh telemetrisi okunamadı (%s): %v", cfg.Provider, err)
+				dc.mesh.Clear()
+			}
+
+			if cfg.Interval != interval {
+				interval = cfg.Interval
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// collectMeshHealth pod bazında gelen trafiği Prometheus'tan okur ve pod'ların node'larına göre toplar
+func (dc *DataCollector) collectMeshHealth(ctx context.Context, cfg *types.MeshConfig) error {
+	queries, ok := meshProviders[cfg.Provider]
+	if !ok {
+		return fmt.Errorf("bilinmeyen mesh: %s", cfg.Provider)
+	}
+	if cfg.PrometheusURL == "" {
+		return fmt.Errorf("prometheus_url tanımlı değil")
+	}
+	if !dc.hasCluster() {
+		return fmt.Errorf("kubernetes client yok")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	client := promql.NewClient(cfg.PrometheusURL, cfg.Timeout)
+	placeholders := strings.NewReplacer(
+		"$by", cfg.NamespaceLabel+", "+cfg.PodLabel,
+		"$window", strconv.Itoa(int(cfg.Window.Seconds()))+"s",
+	)
+	results := make([]map[meshPod]float64, 3)
+	for i, query := range []string{queries.requests, queries.errors, queries.latency} {
+		samples, err := client.QueryVector(ctx, placeholders.Replace(query))
+		if err != nil {
+			return err
+		}
+		results[i] = make(map[meshPod]float64, len(samples))
+		for _, sample := range samples {
+			results[i][meshPod{namespace: sample.Labels[cfg.NamespaceLabel], name: sample.Labels[cfg.PodLabel]}] = sample.Value
+		}
+	}
+	requests, errors, latency := results[0], results[1], results[2]
+
+	pods, err := dc.listPods(ctx)
+	if err != nil {
+		return fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+	namespaces := dc.namespaceFilter()
+	traffic := make([]types.MeshPodTraffic, 0, len(requests))
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || !namespaces.Matches(pod.Namespace) {
+			continue
+		}
+		key := meshPod{namespace: pod.Namespace, name: pod.Name}
+		rate, ok := requests[key]
+		if !ok || math.IsNaN(rate) {
+			continue
+		}
+		p99, ok := latency[key]
+		if !ok {
+			p99 = math.NaN()
+		}
+		traffic = append(traffic, types.MeshPodTraffic{
+			NodeName:    pod.Spec.NodeName,
+			RequestRate: rate,
+			ErrorRate:   errors[key],
+			LatencyMs:   p99,
+		})
+	}
+
+	dc.mesh.Record(traffic, cfg, time.Now())
+	return nil
+}
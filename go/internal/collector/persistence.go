@@ -0,0 +1,137 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ai-scheduler/internal/store"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultSnapshotInterval pod metrik cache'i snapshot aralığının varsayılanı
+const defaultSnapshotInterval = 5 * time.Minute
+
+// ErrNoHistoryStore kalıcılık açık ama geçmiş deposu ayarlanmamış
+var ErrNoHistoryStore = errors.New("geçmiş deposu ayarlanmamış")
+
+// SetHistoryStore pod metrik cache'i snapshot'larının yazılacağı geçmiş deposunu ayarlar
+func (dc *DataCollector) SetHistoryStore(historyStore store.HistoryStore) {
+	dc.persistMu.Lock()
+	defer dc.persistMu.Unlock()
+
+	dc.historyStore = historyStore
+}
+
+// persistenceConfig varsayılanları uygulanmış kalıcılık ayarlarını döndürür
+func (dc *DataCollector) persistenceConfig() types.PersistenceConfig {
+	dc.configMu.RLock()
+	cfg := dc.config.Persistence
+	dc.configMu.RUnlock()
+
+	if cfg.SnapshotInterval <= 0 {
+		cfg.SnapshotInterval = defaultSnapshotInterval
+	}
+	return cfg
+}
+
+// RestorePodCache kalıcılık açıksa son snapshot'ı geçmiş deposundan pod metrik cache'ine yükler ve yüklenen örnek
+// sayısını döndürür. Örnekler cache'e eklendiği için toplama başlamadan önce çağrılmalıdır
+func (dc *DataCollector) RestorePodCache(ctx context.Context) (int, error) {
+	if !dc.persistenceConfig().Enabled {
+		return 0, nil
+	}
+	dc.persistMu.Lock()
+	defer dc.persistMu.Unlock()
+
+	if dc.historyStore == nil {
+		return 0, ErrNoHistoryStore
+	}
+	records, err := dc.historyStore.Query(ctx, store.StreamPodMetrics, time.Time{}, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, record := range records {
+		var sample types.PodMetrics
+		if err := json.Unmarshal(record.Data, &sample); err != nil {
+			logrus.Warnf("Pod metrik örneği okunamadı, atlanıyor: %v", err)
+			continue
+		}
+		dc.podCache.UpdateCache(sample)
+		restored++
+	}
+	dc.snapshotAt.Store(dc.podCache.LastUpdate().UnixNano())
+	return restored, nil
+}
+
+// persistenceLoop pod metrik cache'ini aralıkla kalıcılık backend'ine yazar. Kapanıştaki son snapshot SavePodCache ile
+// alınır
+func (dc *DataCollector) persistenceLoop(ctx context.Context) {
+	interval := dc.persistenceConfig().SnapshotInterval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := dc.persistenceConfig()
+			if cfg.Enabled {
+				if err := dc.snapshotPodCache(ctx); err != nil {
+					logrus.Warnf("Pod metrik cache'i kaydedilemedi: %v", err)
+				}
+			}
+
+			if cfg.SnapshotInterval != interval {
+				interval = cfg.SnapshotInterval
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// SavePodCache kalıcılık açıksa pod metrik cache'inin snapshot'ını hemen yazar (ör: kapanışta)
+func (dc *DataCollector) SavePodCache(ctx context.Context) error {
+	if !dc.persistenceConfig().Enabled {
+		return nil
+	}
+	return dc.snapshotPodCache(ctx)
+}
+
+// snapshotPodCache son snapshot'tan beri yeni örnek geldiyse saklama penceresindeki örneklerle geçmiş deposundaki
+// akışı değiştirir
+func (dc *DataCollector) snapshotPodCache(ctx context.Context) error {
+	dc.persistMu.Lock()
+	defer dc.persistMu.Unlock()
+
+	if dc.historyStore == nil {
+		return ErrNoHistoryStore
+	}
+	lastUpdate := dc.podCache.LastUpdate().UnixNano()
+	if lastUpdate == dc.snapshotAt.Load() {
+		return nil
+	}
+
+	samples := dc.podCache.Samples()
+	records := make([]store.Record, 0, len(samples))
+	for i := range samples {
+		record, err := store.NewRecord(samples[i].Timestamp, samples[i].Namespace+"/"+samples[i].PodName, &samples[i])
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	if err := dc.historyStore.Snapshot(ctx, store.StreamPodMetrics, records); err != nil {
+		return err
+	}
+	dc.snapshotAt.Store(lastUpdate)
+	logrus.Debugf("Pod metrik cache'i kaydedildi: %d örnek", len(records))
+	return nil
+}
@@ -0,0 +1,236 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/promql"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Uygulama SLI toplamanın varsayılanları
+const (
+	defaultSLIInterval    = time.Minute
+	defaultSLITimeout     = 2 * time.Second
+	defaultSLIConcurrency = 8
+	maxSLIResponseBytes   = 64 * 1024
+)
+
+// sliResponse SLI endpoint'inin döndürdüğü JSON
+type sliResponse struct {
+	LatencyMs *float64 `json:"latency_ms"`
+	ErrorRate *float64 `json:"error_rate"`
+}
+
+// sliConfig varsayılanları uygulanmış SLI toplama ayarlarını döndürür
+func (dc *DataCollector) sliConfig() types.AppSLIConfig {
+	dc.configMu.RLock()
+	cfg := dc.config.SLI
+	dc.configMu.RUnlock()
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSLIInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultSLITimeout
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultSLIConcurrency
+	}
+	return cfg
+}
+
+// sliLoop uygulama SLI'larını metrik toplamadan bağımsız aralıkla toplar
+func (dc *DataCollector) sliLoop(ctx context.Context) {
+	interval := dc.sliConfig().Interval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dc.collectAppSLI(ctx)
+
+			if next := dc.sliConfig().Interval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// collectAppSLI SLI annotation'ı olan çalışan pod'ları sınırlı eşzamanlılıkla ölçer.
+// Toplama kapalıysa eski ölçümler geçerlilik süresi dolunca düşer
+func (dc *DataCollector) collectAppSLI(ctx context.Context) {
+	cfg := dc.sliConfig()
+	now := time.Now()
+	if !cfg.Enabled || !dc.hasCluster() {
+		dc.appSLI.Record(nil, now)
+		return
+	}
+
+	pods, err := dc.listPods(ctx)
+	if err != nil {
+		logrus.Warnf("SLI toplaması için pod listesi alınamadı: %v", err)
+		return
+	}
+
+	var prom *promql.Client
+	if cfg.PrometheusURL != "" {
+		prom = promql.NewClient(cfg.PrometheusURL, cfg.Timeout)
+	}
+
+	namespaces := dc.namespaceFilter()
+	var targets []*corev1.Pod
+	for _, pod := range pods {
+		if !namespaces.Matches(pod.Namespace) || pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if pod.Annotations[types.AnnotationSLIEndpoint] != "" ||
+			pod.Annotations[types.AnnotationSLILatencyQuery] != "" ||
+			pod.Annotations[types.AnnotationSLIErrorRateQuery] != "" {
+			targets = append(targets, pod)
+		}
+	}
+
+	var (
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+		samples = make([]types.AppSLISample, 0, len(targets))
+		slots   = make(chan struct{}, cfg.Concurrency)
+	)
+	for _, pod := range targets {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(pod *corev1.Pod) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			sample, err := dc.measurePodSLI(ctx, pod, &cfg, prom)
+			if err != nil {
+				logrus.Debugf("Pod %s/%s SLI ölçülemedi: %v", pod.Namespace, pod.Name, err)
+				return
+			}
+			mutex.Lock()
+			samples = append(samples, sample)
+			mutex.Unlock()
+		}(pod)
+	}
+	wg.Wait()
+
+	dc.appSLI.Record(samples, now)
+}
+
+// measurePodSLI pod'un SLI endpoint'ini okur ve PromQL sorgularını çalıştırır, sorgu sonuçları endpoint'in önüne geçer
+func (dc *DataCollector) measurePodSLI(ctx context.Context, pod *corev1.Pod, cfg *types.AppSLIConfig, prom *promql.Client) (types.AppSLISample, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	sample := types.AppSLISample{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		NodeName:  pod.Spec.NodeName,
+		Timestamp: time.Now(),
+	}
+
+	if endpoint := pod.Annotations[types.AnnotationSLIEndpoint]; endpoint != "" {
+		response, err := dc.fetchSLIEndpoint(ctx, pod, endpoint)
+		if err != nil {
+			return sample, err
+		}
+		sample.LatencyMs, sample.ErrorRate = response.LatencyMs, response.ErrorRate
+	}
+
+	if prom != nil {
+		placeholders := strings.NewReplacer("$namespace", pod.Namespace, "$pod", pod.Name)
+		if query := pod.Annotations[types.AnnotationSLILatencyQuery]; query != "" {
+			value, err := prom.Query(ctx, placeholders.Replace(query))
+			if err != nil {
+				return sample, fmt.Errorf("gecikme sorgusu: %v", err)
+			}
+			sample.LatencyMs = &value
+		}
+		if query := pod.Annotations[types.AnnotationSLIErrorRateQuery]; query != "" {
+			value, err := prom.Query(ctx, placeholders.Replace(query))
+			if err != nil {
+				return sample, fmt.Errorf("hata oranı sorgusu: %v", err)
+			}
+			sample.ErrorRate = &value
+		}
+	}
+
+	if sample.LatencyMs != nil && (math.IsNaN(*sample.LatencyMs) || math.IsInf(*sample.LatencyMs, 0) || *sample.LatencyMs < 0) {
+		sample.LatencyMs = nil
+	}
+	if sample.ErrorRate != nil && (math.IsNaN(*sample.ErrorRate) || *sample.ErrorRate < 0 || *sample.ErrorRate > 1) {
+		sample.ErrorRate = nil
+	}
+	if sample.LatencyMs == nil && sample.ErrorRate == nil {
+		return sample, fmt.Errorf("geçerli gecikme veya hata oranı yok")
+	}
+	return sample, nil
+}
+
+// fetchSLIEndpoint pod'un SLI endpoint'inden JSON ölçümü okur
+func (dc *DataCollector) fetchSLIEndpoint(ctx context.Context, pod *corev1.Pod, endpoint string) (*sliResponse, error) {
+	target, err := sliEndpointURL(pod, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := dc.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SLI endpoint'i hata döndürdü: %d", resp.StatusCode)
+	}
+	var response sliResponse
+	if err := json.NewDecoder(http.MaxBytesReader(nil, resp.Body, maxSLIResponseBytes)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("SLI yanıtı parse edilemedi: %v", err)
+	}
+	return &response, nil
+}
+
+// sliEndpointURL annotation değerini URL'e çevirir, ":port/yol" ve "/yol" pod IP'sine göre çözülür
+func sliEndpointURL(pod *corev1.Pod, endpoint string) (string, error) {
+	if strings.HasPrefix(endpoint, ":") || strings.HasPrefix(endpoint, "/") {
+		host := pod.Status.PodIP
+		if host == "" {
+			return "", fmt.Errorf("pod IP'si yok")
+		}
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]" // IPv6
+		}
+		return "http://" + host + endpoint, nil
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("geçersiz SLI endpoint'i: %s", endpoint)
+	}
+	return endpoint, nil
+}
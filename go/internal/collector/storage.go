@@ -0,0 +1,165 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/promql"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Disk IO toplamanın varsayılanları
+const (
+	defaultStorageInterval  = time.Minute
+	defaultStorageTimeout   = 5 * time.Second
+	defaultStorageWindow    = 5 * time.Minute
+	defaultStorageNodeLabel = "node"
+)
+
+// node-exporter disk sorguları; loop ve RAM diskleri sayılmaz
+const (
+	defaultUtilizationQuery = `max by ($node) (rate(node_disk_io_time_seconds_total{device!~"loop.*|ram.*"}[$window]))`
+	defaultIOPSQuery        = `sum by ($node) (rate(node_disk_reads_completed_total{device!~"loop.*|ram.*"}[$window]) + rate(node_disk_writes_completed_total{device!~"loop.*|ram.*"}[$window]))`
+	defaultThroughputQuery  = `sum by ($node) (rate(node_disk_read_bytes_total{device!~"loop.*|ram.*"}[$window]) + rate(node_disk_written_bytes_total{device!~"loop.*|ram.*"}[$window]))`
+)
+
+// storageConfig varsayılanları uygulanmış disk IO ayarlarını döndürür
+func (dc *DataCollector) storageConfig() types.StorageConfig {
+	dc.configMu.RLock()
+	cfg := dc.config.Storage
+	dc.configMu.RUnlock()
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultStorageInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultStorageTimeout
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultStorageWindow
+	}
+	if cfg.NodeLabel == "" {
+		cfg.NodeLabel = defaultStorageNodeLabel
+	}
+	if cfg.UtilizationQuery == "" {
+		cfg.UtilizationQuery = defaultUtilizationQuery
+	}
+	if cfg.IOPSQuery == "" {
+		cfg.IOPSQuery = defaultIOPSQuery
+	}
+	if cfg.ThroughputQuery == "" {
+		cfg.ThroughputQuery = defaultThroughputQuery
+	}
+	return cfg
+}
+
+// storageLoop disk IO doygunluğunu metrik toplamadan bağımsız aralıkla okur
+func (dc *DataCollector) storageLoop(ctx context.Context) {
+	interval := dc.storageConfig().Interval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := dc.storageConfig()
+			if !cfg.Enabled {
+				dc.storage.Clear()
+			} else if err := dc.collectStorageIO(ctx, &cfg); err != nil {
+				// Eski sonuçlarla ceza vermek yerine disk durumu bilinmiyor kabul edilir
+				logrus.Warnf("Disk IO metrikleri okunamadı: %v", err)
+				dc.storage.Clear()
+			}
+
+			if cfg.Interval != interval {
+				interval = cfg.Interval
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// collectStorageIO disk kullanım oranı, IOPS ve throughput sorgularını çalıştırır ve sonuçları node'lara eşler
+func (dc *DataCollector) collectStorageIO(ctx context.Context, cfg *types.StorageConfig) error {
+	if cfg.PrometheusURL == "" {
+		return fmt.Errorf("prometheus_url tanımlı değil")
+	}
+
+	nodes, err := dc.ListNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("node listesi alınamadı: %v", err)
+	}
+	// Label değeri node adı veya node IP'si olabilir
+	names := make(map[string]string, len(nodes)*2)
+	for _, node := range nodes {
+		names[node.Name] = node.Name
+		for _, address := range node.Status.Addresses {
+			names[address.Address] = node.Name
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	client := promql.NewClient(cfg.PrometheusURL, cfg.Timeout)
+	placeholders := strings.NewReplacer(
+		"$node", cfg.NodeLabel,
+		"$window", strconv.Itoa(int(cfg.Window.Seconds()))+"s",
+	)
+	byNode := make(map[string]*types.NodeStorageIO)
+	for i, query := range []string{cfg.UtilizationQuery, cfg.IOPSQuery, cfg.ThroughputQuery} {
+		samples, err := client.QueryVector(ctx, placeholders.Replace(query))
+		if err != nil {
+			return err
+		}
+		for _, sample := range samples {
+			nodeName, ok := storageNodeName(names, sample.Labels[cfg.NodeLabel])
+			if !ok || math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) || sample.Value < 0 {
+				continue
+			}
+			node := byNode[nodeName]
+			if node == nil {
+				node = &types.NodeStorageIO{NodeName: nodeName}
+				byNode[nodeName] = node
+			}
+			// Aynı node birden fazla seriyle (ör: ad ve IP) gelirse büyük olan kullanılır
+			switch i {
+			case 0:
+				node.Utilization = math.Max(node.Utilization, sample.Value)
+			case 1:
+				node.IOPS = math.Max(node.IOPS, sample.Value)
+			case 2:
+				node.ThroughputMBps = math.Max(node.ThroughputMBps, sample.Value/(1024*1024))
+			}
+		}
+	}
+
+	result := make([]types.NodeStorageIO, 0, len(byNode))
+	for _, node := range byNode {
+		result = append(result, *node)
+	}
+	dc.storage.Record(result, cfg, time.Now())
+	return nil
+}
+
+// storageNodeName label değerini node adına çevirir, "ip:port" biçimindeki instance değerleri de çözülür
+func storageNodeName(names map[string]string, value string) (string, bool) {
+	if name, ok := names[value]; ok {
+		return name, true
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		name, ok := names[host]
+		return name, ok
+	}
+	return "", false
+}
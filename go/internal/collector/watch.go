@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// podObservation pod'un son toplama turunda cache'e yazılan durumu
+type podObservation struct {
+	phase    corev1.PodPhase
+	restarts int
+}
+
+// watch küme kaynağı olay bildiriyorsa (ör: informer) node ve pod olay fonksiyonlarını kaydeder. Toplama turu
+// örneklemesi değişmez; olaylardan sadece turlar arasında kaçacak durumlar kaydedilir: node koşul ve Ready geçişleri,
+// faz veya restart sayısı son kaydedilen durumdan farklı pod'lar (silinenler dahil)
+func (dc *DataCollector) watch() {
+	if source, ok := dc.source.(types.NodeEventSource); ok {
+		source.AddNodeHandler(dc.onNodeEvent)
+	}
+	if source, ok := dc.source.(types.PodEventSource); ok {
+		dc.podMu.Lock()
+		dc.watching = true
+		dc.podMu.Unlock()
+		source.AddPodHandler(dc.onPodEvent)
+	}
+}
+
+// onNodeEvent node'un koşul ve Ready geçişlerini toplama turunu beklemeden kaydeder, kısa süren NotReady
+// dönemleri de flap sayılır. Silinen node'lar sonraki turda düşülür
+func (dc *DataCollector) onNodeEvent(node *corev1.Node, deleted bool) {
+	if deleted {
+		return
+	}
+	now := time.Now()
+	dc.events.ObserveNode(node, now)
+	if dc.chaosConfig().Matches(node.Labels, node.Annotations) {
+		dc.flaps.Sync(node, now)
+	} else {
+		dc.flaps.Observe(node, now)
+		dc.readiness.Observe(node, now)
+	}
+}
+
+// onPodEvent pod'un fazı veya restart sayısı son kaydedilen durumdan farklıysa (ör: turlar arasında CrashLoop
+// restart'ları, Failed olup silinmesi) durumu toplama turunu beklemeden cache'e bir örnek olarak yazar. Kaydedilen
+// durum observed'a yazılır, aynı durumu taşıyan sonraki olaylar tekrar kaydedilmez
+func (dc *DataCollector) onPodEvent(pod *corev1.Pod, deleted bool) {
+	if pod.Spec.NodeName == "" || !dc.namespaceFilter().Matches(pod.Namespace) {
+		return
+	}
+	key := pod.Namespace + "/" + pod.Name
+	restarts := podRestartCount(pod)
+
+	dc.podMu.Lock()
+	defer dc.podMu.Unlock()
+
+	last, ok := dc.observed[key]
+	if deleted {
+		delete(dc.observed, key)
+	}
+	if ok && last.phase == pod.Status.Phase && last.restarts == restarts {
+		return
+	}
+	if !deleted {
+		if dc.observed == nil {
+			dc.observed = make(map[string]podObservation)
+		}
+		dc.observed[key] = podObservation{phase: pod.Status.Phase, restarts: restarts}
+	}
+
+	chaos := dc.chaosConfig()
+	chaosNode := false
+	if node, ok := dc.source.Node(pod.Spec.NodeName); ok {
+		chaosNode = chaos.Matches(node.Labels, node.Annotations)
+	}
+	metrics := dc.podMetrics(pod, chaosNode, chaos, time.Now())
+	dc.podCache.UpdateCache(metrics)
+	if deleted {
+		logrus.Debugf("Silinen pod %s son durumu kaydedildi: %s, %d restart", key, pod.Status.Phase, restarts)
+	} else {
+		logrus.Debugf("Pod %s durumu değişti, kaydedildi: %s, %d restart", key, pod.Status.Phase, restarts)
+	}
+
+	// Olay goroutine'i metrik kanalı dolu diye bloklanmaz
+	select {
+	case dc.metrics <- metrics:
+	default:
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ConfigMapSource konfigürasyonu Kubernetes ConfigMap'inden okur ve izler
+type ConfigMapSource struct {
+	k8sClient *types.K8sClient
+	namespace string
+	name      string
+	key       string
+	// base dosya/env kaynaklı ayarlar, ConfigMap içeriği bunların üzerine yazılır
+	base map[string]interface{}
+	// loadedVersion Load ile okunan ConfigMap'in resourceVersion'ı
+	loadedVersion string
+}
+
+// NewConfigMapSource yeni ConfigMap konfigürasyon kaynağı oluşturur
+func NewConfigMapSource(k8sClient *types.K8sClient, cmConfig *types.ConfigMapSourceConfig, base map[string]interface{}) *ConfigMapSource {
+	key := cmConfig.Key
+	if key == "" {
+		key = "config.yaml"
+	}
+
+	return &ConfigMapSource{
+		k8sClient: k8sClient,
+		namespace: cmConfig.Namespace,
+		name:      cmConfig.Name,
+		key:       key,
+		base:      base,
+	}
+}
+
+// Load ConfigMap'i okuyup temel ayarlarla birleştirilmiş konfigürasyonu döndürür
+func (s *ConfigMapSource) Load(ctx context.Context) (*types.Config, error) {
+	if s.k8sClient == nil || s.k8sClient.GetClientset() == nil {
+		return nil, fmt.Errorf("kubernetes client yok, ConfigMap okunamıyor")
+	}
+
+	cm, err := s.k8sClient.GetClientset().CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("ConfigMap %s/%s alınamadı: %v", s.namespace, s.name, err)
+	}
+
+	cfg, err := s.parse(cm)
+	if err != nil {
+		return nil, err
+	}
+	s.loadedVersion = cm.ResourceVersion
+
+	return cfg, nil
+}
+
+// Watch ConfigMap değişikliklerini izler ve her geçerli değişiklikte onChange'i çağırır
+func (s *ConfigMapSource) Watch(ctx context.Context, onChange func(*types.Config)) {
+	if s.k8sClient == nil || s.k8sClient.GetClientset() == nil {
+		logrus.Warn("Kubernetes client yok, ConfigMap izlenemiyor")
+		return
+	}
+
+	lastVersion := s.loadedVersion
+	for {
+		watcher, err := s.k8sClient.GetClientset().CoreV1().ConfigMaps(s.namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", s.name).String(),
+		})
+		if err != nil {
+			logrus.Warnf("ConfigMap watch başlatılamadı: %v", err)
+		} else {
+			lastVersion = s.consume(ctx, watcher, lastVersion, onChange)
+		}
+
+		// Watch kapandıysa kısa bir beklemeden sonra yeniden bağlan
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// consume watch olaylarını işler, son işlenen resourceVersion'ı döndürür
+func (s *ConfigMapSource) consume(ctx context.Context, watcher watch.Interface, lastVersion string, onChange func(*types.Config)) string {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return lastVersion
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return lastVersion
+			}
+			if event.Type != watch.Added && event.Type != watch.Modified {
+				continue
+			}
+
+			cm, ok := event.Object.(*corev1.ConfigMap)
+			if !ok || cm.ResourceVersion == lastVersion {
+				continue
+			}
+			lastVersion = cm.ResourceVersion
+
+			cfg, err := s.parse(cm)
+			if err != nil {
+				logrus.Errorf("ConfigMap konfigürasyonu uygulanmadı: %v", err)
+				continue
+			}
+
+			logrus.Infof("ConfigMap %s/%s değişti (resourceVersion=%s), konfigürasyon yeniden yükleniyor", s.namespace, s.name, lastVersion)
+			onChange(cfg)
+		}
+	}
+}
+
+// parse ConfigMap içeriğini temel ayarlarla birleştirip Config'e çevirir
+func (s *ConfigMapSource) parse(cm *corev1.ConfigMap) (*types.Config, error) {
+	data, ok := cm.Data[s.key]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s içinde %q anahtarı yok", s.namespace, s.name, s.key)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.MergeConfigMap(s.base); err != nil {
+		return nil, fmt.Errorf("temel ayarlar birleştirilemedi: %v", err)
+	}
+	if err := v.MergeConfig(bytes.NewBufferString(data)); err != nil {
+		return nil, fmt.Errorf("ConfigMap YAML parse edilemedi: %v", err)
+	}
+
+	var cfg types.Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("konfigürasyon parse edilemedi: %v", err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretStore kimlik bilgilerini Kubernetes Secret'larından okur ve rotasyonda yeniler
+type SecretStore struct {
+	k8sClient *types.K8sClient
+	config    *types.SecretsConfig
+
+	mutex      sync.RWMutex
+	aiAPIToken string
+	webhookKey []byte
+	ingestKey  string
+	cert       *tls.Certificate
+	versions   map[string]string // referans -> okunan resourceVersion
+}
+
+// NewSecretStore yeni secret deposu oluşturur
+func NewSecretStore(k8sClient *types.K8sClient, secretsConfig *types.SecretsConfig) *SecretStore {
+	return &SecretStore{
+		k8sClient: k8sClient,
+		config:    secretsConfig,
+		versions:  make(map[string]string),
+	}
+}
+
+// Enabled en az bir Secret referansı tanımlıysa true döner
+func (s *SecretStore) Enabled() bool {
+	return s.config.AIAPIToken.Name != "" || s.config.TLS.Name != "" || s.config.WebhookSigningKey.Name != "" || s.config.IngestToken.Name != ""
+}
+
+// Start Secret'ları periyodik olarak yeniler
+func (s *SecretStore) Start(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+
+	interval := s.config.RefreshInterval
+	if interval == 0 {
+		interval = time.Minute // Default değer
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				logrus.Warnf("Secret'lar yenilenemedi: %v", err)
+			}
+		}
+	}
+}
+
+// Refresh tanımlı tüm Secret'ları okur, değişenleri uygular. Bir Secret okunamasa veya geçersiz olsa da diğerleri
+// yenilenir, hatalar birlikte döner
+func (s *SecretStore) Refresh(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+	if s.k8sClient == nil || s.k8sClient.GetClientset() == nil {
+		return fmt.Errorf("kubernetes client yok, Secret'lar okunamıyor")
+	}
+
+	var errs []error
+	if ref := s.config.AIAPIToken; ref.Name != "" {
+		errs = append(errs, s.refresh(ctx, "ai_api_token", ref.Name, func(secret *corev1.Secret) error {
+			value, ok := secret.Data[keyOrDefault(ref.Key, "token")]
+			if !ok {
+				return fmt.Errorf("secret %s içinde AI API token anahtarı yok", ref.Name)
+			}
+			s.mutex.Lock()
+			s.aiAPIToken = string(value)
+			s.mutex.Unlock()
+			logrus.Infof("AI API token Secret %s'ten yüklendi", ref.Name)
+			return nil
+		}))
+	}
+
+	if ref := s.config.TLS; ref.Name != "" {
+		errs = append(errs, s.refresh(ctx, "tls", ref.Name, func(secret *corev1.Secret) error {
+			cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+			if err != nil {
+				return fmt.Errorf("secret %s içindeki TLS sertifikası geçersiz: %v", ref.Name, err)
+			}
+			s.mutex.Lock()
+			s.cert = &cert
+			s.mutex.Unlock()
+			logrus.Infof("TLS sertifikası Secret %s'ten yüklendi", ref.Name)
+			return nil
+		}))
+	}
+
+	if ref := s.config.WebhookSigningKey; ref.Name != "" {
+		errs = append(errs, s.refresh(ctx, "webhook_signing_key", ref.Name, func(secret *corev1.Secret) error {
+			value, ok := secret.Data[keyOrDefault(ref.Key, "signing-key")]
+			if !ok {
+				return fmt.Errorf("secret %s içinde webhook imzalama anahtarı yok", ref.Name)
+			}
+			s.mutex.Lock()
+			s.webhookKey = value
+			s.mutex.Unlock()
+			logrus.Infof("Webhook imzalama anahtarı Secret %s'ten yüklendi", ref.Name)
+			return nil
+		}))
+	}
+
+	if ref := s.config.IngestToken; ref.Name != "" {
+		errs = append(errs, s.refresh(ctx, "ingest_token", ref.Name, func(secret *corev1.Secret) error {
+			value, ok := secret.Data[keyOrDefault(ref.Key, "token")]
+			if !ok {
+				return fmt.Errorf("secret %s içinde metrik alımı token anahtarı yok", ref.Name)
+			}
+			s.mutex.Lock()
+			s.ingestKey = string(value)
+			s.mutex.Unlock()
+			logrus.Infof("Metrik alımı token'ı Secret %s'ten yüklendi", ref.Name)
+			return nil
+		}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// refresh Secret son uygulanandan beri değiştiyse apply ile uygular. Sürüm sadece değer başarıyla uygulanınca
+// kaydedilir, böylece eksik anahtar veya geçersiz sertifika sonraki yenilemede tekrar denenir
+func (s *SecretStore) refresh(ctx context.Context, ref, name string, apply func(*corev1.Secret) error) error {
+	secret, changed, err := s.fetch(ctx, ref, name)
+	if err != nil || !changed {
+		return err
+	}
+	if err := apply(secret); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.versions[ref] = secret.ResourceVersion
+	return nil
+}
+
+// fetch Secret'ı okur, referans için son uygulanan sürümden farklıysa changed=true döner
+func (s *SecretStore) fetch(ctx context.Context, ref, name string) (*corev1.Secret, bool, error) {
+	secret, err := s.k8sClient.GetClientset().CoreV1().Secrets(s.config.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("secret %s/%s alınamadı: %v", s.config.Namespace, name, err)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	// Aynı Secret birden fazla referansta kullanılabilir, sürümü referans bazında tut
+	return secret, s.versions[ref] != secret.ResourceVersion, nil
+}
+
+// AIAPIToken AI API token'ını döndürür
+func (s *SecretStore) AIAPIToken() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.aiAPIToken
+}
+
+// WebhookSigningKey webhook imzalama anahtarını döndürür
+func (s *SecretStore) WebhookSigningKey() []byte {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhookKey
+}
+
+// IngestToken dış metrik alımı token'ını döndürür
+func (s *SecretStore) IngestToken() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.ingestKey
+}
+
+// HasCertificate Secret'tan TLS sertifikası yüklendiyse true döner
+func (s *SecretStore) HasCertificate() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cert != nil
+}
+
+// GetCertificate tls.Config için geçerli sertifikayı döndürür, rotasyonda yenisi kullanılır
+func (s *SecretStore) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.cert == nil {
+		return nil, fmt.Errorf("TLS sertifikası yüklenmedi")
+	}
+	return s.cert, nil
+}
+
+// keyOrDefault anahtar boşsa varsayılanı döndürür
+func keyOrDefault(key, fallback string) string {
+	if key == "" {
+		return fallback
+	}
+	return key
+}
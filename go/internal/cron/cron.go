@@ -0,0 +1,196 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 5 alanlı (dakika saat ayın-günü ay haftanın-günü) cron ifadesi.
+// Alanlar *, liste (1,3), aralık (1-5), adım (*/15, 8-18/2) ve ay/gün adlarını (jan, mon) destekler
+type Schedule struct {
+	expression string
+	minute     uint64
+	hour       uint64
+	dom        uint64
+	month      uint64
+	dow        uint64
+	domAny     bool // Ayın günü * ise sadece haftanın günü kısıtlar (ve tersi)
+	dowAny     bool
+}
+
+// field cron alanının sınırları ve adları
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "dakika", min: 0, max: 59}
+	hourField   = field{name: "saat", min: 0, max: 23}
+	domField    = field{name: "ayın günü", min: 1, max: 31}
+	monthField  = field{name: "ay", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "haftanın günü", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// macros kısaltma ifadeleri
+var macros = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+// Parse cron ifadesini çözer
+func Parse(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron ifadesi %q: 5 alan gerekli, %d alan var", expression, len(fields))
+	}
+
+	s := &Schedule{expression: expression}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("cron ifadesi %q: %v", expression, err)
+	}
+	if s.hour, err = parseField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("cron ifadesi %q: %v", expression, err)
+	}
+	if s.dom, err = parseField(fields[2], domField); err != nil {
+		return nil, fmt.Errorf("cron ifadesi %q: %v", expression, err)
+	}
+	if s.month, err = parseField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("cron ifadesi %q: %v", expression, err)
+	}
+	if s.dow, err = parseField(fields[4], dowField); err != nil {
+		return nil, fmt.Errorf("cron ifadesi %q: %v", expression, err)
+	}
+
+	// 7 de pazar
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = strings.HasPrefix(fields[2], "*")
+	s.dowAny = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseField virgülle ayrılmış alan ifadesini bit kümesine çevirir
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s alanında geçersiz adım: %s", f.name, part)
+			}
+			step = n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s alanında ters aralık: %s", f.name, rangePart)
+			}
+		default:
+			n, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			low = n
+			// "5/10" 5'ten başlayıp sona kadar adımlar
+			if step == 1 {
+				high = n
+			}
+		}
+
+		for i := low; i <= high; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// value alan değerini sayı veya ad olarak çözer ve sınırları kontrol eder
+func (f field) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s alanında geçersiz değer: %s", f.name, s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s alanı %d-%d aralığında olmalı: %d", f.name, f.min, f.max, n)
+	}
+	return n, nil
+}
+
+// String ifadenin ilk halini döndürür
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// Matches t'nin dakikası ifadeyle eşleşiyor mu (t'nin saat diliminde)
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.dayMatches(t) && s.hour&(1<<uint(t.Hour())) != 0 && s.minute&(1<<uint(t.Minute())) != 0
+}
+
+// dayMatches ay ve gün eşleşmesi. Ayın günü ve haftanın günü ikisi de kısıtlıysa biri yeterlidir
+func (s *Schedule) dayMatches(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Last t'ye kadar (t dahil) within içinde eşleşen son dakikayı döndürür, yoksa false
+func (s *Schedule) Last(t time.Time, within time.Duration) (time.Time, bool) {
+	earliest := t.Add(-within)
+	current := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+
+	for !current.Before(earliest) {
+		switch {
+		case !s.dayMatches(current):
+			// Günün başından bir dakika önceye atla
+			current = time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location()).Add(-time.Minute)
+		case s.hour&(1<<uint(current.Hour())) == 0:
+			current = time.Date(current.Year(), current.Month(), current.Day(), current.Hour(), 0, 0, 0, current.Location()).Add(-time.Minute)
+		case s.minute&(1<<uint(current.Minute())) == 0:
+			current = current.Add(-time.Minute)
+		default:
+			return current, true
+		}
+	}
+	return time.Time{}, false
+}
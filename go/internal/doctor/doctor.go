@@ -0,0 +1,400 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	appconfig "ai-scheduler/internal/config"
+	"ai-scheduler/internal/journal"
+	"ai-scheduler/internal/mqtt"
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/store"
+	"ai-scheduler/internal/types"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Denetim sonuçları
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn" // Scheduler çalışır ama bozulmuş modda
+	StatusFail = "fail"
+	StatusSkip = "skip" // Bu konfigürasyonda denetim geçerli değil
+)
+
+// Denetim varsayılanları
+const (
+	checkTimeout      = 5 * time.Second
+	reportTTL         = 10 * time.Second   // /readyz sonucunun yeniden kullanılma süresi
+	certificateWarnIn = 7 * 24 * time.Hour // Bu süreden kısa sürede dolacak sertifika uyarı verir
+)
+
+// Check tek bir denetimin sonucu
+type Check struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report denetimlerin sonucu, fail olan denetim yoksa OK
+type Report struct {
+	OK        bool      `json:"ok"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []Check   `json:"checks"`
+}
+
+// permission scheduler'ın ihtiyaç duyduğu Kubernetes API yetkisi
+type permission struct {
+	group, resource, subresource, verb, namespace string
+}
+
+// Doctor scheduler'ın bağımlılıklarını (RBAC, metrics-server, AI servisi, TLS sertifikası) ve konfigürasyonunu
+// denetler. ai-scheduler doctor komutu ve /readyz aynı denetimleri kullanır
+type Doctor struct {
+	config      *types.Config
+	k8sClient   *types.K8sClient
+	secretStore *appconfig.SecretStore
+	client      *http.Client
+
+	mutex  sync.Mutex
+	cached *Report
+}
+
+// New yeni denetleyici oluşturur, secretStore nil olabilir
+func New(config *types.Config, k8sClient *types.K8sClient, secretStore *appconfig.SecretStore) *Doctor {
+	return &Doctor{
+		config:      config,
+		k8sClient:   k8sClient,
+		secretStore: secretStore,
+		client:      &http.Client{Timeout: checkTimeout},
+	}
+}
+
+// Run tüm denetimleri çalıştırır
+func (d *Doctor) Run(ctx context.Context) *Report {
+	report := &Report{OK: true, CheckedAt: time.Now()}
+	for _, check := range []struct {
+		name string
+		run  func(ctx context.Context) (string, string)
+	}{
+		{"config", d.checkConfig},
+		{"kubernetes_rbac", d.checkRBAC},
+		{"metrics_server", d.checkMetricsServer},
+		{"ai_service", d.checkAIService},
+		{"tls_certificate", d.checkCertificate},
+	} {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		status, message := check.run(checkCtx)
+		cancel()
+		if status == StatusFail {
+			report.OK = false
+		}
+		report.Checks = append(report.Checks, Check{Name: check.name, Status: status, Message: message})
+	}
+	return report
+}
+
+// Cached son raporu reportTTL boyunca yeniden kullanır (sık çağrılan readiness probe'ları için)
+func (d *Doctor) Cached(ctx context.Context) *Report {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.cached == nil || time.Since(d.cached.CheckedAt) > reportTTL {
+		d.cached = d.Run(ctx)
+	}
+	return d.cached
+}
+
+// checkConfig konfigürasyondaki çelişkileri ve eksikleri bulur
+func (d *Doctor) checkConfig(context.Context) (string, string) {
+	cfg := d.config
+	var issues []string
+	if cfg.Server.Port <= 0 || cfg.Server.Port > 65535 {
+		issues = append(issues, fmt.Sprintf("server.port geçersiz: %d", cfg.Server.Port))
+	}
+	switch cfg.Scheduler.Mode {
+	case "", scheduler.ModeAI:
+		if parsed, err := url.Parse(cfg.Scheduler.AIAPIURL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			issues = append(issues, fmt.Sprintf("scheduler.ai_api_url geçersiz: %q", cfg.Scheduler.AIAPIURL))
+		}
+	case scheduler.ModeHeuristic:
+	default:
+		issues = append(issues, fmt.Sprintf("scheduler.mode bilinmiyor: %q", cfg.Scheduler.Mode))
+	}
+	if cfg.Server.TLS.Enabled && (cfg.Server.TLS.CertFile == "" || cfg.Server.TLS.KeyFile == "") && cfg.Secrets.TLS.Name == "" {
+		issues = append(issues, "server.tls açık ama sertifika dosyası veya secrets.tls tanımlı değil")
+	}
+	if extender := cfg.Server.Extender; extender.Enabled && extender.Bind && (!cfg.Server.TLS.Enabled || extender.ClientCAFile == "") {
+		issues = append(issues, "server.extender.bind açık ama server.tls kapalı veya server.extender.client_ca_file boş, /extender/bind sunulmaz")
+	}
+	if cfg.Monitoring.ExternalMetrics.Enabled && !cfg.Server.TLS.Enabled {
+		issues = append(issues, "monitoring.external_metrics açık ama server.tls kapalı, aggregator istemci sertifikası doğrulanamaz")
+	}
+	if cfg.Server.Auth.Enabled {
+		tokens := make(map[string]bool)
+		for _, key := range cfg.Server.Auth.Keys {
+			if tokens[key.Token] && key.Token != "" {
+				issues = append(issues, fmt.Sprintf("server.auth anahtarı %s başka bir anahtarla aynı token'ı kullanıyor", key.Name))
+			}
+			tokens[key.Token] = true
+		}
+		if len(tokens) == 0 || (len(tokens) == 1 && tokens[""]) {
+			issues = append(issues, "server.auth açık ama token'lı anahtar yok, tüm istekler reddedilir")
+		}
+	}
+	if profile := cfg.Scheduler.Profile; profile != "" && !hasProfile(cfg.Scheduler.Profiles, profile) {
+		issues = append(issues, fmt.Sprintf("scheduler.profile %s scheduler.profiles içinde yok", profile))
+	}
+	if remediation := cfg.Scheduler.Remediation; remediation.Enabled {
+		switch remediation.Mode {
+		case "", scheduler.RemediationPropose, scheduler.RemediationApply:
+		default:
+			issues = append(issues, fmt.Sprintf("scheduler.remediation.mode bilinmiyor: %q", remediation.Mode))
+		}
+		if remediation.RecoveryThreshold > 0 && remediation.RecoveryThreshold < remediation.StabilityThreshold {
+			issues = append(issues, fmt.Sprintf("scheduler.remediation.recovery_threshold (%.2f) stability_threshold'dan (%.2f) düşük", remediation.RecoveryThreshold, remediation.StabilityThreshold))
+		}
+	}
+	staleness := cfg.Scheduler.Staleness
+	if interval := cfg.Metrics.CollectionInterval; staleness.Enabled && staleness.MaxAge > 0 && staleness.MaxAge <= interval {
+		issues = append(issues, fmt.Sprintf("scheduler.staleness.max_age (%s) metrics.collection_interval'dan (%s) kısa, girdiler her toplama arasında bayat sayılır", staleness.MaxAge, interval))
+	}
+	switch cfg.HistoryStore.Backend {
+	case "", store.BackendMemory, store.BackendFile, store.BackendBolt, store.BackendRedis:
+	case store.BackendSQL:
+		if cfg.HistoryStore.SQL.Driver == "" || cfg.HistoryStore.SQL.DSN == "" {
+			issues = append(issues, "history_store.backend sql ama history_store.sql.driver veya dsn boş")
+		}
+	default:
+		issues = append(issues, fmt.Sprintf("history_store.backend bilinmiyor: %q", cfg.HistoryStore.Backend))
+	}
+	switch journalConfig := cfg.Scheduler.Journal; journalConfig.Backend {
+	case "", journal.BackendFile, journal.BackendHistoryStore:
+	default:
+		issues = append(issues, fmt.Sprintf("scheduler.journal.backend bilinmiyor: %q", journalConfig.Backend))
+	}
+	if edge := cfg.Metrics.MQTT; edge.Enabled {
+		if edge.QoS < 0 || edge.QoS > 1 {
+			issues = append(issues, fmt.Sprintf("metrics.mqtt.qos 0 veya 1 olmalı: %d", edge.QoS))
+		}
+		if len(edge.Topics) == 0 {
+			issues = append(issues, "metrics.mqtt açık ama metrics.mqtt.topics boş")
+		}
+		if err := mqtt.Validate(&edge); err != nil {
+			issues = append(issues, fmt.Sprintf("metrics.mqtt: %v", err))
+		}
+	}
+	if logs := cfg.OTLPLogs; logs.Enabled && logs.Endpoint != "" {
+		if parsed, err := url.Parse(logs.Endpoint); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			issues = append(issues, fmt.Sprintf("otlp_logs.endpoint geçersiz: %q", logs.Endpoint))
+		}
+	}
+	if cfg.Handoff.Enabled && len(cfg.Handoff.Peers) == 0 {
+		issues = append(issues, "handoff açık ama handoff.peers boş")
+	}
+
+	if len(issues) > 0 {
+		return StatusFail, strings.Join(issues, "; ")
+	}
+	return StatusPass, ""
+}
+
+// hasProfile profiles içinde name adlı profil varsa true döner
+func hasProfile(profiles []types.SchedulingProfile, name string) bool {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// permissions konfigürasyonun gerektirdiği Kubernetes API yetkileri
+func (d *Doctor) permissions() []permission {
+	perms := []permission{
+		{"", "nodes", "", "list", ""},
+		{"", "nodes", "", "watch", ""},
+		{"", "pods", "", "list", ""},
+		{"", "pods", "", "watch", ""},
+		{"", "services", "", "list", ""},
+		{"", "persistentvolumeclaims", "", "get", ""},
+		{"", "persistentvolumes", "", "get", ""},
+		{"storage.k8s.io", "csinodes", "", "list", ""},
+		{"policy", "poddisruptionbudgets", "", "list", ""},
+		{"metrics.k8s.io", "nodes", "", "get", ""},
+		{"metrics.k8s.io", "pods", "", "list", ""},
+	}
+	if d.config.Scheduler.Binding.Enabled || (d.config.Server.Extender.Enabled && d.config.Server.Extender.Bind) {
+		perms = append(perms, permission{"", "pods", "binding", "create", ""})
+	}
+	if binding := d.config.Scheduler.Binding; binding.Enabled {
+		namespace := binding.LeaderElection.Namespace
+		if namespace == "" {
+			namespace = "kube-system"
+		}
+		for _, verb := range []string{"get", "create", "update"} {
+			perms = append(perms, permission{"coordination.k8s.io", "leases", "", verb, namespace})
+		}
+	}
+	if remediation := d.config.Scheduler.Remediation; remediation.Enabled && remediation.Mode == scheduler.RemediationApply {
+		perms = append(perms, permission{"", "nodes", "", "update", ""})
+	}
+	if configMap := d.config.Kubernetes.ConfigMap; configMap.Enabled {
+		perms = append(perms,
+			permission{"", "configmaps", "", "get", configMap.Namespace},
+			permission{"", "configmaps", "", "watch", configMap.Namespace})
+	}
+	secrets := d.config.Secrets
+	for _, ref := range []types.SecretKeyRef{secrets.AIAPIToken, secrets.TLS, secrets.WebhookSigningKey, secrets.IngestToken} {
+		if ref.Name != "" {
+			perms = append(perms, permission{"", "secrets", "", "get", secrets.Namespace})
+			break
+		}
+	}
+	return perms
+}
+
+// checkRBAC scheduler'ın service account'unun gerekli yetkilere sahip olduğunu SelfSubjectAccessReview ile denetler
+func (d *Doctor) checkRBAC(ctx context.Context) (string, string) {
+	if d.config.Development.MockData {
+		return StatusSkip, "development.mock_data açık, sentetik küme kullanılıyor"
+	}
+	clientset := d.k8sClient.GetClientset()
+	if clientset == nil {
+		return StatusFail, "Kubernetes client yok"
+	}
+
+	var denied []string
+	for _, perm := range d.permissions() {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Group:       perm.group,
+					Resource:    perm.resource,
+					Subresource: perm.subresource,
+					Verb:        perm.verb,
+					Namespace:   perm.namespace,
+				},
+			},
+		}
+		result, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return StatusFail, fmt.Sprintf("yetki denetlenemedi: %v", err)
+		}
+		if !result.Status.Allowed {
+			resource := perm.resource
+			if perm.subresource != "" {
+				resource += "/" + perm.subresource
+			}
+			if perm.group != "" {
+				resource += "." + perm.group
+			}
+			denied = append(denied, perm.verb+" "+resource)
+		}
+	}
+	if len(denied) > 0 {
+		return StatusFail, "eksik yetkiler: " + strings.Join(denied, ", ")
+	}
+	return StatusPass, fmt.Sprintf("%d yetki var", len(d.permissions()))
+}
+
+// checkMetricsServer Metrics API'nin node metriği sunduğunu denetler
+func (d *Doctor) checkMetricsServer(ctx context.Context) (string, string) {
+	if d.config.Development.MockData {
+		return StatusSkip, "development.mock_data açık, kullanım sentetik kümeden okunuyor"
+	}
+	metricsClient, err := types.NewMetricsClient(d.k8sClient)
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	nodes, err := metricsClient.Ping(ctx)
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	if nodes == 0 {
+		return StatusWarn, "metrics-server hiçbir node için metrik döndürmedi"
+	}
+	return StatusPass, fmt.Sprintf("%d node için metrik var", nodes)
+}
+
+// checkAIService AI servisinin /health endpoint'ine ulaşılabildiğini denetler. Kademeli bozulma açıksa AI'sız
+// çalışılabildiğinden ulaşılamaması uyarıdır
+func (d *Doctor) checkAIService(ctx context.Context) (string, string) {
+	if d.config.Scheduler.Mode == scheduler.ModeHeuristic {
+		return StatusSkip, "scheduler.mode heuristic"
+	}
+	unavailable := StatusFail
+	if d.config.Scheduler.Degradation.Enabled {
+		unavailable = StatusWarn
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(d.config.Scheduler.AIAPIURL, "/")+"/health", nil)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("istek oluşturulamadı: %v", err)
+	}
+	token := d.config.Scheduler.AIAPIToken
+	if d.secretStore != nil {
+		if secret := d.secretStore.AIAPIToken(); secret != "" {
+			token = secret
+		}
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return unavailable, fmt.Sprintf("AI servisine ulaşılamadı: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return unavailable, fmt.Sprintf("AI servisi %d döndürdü", resp.StatusCode)
+	}
+	return StatusPass, fmt.Sprintf("%s içinde cevap verdi", time.Since(start).Round(time.Millisecond))
+}
+
+// checkCertificate server TLS sertifikasının (dosyadan veya Secret'tan) yüklenebildiğini ve süresinin
+// dolmadığını denetler
+func (d *Doctor) checkCertificate(context.Context) (string, string) {
+	tlsConfig := d.config.Server.TLS
+	if !tlsConfig.Enabled {
+		return StatusSkip, "server.tls kapalı"
+	}
+
+	var cert *tls.Certificate
+	var err error
+	if d.secretStore != nil && d.secretStore.HasCertificate() {
+		cert, err = d.secretStore.GetCertificate(nil)
+	} else {
+		var loaded tls.Certificate
+		loaded, err = tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		cert = &loaded
+	}
+	if err != nil {
+		return StatusFail, fmt.Sprintf("sertifika yüklenemedi: %v", err)
+	}
+	leaf := cert.Leaf
+	if leaf == nil {
+		if leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return StatusFail, fmt.Sprintf("sertifika parse edilemedi: %v", err)
+		}
+	}
+
+	remaining := time.Until(leaf.NotAfter)
+	switch {
+	case remaining <= 0:
+		return StatusFail, fmt.Sprintf("sertifikanın süresi %s tarihinde doldu", leaf.NotAfter.Format(time.RFC3339))
+	case remaining < certificateWarnIn:
+		return StatusWarn, fmt.Sprintf("sertifikanın süresi %s tarihinde doluyor", leaf.NotAfter.Format(time.RFC3339))
+	}
+	return StatusPass, fmt.Sprintf("%s tarihine kadar geçerli", leaf.NotAfter.Format(time.RFC3339))
+}
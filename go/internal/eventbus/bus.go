@@ -0,0 +1,310 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Olay yolunun desteklediği backend'ler ve serileştirmeler
+const (
+	BackendNATS  = "nats"
+	BackendKafka = "kafka"
+
+	SerializationJSON        = "json"
+	SerializationCloudEvents = "cloudevents"
+)
+
+// Olay yolunun varsayılanları
+const (
+	defaultBufferSize       = 10000
+	defaultBatchSize        = 100
+	defaultFlushInterval    = time.Second
+	defaultMetricsTopic     = "ai-scheduler.metrics"
+	defaultDecisionsTopic   = "ai-scheduler.decisions"
+	cloudEventsSource       = "ai-scheduler"
+	cloudEventsTypePrefix   = "io.ai-scheduler."
+	droppedLogInterval      = time.Minute
+	defaultTransportTimeout = 5 * time.Second
+)
+
+// Message topic'e yayınlanacak serileştirilmiş olay
+type Message struct {
+	Key   string
+	Value []byte
+}
+
+// Transport mesajları bir topic'e toplu olarak yayınlar (NATS veya Kafka)
+type Transport interface {
+	Publish(ctx context.Context, topic string, messages []Message) error
+	Close() error
+}
+
+// Stats olay yolunun sayaçları
+type Stats struct {
+	Published uint64 `json:"published"`
+	Dropped   uint64 `json:"dropped"` // Tampon dolu olduğu için atılan olaylar
+	Failed    uint64 `json:"failed"`  // Gönderilemeyen olaylar
+}
+
+// Bus metrik ve karar olaylarını tamponlayıp arka planda Kafka veya NATS'e yayınlar.
+// Publish hiçbir zaman beklemez, tampon doluysa olay atılır
+type Bus struct {
+	config      *types.EventBusConfig
+	configMu    sync.RWMutex
+	enabled     atomic.Bool
+	events      chan types.Event
+	transport   Transport
+	transportMu sync.Mutex
+	sequence    atomic.Uint64
+	published   atomic.Uint64
+	dropped     atomic.Uint64
+	failed      atomic.Uint64
+}
+
+// NewBus yeni olay yolu oluşturur, bağlantı ilk gönderimde kurulur
+func NewBus(busConfig *types.EventBusConfig) *Bus {
+	cfg := *busConfig
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	b := &Bus{config: &cfg, events: make(chan types.Event, size)}
+	b.enabled.Store(cfg.Enabled)
+	return b
+}
+
+// UpdateConfig konfigürasyonu çalışma anında değiştirir. Backend veya adres değiştiyse
+// bağlantı sonraki gönderimde yeni ayarlarla kurulur; tampon boyutu yeniden başlatmada değişir
+func (b *Bus) UpdateConfig(busConfig *types.EventBusConfig) {
+	cfg := *busConfig
+
+	b.configMu.Lock()
+	previous := b.config
+	b.config = &cfg
+	reconnect := previous.Backend != cfg.Backend || previous.NATS != cfg.NATS || previous.Kafka != cfg.Kafka
+	b.configMu.Unlock()
+	b.enabled.Store(cfg.Enabled)
+
+	if reconnect {
+		b.resetTransport()
+	}
+}
+
+// currentConfig varsayılanları uygulanmış geçerli konfigürasyonu döndürür
+func (b *Bus) currentConfig() types.EventBusConfig {
+	b.configMu.RLock()
+	cfg := *b.config
+	b.configMu.RUnlock()
+
+	if cfg.Backend == "" {
+		cfg.Backend = BackendNATS
+	}
+	if cfg.Serialization == "" {
+		cfg.Serialization = SerializationJSON
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+	if cfg.Topics.Metrics == "" {
+		cfg.Topics.Metrics = defaultMetricsTopic
+	}
+	if cfg.Topics.Decisions == "" {
+		cfg.Topics.Decisions = defaultDecisionsTopic
+	}
+	return cfg
+}
+
+// Publish olayı tampona ekler, olay yolu kapalıysa veya tampon doluysa atar
+func (b *Bus) Publish(event types.Event) {
+	if !b.enabled.Load() {
+		return
+	}
+
+	select {
+	case b.events <- event:
+	default:
+		b.dropped.Add(1)
+	}
+}
+
+// Stats yayınlanan, atılan ve gönderilemeyen olay sayılarını döndürür
+func (b *Bus) Stats() Stats {
+	return Stats{Published: b.published.Load(), Dropped: b.dropped.Load(), Failed: b.failed.Load()}
+}
+
+// Start tampondaki olayları topic başına toplayıp batch dolunca veya flush aralığında yayınlar
+func (b *Bus) Start(ctx context.Context) {
+	cfg := b.currentConfig()
+	ticker := time.NewTicker(cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batches := make(map[string][]Message)
+	pending := 0
+	var lastDropped uint64
+	lastDropLog := time.Now()
+
+	flush := func() {
+		for topic, messages := range batches {
+			if len(messages) > 0 {
+				b.send(ctx, &cfg, topic, messages)
+			}
+			delete(batches, topic)
+		}
+		pending = 0
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			b.resetTransport()
+			return
+		case event := <-b.events:
+			cfg = b.currentConfig()
+			topic := cfg.Topics.Metrics
+			if event.Kind == types.EventDecision {
+				topic = cfg.Topics.Decisions
+			}
+			message, err := b.encode(&cfg, event)
+			if err != nil {
+				logrus.Warnf("Olay serileştirilemedi (%s): %v", event.Kind, err)
+				b.failed.Add(1)
+				continue
+			}
+			batches[topic] = append(batches[topic], message)
+			pending++
+			if len(batches[topic]) >= cfg.BatchSize {
+				b.send(ctx, &cfg, topic, batches[topic])
+				pending -= len(batches[topic])
+				delete(batches, topic)
+			}
+		case <-ticker.C:
+			if pending > 0 {
+				flush()
+			}
+			if next := b.currentConfig().FlushInterval; next != cfg.FlushInterval {
+				cfg.FlushInterval = next
+				ticker.Reset(next)
+			}
+			if dropped := b.dropped.Load(); dropped != lastDropped && time.Since(lastDropLog) >= droppedLogInterval {
+				logrus.Warnf("Olay yolu tamponu dolu, %d olay atıldı", dropped-lastDropped)
+				lastDropped, lastDropLog = dropped, time.Now()
+			}
+		}
+	}
+}
+
+// send batch'i yayınlar, hata olursa bağlantı sonraki gönderimde yeniden kurulur
+func (b *Bus) send(ctx context.Context, cfg *types.EventBusConfig, topic string, messages []Message) {
+	transport, err := b.currentTransport(cfg)
+	if err == nil {
+		err = transport.Publish(ctx, topic, messages)
+	}
+	if err != nil {
+		logrus.Warnf("%d olay %s topic'ine yayınlanamadı (%s): %v", len(messages), topic, cfg.Backend, err)
+		b.failed.Add(uint64(len(messages)))
+		b.resetTransport()
+		return
+	}
+	b.published.Add(uint64(len(messages)))
+}
+
+// currentTransport mevcut bağlantıyı döndürür, yoksa konfigürasyondaki backend'e göre oluşturur
+func (b *Bus) currentTransport(cfg *types.EventBusConfig) (Transport, error) {
+	b.transportMu.Lock()
+	defer b.transportMu.Unlock()
+
+	if b.transport != nil {
+		return b.transport, nil
+	}
+
+	var transport Transport
+	var err error
+	switch cfg.Backend {
+	case BackendNATS:
+		transport, err = dialNATS(&cfg.NATS)
+	case BackendKafka:
+		transport, err = newKafkaREST(&cfg.Kafka)
+	default:
+		err = fmt.Errorf("bilinmeyen olay yolu backend'i: %s", cfg.Backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.transport = transport
+	return transport, nil
+}
+
+// resetTransport mevcut bağlantıyı kapatır
+func (b *Bus) resetTransport() {
+	b.transportMu.Lock()
+	transport := b.transport
+	b.transport = nil
+	b.transportMu.Unlock()
+
+	if transport != nil {
+		if err := transport.Close(); err != nil {
+			logrus.Debugf("Olay yolu bağlantısı kapatılamadı: %v", err)
+		}
+	}
+}
+
+// envelope json serileştirmesinin zarfı
+type envelope struct {
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Key  string      `json:"key"`
+	Data interface{} `json:"data"`
+}
+
+// cloudEvent CloudEvents 1.0 structured JSON
+type cloudEvent struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// encode olayı konfigürasyondaki serileştirmeyle mesaja çevirir
+func (b *Bus) encode(cfg *types.EventBusConfig, event types.Event) (Message, error) {
+	var value interface{}
+	switch cfg.Serialization {
+	case SerializationJSON:
+		value = envelope{Type: event.Kind, Time: event.Time, Key: event.Key, Data: event.Data}
+	case SerializationCloudEvents:
+		value = cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              strconv.FormatInt(event.Time.UnixNano(), 36) + "-" + strconv.FormatUint(b.sequence.Add(1), 36),
+			Source:          cloudEventsSource,
+			Type:            cloudEventsTypePrefix + event.Kind,
+			Subject:         event.Key,
+			Time:            event.Time,
+			DataContentType: "application/json",
+			Data:            event.Data,
+		}
+	default:
+		return Message{}, fmt.Errorf("bilinmeyen serileştirme: %s", cfg.Serialization)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Key: event.Key, Value: data}, nil
+}
@@ -0,0 +1,85 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"ai-scheduler/internal/types"
+)
+
+// kafkaContentType Kafka REST Proxy v2 JSON gömülü formatı
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaREST mesajları Kafka REST Proxy üzerinden topic'e yazar
+type kafkaREST struct {
+	baseURL string
+	client  *http.Client
+}
+
+// kafkaRecord REST Proxy'ye gönderilen kayıt, value olay JSON'unun kendisidir
+type kafkaRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// newKafkaREST REST Proxy adresini doğrular, bağlantı her istekte HTTP ile kurulur
+func newKafkaREST(cfg *types.KafkaConfig) (Transport, error) {
+	if cfg.RESTURL == "" {
+		return nil, fmt.Errorf("kafka rest_url tanımlı değil")
+	}
+	if _, err := url.Parse(cfg.RESTURL); err != nil {
+		return nil, fmt.Errorf("geçersiz kafka rest_url: %v", err)
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTransportTimeout
+	}
+	return &kafkaREST{
+		baseURL: strings.TrimSuffix(cfg.RESTURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Publish batch'i tek istekte topic'e yazar
+func (k *kafkaREST) Publish(ctx context.Context, topic string, messages []Message) error {
+	records := make([]kafkaRecord, len(messages))
+	for i, message := range messages {
+		records[i] = kafkaRecord{Key: message.Key, Value: message.Value}
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return fmt.Errorf("kayıtlar JSON'a çevrilemedi: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.baseURL+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("kafka REST proxy hata döndürdü: %d %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// Close HTTP bağlantıları paylaşıldığı için boşta olanları kapatır
+func (k *kafkaREST) Close() error {
+	k.client.CloseIdleConnections()
+	return nil
+}
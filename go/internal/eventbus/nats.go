@@ -0,0 +1,207 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultNATSPort URL'de port yoksa kullanılır
+const defaultNATSPort = "4222"
+
+// natsConn NATS istemci protokolünün yayınlama için gereken alt kümesi (CONNECT, PUB, PING/PONG)
+type natsConn struct {
+	conn    net.Conn
+	writer  *bufio.Writer
+	writeMu sync.Mutex
+	timeout time.Duration
+	closed  chan struct{}
+	errMu   sync.Mutex
+	err     error // Okuma döngüsünün gördüğü bağlantı hatası
+}
+
+// natsConnect CONNECT komutunun gövdesi
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	AuthToken string `json:"auth_token,omitempty"`
+	User      string `json:"user,omitempty"`
+	Password  string `json:"pass,omitempty"`
+}
+
+// dialNATS sunucuya bağlanır, kimlik doğrular ve PING/PONG ile bağlantıyı doğrular
+func dialNATS(cfg *types.NATSConfig) (Transport, error) {
+	address, err := natsAddress(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTransportTimeout
+	}
+
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("NATS sunucusuna bağlanılamadı: %v", err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	info, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("NATS INFO okunamadı: %v", err)
+	}
+	if !strings.HasPrefix(info, "INFO ") {
+		conn.Close()
+		return nil, fmt.Errorf("beklenmeyen NATS karşılaması: %s", strings.TrimSpace(info))
+	}
+
+	connect, err := json.Marshal(natsConnect{
+		Name:      cloudEventsSource,
+		Lang:      "go",
+		AuthToken: cfg.Token,
+		User:      cfg.User,
+		Password:  cfg.Password,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("NATS CONNECT gönderilemedi: %v", err)
+	}
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("NATS yanıtı okunamadı: %v", err)
+	}
+	if reply = strings.TrimSpace(reply); reply != "PONG" {
+		conn.Close()
+		return nil, fmt.Errorf("NATS bağlantısı reddedildi: %s", reply)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	n := &natsConn{conn: conn, writer: bufio.NewWriter(conn), timeout: timeout, closed: make(chan struct{})}
+	go n.readLoop(reader)
+	return n, nil
+}
+
+// natsAddress nats://host:port veya host:port adresini çözer
+func natsAddress(raw string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("nats url tanımlı değil")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "nats://" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("geçersiz nats url: %v", err)
+	}
+	if parsed.Scheme != "nats" && parsed.Scheme != "tcp" {
+		return "", fmt.Errorf("desteklenmeyen nats url şeması: %s", parsed.Scheme)
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = defaultNATSPort
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), nil
+}
+
+// readLoop sunucunun PING'lerine yanıt verir ve -ERR mesajlarını bağlantı hatası olarak saklar
+func (n *natsConn) readLoop(reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			select {
+			case <-n.closed:
+			default:
+				n.setErr(fmt.Errorf("NATS bağlantısı kapandı: %v", err))
+			}
+			return
+		}
+
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PING":
+			n.writeMu.Lock()
+			_, err = n.writer.WriteString("PONG\r\n")
+			if err == nil {
+				err = n.writer.Flush()
+			}
+			n.writeMu.Unlock()
+			if err != nil {
+				n.setErr(err)
+				return
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			logrus.Warnf("NATS sunucusu hata döndürdü: %s", line)
+			n.setErr(fmt.Errorf("NATS sunucusu hata döndürdü: %s", line))
+		}
+	}
+}
+
+// setErr ilk bağlantı hatasını saklar
+func (n *natsConn) setErr(err error) {
+	n.errMu.Lock()
+	defer n.errMu.Unlock()
+
+	if n.err == nil {
+		n.err = err
+	}
+}
+
+// Publish mesajları subject'e PUB ile yazar. NATS mesaj anahtarı taşımadığı için anahtar olay gövdesinde kalır
+func (n *natsConn) Publish(ctx context.Context, topic string, messages []Message) error {
+	n.errMu.Lock()
+	err := n.err
+	n.errMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(n.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	n.writeMu.Lock()
+	defer n.writeMu.Unlock()
+
+	if err := n.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	for _, message := range messages {
+		n.writer.WriteString("PUB " + topic + " " + strconv.Itoa(len(message.Value)) + "\r\n")
+		n.writer.Write(message.Value)
+		n.writer.WriteString("\r\n")
+	}
+	return n.writer.Flush()
+}
+
+// Close bağlantıyı kapatır, okuma döngüsü sonlanır
+func (n *natsConn) Close() error {
+	close(n.closed)
+	return n.conn.Close()
+}
@@ -0,0 +1,53 @@
+package extmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"ai-scheduler/internal/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// kube-apiserver'ın aggregated API'lere front-proxy istemci sertifikasını doğrulamaları için yayınladığı ConfigMap
+const (
+	authenticationNamespace = "kube-system"
+	authenticationConfigMap = "extension-apiserver-authentication"
+	requestHeaderCAKey      = "requestheader-client-ca-file"
+	requestHeaderNamesKey   = "requestheader-allowed-names"
+)
+
+// AggregatorClientCA aggregator'ın istemci sertifikasını doğrulamak için CA paketini ve izinli ortak adları
+// döndürür. ClientCAFile verilmişse dosyadan okunur (izinli adlar AllowedNames), yoksa kube-system'deki
+// extension-apiserver-authentication ConfigMap'inden (requestheader-client-ca-file, requestheader-allowed-names)
+func AggregatorClientCA(ctx context.Context, k8sClient *types.K8sClient, metricsConfig *types.ExternalMetricsConfig) ([]byte, []string, error) {
+	if metricsConfig.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(metricsConfig.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregator CA dosyası okunamadı: %v", err)
+		}
+		return caPEM, metricsConfig.AllowedNames, nil
+	}
+
+	if k8sClient == nil || k8sClient.GetClientset() == nil {
+		return nil, nil, fmt.Errorf("kubernetes client yok, %s ConfigMap'i okunamıyor", authenticationConfigMap)
+	}
+	cm, err := k8sClient.GetClientset().CoreV1().ConfigMaps(authenticationNamespace).Get(ctx, authenticationConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s/%s okunamadı: %v", authenticationNamespace, authenticationConfigMap, err)
+	}
+	caPEM := cm.Data[requestHeaderCAKey]
+	if caPEM == "" {
+		return nil, nil, fmt.Errorf("%s içinde %s yok, kube-apiserver --requestheader-client-ca-file ile çalışmalı", authenticationConfigMap, requestHeaderCAKey)
+	}
+
+	names := metricsConfig.AllowedNames
+	if len(names) == 0 && cm.Data[requestHeaderNamesKey] != "" {
+		if err := json.Unmarshal([]byte(cm.Data[requestHeaderNamesKey]), &names); err != nil {
+			return nil, nil, fmt.Errorf("%s okunamadı: %v", requestHeaderNamesKey, err)
+		}
+	}
+	return []byte(caPEM), names, nil
+}
@@ -0,0 +1,149 @@
+package extmetrics
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	externalmetrics "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+)
+
+// GroupVersion sunulan Kubernetes external metrics API'si
+const GroupVersion = "external.metrics.k8s.io/v1beta1"
+
+// Sunulan external metrikler, her node için bir seri üretilir
+const (
+	MetricNodeStability      = "ai_scheduler_node_stability"
+	MetricNodeForecastCPU    = "ai_scheduler_node_forecast_cpu_utilization"
+	MetricNodeForecastMemory = "ai_scheduler_node_forecast_memory_utilization"
+)
+
+// NodeLabel serinin ait olduğu node'u taşıyan metrik label'ı (node label'larına ek olarak)
+const NodeLabel = "node"
+
+// metricNames discovery'de listelenen metrikler
+var metricNames = []string{MetricNodeStability, MetricNodeForecastCPU, MetricNodeForecastMemory}
+
+// ErrUnknownMetric istenen external metrik sunulmuyor
+var ErrUnknownMetric = errors.New("bilinmeyen external metrik")
+
+// SignalSource external metriklerin okuduğu scheduler sinyalleri
+type SignalSource interface {
+	NodeSignals(horizon time.Duration) ([]scheduler.NodeSignal, error)
+}
+
+// Provider scheduler'ın node kararlılık ve tahmin sinyallerini HPA'ların okuyabileceği external metrikler olarak sunar.
+// Metrikler küme seviyesindedir, istekteki namespace değerleri etkilemez; seriler label selector ile node label'larına göre seçilir
+type Provider struct {
+	source   SignalSource
+	config   *types.ExternalMetricsConfig
+	configMu sync.RWMutex
+}
+
+// NewProvider yeni external metrics sağlayıcısı oluşturur
+func NewProvider(source SignalSource, metricsConfig *types.ExternalMetricsConfig) *Provider {
+	cfg := *metricsConfig
+	return &Provider{source: source, config: &cfg}
+}
+
+// UpdateConfig konfigürasyonu çalışma anında değiştirir
+func (p *Provider) UpdateConfig(metricsConfig *types.ExternalMetricsConfig) {
+	cfg := *metricsConfig
+
+	p.configMu.Lock()
+	p.config = &cfg
+	p.configMu.Unlock()
+}
+
+// forecastHorizon tahmin metriklerinin kapsadığı süreyi döndürür, 0 ise scheduler'ın tahmin horizon'u kullanılır
+func (p *Provider) forecastHorizon() time.Duration {
+	p.configMu.RLock()
+	defer p.configMu.RUnlock()
+
+	return p.config.ForecastHorizon
+}
+
+// APIResources API discovery için sunulan metrikleri döndürür
+func (p *Provider) APIResources() *metav1.APIResourceList {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: GroupVersion,
+		APIResources: make([]metav1.APIResource, len(metricNames)),
+	}
+	for i, name := range metricNames {
+		list.APIResources[i] = metav1.APIResource{
+			Name:       name,
+			Namespaced: true,
+			Kind:       "ExternalMetricValueList",
+			Verbs:      metav1.Verbs{"get"},
+		}
+	}
+	return list
+}
+
+// GetExternalMetric metriğin selector'a uyan node serilerini döndürür.
+// Tahmin metriklerinde değer horizon içindeki tepe kullanım oranıdır, geçmişi yetersiz node'lar atlanır
+func (p *Provider) GetExternalMetric(metricName string, selector labels.Selector) (*externalmetrics.ExternalMetricValueList, error) {
+	var value func(signal *scheduler.NodeSignal) (float64, bool)
+	switch metricName {
+	case MetricNodeStability:
+		value = func(signal *scheduler.NodeSignal) (float64, bool) { return signal.Stability, true }
+	case MetricNodeForecastCPU:
+		value = func(signal *scheduler.NodeSignal) (float64, bool) {
+			if signal.Forecast == nil {
+				return 0, false
+			}
+			return signal.Forecast.PeakCPU, true
+		}
+	case MetricNodeForecastMemory:
+		value = func(signal *scheduler.NodeSignal) (float64, bool) {
+			if signal.Forecast == nil {
+				return 0, false
+			}
+			return signal.Forecast.PeakMemory, true
+		}
+	default:
+		return nil, fmt.Errorf("%s: %w", metricName, ErrUnknownMetric)
+	}
+
+	signals, err := p.source.NodeSignals(p.forecastHorizon())
+	if err != nil {
+		return nil, fmt.Errorf("node sinyalleri alınamadı: %v", err)
+	}
+
+	now := metav1.Now()
+	list := &externalmetrics.ExternalMetricValueList{
+		TypeMeta: metav1.TypeMeta{Kind: "ExternalMetricValueList", APIVersion: GroupVersion},
+		Items:    make([]externalmetrics.ExternalMetricValue, 0, len(signals)),
+	}
+	for i := range signals {
+		signal := &signals[i]
+		metricLabels := make(map[string]string, len(signal.Node.Labels)+1)
+		for key, labelValue := range signal.Node.Labels {
+			metricLabels[key] = labelValue
+		}
+		metricLabels[NodeLabel] = signal.Node.Name
+		if !selector.Matches(labels.Set(metricLabels)) {
+			continue
+		}
+
+		v, ok := value(signal)
+		if !ok {
+			continue
+		}
+		list.Items = append(list.Items, externalmetrics.ExternalMetricValue{
+			MetricName:   metricName,
+			MetricLabels: metricLabels,
+			Timestamp:    now,
+			Value:        *resource.NewMilliQuantity(int64(v*1000), resource.DecimalSI),
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,136 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Deneysel davranışları açıp kapatan feature flag isimleri
+const (
+	// AIBlending AI skorunu heuristik skorla harmanlayarak karar verir
+	AIBlending = "ai_blending"
+	// Preemption uygun node yoksa düşük öncelikli pod'ların yerinden edilmesine izin verir: kapasite/yük yüzünden
+	// elenen node'lar kube-scheduler'a preemption ile çözülebilir bildirilir
+	Preemption = "preemption"
+	// Descheduling kararsız node'lardaki pod'ların taşınmasına izin verir
+	Descheduling = "descheduling"
+	// ShadowMode AI harmanlamasını hesaplar ve loglar, karara yansıtmaz
+	ShadowMode = "shadow_mode"
+)
+
+// knownFlags desteklenen flag'ler ve açıklamaları
+var knownFlags = map[string]string{
+	AIBlending:   "AI skorunu heuristik skorla harmanla",
+	Preemption:   "Uygun node yoksa düşük öncelikli pod'ları yerinden et",
+	Descheduling: "Kararsız node'lardaki pod'ları taşı",
+	ShadowMode:   "AI kararını hesapla ve logla, uygulama",
+}
+
+// FlagState flag'in anlık durumu
+type FlagState struct {
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+	Description string `json:"description"`
+	Source      string `json:"source"` // default, config veya runtime
+}
+
+// Gate feature flag durumlarını tutar, thread-safe
+type Gate struct {
+	mutex     sync.RWMutex
+	config    map[string]bool
+	overrides map[string]bool
+}
+
+// NewGate konfigürasyondaki değerlerle yeni flag gate'i oluşturur
+func NewGate(flags map[string]bool) *Gate {
+	g := &Gate{
+		config:    make(map[string]bool),
+		overrides: make(map[string]bool),
+	}
+	g.Load(flags)
+	return g
+}
+
+// Load konfigürasyon değerlerini yükler, çalışma anındaki override'lar korunur
+func (g *Gate) Load(flags map[string]bool) {
+	config := make(map[string]bool)
+	for name, enabled := range flags {
+		if _, ok := knownFlags[name]; !ok {
+			logrus.Warnf("Bilinmeyen feature flag yok sayıldı: %s", name)
+			continue
+		}
+		config[name] = enabled
+	}
+
+	g.mutex.Lock()
+	g.config = config
+	g.mutex.Unlock()
+}
+
+// Enabled flag açıksa true döner, nil gate için tüm flag'ler kapalıdır
+func (g *Gate) Enabled(name string) bool {
+	if g == nil {
+		return false
+	}
+
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if enabled, ok := g.overrides[name]; ok {
+		return enabled
+	}
+	return g.config[name]
+}
+
+// Set flag'i çalışma anında açar veya kapatır
+func (g *Gate) Set(name string, enabled bool) error {
+	if _, ok := knownFlags[name]; !ok {
+		return fmt.Errorf("bilinmeyen feature flag: %s", name)
+	}
+
+	g.mutex.Lock()
+	g.overrides[name] = enabled
+	g.mutex.Unlock()
+
+	logrus.Infof("Feature flag %s çalışma anında %t yapıldı", name, enabled)
+	return nil
+}
+
+// Reset çalışma anındaki override'ı kaldırır, konfigürasyon değeri geçerli olur
+func (g *Gate) Reset(name string) error {
+	if _, ok := knownFlags[name]; !ok {
+		return fmt.Errorf("bilinmeyen feature flag: %s", name)
+	}
+
+	g.mutex.Lock()
+	delete(g.overrides, name)
+	g.mutex.Unlock()
+
+	return nil
+}
+
+// Snapshot tüm flag'lerin durumunu isme göre sıralı döndürür
+func (g *Gate) Snapshot() []FlagState {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	states := make([]FlagState, 0, len(knownFlags))
+	for name, description := range knownFlags {
+		state := FlagState{Name: name, Description: description, Source: "default"}
+		if enabled, ok := g.config[name]; ok {
+			state.Enabled = enabled
+			state.Source = "config"
+		}
+		if enabled, ok := g.overrides[name]; ok {
+			state.Enabled = enabled
+			state.Source = "runtime"
+		}
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
@@ -0,0 +1,259 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+)
+
+// Federasyon varsayılanları
+const (
+	defaultClusterName     = "local"
+	defaultTimeout         = 5 * time.Second
+	defaultCapacityWeight  = 0.4
+	defaultCostWeight      = 0.3
+	defaultStabilityWeight = 0.2
+	defaultNodeWeight      = 0.1
+)
+
+// PlacePath üye kümelerin yerleşim özeti endpoint'i
+const PlacePath = "/api/v1/federation/place"
+
+// ErrNoCluster hiçbir küme iş yükünü tüm replikalarıyla alamıyor
+var ErrNoCluster = errors.New("iş yükü için uygun küme yok")
+
+// PlacementSource kümenin kendi yerleşim özetini hesaplayan scheduler
+type PlacementSource interface {
+	PlaceWorkload(spec *scheduler.WorkloadSpec) (*scheduler.ClusterPlacement, error)
+}
+
+// ClusterCandidate kümenin yerleşim özeti ve federasyon skoru
+type ClusterCandidate struct {
+	Cluster    string                      `json:"cluster"`
+	Placement  *scheduler.ClusterPlacement `json:"placement,omitempty"`
+	Eligible   bool                        `json:"eligible"`    // En iyi node var ve tüm replikalar sığıyor
+	HourlyCost float64                     `json:"hourly_cost"` // İş yükünün bu kümedeki saatlik maliyeti
+	Score      float64                     `json:"score"`
+	Error      string                      `json:"error,omitempty"`
+}
+
+// Decision seçilen küme ve node ile tüm adayların karşılaştırması
+type Decision struct {
+	Cluster    string             `json:"cluster"`
+	Node       string             `json:"node"`
+	Score      float64            `json:"score"`
+	Candidates []ClusterCandidate `json:"candidates"` // Uygun adaylar yüksek skor önce
+}
+
+// Federator iş yükü için üye kümelerin yerleşim özetlerini toplar, toplam kapasite, maliyet ve
+// kararlılığa göre hedef kümeyi ve o kümedeki node'u seçer
+type Federator struct {
+	local    PlacementSource
+	client   *http.Client
+	config   *types.FederationConfig
+	configMu sync.RWMutex
+}
+
+// NewFederator yeni federasyon katmanı oluşturur, local bu kümenin scheduler'ıdır
+func NewFederator(local PlacementSource, federationConfig *types.FederationConfig) *Federator {
+	cfg := *federationConfig
+	return &Federator{local: local, client: &http.Client{}, config: &cfg}
+}
+
+// UpdateConfig konfigürasyonu çalışma anında değiştirir
+func (f *Federator) UpdateConfig(federationConfig *types.FederationConfig) {
+	cfg := *federationConfig
+
+	f.configMu.Lock()
+	f.config = &cfg
+	f.configMu.Unlock()
+}
+
+// currentConfig varsayılanları uygulanmış geçerli konfigürasyonu döndürür
+func (f *Federator) currentConfig() types.FederationConfig {
+	f.configMu.RLock()
+	cfg := *f.config
+	f.configMu.RUnlock()
+
+	if cfg.ClusterName == "" {
+		cfg.ClusterName = defaultClusterName
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.CapacityWeight <= 0 && cfg.CostWeight <= 0 && cfg.StabilityWeight <= 0 && cfg.NodeWeight <= 0 {
+		cfg.CapacityWeight = defaultCapacityWeight
+		cfg.CostWeight = defaultCostWeight
+		cfg.StabilityWeight = defaultStabilityWeight
+		cfg.NodeWeight = defaultNodeWeight
+	}
+	return cfg
+}
+
+// Predict iş yükü için hedef küme ve node'u seçer. Üye kümeler paralel sorgulanır, cevap vermeyen
+// küme aday listesinde hatasıyla görünür. Hiçbir küme uygun değilse adaylarla birlikte ErrNoCluster döner
+func (f *Federator) Predict(ctx context.Context, spec *scheduler.WorkloadSpec) (*Decision, error) {
+	cfg := f.currentConfig()
+
+	localPlacement, err := f.local.PlaceWorkload(spec)
+	if errors.Is(err, scheduler.ErrInvalidWorkload) {
+		return nil, err
+	}
+
+	candidates := make([]ClusterCandidate, len(cfg.Members)+1)
+	candidates[0] = newCandidate(cfg.ClusterName, localPlacement, err, spec, cfg.CostPerCoreHour, cfg.CostPerGBHour)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range cfg.Members {
+		member := &cfg.Members[i]
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			placement, err := f.remotePlacement(ctx, member, spec)
+			candidates[i+1] = newCandidate(member.Name, placement, err, spec, member.CostPerCoreHour, member.CostPerGBHour)
+		}(i)
+	}
+	wg.Wait()
+
+	scoreCandidates(candidates, spec, &cfg)
+
+	decision := &Decision{Candidates: candidates}
+	if len(candidates) == 0 || !candidates[0].Eligible {
+		return decision, ErrNoCluster
+	}
+	decision.Cluster = candidates[0].Cluster
+	decision.Node = candidates[0].Placement.Node
+	decision.Score = candidates[0].Score
+	return decision, nil
+}
+
+// newCandidate küme adayını oluşturur, uygunluğu ve saatlik maliyeti hesaplar
+func newCandidate(cluster string, placement *scheduler.ClusterPlacement, err error, spec *scheduler.WorkloadSpec, costPerCore, costPerGB float64) ClusterCandidate {
+	candidate := ClusterCandidate{Cluster: cluster, Placement: placement}
+	if err != nil {
+		candidate.Error = err.Error()
+		return candidate
+	}
+
+	replicas := float64(replicaCount(spec))
+	candidate.Eligible = placement.Node != "" && placement.FittingReplicas >= replicaCount(spec)
+	candidate.HourlyCost = replicas * (spec.CPU*costPerCore + spec.Memory*costPerGB)
+	return candidate
+}
+
+// scoreCandidates uygun adayları ağırlıklı skorla puanlar ve sıralar.
+// Kapasite iş yükü eklendikten sonra kalan pay, maliyet en ucuz kümeye oran, node skoru en iyi node skoruna oranla ölçülür
+func scoreCandidates(candidates []ClusterCandidate, spec *scheduler.WorkloadSpec, cfg *types.FederationConfig) {
+	minCost, maxNodeScore := math.Inf(1), 0.0
+	for i := range candidates {
+		if !candidates[i].Eligible {
+			continue
+		}
+		if cost := candidates[i].HourlyCost; cost > 0 && cost < minCost {
+			minCost = cost
+		}
+		if score := candidates[i].Placement.NodeScore; score > maxNodeScore {
+			maxNodeScore = score
+		}
+	}
+
+	replicas := float64(replicaCount(spec))
+	for i := range candidates {
+		candidate := &candidates[i]
+		if !candidate.Eligible {
+			continue
+		}
+		placement := candidate.Placement
+
+		headroom := 1 - math.Max(
+			utilizationAfter(placement.RequestedCPU+replicas*spec.CPU, placement.AllocatableCPU),
+			utilizationAfter(placement.RequestedMemory+replicas*spec.Memory, placement.AllocatableMemory))
+
+		costScore := 1.0
+		if candidate.HourlyCost > 0 && !math.IsInf(minCost, 1) {
+			costScore = minCost / candidate.HourlyCost
+		}
+
+		nodeScore := 0.0
+		if maxNodeScore > 0 && placement.NodeScore > 0 {
+			nodeScore = placement.NodeScore / maxNodeScore
+		}
+
+		candidate.Score = cfg.CapacityWeight*headroom + cfg.CostWeight*costScore +
+			cfg.StabilityWeight*placement.Stability + cfg.NodeWeight*nodeScore
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Eligible != candidates[j].Eligible {
+			return candidates[i].Eligible
+		}
+		return candidates[i].Score > candidates[j].Score
+	})
+}
+
+// utilizationAfter isteklerin kapasiteye oranını 0-1 aralığında döndürür, kapasite bilinmiyorsa 0
+func utilizationAfter(requested, allocatable float64) float64 {
+	if allocatable <= 0 {
+		return 0
+	}
+	return math.Min(math.Max(requested/allocatable, 0), 1)
+}
+
+// replicaCount en az 1 olan replika sayısını döndürür
+func replicaCount(spec *scheduler.WorkloadSpec) int {
+	if spec.Replicas < 1 {
+		return 1
+	}
+	return spec.Replicas
+}
+
+// remotePlacement üye kümenin ai-scheduler'ından yerleşim özetini ister
+func (f *Federator) remotePlacement(ctx context.Context, member *types.FederationMember, spec *scheduler.WorkloadSpec) (*scheduler.ClusterPlacement, error) {
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("request JSON'a çevrilemedi: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(member.URL, "/")+PlacePath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("istek oluşturulamadı: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if member.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+member.Token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("küme %s'e istek gönderilemedi: %v", member.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiError)
+		return nil, fmt.Errorf("küme %s hata döndürdü: %d %s", member.Name, resp.StatusCode, apiError.Error)
+	}
+
+	var placement scheduler.ClusterPlacement
+	if err := json.NewDecoder(resp.Body).Decode(&placement); err != nil {
+		return nil, fmt.Errorf("küme %s cevabı parse edilemedi: %v", member.Name, err)
+	}
+	return &placement, nil
+}
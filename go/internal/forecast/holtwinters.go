@@ -0,0 +1,105 @@
+package forecast
+
+// Holt-Winters parametrelerinin varsayılan değerleri
+const (
+	defaultAlpha = 0.5
+	defaultBeta  = 0.1
+	defaultGamma = 0.3
+)
+
+// HoltWinters toplamsal (additive) üçlü üstel düzeltme modeli.
+// Season dönemdeki örnek sayısıdır (ör: 1h çözünürlükte günlük mevsimsellik için 24)
+type HoltWinters struct {
+	Alpha  float64 // Seviye düzeltme katsayısı (0-1)
+	Beta   float64 // Eğilim düzeltme katsayısı (0-1)
+	Gamma  float64 // Mevsimsellik düzeltme katsayısı (0-1)
+	Season int
+}
+
+// withDefaults aralık dışındaki katsayılar için varsayılanları uygular
+func (hw HoltWinters) withDefaults() HoltWinters {
+	if hw.Alpha <= 0 || hw.Alpha > 1 {
+		hw.Alpha = defaultAlpha
+	}
+	if hw.Beta <= 0 || hw.Beta > 1 {
+		hw.Beta = defaultBeta
+	}
+	if hw.Gamma <= 0 || hw.Gamma > 1 {
+		hw.Gamma = defaultGamma
+	}
+	return hw
+}
+
+// Forecast serinin son örneğinden sonraki steps adımın tahminini döndürür.
+// En az iki tam dönem yoksa mevsimsellik olmadan Holt'un doğrusal yöntemi, tek örnekte son değer kullanılır
+func (hw HoltWinters) Forecast(series []float64, steps int) []float64 {
+	if steps <= 0 || len(series) == 0 {
+		return nil
+	}
+	hw = hw.withDefaults()
+
+	result := make([]float64, steps)
+	switch {
+	case len(series) == 1:
+		for i := range result {
+			result[i] = series[0]
+		}
+	case hw.Season < 2 || len(series) < 2*hw.Season:
+		hw.linear(series, result)
+	default:
+		hw.seasonal(series, result)
+	}
+	return result
+}
+
+// linear Holt'un doğrusal (çift üstel düzeltme) tahmini
+func (hw HoltWinters) linear(series, result []float64) {
+	level := series[0]
+	trend := series[1] - series[0]
+	for _, value := range series[1:] {
+		previous := level
+		level = hw.Alpha*value + (1-hw.Alpha)*(level+trend)
+		trend = hw.Beta*(level-previous) + (1-hw.Beta)*trend
+	}
+
+	for i := range result {
+		result[i] = level + float64(i+1)*trend
+	}
+}
+
+// seasonal toplamsal mevsimsel tahmin, başlangıç değerleri ilk iki dönemden hesaplanır
+func (hw HoltWinters) seasonal(series, result []float64) {
+	season := hw.Season
+	first := mean(series[:season])
+	second := mean(series[season : 2*season])
+
+	level := first
+	trend := (second - first) / float64(season)
+	seasonals := make([]float64, season)
+	for i := 0; i < season; i++ {
+		seasonals[i] = series[i] - first
+	}
+
+	for t := season; t < len(series); t++ {
+		value := series[t]
+		s := t % season
+		previous := level
+		level = hw.Alpha*(value-seasonals[s]) + (1-hw.Alpha)*(level+trend)
+		trend = hw.Beta*(level-previous) + (1-hw.Beta)*trend
+		seasonals[s] = hw.Gamma*(value-level) + (1-hw.Gamma)*seasonals[s]
+	}
+
+	n := len(series)
+	for i := range result {
+		result[i] = level + float64(i+1)*trend + seasonals[(n+i)%season]
+	}
+}
+
+// mean ortalama
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,80 @@
+package forecast
+
+// Seasonality serinin dönemsel düzeni. Profile[i] serinin i. dilimiyle aynı evredeki (i mod Period) dilimlerin
+// ortalamasıdır; Strength dönem gecikmesindeki otokorelasyondur (-1..1)
+type Seasonality struct {
+	Period   int
+	Strength float64
+	Profile  []float64
+}
+
+// At serinin index. dilimiyle (seri bitiminden sonrası dahil) aynı evredeki ortalama değeri döndürür
+func (s Seasonality) At(index int) float64 {
+	if s.Period == 0 {
+		return 0
+	}
+	phase := index % s.Period
+	if phase < 0 {
+		phase += s.Period
+	}
+	return s.Profile[phase]
+}
+
+// DetectSeasonality aday dönemler (dilim sayısı) arasından otokorelasyonu en yüksek olanı bulur. Seride en az
+// minCycles tam dönem olmayan adaylar atlanır; hiçbir aday minStrength'e ulaşmazsa false döner
+func DetectSeasonality(series []float64, candidates []int, minCycles int, minStrength float64) (Seasonality, bool) {
+	if minCycles < 2 {
+		minCycles = 2
+	}
+
+	best := Seasonality{Strength: minStrength}
+	found := false
+	for _, period := range candidates {
+		if period < 2 || len(series) < minCycles*period {
+			continue
+		}
+		strength := Autocorrelation(series, period)
+		if strength < best.Strength || (found && strength == best.Strength) {
+			continue
+		}
+		best = Seasonality{Period: period, Strength: strength}
+		found = true
+	}
+	if !found {
+		return Seasonality{}, false
+	}
+
+	sums := make([]float64, best.Period)
+	counts := make([]int, best.Period)
+	for i, value := range series {
+		sums[i%best.Period] += value
+		counts[i%best.Period]++
+	}
+	best.Profile = make([]float64, best.Period)
+	for i := range sums {
+		best.Profile[i] = sums[i] / float64(counts[i])
+	}
+	return best, true
+}
+
+// Autocorrelation serinin lag gecikmesindeki otokorelasyonunu döndürür. Sabit seride veya lag seri uzunluğunu
+// aşıyorsa 0'dır
+func Autocorrelation(series []float64, lag int) float64 {
+	if lag <= 0 || lag >= len(series) {
+		return 0
+	}
+	average := mean(series)
+
+	var variance, covariance float64
+	for i, value := range series {
+		deviation := value - average
+		variance += deviation * deviation
+		if i >= lag {
+			covariance += deviation * (series[i-lag] - average)
+		}
+	}
+	if variance == 0 {
+		return 0
+	}
+	return covariance / variance
+}
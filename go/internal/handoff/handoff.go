@@ -0,0 +1,94 @@
+package handoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/backup"
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Durum devri varsayılanları
+const (
+	defaultTimeout       = 30 * time.Second
+	defaultRetryInterval = 2 * time.Second
+)
+
+// BackupPath peer'ların durum arşivi endpoint'i
+const BackupPath = "/api/v1/admin/backup"
+
+// ErrNoPeer durum alınabilecek peer yok
+var ErrNoPeer = errors.New("durum alınabilecek replika yok")
+
+// Result devrin özeti
+type Result struct {
+	Peer string `json:"peer"`
+	backup.RestoreResult
+	CreatedAt time.Time `json:"created_at"` // Peer'da arşivin oluşturulduğu an
+}
+
+// Pull peer'lardan ilk cevap verenin durum arşivini alır ve konfigürasyonu uygulamadan geri yükler. Hiçbir peer
+// cevap vermezse timeout dolana kadar RetryInterval aralıkla tekrar dener, sonunda ErrNoPeer döner
+func Pull(ctx context.Context, cfg *types.HandoffConfig, aiScheduler *scheduler.AIScheduler, podCache types.PodCache, history *types.NodeMetricsHistory) (*Result, error) {
+	if len(cfg.Peers) == 0 {
+		return nil, fmt.Errorf("handoff.peers boş: %w", ErrNoPeer)
+	}
+	timeout, retryInterval := cfg.Timeout, cfg.RetryInterval
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := &http.Client{}
+	for {
+		for _, peer := range cfg.Peers {
+			archive, err := fetch(ctx, client, peer, cfg.Token)
+			if err != nil {
+				logrus.Debugf("Replika %s'ten durum alınamadı: %v", peer, err)
+				continue
+			}
+			result := backup.Restore(aiScheduler, podCache, history, archive, false)
+			return &Result{Peer: peer, RestoreResult: result, CreatedAt: archive.Manifest.CreatedAt}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s içinde: %w", timeout, ErrNoPeer)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// fetch peer'ın durum arşivini indirir ve okur
+func fetch(ctx context.Context, client *http.Client, peer, token string) (*backup.Archive, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(peer, "/")+BackupPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("istek oluşturulamadı: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("replika hata döndürdü: %d", resp.StatusCode)
+	}
+	return backup.Read(resp.Body)
+}
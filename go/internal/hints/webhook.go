@@ -0,0 +1,114 @@
+package hints
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultWebhookTimeout konfigürasyonda süre yoksa webhook isteğinin zaman aşımı
+const defaultWebhookTimeout = 5 * time.Second
+
+// SignatureHeader gövdenin HMAC-SHA256 imzasını taşıyan başlık ("sha256=<hex>")
+const SignatureHeader = "X-AI-Scheduler-Signature"
+
+// SigningKeyProvider webhook imzalama anahtarını sağlar (ör: Secret deposu)
+type SigningKeyProvider interface {
+	WebhookSigningKey() []byte
+}
+
+// WebhookSink kapasite olaylarını JSON olarak webhook'a POST eder.
+// Gönderim scheduling yolunu bekletmemek için arka planda yapılır, hatalar loglanır
+type WebhookSink struct {
+	config   *types.CapacityHintsConfig
+	configMu sync.RWMutex
+	keys     SigningKeyProvider
+	client   *http.Client
+}
+
+// NewWebhookSink yeni webhook alıcısı oluşturur, keys nil ise gövde imzalanmaz
+func NewWebhookSink(hintsConfig *types.CapacityHintsConfig, keys SigningKeyProvider) *WebhookSink {
+	cfg := *hintsConfig
+	return &WebhookSink{config: &cfg, keys: keys, client: &http.Client{}}
+}
+
+// UpdateConfig webhook konfigürasyonunu çalışma anında değiştirir
+func (w *WebhookSink) UpdateConfig(hintsConfig *types.CapacityHintsConfig) {
+	cfg := *hintsConfig
+
+	w.configMu.Lock()
+	w.config = &cfg
+	w.configMu.Unlock()
+}
+
+// currentConfig geçerli konfigürasyonu döndürür
+func (w *WebhookSink) currentConfig() *types.CapacityHintsConfig {
+	w.configMu.RLock()
+	defer w.configMu.RUnlock()
+
+	return w.config
+}
+
+// CapacityNeeded olayı webhook adresi tanımlıysa arka planda gönderir
+func (w *WebhookSink) CapacityNeeded(hint *types.CapacityHint) {
+	cfg := w.currentConfig()
+	if cfg.WebhookURL == "" {
+		return
+	}
+
+	go func() {
+		if err := w.send(cfg, hint); err != nil {
+			logrus.Warnf("Kapasite olayı webhook'a gönderilemedi (%s/%s): %v", hint.Namespace, hint.Pod, err)
+		}
+	}()
+}
+
+// send olayı imzalayarak POST eder
+func (w *WebhookSink) send(cfg *types.CapacityHintsConfig, hint *types.CapacityHint) error {
+	body, err := json.Marshal(hint)
+	if err != nil {
+		return fmt.Errorf("olay JSON'a çevrilemedi: %v", err)
+	}
+
+	timeout := cfg.WebhookTimeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.keys != nil {
+		if key := w.keys.WebhookSigningKey(); len(key) > 0 {
+			mac := hmac.New(sha256.New, key)
+			mac.Write(body)
+			req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook hata döndürdü: %d", resp.StatusCode)
+	}
+	return nil
+}
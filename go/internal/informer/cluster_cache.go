@@ -0,0 +1,193 @@
+package informer
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterCache node ve pod'ları informer cache'lerinde tutar, tahmin başına API çağrısını kaldırır
+// Döndürülen nesneler cache ile paylaşılır, çağıran tarafından değiştirilmemelidir
+type ClusterCache struct {
+	nodeLister    listersv1.NodeLister
+	podLister     listersv1.PodLister
+	nodeInformer  cache.SharedIndexInformer
+	podInformer   cache.SharedIndexInformer
+	metricsClient *types.MetricsClient
+	// ctx Metrics API çağrılarının context'i, uygulama kapanırken iptal edilir
+	ctx        context.Context
+	synced     []cache.InformerSynced
+	generation atomic.Uint64
+}
+
+// NewClusterCache paylaşılan informer factory üzerinden node ve pod informer'larını kaydeder. ctx cache'in ömrüdür
+func NewClusterCache(ctx context.Context, k8sClient *types.K8sClient, metricsClient *types.MetricsClient) (*ClusterCache, error) {
+	factory := k8sClient.InformerFactory()
+	if factory == nil {
+		return nil, fmt.Errorf("kubernetes client yok, informer cache oluşturulamıyor")
+	}
+
+	nodeInformer := factory.Core().V1().Nodes()
+	podInformer := factory.Core().V1().Pods()
+
+	// managedFields scoring'de kullanılmaz, cache belleğini azaltmak için atılır
+	for _, informer := range []cache.SharedIndexInformer{nodeInformer.Informer(), podInformer.Informer()} {
+		if err := informer.SetTransform(stripManagedFields); err != nil {
+			logrus.Warnf("Informer transform ayarlanamadı: %v", err)
+		}
+	}
+
+	c := &ClusterCache{
+		nodeLister:    nodeInformer.Lister(),
+		podLister:     podInformer.Lister(),
+		nodeInformer:  nodeInformer.Informer(),
+		podInformer:   podInformer.Informer(),
+		metricsClient: metricsClient,
+		ctx:           ctx,
+		synced:        []cache.InformerSynced{nodeInformer.Informer().HasSynced, podInformer.Informer().HasSynced},
+	}
+
+	// Her node/pod olayı generation'ı artırır, scheduler snapshot'ı sadece değişiklik varsa yeniden kurar
+	bump := func(interface{}) { c.generation.Add(1) }
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    bump,
+		UpdateFunc: func(_, obj interface{}) { bump(obj) },
+		DeleteFunc: bump,
+	}
+	for _, informer := range []cache.SharedIndexInformer{nodeInformer.Informer(), podInformer.Informer()} {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return nil, fmt.Errorf("informer event handler eklenemedi: %v", err)
+		}
+	}
+
+	return c, nil
+}
+
+// AddPodHandler pod informer'ının ekleme, güncelleme ve silme olaylarını fonksiyona iletir
+func (c *ClusterCache) AddPodHandler(handler func(pod *corev1.Pod, deleted bool)) {
+	_, err := c.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				handler(pod, false)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				handler(pod, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			// Kaçırılan silmelerde son bilinen durum tombstone içinde gelir
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				handler(pod, true)
+			}
+		},
+	})
+	if err != nil {
+		logrus.Warnf("Pod olay fonksiyonu eklenemedi: %v", err)
+	}
+}
+
+// AddNodeHandler node informer'ının ekleme, güncelleme ve silme olaylarını fonksiyona iletir
+func (c *ClusterCache) AddNodeHandler(handler func(node *corev1.Node, deleted bool)) {
+	_, err := c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if node, ok := obj.(*corev1.Node); ok {
+				handler(node, false)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if node, ok := obj.(*corev1.Node); ok {
+				handler(node, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if node, ok := obj.(*corev1.Node); ok {
+				handler(node, true)
+			}
+		},
+	})
+	if err != nil {
+		logrus.Warnf("Node olay fonksiyonu eklenemedi: %v", err)
+	}
+}
+
+// Generation node veya pod olayı geldikçe artan sayacı döndürür
+func (c *ClusterCache) Generation() uint64 {
+	return c.generation.Load()
+}
+
+// WaitForSync informer'ların ilk listesi tamamlanana kadar bekler
+func (c *ClusterCache) WaitForSync(ctx context.Context) bool {
+	return cache.WaitForCacheSync(ctx.Done(), c.synced...)
+}
+
+// Nodes cache'teki node'ları döndürür
+func (c *ClusterCache) Nodes() []*corev1.Node {
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		logrus.Warnf("Node cache listelenemedi: %v", err)
+		return nil
+	}
+	return nodes
+}
+
+// Node cache'teki node'u döndürür
+func (c *ClusterCache) Node(name string) (*corev1.Node, bool) {
+	node, err := c.nodeLister.Get(name)
+	if err != nil {
+		return nil, false
+	}
+	return node, true
+}
+
+// Pods cache'teki pod'ları döndürür
+func (c *ClusterCache) Pods() []*corev1.Pod {
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		logrus.Warnf("Pod cache listelenemedi: %v", err)
+		return nil
+	}
+	return pods
+}
+
+// Pod cache'teki pod'u döndürür
+func (c *ClusterCache) Pod(namespace, name string) (*corev1.Pod, bool) {
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	return pod, true
+}
+
+// NodeUsage node kullanımını Metrics API'den alır, kullanım verisi informer ile izlenmez
+func (c *ClusterCache) NodeUsage(nodeName string) (float64, float64, bool) {
+	cpuUsage, memUsage, err := c.metricsClient.GetNodeMetrics(c.ctx, nodeName)
+	if err != nil {
+		return 0, 0, false
+	}
+	return cpuUsage, memUsage, true
+}
+
+// stripManagedFields nesnenin managedFields alanını temizler
+func stripManagedFields(obj interface{}) (interface{}, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
@@ -0,0 +1,297 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Günlük varsayılanları
+const (
+	defaultFile      = "journal/decisions.jsonl"
+	defaultMaxSizeMB = 64
+	defaultMaxFiles  = 8
+)
+
+// FileJournal kararları ve durum geçişlerini JSON Lines formatında sadece eklenen dosyaya yazar.
+// Dosya max_size_mb'yi aşınca "<dosya>.1" olarak döndürülür, en fazla max_files eski dosya tutulur
+type FileJournal struct {
+	mutex   sync.Mutex
+	config  types.JournalConfig
+	file    *os.File
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	size    int64
+	seq     uint64
+}
+
+// settings varsayılanları uygulanmış günlük ayarları
+func settings(journalConfig *types.JournalConfig) types.JournalConfig {
+	cfg := *journalConfig
+	if cfg.File == "" {
+		cfg.File = defaultFile
+	}
+	if cfg.MaxSizeMB <= 0 {
+		cfg.MaxSizeMB = defaultMaxSizeMB
+	}
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = defaultMaxFiles
+	}
+	return cfg
+}
+
+// Open günlüğü açar ve mevcut kayıtları yazıldıkları sırayla replay fonksiyonuna verir (replay nil olabilir).
+// Çökme sırasında yarım kalan son satır kesilir, yeni kayıtlar son sıra numarasından devam eder
+func Open(journalConfig *types.JournalConfig, replay func(entry *scheduler.JournalEntry)) (*FileJournal, error) {
+	cfg := settings(journalConfig)
+	if dir := filepath.Dir(cfg.File); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("günlük dizini oluşturulamadı: %v", err)
+		}
+	}
+	if err := repairTail(cfg.File); err != nil {
+		return nil, err
+	}
+
+	var seq uint64
+	if err := Read(cfg.File, func(entry *scheduler.JournalEntry) {
+		if entry.Seq > seq {
+			seq = entry.Seq
+		}
+		if replay != nil {
+			replay(entry)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	j := &FileJournal{config: cfg, seq: seq}
+	if err := j.openFile(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// repairTail dosya yeni satırla bitmiyorsa yarım kalan son kaydı keser
+func repairTail(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("günlük dosyası okunamadı: %v", err)
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+
+	keep := bytes.LastIndexByte(data, '\n') + 1
+	logrus.Warnf("Günlüğün yarım kalan son kaydı kesiliyor (%d byte)", len(data)-keep)
+	if err := os.Truncate(path, int64(keep)); err != nil {
+		return fmt.Errorf("günlük dosyası onarılamadı: %v", err)
+	}
+	return nil
+}
+
+// openFile aktif günlük dosyasını ekleme modunda açar
+func (j *FileJournal) openFile() error {
+	file, err := os.OpenFile(j.config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("günlük dosyası açılamadı: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("günlük dosyası okunamadı: %v", err)
+	}
+
+	j.file = file
+	j.size = info.Size()
+	j.writer = bufio.NewWriter(&countingWriter{w: file, n: &j.size})
+	j.encoder = json.NewEncoder(j.writer)
+	return nil
+}
+
+// countingWriter yazılan byte'ları sayar
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+// Write veriyi yazar ve sayacı artırır
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// Append kaydı sıra numarası vererek günlüğe yazar. Kayıtlar çökmede kaybolmamak için hemen diske aktarılır,
+// fsync açıksa ayrıca senkronize edilir
+func (j *FileJournal) Append(entry *scheduler.JournalEntry) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.file == nil {
+		return
+	}
+	j.seq++
+	entry.Seq = j.seq
+	if err := j.encoder.Encode(entry); err != nil {
+		logrus.Warnf("Günlük kaydı yazılamadı: %v", err)
+		return
+	}
+	if err := j.writer.Flush(); err != nil {
+		logrus.Warnf("Günlük dosyası yazılamadı: %v", err)
+		return
+	}
+	if j.config.Fsync {
+		if err := j.file.Sync(); err != nil {
+			logrus.Warnf("Günlük dosyası senkronize edilemedi: %v", err)
+		}
+	}
+
+	if j.size >= int64(j.config.MaxSizeMB)*1024*1024 {
+		if err := j.rotate(); err != nil {
+			logrus.Errorf("Günlük dosyası döndürülemedi: %v", err)
+		}
+	}
+}
+
+// rotate aktif dosyayı kapatıp "<dosya>.1" yapar, eski dosyaları kaydırır ve max_files'ı aşanı siler
+func (j *FileJournal) rotate() error {
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	j.file = nil
+
+	segments, err := rotatedSegments(j.config.File)
+	if err != nil {
+		return err
+	}
+	// En eskiden başlayarak kaydırılır
+	for i := len(segments) - 1; i >= 0; i-- {
+		index := segments[i].index
+		if index >= j.config.MaxFiles {
+			if err := os.Remove(segments[i].path); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.Rename(segments[i].path, segmentPath(j.config.File, index+1)); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(j.config.File, segmentPath(j.config.File, 1)); err != nil {
+		return err
+	}
+	return j.openFile()
+}
+
+// Close tamponu boşaltır ve dosyayı kapatır
+func (j *FileJournal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	if err := j.writer.Flush(); err != nil {
+		return fmt.Errorf("günlük dosyası yazılamadı: %v", err)
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// segment döndürülmüş günlük dosyası
+type segment struct {
+	path  string
+	index int // 1 en yeni döndürülen
+}
+
+// segmentPath döndürülmüş dosyanın yolunu döndürür
+func segmentPath(path string, index int) string {
+	return path + "." + strconv.Itoa(index)
+}
+
+// rotatedSegments "<dosya>.N" dosyalarını en yeniden eskiye döndürür
+func rotatedSegments(path string) ([]segment, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("günlük dosyaları listelenemedi: %v", err)
+	}
+
+	var segments []segment
+	for _, match := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(match, path+"."))
+		if err != nil || index <= 0 {
+			continue
+		}
+		segments = append(segments, segment{path: match, index: index})
+	}
+	sort.Slice(segments, func(a, b int) bool { return segments[a].index < segments[b].index })
+	return segments, nil
+}
+
+// Read döndürülmüş dosyalar dahil günlüğü en eski kayıttan başlayarak okur. Parse edilemeyen satırlar
+// uyarıyla atlanır; dosya yoksa hata dönmez
+func Read(path string, fn func(entry *scheduler.JournalEntry)) error {
+	segments, err := rotatedSegments(path)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(segments)+1)
+	for i := len(segments) - 1; i >= 0; i-- {
+		paths = append(paths, segments[i].path)
+	}
+	paths = append(paths, path)
+
+	for _, segmentPath := range paths {
+		if err := readFile(segmentPath, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFile tek günlük dosyasını okur
+func readFile(path string, fn func(entry *scheduler.JournalEntry)) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("günlük dosyası açılamadı: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var entry scheduler.JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logrus.Warnf("%s satır %d parse edilemedi, atlandı: %v", path, line, err)
+			continue
+		}
+		fn(&entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("günlük dosyası okunamadı: %v", err)
+	}
+	return nil
+}
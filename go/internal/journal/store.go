@@ -0,0 +1,107 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/store"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Günlük backend'leri
+const (
+	BackendFile         = "file"
+	BackendHistoryStore = "history_store"
+)
+
+// storeTimeout geçmiş deposuna tek kayıt yazma süresi sınırı
+const storeTimeout = 5 * time.Second
+
+// StoreJournal kayıtları geçmiş deposunun journal akışına yazar. Dosya döndürme yerine retention'dan eski kayıtlar
+// açılışta silinir
+type StoreJournal struct {
+	mutex  sync.Mutex
+	store  store.HistoryStore
+	seq    uint64
+	closed bool
+}
+
+// OpenStore günlüğü geçmiş deposunda açar ve mevcut kayıtları sıra numarasıyla replay fonksiyonuna verir (replay
+// nil olabilir). Retention verilmişse daha eski kayıtlar önce silinir
+func OpenStore(historyStore store.HistoryStore, journalConfig *types.JournalConfig, replay func(entry *scheduler.JournalEntry)) (*StoreJournal, error) {
+	ctx := context.Background()
+	if journalConfig.Retention > 0 {
+		pruned, err := historyStore.Prune(ctx, store.StreamJournal, time.Now().Add(-journalConfig.Retention))
+		if err != nil {
+			return nil, fmt.Errorf("eski günlük kayıtları silinemedi: %v", err)
+		}
+		if pruned > 0 {
+			logrus.Infof("Günlükten %d eski kayıt silindi", pruned)
+		}
+	}
+
+	records, err := historyStore.Query(ctx, store.StreamJournal, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("günlük okunamadı: %v", err)
+	}
+	entries := make([]*scheduler.JournalEntry, 0, len(records))
+	for _, record := range records {
+		var entry scheduler.JournalEntry
+		if err := json.Unmarshal(record.Data, &entry); err != nil {
+			logrus.Warnf("Günlük kaydı okunamadı, atlanıyor: %v", err)
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	// Depo kayıtları zamana göre döndürür; eşit zamanlılarda yazılma sırası sıra numarasıyla korunur
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Seq < entries[j].Seq })
+
+	j := &StoreJournal{store: historyStore}
+	for _, entry := range entries {
+		if entry.Seq > j.seq {
+			j.seq = entry.Seq
+		}
+		if replay != nil {
+			replay(entry)
+		}
+	}
+	return j, nil
+}
+
+// Append kaydı sıra numarası vererek geçmiş deposuna yazar
+func (j *StoreJournal) Append(entry *scheduler.JournalEntry) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.closed {
+		return
+	}
+	j.seq++
+	entry.Seq = j.seq
+	record, err := store.NewRecord(entry.Time, entry.Kind, entry)
+	if err != nil {
+		logrus.Warnf("Günlük kaydı yazılamadı: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := j.store.Put(ctx, store.StreamJournal, record); err != nil {
+		logrus.Warnf("Günlük kaydı yazılamadı: %v", err)
+	}
+}
+
+// Close sonraki kayıtları yok sayar; depo paylaşıldığı için sahibi tarafından kapatılır
+func (j *StoreJournal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.closed = true
+	return nil
+}
@@ -0,0 +1,77 @@
+package journal
+
+import (
+	"fmt"
+
+	"ai-scheduler/internal/scheduler"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Workload günlükteki kararlardan simülatöre verilecek bekleyen pod'ları, ilk karar sırasıyla üretir.
+// Pod'lar kaydedilen namespace, iş yükü ve kaynak istekleriyle kurulur; aynı pod için sonraki kararlar atlanır
+func Workload(path string) ([]*corev1.Pod, error) {
+	var pods []*corev1.Pod
+	seen := make(map[string]bool)
+	err := Read(path, func(entry *scheduler.JournalEntry) {
+		decision := entry.Decision
+		if entry.Kind != scheduler.JournalDecision || decision == nil {
+			return
+		}
+		key := decision.Namespace + "/" + decision.Pod
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		pods = append(pods, journalPod(decision))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("günlükte karar kaydı yok: %s", path)
+	}
+	return pods, nil
+}
+
+// journalPod karardan bekleyen pod üretir, iş yükü controller sahibi olarak eklenir
+func journalPod(decision *scheduler.Decision) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              decision.Pod,
+			Namespace:         decision.Namespace,
+			CreationTimestamp: metav1.NewTime(decision.Time),
+			Labels:            map[string]string{"app": decision.Workload.Name},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "journal:latest",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(decision.CPU*1000), resource.DecimalSI),
+						corev1.ResourceMemory: *resource.NewQuantity(int64(decision.Memory*1024*1024*1024), resource.BinarySI),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app"}},
+		},
+	}
+
+	if kind := decision.Workload.Kind; kind != "" && kind != "Pod" {
+		// İş yükü doğrudan controller sahibi yazılır, WorkloadOf aynı iş yükünü çözer
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       kind,
+			Name:       decision.Workload.Name,
+			Controller: &controller,
+		}}
+	}
+	return pod
+}
@@ -0,0 +1,508 @@
+// Package mqtt edge node'ların MQTT broker'ına yayınladığı telemetriye abone olur ve topic eşlemelerine göre node
+// sinyallerini dış sinyal deposuna yazar. İstemci MQTT 3.1.1'in abonelik için gereken alt kümesini uygular
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Köprünün varsayılanları
+const (
+	defaultBroker            = "tcp://localhost:1883"
+	defaultClientID          = "ai-scheduler"
+	defaultSource            = "mqtt"
+	defaultKeepAlive         = 60 * time.Second
+	defaultConnectTimeout    = 10 * time.Second
+	defaultReconnectInterval = 5 * time.Second
+	nodeRefreshInterval      = 30 * time.Second
+	valueField               = "value"
+	// millisecondTimestamp bu değerden büyük sayısal zaman damgaları milisaniye sayılır (edge cihazlarında yaygın)
+	millisecondTimestamp = 1e12
+)
+
+// Collector köprünün node listesi ve dış sinyal deposu için kullandığı veri toplayıcı
+type Collector interface {
+	ListNodes(ctx context.Context) ([]*corev1.Node, error)
+	GetExternalSignals() *types.ExternalSignalStore
+}
+
+// Bridge MQTT aboneliğini yönetir: bağlantı koparsa reconnect_interval sonra yeniden bağlanır, konfigürasyon
+// değişince yeni ayarlarla yeniden bağlanır
+type Bridge struct {
+	collector Collector
+	config    *types.MQTTConfig
+	configMu  sync.RWMutex
+	reload    chan struct{}
+
+	nodesMu sync.Mutex
+	nodes   map[string]bool
+	nodesAt time.Time
+}
+
+// NewBridge yeni köprü oluşturur
+func NewBridge(collector Collector, mqttConfig *types.MQTTConfig) *Bridge {
+	cfg := *mqttConfig
+	return &Bridge{collector: collector, config: &cfg, reload: make(chan struct{}, 1)}
+}
+
+// UpdateConfig konfigürasyonu çalışma anında değiştirir, değiştiyse bağlantı yeni ayarlarla yeniden kurulur
+func (b *Bridge) UpdateConfig(mqttConfig *types.MQTTConfig) {
+	cfg := *mqttConfig
+
+	b.configMu.Lock()
+	changed := !reflect.DeepEqual(*b.config, cfg)
+	b.config = &cfg
+	b.configMu.Unlock()
+
+	if changed {
+		select {
+		case b.reload <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// currentConfig varsayılanları uygulanmış geçerli konfigürasyonu döndürür
+func (b *Bridge) currentConfig() types.MQTTConfig {
+	b.configMu.RLock()
+	cfg := *b.config
+	b.configMu.RUnlock()
+
+	if cfg.Broker == "" {
+		cfg.Broker = defaultBroker
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = defaultClientID
+	}
+	if cfg.Source == "" {
+		cfg.Source = defaultSource
+	}
+	if cfg.QoS < 0 || cfg.QoS > 1 {
+		cfg.QoS = 1
+	}
+	if cfg.KeepAlive < time.Second {
+		cfg.KeepAlive = defaultKeepAlive
+	}
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+	if cfg.ReconnectInterval <= 0 {
+		cfg.ReconnectInterval = defaultReconnectInterval
+	}
+	return cfg
+}
+
+// Start köprüyü context bitene kadar çalıştırır. Kapalıyken veya eşleme yokken konfigürasyon değişikliğini bekler
+func (b *Bridge) Start(ctx context.Context) {
+	for {
+		cfg := b.currentConfig()
+		var wait <-chan time.Time
+		if cfg.Enabled && len(cfg.Topics) > 0 {
+			mappings, err := compileMappings(cfg.Topics)
+			if err != nil {
+				logrus.Errorf("MQTT topic eşlemeleri geçersiz, köprü çalışmıyor: %v", err)
+			} else {
+				switch b.run(ctx, &cfg, mappings) {
+				case sessionStopped:
+					return
+				case sessionReloaded:
+					continue
+				case sessionFailed:
+					wait = time.After(cfg.ReconnectInterval)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.reload:
+		case <-wait:
+		}
+	}
+}
+
+// Bağlantı oturumunun bitiş sebepleri
+const (
+	sessionStopped  = iota // Context bitti
+	sessionReloaded        // Konfigürasyon değişti, hemen yeniden bağlanılır
+	sessionFailed          // Bağlantı koptu, reconnect_interval sonra yeniden bağlanılır
+)
+
+// run tek bağlantı oturumunu çalıştırır ve bitiş sebebini döndürür
+func (b *Bridge) run(ctx context.Context, cfg *types.MQTTConfig, mappings []mapping) int {
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- b.session(sessionCtx, cfg, mappings)
+	}()
+
+	select {
+	case <-ctx.Done():
+		cancel()
+		<-done
+		return sessionStopped
+	case <-b.reload:
+		cancel()
+		<-done
+		logrus.Info("MQTT konfigürasyonu değişti, yeniden bağlanılıyor")
+		return sessionReloaded
+	case err := <-done:
+		logrus.Warnf("MQTT bağlantısı koptu, %s sonra yeniden bağlanılacak: %v", cfg.ReconnectInterval, err)
+		return sessionFailed
+	}
+}
+
+// session broker'a bağlanır, topic'lere abone olur ve bağlantı kopana veya context bitene kadar mesajları işler
+func (b *Bridge) session(ctx context.Context, cfg *types.MQTTConfig, mappings []mapping) error {
+	c, err := dial(ctx, cfg.Broker, cfg.ClientID, cfg.Username, cfg.Password, cfg.KeepAlive, cfg.ConnectTimeout)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	// Okuma bloklanmışken context biterse bağlantı kapatılarak okuma sonlandırılır
+	stop := context.AfterFunc(ctx, func() { c.conn.Close() })
+	defer stop()
+
+	handle := func(msg message) {
+		b.handle(ctx, cfg, mappings, msg)
+	}
+	filters := subscriptionFilters(mappings)
+	if err := c.subscribe(filters, byte(cfg.QoS), handle); err != nil {
+		return err
+	}
+	logrus.Infof("MQTT broker'ına bağlanıldı: %s (%d topic filtresi)", cfg.Broker, len(filters))
+	return c.run(handle)
+}
+
+// handle mesajı ilk eşleşen eşlemeye göre node sinyallerine çevirip depoya yazar. Bilinmeyen node'ların, geçersiz
+// yüklerin ve sinyal geçerlilik süresinden eski ölçümlerin mesajları atılır
+func (b *Bridge) handle(ctx context.Context, cfg *types.MQTTConfig, mappings []mapping, msg message) {
+	for i := range mappings {
+		nodeName, signal, ok := mappings[i].match(msg.topic)
+		if !ok {
+			continue
+		}
+		if !b.knownNode(ctx, nodeName) {
+			logrus.Debugf("MQTT mesajı atıldı (%s): node bulunamadı: %s", msg.topic, nodeName)
+			return
+		}
+		values, at, err := mappings[i].values(signal, msg.payload)
+		if err != nil {
+			logrus.Debugf("MQTT mesajı atıldı (%s): %v", msg.topic, err)
+			return
+		}
+
+		store := b.collector.GetExternalSignals()
+		now := time.Now()
+		if at.IsZero() || at.After(now) {
+			at = now
+		}
+		if now.Sub(at) > store.TTL() {
+			logrus.Debugf("MQTT mesajı atıldı (%s): ölçüm zamanı sinyal geçerlilik süresinden (%s) eski", msg.topic, store.TTL())
+			return
+		}
+		for name, value := range values {
+			if err := types.ValidateExternalSignal(name, value); err != nil {
+				logrus.Debugf("MQTT sinyali atıldı (%s): %v", msg.topic, err)
+				delete(values, name)
+			}
+		}
+		overLimit, err := store.Put(nodeName, cfg.Source, values, at)
+		if err != nil {
+			logrus.Debugf("MQTT mesajı atıldı (%s): %v", msg.topic, err)
+			return
+		}
+		if len(overLimit) > 0 {
+			logrus.Debugf("MQTT sinyalleri atıldı (%s): node için sinyal sınırı aşıldı: %s", msg.topic, strings.Join(overLimit, ", "))
+		}
+		return
+	}
+}
+
+// knownNode node'un kümede olup olmadığını döndürür. Node listesi en fazla nodeRefreshInterval'da bir yenilenir;
+// liste alınamazsa son bilinen liste kullanılır
+func (b *Bridge) knownNode(ctx context.Context, nodeName string) bool {
+	b.nodesMu.Lock()
+	defer b.nodesMu.Unlock()
+
+	if time.Since(b.nodesAt) >= nodeRefreshInterval {
+		nodes, err := b.collector.ListNodes(ctx)
+		if err != nil {
+			logrus.Debugf("MQTT köprüsü için node listesi alınamadı: %v", err)
+		} else {
+			b.nodes = make(map[string]bool, len(nodes))
+			for _, node := range nodes {
+				b.nodes[node.Name] = true
+			}
+		}
+		b.nodesAt = time.Now()
+	}
+	return b.nodes[nodeName]
+}
+
+// Validate broker adresini, kimlik bilgilerini ve topic eşlemelerini doğrular
+func Validate(mqttConfig *types.MQTTConfig) error {
+	if mqttConfig.Password != "" && mqttConfig.Username == "" {
+		return errPasswordWithoutUsername
+	}
+	if mqttConfig.Broker != "" {
+		if _, _, err := brokerAddress(mqttConfig.Broker); err != nil {
+			return err
+		}
+	}
+	_, err := compileMappings(mqttConfig.Topics)
+	return err
+}
+
+// mapping derlenmiş topic eşlemesi
+type mapping struct {
+	config    types.MQTTTopicMapping
+	segments  []string
+	hasSignal bool   // Sinyal adı topic'ten okunur
+	filter    string // Abonelik filtresi: yer tutucular + ile değiştirilir
+}
+
+// compileMappings eşlemeleri derler ve doğrular
+func compileMappings(topics []types.MQTTTopicMapping) ([]mapping, error) {
+	mappings := make([]mapping, 0, len(topics))
+	for _, topic := range topics {
+		m, err := compileMapping(topic)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %v", topic.Topic, err)
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
+
+// compileMapping topic desenini doğrular ve abonelik filtresini oluşturur
+func compileMapping(topic types.MQTTTopicMapping) (mapping, error) {
+	segments := strings.Split(topic.Topic, "/")
+	filter := make([]string, len(segments))
+	hasNode, hasSignal := false, false
+	for i, segment := range segments {
+		filter[i] = segment
+		switch {
+		case segment == "{node}":
+			if hasNode {
+				return mapping{}, fmt.Errorf("{node} birden fazla kez geçiyor")
+			}
+			hasNode, filter[i] = true, "+"
+		case segment == "{signal}":
+			if hasSignal {
+				return mapping{}, fmt.Errorf("{signal} birden fazla kez geçiyor")
+			}
+			hasSignal, filter[i] = true, "+"
+		case segment == "#":
+			if i != len(segments)-1 {
+				return mapping{}, fmt.Errorf("# sadece son segment olabilir")
+			}
+		case strings.ContainsAny(segment, "{}#+"):
+			return mapping{}, fmt.Errorf("geçersiz segment: %q", segment)
+		}
+	}
+	if !hasNode {
+		return mapping{}, fmt.Errorf("desen {node} segmenti içermeli")
+	}
+	if hasSignal && topic.Signal != "" {
+		return mapping{}, fmt.Errorf("signal ve {signal} birlikte verilemez")
+	}
+	if topic.Signal != "" {
+		if err := types.ValidateExternalSignal(signalName(topic.Signal), 0); err != nil {
+			return mapping{}, err
+		}
+	}
+	if topic.Field != "" && !hasSignal && topic.Signal == "" {
+		return mapping{}, fmt.Errorf("field için signal veya {signal} gerekli")
+	}
+	return mapping{config: topic, segments: segments, hasSignal: hasSignal, filter: strings.Join(filter, "/")}, nil
+}
+
+// subscriptionFilters eşlemelerin tekil abonelik filtreleri
+func subscriptionFilters(mappings []mapping) []string {
+	seen := make(map[string]bool, len(mappings))
+	filters := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		if !seen[m.filter] {
+			seen[m.filter] = true
+			filters = append(filters, m.filter)
+		}
+	}
+	return filters
+}
+
+// match topic desene uyuyorsa node adını ve (desende varsa) sinyal adını döndürür
+func (m *mapping) match(topic string) (string, string, bool) {
+	parts := strings.Split(topic, "/")
+	var nodeName, signal string
+	for i, segment := range m.segments {
+		if segment == "#" {
+			break
+		}
+		if i >= len(parts) {
+			return "", "", false
+		}
+		switch segment {
+		case "{node}":
+			nodeName = parts[i]
+		case "{signal}":
+			signal = parts[i]
+		case "+":
+		default:
+			if parts[i] != segment {
+				return "", "", false
+			}
+		}
+	}
+	wildcard := m.segments[len(m.segments)-1] == "#"
+	if (!wildcard && len(parts) != len(m.segments)) || nodeName == "" || (m.hasSignal && signal == "") {
+		return "", "", false
+	}
+	return nodeName, signal, true
+}
+
+// values yükten sinyal değerlerini ve (timestamp_field verilmişse) ölçüm zamanını çıkarır
+func (m *mapping) values(topicSignal string, payload []byte) (map[string]float64, time.Time, error) {
+	name := topicSignal
+	if name == "" {
+		name = m.config.Signal
+	}
+	if name != "" {
+		name = signalName(name)
+	}
+	scale := m.config.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	payload = bytes.TrimSpace(payload)
+	if value, err := strconv.ParseFloat(string(payload), 64); err == nil {
+		if name == "" {
+			return nil, time.Time{}, fmt.Errorf("yük sayı ama sinyal adı yok")
+		}
+		return map[string]float64{name: value * scale}, time.Time{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, time.Time{}, fmt.Errorf("yük sayı veya JSON nesnesi değil")
+	}
+
+	var at time.Time
+	if m.config.TimestampField != "" {
+		parsed, err := timestamp(lookup(object, m.config.TimestampField))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %v", m.config.TimestampField, err)
+		}
+		at = parsed
+	}
+
+	values := make(map[string]float64)
+	switch {
+	case m.config.Field != "" || name != "":
+		field := m.config.Field
+		if field == "" {
+			field = valueField
+		}
+		value, ok := number(lookup(object, field))
+		if !ok {
+			return nil, time.Time{}, fmt.Errorf("%s alanı sayı değil", field)
+		}
+		values[name] = value * scale
+	default:
+		for key, raw := range object {
+			if key == m.config.TimestampField {
+				continue
+			}
+			if value, ok := number(raw); ok {
+				values[signalName(key)] = value * scale
+			}
+		}
+		if len(values) == 0 {
+			return nil, time.Time{}, fmt.Errorf("yükte sayısal alan yok")
+		}
+	}
+	return values, at, nil
+}
+
+// lookup nesnede nokta ile ayrılmış alan yolunu izler
+func lookup(object map[string]interface{}, path string) interface{} {
+	var current interface{} = object
+	for _, key := range strings.Split(path, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = fields[key]
+	}
+	return current
+}
+
+// number JSON değerini sayıya çevirir: sayı, sayısal dizgi veya bool (1/0)
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// timestamp unix saniye (büyükse milisaniye) veya RFC3339 zaman damgasını çözer
+func timestamp(value interface{}) (time.Time, error) {
+	if text, ok := value.(string); ok {
+		if at, err := time.Parse(time.RFC3339Nano, text); err == nil {
+			return at, nil
+		}
+	}
+	seconds, ok := number(value)
+	if !ok {
+		return time.Time{}, fmt.Errorf("zaman damgası unix saniye veya RFC3339 olmalı")
+	}
+	if seconds > millisecondTimestamp {
+		return time.UnixMilli(int64(seconds)), nil
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
+
+// signalName topic segmentini veya alan adını sinyal adına çevirir: küçük harfe çevrilir, harf, rakam ve alt çizgi
+// dışındaki karakterler (ör: '-', '.') alt çizgi olur
+func signalName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '_'
+		}
+	}, name)
+}
@@ -0,0 +1,377 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 kontrol paketi türleri
+const (
+	packetConnect     = 1
+	packetConnAck     = 2
+	packetPublish     = 3
+	packetPubAck      = 4
+	packetSubscribe   = 8
+	packetSubAck      = 9
+	packetPingReq     = 12
+	packetPingResp    = 13
+	packetDisconnect  = 14
+	protocolLevel     = 4
+	subscribePacketID = 1
+	maxPacketSize     = 1024 * 1024 // Telemetri mesajları küçüktür, daha büyük paketler reddedilir
+)
+
+// Bağlantı bayrakları
+const (
+	flagCleanSession = 0x02
+	flagPassword     = 0x40
+	flagUsername     = 0x80
+)
+
+// CONNACK dönüş kodlarının açıklamaları
+var connAckErrors = map[byte]string{
+	1: "desteklenmeyen protokol sürümü",
+	2: "client id reddedildi",
+	3: "sunucu kullanılamıyor",
+	4: "geçersiz kullanıcı adı veya parola",
+	5: "yetkisiz",
+}
+
+// errPingTimeout broker PINGREQ'e keep-alive süresi içinde cevap vermedi
+var errPingTimeout = errors.New("broker PINGREQ'e cevap vermedi")
+
+// errPasswordWithoutUsername MQTT 3.1.1 (§3.1.2.9) kullanıcı adı bayrağı olmadan parola bayrağına izin vermez
+var errPasswordWithoutUsername = errors.New("password username olmadan kullanılamaz")
+
+// packet okunan kontrol paketi
+type packet struct {
+	kind  byte
+	flags byte
+	body  []byte
+}
+
+// message broker'ın ilettiği yayın
+type message struct {
+	topic   string
+	payload []byte
+}
+
+// client MQTT 3.1.1 istemcisinin abonelik için gereken alt kümesi (CONNECT, SUBSCRIBE, PUBLISH alma ve QoS 1
+// onayı, PINGREQ). Paketler tek goroutine'den okunur; yazmalar kilitle sıralanır
+type client struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	writeMu   sync.Mutex
+	keepAlive time.Duration
+	lastSent  time.Time
+}
+
+// brokerAddress broker URL'sini adrese çevirir, TLS gerekip gerekmediğini döndürür
+func brokerAddress(broker string) (string, bool, error) {
+	parsed, err := url.Parse(broker)
+	if err != nil || parsed.Host == "" {
+		return "", false, fmt.Errorf("geçersiz MQTT broker adresi: %q", broker)
+	}
+	useTLS := false
+	port := "1883"
+	switch parsed.Scheme {
+	case "tcp", "mqtt":
+	case "tls", "ssl", "mqtts":
+		useTLS, port = true, "8883"
+	default:
+		return "", false, fmt.Errorf("desteklenmeyen MQTT broker şeması: %q", parsed.Scheme)
+	}
+	if parsed.Port() != "" {
+		port = parsed.Port()
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), useTLS, nil
+}
+
+// dial broker'a bağlanır ve CONNACK'i bekler
+func dial(ctx context.Context, broker, clientID, username, password string, keepAlive, timeout time.Duration) (*client, error) {
+	address, useTLS, err := brokerAddress(broker)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if useTLS {
+		host, _, _ := net.SplitHostPort(address)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("MQTT broker'ına bağlanılamadı: %v", err)
+	}
+
+	c := &client{conn: conn, reader: bufio.NewReader(conn), keepAlive: keepAlive}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := c.connect(clientID, username, password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect CONNECT gönderir ve CONNACK'i doğrular
+func (c *client) connect(clientID, username, password string) error {
+	if password != "" && username == "" {
+		return errPasswordWithoutUsername
+	}
+
+	var body []byte
+	body = appendString(body, "MQTT")
+	flags := byte(flagCleanSession)
+	if username != "" {
+		flags |= flagUsername
+	}
+	if password != "" {
+		flags |= flagPassword
+	}
+	body = append(body, protocolLevel, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(c.keepAlive/time.Second))
+	body = appendString(body, clientID)
+	if username != "" {
+		body = appendString(body, username)
+	}
+	if password != "" {
+		body = appendString(body, password)
+	}
+	if err := c.write(packetConnect<<4, body); err != nil {
+		return err
+	}
+
+	ack, err := c.read()
+	if err != nil {
+		return fmt.Errorf("MQTT CONNACK okunamadı: %v", err)
+	}
+	if ack.kind != packetConnAck || len(ack.body) != 2 {
+		return fmt.Errorf("beklenmeyen MQTT paketi (tür %d), CONNACK bekleniyordu", ack.kind)
+	}
+	if code := ack.body[1]; code != 0 {
+		reason, ok := connAckErrors[code]
+		if !ok {
+			reason = fmt.Sprintf("dönüş kodu %d", code)
+		}
+		return fmt.Errorf("MQTT bağlantısı reddedildi: %s", reason)
+	}
+	return nil
+}
+
+// subscribe topic filtrelerine abone olur ve SUBACK'i bekler. Yayınlar SUBACK'ten önce gelebileceği için araya
+// girenler handle'a verilir
+func (c *client) subscribe(filters []string, qos byte, handle func(message)) error {
+	body := binary.BigEndian.AppendUint16(nil, subscribePacketID)
+	for _, filter := range filters {
+		body = appendString(body, filter)
+		body = append(body, qos)
+	}
+	if err := c.write(packetSubscribe<<4|0x02, body); err != nil {
+		return err
+	}
+
+	for {
+		p, err := c.read()
+		if err != nil {
+			return fmt.Errorf("MQTT SUBACK okunamadı: %v", err)
+		}
+		if p.kind == packetPublish {
+			if err := c.handlePublish(p, handle); err != nil {
+				return err
+			}
+			continue
+		}
+		if p.kind != packetSubAck || len(p.body) != 2+len(filters) {
+			return fmt.Errorf("beklenmeyen MQTT paketi (tür %d), SUBACK bekleniyordu", p.kind)
+		}
+		for i, code := range p.body[2:] {
+			if code == 0x80 {
+				return fmt.Errorf("MQTT aboneliği reddedildi: %s", filters[i])
+			}
+		}
+		return nil
+	}
+}
+
+// run yayınları bağlantı kapanana kadar okuyup handle'a verir ve keep-alive için PINGREQ gönderir. Broker
+// keep-alive süresi içinde PINGRESP göndermezse bağlantı kopmuş sayılır
+func (c *client) run(handle func(message)) error {
+	var pingSent time.Time
+	for {
+		now := time.Now()
+		if pingSent.IsZero() && now.Sub(c.sent()) >= c.keepAlive {
+			if err := c.write(packetPingReq<<4, nil); err != nil {
+				return err
+			}
+			pingSent = now
+		}
+		if !pingSent.IsZero() && now.Sub(pingSent) >= c.keepAlive {
+			return errPingTimeout
+		}
+
+		deadline := c.sent().Add(c.keepAlive)
+		if !pingSent.IsZero() {
+			deadline = pingSent.Add(c.keepAlive)
+		}
+		if err := c.conn.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+		p, err := c.read()
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		switch p.kind {
+		case packetPublish:
+			if err := c.handlePublish(p, handle); err != nil {
+				return err
+			}
+		case packetPingResp:
+			pingSent = time.Time{}
+		}
+	}
+}
+
+// handlePublish PUBLISH paketini çözer, QoS 1 ise PUBACK gönderir ve mesajı handle'a verir
+func (c *client) handlePublish(p packet, handle func(message)) error {
+	qos := (p.flags >> 1) & 0x03
+	topic, rest, err := readString(p.body)
+	if err != nil {
+		return fmt.Errorf("geçersiz MQTT PUBLISH paketi: %v", err)
+	}
+	if qos > 0 {
+		if len(rest) < 2 {
+			return fmt.Errorf("geçersiz MQTT PUBLISH paketi: paket kimliği eksik")
+		}
+		packetID := rest[:2]
+		rest = rest[2:]
+		// QoS 2 istenmediği için gelmez; gelirse QoS 1 gibi onaylanır
+		if err := c.write(packetPubAck<<4, packetID); err != nil {
+			return err
+		}
+	}
+	handle(message{topic: topic, payload: rest})
+	return nil
+}
+
+// sent son paketin yazıldığı anı döndürür
+func (c *client) sent() time.Time {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return c.lastSent
+}
+
+// write sabit başlık ve gövdeden oluşan paketi yazar
+func (c *client) write(header byte, body []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	buffer := append([]byte{header}, appendLength(nil, len(body))...)
+	buffer = append(buffer, body...)
+	if err := c.conn.SetWriteDeadline(time.Now().Add(c.keepAlive)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(buffer); err != nil {
+		return fmt.Errorf("MQTT broker'ına yazılamadı: %v", err)
+	}
+	c.lastSent = time.Now()
+	return nil
+}
+
+// read sonraki kontrol paketini okur. Sadece paket başlamadan önceki okuma zaman aşımı döner; paket yarıda kalırsa
+// akış hizası bozulduğu için hata zaman aşımı olarak dönmez
+func (c *client) read() (packet, error) {
+	header, err := c.reader.ReadByte()
+	if err != nil {
+		return packet{}, err
+	}
+	p, err := c.readRest(header)
+	if err != nil {
+		return packet{}, fmt.Errorf("MQTT paketi okunamadı: %v", err)
+	}
+	return p, nil
+}
+
+// readRest başlık byte'ından sonra kalan uzunluğu ve gövdeyi okur
+func (c *client) readRest(header byte) (packet, error) {
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return packet{}, fmt.Errorf("geçersiz kalan uzunluk")
+		}
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return packet{}, err
+		}
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	if length > maxPacketSize {
+		return packet{}, fmt.Errorf("paket çok büyük: %d byte", length)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return packet{}, err
+	}
+	return packet{kind: header >> 4, flags: header & 0x0f, body: body}, nil
+}
+
+// Close DISCONNECT gönderip bağlantıyı kapatır
+func (c *client) Close() error {
+	c.write(packetDisconnect<<4, nil)
+	return c.conn.Close()
+}
+
+// appendLength kalan uzunluğu MQTT varint olarak ekler
+func appendLength(buffer []byte, length int) []byte {
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		buffer = append(buffer, b)
+		if length == 0 {
+			return buffer
+		}
+	}
+}
+
+// appendString uzunluk önekli UTF-8 dizgiyi ekler
+func appendString(buffer []byte, value string) []byte {
+	buffer = binary.BigEndian.AppendUint16(buffer, uint16(len(value)))
+	return append(buffer, value...)
+}
+
+// readString uzunluk önekli dizgiyi okur, kalan byte'ları döndürür
+func readString(data []byte) (string, []byte, error) {
+	if len(data) < 2 {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	length := int(binary.BigEndian.Uint16(data))
+	if len(data) < 2+length {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	return string(data[2 : 2+length]), data[2+length:], nil
+}
@@ -0,0 +1,147 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+func TestAppendLength(t *testing.T) {
+	tests := []struct {
+		length int
+		want   []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{16383, []byte{0xff, 0x7f}},
+		{16384, []byte{0x80, 0x80, 0x01}},
+		{2097151, []byte{0xff, 0xff, 0x7f}},
+		{2097152, []byte{0x80, 0x80, 0x80, 0x01}},
+	}
+	for _, tt := range tests {
+		if got := appendLength(nil, tt.length); !bytes.Equal(got, tt.want) {
+			t.Errorf("appendLength(%d) = % x, beklenen % x", tt.length, got, tt.want)
+		}
+	}
+}
+
+func TestReadRestLength(t *testing.T) {
+	for _, length := range []int{0, 127, 128, 16383, 16384, maxPacketSize} {
+		body := bytes.Repeat([]byte{0xab}, length)
+		encoded := append(appendLength(nil, length), body...)
+		c := &client{reader: bufio.NewReader(bytes.NewReader(encoded))}
+
+		p, err := c.readRest(packetPublish<<4 | 0x02)
+		if err != nil {
+			t.Fatalf("readRest(%d): %v", length, err)
+		}
+		if p.kind != packetPublish || p.flags != 0x02 || len(p.body) != length {
+			t.Errorf("readRest(%d) = tür %d, bayrak %d, %d byte", length, p.kind, p.flags, len(p.body))
+		}
+	}
+}
+
+func TestReadRestInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []byte
+	}{
+		{"beş byte uzunluk", []byte{0x80, 0x80, 0x80, 0x80, 0x01}},
+		{"paket sınırı aşılıyor", appendLength(nil, maxPacketSize+1)},
+		{"gövde eksik", []byte{0x05, 0x01, 0x02}},
+	}
+	for _, tt := range tests {
+		c := &client{reader: bufio.NewReader(bytes.NewReader(tt.encoded))}
+		if _, err := c.readRest(packetPublish << 4); err == nil {
+			t.Errorf("%s: hata bekleniyordu", tt.name)
+		}
+	}
+}
+
+func TestHandlePublish(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags  byte
+		body   []byte
+		pubAck []byte // nil ise PUBACK gönderilmemeli
+	}{
+		{"qos0", 0x00, append(appendString(nil, "edge/n1"), "42"...), nil},
+		{"qos1", 0x02, append(appendString(nil, "edge/n1"), append([]byte{0x12, 0x34}, "42"...)...), []byte{packetPubAck << 4, 0x02, 0x12, 0x34}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, broker := net.Pipe()
+			defer local.Close()
+			defer broker.Close()
+			c := &client{conn: local, keepAlive: time.Second}
+
+			var got message
+			done := make(chan error, 1)
+			go func() {
+				done <- c.handlePublish(packet{kind: packetPublish, flags: tt.flags, body: tt.body}, func(msg message) { got = msg })
+			}()
+
+			if tt.pubAck != nil {
+				ack := make([]byte, len(tt.pubAck))
+				broker.SetReadDeadline(time.Now().Add(time.Second))
+				if _, err := io.ReadFull(broker, ack); err != nil {
+					t.Fatalf("PUBACK okunamadı: %v", err)
+				}
+				if !bytes.Equal(ack, tt.pubAck) {
+					t.Errorf("PUBACK = % x, beklenen % x", ack, tt.pubAck)
+				}
+			}
+			if err := <-done; err != nil {
+				t.Fatalf("handlePublish: %v", err)
+			}
+			if got.topic != "edge/n1" || string(got.payload) != "42" {
+				t.Errorf("mesaj = %q %q", got.topic, got.payload)
+			}
+		})
+	}
+}
+
+func TestHandlePublishMissingPacketID(t *testing.T) {
+	c := &client{keepAlive: time.Second}
+	err := c.handlePublish(packet{kind: packetPublish, flags: 0x02, body: appendString(nil, "edge/n1")}, func(message) {})
+	if err == nil {
+		t.Fatal("paket kimliği eksik QoS 1 yayını için hata bekleniyordu")
+	}
+}
+
+func TestConnectPasswordWithoutUsername(t *testing.T) {
+	local, broker := net.Pipe()
+	defer local.Close()
+	defer broker.Close()
+	c := &client{conn: local, reader: bufio.NewReader(local), keepAlive: time.Second}
+
+	// CONNECT yazılmadan reddedilmeli, yazılsaydı okuyan olmadığı için pipe bloklanırdı
+	if err := c.connect("ai-scheduler", "", "secret"); !errors.Is(err, errPasswordWithoutUsername) {
+		t.Fatalf("connect = %v, beklenen %v", err, errPasswordWithoutUsername)
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		username, password string
+		wantErr            bool
+	}{
+		{"", "", false},
+		{"edge", "", false},
+		{"edge", "secret", false},
+		{"", "secret", true},
+	}
+	for _, tt := range tests {
+		err := Validate(&types.MQTTConfig{Broker: "tcp://localhost:1883", Username: tt.username, Password: tt.password})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(username %q, password %q) = %v", tt.username, tt.password, err)
+		}
+	}
+}
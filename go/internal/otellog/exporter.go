@@ -0,0 +1,423 @@
+// Package otellog karar olaylarını OpenTelemetry log kaydı olarak OTLP/HTTP (JSON kodlaması) alıcısına gönderir
+package otellog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Exporter'ın varsayılanları
+const (
+	defaultEndpoint      = "http://localhost:4318"
+	defaultServiceName   = "ai-scheduler"
+	defaultBufferSize    = 10000
+	defaultBatchSize     = 200
+	defaultFlushInterval = 5 * time.Second
+	defaultTimeout       = 10 * time.Second
+	logsPath             = "/v1/logs"
+	scopeName            = "ai-scheduler/scheduler"
+	eventName            = "ai_scheduler.decision"
+	droppedLogInterval   = time.Minute
+	shutdownFlushTimeout = 5 * time.Second
+)
+
+// OpenTelemetry log veri modelindeki önem seviyeleri
+const (
+	severityInfo = 9
+	severityWarn = 13
+)
+
+// Stats exporter'ın sayaçları
+type Stats struct {
+	Exported uint64 `json:"exported"`
+	Dropped  uint64 `json:"dropped"` // Tampon dolu olduğu için atılan kararlar
+	Failed   uint64 `json:"failed"`  // Gönderilemeyen kararlar
+}
+
+// Exporter karar olaylarını tamponlayıp arka planda OTLP log kaydı olarak gönderir. Publish hiçbir zaman beklemez,
+// tampon doluysa karar atılır; karar dışındaki olaylar yok sayılır
+type Exporter struct {
+	config   *types.OTLPLogsConfig
+	configMu sync.RWMutex
+	enabled  atomic.Bool
+	records  chan scheduler.Decision
+	client   *http.Client
+	exported atomic.Uint64
+	dropped  atomic.Uint64
+	failed   atomic.Uint64
+}
+
+// NewExporter yeni exporter oluşturur
+func NewExporter(logsConfig *types.OTLPLogsConfig) *Exporter {
+	cfg := *logsConfig
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	e := &Exporter{config: &cfg, records: make(chan scheduler.Decision, size), client: &http.Client{}}
+	e.enabled.Store(cfg.Enabled)
+	return e
+}
+
+// UpdateConfig konfigürasyonu çalışma anında değiştirir, tampon boyutu yeniden başlatmada değişir
+func (e *Exporter) UpdateConfig(logsConfig *types.OTLPLogsConfig) {
+	cfg := *logsConfig
+
+	e.configMu.Lock()
+	e.config = &cfg
+	e.configMu.Unlock()
+	e.enabled.Store(cfg.Enabled)
+}
+
+// currentConfig varsayılanları uygulanmış geçerli konfigürasyonu döndürür
+func (e *Exporter) currentConfig() types.OTLPLogsConfig {
+	e.configMu.RLock()
+	cfg := *e.config
+	e.configMu.RUnlock()
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultEndpoint
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = defaultServiceName
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return cfg
+}
+
+// Publish karar olayını tampona ekler, exporter kapalıysa, olay karar değilse veya tampon doluysa atar
+func (e *Exporter) Publish(event types.Event) {
+	if !e.enabled.Load() || event.Kind != types.EventDecision {
+		return
+	}
+	decision, ok := event.Data.(scheduler.Decision)
+	if !ok {
+		return
+	}
+
+	select {
+	case e.records <- decision:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Stats gönderilen, atılan ve gönderilemeyen karar sayılarını döndürür
+func (e *Exporter) Stats() Stats {
+	return Stats{Exported: e.exported.Load(), Dropped: e.dropped.Load(), Failed: e.failed.Load()}
+}
+
+// Start tampondaki kararları toplayıp batch dolunca veya flush aralığında gönderir. Kapanışta bekleyen kararlar
+// kısa bir süre içinde gönderilmeye çalışılır
+func (e *Exporter) Start(ctx context.Context) {
+	cfg := e.currentConfig()
+	ticker := time.NewTicker(cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]scheduler.Decision, 0, cfg.BatchSize)
+	var lastDropped uint64
+	lastDropLog := time.Now()
+
+	flush := func(ctx context.Context) {
+		if len(batch) > 0 {
+			e.send(ctx, &cfg, batch)
+			batch = batch[:0]
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownFlushTimeout)
+			flush(shutdownCtx)
+			cancel()
+			return
+		case decision := <-e.records:
+			cfg = e.currentConfig()
+			batch = append(batch, decision)
+			if len(batch) >= cfg.BatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+			if next := e.currentConfig().FlushInterval; next != cfg.FlushInterval {
+				cfg.FlushInterval = next
+				ticker.Reset(next)
+			}
+			if dropped := e.dropped.Load(); dropped != lastDropped && time.Since(lastDropLog) >= droppedLogInterval {
+				logrus.Warnf("OTLP log tamponu dolu, %d karar atıldı", dropped-lastDropped)
+				lastDropped, lastDropLog = dropped, time.Now()
+			}
+		}
+	}
+}
+
+// send kararları tek ExportLogsServiceRequest olarak gönderir
+func (e *Exporter) send(ctx context.Context, cfg *types.OTLPLogsConfig, decisions []scheduler.Decision) {
+	if err := e.export(ctx, cfg, decisions); err != nil {
+		logrus.Warnf("%d karar OTLP log olarak gönderilemedi: %v", len(decisions), err)
+		e.failed.Add(uint64(len(decisions)))
+		return
+	}
+	e.exported.Add(uint64(len(decisions)))
+}
+
+// export isteği oluşturup alıcıya POST eder
+func (e *Exporter) export(ctx context.Context, cfg *types.OTLPLogsConfig, decisions []scheduler.Decision) error {
+	endpoint, err := logsEndpoint(cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(newExportRequest(cfg, decisions, time.Now()))
+	if err != nil {
+		return fmt.Errorf("log kayıtları JSON'a çevrilemedi: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("OTLP alıcısı hata döndürdü: %d %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	// Kısmi başarı: alıcı bazı kayıtları reddettiyse loglanır, batch yeniden gönderilmez
+	var partial struct {
+		PartialSuccess struct {
+			RejectedLogRecords string `json:"rejectedLogRecords"`
+			ErrorMessage       string `json:"errorMessage"`
+		} `json:"partialSuccess"`
+	}
+	if json.Unmarshal(detail, &partial) == nil && partial.PartialSuccess.RejectedLogRecords != "" && partial.PartialSuccess.RejectedLogRecords != "0" {
+		logrus.Warnf("OTLP alıcısı %s log kaydını reddetti: %s", partial.PartialSuccess.RejectedLogRecords, partial.PartialSuccess.ErrorMessage)
+	}
+	return nil
+}
+
+// logsEndpoint adreste yol yoksa OTLP/HTTP log yolunu ekler
+func logsEndpoint(endpoint string) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("geçersiz OTLP endpoint'i: %s", endpoint)
+	}
+	if parsed.Path == "" || parsed.Path == "/" {
+		parsed.Path = logsPath
+	}
+	return parsed.String(), nil
+}
+
+// OTLP/HTTP JSON kodlaması (opentelemetry-proto logs/v1). 64 bit tam sayılar string olarak kodlanır
+type (
+	exportRequest struct {
+		ResourceLogs []resourceLogs `json:"resourceLogs"`
+	}
+	resourceLogs struct {
+		Resource  resource    `json:"resource"`
+		ScopeLogs []scopeLogs `json:"scopeLogs"`
+	}
+	resource struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+	scopeLogs struct {
+		Scope      scope       `json:"scope"`
+		LogRecords []logRecord `json:"logRecords"`
+	}
+	scope struct {
+		Name string `json:"name"`
+	}
+	logRecord struct {
+		TimeUnixNano         string     `json:"timeUnixNano"`
+		ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+		SeverityNumber       int        `json:"severityNumber"`
+		SeverityText         string     `json:"severityText"`
+		Body                 anyValue   `json:"body"`
+		Attributes           []keyValue `json:"attributes"`
+	}
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue *string      `json:"stringValue,omitempty"`
+		BoolValue   *bool        `json:"boolValue,omitempty"`
+		IntValue    *string      `json:"intValue,omitempty"`
+		DoubleValue *float64     `json:"doubleValue,omitempty"`
+		KvlistValue *keyValueSet `json:"kvlistValue,omitempty"`
+	}
+	keyValueSet struct {
+		Values []keyValue `json:"values"`
+	}
+)
+
+// stringAttr string öznitelik
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+// boolAttr bool öznitelik
+func boolAttr(key string, value bool) keyValue {
+	return keyValue{Key: key, Value: anyValue{BoolValue: &value}}
+}
+
+// intAttr tam sayı öznitelik
+func intAttr(key string, value int64) keyValue {
+	encoded := strconv.FormatInt(value, 10)
+	return keyValue{Key: key, Value: anyValue{IntValue: &encoded}}
+}
+
+// doubleAttr ondalıklı sayı öznitelik
+func doubleAttr(key string, value float64) keyValue {
+	return keyValue{Key: key, Value: anyValue{DoubleValue: &value}}
+}
+
+// newExportRequest kararları tek resource ve scope altında log kayıtlarına çevirir
+func newExportRequest(cfg *types.OTLPLogsConfig, decisions []scheduler.Decision, observed time.Time) exportRequest {
+	records := make([]logRecord, 0, len(decisions))
+	for i := range decisions {
+		records = append(records, newLogRecord(&decisions[i], observed))
+	}
+	return exportRequest{ResourceLogs: []resourceLogs{{
+		Resource:  resource{Attributes: resourceAttributes(cfg)},
+		ScopeLogs: []scopeLogs{{Scope: scope{Name: scopeName}, LogRecords: records}},
+	}}}
+}
+
+// resourceAttributes servis adı, örnek kimliği (hostname) ve konfigürasyondaki ek öznitelikler
+func resourceAttributes(cfg *types.OTLPLogsConfig) []keyValue {
+	attributes := []keyValue{stringAttr("service.name", cfg.ServiceName)}
+	if hostname, err := os.Hostname(); err == nil {
+		attributes = append(attributes, stringAttr("service.instance.id", hostname))
+	}
+	for _, pair := range strings.Split(cfg.ResourceAttributes, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); ok && key != "" && key != "service.name" {
+			attributes = append(attributes, stringAttr(key, strings.TrimSpace(value)))
+		}
+	}
+	return attributes
+}
+
+// newLogRecord kararı log kaydına çevirir: gövde kararın okunabilir özeti, öznitelikler yapılandırılmış alanlarıdır.
+// Yerleştirilemeyen pod'lar WARN, diğer kararlar INFO seviyesindedir
+func newLogRecord(decision *scheduler.Decision, observed time.Time) logRecord {
+	severity, severityText := severityInfo, "INFO"
+	if decision.Outcome == scheduler.OutcomeUnschedulable {
+		severity, severityText = severityWarn, "WARN"
+	}
+	timestamp := decision.Time
+	if timestamp.IsZero() {
+		timestamp = observed
+	}
+
+	body := decisionSummary(decision)
+	attributes := []keyValue{
+		stringAttr("event.name", eventName),
+		stringAttr("k8s.namespace.name", decision.Namespace),
+		stringAttr("k8s.pod.name", decision.Pod),
+		stringAttr("ai_scheduler.decision.outcome", decision.Outcome),
+		stringAttr("ai_scheduler.workload.kind", decision.Workload.Kind),
+		stringAttr("ai_scheduler.workload.name", decision.Workload.Name),
+	}
+	if decision.Node != "" {
+		attributes = append(attributes,
+			stringAttr("k8s.node.name", decision.Node),
+			doubleAttr("ai_scheduler.decision.score", decision.Score))
+	}
+	if decision.Reason != "" {
+		attributes = append(attributes, stringAttr("ai_scheduler.decision.reason", decision.Reason))
+	}
+	if decision.Template != "" {
+		attributes = append(attributes, stringAttr("ai_scheduler.decision.template", decision.Template))
+	}
+	if decision.Ranked {
+		attributes = append(attributes, boolAttr("ai_scheduler.decision.ranked", true))
+	}
+	if decision.AISkipped {
+		attributes = append(attributes, boolAttr("ai_scheduler.decision.ai_skipped", true))
+	}
+	if decision.Stale != nil {
+		attributes = append(attributes, boolAttr("ai_scheduler.decision.stale", true))
+	}
+	if decision.CPU > 0 || decision.Memory > 0 {
+		attributes = append(attributes,
+			doubleAttr("ai_scheduler.pod.cpu_request", decision.CPU),
+			doubleAttr("ai_scheduler.pod.memory_request_gb", decision.Memory))
+	}
+	if len(decision.Rejected) > 0 {
+		attributes = append(attributes, keyValue{Key: "ai_scheduler.decision.rejected", Value: anyValue{KvlistValue: rejectedCounts(decision.Rejected)}})
+	}
+
+	return logRecord{
+		TimeUnixNano:         strconv.FormatInt(timestamp.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(observed.UnixNano(), 10),
+		SeverityNumber:       severity,
+		SeverityText:         severityText,
+		Body:                 anyValue{StringValue: &body},
+		Attributes:           attributes,
+	}
+}
+
+// rejectedCounts filtre eleme sayılarını neden adına göre sıralı kvlist'e çevirir
+func rejectedCounts(rejected map[string]int) *keyValueSet {
+	reasons := make([]string, 0, len(rejected))
+	for reason := range rejected {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	set := &keyValueSet{Values: make([]keyValue, 0, len(reasons))}
+	for _, reason := range reasons {
+		set.Values = append(set.Values, intAttr(reason, int64(rejected[reason])))
+	}
+	return set
+}
+
+// decisionSummary kayıt gövdesi olarak kararın tek satırlık özeti
+func decisionSummary(decision *scheduler.Decision) string {
+	switch decision.Outcome {
+	case scheduler.OutcomeUnschedulable:
+		return fmt.Sprintf("%s/%s yerleştirilemedi", decision.Namespace, decision.Pod)
+	case scheduler.OutcomeObserveOnly:
+		return fmt.Sprintf("%s/%s için %s önerildi (gözlem modu, skor: %.2f)", decision.Namespace, decision.Pod, decision.Node, decision.Score)
+	default:
+		return fmt.Sprintf("%s/%s %s node'una yerleştirildi (skor: %.2f)", decision.Namespace, decision.Pod, decision.Node, decision.Score)
+	}
+}
@@ -0,0 +1,313 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Çözümleyicinin varsayılanları
+const (
+	defaultCacheTTL    = 6 * time.Hour
+	defaultTimeout     = 10 * time.Second
+	failureTTL         = 5 * time.Minute // Çözülemeyen imaj bu süre sonra tekrar denenir
+	maxConcurrent      = 4
+	maxManifestBytes   = 4 << 20
+	dockerHubRegistry  = "registry-1.docker.io"
+	manifestAcceptList = "application/vnd.oci.image.index.v1+json, application/vnd.docker.distribution.manifest.list.v2+json, " +
+		"application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// challengeParam WWW-Authenticate başlığındaki anahtar="değer" çiftleri
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// cacheEntry imajın çözülmüş platformları, çözülemediyse failed true
+type cacheEntry struct {
+	platforms []types.Platform
+	failed    bool
+	expires   time.Time
+}
+
+// manifest manifest listesi (index) veya tek platformlu imaj manifesti
+type manifest struct {
+	Manifests []struct {
+		Platform *types.Platform `json:"platform"`
+	} `json:"manifests"`
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+}
+
+// Resolver imajların desteklediği platformları registry'lerden arka planda çözer ve cache'ler.
+// Scheduling yolu sadece cache'i okur, registry isteği beklenmez
+type Resolver struct {
+	config   *types.PlatformConfig
+	configMu sync.RWMutex
+	client   *http.Client
+	mutex    sync.Mutex
+	cache    map[string]cacheEntry
+	pending  map[string]bool
+	slots    chan struct{}
+}
+
+// NewResolver yeni imaj platformu çözümleyicisi oluşturur
+func NewResolver(platformConfig *types.PlatformConfig) *Resolver {
+	return &Resolver{
+		config:  platformConfig,
+		client:  &http.Client{},
+		cache:   make(map[string]cacheEntry),
+		pending: make(map[string]bool),
+		slots:   make(chan struct{}, maxConcurrent),
+	}
+}
+
+// UpdateConfig çözümleyici konfigürasyonunu çalışma anında değiştirir
+func (r *Resolver) UpdateConfig(platformConfig *types.PlatformConfig) {
+	cfg := *platformConfig
+
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+
+	r.config = &cfg
+}
+
+// settings varsayılanları uygulanmış konfigürasyonu döndürür
+func (r *Resolver) settings() types.PlatformConfig {
+	r.configMu.RLock()
+	cfg := *r.config
+	r.configMu.RUnlock()
+
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaultCacheTTL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return cfg
+}
+
+// Platforms imajın cache'teki platformlarını döndürür. Cache'te yoksa veya süresi dolduysa çözümleme arka planda
+// başlatılır ve (varsa eski değerle) hemen dönülür; platformlar bilinmiyorsa false
+func (r *Resolver) Platforms(image string) ([]types.Platform, bool) {
+	cfg := r.settings()
+	if !cfg.Enabled || !cfg.ResolveImages || image == "" {
+		return nil, false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.cache[image]
+	if (!ok || time.Now().After(entry.expires)) && !r.pending[image] {
+		r.pending[image] = true
+		go r.refresh(image, &cfg)
+	}
+	if !ok || entry.failed {
+		return nil, false
+	}
+	return entry.platforms, true
+}
+
+// refresh imajı çözer ve sonucu cache'e yazar, eşzamanlı registry isteği sayısı sınırlıdır
+func (r *Resolver) refresh(image string, cfg *types.PlatformConfig) {
+	r.slots <- struct{}{}
+	defer func() { <-r.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	platforms, err := r.resolve(ctx, image)
+	entry := cacheEntry{platforms: platforms, expires: time.Now().Add(cfg.CacheTTL)}
+	if err != nil {
+		logrus.Debugf("İmaj platformları çözülemedi (%s): %v", image, err)
+		entry = cacheEntry{failed: true, expires: time.Now().Add(failureTTL)}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cache[image] = entry
+	delete(r.pending, image)
+	// Süresi dolmuş kayıtlar yeni kayıt eklenirken temizlenir
+	now := time.Now()
+	for key, cached := range r.cache {
+		if now.After(cached.expires) && !r.pending[key] {
+			delete(r.cache, key)
+		}
+	}
+}
+
+// resolve imajın manifestini okur; manifest listesinde tüm platformlar, tek manifestte config blob'undaki platform döner
+func (r *Resolver) resolve(ctx context.Context, image string) ([]types.Platform, error) {
+	registry, repository, reference, err := parseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	base := registryURL(registry) + "/v2/" + repository
+
+	var parsed manifest
+	if err := r.getJSON(ctx, base+"/manifests/"+reference, manifestAcceptList, &parsed); err != nil {
+		return nil, err
+	}
+
+	if len(parsed.Manifests) > 0 {
+		var platforms []types.Platform
+		for _, entry := range parsed.Manifests {
+			// Attestation manifestleri "unknown/unknown" platformla gelir
+			if entry.Platform == nil || entry.Platform.OS == "unknown" || entry.Platform.Architecture == "unknown" {
+				continue
+			}
+			platforms = append(platforms, *entry.Platform)
+		}
+		if len(platforms) == 0 {
+			return nil, fmt.Errorf("manifest listesinde platform yok")
+		}
+		return platforms, nil
+	}
+
+	if parsed.Config.Digest == "" {
+		return nil, fmt.Errorf("manifestte config yok")
+	}
+	var config types.Platform
+	if err := r.getJSON(ctx, base+"/blobs/"+parsed.Config.Digest, "application/json", &config); err != nil {
+		return nil, err
+	}
+	if config.OS == "" || config.Architecture == "" {
+		return nil, fmt.Errorf("imaj config'inde platform yok")
+	}
+	return []types.Platform{config}, nil
+}
+
+// getJSON registry'den JSON okur. 401 dönerse WWW-Authenticate'teki token servisinden anonim token alınıp tekrar denenir
+func (r *Resolver) getJSON(ctx context.Context, target, accept string, out interface{}) error {
+	resp, err := r.get(ctx, target, accept, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		token, err := r.anonymousToken(ctx, challenge)
+		if err != nil {
+			return err
+		}
+		if resp, err = r.get(ctx, target, accept, token); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry hata döndürdü: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestBytes)).Decode(out); err != nil {
+		return fmt.Errorf("registry yanıtı parse edilemedi: %v", err)
+	}
+	return nil
+}
+
+// get Accept ve varsa Bearer token ile GET isteği yapar
+func (r *Resolver) get(ctx context.Context, target, accept, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.client.Do(req)
+}
+
+// anonymousToken Bearer challenge'ındaki realm'den service ve scope ile anonim pull token'ı alır
+func (r *Resolver) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("desteklenmeyen registry kimlik doğrulaması: %s", scheme)
+	}
+	values := url.Values{}
+	realm := ""
+	for _, match := range challengeParam.FindAllStringSubmatch(params, -1) {
+		if match[1] == "realm" {
+			realm = match[2]
+		} else {
+			values.Set(match[1], match[2])
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("token servisi (realm) yok")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token servisi hata döndürdü: %d", resp.StatusCode)
+	}
+	var response struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestBytes)).Decode(&response); err != nil {
+		return "", fmt.Errorf("token yanıtı parse edilemedi: %v", err)
+	}
+	if response.Token != "" {
+		return response.Token, nil
+	}
+	return response.AccessToken, nil
+}
+
+// parseReference imaj referansını registry, repository ve tag/digest'e ayırır (Docker Hub kısaltmaları dahil)
+func parseReference(image string) (string, string, string, error) {
+	name, reference := image, "latest"
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, reference = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, reference = name[:i], name[i+1:]
+	}
+
+	registry := dockerHubRegistry
+	if i := strings.Index(name, "/"); i >= 0 {
+		if host := name[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			registry, name = host, name[i+1:]
+		}
+	}
+	if registry == "docker.io" || registry == "index.docker.io" {
+		registry = dockerHubRegistry
+	}
+	if registry == dockerHubRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+
+	if name == "" || reference == "" {
+		return "", "", "", fmt.Errorf("geçersiz imaj referansı: %s", image)
+	}
+	return registry, name, reference, nil
+}
+
+// registryURL registry'nin API adresini döndürür, localhost registry'leri düz HTTP'dir
+func registryURL(registry string) string {
+	if registry == "localhost" || strings.HasPrefix(registry, "localhost:") || strings.HasPrefix(registry, "127.0.0.1") {
+		return "http://" + registry
+	}
+	return "https://" + registry
+}
@@ -0,0 +1,124 @@
+package promql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoData sorgu sonuç döndürmedi
+var ErrNoData = errors.New("sorgu sonuç döndürmedi")
+
+// Client Prometheus HTTP API'sinin anlık sorgu istemcisi
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// queryResponse /api/v1/query yanıtı
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// vectorSample vektör sonucundaki seri
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+// NewClient yeni Prometheus istemcisi oluşturur, timeout her sorgunun süre sınırıdır
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Sample vektör sonucundaki serinin label'ları ve değeri
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Query anlık sorguyu çalıştırır ve tek değer döndürür. Vektör birden fazla seri içerirse ilkinin değeri kullanılır
+func (c *Client) Query(ctx context.Context, query string) (float64, error) {
+	samples, err := c.QueryVector(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	if len(samples) == 0 {
+		return 0, ErrNoData
+	}
+	return samples[0].Value, nil
+}
+
+// QueryVector anlık sorguyu çalıştırır ve serileri döndürür, scalar sonuç label'sız tek seri olarak döner
+func (c *Client) QueryVector(ctx context.Context, query string) ([]Sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/query?"+url.Values{"query": {query}}.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response queryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("prometheus yanıtı parse edilemedi (%d): %v", resp.StatusCode, err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus hata döndürdü (%d): %s", resp.StatusCode, response.Error)
+	}
+
+	switch response.Data.ResultType {
+	case "scalar":
+		var value [2]interface{}
+		if err := json.Unmarshal(response.Data.Result, &value); err != nil {
+			return nil, fmt.Errorf("scalar sonuç parse edilemedi: %v", err)
+		}
+		parsed, err := sampleValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return []Sample{{Value: parsed}}, nil
+	case "vector":
+		var series []vectorSample
+		if err := json.Unmarshal(response.Data.Result, &series); err != nil {
+			return nil, fmt.Errorf("vektör sonuç parse edilemedi: %v", err)
+		}
+		samples := make([]Sample, 0, len(series))
+		for _, serie := range series {
+			parsed, err := sampleValue(serie.Value)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, Sample{Labels: serie.Metric, Value: parsed})
+		}
+		return samples, nil
+	default:
+		return nil, fmt.Errorf("desteklenmeyen sonuç tipi: %s", response.Data.ResultType)
+	}
+}
+
+// sampleValue [zaman, "değer"] çiftinin değerini çözer
+func sampleValue(value [2]interface{}) (float64, error) {
+	text, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("beklenmeyen örnek değeri: %v", value[1])
+	}
+	return strconv.ParseFloat(text, 64)
+}
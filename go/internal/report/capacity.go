@@ -0,0 +1,306 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Kapasite raporunun varsayılan değerleri
+const (
+	defaultHistoryWindow     = 14 * 24 * time.Hour
+	defaultHorizon           = 90 * 24 * time.Hour
+	defaultTargetUtilization = 0.8
+	currentUsageWindow       = 24 * time.Hour // Güncel kullanımın ortalandığı süre
+)
+
+// CapacitySource kapasite raporunun okuduğu küme durumu ve kullanım geçmişi
+type CapacitySource interface {
+	ListNodes(ctx context.Context) ([]*corev1.Node, error)
+	ListPods(ctx context.Context) ([]*corev1.Pod, error)
+	GetNodeHistory() *types.NodeMetricsHistory
+}
+
+// ResourceCapacity bir kaynağın (CPU core veya memory GB) grup toplamındaki durumu ve projeksiyonu
+type ResourceCapacity struct {
+	Allocatable  float64  `json:"allocatable"`
+	Requested    float64  `json:"requested"`
+	Usage        float64  `json:"usage"`                    // Son 24 saatin ortalama kullanımı
+	GrowthPerDay float64  `json:"growth_per_day"`           // Kullanımın günlük artışı (eğilim)
+	Projected    float64  `json:"projected"`                // Horizon sonundaki tahmini kullanım
+	Headroom     float64  `json:"headroom"`                 // Hedef kullanıma göre projeksiyon sonrası kalan kapasite
+	DaysToTarget *float64 `json:"days_to_target,omitempty"` // Hedef kullanıma horizon içinde ulaşılıyorsa kalan gün
+}
+
+// GroupCapacity node havuzu ve zone bazında kapasite durumu
+type GroupCapacity struct {
+	Pool    string           `json:"pool"`
+	Zone    string           `json:"zone"`
+	Nodes   int              `json:"nodes"`
+	Samples int              `json:"samples"` // Eğilimin hesaplandığı geçmiş dilim sayısı
+	CPU     ResourceCapacity `json:"cpu"`
+	Memory  ResourceCapacity `json:"memory_gb"`
+}
+
+// CapacityReport kapasite planlama raporu
+type CapacityReport struct {
+	GeneratedAt       time.Time       `json:"generated_at"`
+	HistoryDays       float64         `json:"history_days"`
+	HorizonDays       float64         `json:"horizon_days"`
+	TargetUtilization float64         `json:"target_utilization"`
+	Groups            []GroupCapacity `json:"groups"`
+}
+
+// CapacityPlanner küme geçmişinden node havuzu/zone bazında kapasite projeksiyonu üretir
+type CapacityPlanner struct {
+	source   CapacitySource
+	config   *types.CapacityReportConfig
+	configMu sync.RWMutex
+}
+
+// NewCapacityPlanner yeni kapasite planlayıcısı oluşturur
+func NewCapacityPlanner(source CapacitySource, reportConfig *types.CapacityReportConfig) *CapacityPlanner {
+	cfg := *reportConfig
+	return &CapacityPlanner{source: source, config: &cfg}
+}
+
+// UpdateConfig rapor konfigürasyonunu çalışma anında değiştirir
+func (p *CapacityPlanner) UpdateConfig(reportConfig *types.CapacityReportConfig) {
+	cfg := *reportConfig
+
+	p.configMu.Lock()
+	p.config = &cfg
+	p.configMu.Unlock()
+}
+
+// currentConfig varsayılanları uygulanmış geçerli konfigürasyonu döndürür
+func (p *CapacityPlanner) currentConfig() types.CapacityReportConfig {
+	p.configMu.RLock()
+	cfg := *p.config
+	p.configMu.RUnlock()
+
+	if cfg.HistoryWindow <= 0 {
+		cfg.HistoryWindow = defaultHistoryWindow
+	}
+	if cfg.Horizon <= 0 {
+		cfg.Horizon = defaultHorizon
+	}
+	if cfg.TargetUtilization <= 0 || cfg.TargetUtilization > 1 {
+		cfg.TargetUtilization = defaultTargetUtilization
+	}
+	return cfg
+}
+
+// capacityGroup rapor hesaplanırken grubun ara toplamları
+type capacityGroup struct {
+	result  GroupCapacity
+	nodes   []*corev1.Node
+	buckets map[time.Time]*usageBucket
+}
+
+// usageBucket bir geçmiş diliminde örnek veren node'ların kullanım ve kapasite toplamı
+type usageBucket struct {
+	cpuUsage, memUsage             float64
+	cpuAllocatable, memAllocatable float64
+}
+
+// Generate raporu now anına göre üretir
+func (p *CapacityPlanner) Generate(ctx context.Context, now time.Time) (*CapacityReport, error) {
+	cfg := p.currentConfig()
+
+	nodes, err := p.source.ListNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("node listesi alınamadı: %v", err)
+	}
+	pods, err := p.source.ListPods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+
+	groups := make(map[[2]string]*capacityGroup)
+	groupOf := make(map[string]*capacityGroup, len(nodes))
+	for _, node := range nodes {
+		key := [2]string{node.Labels[cfg.PoolLabel], node.Labels[cfg.ZoneLabel]}
+		group, ok := groups[key]
+		if !ok {
+			group = &capacityGroup{
+				result:  GroupCapacity{Pool: key[0], Zone: key[1]},
+				buckets: make(map[time.Time]*usageBucket),
+			}
+			groups[key] = group
+		}
+		group.nodes = append(group.nodes, node)
+		groupOf[node.Name] = group
+
+		cpu, memory := allocatable(node)
+		group.result.Nodes++
+		group.result.CPU.Allocatable += cpu
+		group.result.Memory.Allocatable += memory
+	}
+
+	// Node'a yerleşmiş ve sonlanmamış pod'ların istekleri
+	for _, pod := range pods {
+		group, ok := groupOf[pod.Spec.NodeName]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		cpu, memory := types.PodResourceRequests(pod)
+		group.result.CPU.Requested += cpu
+		group.result.Memory.Requested += memory
+	}
+
+	// Geçmiş: her dilimde örnek veren node'ların kullanım oranı (sonradan eklenen node'lar eğilimi bozmasın diye)
+	history := p.source.GetNodeHistory()
+	since := now.Add(-cfg.HistoryWindow)
+	for _, group := range groups {
+		for _, node := range group.nodes {
+			cpu, memory := allocatable(node)
+			for _, sample := range history.Samples(node.Name, since) {
+				bucket, ok := group.buckets[sample.Timestamp]
+				if !ok {
+					bucket = &usageBucket{}
+					group.buckets[sample.Timestamp] = bucket
+				}
+				bucket.cpuUsage += sample.CPU
+				bucket.memUsage += sample.Memory
+				bucket.cpuAllocatable += cpu
+				bucket.memAllocatable += memory
+			}
+		}
+	}
+
+	report := &CapacityReport{
+		GeneratedAt:       now,
+		HistoryDays:       cfg.HistoryWindow.Hours() / 24,
+		HorizonDays:       cfg.Horizon.Hours() / 24,
+		TargetUtilization: cfg.TargetUtilization,
+		Groups:            make([]GroupCapacity, 0, len(groups)),
+	}
+	for _, group := range groups {
+		group.project(now, &cfg)
+		report.Groups = append(report.Groups, group.result)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Pool != report.Groups[j].Pool {
+			return report.Groups[i].Pool < report.Groups[j].Pool
+		}
+		return report.Groups[i].Zone < report.Groups[j].Zone
+	})
+
+	return report, nil
+}
+
+// project grubun kullanım oranı serisinden eğilimi ve horizon sonundaki projeksiyonu hesaplar
+func (g *capacityGroup) project(now time.Time, cfg *types.CapacityReportConfig) {
+	times := make([]time.Time, 0, len(g.buckets))
+	for at := range g.buckets {
+		times = append(times, at)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	g.result.Samples = len(times)
+
+	days := make([]float64, len(times))
+	cpuRatios := make([]float64, len(times))
+	memRatios := make([]float64, len(times))
+	for i, at := range times {
+		bucket := g.buckets[at]
+		days[i] = at.Sub(now).Hours() / 24
+		cpuRatios[i] = ratio(bucket.cpuUsage, bucket.cpuAllocatable)
+		memRatios[i] = ratio(bucket.memUsage, bucket.memAllocatable)
+	}
+
+	horizonDays := cfg.Horizon.Hours() / 24
+	currentSince := -currentUsageWindow.Hours() / 24
+	g.result.CPU.projectRatios(days, cpuRatios, currentSince, horizonDays, cfg.TargetUtilization)
+	g.result.Memory.projectRatios(days, memRatios, currentSince, horizonDays, cfg.TargetUtilization)
+}
+
+// projectRatios kullanım oranı serisinden güncel kullanımı, eğilimi ve projeksiyonu doldurur.
+// days değerleri rapor anına göre gün cinsindendir (geçmiş için negatif)
+func (r *ResourceCapacity) projectRatios(days, ratios []float64, currentSince, horizonDays, target float64) {
+	if len(ratios) == 0 || r.Allocatable <= 0 {
+		r.Headroom = target*r.Allocatable - r.Usage
+		return
+	}
+
+	// Güncel kullanım: son 24 saatteki dilimlerin ortalaması, yoksa son dilim
+	var sum float64
+	var count int
+	for i := range days {
+		if days[i] >= currentSince {
+			sum += ratios[i]
+			count++
+		}
+	}
+	current := ratios[len(ratios)-1]
+	if count > 0 {
+		current = sum / float64(count)
+	}
+
+	slope := linearSlope(days, ratios)
+	projected := current + slope*horizonDays
+	if projected < 0 {
+		projected = 0
+	}
+
+	r.Usage = current * r.Allocatable
+	r.GrowthPerDay = slope * r.Allocatable
+	r.Projected = projected * r.Allocatable
+	r.Headroom = target*r.Allocatable - r.Projected
+
+	switch {
+	case current >= target:
+		zero := 0.0
+		r.DaysToTarget = &zero
+	case slope > 0 && (target-current)/slope <= horizonDays:
+		remaining := (target - current) / slope
+		r.DaysToTarget = &remaining
+	}
+}
+
+// linearSlope en küçük kareler doğrusunun eğimini döndürür, tek noktada 0
+func linearSlope(x, y []float64) float64 {
+	n := float64(len(x))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+		sumXY += x[i] * y[i]
+		sumXX += x[i] * x[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// ratio kullanımın kapasiteye oranını döndürür
+func ratio(usage, capacity float64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return usage / capacity
+}
+
+// allocatable node'un ayrılabilir CPU (core) ve memory (GB) kapasitesini döndürür
+func allocatable(node *corev1.Node) (float64, float64) {
+	var cpu, memory float64
+	if quantity, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+		cpu = float64(quantity.MilliValue()) / 1000.0
+	}
+	if quantity, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+		memory = float64(quantity.Value()) / (1024 * 1024 * 1024) // GB
+	}
+	return cpu, memory
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// capacityCSVHeader CSV çıktısının kolonları, her grup tek satırdır
+var capacityCSVHeader = []string{
+	"pool", "zone", "nodes", "samples",
+	"cpu_allocatable", "cpu_requested", "cpu_usage", "cpu_growth_per_day", "cpu_projected", "cpu_headroom", "cpu_days_to_target",
+	"memory_allocatable_gb", "memory_requested_gb", "memory_usage_gb", "memory_growth_per_day_gb", "memory_projected_gb", "memory_headroom_gb", "memory_days_to_target",
+}
+
+// WriteCapacityReport raporu verilen formatta (json veya csv) yazar
+func WriteCapacityReport(w io.Writer, report *CapacityReport, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "csv":
+		return writeCapacityCSV(w, report)
+	default:
+		return fmt.Errorf("desteklenmeyen rapor formatı: %s", format)
+	}
+}
+
+// writeCapacityCSV raporu grup başına bir satır olarak CSV yazar
+func writeCapacityCSV(w io.Writer, report *CapacityReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(capacityCSVHeader); err != nil {
+		return err
+	}
+
+	for _, group := range report.Groups {
+		row := []string{group.Pool, group.Zone, strconv.Itoa(group.Nodes), strconv.Itoa(group.Samples)}
+		row = append(row, resourceColumns(&group.CPU)...)
+		row = append(row, resourceColumns(&group.Memory)...)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// resourceColumns kaynağın CSV kolonlarını döndürür, hedefe ulaşma süresi yoksa boş bırakılır
+func resourceColumns(r *ResourceCapacity) []string {
+	daysToTarget := ""
+	if r.DaysToTarget != nil {
+		daysToTarget = formatFloat(*r.DaysToTarget)
+	}
+	return []string{
+		formatFloat(r.Allocatable),
+		formatFloat(r.Requested),
+		formatFloat(r.Usage),
+		formatFloat(r.GrowthPerDay),
+		formatFloat(r.Projected),
+		formatFloat(r.Headroom),
+		daysToTarget,
+	}
+}
+
+// formatFloat CSV için iki basamaklı sayı üretir
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
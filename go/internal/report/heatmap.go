@@ -0,0 +1,181 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Heatmap metrikleri
+const (
+	HeatmapCPU      = "cpu"      // CPU kullanımı / ayrılabilir CPU
+	HeatmapMemory   = "memory"   // Memory kullanımı / ayrılabilir memory
+	HeatmapFailures = "failures" // Başarısız pod örnekleri / tüm pod örnekleri
+)
+
+// Heatmap sorgusunun varsayılanları ve sınırları
+const (
+	defaultHeatmapRange  = 24 * time.Hour
+	defaultHeatmapBucket = time.Hour
+	maxHeatmapBuckets    = 1000
+)
+
+// ErrInvalidHeatmapQuery heatmap sorgusu geçersiz
+var ErrInvalidHeatmapQuery = errors.New("geçersiz heatmap sorgusu")
+
+// HeatmapSource heatmap'in okuduğu node listesi ve cache'ler
+type HeatmapSource interface {
+	ListNodes(ctx context.Context) ([]*corev1.Node, error)
+	GetNodeHistory() *types.NodeMetricsHistory
+	GetPodCache() types.PodCache
+}
+
+// HeatmapQuery heatmap'in metriği, geriye dönük süresi ve dilim genişliği
+type HeatmapQuery struct {
+	Metric string
+	Range  time.Duration
+	Bucket time.Duration
+}
+
+// HeatmapRow node'un dilim değerleri, verisi olmayan dilimler null döner
+type HeatmapRow struct {
+	NodeName string     `json:"node_name"`
+	Values   []*float64 `json:"values"`
+}
+
+// Heatmap node × zaman matrisi, Values[i] Buckets[i] ile başlayan dilimin değeridir
+type Heatmap struct {
+	Metric      string       `json:"metric"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Bucket      string       `json:"bucket"`
+	Buckets     []time.Time  `json:"buckets"`
+	Nodes       []HeatmapRow `json:"nodes"`
+}
+
+// heatmapCell dilimdeki değerlerin toplamı (ortalama için)
+type heatmapCell struct {
+	sum   float64
+	count float64
+}
+
+// ParseRange "7d" gibi gün birimini de kabul eden süre çözer
+func ParseRange(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("geçersiz süre: %s", value)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(value)
+}
+
+// BuildHeatmap cache'lerdeki node geçmişinden now'a kadar olan heatmap'i üretir.
+// CPU ve memory node kullanım geçmişinden, başarısızlık oranı pod metrik cache'inden okunur
+func BuildHeatmap(ctx context.Context, source HeatmapSource, query HeatmapQuery, now time.Time) (*Heatmap, error) {
+	if query.Metric == "" {
+		query.Metric = HeatmapCPU
+	}
+	if query.Range == 0 {
+		query.Range = defaultHeatmapRange
+	}
+	if query.Bucket == 0 {
+		query.Bucket = defaultHeatmapBucket
+	}
+
+	switch query.Metric {
+	case HeatmapCPU, HeatmapMemory, HeatmapFailures:
+	default:
+		return nil, fmt.Errorf("%w: bilinmeyen metrik %s", ErrInvalidHeatmapQuery, query.Metric)
+	}
+	if query.Range < 0 || query.Bucket < 0 {
+		return nil, fmt.Errorf("%w: süreler pozitif olmalı", ErrInvalidHeatmapQuery)
+	}
+	history := source.GetNodeHistory()
+	if query.Metric != HeatmapFailures && history != nil && query.Bucket < history.Resolution() {
+		return nil, fmt.Errorf("%w: bucket geçmiş çözünürlüğünden (%s) küçük olamaz", ErrInvalidHeatmapQuery, history.Resolution())
+	}
+	columns := int((query.Range + query.Bucket - 1) / query.Bucket)
+	if columns > maxHeatmapBuckets {
+		return nil, fmt.Errorf("%w: en fazla %d dilim istenebilir (%d)", ErrInvalidHeatmapQuery, maxHeatmapBuckets, columns)
+	}
+
+	nodes, err := source.ListNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("node listesi alınamadı: %v", err)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	// Son dilim now'u içerir, dilimler bucket'a hizalıdır
+	end := now.Truncate(query.Bucket).Add(query.Bucket)
+	start := end.Add(-time.Duration(columns) * query.Bucket)
+	heatmap := &Heatmap{
+		Metric:      query.Metric,
+		GeneratedAt: now,
+		Bucket:      query.Bucket.String(),
+		Buckets:     make([]time.Time, columns),
+		Nodes:       make([]HeatmapRow, 0, len(nodes)),
+	}
+	for i := range heatmap.Buckets {
+		heatmap.Buckets[i] = start.Add(time.Duration(i) * query.Bucket)
+	}
+
+	podCache := source.GetPodCache()
+	for _, node := range nodes {
+		cells := make([]heatmapCell, columns)
+		add := func(at time.Time, value float64) {
+			if at.Before(start) || !at.Before(end) {
+				return
+			}
+			cell := &cells[int(at.Sub(start)/query.Bucket)]
+			cell.sum += value
+			cell.count++
+		}
+
+		switch query.Metric {
+		case HeatmapCPU, HeatmapMemory:
+			cpu, memory := allocatable(node)
+			if history == nil || (query.Metric == HeatmapCPU && cpu <= 0) || (query.Metric == HeatmapMemory && memory <= 0) {
+				break
+			}
+			for _, sample := range history.Samples(node.Name, start) {
+				if query.Metric == HeatmapCPU {
+					add(sample.Timestamp, sample.CPU/cpu)
+				} else {
+					add(sample.Timestamp, sample.Memory/memory)
+				}
+			}
+		case HeatmapFailures:
+			if podCache == nil {
+				break
+			}
+			for _, metric := range podCache.GetNodeMetrics(node.Name) {
+				// Chaos deneyinin hataları kararlılık istatistiklerindeki gibi sayılmaz
+				failed := 0.0
+				if metric.Status == "Failed" && !metric.Chaos {
+					failed = 1
+				}
+				add(metric.Timestamp, failed)
+			}
+		}
+
+		row := HeatmapRow{NodeName: node.Name, Values: make([]*float64, columns)}
+		for i := range cells {
+			if cells[i].count > 0 {
+				value := cells[i].sum / cells[i].count
+				row.Values[i] = &value
+			}
+		}
+		heatmap.Nodes = append(heatmap.Nodes, row)
+	}
+
+	return heatmap, nil
+}
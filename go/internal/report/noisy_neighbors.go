@@ -0,0 +1,69 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// NoisyNeighborSource noisy neighbor raporunun okuduğu pod kullanım penceresi
+type NoisyNeighborSource interface {
+	GetNeighborUsage() *types.NeighborUsageTracker
+}
+
+// NoisyWorkload patlamaları komşu pod'ları bozan pod'ları olan iş yükü
+type NoisyWorkload struct {
+	types.WorkloadRef
+	MaxCorrelation float64          `json:"max_correlation"`
+	Nodes          []string         `json:"nodes"`
+	Pods           []types.NoisyPod `json:"pods"`
+}
+
+// NoisyNeighborReport noisy neighbor iş yükleri, en yüksek korelasyon önce
+type NoisyNeighborReport struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Workloads   []NoisyWorkload `json:"workloads"`
+}
+
+// NoisyNeighbors namespace filtresine uyan noisy neighbor pod'larını iş yükü bazında gruplar
+func NoisyNeighbors(source NoisyNeighborSource, namespaces types.NamespaceFilter, now time.Time) *NoisyNeighborReport {
+	report := &NoisyNeighborReport{GeneratedAt: now, Workloads: []NoisyWorkload{}}
+
+	index := make(map[types.WorkloadRef]int)
+	for _, pod := range source.GetNeighborUsage().NoisyPods(namespaces) {
+		i, ok := index[pod.Workload]
+		if !ok {
+			i = len(report.Workloads)
+			index[pod.Workload] = i
+			report.Workloads = append(report.Workloads, NoisyWorkload{WorkloadRef: pod.Workload})
+		}
+
+		workload := &report.Workloads[i]
+		workload.Pods = append(workload.Pods, pod)
+		if pod.Correlation > workload.MaxCorrelation {
+			workload.MaxCorrelation = pod.Correlation
+		}
+		if !containsString(workload.Nodes, pod.NodeName) {
+			workload.Nodes = append(workload.Nodes, pod.NodeName)
+		}
+	}
+
+	for i := range report.Workloads {
+		sort.Strings(report.Workloads[i].Nodes)
+	}
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		return report.Workloads[i].MaxCorrelation > report.Workloads[j].MaxCorrelation
+	})
+	return report
+}
+
+// containsString listede değer var mı
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
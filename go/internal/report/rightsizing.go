@@ -0,0 +1,197 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// Rightsizing önerilerinin varsayılan değerleri (VPA recommender'a yakın)
+const (
+	defaultCPUPercentile    = 0.9
+	defaultMemoryPercentile = 0.95
+	defaultSafetyMargin     = 0.15
+	defaultMinCPU           = 0.025 // core
+	defaultMinMemoryGB      = 0.25
+	defaultTolerance        = 0.2
+	defaultMinSamples       = 60
+	lowerBoundPercentile    = 0.5
+	upperBoundPercentile    = 0.99
+)
+
+// Önerilen istek değişiklikleri
+const (
+	ActionKeep     = "keep"
+	ActionDecrease = "decrease"
+	ActionIncrease = "increase"
+)
+
+// RightsizingSource önerilerin okuduğu iş yükü kullanım geçmişi
+type RightsizingSource interface {
+	GetWorkloadUsage() *types.WorkloadUsageTracker
+}
+
+// ResourceAmounts CPU (core) ve memory (GB) miktarları
+type ResourceAmounts struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory_gb"`
+}
+
+// ContainerRecommendation container için VPA benzeri istek önerisi
+type ContainerRecommendation struct {
+	Container    string          `json:"container"`
+	Samples      int             `json:"samples"`
+	Confident    bool            `json:"confident"` // Yeterli örnek var
+	Request      ResourceAmounts `json:"request"`
+	Target       ResourceAmounts `json:"target"`
+	LowerBound   ResourceAmounts `json:"lower_bound"`
+	UpperBound   ResourceAmounts `json:"upper_bound"`
+	CPUAction    string          `json:"cpu_action"`
+	MemoryAction string          `json:"memory_action"`
+}
+
+// WorkloadRecommendation iş yükünün container önerileri ve pod başına isteklerin hedefe göre farkı
+type WorkloadRecommendation struct {
+	types.WorkloadRef
+	Pods       int                       `json:"pods"`
+	LastSeen   time.Time                 `json:"last_seen"`
+	Savings    ResourceAmounts           `json:"savings"` // (istek - hedef) x pod, negatifse iş yükü daha fazla istemeli
+	Containers []ContainerRecommendation `json:"containers"`
+}
+
+// RightsizingReport iş yükü bazında istek önerileri
+type RightsizingReport struct {
+	GeneratedAt  time.Time                `json:"generated_at"`
+	TotalSavings ResourceAmounts          `json:"total_savings"`
+	Workloads    []WorkloadRecommendation `json:"workloads"`
+}
+
+// RightsizingRecommender toplanan pod kullanımından VPA benzeri istek önerileri üretir
+type RightsizingRecommender struct {
+	source   RightsizingSource
+	config   *types.RightsizingConfig
+	configMu sync.RWMutex
+}
+
+// NewRightsizingRecommender yeni rightsizing önerici oluşturur
+func NewRightsizingRecommender(source RightsizingSource, rightsizingConfig *types.RightsizingConfig) *RightsizingRecommender {
+	cfg := *rightsizingConfig
+	return &RightsizingRecommender{source: source, config: &cfg}
+}
+
+// UpdateConfig öneri konfigürasyonunu çalışma anında değiştirir
+func (r *RightsizingRecommender) UpdateConfig(rightsizingConfig *types.RightsizingConfig) {
+	cfg := *rightsizingConfig
+
+	r.configMu.Lock()
+	r.config = &cfg
+	r.configMu.Unlock()
+}
+
+// currentConfig varsayılanları uygulanmış geçerli konfigürasyonu döndürür
+func (r *RightsizingRecommender) currentConfig() types.RightsizingConfig {
+	r.configMu.RLock()
+	cfg := *r.config
+	r.configMu.RUnlock()
+
+	if cfg.CPUPercentile <= 0 || cfg.CPUPercentile > 1 {
+		cfg.CPUPercentile = defaultCPUPercentile
+	}
+	if cfg.MemoryPercentile <= 0 || cfg.MemoryPercentile > 1 {
+		cfg.MemoryPercentile = defaultMemoryPercentile
+	}
+	if cfg.SafetyMargin < 0 {
+		cfg.SafetyMargin = defaultSafetyMargin
+	}
+	if cfg.MinCPU <= 0 {
+		cfg.MinCPU = defaultMinCPU
+	}
+	if cfg.MinMemoryGB <= 0 {
+		cfg.MinMemoryGB = defaultMinMemoryGB
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = defaultTolerance
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultMinSamples
+	}
+	return cfg
+}
+
+// Recommend namespace filtresine uyan iş yükleri için önerileri now anına göre üretir,
+// en çok kaynak geri kazandıran iş yükü önce gelir
+func (r *RightsizingRecommender) Recommend(namespaces types.NamespaceFilter, now time.Time) *RightsizingReport {
+	cfg := r.currentConfig()
+	summaries := r.source.GetWorkloadUsage().Summaries(namespaces)
+
+	report := &RightsizingReport{
+		GeneratedAt: now,
+		Workloads:   make([]WorkloadRecommendation, 0, len(summaries)),
+	}
+	for i := range summaries {
+		summary := &summaries[i]
+		workload := WorkloadRecommendation{
+			WorkloadRef: summary.Workload,
+			Pods:        summary.Pods,
+			LastSeen:    summary.LastSeen,
+			Containers:  make([]ContainerRecommendation, len(summary.Containers)),
+		}
+		for j := range summary.Containers {
+			recommendation := recommendContainer(&summary.Containers[j], &cfg)
+			workload.Containers[j] = recommendation
+			workload.Savings.CPU += (recommendation.Request.CPU - recommendation.Target.CPU) * float64(summary.Pods)
+			workload.Savings.Memory += (recommendation.Request.Memory - recommendation.Target.Memory) * float64(summary.Pods)
+		}
+
+		report.TotalSavings.CPU += workload.Savings.CPU
+		report.TotalSavings.Memory += workload.Savings.Memory
+		report.Workloads = append(report.Workloads, workload)
+	}
+
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		return report.Workloads[i].Savings.CPU > report.Workloads[j].Savings.CPU
+	})
+	return report
+}
+
+// recommendContainer container'ın kullanım dağılımından hedef ve sınırları hesaplar
+func recommendContainer(usage *types.ContainerUsageSummary, cfg *types.RightsizingConfig) ContainerRecommendation {
+	margin := 1 + cfg.SafetyMargin
+	recommendation := ContainerRecommendation{
+		Container: usage.Container,
+		Samples:   usage.Samples,
+		Confident: usage.Samples >= cfg.MinSamples,
+		Request:   ResourceAmounts{CPU: usage.CPURequest, Memory: usage.MemoryRequest},
+		Target: ResourceAmounts{
+			CPU:    math.Max(usage.CPU.Percentile(cfg.CPUPercentile)*margin, cfg.MinCPU),
+			Memory: math.Max(usage.Memory.Percentile(cfg.MemoryPercentile)*margin, cfg.MinMemoryGB),
+		},
+		LowerBound: ResourceAmounts{
+			CPU:    math.Max(usage.CPU.Percentile(lowerBoundPercentile)*margin, cfg.MinCPU),
+			Memory: math.Max(usage.Memory.Percentile(lowerBoundPercentile)*margin, cfg.MinMemoryGB),
+		},
+		UpperBound: ResourceAmounts{
+			CPU:    math.Max(usage.CPU.Percentile(upperBoundPercentile)*margin, cfg.MinCPU),
+			Memory: math.Max(usage.Memory.Percentile(upperBoundPercentile)*margin, cfg.MinMemoryGB),
+		},
+	}
+
+	recommendation.CPUAction = requestAction(recommendation.Request.CPU, recommendation.Target.CPU, cfg.Tolerance)
+	recommendation.MemoryAction = requestAction(recommendation.Request.Memory, recommendation.Target.Memory, cfg.Tolerance)
+	return recommendation
+}
+
+// requestAction istek hedeften tolerans kadar saparsa artırma veya azaltma önerir
+func requestAction(request, target, tolerance float64) string {
+	switch {
+	case request < target*(1-tolerance):
+		return ActionIncrease
+	case request > target*(1+tolerance):
+		return ActionDecrease
+	default:
+		return ActionKeep
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// podAdjustments pod'a özgü skor ayarlarının girdileri. Pod başına bir kez hesaplanır ve her aday node'a apply ile
+// uygulanır; tam skorlama (scoreCandidates) ve hazır sıralamadan tahmin (predictFromRanking) aynı zinciri kullanır
+type podAdjustments struct {
+	request          *podRequest
+	overShareTeam    *TeamUsageStatus
+	latencySensitive bool
+	heavyIO          bool
+	peers            *communicationPeers
+	shapes           []podShape
+	burst            *burstContext
+	seasonal         *seasonalContext
+	locality         *podLocality
+	hints            *podHints
+}
+
+// podAdjustmentsFor pod'un skor ayarı girdilerini snapshot'a göre hesaplar
+func (as *AIScheduler) podAdjustmentsFor(pod *corev1.Pod, snapshot *clusterSnapshot, request *podRequest, hints *podHints) *podAdjustments {
+	return &podAdjustments{
+		request:          request,
+		overShareTeam:    as.overShareTeam(pod),
+		latencySensitive: as.latencySensitive(pod),
+		heavyIO:          as.ioHeavy(pod),
+		peers:            as.peersFor(pod),
+		shapes:           as.fragmentationShapes(snapshot),
+		burst:            as.burstContextFor(pod, snapshot),
+		seasonal:         as.seasonalContextFor(pod),
+		locality:         as.dataLocality(pod),
+		hints:            hints,
+	}
+}
+
+// maxBonus ayarların skoru en fazla ne kadar yükseltebileceğini döndürür. Cezalar skoru sadece düşürür, yükseltebilen
+// tek ayar veri yerelliği bonusudur
+func (as *AIScheduler) maxBonus(adjustments *podAdjustments) float64 {
+	return as.maxLocalityBonus(adjustments.locality)
+}
+
+// applyAdjustments node'un skoruna pod'a özgü cezaları ve bonusları uygular, gerekçeleri reason'a ekler
+func (as *AIScheduler) applyAdjustments(adjustments *podAdjustments, info *nodeInfo, score float64, reason string) (float64, string) {
+	node := info.node
+	penalize := func(penalty float64, why string) {
+		if penalty > 0 {
+			score -= penalty
+			reason += " - " + why
+		}
+	}
+
+	// Takım payı aşıldıysa çekişmeli node'larda fairness cezası
+	penalize(as.fairnessPenalty(adjustments.overShareTeam, node))
+
+	// Gecikmeye duyarlı pod'lar yavaş başlatan node'larda cezalandırılır
+	penalize(as.startupLatencyPenalty(adjustments.latencySensitive, node))
+
+	// IO yoğun pod'lar diskleri doygun node'larda cezalandırılır
+	penalize(as.storagePressurePenalty(adjustments.heavyIO, node))
+
+	// Konuştuğu pod'lara gecikmesi yüksek node'lar cezalandırılır
+	penalize(as.communicationPenalty(adjustments.peers, node))
+
+	// Boş kapasiteyi bekleyen pod'lara sığmayan dilimlere bölen yerleşimler cezalandırılır
+	penalize(as.fragmentationPenalty(adjustments.shapes, adjustments.request, info))
+
+	// CronJob patlamaları node'lara yayılır, yaklaşan patlamalar için boş kapasite ayrılır
+	penalize(as.burstPenalty(adjustments.burst, adjustments.request, info))
+
+	// Pod'un yaşam süresi boyunca döngüsel olarak yoğunlaşan node'lar cezalandırılır
+	penalize(as.seasonalPenalty(adjustments.seasonal, node))
+
+	// StatefulSet pod'ları verisinin veya önceki kopyasının bulunduğu node'a yönlendirilir
+	if bonus, why := as.localityBonus(adjustments.locality, node); bonus > 0 {
+		score += bonus
+		reason += " + " + why
+	}
+
+	// Pod annotation ipuçları: kaçınılan veya kararlılığı yetersiz node'lar
+	penalize(as.hintPenalty(adjustments.hints, node))
+
+	return score, reason
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// testCollector testte scheduler'ın okuduğu takipçileri sağlar, verilmeyenler devre dışıdır
+type testCollector struct {
+	podCache *types.PodMetricsCache
+	usage    *types.NamespaceUsageTracker
+	startup  *types.StartupLatencyTracker
+}
+
+func (c *testCollector) GetMetricsChannel() <-chan interface{}             { return nil }
+func (c *testCollector) GetPodCache() types.PodCache                       { return c.podCache }
+func (c *testCollector) GetNamespaceUsage() *types.NamespaceUsageTracker   { return c.usage }
+func (c *testCollector) GetNodeHistory() *types.NodeMetricsHistory         { return nil }
+func (c *testCollector) GetStartupLatency() *types.StartupLatencyTracker   { return c.startup }
+func (c *testCollector) GetNeighborUsage() *types.NeighborUsageTracker     { return nil }
+func (c *testCollector) GetNodeFlaps() *types.NodeFlapTracker              { return nil }
+func (c *testCollector) GetNodeReadiness() *types.NodeReadinessTracker     { return nil }
+func (c *testCollector) GetClusterEvents() *types.ClusterEventLog          { return nil }
+func (c *testCollector) GetExternalSignals() *types.ExternalSignalStore    { return nil }
+func (c *testCollector) GetAppSLI() *types.AppSLITracker                   { return nil }
+func (c *testCollector) GetMeshHealth() *types.MeshHealthTracker           { return nil }
+func (c *testCollector) GetNodeLatency() *types.NodeLatencyMatrix          { return nil }
+func (c *testCollector) GetStoragePressure() *types.StoragePressureTracker { return nil }
+
+// testSource sabit node, pod ve kullanım değerlerinden oluşan küme kaynağı
+type testSource struct {
+	nodes []*corev1.Node
+	pods  []*corev1.Pod
+	usage map[string][2]float64 // node -> CPU (core), memory (GB)
+}
+
+func (s *testSource) Nodes() []*corev1.Node { return s.nodes }
+func (s *testSource) Pods() []*corev1.Pod   { return s.pods }
+func (s *testSource) Generation() uint64    { return 1 }
+
+func (s *testSource) Node(name string) (*corev1.Node, bool) {
+	for _, node := range s.nodes {
+		if node.Name == name {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+func (s *testSource) Pod(namespace, name string) (*corev1.Pod, bool) {
+	for _, pod := range s.pods {
+		if pod.Namespace == namespace && pod.Name == name {
+			return pod, true
+		}
+	}
+	return nil, false
+}
+
+func (s *testSource) NodeUsage(nodeName string) (float64, float64, bool) {
+	usage, ok := s.usage[nodeName]
+	return usage[0], usage[1], ok
+}
+
+// testNode 8 core, 32GB kapasiteli Ready node
+func testNode(name string) *corev1.Node {
+	capacity := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("8"),
+		corev1.ResourceMemory: resource.MustParse("32Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Capacity:    capacity,
+			Allocatable: capacity,
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+// startedPod node'a bağlandıktan startup kadar sonra container'ı başlamış pod
+func startedPod(name, nodeName string, scheduled time.Time, startup time.Duration) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: k8stypes.UID(name)},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(scheduled)}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(scheduled.Add(startup))}},
+			}},
+		},
+	}
+}
+
+func TestRankedPredictionMatchesFullScoring(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// fast daha yoğun olduğu için sıralamada slow'un gerisinde kalır, ama slow pod'ları hedefin çok üstünde başlatır
+	startup := types.NewStartupLatencyTracker(&types.StartupLatencyConfig{})
+	for i := 0; i < 3; i++ {
+		startup.Record(startedPod(fmt.Sprintf("slow-%d", i), "slow", now.Add(-time.Hour), 40*time.Second), now)
+		startup.Record(startedPod(fmt.Sprintf("fast-%d", i), "fast", now.Add(-time.Hour), 2*time.Second), now)
+	}
+
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{defaultSensitiveLabel: "true"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}},
+		}}},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	source := &testSource{
+		nodes: []*corev1.Node{testNode("slow"), testNode("fast")},
+		pods:  []*corev1.Pod{pending},
+		usage: map[string][2]float64{"slow": {1, 4}, "fast": {4, 16}},
+	}
+
+	collector := &testCollector{
+		podCache: types.NewPodMetricsCache(),
+		usage:    types.NewNamespaceUsageTracker(),
+		startup:  startup,
+	}
+	cfg := types.SchedulerConfig{
+		Mode:               ModeHeuristic,
+		Scoring:            types.ScoringConfig{CPUWeight: 30, MemoryWeight: 30},
+		IncrementalScoring: true,
+		StartupLatency:     types.StartupLatencyScoringConfig{Enabled: true, Weight: 50},
+	}
+	aiScheduler := NewAIScheduler(nil, collector, &cfg)
+	aiScheduler.SetClusterSource(source)
+	aiScheduler.SetClock(types.NewManualClock(now))
+
+	ctx := context.Background()
+	ranked, err := aiScheduler.PredictFromRanking(ctx, "web", "default")
+	if err != nil {
+		t.Fatalf("PredictFromRanking: %v", err)
+	}
+	full, err := aiScheduler.PredictBestNode(ctx, "web", "default")
+	if err != nil {
+		t.Fatalf("PredictBestNode: %v", err)
+	}
+
+	if full.NodeName != "fast" {
+		t.Errorf("tam skorlama %s seçti, beklenen fast (slow başlatma gecikmesiyle cezalandırılmalı)", full.NodeName)
+	}
+	if ranked.NodeName != full.NodeName || ranked.Score != full.Score {
+		t.Errorf("sıralamadan tahmin %s (%.4f), tam skorlama %s (%.4f)", ranked.NodeName, ranked.Score, full.NodeName, full.Score)
+	}
+}
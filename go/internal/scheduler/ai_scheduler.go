@@ -1,43 +1,120 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"ai-scheduler/internal/features"
 	"ai-scheduler/internal/types"
 
-	"bytes"
-
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NodeScore node skor bilgisi
 type NodeScore struct {
-	NodeName string  `json:"node_name"`
-	Score    float64 `json:"score"`
-	Reason   string  `json:"reason"`
+	NodeName    string  `json:"node_name"`
+	Score       float64 `json:"score"`
+	Reason      string  `json:"reason"`
+	ObserveOnly bool    `json:"observe_only,omitempty"`
+	Ranked      bool    `json:"ranked,omitempty"` // Hazır sıralamadan cevaplandı
+	// Stale kararın skorlama girdilerinden bayat olanlar (scheduler.staleness flag modunda)
+	Stale *Staleness `json:"stale,omitempty"`
+	// AISkipped AI analizi bütçesinde dönmediği için karar heuristik skorla verildi
+	AISkipped bool `json:"ai_skipped,omitempty"`
 }
 
+// ErrNamespaceOutOfScope namespace scheduling kapsamı dışında
+var ErrNamespaceOutOfScope = errors.New("namespace scheduling kapsamı dışında")
+
+// aiBlendCandidates AI analizine gönderilen en iyi heuristik aday sayısı
+const aiBlendCandidates = 3
+
 // Collector interface'i tanımla
 // Sadece gerekli metotları içersin (ör: GetMetricsChannel)
 type Collector interface {
 	GetMetricsChannel() <-chan interface{}
-	GetPodCache() *types.PodMetricsCache
+	GetPodCache() types.PodCache
+	GetNamespaceUsage() *types.NamespaceUsageTracker
+	GetNodeHistory() *types.NodeMetricsHistory
+	GetStartupLatency() *types.StartupLatencyTracker
+	GetNeighborUsage() *types.NeighborUsageTracker
+	GetNodeFlaps() *types.NodeFlapTracker
+	GetNodeReadiness() *types.NodeReadinessTracker
+	GetClusterEvents() *types.ClusterEventLog
+	GetExternalSignals() *types.ExternalSignalStore
+	GetAppSLI() *types.AppSLITracker
+	GetMeshHealth() *types.MeshHealthTracker
+	GetNodeLatency() *types.NodeLatencyMatrix
+	GetStoragePressure() *types.StoragePressureTracker
+}
+
+// CredentialProvider AI API kimlik bilgisini sağlar (ör: Secret deposu)
+type CredentialProvider interface {
+	AIAPIToken() string
 }
 
 // AIScheduler AI tabanlı scheduler
 type AIScheduler struct {
-	k8sClient     *types.K8sClient
-	metricsClient *types.MetricsClient
-	collector     Collector
-	aiAPI         string
-	config        *types.SchedulerConfig
-	podCache      *types.PodMetricsCache
+	k8sClient       *types.K8sClient
+	metricsClient   *types.MetricsClient
+	collector       Collector
+	config          *types.SchedulerConfig // Etkin politika uygulanmış konfigürasyon
+	baseConfig      *types.SchedulerConfig
+	configMu        sync.RWMutex
+	calendar        *temporalCalendar
+	policies        []scheduledPolicy
+	policy          PolicyStatus
+	tunedWeights    map[string]float64 // Otomatik ayarlanan skorlama ağırlıkları, baseConfig'in üzerine uygulanır
+	profiles        profileState
+	podCache        types.PodCache
+	credentials     CredentialProvider
+	featureGate     *features.Gate
+	source          types.ClusterSource
+	recorder        Recorder
+	journal         Journal
+	events          types.EventPublisher
+	clock           types.Clock
+	scores          scoreCache
+	ranking         rankedIndex
+	forecasts       forecastCache
+	seasonality     seasonalityCache
+	decisions       decisionHistory
+	decisionStore   decisionStore
+	outcomes        outcomeCorrelator
+	comparison      comparisonTracker
+	audit           auditTracker
+	remediation     remediationTracker
+	hints           capacityHints
+	cache           schedulerCache
+	overBudget      atomic.Uint64
+	aiSkipped       atomic.Uint64
+	mode            modeState
+	degradation     degradationState
+	incarnations    incarnationTracker
+	bursts          burstTracker
+	pending         pendingTracker
+	binding         bindingQueue
+	templates       templateFilterCache
+	templateHistory templateHistory
+	tuning          weightTuner
+	cooldowns       cooldownTracker
+	smoothing       scoreSmoother
+	csiLimits       csiLimitCache
+	errorSinks      types.ErrorSinks
+	runCtx          atomic.Pointer[context.Context] // Start'a verilen context
+	platforms       PlatformResolver
+	placements      placementTracker
 }
 
 // NewAIScheduler yeni AI scheduler oluşturur
@@ -50,22 +127,156 @@ func NewAIScheduler(k8sClient *types.K8sClient, collector Collector, schedulerCo
 	// Collector'dan PodMetricsCache'i al
 	podCache := collector.GetPodCache()
 
-	return &AIScheduler{
+	as := &AIScheduler{
 		k8sClient:     k8sClient,
 		metricsClient: metricsClient,
 		collector:     collector,
-		aiAPI:         schedulerConfig.AIAPIURL,
-		config:        schedulerConfig,
+		baseConfig:    schedulerConfig,
+		calendar:      newTemporalCalendar(&schedulerConfig.Temporal),
+		policies:      compilePolicies(schedulerConfig.Policies),
 		podCache:      podCache,
+		clock:         types.RealClock,
 	}
+	as.loadProfiles(nil, schedulerConfig)
+	as.resolvePolicy(as.now(), true)
+	as.mode.set(schedulerConfig.ObserveOnly, "konfigürasyon")
+	podCache.SetDecayHalfLife(decayHalfLife(&schedulerConfig.ReliabilityDecay))
+
+	return as
 }
 
 // Start AI scheduler'ı başlatır
 func (as *AIScheduler) Start(ctx context.Context) {
 	logrus.Info("AI Scheduler başlatılıyor...")
+	as.runCtx.Store(&ctx)
 
-	// Metrik dinleyicisi
+	// Metrik dinleyicisi, metrik tazeliği başlangıçtan itibaren ölçülür
+	as.markStarted(as.now())
 	go as.metricsListener(ctx)
+
+	// Zamanlanmış politikalar
+	go as.policyLoop(ctx)
+
+	// Karar sonuçlarının etiketlenmesi
+	go as.outcomeLoop(ctx)
+
+	// Varsayılan scheduler ile karşılaştırma
+	go as.comparisonLoop(ctx)
+
+	// Heuristik kararların AI'ya denetim için gönderilmesi
+	go as.auditLoop(ctx)
+
+	// Kararsız node'lara taint önerilmesi veya uygulanması
+	go as.remediationLoop(ctx)
+
+	// Skorlama ağırlıklarının karar sonuçlarına göre ayarı
+	go as.tuningLoop(ctx)
+
+	// Bu scheduler'a atanmış bekleyen pod'ların bağlanması
+	go as.bindingLoop(ctx)
+
+	// Karar geçmişinin geçmiş deposuna yazılması
+	go as.decisionStoreLoop(ctx)
+
+	if as.HeuristicOnly() {
+		logrus.Info("Heuristic modu: AI API çağrıları kapalı")
+	}
+}
+
+// UpdateConfig scheduler konfigürasyonunu çalışma anında değiştirir
+func (as *AIScheduler) UpdateConfig(schedulerConfig *types.SchedulerConfig) {
+	cfg := *schedulerConfig
+	calendar := newTemporalCalendar(&cfg.Temporal)
+	policies := compilePolicies(cfg.Policies)
+	if !validMode(cfg.Mode) {
+		logrus.Warnf("Bilinmeyen scheduler modu %s, ai kullanılacak", cfg.Mode)
+	}
+	if cfg.Strategy != "" && !validStrategy(cfg.Strategy) {
+		logrus.Warnf("Bilinmeyen strateji %s, spread kullanılacak", cfg.Strategy)
+	}
+
+	as.configMu.Lock()
+	previous := as.baseConfig
+	as.baseConfig = &cfg
+	as.calendar = calendar
+	as.policies = policies
+	as.loadProfiles(previous, &cfg)
+	as.resolvePolicy(as.now(), true)
+	as.configMu.Unlock()
+
+	as.podCache.SetDecayHalfLife(decayHalfLife(&cfg.ReliabilityDecay))
+
+	// Skorlama ağırlıkları ve overcommit oranları değişmiş olabilir
+	as.cache.mutex.Lock()
+	as.cache.current.Store(nil)
+	as.cache.mutex.Unlock()
+	as.scores.clear()
+	as.ranking.reset()
+	as.smoothing.reset()
+	as.forecasts.clear()
+	as.seasonality.clear()
+
+	// Gözlem modu sadece konfigürasyonda değiştiyse uygulanır, API ile yapılan değişiklik korunur
+	if previous.ObserveOnly != cfg.ObserveOnly {
+		as.SetObserveOnly(cfg.ObserveOnly, "konfigürasyon")
+	}
+
+	logrus.Info("Scheduler konfigürasyonu güncellendi")
+}
+
+// SetCredentials AI API kimlik bilgisi sağlayıcısını ayarlar
+func (as *AIScheduler) SetCredentials(provider CredentialProvider) {
+	as.credentials = provider
+}
+
+// SetFeatureGate feature flag gate'ini ayarlar
+func (as *AIScheduler) SetFeatureGate(gate *features.Gate) {
+	as.featureGate = gate
+}
+
+// aiAPIToken AI API token'ını döndürür, Secret'taki değer inline konfigürasyondan önceliklidir
+func (as *AIScheduler) aiAPIToken() string {
+	if as.credentials != nil {
+		if token := as.credentials.AIAPIToken(); token != "" {
+			return token
+		}
+	}
+	return as.currentConfig().AIAPIToken
+}
+
+// postToAI AI API'ye kimlik bilgisiyle birlikte JSON POST isteği gönderir, heuristic modda istek gönderilmez.
+// ctx iptal edilince veya süresi dolunca istek kesilir
+func (as *AIScheduler) postToAI(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	if as.HeuristicOnly() {
+		return nil, ErrHeuristicMode
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, as.currentConfig().AIAPIURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := as.aiAPIToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+// BaseConfig zamanlanmış politikalar uygulanmadan önceki scheduler konfigürasyonunu döndürür
+func (as *AIScheduler) BaseConfig() types.SchedulerConfig {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	return *as.baseConfig
+}
+
+// currentConfig geçerli scheduler konfigürasyonunu döndürür
+func (as *AIScheduler) currentConfig() *types.SchedulerConfig {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	return as.config
 }
 
 // metricsListener metrikleri dinler ve AI modelini günceller
@@ -77,21 +288,40 @@ func (as *AIScheduler) metricsListener(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case metric := <-metricsChan:
+			if as.recorder != nil {
+				as.recorder.RecordMetric(as.now(), metric)
+			}
+			as.metricSeen(as.now())
+			as.publishMetric(metric)
+			as.invalidateForMetric(metric)
+
+			// Artımlı skorlama: değişen node işaretlenir, kanal boşaldığında toplu yeniden skorlanır
+			if as.incrementalScoring() {
+				if nodeName, ok := metricNodeName(metric); ok {
+					as.ranking.markDirty(nodeName)
+				}
+				if len(metricsChan) == 0 {
+					as.refreshRanking()
+				}
+			}
+
 			// Metrikleri AI modeline gönder
-			as.sendMetricToAI(metric)
+			if !as.HeuristicOnly() {
+				as.sendMetricToAI(ctx, metric)
+			}
 		}
 	}
 }
 
 // sendMetricToAI metriği AI modeline gönderir
-func (as *AIScheduler) sendMetricToAI(metric interface{}) {
-	_, err := json.Marshal(metric)
+func (as *AIScheduler) sendMetricToAI(ctx context.Context, metric interface{}) {
+	jsonData, err := json.Marshal(metric)
 	if err != nil {
 		logrus.Errorf("Metrik JSON'a çevrilemedi: %v", err)
 		return
 	}
 
-	resp, err := http.Post(as.aiAPI+"/metrics", "application/json", nil)
+	resp, err := as.postToAI(ctx, "/metrics", bytes.NewBuffer(jsonData))
 	if err != nil {
 		logrus.Errorf("AI API'ye metrik gönderilemedi: %v", err)
 		return
@@ -99,224 +329,514 @@ func (as *AIScheduler) sendMetricToAI(metric interface{}) {
 	defer resp.Body.Close()
 }
 
-// PredictBestNode en iyi node'u tahmin eder
-func (as *AIScheduler) PredictBestNode(podName, namespace string) (*NodeScore, error) {
+// PredictBestNode en iyi node'u tahmin eder. Uygun node yoksa types.ErrNoFeasibleNode döner. ctx iptal edilirse
+// veya süresi dolarsa AI çağrıları kesilir, pod assume edilmez ve karar kaydedilmez
+func (as *AIScheduler) PredictBestNode(ctx context.Context, podName, namespace string) (*NodeScore, error) {
+	result, err := as.predictBestNode(ctx, podName, namespace)
+	if err != nil {
+		as.reportError(types.ErrorComponentPredict, err)
+	}
+	return result, err
+}
+
+// predictBestNode pod'u filtreler, skorlar ve en iyi node'u seçer
+func (as *AIScheduler) predictBestNode(ctx context.Context, podName, namespace string) (*NodeScore, error) {
+	start := time.Now()
+	cfg := as.currentConfig()
+
+	// Namespace kapsam kontrolü
+	if !cfg.Namespaces.Matches(namespace) {
+		return nil, fmt.Errorf("%s: %w", namespace, ErrNamespaceOutOfScope)
+	}
+
 	// Pod bilgilerini al
-	_, err := as.k8sClient.GetClientset().CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	pod, err := as.getPod(ctx, namespace, podName)
 	if err != nil {
-		return nil, fmt.Errorf("pod bulunamadı: %v", err)
+		return nil, fmt.Errorf("pod alınamadı: %w", err)
 	}
 
-	// Node listesini al
-	nodes, err := as.k8sClient.GetClientset().CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	// Filtreleme ve skorlama değiştirilemez snapshot'tan okur
+	snapshot, err := as.currentSnapshot()
 	if err != nil {
-		return nil, fmt.Errorf("node listesi alınamadı: %v", err)
+		return nil, err
 	}
 
-	// Her node için skor hesapla
-	var bestNode *NodeScore
-	bestScore := -1.0
+	// Trace kaydı açıksa tahmin girdileri skorlamadan önce toplanır
+	var record *PredictionRecord
+	if as.recorder != nil {
+		record = as.newPredictionRecord(namespace, podName, pod, snapshot)
+	}
+
+	// Filtreleme: pod'un yerleşemeyeceği node'lar skorlanmaz
+	request, feasible, rejected := as.filterPod(pod, snapshot)
+	if len(feasible) == 0 && len(rejected) > 0 {
+		logrus.Debugf("%s/%s için uygun node yok, elenen node'lar: %v", namespace, podName, rejected)
+	}
+
+	// Bozulma seviyesi: sıralı seçimde skorlama atlanır, toparlanma denemesi zamanı geldiyse yine skorlanır
+	now := as.now()
+	degradation := as.evaluateTier(now)
+	roundRobin := degradation.Tier == TierRoundRobin && !as.probeDue(now)
+
+	// Her uygun node için skor hesapla
+	var candidates []NodeScore
+	if roundRobin {
+		candidates = as.roundRobinCandidates(feasible, degradation.Reason)
+	} else {
+		candidates = as.scoreCandidates(pod, snapshot, &request, feasible)
+
+		// Reject modunda bayat girdili node'lar aday olmaz, hiç aday kalmazsa tahmin reddedilir
+		var stale int
+		if candidates, stale = as.rejectStale(candidates); len(candidates) == 0 && stale > 0 {
+			return nil, fmt.Errorf("%s/%s: %d uygun node'un skorlama girdileri bayat: %w", namespace, podName, stale, types.ErrMetricsStale)
+		}
+	}
+	if len(candidates) == 0 {
+		as.checkLatencyBudget(time.Since(start), namespace, podName)
+		as.recordUnschedulable(&request, rejected)
+		if record != nil {
+			as.recorder.RecordPrediction(as.now(), record)
+		}
+		return nil, fmt.Errorf("%s/%s: %w", namespace, podName, types.ErrNoFeasibleNode)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	bestNode := candidates[0]
+	if !roundRobin {
+		as.checkLatencyBudget(time.Since(start), namespace, podName)
+	}
+
+	// AI harmanlama: açıksa karara yansıt, shadow modda sadece logla. Heuristic modda AI'ya gidilmez.
+	// AI hata veriyorsa önbellekteki analizler kullanılır, önbellek de boşsa harmanlama atlanır
+	heuristicOnly := as.HeuristicOnly() || roundRobin
+	blendingEnabled := !heuristicOnly && as.featureGate.Enabled(features.AIBlending)
+	live := !heuristicOnly && as.liveAI(&degradation, now)
+	if !live && degradation.Tier != TierCachedAI {
+		blendingEnabled, heuristicOnly = false, true
+	}
+	if blendingEnabled || (!heuristicOnly && as.featureGate.Enabled(features.ShadowMode)) {
+		blended, ok := as.blendWithAI(ctx, candidates, live)
+		switch {
+		case !ok:
+			// AI bütçesi aşıldı, karar heuristik skorla verilir
+			as.aiSkipped.Add(1)
+			if blendingEnabled {
+				bestNode.AISkipped = true
+				bestNode.Reason += aiBudgetReasonSuffix
+			}
+			logrus.Debugf("%s/%s için AI analizi bütçesinde dönmedi, heuristik karar: %s", namespace, podName, bestNode.NodeName)
+		case blendingEnabled:
+			bestNode = blended
+		case blended.NodeName != bestNode.NodeName:
+			logrus.Infof("[shadow] %s/%s için AI harmanlı karar farklı: %s (%.2f), heuristik: %s (%.2f)",
+				namespace, podName, blended.NodeName, blended.Score, bestNode.NodeName, bestNode.Score)
+		}
+	}
+
+	if !roundRobin {
+		as.flagStale(&bestNode)
 
-	for _, node := range nodes.Items {
-		score, reason := as.calculateNodeScore(&node)
+		// AI harmanlaması olmadan verilen kararlar AI'nın eleştirisi için örneklenir
+		if !blendingEnabled || bestNode.AISkipped {
+			as.sampleAudit(pod, candidates, &bestNode)
+		}
+	}
 
-		if score > bestScore {
-			bestScore = score
-			bestNode = &NodeScore{
-				NodeName: node.Name,
-				Score:    score,
+	// İstek iptal edildiyse veya süresi dolduysa karar uygulanmaz
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Sadece gözlem modunda karar loglanır ama binding için kullanılmamalıdır
+	if as.observeOnly() {
+		bestNode.ObserveOnly = true
+		logrus.Infof("[observe-only] %s/%s için tahmin: %s (skor: %.2f), binding yapılmayacak",
+			namespace, podName, bestNode.NodeName, bestNode.Score)
+	}
+
+	// Bekleyen pod seçilen node'a assume edilir, binding görülene kadar sonraki tahminler kapasiteyi dolu görür
+	if cfg.AssumeTTL > 0 && !bestNode.ObserveOnly && pod.Spec.NodeName == "" {
+		as.AssumePod(pod, bestNode.NodeName, cfg.AssumeTTL)
+	}
+
+	// CronJob pod'unun node'u binding görülmeden sonraki patlama pod'larının yayılmasında sayılır
+	if !bestNode.ObserveOnly && pod.Spec.NodeName == "" {
+		as.recordCronPod(pod, bestNode.NodeName)
+	}
+
+	if record != nil {
+		result := bestNode
+		record.Result = &result
+		as.recorder.RecordPrediction(as.now(), record)
+	}
+
+	as.recordDecision(as.decisionFor(pod, &bestNode, rejected))
+	return &bestNode, nil
+}
+
+// scoreCandidates filtreden geçen node'ları skorlar ve pod'a özgü cezaları uygular, sıralanmamış adayları döndürür
+func (as *AIScheduler) scoreCandidates(pod *corev1.Pod, snapshot *clusterSnapshot, request *podRequest, feasible []*nodeInfo) []NodeScore {
+	hints := as.hintsFor(pod)
+	override := as.scoreOverrideFor(pod, hints)
+	adjustments := as.podAdjustmentsFor(pod, snapshot, request, hints)
+	candidates := make([]NodeScore, 0, len(feasible))
+	for _, info := range feasible {
+		score, reason := as.overriddenNodeScore(override, info.node)
+		score, reason = as.applyAdjustments(adjustments, info, score, reason)
+		candidates = append(candidates, NodeScore{
+			NodeName: info.node.Name,
+			Score:    score,
+			Reason:   reason,
+		})
+	}
+	return candidates
+}
+
+// checkLatencyBudget filtreleme+skorlama süresi bütçeyi aştıysa sayar
+func (as *AIScheduler) checkLatencyBudget(elapsed time.Duration, namespace, podName string) {
+	budget := as.currentConfig().LatencyBudget
+	withinBudget := budget <= 0 || elapsed <= budget
+	as.recordScoringLatency(withinBudget)
+	if withinBudget {
+		return
+	}
+
+	as.overBudget.Add(1)
+	logrus.Debugf("%s/%s tahmini gecikme bütçesini aştı: %s > %s", namespace, podName, elapsed, budget)
+}
+
+// LatencyBudgetExceeded gecikme bütçesini aşan tahmin sayısını döndürür
+func (as *AIScheduler) LatencyBudgetExceeded() uint64 {
+	return as.overBudget.Load()
+}
+
+// AIBudgetExceeded AI analizi bütçesinde dönmediği için heuristik skorla verilen karar sayısını döndürür
+func (as *AIScheduler) AIBudgetExceeded() uint64 {
+	return as.aiSkipped.Load()
+}
+
+// blendWithAI en iyi heuristik adayları AI analiziyle harmanlar ve en yüksek final skorlu adayı döndürür.
+// live false ise AI'ya gidilmez, önbellekteki analizler kullanılır. Canlı analizler scheduler.ai_budget içinde
+// tamamlanmazsa harmanlama bırakılır ve false döner
+func (as *AIScheduler) blendWithAI(ctx context.Context, candidates []NodeScore, live bool) (NodeScore, bool) {
+	budget := as.currentConfig().AIBudget
+	aiCtx := ctx
+	if live && budget > 0 {
+		var cancel context.CancelFunc
+		aiCtx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	best := candidates[0]
+	bestScore := -1.0
+
+	for i := 0; i < len(candidates) && i < aiBlendCandidates; i++ {
+		finalScore, reason := as.makeFinalDecision(aiCtx, candidates[i].NodeName, candidates[i].Score, live)
+		// İsteğin kendisi iptal edilmeden bütçe dolduysa yavaş analiz AI hatası sayılır
+		if aiCtx.Err() != nil && ctx.Err() == nil {
+			as.recordAIResult(candidates[i].NodeName, 0, 0, fmt.Errorf("AI analizi %s bütçesinde dönmedi: %w", budget, types.ErrAIUnavailable))
+			return candidates[0], false
+		}
+		if finalScore > bestScore {
+			bestScore = finalScore
+			best = NodeScore{
+				NodeName: candidates[i].NodeName,
+				Score:    finalScore,
 				Reason:   reason,
 			}
 		}
 	}
 
-	return bestNode, nil
+	return best, true
+}
+
+// calculateNodeScore node skorunu güncel kullanım, tahmin ve pod analiziyle hesaplar.
+// strategy boş değilse konfigürasyondaki stratejinin yerine kullanılır
+func (as *AIScheduler) calculateNodeScore(node *corev1.Node, override scoreOverride) (float64, string) {
+	reasons := getReasonBuilder()
+	defer reasons.release()
+
+	inputs := scoreInputs{analysis: as.nodeAnalysis(node.Name), override: override}
+
+	// Gerçek CPU ve Memory kullanımı node başına bir kez alınır
+	if hasCapacity(node) {
+		var err error
+		inputs.cpuUsage, inputs.memUsage, err = as.nodeUsage(node.Name)
+		if err != nil {
+			logrus.Warnf("Node %s için kullanım alınamadı: %v", node.Name, err)
+			inputs.cpuUsage, inputs.memUsage = 0.0, 0.0 // Fallback
+		}
+	}
+	inputs.penalty, inputs.peak = as.forecastPenalty(node)
+	inputs.flapPenalty, inputs.flapFigure = as.flapPenaltyAt(node.Name, as.now())
+	inputs.readiness, inputs.readinessKnown = as.readinessAt(node.Name, nodeReady(node), as.now())
+	inputs.networkPenalty, inputs.networkHealth = as.networkPenalty(node.Name)
+	inputs.warmUpPenalty, inputs.warmth = as.warmUpPenaltyAt(node, &inputs.analysis, as.now())
+	inputs.cooldownPenalty = as.cooldownPenalty(node.Name)
+
+	score := as.scoreNode(node, &inputs, reasons, nil)
+
+	// Pod'a özgü strateji veya ağırlıklarla hesaplanan skor başka ölçekte olduğundan yumuşatılmaz
+	if override.empty() {
+		if smoothed, ok := as.smoothScore(node.Name, score, true); ok {
+			reasons.item().text("Yumuşatılmış skor: ").float(smoothed, 2).text(" (ham: ").float(score, 2).text(")")
+			score = smoothed
+		}
+	}
+	return score, reasons.total(score)
+}
+
+// scoreInputs skorlamanın node nesnesi dışındaki girdileri (güncel ya da geçmişteki bir ana ait)
+type scoreInputs struct {
+	cpuUsage float64 // core
+	memUsage float64 // GB
+	penalty  float64 // Tahmini yoğunlaşma cezası
+	peak     float64 // Tahmin horizon'undaki tepe kullanım oranı
+	analysis types.NodeAnalysis
+
+	flapPenalty float64 // Yakın zamandaki NotReady↔Ready geçişlerinin cezası
+	flapFigure  float64 // Sönümlenmiş geçiş sayısı
+
+	readiness      types.NodeReadiness // Son 1 saat, 24 saat ve 7 gündeki Ready oranları
+	readinessKnown bool                // Ready geçmişi biliniyor mu (bilinmiyorsa güncel durum)
+
+	// Mesh telemetrisine göre ağ sağlığı cezası; geçmiş tutulmadığı için geçmişteki anlar için 0'dır
+	networkPenalty float64
+	networkHealth  float64
+
+	warmUpPenalty float64 // Yeni node ısınma cezası
+	warmth        float64 // Isınma oranı (0-1)
+
+	// Cooldown cezası (penalize modu); geçmiş tutulmadığı için geçmişteki anlar için 0'dır
+	cooldownPenalty float64
+
+	override scoreOverride // Konfigürasyondaki strateji ve ağırlıkların yerine geçenler (namespace profili, pod ipucu)
+}
+
+// hasCapacity node'un ayrılabilir CPU veya memory bilgisi var mı
+func hasCapacity(node *corev1.Node) bool {
+	cpu, cpuExists := node.Status.Allocatable["cpu"]
+	memory, memExists := node.Status.Allocatable["memory"]
+	return (cpuExists && !cpu.IsZero()) || (memExists && !memory.IsZero())
 }
 
-// calculateNodeScore node skorunu hesaplar
-func (as *AIScheduler) calculateNodeScore(node *corev1.Node) (float64, string) {
+// scoreNode node skorunu girdilerden hesaplar, gerekçeleri reasons'a ve bileşenleri (nil değilse) breakdown'a yazar
+func (as *AIScheduler) scoreNode(node *corev1.Node, inputs *scoreInputs, reasons *reasonBuilder, breakdown *ScoreBreakdown) float64 {
+	cfg := as.currentConfig()
+	if inputs.override.scoring != nil {
+		overridden := *cfg
+		overridden.Scoring = *inputs.override.scoring
+		cfg = &overridden
+	}
 	score := 0.0
-	reasons := []string{}
+
+	// Bin-pack stratejisinde dolu node'lar, spread'de boş node'lar yüksek skor alır
+	strategy := strategyOf(cfg)
+	if inputs.override.strategy != "" {
+		strategy = inputs.override.strategy
+	}
+	binPack := strategy == StrategyBinPack
+	if binPack {
+		reasons.add("Bin-pack stratejisi")
+	}
 
 	// CPU kullanımı (lineer skorlama)
-	cpu, cpuExists := node.Status.Allocatable["cpu"]
-	if cpuExists && !cpu.IsZero() {
+	if cpu, exists := node.Status.Allocatable["cpu"]; exists && !cpu.IsZero() {
 		cpuCapacity := float64(cpu.MilliValue()) / 1000.0
 
-		// Gerçek CPU kullanımını al
-		var cpuUsage float64
-		if as.metricsClient != nil {
-			usage, _, err := as.metricsClient.GetNodeMetrics(node.Name)
-			if err != nil {
-				logrus.Warnf("Node %s için CPU kullanımı alınamadı: %v", node.Name, err)
-				cpuUsage = 0.0 // Fallback
-			} else {
-				cpuUsage = usage
-			}
-		} else {
-			cpuUsage = 0.0 // Fallback
-		}
-
 		if cpuCapacity > 0 {
-			cpuPercent := (cpuUsage / cpuCapacity) * 100
-			cpuScore := as.config.Scoring.CPUWeight * (1 - cpuPercent/100)
-			if cpuScore < 0 {
-				cpuScore = 0
-			}
+			cpuScore := utilizationScore(cfg.Scoring.CPUWeight, inputs.cpuUsage/cpuCapacity, binPack)
 			score += cpuScore
-			reasons = append(reasons, fmt.Sprintf("CPU skoru: %.1f (kullanım: %.2f/%.2f)", cpuScore, cpuUsage, cpuCapacity))
+			breakdown.add(ScoreComponentCPU, cpuScore)
+			reasons.item().text("CPU skoru: ").float(cpuScore, 1).
+				text(" (kullanım: ").float(inputs.cpuUsage, 2).text("/").float(cpuCapacity, 2).text(")")
 		}
 	}
 
-	// Memory kullanımı (lineer skorlama)
-	memory, memExists := node.Status.Allocatable["memory"]
-	if memExists && !memory.IsZero() {
-		memCapacity := float64(memory.Value()) / (1024 * 1024 * 1024) // GB
-
-		// Gerçek Memory kullanımını al
-		var memUsage float64
-		if as.metricsClient != nil {
-			_, usage, err := as.metricsClient.GetNodeMetrics(node.Name)
-			if err != nil {
-				logrus.Warnf("Node %s için Memory kullanımı alınamadı: %v", node.Name, err)
-				memUsage = 0.0 // Fallback
-			} else {
-				memUsage = usage
-			}
-		} else {
-			memUsage = 0.0 // Fallback
-		}
+	// Memory kullanımı (lineer skorlama), Windows'ta overcommit olmadığından headroom payı kullanılamaz sayılır
+	windows := as.windowsSettings()
+	if memory, exists := node.Status.Allocatable["memory"]; exists && !memory.IsZero() {
+		memCapacity := usableMemory(node, float64(memory.Value())/(1024*1024*1024), windows.MemoryHeadroom) // GB
 
 		if memCapacity > 0 {
-			memPercent := (memUsage / memCapacity) * 100
-			memScore := as.config.Scoring.MemoryWeight * (1 - memPercent/100)
-			if memScore < 0 {
-				memScore = 0
-			}
+			memScore := utilizationScore(cfg.Scoring.MemoryWeight, inputs.memUsage/memCapacity, binPack)
 			score += memScore
-			reasons = append(reasons, fmt.Sprintf("Memory skoru: %.1f (kullanım: %.2f/%.2f GB)", memScore, memUsage, memCapacity))
+			breakdown.add(ScoreComponentMemory, memScore)
+			reasons.item().text("Memory skoru: ").float(memScore, 1).
+				text(" (kullanım: ").float(inputs.memUsage, 2).text("/").float(memCapacity, 2).text(" GB)")
 		}
 	}
 
-	// Node Ready durumu
-	ready := false
-	if node.Status.Conditions != nil {
-		for _, condition := range node.Status.Conditions {
-			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
-				score += as.config.Scoring.NodeReadyWeight
-				reasons = append(reasons, "Node hazır")
-				ready = true
-				break
-			}
+	// Tahmini yoğunlaşma: horizon içinde eşiği aşması beklenen node'lar cezalandırılır
+	if inputs.penalty > 0 {
+		score -= inputs.penalty
+		breakdown.add(ScoreComponentForecast, -inputs.penalty)
+		reasons.item().text("Tahmini yoğunlaşma cezası: ").float(inputs.penalty, 1).
+			text(" (tepe kullanım: ").float(inputs.peak, 2).text(")")
+	}
+
+	// Node Ready durumu: Ready node'lar son 1 saat, 24 saat ve 7 günde Ready kaldıkları sürenin ortalama oranıyla
+	// puan alır, böylece sürekli gidip gelen node'lar hep sağlam olanlardan ayrılır
+	if nodeReady(node) {
+		readyScore := cfg.Scoring.NodeReadyWeight * inputs.readiness.Score()
+		score += readyScore
+		breakdown.add(ScoreComponentNodeReady, readyScore)
+		if inputs.readinessKnown {
+			reasons.item().text("Node hazır: ").float(readyScore, 1).
+				text(" (Ready oranı 1s/24s/7g: ").float(inputs.readiness.Hour, 2).
+				text("/").float(inputs.readiness.Day, 2).text("/").float(inputs.readiness.Week, 2).text(")")
+		} else {
+			reasons.add("Node hazır")
 		}
+	} else {
+		breakdown.add(ScoreComponentNodeReady, 0)
+		reasons.add("Node hazır değil")
+	}
+
+	// Flap cezası: yakın zamanda gidip gelen node'a o an Ready olsa da hemen güvenilmez
+	if inputs.flapPenalty > 0 {
+		score -= inputs.flapPenalty
+		breakdown.add(ScoreComponentFlap, -inputs.flapPenalty)
+		reasons.item().text("Flap cezası: ").float(inputs.flapPenalty, 1).
+			text(" (sönümlenmiş geçiş: ").float(inputs.flapFigure, 2).text(")")
+	}
+
+	// Ağ sağlığı cezası: east-west trafiğinde gecikmesi veya hata oranı küme geneline göre bozulmuş node'lar
+	if inputs.networkPenalty > 0 {
+		score -= inputs.networkPenalty
+		breakdown.add(ScoreComponentNetwork, -inputs.networkPenalty)
+		reasons.item().text("Ağ sağlığı cezası: ").float(inputs.networkPenalty, 1).
+			text(" (ağ sağlığı: ").float(inputs.networkHealth, 2).text(")")
 	}
-	if !ready {
-		reasons = append(reasons, "Node hazır değil")
+
+	// Isınma cezası: geçmişi olmayan yeni node'un kararlılık bileşenleri yanıltıcı biçimde iyi görünür
+	if inputs.warmUpPenalty > 0 {
+		score -= inputs.warmUpPenalty
+		breakdown.add(ScoreComponentWarmUp, -inputs.warmUpPenalty)
+		reasons.item().text("Isınma cezası: ").float(inputs.warmUpPenalty, 1).
+			text(" (ısınma: ").float(inputs.warmth, 2).text(")")
+	}
+
+	// Cooldown cezası: node'a yerleşen pod'lar art arda kısa sürede başarısız oldu
+	if inputs.cooldownPenalty > 0 {
+		score -= inputs.cooldownPenalty
+		breakdown.add(ScoreComponentCooldown, -inputs.cooldownPenalty)
+		reasons.item().text("Cooldown cezası: ").float(inputs.cooldownPenalty, 1)
 	}
 
-	// Taints kontrolü
-	if len(node.Spec.Taints) == 0 {
-		score += as.config.Scoring.TaintWeight
-		reasons = append(reasons, "Taint yok")
+	// Taints kontrolü (işletim sistemi ayırma taint'leri hariç)
+	if countedTaints(node, windows.OSTaintKeys) == 0 {
+		score += cfg.Scoring.TaintWeight
+		breakdown.add(ScoreComponentTaint, cfg.Scoring.TaintWeight)
+		reasons.add("Taint yok")
 	} else {
-		reasons = append(reasons, "Taint var")
+		breakdown.add(ScoreComponentTaint, 0)
+		reasons.add("Taint var")
 	}
 
 	// PodMetrics analizi (gelişmiş)
-	podAnalysis := as.analyzePodMetrics(node.Name)
-	score += podAnalysis.Score
-	reasons = append(reasons, podAnalysis.Reasons...)
+	score += analyzePodMetrics(&inputs.analysis, cfg, reasons, breakdown)
 
-	reason := fmt.Sprintf("Toplam skor: %.2f - %s", score, reasons)
-	return score, reason
+	return score
 }
 
-// analyzePodMetrics PodMetrics'ten node analizi yapar
-func (as *AIScheduler) analyzePodMetrics(nodeName string) PodAnalysisResult {
-	// Son 24 saatlik analiz
-	analysis := as.podCache.GetNodeAnalysis(nodeName, 24*time.Hour)
+// utilizationScore kullanım oranını stratejiye göre ağırlıkla lineer skorlar (0 ile weight arası)
+func utilizationScore(weight, ratio float64, binPack bool) float64 {
+	if !binPack {
+		ratio = 1 - ratio
+	}
+	return weight * math.Max(0, math.Min(1, ratio))
+}
 
+// analyzePodMetrics PodMetrics node analizini skorlar, gerekçeleri reasons'a ekler ve skoru döndürür
+func analyzePodMetrics(analysis *types.NodeAnalysis, cfg *types.SchedulerConfig, reasons *reasonBuilder, breakdown *ScoreBreakdown) float64 {
 	score := 0.0
-	var reasons []string
 
 	// Kararlılık skoru (0-1 arası)
 	stabilityScore := analysis.StabilityScore
 	if stabilityScore > 0.8 {
-		score += as.config.Scoring.FailedPodsWeight
-		reasons = append(reasons, "Yüksek kararlılık")
+		score += cfg.Scoring.FailedPodsWeight
+		breakdown.add(ScoreComponentStability, cfg.Scoring.FailedPodsWeight)
+		reasons.add("Yüksek kararlılık")
 	} else if stabilityScore > 0.6 {
-		score += as.config.Scoring.FailedPodsWeight / 2
-		reasons = append(reasons, "Orta kararlılık")
+		score += cfg.Scoring.FailedPodsWeight / 2
+		breakdown.add(ScoreComponentStability, cfg.Scoring.FailedPodsWeight/2)
+		reasons.add("Orta kararlılık")
 	} else {
-		reasons = append(reasons, "Düşük kararlılık")
+		breakdown.add(ScoreComponentStability, 0)
+		reasons.add("Düşük kararlılık")
 	}
 
 	// Başarısızlık oranı
 	failureRate := analysis.FailureRate
 	if failureRate < 0.05 {
-		score += as.config.Scoring.FailedPodsWeight
-		reasons = append(reasons, "Düşük başarısızlık oranı")
+		score += cfg.Scoring.FailedPodsWeight
+		breakdown.add(ScoreComponentFailureRate, cfg.Scoring.FailedPodsWeight)
+		reasons.add("Düşük başarısızlık oranı")
 	} else if failureRate < 0.1 {
-		score += as.config.Scoring.FailedPodsWeight / 2
-		reasons = append(reasons, fmt.Sprintf("Orta başarısızlık oranı: %.2f", failureRate))
+		score += cfg.Scoring.FailedPodsWeight / 2
+		breakdown.add(ScoreComponentFailureRate, cfg.Scoring.FailedPodsWeight/2)
+		reasons.item().text("Orta başarısızlık oranı: ").float(failureRate, 2)
 	} else {
-		score -= as.config.Scoring.FailedPodsWeight
-		reasons = append(reasons, fmt.Sprintf("Yüksek başarısızlık oranı: %.2f", failureRate))
+		score -= cfg.Scoring.FailedPodsWeight
+		breakdown.add(ScoreComponentFailureRate, -cfg.Scoring.FailedPodsWeight)
+		reasons.item().text("Yüksek başarısızlık oranı: ").float(failureRate, 2)
 	}
 
 	// Restart oranı
 	avgRestart := analysis.AverageRestartCount
 	if avgRestart <= 1.0 {
-		score += as.config.Scoring.RestartWeight
-		reasons = append(reasons, "Düşük restart oranı")
+		score += cfg.Scoring.RestartWeight
+		breakdown.add(ScoreComponentRestarts, cfg.Scoring.RestartWeight)
+		reasons.add("Düşük restart oranı")
 	} else if avgRestart <= 2.0 {
-		reasons = append(reasons, fmt.Sprintf("Orta restart oranı: %.2f", avgRestart))
+		breakdown.add(ScoreComponentRestarts, 0)
+		reasons.item().text("Orta restart oranı: ").float(avgRestart, 2)
 	} else {
-		score -= as.config.Scoring.RestartWeight
-		reasons = append(reasons, fmt.Sprintf("Yüksek restart oranı: %.2f", avgRestart))
+		score -= cfg.Scoring.RestartWeight
+		breakdown.add(ScoreComponentRestarts, -cfg.Scoring.RestartWeight)
+		reasons.item().text("Yüksek restart oranı: ").float(avgRestart, 2)
 	}
 
 	// Pod yaşam süresi
 	avgLifetime := analysis.AverageLifetime
 	if avgLifetime > 24*time.Hour {
 		score += 10.0
-		reasons = append(reasons, "Uzun pod yaşam süresi")
+		breakdown.add(ScoreComponentLifetime, 10)
+		reasons.add("Uzun pod yaşam süresi")
 	} else if avgLifetime > 1*time.Hour {
-		reasons = append(reasons, "Normal pod yaşam süresi")
+		breakdown.add(ScoreComponentLifetime, 0)
+		reasons.add("Normal pod yaşam süresi")
 	} else {
 		score -= 10.0
-		reasons = append(reasons, "Kısa pod yaşam süresi")
+		breakdown.add(ScoreComponentLifetime, -10)
+		reasons.add("Kısa pod yaşam süresi")
 	}
 
-	return PodAnalysisResult{
-		Score:   score,
-		Reasons: reasons,
-	}
+	return score
 }
 
-// PodAnalysisResult pod analiz sonucu
-type PodAnalysisResult struct {
-	Score   float64
-	Reasons []string
+// featureCount AI'ya gönderilen özellik sayısı (map ön boyutlandırması için)
+const featureCount = 48
+
+// featurePool AI feature map'lerini istekler arasında yeniden kullanır
+var featurePool = sync.Pool{
+	New: func() interface{} { return make(map[string]interface{}, featureCount) },
 }
 
-// extractFeaturesForAI node için AI modeli için features çıkarır
-func (as *AIScheduler) extractFeaturesForAI(nodeName string) map[string]interface{} {
+// extractFeaturesForAI node için AI modeli için features'ı verilen map'e yazar
+func (as *AIScheduler) extractFeaturesForAI(nodeName string, features map[string]interface{}) {
 	// Node analizi
-	nodeAnalysis := as.podCache.GetNodeAnalysis(nodeName, 24*time.Hour)
+	nodeAnalysis := as.nodeAnalysis(nodeName)
 
 	// CPU ve Memory kullanımı
-	var cpuUsage, memUsage float64
-	if as.metricsClient != nil {
-		cpu, mem, err := as.metricsClient.GetNodeMetrics(nodeName)
-		if err == nil {
-			cpuUsage = cpu
-			memUsage = mem
-		}
+	cpuUsage, memUsage, err := as.nodeUsage(nodeName)
+	if err != nil {
+		cpuUsage, memUsage = 0, 0
 	}
 
 	// Node kapasitesi
-	node, err := as.k8sClient.GetClientset().CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	node, err := as.getNode(nodeName)
 	var cpuCapacity, memCapacity float64
 	if err == nil {
 		if cpu := node.Status.Allocatable["cpu"]; !cpu.IsZero() {
@@ -327,6 +847,35 @@ func (as *AIScheduler) extractFeaturesForAI(nodeName string) map[string]interfac
 		}
 	}
 
+	// Platform kategorik özellik olarak gönderilir (node okunamadıysa boş)
+	var platform types.Platform
+	if err == nil {
+		platform = types.NodePlatform(node)
+	}
+	features["node_os"] = platform.OS
+	features["node_arch"] = platform.Architecture
+
+	// Windows node'ları farklı kullanım ve başlatma karakteristiğine sahiptir; build numarası kategorik gönderilir
+	isWindows, windowsBuild := 0.0, ""
+	if platform.OS == types.OSWindows {
+		isWindows, windowsBuild = 1.0, node.Labels[types.LabelWindowsBuild]
+	}
+	features["is_windows"] = isWindows
+	features["windows_build"] = windowsBuild
+
+	// Node yaşı ve ısınma oranı (node okunamadıysa ısınmış kabul edilir)
+	ageHours, warmth := 0.0, 1.0
+	if err == nil && !node.CreationTimestamp.IsZero() {
+		ageHours = as.now().Sub(node.CreationTimestamp.Time).Hours()
+		cfg := as.warmUpSettings()
+		warmth = nodeWarmth(node, nodeAnalysis.TotalPods, as.now(), &cfg)
+	}
+	features["node_age_hours"] = ageHours
+	features["warm_up"] = warmth
+
+	// Boş kapasitenin bekleyen pod şekillerine sığmayan oranı (bin-packing girdisi)
+	features["fragmentation"] = as.nodeFragmentation(nodeName)
+
 	// CPU ve Memory oranları
 	cpuRatio := 0.0
 	memRatio := 0.0
@@ -362,44 +911,106 @@ func (as *AIScheduler) extractFeaturesForAI(nodeName string) map[string]interfac
 		riskFactors = append(riskFactors, "high_memory_usage")
 	}
 
-	// Özellik vektörü
-	features := map[string]interface{}{
-		// Temel metrikler
-		"cpu_usage_ratio":        cpuRatio,
-		"memory_usage_ratio":     memRatio,
-		"pod_count":              nodeAnalysis.TotalPods,
-		"failed_pods_ratio":      nodeAnalysis.FailureRate,
-		"avg_restart_count":      nodeAnalysis.AverageRestartCount,
-		"avg_pod_lifetime_hours": nodeAnalysis.AverageLifetime.Hours(),
-
-		// Türetilen özellikler
-		"stability_score": nodeAnalysis.StabilityScore,
-		"pod_density":     podDensity,
-		"trend_score":     trendScore,
-		"success_rate":    1.0 - nodeAnalysis.FailureRate,
-
-		// Risk faktörleri
-		"risk_factors": riskFactors,
-		"risk_score":   float64(len(riskFactors)) / 4.0, // 0-1 arası
-
-		// Zaman bazlı özellikler
-		"hour_of_day": float64(time.Now().Hour()) / 24.0,
-		"day_of_week": float64(time.Now().Weekday()) / 7.0,
+	// Özellik vektörü (temel metrikler)
+	features["cpu_usage_ratio"] = cpuRatio
+	features["memory_usage_ratio"] = memRatio
+	features["pod_count"] = nodeAnalysis.TotalPods
+	features["failed_pods_ratio"] = nodeAnalysis.FailureRate
+	features["avg_restart_count"] = nodeAnalysis.AverageRestartCount
+
+	// Pod sağkalımı: ortalama yaşam süresi yerine pod'un node'da 1 ve 24 saat başarısız olmadan çalışma olasılığı
+	survival := as.podCache.GetNodeSurvival(nodeName)
+	features["survival_1h"] = survival.Survival1h
+	features["survival_24h"] = survival.Survival24h
+	features["survival_observed_pods"] = survival.Pods
+
+	// Türetilen özellikler
+	features["stability_score"] = nodeAnalysis.StabilityScore
+	features["pod_density"] = podDensity
+	features["trend_score"] = trendScore
+	features["success_rate"] = 1.0 - nodeAnalysis.FailureRate
 
-		// Kapasite bilgileri
-		"cpu_capacity":        cpuCapacity,
-		"memory_capacity_gb":  memCapacity,
-		"available_cpu":       cpuCapacity - cpuUsage,
-		"available_memory_gb": memCapacity - memUsage,
+	// Risk faktörleri
+	features["risk_factors"] = riskFactors
+	features["risk_score"] = float64(len(riskFactors)) / 4.0 // 0-1 arası
+
+	// Kapasite bilgileri
+	features["cpu_capacity"] = cpuCapacity
+	features["memory_capacity_gb"] = memCapacity
+	features["available_cpu"] = cpuCapacity - cpuUsage
+	features["available_memory_gb"] = memCapacity - memUsage
+
+	// Beklenen pod başlatma süresi (ölçüm yoksa 0)
+	startup, _ := as.expectedStartup(nodeName, 1)
+	features["expected_startup_seconds"] = startup.Total.Seconds()
+	features["admission_seconds"] = startup.Admission.Seconds()
+	features["network_setup_seconds"] = startup.Network.Seconds()
+	features["image_pull_seconds"] = startup.ImagePull.Seconds()
+
+	// Noisy neighbor riski (0-1)
+	noisyNeighborRisk := 0.0
+	if neighbors := as.collector.GetNeighborUsage(); neighbors != nil {
+		noisyNeighborRisk = neighbors.NodeRisk(nodeName)
+	}
+	features["noisy_neighbor_risk"] = noisyNeighborRisk
+
+	// Yakın zamandaki NotReady↔Ready geçişleri (yarı ömürle sönümlenmiş)
+	flaps, _ := as.flapState(nodeName, as.now())
+	features["node_flap_figure"] = flaps.Figure
+
+	// Son 1 saat, 24 saat ve 7 günde Ready kalınan sürenin oranı (geçmiş yoksa güncel durum, node okunamadıysa 0)
+	readiness, _ := as.readinessAt(nodeName, err == nil && nodeReady(node), as.now())
+	features["node_ready_1h"] = readiness.Hour
+	features["node_ready_24h"] = readiness.Day
+	features["node_ready_7d"] = readiness.Week
+
+	// Node'daki pod'ların uygulama gecikmesi ve hata oranı (SLI tanımlı pod yoksa 0)
+	var appSLI types.NodeAppSLI
+	if tracker := as.collector.GetAppSLI(); tracker != nil {
+		appSLI = tracker.Node(nodeName)
+	}
+	features["app_latency_ms"] = appSLI.LatencyMs
+	features["app_error_rate"] = appSLI.ErrorRate
+	features["app_sli_pods"] = appSLI.Pods
+
+	// Servis mesh telemetrisinden ağ sağlığı (trafiği ölçülmeyen node için 1)
+	network, measured := as.networkHealth(nodeName)
+	if !measured {
+		network.Health = 1
 	}
+	features["mesh_latency_ms"] = network.LatencyMs
+	features["mesh_error_rate"] = network.ErrorRate
+	features["network_health"] = network.Health
+
+	// Disk IO doygunluğu (ölçüm yoksa 0)
+	storage, _ := as.storageIO(nodeName)
+	features["disk_io_saturation"] = storage.Saturation
+	features["disk_iops"] = storage.IOPS
+	features["disk_throughput_mbps"] = storage.ThroughputMBps
+
+	// Dış ajanların gönderdiği, süresi dolmamış node sinyalleri
+	if external := as.collector.GetExternalSignals(); external != nil {
+		for _, signal := range external.Get(nodeName, as.now()) {
+			features[types.ExternalSignalFeaturePrefix+signal.Name] = signal.Value
+		}
+	}
+
+	// Kullanımın günlük/haftalık döngüsü ve döngüye göre şu an beklenen kullanım
+	as.seasonalFeatures(node, 0, features)
 
-	return features
+	// Zaman bazlı özellikler (iş yükünün saat diliminde)
+	as.temporalCalendar().addFeatures(features, as.now())
 }
 
 // getAIAnalysis Python AI'dan analiz alır
-func (as *AIScheduler) getAIAnalysis(nodeName string) (map[string]interface{}, error) {
-	// Features çıkar
-	features := as.extractFeaturesForAI(nodeName)
+func (as *AIScheduler) getAIAnalysis(ctx context.Context, nodeName string) (map[string]interface{}, error) {
+	// Features çıkar (map, JSON'a çevrildikten sonra havuza döner)
+	features := featurePool.Get().(map[string]interface{})
+	defer func() {
+		clear(features)
+		featurePool.Put(features)
+	}()
+	as.extractFeaturesForAI(nodeName, features)
 
 	// Python AI'ya gönder
 	requestBody := map[string]interface{}{
@@ -414,52 +1025,77 @@ func (as *AIScheduler) getAIAnalysis(nodeName string) (map[string]interface{}, e
 	}
 
 	// HTTP request
-	resp, err := http.Post(as.aiAPI+"/analyze", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := as.postToAI(ctx, "/analyze", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("AI API'ye istek gönderilemedi: %v", err)
+		return nil, fmt.Errorf("AI API'ye istek gönderilemedi: %v: %w", err, types.ErrAIUnavailable)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI API hata döndürdü: %d", resp.StatusCode)
+		return nil, fmt.Errorf("AI API hata döndürdü: %d: %w", resp.StatusCode, types.ErrAIUnavailable)
 	}
 
 	// Response parse et
 	var aiResponse map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&aiResponse); err != nil {
-		return nil, fmt.Errorf("AI response parse edilemedi: %v", err)
+		return nil, fmt.Errorf("AI response parse edilemedi: %v: %w", err, types.ErrAIUnavailable)
 	}
 
 	return aiResponse, nil
 }
 
-// makeFinalDecision AI analizi ve Go algoritmasını birleştirir
-func (as *AIScheduler) makeFinalDecision(nodeName string, goScore float64) (float64, string) {
-	// AI analizi al
-	aiAnalysis, err := as.getAIAnalysis(nodeName)
+// makeFinalDecision AI analizi ve Go algoritmasını birleştirir. Canlı analiz alınamazsa kademeli bozulma
+// açıkken node'un önbellekteki son analizi, o da yoksa sadece Go skoru kullanılır
+func (as *AIScheduler) makeFinalDecision(ctx context.Context, nodeName string, goScore float64, live bool) (float64, string) {
+	var aiScore, confidence float64
+	err := errAIAnalysisSkipped
+	if live && ctx.Err() == nil {
+		aiScore, confidence, err = as.liveAIAnalysis(ctx, nodeName)
+		// İsteğin iptali veya süresinin dolması AI hatası sayılmaz
+		if ctx.Err() == nil {
+			as.recordAIResult(nodeName, aiScore, confidence, err)
+		}
+	}
+
+	cached := false
 	if err != nil {
-		logrus.Warnf("AI analizi alınamadı, sadece Go skoru kullanılacak: %v", err)
-		return goScore, "Sadece Go algoritması kullanıldı"
+		analysis, ok := as.cachedAIAnalysis(nodeName)
+		if !ok {
+			logrus.Warnf("AI analizi alınamadı, sadece Go skoru kullanılacak: %v", err)
+			return goScore, "Sadece Go algoritması kullanıldı"
+		}
+		aiScore, confidence, cached = analysis.score, analysis.confidence, true
+	}
+
+	// Final skor hesapla (AI %70, Go %30)
+	finalScore := (aiScore * confidence * 0.7) + (goScore * 0.3)
+
+	reason := fmt.Sprintf("Final skor: %.2f (AI: %.2f, Go: %.2f, Confidence: %.2f)",
+		finalScore, aiScore, goScore, confidence)
+	if cached {
+		reason += cachedAnalysisReasonSuffix
+	}
+
+	return finalScore, reason
+}
+
+// liveAIAnalysis Python AI'dan node'un skorunu ve güvenilirliğini alır
+func (as *AIScheduler) liveAIAnalysis(ctx context.Context, nodeName string) (float64, float64, error) {
+	aiAnalysis, err := as.getAIAnalysis(ctx, nodeName)
+	if err != nil {
+		return 0, 0, err
 	}
 
 	// AI skorunu al
 	aiScore, ok := aiAnalysis["score"].(float64)
 	if !ok {
-		logrus.Warnf("AI skoru alınamadı, sadece Go skoru kullanılacak")
-		return goScore, "Sadece Go algoritması kullanıldı"
+		return 0, 0, fmt.Errorf("AI skoru alınamadı: %w", types.ErrAIUnavailable)
 	}
 
 	// AI güvenilirlik skoru
 	confidence, ok := aiAnalysis["confidence"].(float64)
 	if !ok {
-		confidence = 0.5 // Default güvenilirlik
+		confidence = defaultAIAnalysisConfidence
 	}
-
-	// Final skor hesapla (AI %70, Go %30)
-	finalScore := (aiScore * confidence * 0.7) + (goScore * 0.3)
-
-	reason := fmt.Sprintf("Final skor: %.2f (AI: %.2f, Go: %.2f, Confidence: %.2f)",
-		finalScore, aiScore, goScore, confidence)
-
-	return finalScore, reason
+	return aiScore, confidence, nil
 }
@@ -0,0 +1,355 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Karar denetimi (audit) varsayılanları
+const (
+	defaultAuditSampleRate = 0.05
+	defaultAuditInterval   = 5 * time.Minute
+	defaultAuditBatchSize  = 50
+	defaultAuditMaxPending = 1000
+	defaultAuditCandidates = 5
+	defaultAuditWindow     = 500
+	auditDisagreements     = 20 // Raporda tutulan son ayrışan eleştiri sayısı
+)
+
+// AuditCandidate denetim örneğinde karar anındaki bir aday node, heuristik skoru ve AI özellikleri
+type AuditCandidate struct {
+	NodeName string                 `json:"node_name"`
+	Score    float64                `json:"score"`
+	Features map[string]interface{} `json:"features"`
+}
+
+// AuditSample AI'ya eleştiri için gönderilen, sadece heuristik skorla verilmiş bir karar
+type AuditSample struct {
+	ID         string            `json:"id"`
+	Time       time.Time         `json:"time"`
+	Namespace  string            `json:"namespace"`
+	Pod        string            `json:"pod"`
+	Workload   types.WorkloadRef `json:"workload"`
+	Node       string            `json:"node"` // Heuristiğin seçtiği node
+	Score      float64           `json:"score"`
+	Reason     string            `json:"reason,omitempty"`
+	Candidates []AuditCandidate  `json:"candidates"` // Skora göre sıralı, ilki seçilen node
+}
+
+// AuditCritique AI'nın bir denetim örneği için önerdiği node
+type AuditCritique struct {
+	ID       string  `json:"id"`
+	NodeName string  `json:"node_name"`
+	Score    float64 `json:"score,omitempty"`
+	Reason   string  `json:"reason,omitempty"`
+}
+
+// AuditDisagreement AI'nın heuristikten farklı node önerdiği karar
+type AuditDisagreement struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Node      string    `json:"node"`
+	AINode    string    `json:"ai_node"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// AuditReport karar denetiminin durumu ve heuristik ile AI arasındaki uyuşma oranı
+type AuditReport struct {
+	Enabled   bool   `json:"enabled"`
+	Pending   int    `json:"pending"`   // Gönderilmeyi bekleyen örnekler
+	Sampled   uint64 `json:"sampled"`   // Başlangıçtan beri örneklenen kararlar
+	Dropped   uint64 `json:"dropped"`   // Kuyruk dolduğu için gönderilmeden atılan örnekler
+	Critiqued uint64 `json:"critiqued"` // AI'nın eleştirdiği örnekler
+	Agreed    uint64 `json:"agreed"`
+	// WindowSize ve AgreementRate son eleştirilerdeki uyuşma oranı (0 eleştiri varsa oran 0)
+	WindowSize    int                 `json:"window_size"`
+	AgreementRate float64             `json:"agreement_rate"`
+	LastSent      time.Time           `json:"last_sent,omitempty"`
+	LastError     string              `json:"last_error,omitempty"`
+	Disagreements []AuditDisagreement `json:"disagreements"` // En yeniden eskiye
+}
+
+// auditTracker örneklenen kararları gönderilene kadar tutar ve eleştirilerin uyuşma penceresini hesaplar
+type auditTracker struct {
+	mutex         sync.Mutex
+	pending       []AuditSample
+	window        []bool // Son eleştirilerin uyuşup uyuşmadığı, halka tampon
+	next          int
+	disagreements []AuditDisagreement
+	lastSent      time.Time
+	lastError     string
+	seq           uint64
+	sampled       atomic.Uint64
+	dropped       atomic.Uint64
+	critiqued     atomic.Uint64
+	agreed        atomic.Uint64
+}
+
+// auditSettings varsayılanları uygulanmış karar denetimi ayarları
+func (as *AIScheduler) auditSettings() types.AuditConfig {
+	cfg := as.currentConfig().Audit
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		cfg.SampleRate = defaultAuditSampleRate
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultAuditInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultAuditBatchSize
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = defaultAuditMaxPending
+	}
+	if cfg.Candidates <= 0 {
+		cfg.Candidates = defaultAuditCandidates
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultAuditWindow
+	}
+	return cfg
+}
+
+// sampleAudit sadece heuristik skorla verilen kararı SampleRate olasılıkla denetim kuyruğuna ekler. En iyi
+// Candidates aday (sıralı) ve karar anındaki AI özellikleri örneğe yazılır; kuyruk doluysa en eski örnek atılır
+func (as *AIScheduler) sampleAudit(pod *corev1.Pod, candidates []NodeScore, best *NodeScore) {
+	cfg := as.auditSettings()
+	if !cfg.Enabled || as.HeuristicOnly() || len(candidates) == 0 || rand.Float64() >= cfg.SampleRate {
+		return
+	}
+
+	t := &as.audit
+	now := as.now()
+	sample := AuditSample{
+		Time:       now,
+		Namespace:  pod.Namespace,
+		Pod:        pod.Name,
+		Workload:   types.WorkloadOf(pod),
+		Node:       best.NodeName,
+		Score:      best.Score,
+		Reason:     best.Reason,
+		Candidates: make([]AuditCandidate, 0, min(len(candidates), cfg.Candidates)),
+	}
+	template, history := types.TemplateFingerprint(pod), as.templateHistorySettings().Enabled
+	lifetime := as.podLifetime(pod)
+	for _, candidate := range candidates[:min(len(candidates), cfg.Candidates)] {
+		// Örnek gönderilene kadar tutulduğu için havuzdaki map kullanılmaz
+		features := make(map[string]interface{}, featureCount+8)
+		as.extractFeaturesForAI(candidate.NodeName, features)
+		if history {
+			as.templateFeatures(template, candidate.NodeName, as.nodePropertiesOf(candidate.NodeName), features)
+		}
+		if lifetime > 0 {
+			if node, err := as.getNode(candidate.NodeName); err == nil {
+				as.seasonalFeatures(node, lifetime, features)
+			}
+		}
+		sample.Candidates = append(sample.Candidates, AuditCandidate{NodeName: candidate.NodeName, Score: candidate.Score, Features: features})
+	}
+	t.sampled.Add(1)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.seq++
+	sample.ID = fmt.Sprintf("%s/%s-%d", pod.Namespace, pod.Name, t.seq)
+	if drop := len(t.pending) + 1 - cfg.MaxPending; drop > 0 {
+		t.pending = append(t.pending[:0], t.pending[drop:]...)
+		t.dropped.Add(uint64(drop))
+	}
+	t.pending = append(t.pending, sample)
+}
+
+// auditLoop örneklenen kararları Interval aralıkla BatchSize'lık gruplar halinde AI'ya gönderir
+func (as *AIScheduler) auditLoop(ctx context.Context) {
+	interval := as.auditSettings().Interval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			as.sendAuditBatch(ctx)
+			if next := as.auditSettings().Interval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// sendAuditBatch bekleyen en eski örnekleri AI API'nin /audit endpoint'ine gönderir ve dönen eleştirileri
+// uyuşma penceresine yazar. Gönderim başarısız olursa örnekler kuyrukta kalır
+func (as *AIScheduler) sendAuditBatch(ctx context.Context) {
+	cfg := as.auditSettings()
+	if !cfg.Enabled || as.HeuristicOnly() {
+		return
+	}
+
+	t := &as.audit
+	t.mutex.Lock()
+	batch := append([]AuditSample(nil), t.pending[:min(len(t.pending), cfg.BatchSize)]...)
+	t.mutex.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+
+	critiques, err := as.requestAuditCritiques(ctx, batch)
+	if err != nil {
+		if ctx.Err() == nil {
+			as.reportError(types.ErrorComponentAI, err)
+			logrus.Warnf("Karar denetimi örnekleri AI'ya gönderilemedi: %v", err)
+			t.mutex.Lock()
+			t.lastError = err.Error()
+			t.mutex.Unlock()
+		}
+		return
+	}
+
+	sent := make(map[string]*AuditSample, len(batch))
+	for i := range batch {
+		sent[batch[i].ID] = &batch[i]
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// Gönderim sırasında eklenen veya atılan örnekler korunur, sadece gönderilenler kuyruktan çıkar
+	remaining := t.pending[:0]
+	for _, sample := range t.pending {
+		if sent[sample.ID] == nil {
+			remaining = append(remaining, sample)
+		}
+	}
+	clear(t.pending[len(remaining):])
+	t.pending = remaining
+	t.lastSent, t.lastError = as.now(), ""
+
+	// Pencere boyutu değiştiyse uyuşma oranı yeni pencereyle baştan hesaplanır
+	if cap(t.window) != cfg.Window {
+		t.window, t.next = make([]bool, 0, cfg.Window), 0
+	}
+	for _, critique := range critiques {
+		sample := sent[critique.ID]
+		if sample == nil || critique.NodeName == "" {
+			continue
+		}
+		agreed := critique.NodeName == sample.Node
+		t.critiqued.Add(1)
+		if agreed {
+			t.agreed.Add(1)
+		}
+		if len(t.window) < cfg.Window {
+			t.window = append(t.window, agreed)
+		} else {
+			t.window[t.next] = agreed
+			t.next = (t.next + 1) % cfg.Window
+		}
+		if !agreed {
+			t.disagreements = append(t.disagreements, AuditDisagreement{
+				Time:      sample.Time,
+				Namespace: sample.Namespace,
+				Pod:       sample.Pod,
+				Node:      sample.Node,
+				AINode:    critique.NodeName,
+				Reason:    critique.Reason,
+			})
+			if drop := len(t.disagreements) - auditDisagreements; drop > 0 {
+				t.disagreements = append(t.disagreements[:0], t.disagreements[drop:]...)
+			}
+		}
+	}
+	logrus.Debugf("Karar denetimi: %d örnek gönderildi, %d eleştiri alındı", len(batch), len(critiques))
+}
+
+// requestAuditCritiques örnekleri AI'ya gönderir ve her örnek için AI'nın önerdiği node'u döndürür
+func (as *AIScheduler) requestAuditCritiques(ctx context.Context, batch []AuditSample) ([]AuditCritique, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"samples":   batch,
+		"timestamp": time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request JSON'a çevrilemedi: %v", err)
+	}
+
+	resp, err := as.postToAI(ctx, "/audit", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("AI API'ye istek gönderilemedi: %v: %w", err, types.ErrAIUnavailable)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AI API hata döndürdü: %d: %w", resp.StatusCode, types.ErrAIUnavailable)
+	}
+
+	var response struct {
+		Critiques []AuditCritique `json:"critiques"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("AI response parse edilemedi: %v: %w", err, types.ErrAIUnavailable)
+	}
+	return response.Critiques, nil
+}
+
+// AuditAgreement son eleştirilerdeki uyuşma oranını ve başlangıçtan beri eleştirilen/uyuşan örnek sayılarını
+// döndürür. Oran AI modelinin heuristik kurallardan ne kadar ayrıştığını gösterir
+func (as *AIScheduler) AuditAgreement() (rate float64, critiqued, agreed uint64) {
+	t := &as.audit
+	t.mutex.Lock()
+	rate = agreementRate(t.window)
+	t.mutex.Unlock()
+	return rate, t.critiqued.Load(), t.agreed.Load()
+}
+
+// AuditReport karar denetiminin durumunu ve uyuşma oranını döndürür
+func (as *AIScheduler) AuditReport() AuditReport {
+	t := &as.audit
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	report := AuditReport{
+		Enabled:       as.auditSettings().Enabled,
+		Pending:       len(t.pending),
+		Sampled:       t.sampled.Load(),
+		Dropped:       t.dropped.Load(),
+		Critiqued:     t.critiqued.Load(),
+		Agreed:        t.agreed.Load(),
+		WindowSize:    len(t.window),
+		AgreementRate: agreementRate(t.window),
+		LastSent:      t.lastSent,
+		LastError:     t.lastError,
+		Disagreements: make([]AuditDisagreement, 0, len(t.disagreements)),
+	}
+	for i := len(t.disagreements) - 1; i >= 0; i-- {
+		report.Disagreements = append(report.Disagreements, t.disagreements[i])
+	}
+	return report
+}
+
+// agreementRate penceredeki uyuşan eleştirilerin oranı
+func agreementRate(window []bool) float64 {
+	if len(window) == 0 {
+		return 0
+	}
+	var agreed int
+	for _, ok := range window {
+		if ok {
+			agreed++
+		}
+	}
+	return float64(agreed) / float64(len(window))
+}
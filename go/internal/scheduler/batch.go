@@ -0,0 +1,867 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Toplu yerleşim optimizasyonunun varsayılanları
+const (
+	defaultBatchMaxPods       = 500
+	defaultBatchMaxRounds     = 10
+	defaultBatchSpreadPenalty = 10.0
+	defaultBatchLoadWeight    = 20.0
+	batchImprovementEpsilon   = 1e-6
+)
+
+// ErrBatchTooLarge toplu istekteki pod sayısı sınırı aşıyor
+var ErrBatchTooLarge = errors.New("toplu istekte çok fazla pod")
+
+// ErrEmptyBatch toplu istekte pod yok
+var ErrEmptyBatch = errors.New("toplu istekte pod yok")
+
+// BatchPod toplu tahminde yerleştirilecek pod
+type BatchPod struct {
+	Namespace string `json:"namespace" binding:"required,k8s_namespace"`
+	PodName   string `json:"pod_name" binding:"required,k8s_name"`
+}
+
+// BatchPlacement toplu tahminde pod'un yerleşimi. Node boşsa pod yerleştirilemedi (Error doluysa pod hiç
+// değerlendirilemedi)
+type BatchPlacement struct {
+	Namespace   string         `json:"namespace"`
+	Pod         string         `json:"pod"`
+	Node        string         `json:"node,omitempty"`
+	Score       float64        `json:"score"`
+	Reason      string         `json:"reason,omitempty"`
+	ObserveOnly bool           `json:"observe_only,omitempty"`
+	GreedyNode  string         `json:"greedy_node,omitempty"` // Pod'lar sırayla tek tek yerleştirilseydi seçilecek node
+	Rejected    map[string]int `json:"rejected,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// BatchResult toplu yerleşimin sonucu ve sırayla tek tek yerleştirmeyle karşılaştırması
+type BatchResult struct {
+	Placements   []BatchPlacement `json:"placements"`
+	Placed       int              `json:"placed"`
+	Unplaced     int              `json:"unplaced"`
+	TotalScore   float64          `json:"total_score"`
+	GreedyPlaced int              `json:"greedy_placed"`
+	GreedyScore  float64          `json:"greedy_score"`
+	Moves        int              `json:"moves"` // Yerel aramada yapılan taşıma ve takaslar
+}
+
+// batchTerm toplu yerleşimde izlenen pod anti-affinity terimi veya topology spread kısıtı. Aynı seçici ve
+// topoloji anahtarını kullanan pod'lar (ör: bir rollout'un replikaları) tek terimi paylaşır
+type batchTerm struct {
+	antiAffinity bool // false ise topology spread kısıtı
+	hard         bool // Spread için DoNotSchedule
+	topologyKey  string
+	maxSkew      int
+	selector     labels.Selector
+	namespaces   map[string]bool // nil ise tüm namespace'ler
+	existing     map[string]int  // Seçiciye uyan mevcut pod'ların alan başına sayısı
+	matches      []bool          // Batch pod'u seçiciye uyuyor mu
+}
+
+// matchesPod pod terimin namespace'lerinde ve seçicisine uyuyorsa true döner
+func (t *batchTerm) matchesPod(pod *corev1.Pod) bool {
+	if t.namespaces != nil && !t.namespaces[pod.Namespace] {
+		return false
+	}
+	return t.selector.Matches(labels.Set(pod.Labels))
+}
+
+// topologyDomain node'un topoloji anahtarındaki değeri
+type topologyDomain struct {
+	key   string
+	value string
+}
+
+// batchItem toplu yerleşimdeki pod, filtreden geçen node'lardaki skorları ve kısıtları
+type batchItem struct {
+	pod      *corev1.Pod
+	request  podRequest
+	workload string
+	strategy string
+	scores   map[int]NodeScore // Node indeksi -> heuristik skor
+	order    []int             // Filtreden geçen node'lar, skora göre azalan
+	terms    []int             // Pod'un kendi anti-affinity ve spread terimleri
+	domains  map[int][]string  // Spread terimi -> pod'un uygun node'larının alanları
+	blocked  []topologyDomain  // Mevcut pod'ların anti-affinity'si yüzünden kapalı alanlar
+	rejected map[string]int    // Filtrede elenen node sayıları
+}
+
+// batchState toplu yerleşimin çalışma durumu: node'ların istekleri batch yerleşimleriyle birlikte tutulur
+type batchState struct {
+	nodes   []nodeInfo
+	placed  []map[string]int // Terim -> seçiciye uyan yerleşmiş batch pod'larının alan başına sayısı
+	owners  []map[string]int // Anti-affinity terimi -> terimin sahibi yerleşmiş batch pod'larının alan başına sayısı
+	assign  []int            // Pod -> node indeksi, -1 ise yerleşmedi
+	moves   int
+	problem *batchProblem
+}
+
+// batchProblem toplu yerleşimin değişmeyen girdileri
+type batchProblem struct {
+	snapshot      *clusterSnapshot
+	nodes         []nodeInfo // Batch pod'larının assume kayıtları çıkarılmış node'lar
+	items         []*batchItem
+	terms         []*batchTerm
+	spreadPenalty float64
+	loadWeight    float64
+	maxRounds     int
+}
+
+// batchSettings varsayılanları uygulanmış toplu yerleşim ayarları
+func (as *AIScheduler) batchSettings() types.BatchConfig {
+	cfg := as.currentConfig().Batch
+	if cfg.MaxPods <= 0 {
+		cfg.MaxPods = defaultBatchMaxPods
+	}
+	if cfg.MaxRounds == 0 {
+		cfg.MaxRounds = defaultBatchMaxRounds
+	}
+	if cfg.SpreadPenalty <= 0 {
+		cfg.SpreadPenalty = defaultBatchSpreadPenalty
+	}
+	if cfg.LoadWeight == 0 {
+		cfg.LoadWeight = defaultBatchLoadWeight
+	}
+	return cfg
+}
+
+// PredictBatch pod'ların yerleşimini tek tek değil birlikte çözer: kapasite, iş yükü sınırları, zorunlu pod
+// anti-affinity ve topology spread kısıtları batch içindeki diğer yerleşimlerle birlikte değerlendirilir.
+// Sırayla tek tek ve en kısıtlı pod önce yerleştirmeden iyi olanı yerel aramayla (taşıma, yer açma, takas)
+// iyileştirilir. AI harmanlaması yapılmaz, skorlar heuristiktir. Kararlar geçmişe yazılır ve yerleşen pod'lar
+// tek pod tahminindeki gibi assume edilir
+func (as *AIScheduler) PredictBatch(ctx context.Context, pods []BatchPod) (*BatchResult, error) {
+	cfg := as.batchSettings()
+	if len(pods) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(pods) > cfg.MaxPods {
+		return nil, fmt.Errorf("%w: %d (en fazla %d)", ErrBatchTooLarge, len(pods), cfg.MaxPods)
+	}
+
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{Placements: make([]BatchPlacement, len(pods))}
+	resolved := make([]*corev1.Pod, len(pods))
+	seen := make(map[podKey]bool, len(pods))
+	schedulerConfig := as.currentConfig()
+	for i, ref := range pods {
+		result.Placements[i] = BatchPlacement{Namespace: ref.Namespace, Pod: ref.PodName}
+		key := podKey{namespace: ref.Namespace, name: ref.PodName}
+		switch {
+		case seen[key]:
+			result.Placements[i].Error = "pod toplu istekte birden fazla kez var"
+			continue
+		case !schedulerConfig.Namespaces.Matches(ref.Namespace):
+			result.Placements[i].Error = ErrNamespaceOutOfScope.Error()
+			continue
+		}
+		seen[key] = true
+
+		pod, err := as.getPod(ctx, ref.Namespace, ref.PodName)
+		if err != nil {
+			result.Placements[i].Error = fmt.Sprintf("pod alınamadı: %v", err)
+			continue
+		}
+		if pod.Spec.NodeName != "" {
+			result.Placements[i].Error = fmt.Sprintf("pod zaten %s node'una bağlı", pod.Spec.NodeName)
+			continue
+		}
+		resolved[i] = pod
+	}
+
+	existing, err := as.listPods()
+	if err != nil {
+		return nil, fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+	problem := as.newBatchProblem(snapshot, resolved, existing, &cfg)
+
+	// Başlangıç çözümleri: istek sırasıyla tek tek ve en kısıtlı pod önce
+	greedy := problem.newState()
+	greedy.construct(problem.requestOrder())
+	constrained := problem.newState()
+	constrained.construct(problem.constrainedOrder())
+
+	best := greedy
+	if constrained.better(greedy) {
+		best = constrained
+	}
+	if best == greedy {
+		// Yerel arama greedy durumunu değiştireceği için karşılaştırma için kopyası alınır
+		best = problem.newState()
+		best.construct(problem.requestOrder())
+	}
+	best.improve()
+
+	result.GreedyPlaced, result.GreedyScore = greedy.objective()
+	result.Placed, result.TotalScore = best.objective()
+	result.Moves = best.moves
+	for i, item := range problem.items {
+		if item == nil {
+			continue
+		}
+		if n := greedy.assign[i]; n >= 0 {
+			result.Placements[i].GreedyNode = problem.nodes[n].node.Name
+		}
+	}
+
+	// İstek iptal edildiyse veya süresi dolduysa yerleşimler uygulanmaz
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	as.applyBatch(problem, best, result)
+
+	result.Unplaced = len(pods) - result.Placed
+	return result, nil
+}
+
+// newBatchProblem pod'ları filtreler ve skorlar, anti-affinity ve spread terimlerini mevcut pod'larla kurar.
+// resolved'daki nil pod'lar (çözülemeyenler) yerleştirilmez
+func (as *AIScheduler) newBatchProblem(snapshot *clusterSnapshot, resolved, existing []*corev1.Pod, cfg *types.BatchConfig) *batchProblem {
+	problem := &batchProblem{
+		snapshot:      snapshot,
+		nodes:         make([]nodeInfo, len(snapshot.nodes)),
+		items:         make([]*batchItem, len(resolved)),
+		spreadPenalty: cfg.SpreadPenalty,
+		loadWeight:    cfg.LoadWeight,
+		maxRounds:     cfg.MaxRounds,
+	}
+	for i, info := range snapshot.nodes {
+		problem.nodes[i] = *info
+		problem.nodes[i].workloads = copyCounts(info.workloads)
+	}
+
+	defaultStrategy := strategyOf(as.currentConfig())
+	termIndex := make(map[string]int)
+	for i, pod := range resolved {
+		if pod == nil {
+			continue
+		}
+		request := as.newPodRequest(pod)
+		item := &batchItem{pod: pod, workload: workloadKey(pod), strategy: defaultStrategy}
+		if override := as.scoreOverrideFor(pod, as.hintsFor(pod)); override.strategy != "" {
+			item.strategy = override.strategy
+		}
+
+		// Önceki tahminde assume edilen pod'un istekleri node'dan çıkarılır, pod yeniden yerleştirilir
+		if request.ownNode != "" {
+			if n, ok := snapshot.index[request.ownNode]; ok {
+				problem.nodes[n].addPod(request.cpu, request.memory, item.workload, -1)
+			}
+			request.ownNode = ""
+		}
+		item.request = request
+		item.terms = problem.addTerms(termIndex, pod)
+		problem.items[i] = item
+	}
+
+	// Terimlerin batch pod'larıyla ve mevcut pod'larla eşleşmeleri
+	for _, term := range problem.terms {
+		term.matches = make([]bool, len(problem.items))
+		for i, item := range problem.items {
+			term.matches[i] = item != nil && term.matchesPod(item.pod)
+		}
+		term.existing = make(map[string]int)
+		for _, pod := range existing {
+			if !countsForTopology(pod) || !term.matchesPod(pod) {
+				continue
+			}
+			if n, ok := snapshot.index[pod.Spec.NodeName]; ok {
+				if domain, ok := nodeDomain(snapshot.nodes[n].node, term.topologyKey); ok {
+					term.existing[domain]++
+				}
+			}
+		}
+	}
+
+	// Mevcut pod'ların zorunlu anti-affinity'si kendisine uyan batch pod'larını alanından uzak tutar
+	for _, pod := range existing {
+		if !countsForTopology(pod) || pod.Spec.Affinity == nil || pod.Spec.Affinity.PodAntiAffinity == nil {
+			continue
+		}
+		n, ok := snapshot.index[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		for _, affinityTerm := range pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			domain, ok := nodeDomain(snapshot.nodes[n].node, affinityTerm.TopologyKey)
+			if !ok {
+				continue
+			}
+			term := newAntiAffinityTerm(pod, &affinityTerm)
+			for _, item := range problem.items {
+				if item != nil && term.matchesPod(item.pod) {
+					item.blocked = append(item.blocked, topologyDomain{key: affinityTerm.TopologyKey, value: domain})
+				}
+			}
+		}
+	}
+
+	// Filtre ve skorlar batch pod'larının assume kayıtları çıkarılmış node'larda hesaplanır
+	for _, item := range problem.items {
+		if item == nil {
+			continue
+		}
+		feasible := make([]*nodeInfo, 0, len(problem.nodes))
+		for n := range problem.nodes {
+			if reason := filterNode(&item.request, &problem.nodes[n]); reason != "" {
+				if item.rejected == nil {
+					item.rejected = make(map[string]int)
+				}
+				item.rejected[reason]++
+				continue
+			}
+			feasible = append(feasible, &problem.nodes[n])
+		}
+		item.scores = make(map[int]NodeScore, len(feasible))
+		for _, candidate := range as.scoreCandidates(item.pod, snapshot, &item.request, feasible) {
+			n := snapshot.index[candidate.NodeName]
+			item.scores[n] = candidate
+			item.order = append(item.order, n)
+		}
+		sort.SliceStable(item.order, func(a, b int) bool { return item.scores[item.order[a]].Score > item.scores[item.order[b]].Score })
+
+		// Spread kısıtında çarpıklık pod'un yerleşebileceği node'ların alanları üzerinden hesaplanır
+		for _, t := range item.terms {
+			term := problem.terms[t]
+			if term.antiAffinity {
+				continue
+			}
+			domains := make(map[string]bool)
+			for _, n := range item.order {
+				if domain, ok := nodeDomain(problem.nodes[n].node, term.topologyKey); ok {
+					domains[domain] = true
+				}
+			}
+			if item.domains == nil {
+				item.domains = make(map[int][]string)
+			}
+			for domain := range domains {
+				item.domains[t] = append(item.domains[t], domain)
+			}
+		}
+	}
+	return problem
+}
+
+// addTerms pod'un zorunlu anti-affinity terimlerini ve topology spread kısıtlarını terim tablosuna ekler,
+// pod'un terim indekslerini döndürür
+func (p *batchProblem) addTerms(index map[string]int, pod *corev1.Pod) []int {
+	var terms []int
+	add := func(key string, build func() *batchTerm) {
+		t, ok := index[key]
+		if !ok {
+			t = len(p.terms)
+			index[key] = t
+			p.terms = append(p.terms, build())
+		}
+		terms = append(terms, t)
+	}
+
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.PodAntiAffinity != nil {
+		for i := range pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			affinityTerm := &pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i]
+			term := newAntiAffinityTerm(pod, affinityTerm)
+			add(fmt.Sprintf("anti|%s|%s|%s", affinityTerm.TopologyKey, term.selector, namespaceKey(term.namespaces)),
+				func() *batchTerm { return term })
+		}
+	}
+	for i := range pod.Spec.TopologySpreadConstraints {
+		constraint := &pod.Spec.TopologySpreadConstraints[i]
+		selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil || constraint.LabelSelector == nil {
+			selector = labels.Nothing()
+		}
+		hard := constraint.WhenUnsatisfiable != corev1.ScheduleAnyway
+		maxSkew := max(int(constraint.MaxSkew), 1)
+		add(fmt.Sprintf("spread|%s|%s|%s|%d|%t", constraint.TopologyKey, selector, pod.Namespace, maxSkew, hard),
+			func() *batchTerm {
+				return &batchTerm{hard: hard, topologyKey: constraint.TopologyKey, maxSkew: maxSkew, selector: selector,
+					namespaces: map[string]bool{pod.Namespace: true}}
+			})
+	}
+	return terms
+}
+
+// newAntiAffinityTerm pod'un anti-affinity terimini çözer. Namespace listesi boşsa pod'un namespace'i kullanılır;
+// namespace seçicisi olan terimler namespace label'ları bilinmediği için tüm namespace'lere uygulanır
+func newAntiAffinityTerm(pod *corev1.Pod, affinityTerm *corev1.PodAffinityTerm) *batchTerm {
+	selector, err := metav1.LabelSelectorAsSelector(affinityTerm.LabelSelector)
+	if err != nil || affinityTerm.LabelSelector == nil {
+		selector = labels.Nothing()
+	}
+	term := &batchTerm{antiAffinity: true, hard: true, topologyKey: affinityTerm.TopologyKey, selector: selector}
+	switch {
+	case affinityTerm.NamespaceSelector != nil:
+		// Tüm namespace'ler
+	case len(affinityTerm.Namespaces) > 0:
+		term.namespaces = make(map[string]bool, len(affinityTerm.Namespaces))
+		for _, namespace := range affinityTerm.Namespaces {
+			term.namespaces[namespace] = true
+		}
+	default:
+		term.namespaces = map[string]bool{pod.Namespace: true}
+	}
+	return term
+}
+
+// namespaceKey namespace kümesini terim anahtarı için sıralı metne çevirir
+func namespaceKey(namespaces map[string]bool) string {
+	if namespaces == nil {
+		return "*"
+	}
+	keys := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		keys = append(keys, namespace)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// countsForTopology pod node'a bağlı ve bitmemişse kısıtlarda sayılır
+func countsForTopology(pod *corev1.Pod) bool {
+	return pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+// nodeDomain node'un topoloji anahtarındaki değerini döndürür, hostname label'ı yoksa node adı kullanılır
+func nodeDomain(node *corev1.Node, key string) (string, bool) {
+	if value, ok := node.Labels[key]; ok {
+		return value, true
+	}
+	if key == corev1.LabelHostname {
+		return node.Name, true
+	}
+	return "", false
+}
+
+// copyCounts sayaç haritasını kopyalar
+func copyCounts(counts map[string]int) map[string]int {
+	copied := make(map[string]int, len(counts))
+	for key, count := range counts {
+		copied[key] = count
+	}
+	return copied
+}
+
+// addPod node'un isteklerine pod ekler (delta 1) veya çıkarır (delta -1)
+func (info *nodeInfo) addPod(cpu, memory float64, workload string, delta int) {
+	info.requestedCPU += cpu * float64(delta)
+	info.requestedMemory += memory * float64(delta)
+	info.pods += delta
+	if workload != "" {
+		info.workloads[workload] += delta
+		if info.workloads[workload] <= 0 {
+			delete(info.workloads, workload)
+		}
+	}
+}
+
+// requestOrder pod'ların istekteki sırası
+func (p *batchProblem) requestOrder() []int {
+	order := make([]int, 0, len(p.items))
+	for i, item := range p.items {
+		if item != nil {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// constrainedOrder en az node'a sığan, sonra en büyük istekli pod önce
+func (p *batchProblem) constrainedOrder() []int {
+	order := p.requestOrder()
+	sort.SliceStable(order, func(a, b int) bool {
+		first, second := p.items[order[a]], p.items[order[b]]
+		if len(first.order) != len(second.order) {
+			return len(first.order) < len(second.order)
+		}
+		if first.request.cpu != second.request.cpu {
+			return first.request.cpu > second.request.cpu
+		}
+		return first.request.memory > second.request.memory
+	})
+	return order
+}
+
+// newState hiçbir batch pod'u yerleşmemiş çalışma durumu kurar
+func (p *batchProblem) newState() *batchState {
+	state := &batchState{
+		nodes:   make([]nodeInfo, len(p.nodes)),
+		placed:  make([]map[string]int, len(p.terms)),
+		owners:  make([]map[string]int, len(p.terms)),
+		assign:  make([]int, len(p.items)),
+		problem: p,
+	}
+	for i := range p.nodes {
+		state.nodes[i] = p.nodes[i]
+		state.nodes[i].workloads = copyCounts(p.nodes[i].workloads)
+	}
+	for t := range p.terms {
+		state.placed[t] = make(map[string]int)
+		state.owners[t] = make(map[string]int)
+	}
+	for i := range state.assign {
+		state.assign[i] = -1
+	}
+	return state
+}
+
+// set pod'u node'a yerleştirir (delta 1) veya node'dan kaldırır (delta -1)
+func (s *batchState) set(i, n, delta int) {
+	item := s.problem.items[i]
+	s.nodes[n].addPod(item.request.cpu, item.request.memory, item.workload, delta)
+	node := s.nodes[n].node
+	for t, term := range s.problem.terms {
+		if !term.matches[i] {
+			continue
+		}
+		if domain, ok := nodeDomain(node, term.topologyKey); ok {
+			s.placed[t][domain] += delta
+		}
+	}
+	for _, t := range item.terms {
+		if !s.problem.terms[t].antiAffinity {
+			continue
+		}
+		if domain, ok := nodeDomain(node, s.problem.terms[t].topologyKey); ok {
+			s.owners[t][domain] += delta
+		}
+	}
+	if delta > 0 {
+		s.assign[i] = n
+	} else {
+		s.assign[i] = -1
+	}
+}
+
+// violation yerleşmemiş pod filtreden geçtiği node'a konamıyorsa sebebini döndürür
+func (s *batchState) violation(i, n int) string {
+	item := s.problem.items[i]
+	if reason := filterNode(&item.request, &s.nodes[n]); reason != "" {
+		return reason
+	}
+
+	node := s.nodes[n].node
+	for _, blocked := range item.blocked {
+		if domain, ok := nodeDomain(node, blocked.key); ok && domain == blocked.value {
+			return filterPodAntiAffinity
+		}
+	}
+	for t, term := range s.problem.terms {
+		// Yerleşmiş batch pod'larının anti-affinity'si bu pod'u dışlıyor mu
+		if !term.antiAffinity || !term.matches[i] {
+			continue
+		}
+		if domain, ok := nodeDomain(node, term.topologyKey); ok && s.owners[t][domain] > 0 {
+			return filterPodAntiAffinity
+		}
+	}
+	for _, t := range item.terms {
+		term := s.problem.terms[t]
+		domain, ok := nodeDomain(node, term.topologyKey)
+		switch {
+		case term.antiAffinity:
+			if ok && term.existing[domain]+s.placed[t][domain] > 0 {
+				return filterPodAntiAffinity
+			}
+		case term.hard:
+			// Topoloji anahtarı olmayan node'lar DoNotSchedule kısıtını sağlayamaz
+			if !ok || s.skew(i, t, domain) > term.maxSkew {
+				return filterTopologySpread
+			}
+		}
+	}
+	return ""
+}
+
+// skew pod alana yerleşirse spread teriminin çarpıklığını döndürür
+func (s *batchState) skew(i, t int, domain string) int {
+	term := s.problem.terms[t]
+	minimum := -1
+	for _, candidate := range s.problem.items[i].domains[t] {
+		count := term.existing[candidate] + s.placed[t][candidate]
+		if minimum < 0 || count < minimum {
+			minimum = count
+		}
+	}
+	if minimum < 0 {
+		minimum = 0
+	}
+	return term.existing[domain] + s.placed[t][domain] + 1 - minimum
+}
+
+// value yerleşmemiş pod'un node'daki değerini döndürür: heuristik skor, stratejiye göre node'un istek doluluğu
+// (spread'de ceza, binpack'te bonus) ve ScheduleAnyway spread kısıtlarının aşılan çarpıklığı için ceza
+func (s *batchState) value(i, n int) (float64, string) {
+	item := s.problem.items[i]
+	candidate := item.scores[n]
+	score, reason := candidate.Score, candidate.Reason
+
+	if weight := s.problem.loadWeight; weight > 0 {
+		info := &s.nodes[n]
+		load := 0.0
+		if info.capacityCPU > 0 {
+			load = (info.requestedCPU + item.request.cpu) / info.capacityCPU
+		}
+		if info.capacityMemory > 0 {
+			load = max(load, (info.requestedMemory+item.request.memory)/info.capacityMemory)
+		}
+		if item.strategy == StrategyBinPack {
+			score += weight * load
+			reason += fmt.Sprintf(" + Toplu yerleşim doluluk bonusu: %.1f", weight*load)
+		} else {
+			score -= weight * load
+			reason += fmt.Sprintf(" - Toplu yerleşim doluluk cezası: %.1f", weight*load)
+		}
+	}
+
+	for _, t := range item.terms {
+		term := s.problem.terms[t]
+		if term.antiAffinity || term.hard {
+			continue
+		}
+		domain, ok := nodeDomain(s.nodes[n].node, term.topologyKey)
+		if !ok {
+			continue
+		}
+		if excess := s.skew(i, t, domain) - term.maxSkew; excess > 0 {
+			penalty := s.problem.spreadPenalty * float64(excess)
+			score -= penalty
+			reason += fmt.Sprintf(" - Yayılım cezası: %.1f (%s çarpıklığı %d aşıldı)", penalty, term.topologyKey, excess)
+		}
+	}
+	return score, reason
+}
+
+// bestNode yerleşmemiş pod'un kısıtları sağlayan en değerli node'unu döndürür, yoksa -1
+func (s *batchState) bestNode(i, exclude int) (int, float64) {
+	best, bestValue := -1, 0.0
+	for _, n := range s.problem.items[i].order {
+		if n == exclude || s.violation(i, n) != "" {
+			continue
+		}
+		if value, _ := s.value(i, n); best < 0 || value > bestValue {
+			best, bestValue = n, value
+		}
+	}
+	return best, bestValue
+}
+
+// construct pod'ları verilen sırayla en değerli uygun node'a yerleştirir
+func (s *batchState) construct(order []int) {
+	for _, i := range order {
+		if n, _ := s.bestNode(i, -1); n >= 0 {
+			s.set(i, n, 1)
+		}
+	}
+}
+
+// objective yerleşen pod sayısını ve değerlerinin toplamını döndürür
+func (s *batchState) objective() (int, float64) {
+	placed, total := 0, 0.0
+	for i, n := range s.assign {
+		if n < 0 {
+			continue
+		}
+		s.set(i, n, -1)
+		value, _ := s.value(i, n)
+		s.set(i, n, 1)
+		placed++
+		total += value
+	}
+	return placed, total
+}
+
+// better durum diğerinden daha çok pod yerleştiriyorsa veya eşit sayıda pod'la daha yüksek toplam değer veriyorsa
+// true döner
+func (s *batchState) better(other *batchState) bool {
+	placed, total := s.objective()
+	otherPlaced, otherTotal := other.objective()
+	if placed != otherPlaced {
+		return placed > otherPlaced
+	}
+	return total > otherTotal+batchImprovementEpsilon
+}
+
+// improve çözümü iyileşme kalmayana veya tur sınırına kadar yerel aramayla iyileştirir
+func (s *batchState) improve() {
+	for round := 0; round < s.problem.maxRounds; round++ {
+		improved := s.insertUnplaced()
+		improved = s.relocate() || improved
+		improved = s.swap() || improved
+		if !improved {
+			return
+		}
+	}
+}
+
+// relocate her pod'u kısıtları sağlayan daha değerli bir node varsa oraya taşır
+func (s *batchState) relocate() bool {
+	improved := false
+	for i, n := range s.assign {
+		if n < 0 {
+			continue
+		}
+		s.set(i, n, -1)
+		current, _ := s.value(i, n)
+		best, value := s.bestNode(i, n)
+		if best >= 0 && value > current+batchImprovementEpsilon {
+			n = best
+			s.moves++
+			improved = true
+		}
+		s.set(i, n, 1)
+	}
+	return improved
+}
+
+// insertUnplaced yerleşmemiş pod'ları yerleştirir; uygun node yoksa bir node'daki pod'u başka bir node'a taşıyarak
+// yer açmayı dener
+func (s *batchState) insertUnplaced() bool {
+	improved := false
+	for i, n := range s.assign {
+		if n >= 0 || s.problem.items[i] == nil {
+			continue
+		}
+		if best, _ := s.bestNode(i, -1); best >= 0 {
+			s.set(i, best, 1)
+			improved = true
+			continue
+		}
+		if s.makeRoom(i) {
+			s.moves++
+			improved = true
+		}
+	}
+	return improved
+}
+
+// makeRoom pod'un filtreden geçtiği node'lardan birindeki bir batch pod'unu başka bir node'a taşıyıp pod'u
+// boşalan yere yerleştirir, başarırsa true döner
+func (s *batchState) makeRoom(i int) bool {
+	for _, n := range s.problem.items[i].order {
+		for j, m := range s.assign {
+			if m != n {
+				continue
+			}
+			s.set(j, n, -1)
+			if s.violation(i, n) == "" {
+				s.set(i, n, 1)
+				if target, _ := s.bestNode(j, n); target >= 0 {
+					s.set(j, target, 1)
+					return true
+				}
+				s.set(i, n, -1)
+			}
+			s.set(j, n, 1)
+		}
+	}
+	return false
+}
+
+// swap farklı node'lardaki iki pod'un yerlerini değiştirmek toplam değeri artırıyorsa değiştirir
+func (s *batchState) swap() bool {
+	improved := false
+	for i := range s.assign {
+		for j := i + 1; j < len(s.assign); j++ {
+			first, second := s.assign[i], s.assign[j]
+			if first < 0 || second < 0 || first == second {
+				continue
+			}
+			if _, ok := s.problem.items[i].scores[second]; !ok {
+				continue
+			}
+			if _, ok := s.problem.items[j].scores[first]; !ok {
+				continue
+			}
+
+			s.set(i, first, -1)
+			s.set(j, second, -1)
+			before := s.pairValue(i, first, j, second)
+			after, ok := 0.0, false
+			if s.violation(i, second) == "" {
+				s.set(i, second, 1)
+				if s.violation(j, first) == "" {
+					s.set(i, second, -1)
+					after, ok = s.pairValue(i, second, j, first), true
+				} else {
+					s.set(i, second, -1)
+				}
+			}
+			if ok && after > before+batchImprovementEpsilon {
+				first, second = second, first
+				s.moves++
+				improved = true
+			}
+			s.set(i, first, 1)
+			s.set(j, second, 1)
+		}
+	}
+	return improved
+}
+
+// pairValue yerleşmemiş iki pod'un sırayla verilen node'lara yerleşince değerlerinin toplamını döndürür
+func (s *batchState) pairValue(i, first, j, second int) float64 {
+	value, _ := s.value(i, first)
+	s.set(i, first, 1)
+	other, _ := s.value(j, second)
+	s.set(i, first, -1)
+	return value + other
+}
+
+// applyBatch çözümü sonuca yazar; yerleşen pod'lar tek pod tahminindeki gibi assume edilip karar geçmişine,
+// yerleşemeyenler uygun node bulunamayan karar olarak yazılır
+func (as *AIScheduler) applyBatch(problem *batchProblem, state *batchState, result *BatchResult) {
+	cfg := as.currentConfig()
+	observeOnly := as.observeOnly()
+	for i, item := range problem.items {
+		if item == nil {
+			continue
+		}
+		placement := &result.Placements[i]
+		n := state.assign[i]
+		if n < 0 {
+			rejected := copyCounts(item.rejected)
+			for _, candidate := range item.order {
+				if reason := state.violation(i, candidate); reason != "" {
+					rejected[reason]++
+				}
+			}
+			placement.Rejected = rejected
+			as.recordUnschedulable(&item.request, rejected)
+			continue
+		}
+
+		state.set(i, n, -1)
+		score, reason := state.value(i, n)
+		state.set(i, n, 1)
+		nodeScore := NodeScore{NodeName: problem.nodes[n].node.Name, Score: score, Reason: reason, ObserveOnly: observeOnly}
+		placement.Node, placement.Score, placement.Reason, placement.ObserveOnly = nodeScore.NodeName, score, reason, observeOnly
+
+		if cfg.AssumeTTL > 0 && !observeOnly {
+			as.AssumePod(item.pod, nodeScore.NodeName, cfg.AssumeTTL)
+		}
+		if !observeOnly {
+			as.recordCronPod(item.pod, nodeScore.NodeName)
+		}
+		as.recordDecision(as.decisionFor(item.pod, &nodeScore, item.rejected))
+	}
+}
@@ -0,0 +1,363 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Binding varsayılanları
+const (
+	defaultBindingSchedulerName = "ai-scheduler"
+	defaultBindingResync        = 30 * time.Second
+	defaultBindingBackoff       = time.Second
+	defaultBindingMaxBackoff    = time.Minute
+	bindingQueueSize            = 1000
+	bindingEnabledCheck         = 5 * time.Second // binding açılıp kapanmasının denetim aralığı
+
+	defaultBindingLeaseNamespace = "kube-system"
+	defaultBindingLeaseName      = "ai-scheduler-binding"
+	defaultBindingLeaseDuration  = 15 * time.Second
+	defaultBindingRenewDeadline  = 10 * time.Second
+	defaultBindingRetryPeriod    = 2 * time.Second
+)
+
+// bindingQueue bağlanacak pod'ların kuyruğu. Pod olayları ve periyodik tarama kuyruğa ekler, aynı pod kuyrukta bir
+// kez bulunur; başarısız denemeler üstel artan bekleme ile yeniden kuyruğa alınır. Kuyruğa sadece bağlayan (lider)
+// replika ekler
+type bindingQueue struct {
+	init     sync.Once
+	leading  atomic.Bool
+	queue    chan podKey
+	mutex    sync.Mutex
+	queued   map[podKey]bool
+	attempts map[podKey]int
+	bound    atomic.Uint64
+	failures atomic.Uint64
+}
+
+// channel bağlama kuyruğunu döndürür
+func (q *bindingQueue) channel() chan podKey {
+	q.init.Do(func() { q.queue = make(chan podKey, bindingQueueSize) })
+	return q.queue
+}
+
+// bindingSettings varsayılanları uygulanmış binding ayarları
+func (as *AIScheduler) bindingSettings() types.BindingConfig {
+	cfg := as.currentConfig().Binding
+	if cfg.SchedulerName == "" {
+		cfg.SchedulerName = defaultBindingSchedulerName
+	}
+	if cfg.ResyncInterval <= 0 {
+		cfg.ResyncInterval = defaultBindingResync
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultBindingBackoff
+	}
+	if cfg.MaxBackoff < cfg.Backoff {
+		cfg.MaxBackoff = max(defaultBindingMaxBackoff, cfg.Backoff)
+	}
+	election := &cfg.LeaderElection
+	if election.Namespace == "" {
+		election.Namespace = defaultBindingLeaseNamespace
+	}
+	if election.Name == "" {
+		election.Name = defaultBindingLeaseName
+	}
+	if election.LeaseDuration <= 0 {
+		election.LeaseDuration = defaultBindingLeaseDuration
+	}
+	if election.RenewDeadline <= 0 || election.RenewDeadline >= election.LeaseDuration {
+		election.RenewDeadline = min(defaultBindingRenewDeadline, election.LeaseDuration*2/3)
+	}
+	if election.RetryPeriod <= 0 {
+		election.RetryPeriod = defaultBindingRetryPeriod
+	}
+	return cfg
+}
+
+// bindable pod bu scheduler'a atanmış, henüz bağlanmamış ve silinmiyorsa true döner
+func bindable(pod *corev1.Pod, schedulerName string) bool {
+	return pod.Spec.SchedulerName == schedulerName && pod.Spec.NodeName == "" &&
+		pod.Status.Phase == corev1.PodPending && pod.DeletionTimestamp == nil
+}
+
+// enqueueBinding pod'u bağlama kuyruğuna ekler, bu replika bağlamıyorsa, pod zaten kuyruktaysa veya kuyruk doluysa
+// eklemez
+func (as *AIScheduler) enqueueBinding(key podKey) {
+	q := &as.binding
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if !q.leading.Load() || q.queued[key] {
+		return
+	}
+	select {
+	case q.channel() <- key:
+		if q.queued == nil {
+			q.queued = make(map[podKey]bool)
+		}
+		q.queued[key] = true
+	default:
+		logrus.Debugf("Binding kuyruğu dolu, %s/%s sonraki taramada denenecek", key.namespace, key.name)
+	}
+}
+
+// dequeueBinding pod'u kuyrukta değil olarak işaretler, sonraki olaylarda yeniden eklenebilir
+func (as *AIScheduler) dequeueBinding(key podKey) {
+	q := &as.binding
+	q.mutex.Lock()
+	delete(q.queued, key)
+	q.mutex.Unlock()
+}
+
+// observeBinding bağlanmayı bekleyen pod'ların olaylarını kuyruğa alır, bağlanan veya silinen pod'ların deneme
+// sayacını siler
+func (as *AIScheduler) observeBinding(pod *corev1.Pod, deleted bool) {
+	cfg := as.bindingSettings()
+	if !cfg.Enabled {
+		return
+	}
+	key := podKey{namespace: pod.Namespace, name: pod.Name}
+	if deleted || !bindable(pod, cfg.SchedulerName) {
+		q := &as.binding
+		q.mutex.Lock()
+		delete(q.attempts, key)
+		q.mutex.Unlock()
+		return
+	}
+	as.enqueueBinding(key)
+}
+
+// bindingLoop binding açıldığında bağlamayı başlatır, kapatıldığında durdurur. Kubernetes API'ye erişilebiliyorsa
+// replikalar Lease ile lider seçer ve sadece lider bağlar; böylece birden fazla replikada aynı pod iki kez bağlanmaz
+func (as *AIScheduler) bindingLoop(ctx context.Context) {
+	ticker := time.NewTicker(bindingEnabledCheck)
+	defer ticker.Stop()
+
+	for {
+		if as.bindingSettings().Enabled {
+			enabledCtx, cancel := as.whileBindingEnabled(ctx)
+			if as.hasAPI() {
+				as.campaignBinding(enabledCtx)
+			} else {
+				// Sentetik küme tek süreçtir, lider seçimi gerekmez
+				as.runBinding(enabledCtx)
+			}
+			cancel()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// whileBindingEnabled binding kapatıldığında iptal olan context döndürür
+func (as *AIScheduler) whileBindingEnabled(ctx context.Context) (context.Context, context.CancelFunc) {
+	enabledCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(bindingEnabledCheck)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-enabledCtx.Done():
+				return
+			case <-ticker.C:
+				if !as.bindingSettings().Enabled {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return enabledCtx, cancel
+}
+
+// campaignBinding Lease'i almaya çalışır, alırsa liderlik sürdükçe bağlar. Liderlik kaybedildiğinde veya ctx iptal
+// olduğunda döner, iptalde Lease bırakılır ki diğer replika beklemeden devralsın
+func (as *AIScheduler) campaignBinding(ctx context.Context) {
+	election := as.bindingSettings().LeaderElection
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "ai-scheduler"
+	}
+	identity := hostname + "_" + string(uuid.NewUUID())
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: election.Namespace, Name: election.Name},
+			Client:     as.k8sClient.GetClientset().CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		},
+		LeaseDuration:   election.LeaseDuration,
+		RenewDeadline:   election.RenewDeadline,
+		RetryPeriod:     election.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            election.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				logrus.Infof("Binding lideri bu replika (%s), pod'lar bağlanıyor", identity)
+				as.runBinding(leaderCtx)
+				logrus.Infof("Binding liderliği bitti (%s)", identity)
+			},
+			OnStoppedLeading: func() {},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					logrus.Infof("Binding lideri %s, bu replika bağlamıyor", leader)
+				}
+			},
+		},
+	})
+	if err != nil {
+		logrus.Errorf("Binding lider seçimi kurulamadı: %v", err)
+		return
+	}
+	elector.Run(ctx)
+}
+
+// runBinding ctx iptal olana kadar kuyruktaki pod'ları tahmin edilen node'a bağlar. Olay bildirmeyen küme
+// kaynaklarında (veya kaçan olaylar için) bekleyen pod'lar ResyncInterval aralıkla taranır
+func (as *AIScheduler) runBinding(ctx context.Context) {
+	as.binding.leading.Store(true)
+	defer as.binding.leading.Store(false)
+
+	resync := as.bindingSettings().ResyncInterval
+	ticker := time.NewTicker(resync)
+	defer ticker.Stop()
+
+	as.resyncBinding()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case key := <-as.binding.channel():
+			as.dequeueBinding(key)
+			as.bindPod(ctx, key)
+		case <-ticker.C:
+			as.resyncBinding()
+			if next := as.bindingSettings().ResyncInterval; next != resync {
+				resync = next
+				ticker.Reset(resync)
+			}
+		}
+	}
+}
+
+// resyncBinding bağlanmayı bekleyen tüm pod'ları kuyruğa ekler
+func (as *AIScheduler) resyncBinding() {
+	cfg := as.bindingSettings()
+	if !cfg.Enabled {
+		return
+	}
+	pods, err := as.listPods()
+	if err != nil {
+		logrus.Warnf("Bağlanacak pod'lar listelenemedi: %v", err)
+		return
+	}
+	for _, pod := range pods {
+		if bindable(pod, cfg.SchedulerName) {
+			as.enqueueBinding(podKey{namespace: pod.Namespace, name: pod.Name})
+		}
+	}
+}
+
+// bindPod pod için en iyi node'u tahmin eder ve pod'u o node'a bağlar. Tahmin veya binding başarısız olursa
+// pod bekleme süresi sonunda yeniden kuyruğa alınır
+func (as *AIScheduler) bindPod(ctx context.Context, key podKey) {
+	cfg := as.bindingSettings()
+	if !cfg.Enabled {
+		return
+	}
+	pod, err := as.getPod(ctx, key.namespace, key.name)
+	if err != nil || !bindable(pod, cfg.SchedulerName) {
+		// Pod silindi, başka bir yolla bağlandı veya artık bu scheduler'a ait değil
+		return
+	}
+	if !as.currentConfig().Namespaces.Matches(pod.Namespace) {
+		logrus.Debugf("%s/%s scheduling kapsamı dışında, bağlanmayacak", pod.Namespace, pod.Name)
+		return
+	}
+
+	score, err := as.PredictBestNode(ctx, pod.Name, pod.Namespace)
+	switch {
+	case err != nil:
+		as.retryBinding(key, cfg, fmt.Sprintf("node seçilemedi: %v", err))
+		return
+	case score.ObserveOnly:
+		as.retryBinding(key, cfg, "gözlem modu açık, binding yapılmıyor")
+		return
+	}
+
+	if err := as.bind(ctx, pod, score.NodeName); err != nil {
+		as.binding.failures.Add(1)
+		as.reportError(types.ErrorComponentBinding, err)
+		// Assume edilen kapasite binding olmadan serbest bırakılır
+		as.forgetPod(key)
+		as.retryBinding(key, cfg, fmt.Sprintf("%s node'una bağlanamadı: %v", score.NodeName, err))
+		return
+	}
+
+	as.binding.bound.Add(1)
+	q := &as.binding
+	q.mutex.Lock()
+	delete(q.attempts, key)
+	q.mutex.Unlock()
+	logrus.Infof("%s/%s %s node'una bağlandı (skor: %.2f)", pod.Namespace, pod.Name, score.NodeName, score.Score)
+}
+
+// bind pod'u node'a bağlar: küme kaynağı binding destekliyorsa (ör: sentetik küme) kaynakta, değilse Binding API ile
+func (as *AIScheduler) bind(ctx context.Context, pod *corev1.Pod, nodeName string) error {
+	if binder, ok := as.source.(types.PodBinder); ok {
+		return binder.BindPod(pod.Namespace, pod.Name, nodeName)
+	}
+	if !as.hasAPI() {
+		return errors.New("Kubernetes client yok")
+	}
+
+	binding := &corev1.Binding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID},
+		Target:     corev1.ObjectReference{Kind: "Node", Name: nodeName},
+	}
+	return as.k8sClient.GetClientset().CoreV1().Pods(pod.Namespace).Bind(ctx, binding, metav1.CreateOptions{})
+}
+
+// retryBinding pod'u deneme sayısına göre üstel artan (MaxBackoff ile sınırlı) süre sonra yeniden kuyruğa alır
+func (as *AIScheduler) retryBinding(key podKey, cfg types.BindingConfig, reason string) {
+	q := &as.binding
+	q.mutex.Lock()
+	if q.attempts == nil {
+		q.attempts = make(map[podKey]int)
+	}
+	q.attempts[key]++
+	attempts := q.attempts[key]
+	q.mutex.Unlock()
+
+	delay := cfg.Backoff
+	for i := 1; i < attempts && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, cfg.MaxBackoff)
+
+	logrus.Debugf("%s/%s bağlanamadı (%d. deneme), %s sonra yeniden denenecek: %s", key.namespace, key.name, attempts, delay, reason)
+	time.AfterFunc(delay, func() { as.enqueueBinding(key) })
+}
+
+// BindingStats bağlanan pod sayısını ve başarısız binding isteklerini döndürür
+func (as *AIScheduler) BindingStats() (bound, failures uint64) {
+	return as.binding.bound.Load(), as.binding.failures.Load()
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// capacityHints kapasite olaylarının alıcıları ve tekrar gönderimi engelleyen son gönderim zamanları
+type capacityHints struct {
+	mutex sync.Mutex
+	sinks []types.CapacityHintSink
+	sent  map[string]time.Time // iş yükü + kaynak şekli -> son gönderim
+}
+
+// AddCapacityHintSink "kapasite gerekli" olaylarının alıcısını ekler (ör: webhook, Prometheus)
+func (as *AIScheduler) AddCapacityHintSink(sink types.CapacityHintSink) {
+	as.hints.mutex.Lock()
+	defer as.hints.mutex.Unlock()
+
+	as.hints.sinks = append(as.hints.sinks, sink)
+}
+
+// capacityHint uygun node'u olmayan pod için kapasite olayını oluşturur, kapalıysa nil
+func (as *AIScheduler) capacityHint(request *podRequest, rejected map[string]int) *types.CapacityHint {
+	cfg := as.currentConfig().CapacityHints
+	if !cfg.Enabled {
+		return nil
+	}
+
+	pod := request.pod
+	return &types.CapacityHint{
+		Time:         as.now(),
+		Namespace:    pod.Namespace,
+		Pod:          pod.Name,
+		Workload:     types.WorkloadOf(pod),
+		CPU:          request.cpu,
+		Memory:       request.memory,
+		NodePool:     types.PodPlacementLabel(pod, cfg.PoolLabel),
+		Zone:         types.PodPlacementLabel(pod, cfg.ZoneLabel),
+		NodeSelector: pod.Spec.NodeSelector,
+		Rejected:     rejected,
+	}
+}
+
+// recordUnschedulable uygun node'u olmayan bekleyen pod için kararı geçmişe yazar ve kapasite olayını üretir.
+// Gözlem modunda olay sadece karara eklenir, dışarıya gönderilmez
+func (as *AIScheduler) recordUnschedulable(request *podRequest, rejected map[string]int) {
+	decision := Decision{
+		Namespace: request.pod.Namespace,
+		Pod:       request.pod.Name,
+		Workload:  types.WorkloadOf(request.pod),
+		Outcome:   OutcomeUnschedulable,
+		Rejected:  rejected,
+		CPU:       request.cpu,
+		Memory:    request.memory,
+	}
+	if request.pod.Spec.NodeName == "" {
+		decision.CapacityNeeded = as.capacityHint(request, rejected)
+		if decision.CapacityNeeded != nil && !as.observeOnly() {
+			as.emitCapacityHint(decision.CapacityNeeded)
+		}
+	}
+	as.recordDecision(decision)
+}
+
+// emitCapacityHint olayı alıcılara iletir; aynı iş yükü ve kaynak şekli cooldown süresince tekrar gönderilmez
+func (as *AIScheduler) emitCapacityHint(hint *types.CapacityHint) {
+	cooldown := as.currentConfig().CapacityHints.Cooldown
+	key := fmt.Sprintf("%s|%.3f|%.3f|%s|%s", hint.Workload, hint.CPU, hint.Memory, hint.NodePool, hint.Zone)
+
+	as.hints.mutex.Lock()
+	if last, ok := as.hints.sent[key]; ok && hint.Time.Sub(last) < cooldown {
+		as.hints.mutex.Unlock()
+		return
+	}
+	if as.hints.sent == nil {
+		as.hints.sent = make(map[string]time.Time)
+	}
+	// Süresi geçen kayıtlar temizlenir, harita sadece cooldown içindeki şekilleri tutar
+	for k, last := range as.hints.sent {
+		if hint.Time.Sub(last) >= cooldown {
+			delete(as.hints.sent, k)
+		}
+	}
+	as.hints.sent[key] = hint.Time
+	sinks := as.hints.sinks
+	as.hints.mutex.Unlock()
+
+	logrus.Infof("%s/%s için uygun node yok, kapasite gerekli: %.2f CPU, %.2f GB (havuz: %q, zone: %q)",
+		hint.Namespace, hint.Pod, hint.CPU, hint.Memory, hint.NodePool, hint.Zone)
+	for _, sink := range sinks {
+		sink.CapacityNeeded(hint)
+	}
+}
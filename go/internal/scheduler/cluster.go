@@ -0,0 +1,241 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetClusterSource Kubernetes API yerine kullanılacak küme kaynağını ayarlar (ör: informer cache, sentetik küme).
+// Kaynak pod olaylarını bildiriyorsa kararların sonuçları ve diğer scheduler'ların yerleşimleri bu olaylarla eşleştirilir,
+// StatefulSet pod'larının çalıştığı node'lar hatırlanır ve CronJob tetiklenmeleri öğrenilir
+func (as *AIScheduler) SetClusterSource(source types.ClusterSource) {
+	as.source = source
+	if events, ok := source.(types.PodEventSource); ok {
+		events.AddPodHandler(as.observePod)
+		events.AddPodHandler(as.observeComparison)
+		events.AddPodHandler(as.observeIncarnation)
+		events.AddPodHandler(as.observeCronBurst)
+		events.AddPodHandler(as.observePending)
+		events.AddPodHandler(as.observeBinding)
+	}
+}
+
+// hasAPI Kubernetes API'ye erişilebiliyorsa true döner
+func (as *AIScheduler) hasAPI() bool {
+	return as.k8sClient != nil && as.k8sClient.GetClientset() != nil
+}
+
+// runContext Start'a verilen context'i döndürür, Start çağrılmadıysa Background. Paylaşılan snapshot ve cache'leri
+// dolduran API çağrıları tek bir isteğe ait olmadığından bu context'le yapılır ve kapanışta iptal olur
+func (as *AIScheduler) runContext() context.Context {
+	if ctx := as.runCtx.Load(); ctx != nil {
+		return *ctx
+	}
+	return context.Background()
+}
+
+// getPod pod'u küme kaynağından veya Kubernetes API'den alır
+func (as *AIScheduler) getPod(ctx context.Context, namespace, podName string) (*corev1.Pod, error) {
+	if as.source != nil {
+		if pod, ok := as.source.Pod(namespace, podName); ok {
+			return pod, nil
+		}
+		// Yeni oluşturulan pod informer cache'ine henüz düşmemiş olabilir, API'ye sorulur
+		if !as.hasAPI() {
+			return nil, fmt.Errorf("%s/%s küme kaynağında yok: %w", namespace, podName, types.ErrPodNotFound)
+		}
+	}
+
+	pod, err := as.k8sClient.GetClientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%s/%s: %w", namespace, podName, types.ErrPodNotFound)
+	}
+	return pod, err
+}
+
+// listNodes node listesini küme kaynağından veya Kubernetes API'den alır
+func (as *AIScheduler) listNodes() ([]*corev1.Node, error) {
+	if as.source != nil {
+		return as.source.Nodes(), nil
+	}
+
+	nodes, err := as.k8sClient.GetClientset().CoreV1().Nodes().List(as.runContext(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*corev1.Node, len(nodes.Items))
+	for i := range nodes.Items {
+		result[i] = &nodes.Items[i]
+	}
+	return result, nil
+}
+
+// listPods pod listesini küme kaynağından veya Kubernetes API'den alır
+func (as *AIScheduler) listPods() ([]*corev1.Pod, error) {
+	if as.source != nil {
+		return as.source.Pods(), nil
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("kubernetes client yok")
+	}
+
+	pods, err := as.k8sClient.GetClientset().CoreV1().Pods("").List(as.runContext(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		result[i] = &pods.Items[i]
+	}
+	return result, nil
+}
+
+// listPDBs PodDisruptionBudget'ları küme kaynağından (destekliyorsa) veya Kubernetes API'den alır
+func (as *AIScheduler) listPDBs() ([]*policyv1.PodDisruptionBudget, error) {
+	if source, ok := as.source.(types.DisruptionBudgetSource); ok {
+		return source.PodDisruptionBudgets(), nil
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("kubernetes client yok")
+	}
+
+	pdbs, err := as.k8sClient.GetClientset().PolicyV1().PodDisruptionBudgets("").List(as.runContext(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*policyv1.PodDisruptionBudget, len(pdbs.Items))
+	for i := range pdbs.Items {
+		result[i] = &pdbs.Items[i]
+	}
+	return result, nil
+}
+
+// listServices Service'leri küme kaynağından (destekliyorsa) veya Kubernetes API'den alır
+func (as *AIScheduler) listServices() ([]*corev1.Service, error) {
+	if source, ok := as.source.(types.ServiceSource); ok {
+		return source.Services(), nil
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("kubernetes client yok")
+	}
+
+	services, err := as.k8sClient.GetClientset().CoreV1().Services("").List(as.runContext(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*corev1.Service, len(services.Items))
+	for i := range services.Items {
+		result[i] = &services.Items[i]
+	}
+	return result, nil
+}
+
+// listCSINodes CSINode listesini küme kaynağından (destekliyorsa) veya Kubernetes API'den alır
+func (as *AIScheduler) listCSINodes() ([]*storagev1.CSINode, error) {
+	if source, ok := as.source.(types.CSINodeSource); ok {
+		return source.CSINodes(), nil
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("kubernetes client yok")
+	}
+
+	csiNodes, err := as.k8sClient.GetClientset().StorageV1().CSINodes().List(as.runContext(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*storagev1.CSINode, len(csiNodes.Items))
+	for i := range csiNodes.Items {
+		result[i] = &csiNodes.Items[i]
+	}
+	return result, nil
+}
+
+// getRuntimeClass RuntimeClass'ı küme kaynağından (destekliyorsa) veya Kubernetes API'den alır
+func (as *AIScheduler) getRuntimeClass(name string) (*nodev1.RuntimeClass, error) {
+	if source, ok := as.source.(types.RuntimeClassSource); ok {
+		if runtimeClass, ok := source.RuntimeClass(name); ok {
+			return runtimeClass, nil
+		}
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("runtime class %s bulunamadı: kubernetes client yok", name)
+	}
+
+	return as.k8sClient.GetClientset().NodeV1().RuntimeClasses().Get(as.runContext(), name, metav1.GetOptions{})
+}
+
+// getPersistentVolumeClaim PVC'yi küme kaynağından (destekliyorsa) veya Kubernetes API'den alır
+func (as *AIScheduler) getPersistentVolumeClaim(namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	if source, ok := as.source.(types.VolumeSource); ok {
+		if claim, ok := source.PersistentVolumeClaim(namespace, name); ok {
+			return claim, nil
+		}
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("persistent volume claim %s/%s bulunamadı: kubernetes client yok", namespace, name)
+	}
+
+	return as.k8sClient.GetClientset().CoreV1().PersistentVolumeClaims(namespace).Get(as.runContext(), name, metav1.GetOptions{})
+}
+
+// getPersistentVolume PV'yi küme kaynağından (destekliyorsa) veya Kubernetes API'den alır
+func (as *AIScheduler) getPersistentVolume(name string) (*corev1.PersistentVolume, error) {
+	if source, ok := as.source.(types.VolumeSource); ok {
+		if volume, ok := source.PersistentVolume(name); ok {
+			return volume, nil
+		}
+	}
+	if !as.hasAPI() {
+		return nil, fmt.Errorf("persistent volume %s bulunamadı: kubernetes client yok", name)
+	}
+
+	return as.k8sClient.GetClientset().CoreV1().PersistentVolumes().Get(as.runContext(), name, metav1.GetOptions{})
+}
+
+// getNode node'u küme kaynağından veya Kubernetes API'den alır
+func (as *AIScheduler) getNode(nodeName string) (*corev1.Node, error) {
+	if as.source != nil {
+		if node, ok := as.source.Node(nodeName); ok {
+			return node, nil
+		}
+		if !as.hasAPI() {
+			return nil, fmt.Errorf("node %s küme kaynağında yok", nodeName)
+		}
+	}
+
+	return as.k8sClient.GetClientset().CoreV1().Nodes().Get(as.runContext(), nodeName, metav1.GetOptions{})
+}
+
+// nodeUsage node'un CPU ve memory kullanımını küme kaynağından veya Metrics API'den alır
+func (as *AIScheduler) nodeUsage(nodeName string) (float64, float64, error) {
+	if as.source != nil {
+		cpuUsage, memUsage, ok := as.source.NodeUsage(nodeName)
+		if !ok {
+			return 0, 0, fmt.Errorf("node %s için kullanım verisi yok: %w", nodeName, types.ErrMetricsStale)
+		}
+		return cpuUsage, memUsage, nil
+	}
+
+	if as.metricsClient == nil {
+		return 0, 0, fmt.Errorf("metrics client kullanılamıyor: %w", types.ErrMetricsStale)
+	}
+	cpuUsage, memUsage, err := as.metricsClient.GetNodeMetrics(as.runContext(), nodeName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Metrics API: %v: %w", err, types.ErrMetricsStale)
+	}
+	return cpuUsage, memUsage, nil
+}
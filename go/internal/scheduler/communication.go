@@ -0,0 +1,215 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AnnotationCommunicatesWith pod'un yoğun konuştuğu Service'ler: virgülle ayrılmış "svc" (pod'un namespace'i) veya "namespace/svc".
+// communication.discover_from_services açıksa Service'e konan annotation o Service'in pod'larına da uygulanır
+const AnnotationCommunicatesWith = "ai-scheduler/communicates-with"
+
+// İletişim farkındalıklı yerleşimin varsayılanları
+const (
+	defaultCommunicationMaxLatency = 5.0
+	maxCommunicationServices       = 10
+)
+
+// communicationPeers pod'un eşlerinin bulunduğu node'lar ve node başına eş pod sayısı
+type communicationPeers struct {
+	nodes map[string]int
+	pods  int
+}
+
+// indexedService seçicisi ve iletişim annotation'ı çözülmüş Service
+type indexedService struct {
+	namespace string
+	selector  labels.Selector
+	refs      map[string]bool
+}
+
+// selects Service'in pod'u seçip seçmediğini döndürür
+func (s *indexedService) selects(pod *corev1.Pod) bool {
+	return s.namespace == pod.Namespace && s.selector.Matches(labels.Set(pod.Labels))
+}
+
+// serviceIndex seçicisi olan Service'ler "namespace/ad" anahtarıyla
+type serviceIndex map[string]*indexedService
+
+// newServiceIndex seçicisiz Service'leri (pod seçmedikleri için) atlayarak indeks kurar
+func newServiceIndex(services []*corev1.Service) serviceIndex {
+	index := make(serviceIndex, len(services))
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		index[svc.Namespace+"/"+svc.Name] = &indexedService{
+			namespace: svc.Namespace,
+			selector:  labels.SelectorFromSet(svc.Spec.Selector),
+			refs:      parseServiceRefs(svc.Namespace, svc.Annotations[AnnotationCommunicatesWith]),
+		}
+	}
+	return index
+}
+
+// selecting pod'u seçen Service'lerin anahtarlarını döndürür
+func (index serviceIndex) selecting(pod *corev1.Pod) map[string]bool {
+	keys := make(map[string]bool)
+	for key, svc := range index {
+		if svc.selects(pod) {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// declared pod'un annotation'ında, inherit açıksa pod'u seçen Service'lerin annotation'ında adı geçen Service'ler
+func (index serviceIndex) declared(pod *corev1.Pod, inherit bool) map[string]bool {
+	refs := parseServiceRefs(pod.Namespace, pod.Annotations[AnnotationCommunicatesWith])
+	if !inherit {
+		return refs
+	}
+	for _, svc := range index {
+		if len(svc.refs) > 0 && svc.selects(pod) {
+			for key := range svc.refs {
+				refs[key] = true
+			}
+		}
+	}
+	return refs
+}
+
+// selectedByAny pod'un verilen Service'lerden biri tarafından seçilip seçilmediğini döndürür
+func (index serviceIndex) selectedByAny(pod *corev1.Pod, keys map[string]bool) bool {
+	for key := range keys {
+		if svc, ok := index[key]; ok && svc.selects(pod) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseServiceRefs annotation değerini "namespace/ad" anahtarlarına çevirir, en fazla maxCommunicationServices Service okunur
+func parseServiceRefs(namespace, value string) map[string]bool {
+	refs := make(map[string]bool)
+	for _, ref := range strings.Split(value, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if len(refs) == maxCommunicationServices {
+			break
+		}
+		if !strings.Contains(ref, "/") {
+			ref = namespace + "/" + ref
+		}
+		refs[ref] = true
+	}
+	return refs
+}
+
+// communicationSettings varsayılanları uygulanmış iletişim farkındalıklı yerleşim konfigürasyonunu döndürür
+func (as *AIScheduler) communicationSettings() types.CommunicationConfig {
+	cfg := as.currentConfig().Communication
+	if cfg.MaxLatency <= 0 {
+		cfg.MaxLatency = defaultCommunicationMaxLatency
+	}
+	return cfg
+}
+
+// peersFor pod'un konuştuğu pod'ların node'larını döndürür. Pod'un annotation'ındaki Service'lerin pod'ları eştir;
+// keşif açıksa Service annotation'ları da miras alınır ve pod'un Service'lerini bildiren pod'lar da eş sayılır.
+// Özellik kapalıysa, gecikme matrisi yoksa veya eş bulunamazsa nil döner
+func (as *AIScheduler) peersFor(pod *corev1.Pod) *communicationPeers {
+	cfg := as.communicationSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 || pod == nil || as.collector.GetNodeLatency() == nil {
+		return nil
+	}
+	if pod.Annotations[AnnotationCommunicatesWith] == "" && !cfg.DiscoverFromServices {
+		return nil
+	}
+
+	services, err := as.listServices()
+	if err != nil {
+		logrus.Debugf("%s/%s için Service listesi alınamadı, iletişim cezası uygulanmayacak: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	index := newServiceIndex(services)
+
+	targets := index.declared(pod, cfg.DiscoverFromServices)
+	var own map[string]bool
+	if cfg.DiscoverFromServices {
+		own = index.selecting(pod)
+	}
+	if len(targets) == 0 && len(own) == 0 {
+		return nil
+	}
+
+	pods, err := as.listPods()
+	if err != nil {
+		logrus.Debugf("%s/%s için pod listesi alınamadı, iletişim cezası uygulanmayacak: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+
+	peers := &communicationPeers{nodes: make(map[string]int)}
+	for _, other := range pods {
+		if other.Spec.NodeName == "" || (other.Namespace == pod.Namespace && other.Name == pod.Name) ||
+			other.Status.Phase == corev1.PodSucceeded || other.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if !index.selectedByAny(other, targets) && !declaresAny(index.declared(other, cfg.DiscoverFromServices), own) {
+			continue
+		}
+		peers.nodes[other.Spec.NodeName]++
+		peers.pods++
+	}
+	if peers.pods == 0 {
+		return nil
+	}
+	return peers
+}
+
+// declaresAny pod'un bildirdiği Service'lerden birinin verilen Service'lerde olup olmadığını döndürür
+func declaresAny(declared, keys map[string]bool) bool {
+	for key := range declared {
+		if keys[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// communicationPenalty eşlerine ortalama gecikmesi yüksek node'a ceza döndürür. Ortalama eş pod sayısıyla ağırlıklıdır,
+// eşle aynı node'da gecikme 0 sayılır, ölçümü olmayan node çiftleri hesaba katılmaz
+func (as *AIScheduler) communicationPenalty(peers *communicationPeers, node *corev1.Node) (float64, string) {
+	if peers == nil {
+		return 0, ""
+	}
+	cfg := as.communicationSettings()
+	matrix := as.collector.GetNodeLatency()
+	now := as.now()
+
+	total, measured := 0.0, 0
+	for peerNode, count := range peers.nodes {
+		latency, ok := matrix.Latency(node.Name, peerNode, now)
+		if !ok {
+			continue
+		}
+		total += latency * float64(count)
+		measured += count
+	}
+	if measured == 0 || total <= 0 {
+		return 0, ""
+	}
+
+	mean := total / float64(measured)
+	penalty := cfg.Weight * math.Min(1, mean/cfg.MaxLatency)
+	return penalty, fmt.Sprintf("İletişim gecikmesi cezası: %.1f (%d/%d eş pod, ortalama: %.2fms)", penalty, measured, peers.pods, mean)
+}
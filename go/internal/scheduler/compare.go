@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxCompareNodes tek karşılaştırmada izin verilen node sayısı
+const maxCompareNodes = 20
+
+// Skor bileşenleri
+const (
+	ScoreComponentCPU         = "cpu"
+	ScoreComponentMemory      = "memory"
+	ScoreComponentForecast    = "forecast"
+	ScoreComponentNodeReady   = "node_ready"
+	ScoreComponentFlap        = "flap"
+	ScoreComponentNetwork     = "network_health"
+	ScoreComponentWarmUp      = "warm_up"
+	ScoreComponentCooldown    = "cooldown"
+	ScoreComponentSmoothing   = "smoothing"
+	ScoreComponentTaint       = "taint"
+	ScoreComponentStability   = "stability"
+	ScoreComponentFailureRate = "failure_rate"
+	ScoreComponentRestarts    = "restarts"
+	ScoreComponentLifetime    = "lifetime"
+)
+
+var (
+	// ErrCompareNodes karşılaştırılacak node listesi boş veya çok uzun
+	ErrCompareNodes = errors.New("karşılaştırma için 1-20 node gerekli")
+	// ErrCompareTime karşılaştırma zamanı gelecekte
+	ErrCompareTime = errors.New("karşılaştırma zamanı gelecekte olamaz")
+)
+
+// ScoreComponent skorun tek bir bileşeninin katkısı
+type ScoreComponent struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// ScoreBreakdown node skorunun bileşenlerine ayrılmış hali ve skorlamanın girdileri
+type ScoreBreakdown struct {
+	NodeName        string              `json:"node_name"`
+	Score           float64             `json:"score"`
+	RawScore        *float64            `json:"raw_score,omitempty"` // Skor yumuşatması açıksa yumuşatılmamış skor
+	Reason          string              `json:"reason,omitempty"`
+	CPUUsage        float64             `json:"cpu_usage"`
+	MemoryUsage     float64             `json:"memory_usage_gb"`
+	UsageSampledAt  *time.Time          `json:"usage_sampled_at,omitempty"` // Geçmiş skorlarda kullanımın alındığı dilim
+	ForecastPeak    float64             `json:"forecast_peak_utilization"`
+	FlapFigure      float64             `json:"flap_figure"` // Sönümlenmiş NotReady↔Ready geçiş sayısı
+	Readiness       types.NodeReadiness `json:"readiness"`   // Son 1 saat, 24 saat ve 7 gündeki Ready oranları
+	StabilityScore  float64             `json:"stability_score"`
+	FailureRate     float64             `json:"failure_rate"`
+	AverageRestarts float64             `json:"average_restarts"`
+	AverageLifetime string              `json:"average_lifetime"`
+	Components      []ScoreComponent    `json:"components"`
+	Error           string              `json:"error,omitempty"`
+}
+
+// add bileşeni ekler, breakdown nil ise (normal skorlama) hiçbir şey yapmaz
+func (b *ScoreBreakdown) add(name string, score float64) {
+	if b == nil {
+		return
+	}
+	b.Components = append(b.Components, ScoreComponent{Name: name, Score: score})
+}
+
+// NodeComparison node skorlarının yan yana karşılaştırması
+type NodeComparison struct {
+	At         time.Time        `json:"at"`
+	Historical bool             `json:"historical"`
+	Nodes      []ScoreBreakdown `json:"nodes"` // Yüksek skor önce
+	Notes      []string         `json:"notes,omitempty"`
+}
+
+// historicalNotes geçmiş skorların yaklaşık olduğu noktalar
+var historicalNotes = []string{
+	"Node hazır/taint durumu ve skor ağırlıkları güncel değerlerdir, geçmişte saklanmaz",
+	"CPU/memory kullanımı at anını içeren (yoksa önceki son) geçmiş diliminin ortalamasıdır",
+	"Pod analizi ve tahmin saklama süresindeki verilerle at anına kadar hesaplanır, daha eski veriler eksik olabilir",
+}
+
+// CompareNodes node skorlarını bileşenleriyle karşılaştırır. at sıfırsa güncel skorlar, değilse
+// saklanan kullanım ve pod geçmişinden at anındaki skorlar yeniden hesaplanır. Node bazındaki hatalar sonuçta döner
+func (as *AIScheduler) CompareNodes(nodeNames []string, at time.Time) (*NodeComparison, error) {
+	if len(nodeNames) == 0 || len(nodeNames) > maxCompareNodes {
+		return nil, ErrCompareNodes
+	}
+
+	now := as.now()
+	if at.After(now) {
+		return nil, fmt.Errorf("%s: %w", at.Format(time.RFC3339), ErrCompareTime)
+	}
+
+	comparison := &NodeComparison{
+		At:         at,
+		Historical: !at.IsZero(),
+		Nodes:      make([]ScoreBreakdown, 0, len(nodeNames)),
+	}
+	if !comparison.Historical {
+		comparison.At = now
+	} else {
+		comparison.Notes = historicalNotes
+	}
+
+	for _, name := range nodeNames {
+		breakdown := ScoreBreakdown{NodeName: name}
+
+		node, err := as.getNode(name)
+		if err != nil {
+			breakdown.Error = fmt.Sprintf("%s: %v", types.ErrNodeNotFound, err)
+			comparison.Nodes = append(comparison.Nodes, breakdown)
+			continue
+		}
+
+		var inputs scoreInputs
+		if comparison.Historical {
+			inputs, err = as.historicalScoreInputs(node, at, &breakdown)
+		} else {
+			inputs, err = as.currentScoreInputs(node)
+		}
+		if err != nil {
+			breakdown.Error = err.Error()
+		}
+
+		reasons := getReasonBuilder()
+		breakdown.Score = as.scoreNode(node, &inputs, reasons, &breakdown)
+		if !comparison.Historical {
+			if smoothed, ok := as.smoothScore(name, breakdown.Score, false); ok {
+				raw := breakdown.Score
+				breakdown.RawScore = &raw
+				breakdown.add(ScoreComponentSmoothing, smoothed-raw)
+				reasons.item().text("Yumuşatılmış skor: ").float(smoothed, 2).text(" (ham: ").float(raw, 2).text(")")
+				breakdown.Score = smoothed
+			}
+		}
+		breakdown.Reason = reasons.total(breakdown.Score)
+		reasons.release()
+
+		breakdown.CPUUsage = inputs.cpuUsage
+		breakdown.MemoryUsage = inputs.memUsage
+		breakdown.ForecastPeak = inputs.peak
+		breakdown.FlapFigure = inputs.flapFigure
+		breakdown.Readiness = inputs.readiness
+		breakdown.StabilityScore = inputs.analysis.StabilityScore
+		breakdown.FailureRate = inputs.analysis.FailureRate
+		breakdown.AverageRestarts = inputs.analysis.AverageRestartCount
+		breakdown.AverageLifetime = inputs.analysis.AverageLifetime.String()
+		comparison.Nodes = append(comparison.Nodes, breakdown)
+	}
+
+	sort.SliceStable(comparison.Nodes, func(i, j int) bool {
+		if (comparison.Nodes[i].Error == "") != (comparison.Nodes[j].Error == "") {
+			return comparison.Nodes[i].Error == ""
+		}
+		return comparison.Nodes[i].Score > comparison.Nodes[j].Score
+	})
+	return comparison, nil
+}
+
+// currentScoreInputs calculateNodeScore ile aynı güncel girdileri toplar, kullanım hatası sonuçta gösterilir
+func (as *AIScheduler) currentScoreInputs(node *corev1.Node) (scoreInputs, error) {
+	inputs := scoreInputs{analysis: as.nodeAnalysis(node.Name)}
+	inputs.penalty, inputs.peak = as.forecastPenalty(node)
+	inputs.flapPenalty, inputs.flapFigure = as.flapPenaltyAt(node.Name, as.now())
+	inputs.readiness, inputs.readinessKnown = as.readinessAt(node.Name, nodeReady(node), as.now())
+	inputs.networkPenalty, inputs.networkHealth = as.networkPenalty(node.Name)
+	inputs.warmUpPenalty, inputs.warmth = as.warmUpPenaltyAt(node, &inputs.analysis, as.now())
+	inputs.cooldownPenalty = as.cooldownPenalty(node.Name)
+
+	var err error
+	if hasCapacity(node) {
+		inputs.cpuUsage, inputs.memUsage, err = as.nodeUsage(node.Name)
+		if err != nil {
+			inputs.cpuUsage, inputs.memUsage = 0, 0
+			err = fmt.Errorf("kullanım alınamadı, 0 kabul edildi: %v", err)
+		}
+	}
+	return inputs, err
+}
+
+// historicalScoreInputs at anındaki girdileri kullanım geçmişi, pod metrik cache'i ve at'te kesilmiş tahminden yeniden kurar
+func (as *AIScheduler) historicalScoreInputs(node *corev1.Node, at time.Time, breakdown *ScoreBreakdown) (scoreInputs, error) {
+	inputs := scoreInputs{analysis: as.nodeAnalysisAt(node.Name, at)}
+	inputs.penalty, inputs.peak = as.forecastPenaltyAt(node, at)
+	inputs.flapPenalty, inputs.flapFigure = as.flapPenaltyAt(node.Name, at)
+	inputs.readiness, inputs.readinessKnown = as.readinessAt(node.Name, nodeReady(node), at)
+	inputs.warmUpPenalty, inputs.warmth = as.warmUpPenaltyAt(node, &inputs.analysis, at)
+
+	history := as.collector.GetNodeHistory()
+	if history == nil {
+		return inputs, fmt.Errorf("kullanım geçmişi tutulmuyor, kullanım 0 kabul edildi")
+	}
+
+	sample, ok := usageAt(history.Samples(node.Name, time.Time{}), at)
+	if !ok {
+		return inputs, fmt.Errorf("%s öncesine ait kullanım geçmişi yok, kullanım 0 kabul edildi", at.Format(time.RFC3339))
+	}
+	inputs.cpuUsage, inputs.memUsage = sample.CPU, sample.Memory
+	sampledAt := sample.Timestamp
+	breakdown.UsageSampledAt = &sampledAt
+	return inputs, nil
+}
+
+// usageAt at anını içeren ya da ondan önceki son kullanım dilimini döndürür (örnekler zamana göre sıralıdır)
+func usageAt(samples []types.NodeUsageSample, at time.Time) (types.NodeUsageSample, bool) {
+	i := sort.Search(len(samples), func(i int) bool { return samples[i].Timestamp.After(at) })
+	if i == 0 {
+		return types.NodeUsageSample{}, false
+	}
+	return samples[i-1], true
+}
@@ -0,0 +1,458 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Varsayılan scheduler karşılaştırmasının varsayılanları
+const (
+	defaultComparisonScheduler  = "default-scheduler"
+	defaultComparisonMinSamples = 100
+	comparisonQueueSize         = 1024
+	comparisonBucketSize        = time.Hour
+	comparisonMargin            = 0.05 // Başarısızlık oranları arasında anlamlı sayılan fark
+)
+
+// Karşılaştırma raporunun kararları
+const (
+	VerdictInsufficientData = "insufficient_data"         // Yeterli ayrışan karşılaştırma yok
+	VerdictAIFavored        = "ai_favored"                // AI'nın katılmadığı yerleşimler daha sık sorun çıkarıyor
+	VerdictDefaultFavored   = "default_favored"           // AI'nın katılmadığı yerleşimler daha başarılı
+	VerdictNoDifference     = "no_significant_difference" // Sonuçlar arasında anlamlı fark yok
+)
+
+// ComparisonRecord diğer scheduler'ın yerleştirdiği pod için AI'nın seçimi ve pod'un sonucu
+type ComparisonRecord struct {
+	ComparedAt time.Time         `json:"compared_at"`
+	ObservedAt time.Time         `json:"observed_at"`
+	Namespace  string            `json:"namespace"`
+	Pod        string            `json:"pod"`
+	Workload   types.WorkloadRef `json:"workload"`
+	Scheduler  string            `json:"scheduler"`
+	// DefaultNode diğer scheduler'ın bağladığı node, AINode AI'nın seçeceği node (uygun node yoksa boş)
+	DefaultNode string  `json:"default_node"`
+	AINode      string  `json:"ai_node,omitempty"`
+	AIScore     float64 `json:"ai_score"`
+	// DefaultScore AI skorlamasına göre bağlanan node'un skoru, DefaultFeasible false ise AI filtresi bu node'u eler
+	DefaultScore    float64 `json:"default_score"`
+	DefaultFeasible bool    `json:"default_feasible"`
+	Diverged        bool    `json:"diverged"`
+	Result          string  `json:"result"`
+	// Sonuç anında iki node'un kararlılık skorları
+	DefaultStability float64 `json:"default_stability"`
+	AIStability      float64 `json:"ai_stability"`
+}
+
+// ComparisonBucket karşılaştırmaların saatlik özeti
+type ComparisonBucket struct {
+	Start       time.Time `json:"start"`
+	Compared    int       `json:"compared"`
+	Diverged    int       `json:"diverged"`
+	DivergedBad int       `json:"diverged_bad"` // Ayrışan ve başarısız/yeniden başlamış/başlamamış sonuçlar
+	AgreedBad   int       `json:"agreed_bad"`
+}
+
+// NamespaceComparison namespace bazında karşılaştırma sayıları
+type NamespaceComparison struct {
+	Compared int `json:"compared"`
+	Diverged int `json:"diverged"`
+}
+
+// ComparisonReport varsayılan scheduler ile AI seçimlerinin ayrışması ve karşılaştırmalı sonuçları
+type ComparisonReport struct {
+	Enabled           bool    `json:"enabled"`
+	Pending           int     `json:"pending"`
+	Compared          int     `json:"compared"`
+	Agreed            int     `json:"agreed"`
+	Diverged          int     `json:"diverged"`
+	DivergenceRate    float64 `json:"divergence_rate"`
+	DefaultInfeasible int     `json:"default_infeasible"` // AI filtresinin eleyeceği node'a bağlananlar
+	// MeanScoreGap ayrışan kararlarda AI'nın seçtiği ile bağlanan node arasındaki ortalama skor farkı
+	MeanScoreGap float64 `json:"mean_score_gap"`
+	// AgreedFailureRate ve DivergedFailureRate sonucu kötü (failed, restarted, not_started) olanların oranı
+	AgreedFailureRate   float64 `json:"agreed_failure_rate"`
+	DivergedFailureRate float64 `json:"diverged_failure_rate"`
+	// StabilityAdvantage ayrışan kararlarda sonuç anında AI node'unun kararlılığının bağlanan node'dan ortalama farkı
+	StabilityAdvantage float64                         `json:"stability_advantage"`
+	Results            map[string]int                  `json:"results"`
+	Namespaces         map[string]*NamespaceComparison `json:"namespaces,omitempty"`
+	Buckets            []ComparisonBucket              `json:"buckets"`
+	Verdict            string                          `json:"verdict"`
+}
+
+// pendingComparison sonucu henüz belli olmayan karşılaştırma
+type pendingComparison struct {
+	record      ComparisonRecord
+	compared    bool // AI seçimi hesaplandı
+	restartBase int32
+	running     bool
+}
+
+// comparisonTracker izlenen karşılaştırmaları ve sonuçlanan kayıtları tutar.
+// Pod olayları kuyruğa alınır, AI seçimi olay işleyicisini bekletmemek için arka planda hesaplanır
+type comparisonTracker struct {
+	init    sync.Once
+	queue   chan *corev1.Pod
+	mutex   sync.Mutex
+	pending map[podKey]*pendingComparison
+	done    map[podKey]time.Time // Sonuçlanan pod'lar, sonraki olaylarda yeniden karşılaştırılmaz
+	records []ComparisonRecord
+}
+
+// channel karşılaştırma kuyruğunu döndürür
+func (t *comparisonTracker) channel() chan *corev1.Pod {
+	t.init.Do(func() { t.queue = make(chan *corev1.Pod, comparisonQueueSize) })
+	return t.queue
+}
+
+// comparisonSettings varsayılanları uygulanmış karşılaştırma ayarları
+func (as *AIScheduler) comparisonSettings() types.ComparisonConfig {
+	cfg := as.currentConfig().Comparison
+	if len(cfg.SchedulerNames) == 0 {
+		cfg.SchedulerNames = []string{defaultComparisonScheduler}
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultOutcomeWindow
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = defaultOutcomeMaxPending
+	}
+	if cfg.HistorySize <= 0 {
+		cfg.HistorySize = defaultOutcomeHistorySize
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultComparisonMinSamples
+	}
+	return cfg
+}
+
+// comparisonCandidate pod karşılaştırılacak bir scheduler tarafından pencere içinde bağlandıysa true döner.
+// DaemonSet pod'larının node'u sabit olduğu için karşılaştırılmaz
+func comparisonCandidate(pod *corev1.Pod, cfg *types.ComparisonConfig, now time.Time) bool {
+	if pod.Spec.NodeName == "" || types.WorkloadOf(pod).Kind == "DaemonSet" {
+		return false
+	}
+	schedulerName := pod.Spec.SchedulerName
+	if schedulerName == "" {
+		schedulerName = defaultComparisonScheduler
+	}
+	if !containsString(cfg.SchedulerNames, schedulerName) {
+		return false
+	}
+
+	// Başlangıçta veya resync'te eski pod'lar karşılaştırılmaz
+	scheduledAt := pod.CreationTimestamp.Time
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+			scheduledAt = condition.LastTransitionTime.Time
+		}
+	}
+	return now.Sub(scheduledAt) < cfg.Window
+}
+
+// observeComparison pod olayını karşılaştırmalarla eşleştirir: yeni bağlanan pod kuyruğa alınır,
+// izlenen pod'un sonucu belli olduysa kayıt etiketlenir
+func (as *AIScheduler) observeComparison(pod *corev1.Pod, deleted bool) {
+	cfg := as.comparisonSettings()
+	if !cfg.Enabled {
+		return
+	}
+
+	t := &as.comparison
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := podKey{namespace: pod.Namespace, name: pod.Name}
+	pending, ok := t.pending[key]
+	if !ok {
+		if _, finished := t.done[key]; finished || deleted || len(t.pending) >= cfg.MaxPending || !comparisonCandidate(pod, &cfg, as.now()) {
+			return
+		}
+		select {
+		case t.channel() <- pod:
+		default:
+			logrus.Debugf("Karşılaştırma kuyruğu dolu, %s/%s karşılaştırılmıyor", pod.Namespace, pod.Name)
+			return
+		}
+		if t.pending == nil {
+			t.pending = make(map[podKey]*pendingComparison)
+		}
+		pending = &pendingComparison{record: ComparisonRecord{
+			ComparedAt:  as.now(),
+			Namespace:   pod.Namespace,
+			Pod:         pod.Name,
+			Workload:    types.WorkloadOf(pod),
+			Scheduler:   pod.Spec.SchedulerName,
+			DefaultNode: pod.Spec.NodeName,
+		}}
+		pending.restartBase = podRestarts(pod)
+		t.pending[key] = pending
+	}
+
+	switch {
+	case deleted:
+		as.finishComparisonLocked(key, pending, ResultDeleted)
+	case pod.Status.Phase == corev1.PodFailed:
+		as.finishComparisonLocked(key, pending, ResultFailed)
+	case podRestarts(pod) > pending.restartBase:
+		as.finishComparisonLocked(key, pending, ResultRestarted)
+	default:
+		pending.running = pod.Status.Phase == corev1.PodRunning
+	}
+}
+
+// comparePlacement bağlanmış pod için AI'nın seçeceği node'u binding ve assume yapmadan hesaplar
+func (as *AIScheduler) comparePlacement(pod *corev1.Pod) {
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		logrus.Debugf("%s/%s karşılaştırılamadı: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+
+	// Pod bağlandığı node'un toplamında sayılı, ownNode ile tekrar sayılmaz
+	request, feasible, _ := as.filterPod(pod, snapshot)
+	candidates := as.scoreCandidates(pod, snapshot, &request, feasible)
+
+	var best *NodeScore
+	var defaultScore *NodeScore
+	for i := range candidates {
+		if best == nil || candidates[i].Score > best.Score {
+			best = &candidates[i]
+		}
+		if candidates[i].NodeName == pod.Spec.NodeName {
+			defaultScore = &candidates[i]
+		}
+	}
+
+	t := &as.comparison
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// Kuyrukta beklerken silinmiş veya sonuçlanmış olabilir
+	pending, ok := t.pending[podKey{namespace: pod.Namespace, name: pod.Name}]
+	if !ok {
+		return
+	}
+	pending.compared = true
+	if best != nil {
+		pending.record.AINode = best.NodeName
+		pending.record.AIScore = best.Score
+	}
+	if defaultScore != nil {
+		pending.record.DefaultFeasible = true
+		pending.record.DefaultScore = defaultScore.Score
+	}
+	pending.record.Diverged = pending.record.AINode != pending.record.DefaultNode
+}
+
+// finishComparisonLocked karşılaştırmayı izlemeden çıkarıp sonuçlanan kayıt olarak saklar, kilit tutulurken çağrılır.
+// AI seçimi henüz hesaplanmadıysa kayıt atılır
+func (as *AIScheduler) finishComparisonLocked(key podKey, pending *pendingComparison, result string) {
+	t := &as.comparison
+	delete(t.pending, key)
+	if t.done == nil {
+		t.done = make(map[podKey]time.Time)
+	}
+	t.done[key] = as.now()
+	if !pending.compared {
+		return
+	}
+
+	record := pending.record
+	record.Result = result
+	record.ObservedAt = as.now()
+	record.DefaultStability = as.nodeAnalysis(record.DefaultNode).StabilityScore
+	if record.AINode != "" {
+		record.AIStability = as.nodeAnalysis(record.AINode).StabilityScore
+	}
+	t.records = append(t.records, record)
+	if size := as.comparisonSettings().HistorySize; len(t.records) > size {
+		t.records = append(t.records[:0:0], t.records[len(t.records)-size:]...)
+	}
+}
+
+// sweepComparisons penceresi dolan karşılaştırmaları son görülen duruma göre etiketler.
+// Karşılaştırma kapatıldıysa izlenenler bırakılır
+func (as *AIScheduler) sweepComparisons(now time.Time) {
+	cfg := as.comparisonSettings()
+
+	t := &as.comparison
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !cfg.Enabled {
+		t.pending = nil
+		t.done = nil
+		return
+	}
+	for key, pending := range t.pending {
+		if now.Sub(pending.record.ComparedAt) < cfg.Window {
+			continue
+		}
+		if pending.running {
+			as.finishComparisonLocked(key, pending, ResultSucceeded)
+		} else {
+			as.finishComparisonLocked(key, pending, ResultNotStarted)
+		}
+	}
+	// Pencereden eski pod olayları comparisonCandidate tarafından zaten elenir
+	for key, finished := range t.done {
+		if now.Sub(finished) >= cfg.Window {
+			delete(t.done, key)
+		}
+	}
+}
+
+// comparisonLoop kuyruğa alınan pod'lar için AI seçimini hesaplar ve penceresi dolanları etiketler
+func (as *AIScheduler) comparisonLoop(ctx context.Context) {
+	ticker := time.NewTicker(outcomeSweepInterval)
+	defer ticker.Stop()
+
+	queue := as.comparison.channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case pod := <-queue:
+			as.comparePlacement(pod)
+		case <-ticker.C:
+			as.sweepComparisons(as.now())
+		}
+	}
+}
+
+// ComparisonRecords en yeniden eskiye en fazla limit sonuçlanan karşılaştırmayı döndürür, limit 0 ise tümü
+func (as *AIScheduler) ComparisonRecords(limit int) []ComparisonRecord {
+	t := &as.comparison
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	count := len(t.records)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+	result := make([]ComparisonRecord, 0, count)
+	for i := len(t.records) - 1; i >= len(t.records)-count; i-- {
+		result = append(result, t.records[i])
+	}
+	return result
+}
+
+// badResult sonuç pod'un yerleştiği node'da sorun yaşadığını gösteriyorsa true döner
+func badResult(result string) bool {
+	return result == ResultFailed || result == ResultRestarted || result == ResultNotStarted
+}
+
+// ComparisonReport sonuçlanan karşılaştırmalardan ayrışma oranını, ayrışan ve uyuşan kararların sonuçlarını
+// ve AI'ya geçişi destekleyip desteklemediğini hesaplar
+func (as *AIScheduler) ComparisonReport() ComparisonReport {
+	cfg := as.comparisonSettings()
+	report := ComparisonReport{Enabled: cfg.Enabled, Results: make(map[string]int), Buckets: []ComparisonBucket{}}
+
+	t := &as.comparison
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	report.Pending = len(t.pending)
+	report.Compared = len(t.records)
+
+	var scoreGap, stabilityGap float64
+	var gapSamples, agreedLabeled, agreedBad, divergedLabeled, divergedBad int
+	buckets := make(map[time.Time]*ComparisonBucket)
+	for i := range t.records {
+		record := &t.records[i]
+		report.Results[record.Result]++
+		if !record.DefaultFeasible {
+			report.DefaultInfeasible++
+		}
+
+		if report.Namespaces == nil {
+			report.Namespaces = make(map[string]*NamespaceComparison)
+		}
+		namespace := report.Namespaces[record.Namespace]
+		if namespace == nil {
+			namespace = &NamespaceComparison{}
+			report.Namespaces[record.Namespace] = namespace
+		}
+		namespace.Compared++
+
+		start := record.ComparedAt.Truncate(comparisonBucketSize)
+		bucket := buckets[start]
+		if bucket == nil {
+			bucket = &ComparisonBucket{Start: start}
+			buckets[start] = bucket
+		}
+		bucket.Compared++
+
+		// Silinen pod'lar sonuç oranlarına katılmaz
+		labeled := record.Result != ResultDeleted
+		bad := badResult(record.Result)
+		if !record.Diverged {
+			report.Agreed++
+			if labeled {
+				agreedLabeled++
+			}
+			if bad {
+				agreedBad++
+				bucket.AgreedBad++
+			}
+			continue
+		}
+
+		report.Diverged++
+		namespace.Diverged++
+		bucket.Diverged++
+		if labeled {
+			divergedLabeled++
+		}
+		if bad {
+			divergedBad++
+			bucket.DivergedBad++
+		}
+		if record.AINode != "" && record.DefaultFeasible {
+			scoreGap += record.AIScore - record.DefaultScore
+			stabilityGap += record.AIStability - record.DefaultStability
+			gapSamples++
+		}
+	}
+
+	if report.Compared > 0 {
+		report.DivergenceRate = float64(report.Diverged) / float64(report.Compared)
+	}
+	if gapSamples > 0 {
+		report.MeanScoreGap = scoreGap / float64(gapSamples)
+		report.StabilityAdvantage = stabilityGap / float64(gapSamples)
+	}
+	if agreedLabeled > 0 {
+		report.AgreedFailureRate = float64(agreedBad) / float64(agreedLabeled)
+	}
+	if divergedLabeled > 0 {
+		report.DivergedFailureRate = float64(divergedBad) / float64(divergedLabeled)
+	}
+
+	for _, bucket := range buckets {
+		report.Buckets = append(report.Buckets, *bucket)
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool { return report.Buckets[i].Start.Before(report.Buckets[j].Start) })
+
+	// Ayrışan yerleşimler uyuşanlardan daha sık sorun çıkarıyorsa AI'nın seçimi tercih edilir
+	switch difference := report.DivergedFailureRate - report.AgreedFailureRate; {
+	case divergedLabeled < cfg.MinSamples:
+		report.Verdict = VerdictInsufficientData
+	case difference > comparisonMargin:
+		report.Verdict = VerdictAIFavored
+	case difference < -comparisonMargin:
+		report.Verdict = VerdictDefaultFavored
+	default:
+		report.Verdict = VerdictNoDifference
+	}
+	return report
+}
@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Node cooldown'unun varsayılanları
+const (
+	defaultCooldownFailures = 3
+	defaultCooldownWindow   = 15 * time.Minute
+	defaultCooldownDuration = 10 * time.Minute
+	defaultCooldownPenalty  = 50.0
+)
+
+// Cooldown modları
+const (
+	CooldownExclude  = "exclude"  // Node filtrede elenir
+	CooldownPenalize = "penalize" // Node'un skorundan ceza düşülür
+)
+
+// ErrNoCooldown node cooldown'da değil
+var ErrNoCooldown = errors.New("node cooldown'da değil")
+
+// NodeCooldown yerleşen pod'ları art arda kısa sürede başarısız olduğu için geçici olarak kaçınılan node
+type NodeCooldown struct {
+	Node  string    `json:"node"`
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Failures ve Pods cooldown'u başlatan, pencere içinde başarısız olan pod'lar
+	Failures int      `json:"failures"`
+	Pods     []string `json:"pods"`
+}
+
+// nodeFailure node'a yerleşip kısa sürede başarısız olan pod
+type nodeFailure struct {
+	at  time.Time
+	pod string
+}
+
+// cooldownTracker node başına son başarısızlıkları ve etkin cooldown'ları tutar
+type cooldownTracker struct {
+	mutex    sync.Mutex
+	failures map[string][]nodeFailure
+	nodes    map[string]*NodeCooldown
+}
+
+// cooldownSettings varsayılanları uygulanmış cooldown ayarları
+func (as *AIScheduler) cooldownSettings() types.CooldownConfig {
+	cfg := as.currentConfig().Cooldown
+	if cfg.Failures <= 0 {
+		cfg.Failures = defaultCooldownFailures
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultCooldownWindow
+	}
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultCooldownDuration
+	}
+	if cfg.Mode != CooldownPenalize {
+		cfg.Mode = CooldownExclude
+	}
+	if cfg.Penalty <= 0 {
+		cfg.Penalty = defaultCooldownPenalty
+	}
+	return cfg
+}
+
+// recordNodeFailure tahmin edilen node'a yerleşip sonuç penceresinde başarısız olan (Failed veya restart) pod'u
+// sayar. Window içinde Failures başarısızlığa ulaşan node Duration boyunca cooldown'a alınır
+func (as *AIScheduler) recordNodeFailure(nodeName, pod string, at time.Time) {
+	cfg := as.cooldownSettings()
+	if !cfg.Enabled {
+		return
+	}
+
+	t := &as.cooldowns
+	t.mutex.Lock()
+	if t.failures == nil {
+		t.failures = make(map[string][]nodeFailure)
+		t.nodes = make(map[string]*NodeCooldown)
+	}
+	failures := t.failures[nodeName]
+	cutoff := at.Add(-cfg.Window)
+	i := 0
+	for i < len(failures) && !failures[i].at.After(cutoff) {
+		i++
+	}
+	failures = append(failures[i:], nodeFailure{at: at, pod: pod})
+
+	if cooldown, ok := t.nodes[nodeName]; (ok && at.Before(cooldown.Until)) || len(failures) < cfg.Failures {
+		t.failures[nodeName] = failures
+		t.mutex.Unlock()
+		return
+	}
+	cooldown := &NodeCooldown{Node: nodeName, Since: at, Until: at.Add(cfg.Duration), Failures: len(failures)}
+	for _, failure := range failures {
+		cooldown.Pods = append(cooldown.Pods, failure.pod)
+	}
+	t.nodes[nodeName] = cooldown
+	delete(t.failures, nodeName)
+	t.mutex.Unlock()
+
+	as.scores.invalidate(nodeName)
+	as.ranking.markDirty(nodeName)
+	logrus.Warnf("Node %s cooldown'a alındı (%s, bitiş: %s): %s içinde %d pod kısa sürede başarısız oldu",
+		nodeName, cfg.Mode, cooldown.Until.Format(time.RFC3339), cfg.Window, cooldown.Failures)
+}
+
+// active node at anında cooldown'daysa bitiş zamanını döndürür, süresi dolan cooldown silinir
+func (t *cooldownTracker) active(nodeName string, at time.Time) (time.Time, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cooldown, ok := t.nodes[nodeName]
+	if !ok {
+		return time.Time{}, false
+	}
+	if !at.Before(cooldown.Until) {
+		delete(t.nodes, nodeName)
+		return time.Time{}, false
+	}
+	return cooldown.Until, true
+}
+
+// excludedNodes exclude modunda cooldown'daki node'ları döndürür, yoksa nil
+func (as *AIScheduler) excludedNodes() map[string]bool {
+	cfg := as.cooldownSettings()
+	if !cfg.Enabled || cfg.Mode != CooldownExclude {
+		return nil
+	}
+	cooldowns := as.Cooldowns()
+	if len(cooldowns) == 0 {
+		return nil
+	}
+	excluded := make(map[string]bool, len(cooldowns))
+	for i := range cooldowns {
+		excluded[cooldowns[i].Node] = true
+	}
+	return excluded
+}
+
+// cooldownPenalty penalize modunda node cooldown'daysa skor cezasını döndürür
+func (as *AIScheduler) cooldownPenalty(nodeName string) float64 {
+	cfg := as.cooldownSettings()
+	if !cfg.Enabled || cfg.Mode != CooldownPenalize {
+		return 0
+	}
+	if _, ok := as.cooldowns.active(nodeName, as.now()); !ok {
+		return 0
+	}
+	return cfg.Penalty
+}
+
+// Cooldowns etkin cooldown'ları bitişi en yakından başlayarak döndürür, süresi dolanlar silinir
+func (as *AIScheduler) Cooldowns() []NodeCooldown {
+	now := as.now()
+
+	t := &as.cooldowns
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	result := make([]NodeCooldown, 0, len(t.nodes))
+	for nodeName, cooldown := range t.nodes {
+		if !now.Before(cooldown.Until) {
+			delete(t.nodes, nodeName)
+			continue
+		}
+		result = append(result, *cooldown)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Until.Equal(result[j].Until) {
+			return result[i].Until.Before(result[j].Until)
+		}
+		return result[i].Node < result[j].Node
+	})
+	return result
+}
+
+// ClearCooldown node'un cooldown'unu ve başarısızlık sayacını siler
+func (as *AIScheduler) ClearCooldown(nodeName string) error {
+	t := &as.cooldowns
+	t.mutex.Lock()
+	cooldown, ok := t.nodes[nodeName]
+	active := ok && as.now().Before(cooldown.Until)
+	delete(t.nodes, nodeName)
+	delete(t.failures, nodeName)
+	t.mutex.Unlock()
+
+	if !active {
+		return fmt.Errorf("%s: %w", nodeName, ErrNoCooldown)
+	}
+	as.scores.invalidate(nodeName)
+	as.ranking.markDirty(nodeName)
+	logrus.Infof("Node %s cooldown'u kaldırıldı", nodeName)
+	return nil
+}
@@ -0,0 +1,377 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CronJob patlaması öngörüsünün varsayılanları
+const (
+	defaultBurstLead        = 2 * time.Minute
+	defaultBurstWindow      = 5 * time.Minute
+	defaultBurstMinFirings  = 3
+	defaultBurstHistorySize = 10
+	defaultBurstTolerance   = 0.1
+	cronIdleRetention       = 7 * 24 * time.Hour
+	cronSweepInterval       = time.Hour
+	minScheduledMinutes     = 15778080 // 2000-01-01, CronJob Job adının son eki bundan küçükse zaman damgası değildir
+)
+
+// CronBurst CronJob'un geçmişten öğrenilen tetiklenme düzeni ve tetiklenme başına yükü
+type CronBurst struct {
+	Namespace     string        `json:"namespace"`
+	CronJob       string        `json:"cronjob"`
+	Firings       int           `json:"firings"`
+	Period        time.Duration `json:"period"`
+	Regular       bool          `json:"regular"` // Aralıklar tolerans içinde, sonraki tetiklenme tahmin edilebilir
+	LastFiring    time.Time     `json:"last_firing"`
+	NextFiring    *time.Time    `json:"next_firing,omitempty"`
+	PodsPerFiring float64       `json:"pods_per_firing"`
+	CPU           float64       `json:"cpu_per_firing"`
+	Memory        float64       `json:"memory_per_firing_gb"`
+	// Phase: "idle", "upcoming" (lead içinde, kapasite ayrılıyor) veya "firing" (patlama pod'ları yayılıyor)
+	Phase string `json:"phase"`
+}
+
+// Patlama evreleri
+const (
+	BurstIdle     = "idle"
+	BurstUpcoming = "upcoming"
+	BurstFiring   = "firing"
+)
+
+// cronFiring CronJob'un bir tetiklenmesi: Job adındaki planlanan zaman ve oluşturduğu pod'lar
+type cronFiring struct {
+	scheduled time.Time
+	pods      map[string]string // Pod adı -> node (henüz yerleşmediyse boş)
+	cpu       float64
+	memory    float64
+}
+
+// cronHistory CronJob'un son tetiklenmeleri, planlanan zamana göre sıralı
+type cronHistory struct {
+	namespace string
+	name      string
+	firings   []*cronFiring
+}
+
+// burstTracker pod olaylarından CronJob tetiklenmelerini öğrenir
+type burstTracker struct {
+	mutex    sync.Mutex
+	cronjobs map[string]*cronHistory
+	swept    time.Time
+}
+
+// cronBurstSettings varsayılanları uygulanmış CronJob patlaması konfigürasyonunu döndürür
+func (as *AIScheduler) cronBurstSettings() types.CronBurstConfig {
+	cfg := as.currentConfig().CronBursts
+	if cfg.Lead <= 0 {
+		cfg.Lead = defaultBurstLead
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultBurstWindow
+	}
+	if cfg.MinFirings < 2 {
+		cfg.MinFirings = defaultBurstMinFirings
+	}
+	if cfg.HistorySize < cfg.MinFirings {
+		cfg.HistorySize = max(defaultBurstHistorySize, cfg.MinFirings)
+	}
+	if cfg.Tolerance <= 0 || cfg.Tolerance >= 1 {
+		cfg.Tolerance = defaultBurstTolerance
+	}
+	return cfg
+}
+
+// cronJobFiring pod bir CronJob Job'una aitse CronJob adını ve Job adındaki planlanan zamanı döndürür.
+// CronJob controller'ı Job'ları "<cronjob>-<planlanan zaman, epoch dakika>" olarak adlandırır
+func cronJobFiring(pod *corev1.Pod) (string, time.Time, bool) {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil || controller.Kind != "Job" {
+		return "", time.Time{}, false
+	}
+	index := strings.LastIndexByte(controller.Name, '-')
+	if index <= 0 {
+		return "", time.Time{}, false
+	}
+	minutes, err := strconv.ParseInt(controller.Name[index+1:], 10, 64)
+	if err != nil || minutes < minScheduledMinutes {
+		return "", time.Time{}, false
+	}
+	return controller.Name[:index], time.Unix(minutes*60, 0), true
+}
+
+// observeCronBurst CronJob pod'unu tetiklenmesine ekler, yerleştiği node'u kaydeder
+func (as *AIScheduler) observeCronBurst(pod *corev1.Pod, deleted bool) {
+	if deleted {
+		return
+	}
+	as.recordCronPod(pod, pod.Spec.NodeName)
+}
+
+// recordCronPod CronJob pod'unu ve (boş değilse) node'unu tetiklenme geçmişine yazar
+func (as *AIScheduler) recordCronPod(pod *corev1.Pod, nodeName string) {
+	cfg := as.cronBurstSettings()
+	if !cfg.Enabled {
+		return
+	}
+	cronjob, scheduled, ok := cronJobFiring(pod)
+	if !ok {
+		return
+	}
+	cpu, memory := types.PodResourceRequests(pod)
+	as.bursts.record(pod.Namespace, cronjob, scheduled, pod.Name, nodeName, cpu, memory, cfg.HistorySize, as.now())
+}
+
+// record pod'u tetiklenmesine ekler, en fazla historySize tetiklenme tutulur. Saatte bir uzun süredir
+// tetiklenmeyen CronJob'lar silinir
+func (t *burstTracker) record(namespace, cronjob string, scheduled time.Time, podName, nodeName string, cpu, memory float64, historySize int, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.cronjobs == nil {
+		t.cronjobs = make(map[string]*cronHistory)
+	}
+	key := namespace + "/" + cronjob
+	history, ok := t.cronjobs[key]
+	if !ok {
+		history = &cronHistory{namespace: namespace, name: cronjob}
+		t.cronjobs[key] = history
+	}
+
+	index := sort.Search(len(history.firings), func(i int) bool { return !history.firings[i].scheduled.Before(scheduled) })
+	if index == len(history.firings) || !history.firings[index].scheduled.Equal(scheduled) {
+		// Geçmişten eski ve geçmiş doluysa tetiklenme tutulmaz
+		if index == 0 && len(history.firings) >= historySize {
+			return
+		}
+		firing := &cronFiring{scheduled: scheduled, pods: make(map[string]string)}
+		history.firings = append(history.firings, nil)
+		copy(history.firings[index+1:], history.firings[index:])
+		history.firings[index] = firing
+		if excess := len(history.firings) - historySize; excess > 0 {
+			history.firings = history.firings[excess:]
+			index -= excess
+		}
+	}
+
+	firing := history.firings[index]
+	if _, seen := firing.pods[podName]; !seen {
+		firing.cpu += cpu
+		firing.memory += memory
+		firing.pods[podName] = ""
+	}
+	if nodeName != "" {
+		firing.pods[podName] = nodeName
+	}
+
+	if now.Sub(t.swept) < cronSweepInterval {
+		return
+	}
+	t.swept = now
+	for other, history := range t.cronjobs {
+		if last := history.firings[len(history.firings)-1]; now.Sub(last.scheduled) > cronIdleRetention {
+			delete(t.cronjobs, other)
+		}
+	}
+}
+
+// learn CronJob'un tetiklenme düzenini ve tetiklenme başına ortalama yükü hesaplar. Periyot aralıkların
+// medyanıdır, tüm aralıklar medyana tolerans içinde yakınsa düzenli sayılır
+func (h *cronHistory) learn(now time.Time, cfg *types.CronBurstConfig) CronBurst {
+	last := h.firings[len(h.firings)-1]
+	burst := CronBurst{
+		Namespace:  h.namespace,
+		CronJob:    h.name,
+		Firings:    len(h.firings),
+		LastFiring: last.scheduled,
+		Phase:      BurstIdle,
+	}
+
+	// Yük, devam eden tetiklenme hariç tamamlananların ortalaması
+	completed := h.firings
+	if len(completed) > 1 && now.Sub(last.scheduled) < cfg.Window {
+		completed = completed[:len(completed)-1]
+	}
+	for _, firing := range completed {
+		burst.PodsPerFiring += float64(len(firing.pods))
+		burst.CPU += firing.cpu
+		burst.Memory += firing.memory
+	}
+	burst.PodsPerFiring /= float64(len(completed))
+	burst.CPU /= float64(len(completed))
+	burst.Memory /= float64(len(completed))
+
+	if now.Sub(last.scheduled) < cfg.Window {
+		burst.Phase = BurstFiring
+	}
+	if len(h.firings) < cfg.MinFirings {
+		return burst
+	}
+
+	intervals := make([]time.Duration, 0, len(h.firings)-1)
+	for i := 1; i < len(h.firings); i++ {
+		intervals = append(intervals, h.firings[i].scheduled.Sub(h.firings[i-1].scheduled))
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	burst.Period = intervals[len(intervals)/2]
+	if burst.Period <= 0 {
+		return burst
+	}
+	burst.Regular = true
+	for _, interval := range intervals {
+		if math.Abs(float64(interval-burst.Period)) > cfg.Tolerance*float64(burst.Period) {
+			burst.Regular = false
+			break
+		}
+	}
+	if !burst.Regular {
+		return burst
+	}
+
+	// Kaçırılan (pod'u görülmeyen) tetiklenmeler atlanır
+	next := last.scheduled.Add(burst.Period)
+	for !next.After(now) {
+		next = next.Add(burst.Period)
+	}
+	burst.NextFiring = &next
+	if burst.Phase == BurstIdle && next.Sub(now) <= cfg.Lead {
+		burst.Phase = BurstUpcoming
+	}
+	return burst
+}
+
+// CronBursts öğrenilen CronJob tetiklenme düzenlerini sonraki tetiklenmeye göre sıralı döndürür
+func (as *AIScheduler) CronBursts() []CronBurst {
+	cfg := as.cronBurstSettings()
+	now := as.now()
+
+	t := &as.bursts
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	bursts := make([]CronBurst, 0, len(t.cronjobs))
+	for _, history := range t.cronjobs {
+		bursts = append(bursts, history.learn(now, &cfg))
+	}
+	sort.Slice(bursts, func(i, j int) bool {
+		a, b := bursts[i].NextFiring, bursts[j].NextFiring
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return bursts[i].Namespace+"/"+bursts[i].CronJob < bursts[j].Namespace+"/"+bursts[j].CronJob
+	})
+	return bursts
+}
+
+// burstContext skorlanan pod için patlama durumu: pod devam eden bir tetiklenmeye aitse tetiklenmenin
+// node'lara dağılımı, değilse yaklaşan patlamalar için node başına ayrılacak kapasite
+type burstContext struct {
+	cronjob       string
+	expected      float64        // Tetiklenmenin beklenen pod sayısı
+	placed        map[string]int // Tetiklenmenin node başına pod sayısı
+	nodes         int
+	reserveCPU    float64
+	reserveMemory float64
+	upcoming      []string
+}
+
+// burstContextFor pod'un patlama durumunu döndürür, özellik kapalıysa veya ilgili patlama yoksa nil
+func (as *AIScheduler) burstContextFor(pod *corev1.Pod, snapshot *clusterSnapshot) *burstContext {
+	cfg := as.cronBurstSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 || pod == nil || len(snapshot.nodes) == 0 {
+		return nil
+	}
+	now := as.now()
+	cronjob, scheduled, isCron := cronJobFiring(pod)
+
+	t := &as.bursts
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ctx := &burstContext{nodes: len(snapshot.nodes)}
+	for key, history := range t.cronjobs {
+		burst := history.learn(now, &cfg)
+
+		// Pod'un kendi tetiklenmesi: aynı tetiklenmenin pod'ları node'lara yayılır
+		if isCron && history.namespace == pod.Namespace && history.name == cronjob {
+			firing := history.firings[len(history.firings)-1]
+			if !firing.scheduled.Equal(scheduled) {
+				continue
+			}
+			ctx.cronjob = key
+			ctx.expected = math.Max(burst.PodsPerFiring, float64(len(firing.pods)))
+			ctx.placed = make(map[string]int)
+			for name, nodeName := range firing.pods {
+				if nodeName != "" && name != pod.Name {
+					ctx.placed[nodeName]++
+				}
+			}
+			continue
+		}
+
+		// Yaklaşan patlamaların yükü node'lara eşit paylaştırılarak ayrılır
+		if burst.Phase == BurstUpcoming {
+			ctx.reserveCPU += burst.CPU / float64(ctx.nodes)
+			ctx.reserveMemory += burst.Memory / float64(ctx.nodes)
+			ctx.upcoming = append(ctx.upcoming, key)
+		}
+	}
+	if ctx.cronjob == "" && len(ctx.upcoming) == 0 {
+		return nil
+	}
+	return ctx
+}
+
+// burstPenalty iki durumda ceza döndürür: pod devam eden tetiklenmesinin node başına adil payından fazlasını
+// almış node'a gidecekse (patlamanın tek node'a yığılmaması için) veya yaklaşan patlamalar için node'da
+// ayrılması gereken boş kapasiteyi tüketecekse. Ceza en fazla weight'tir
+func (as *AIScheduler) burstPenalty(ctx *burstContext, request *podRequest, info *nodeInfo) (float64, string) {
+	if ctx == nil {
+		return 0, ""
+	}
+	weight := as.currentConfig().CronBursts.Weight
+	node := info.node
+
+	if ctx.cronjob != "" {
+		share := math.Max(1, ctx.expected/float64(ctx.nodes))
+		placed := ctx.placed[node.Name]
+		if placed == 0 {
+			return 0, ""
+		}
+		penalty := weight * math.Min(1, float64(placed)/share)
+		return penalty, fmt.Sprintf("Patlama yayma cezası: %.1f (%s tetiklenmesinin %d pod'u bu node'da, pay: %.1f)", penalty, ctx.cronjob, placed, share)
+	}
+
+	freeCPU, freeMemory := info.freeCapacity()
+	if request.ownNode != node.Name {
+		freeCPU -= request.cpu
+		freeMemory -= request.memory
+	}
+	deficit := 0.0
+	if ctx.reserveCPU > 0 && freeCPU < ctx.reserveCPU {
+		deficit = math.Max(deficit, (ctx.reserveCPU-freeCPU)/ctx.reserveCPU)
+	}
+	if ctx.reserveMemory > 0 && freeMemory < ctx.reserveMemory {
+		deficit = math.Max(deficit, (ctx.reserveMemory-freeMemory)/ctx.reserveMemory)
+	}
+	if deficit <= 0 {
+		return 0, ""
+	}
+	penalty := weight * math.Min(1, deficit)
+	return penalty, fmt.Sprintf("Patlama rezervasyon cezası: %.1f (%s için ayrılan: %.2f CPU, %.2f GB)",
+		penalty, strings.Join(ctx.upcoming, ","), ctx.reserveCPU, ctx.reserveMemory)
+}
@@ -0,0 +1,273 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ai-scheduler/internal/cron"
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Karar farkı önizlemesinin sınırları
+const (
+	defaultDiffDecisions = 100
+	maxDiffDecisions     = 1000
+)
+
+// ErrInvalidProposal önerilen konfigürasyon geçersiz
+var ErrInvalidProposal = errors.New("geçersiz konfigürasyon önerisi")
+
+// decisionDiffNotes yeniden skorlamanın sınırları
+var decisionDiffNotes = []string{
+	"Kararlar karar anındaki kullanım geçmişi, pod metrikleri ve tahminle yeniden skorlanır",
+	"Node'lar güncel node listesinden alınır, pod'a özgü filtreler ve cezalar (fairness, parçalanma, ipuçları...) uygulanmaz",
+	"Değişim önerilen konfigürasyonun seçtiği node'un geçerli konfigürasyonla yeniden skorlamanın seçtiğinden farklı olmasıdır",
+}
+
+// ConfigProposal karar farkı önizlemesi için önerilen skorlama konfigürasyonu, nil alanlar geçerli değerini korur
+type ConfigProposal struct {
+	Strategy *string `json:"strategy"`
+	// Weights scoring altındaki ağırlıklar (cpu_weight, memory_weight...), verilmeyenler değişmez. Verilirse
+	// otomatik ayarlı ağırlıklar uygulanmaz
+	Weights map[string]float64 `json:"weights"`
+	// Profile etkin profil, boş string temel strateji ve ağırlıklar. Namespace bağları değişmez
+	Profile *string `json:"profile"`
+	// Policies verilirse zamanlanmış politikaların tamamının yerine geçer
+	Policies *[]PolicyProposal `json:"policies"`
+}
+
+// PolicyProposal önerilen zamanlanmış politika
+type PolicyProposal struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	Duration string `json:"duration"`
+	Strategy string `json:"strategy"`
+	// Weights verilirse önerilen temel ağırlıkların üzerine uygulanır ve politikanın ağırlıkları olur
+	Weights map[string]float64 `json:"weights"`
+}
+
+// DecisionDiffEntry yerleşimi değişen karar
+type DecisionDiffEntry struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	// Node kararda seçilen node, Current geçerli konfigürasyonla yeniden skorlamanın seçtiği
+	Node          string  `json:"node"`
+	Current       string  `json:"current"`
+	CurrentScore  float64 `json:"current_score"`
+	Proposed      string  `json:"proposed"`
+	ProposedScore float64 `json:"proposed_score"`
+}
+
+// DecisionDiff son kararların önerilen konfigürasyonla yeniden skorlanmasının özeti
+type DecisionDiff struct {
+	Evaluated  int     `json:"evaluated"`
+	Changed    int     `json:"changed"`
+	ChangeRate float64 `json:"change_rate"`
+	// Reproduced geçerli konfigürasyonla yeniden skorlamanın kararın node'unu seçtiği karar sayısı. Düşükse yeniden
+	// skorlama gerçek kararlara yaklaşamıyor, değişim oranı temkinli yorumlanmalıdır
+	Reproduced int `json:"reproduced"`
+	// Changes yerleşimi değişen kararlar, en yeniden eskiye
+	Changes []DecisionDiffEntry `json:"changes"`
+	Notes   []string            `json:"notes"`
+}
+
+// applyWeights ağırlıkları skorlama konfigürasyonuna uygular, bilinmeyen veya negatif ağırlık hata döndürür
+func applyWeights(scoring *types.ScoringConfig, weights map[string]float64) error {
+	for name, value := range weights {
+		field := weightField(scoring, name)
+		if field == nil {
+			return fmt.Errorf("bilinmeyen ağırlık %s: %w", name, ErrInvalidProposal)
+		}
+		if value < 0 {
+			return fmt.Errorf("ağırlık %s negatif olamaz: %w", name, ErrInvalidProposal)
+		}
+		*field = value
+	}
+	return nil
+}
+
+// proposedPolicies önerilen politikaları doğrular ve cron ifadelerini çözer
+func proposedPolicies(proposals []PolicyProposal, scoring types.ScoringConfig) ([]scheduledPolicy, error) {
+	compiled := make([]scheduledPolicy, 0, len(proposals))
+	for i, proposal := range proposals {
+		policy := types.ScheduledPolicy{Name: proposal.Name, Schedule: proposal.Schedule, Strategy: proposal.Strategy}
+		if policy.Name == "" {
+			policy.Name = fmt.Sprintf("policy-%d", i)
+		}
+
+		schedule, err := cron.Parse(policy.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("politika %s: %v: %w", policy.Name, err, ErrInvalidProposal)
+		}
+		policy.Duration, err = time.ParseDuration(proposal.Duration)
+		if err != nil || policy.Duration <= 0 || policy.Duration > maxPolicyDuration {
+			return nil, fmt.Errorf("politika %s: süre 0-%s aralığında olmalı: %s: %w", policy.Name, maxPolicyDuration,
+				proposal.Duration, ErrInvalidProposal)
+		}
+		if policy.Strategy != "" && !validStrategy(policy.Strategy) {
+			return nil, fmt.Errorf("politika %s: bilinmeyen strateji %s: %w", policy.Name, policy.Strategy, ErrInvalidProposal)
+		}
+		if proposal.Weights != nil {
+			weights := scoring
+			if err := applyWeights(&weights, proposal.Weights); err != nil {
+				return nil, fmt.Errorf("politika %s: %w", policy.Name, err)
+			}
+			policy.Scoring = &weights
+		}
+
+		compiled = append(compiled, scheduledPolicy{ScheduledPolicy: policy, schedule: schedule})
+	}
+	return compiled, nil
+}
+
+// configSet karar anındaki konfigürasyonu çözmek için gereken temel konfigürasyon, profiller, politikalar ve ağırlıklar
+type configSet struct {
+	base     *types.SchedulerConfig
+	profiles map[string]*types.SchedulingProfile
+	bindings ProfileBindings
+	policies []scheduledPolicy
+	tuned    map[string]float64
+	location *time.Location
+}
+
+// at namespace'in pod'ları için at anında geçerli konfigürasyonu döndürür
+func (s *configSet) at(namespace string, at time.Time) *types.SchedulerConfig {
+	name, ok := s.bindings.Namespaces[namespace]
+	if !ok {
+		name = s.bindings.Active
+	}
+	policy, _ := activePolicy(s.policies, at.In(s.location))
+	return withPolicy(s.base, s.profiles[name], policy, s.tuned)
+}
+
+// DiffDecisions son limit kararı (0 ise varsayılan) karar anındaki girdilerle geçerli ve önerilen konfigürasyonla
+// yeniden skorlar ve kaç yerleşimin değişeceğini raporlar. Konfigürasyon değişmez
+func (as *AIScheduler) DiffDecisions(proposal ConfigProposal, limit int) (*DecisionDiff, error) {
+	if limit <= 0 {
+		limit = defaultDiffDecisions
+	}
+	if limit > maxDiffDecisions {
+		limit = maxDiffDecisions
+	}
+
+	as.configMu.RLock()
+	current := configSet{
+		base:     as.baseConfig,
+		profiles: as.profiles.profiles,
+		bindings: copyBindings(as.profiles.bindings),
+		policies: as.policies,
+		tuned:    as.tunedWeights,
+		location: as.calendar.location,
+	}
+	as.configMu.RUnlock()
+
+	proposed, err := proposedConfigSet(current, proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := as.listNodes()
+	if err != nil {
+		return nil, fmt.Errorf("node listesi alınamadı: %v", err)
+	}
+
+	diff := &DecisionDiff{Changes: make([]DecisionDiffEntry, 0), Notes: decisionDiffNotes}
+	if len(nodes) == 0 {
+		return diff, nil
+	}
+	effective := as.currentConfig()
+	for _, decision := range as.Decisions(0) {
+		if diff.Evaluated == limit {
+			break
+		}
+		if decision.Node == "" {
+			continue
+		}
+
+		inputs := as.inputsAt(nodes, decision.Time)
+		currentNode, currentScore := bestScore(nodes, as.previewScores(nodes, inputs,
+			overrideBetween(effective, current.at(decision.Namespace, decision.Time))))
+		proposedNode, proposedScore := bestScore(nodes, as.previewScores(nodes, inputs,
+			overrideBetween(effective, proposed.at(decision.Namespace, decision.Time))))
+		diff.Evaluated++
+		if currentNode == decision.Node {
+			diff.Reproduced++
+		}
+		if proposedNode != currentNode {
+			diff.Changed++
+			diff.Changes = append(diff.Changes, DecisionDiffEntry{
+				Time:          decision.Time,
+				Namespace:     decision.Namespace,
+				Pod:           decision.Pod,
+				Node:          decision.Node,
+				Current:       currentNode,
+				CurrentScore:  currentScore,
+				Proposed:      proposedNode,
+				ProposedScore: proposedScore,
+			})
+		}
+	}
+	if diff.Evaluated > 0 {
+		diff.ChangeRate = float64(diff.Changed) / float64(diff.Evaluated)
+	}
+	return diff, nil
+}
+
+// proposedConfigSet geçerli konfigürasyon kümesine öneriyi uygular
+func proposedConfigSet(current configSet, proposal ConfigProposal) (configSet, error) {
+	proposed := current
+	base := *current.base
+	if proposal.Strategy != nil {
+		if *proposal.Strategy != "" && !validStrategy(*proposal.Strategy) {
+			return proposed, fmt.Errorf("bilinmeyen strateji %s: %w", *proposal.Strategy, ErrInvalidProposal)
+		}
+		base.Strategy = *proposal.Strategy
+	}
+	if proposal.Weights != nil {
+		if err := applyWeights(&base.Scoring, proposal.Weights); err != nil {
+			return proposed, err
+		}
+		proposed.tuned = nil
+	}
+	proposed.base = &base
+
+	if proposal.Profile != nil {
+		proposed.bindings.Active = *proposal.Profile
+		if err := validateBindings(current.profiles, proposed.bindings); err != nil {
+			return proposed, err
+		}
+	}
+	if proposal.Policies != nil {
+		policies, err := proposedPolicies(*proposal.Policies, base.Scoring)
+		if err != nil {
+			return proposed, err
+		}
+		proposed.policies = policies
+	}
+	return proposed, nil
+}
+
+// inputsAt node'ların at anındaki skorlama girdilerini kurar, geçmişi olmayan node'ların kullanımı 0 kabul edilir
+func (as *AIScheduler) inputsAt(nodes []*corev1.Node, at time.Time) []scoreInputs {
+	inputs := make([]scoreInputs, len(nodes))
+	for i, node := range nodes {
+		var breakdown ScoreBreakdown
+		inputs[i], _ = as.historicalScoreInputs(node, at, &breakdown)
+	}
+	return inputs
+}
+
+// bestScore en yüksek skorlu node'u ve skorunu döndürür, eşit skorda adı önce gelen seçilir
+func bestScore(nodes []*corev1.Node, scores []float64) (string, float64) {
+	best := 0
+	for i := 1; i < len(nodes); i++ {
+		if scores[i] > scores[best] || (scores[i] == scores[best] && nodes[i].Name < nodes[best].Name) {
+			best = i
+		}
+	}
+	return nodes[best].Name, scores[best]
+}
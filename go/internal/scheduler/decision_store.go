@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/store"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultDecisionSnapshotInterval karar geçmişi snapshot aralığının varsayılanı
+const defaultDecisionSnapshotInterval = 5 * time.Minute
+
+// ErrNoHistoryStore karar geçmişi kalıcılığı açık ama geçmiş deposu ayarlanmamış
+var ErrNoHistoryStore = errors.New("geçmiş deposu ayarlanmamış")
+
+// decisionStore karar geçmişinin geçmiş deposundaki snapshot'ı. mutex snapshot yazımlarını sıralar; savedVersion son
+// snapshot'taki decisionHistory sürümü
+type decisionStore struct {
+	mutex        sync.Mutex
+	history      store.HistoryStore
+	savedVersion uint64
+}
+
+// SetHistoryStore karar geçmişi snapshot'larının yazılacağı geçmiş deposunu ayarlar
+func (as *AIScheduler) SetHistoryStore(historyStore store.HistoryStore) {
+	as.decisionStore.mutex.Lock()
+	defer as.decisionStore.mutex.Unlock()
+
+	as.decisionStore.history = historyStore
+}
+
+// decisionStoreConfig varsayılanları uygulanmış karar geçmişi kalıcılık ayarlarını döndürür
+func (as *AIScheduler) decisionStoreConfig() types.PersistenceConfig {
+	cfg := as.currentConfig().DecisionStore
+	if cfg.SnapshotInterval <= 0 {
+		cfg.SnapshotInterval = defaultDecisionSnapshotInterval
+	}
+	return cfg
+}
+
+// RestoreDecisions kalıcılık açıksa son snapshot'taki kararları karar geçmişine yükler ve yüklenen karar sayısını
+// döndürür. Karar günlüğü replay'i ve replika devri kararları zaten yeniden kurduğundan onlardan sonra çağrılmamalıdır
+func (as *AIScheduler) RestoreDecisions(ctx context.Context) (int, error) {
+	if !as.decisionStoreConfig().Enabled {
+		return 0, nil
+	}
+	as.decisionStore.mutex.Lock()
+	defer as.decisionStore.mutex.Unlock()
+
+	if as.decisionStore.history == nil {
+		return 0, ErrNoHistoryStore
+	}
+	records, err := as.decisionStore.history.Query(ctx, store.StreamDecisions, time.Time{}, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+
+	size := as.currentConfig().DecisionHistorySize
+	restored := 0
+	for _, record := range records {
+		var decision Decision
+		if err := json.Unmarshal(record.Data, &decision); err != nil {
+			logrus.Warnf("Karar okunamadı, atlanıyor: %v", err)
+			continue
+		}
+		as.decisions.add(decision, size)
+		restored++
+	}
+	_, as.decisionStore.savedVersion = as.decisions.snapshot()
+	return restored, nil
+}
+
+// decisionStoreLoop karar geçmişini aralıkla geçmiş deposuna yazar. Kapanıştaki son snapshot SaveDecisions ile alınır
+func (as *AIScheduler) decisionStoreLoop(ctx context.Context) {
+	interval := as.decisionStoreConfig().SnapshotInterval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := as.decisionStoreConfig()
+			if cfg.Enabled {
+				if err := as.snapshotDecisions(ctx); err != nil {
+					logrus.Warnf("Karar geçmişi kaydedilemedi: %v", err)
+				}
+			}
+
+			if cfg.SnapshotInterval != interval {
+				interval = cfg.SnapshotInterval
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// SaveDecisions kalıcılık açıksa karar geçmişinin snapshot'ını hemen yazar (ör: kapanışta)
+func (as *AIScheduler) SaveDecisions(ctx context.Context) error {
+	if !as.decisionStoreConfig().Enabled {
+		return nil
+	}
+	return as.snapshotDecisions(ctx)
+}
+
+// snapshotDecisions son snapshot'tan beri yeni karar eklendiyse geçmiş deposundaki akışı tampondaki kararlarla
+// değiştirir
+func (as *AIScheduler) snapshotDecisions(ctx context.Context) error {
+	as.decisionStore.mutex.Lock()
+	defer as.decisionStore.mutex.Unlock()
+
+	if as.decisionStore.history == nil {
+		return ErrNoHistoryStore
+	}
+	decisions, version := as.decisions.snapshot()
+	if version == as.decisionStore.savedVersion {
+		return nil
+	}
+
+	// Kararlar en yeniden eskiye geldiğinden eskiden yeniye yazılır, eşit zamanlılar eklenme sırasını korur
+	records := make([]store.Record, 0, len(decisions))
+	for i := len(decisions) - 1; i >= 0; i-- {
+		record, err := store.NewRecord(decisions[i].Time, decisions[i].Namespace+"/"+decisions[i].Pod, &decisions[i])
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	if err := as.decisionStore.history.Snapshot(ctx, store.StreamDecisions, records); err != nil {
+		return err
+	}
+	as.decisionStore.savedVersion = version
+	logrus.Debugf("Karar geçmişi kaydedildi: %d karar", len(records))
+	return nil
+}
@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultDecisionHistorySize konfigürasyonda boyut yoksa tutulan karar sayısı
+const defaultDecisionHistorySize = 1000
+
+// Karar sonuçları
+const (
+	OutcomeScheduled     = "scheduled"
+	OutcomeObserveOnly   = "observe_only"
+	OutcomeUnschedulable = "unschedulable"
+)
+
+// Decision scheduler'ın bir pod için verdiği karar
+type Decision struct {
+	Time           time.Time           `json:"time"`
+	Namespace      string              `json:"namespace"`
+	Pod            string              `json:"pod"`
+	Workload       types.WorkloadRef   `json:"workload"`
+	Template       string              `json:"template,omitempty"` // Pod şablonunun içerik özeti (types.TemplateFingerprint)
+	Outcome        string              `json:"outcome"`
+	Node           string              `json:"node,omitempty"`
+	Score          float64             `json:"score,omitempty"`
+	Reason         string              `json:"reason,omitempty"`
+	Ranked         bool                `json:"ranked,omitempty"`
+	Rejected       map[string]int      `json:"rejected,omitempty"` // Filtrede elenen node sayıları
+	CPU            float64             `json:"cpu,omitempty"`      // Pod'un kaynak istekleri (günlükten iş yükü üretmek için)
+	Memory         float64             `json:"memory_gb,omitempty"`
+	CapacityNeeded *types.CapacityHint `json:"capacity_needed,omitempty"`
+	Stale          *Staleness          `json:"stale,omitempty"` // Kararın bayat skorlama girdileri
+	AISkipped      bool                `json:"ai_skipped,omitempty"`
+	Lifetime       time.Duration       `json:"expected_lifetime,omitempty"` // Pod'un tahmini yaşam süresi (dönemsel skorlama açıksa)
+}
+
+// decisionHistory son kararları sabit boyutlu halka tamponda tutar. version her eklenen kararla artar, snapshot
+// değişiklik olmadıysa yazılmaz
+type decisionHistory struct {
+	mutex   sync.Mutex
+	entries []Decision
+	next    int
+	full    bool
+	version uint64
+}
+
+// add kararı ekler, tampon doluysa en eski karar silinir. En yeni karardan eski karar (ör: çalışan scheduler'a geri
+// yüklenen yedek) zamanına göre yerleştirilir, tampondaki tüm kararlardan eskiyse ve tampon doluysa eklenmez
+func (h *decisionHistory) add(decision Decision, size int) {
+	if size <= 0 {
+		size = defaultDecisionHistorySize
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// Boyut değiştiyse son kararlar korunarak tampon yeniden kurulur
+	if len(h.entries) != size {
+		h.resetLocked(h.recentLocked(size), size)
+	}
+	if (h.next == 0 && !h.full) || !decision.Time.Before(h.entries[(h.next-1+size)%size].Time) {
+		h.appendLocked(decision)
+		return
+	}
+
+	recent := h.recentLocked(0)
+	i := sort.Search(len(recent), func(i int) bool { return !recent[i].Time.After(decision.Time) })
+	if i == len(recent) && h.full {
+		return
+	}
+	recent = append(recent[:i], append([]Decision{decision}, recent[i:]...)...)
+	if len(recent) > size {
+		recent = recent[:size]
+	}
+	h.resetLocked(recent, size)
+}
+
+// resetLocked tamponu en yeniden eskiye verilen kararlarla yeniden kurar
+func (h *decisionHistory) resetLocked(recent []Decision, size int) {
+	h.entries = make([]Decision, size)
+	h.next = 0
+	h.full = false
+	for i := len(recent) - 1; i >= 0; i-- {
+		h.appendLocked(recent[i])
+	}
+}
+
+// appendLocked kararı sıradaki yuvaya yazar
+func (h *decisionHistory) appendLocked(decision Decision) {
+	h.entries[h.next] = decision
+	h.version++
+	h.next++
+	if h.next == len(h.entries) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// recentLocked en yeniden eskiye en fazla limit kararı döndürür
+func (h *decisionHistory) recentLocked(limit int) []Decision {
+	count := h.next
+	if h.full {
+		count = len(h.entries)
+	}
+	if limit > 0 && limit < count {
+		count = limit
+	}
+
+	result := make([]Decision, 0, count)
+	for i := 1; i <= count; i++ {
+		result = append(result, h.entries[(h.next-i+len(h.entries))%len(h.entries)])
+	}
+	return result
+}
+
+// recent en yeniden eskiye en fazla limit kararı döndürür, limit 0 ise hepsi
+func (h *decisionHistory) recent(limit int) []Decision {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.entries) == 0 {
+		return nil
+	}
+	return h.recentLocked(limit)
+}
+
+// snapshot en yeniden eskiye tüm kararları ve tamponun sürümünü döndürür
+func (h *decisionHistory) snapshot() ([]Decision, uint64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.entries) == 0 {
+		return nil, h.version
+	}
+	return h.recentLocked(0), h.version
+}
+
+// decisionFor seçilen node için kararı oluşturur
+func (as *AIScheduler) decisionFor(pod *corev1.Pod, result *NodeScore, rejected map[string]int) Decision {
+	outcome := OutcomeScheduled
+	if result.ObserveOnly {
+		outcome = OutcomeObserveOnly
+	}
+	cpu, memory := types.PodResourceRequests(pod)
+	return Decision{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Workload:  types.WorkloadOf(pod),
+		Template:  types.TemplateFingerprint(pod),
+		Outcome:   outcome,
+		Node:      result.NodeName,
+		Score:     result.Score,
+		Reason:    result.Reason,
+		Ranked:    result.Ranked,
+		Rejected:  rejected,
+		Stale:     result.Stale,
+		AISkipped: result.AISkipped,
+		CPU:       cpu,
+		Memory:    memory,
+		Lifetime:  as.podLifetime(pod),
+	}
+}
+
+// recordDecision kararı geçmişe yazar
+func (as *AIScheduler) recordDecision(decision Decision) {
+	decision.Time = as.now()
+	as.decisions.add(decision, as.currentConfig().DecisionHistorySize)
+	as.recordAttempt(&decision)
+	as.appendJournal(JournalEntry{Kind: JournalDecision, Time: decision.Time, Decision: &decision})
+	if as.events != nil {
+		as.events.Publish(types.Event{Kind: types.EventDecision, Time: decision.Time, Key: decision.Namespace + "/" + decision.Pod, Data: decision})
+	}
+	if decision.Outcome == OutcomeScheduled {
+		as.recordPlacement(decision.Node, decision.Time)
+	}
+	if decision.Outcome == OutcomeScheduled || decision.Outcome == OutcomeObserveOnly {
+		as.trackOutcome(&decision)
+	}
+}
+
+// Decisions en yeniden eskiye en fazla limit kararı döndürür, limit 0 ise tümü
+func (as *AIScheduler) Decisions(limit int) []Decision {
+	return as.decisions.recent(limit)
+}
@@ -0,0 +1,318 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Kademeli bozulma seviyeleri, sıradaki seviye bir öncekinden daha az bileşene dayanır
+const (
+	TierFullAI     = "full_ai"     // Her tahminde canlı AI analizi
+	TierCachedAI   = "cached_ai"   // AI hata veriyor, süresi dolmamış son AI analizleri kullanılır
+	TierHeuristic  = "heuristic"   // AI kullanılmaz, sadece Go skorlaması
+	TierRoundRobin = "round_robin" // Skorlama girdilerine güvenilmez, filtreden geçen node'lar sırayla seçilir
+)
+
+// Tiers bozulma seviyeleri, indeks seviye numarasıdır (0 = tam AI)
+var Tiers = []string{TierFullAI, TierCachedAI, TierHeuristic, TierRoundRobin}
+
+// Kademeli bozulmanın varsayılanları
+const (
+	defaultAIFailureThreshold     = 3
+	defaultAICacheTTL             = 5 * time.Minute
+	defaultRecoveryProbeInterval  = 30 * time.Second
+	defaultBudgetBreachThreshold  = 20
+	defaultMetricsStaleAfter      = 5 * time.Minute
+	defaultAIAnalysisConfidence   = 0.5
+	cachedAnalysisReasonSuffix    = " [önbellekteki AI analizi]"
+	aiBudgetReasonSuffix          = " [AI bütçesi aşıldı, heuristik skor]"
+	degradationMetricsStaleReason = "%s süredir metrik gelmiyor"
+	tierHistorySize               = 100 // Saklanan en fazla seviye geçişi
+)
+
+// errAIAnalysisSkipped AI hata eşiğini aştığı için canlı analiz istenmedi
+var errAIAnalysisSkipped = fmt.Errorf("AI hata eşiğini aştı, canlı analiz istenmedi: %w", types.ErrAIUnavailable)
+
+// DegradationStatus bozulma seviyesi ve seviyeyi belirleyen koşulların anlık değerleri
+type DegradationStatus struct {
+	Enabled     bool      `json:"enabled"`
+	Tier        string    `json:"tier"`
+	Level       int       `json:"level"`
+	Since       time.Time `json:"since"`
+	Reason      string    `json:"reason,omitempty"`
+	Transitions uint64    `json:"transitions"`
+	// Koşullar
+	AIFailures     int       `json:"ai_consecutive_failures"`
+	LastAISuccess  time.Time `json:"last_ai_success"`
+	CachedAnalyses int       `json:"cached_analyses"` // Süresi dolmamış AI analizi olan node sayısı
+	BudgetBreaches int       `json:"consecutive_budget_breaches"`
+	LastMetric     time.Time `json:"last_metric"`
+}
+
+// TierTransition bozulma seviyesi geçişi
+type TierTransition struct {
+	Time   time.Time `json:"time"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// cachedAnalysis node için son başarılı AI analizi
+type cachedAnalysis struct {
+	score      float64
+	confidence float64
+	at         time.Time
+}
+
+// degradationState seviyeyi belirleyen sayaçlar ve son AI analizleri
+type degradationState struct {
+	mutex          sync.Mutex
+	tier           string
+	since          time.Time
+	reason         string
+	transitions    uint64
+	history        []TierTransition // Son seviye geçişleri, eskiden yeniye
+	started        time.Time        // Start çağrıldığı an, sıfırsa metrik tazeliği kontrol edilmez (bench, replay)
+	aiFailures     int
+	lastAISuccess  time.Time
+	lastProbe      time.Time
+	budgetBreaches int
+	lastMetric     time.Time
+	analyses       map[string]cachedAnalysis
+	roundRobin     atomic.Uint64
+}
+
+// degradationSettings varsayılanları uygulanmış bozulma ayarları
+func (as *AIScheduler) degradationSettings() (enabled bool, failureThreshold int, cacheTTL, probeInterval time.Duration, breachThreshold int, staleAfter time.Duration) {
+	cfg := as.currentConfig().Degradation
+	failureThreshold, cacheTTL, probeInterval = cfg.AIFailureThreshold, cfg.AICacheTTL, cfg.RecoveryProbeInterval
+	breachThreshold, staleAfter = cfg.BudgetBreachThreshold, cfg.MetricsStaleAfter
+	if failureThreshold <= 0 {
+		failureThreshold = defaultAIFailureThreshold
+	}
+	if cacheTTL <= 0 {
+		cacheTTL = defaultAICacheTTL
+	}
+	if probeInterval <= 0 {
+		probeInterval = defaultRecoveryProbeInterval
+	}
+	if breachThreshold == 0 {
+		breachThreshold = defaultBudgetBreachThreshold
+	}
+	if staleAfter == 0 {
+		staleAfter = defaultMetricsStaleAfter
+	}
+	return cfg.Enabled, failureThreshold, cacheTTL, probeInterval, breachThreshold, staleAfter
+}
+
+// evaluateTier koşullardan seviyeyi hesaplar, değiştiyse geçişi kaydeder ve loglar.
+// Kapalıyken sadece yapılandırılmış davranış raporlanır: tam AI veya heuristik
+func (as *AIScheduler) evaluateTier(now time.Time) DegradationStatus {
+	enabled, failureThreshold, cacheTTL, _, breachThreshold, staleAfter := as.degradationSettings()
+	heuristicOnly := as.HeuristicOnly()
+	blending := as.featureGate.Enabled(features.AIBlending)
+
+	d := &as.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	cached := d.freshAnalysesLocked(now, cacheTTL)
+	tier, reason := TierFullAI, ""
+	switch metricsAt := d.lastMetric; {
+	case enabled && staleAfter > 0 && !d.started.IsZero() && now.Sub(latest(metricsAt, d.started)) > staleAfter:
+		tier, reason = TierRoundRobin, fmt.Sprintf(degradationMetricsStaleReason, now.Sub(latest(metricsAt, d.started)).Round(time.Second))
+	case enabled && breachThreshold > 0 && d.budgetBreaches >= breachThreshold:
+		tier, reason = TierRoundRobin, fmt.Sprintf("gecikme bütçesi %d kez üst üste aşıldı", d.budgetBreaches)
+	case heuristicOnly:
+		tier, reason = TierHeuristic, "scheduler.mode heuristic"
+	case !blending:
+		tier, reason = TierHeuristic, features.AIBlending+" kapalı"
+	case enabled && d.aiFailures >= failureThreshold && cached > 0:
+		tier, reason = TierCachedAI, fmt.Sprintf("AI %d kez üst üste hata verdi", d.aiFailures)
+	case enabled && d.aiFailures >= failureThreshold:
+		tier, reason = TierHeuristic, fmt.Sprintf("AI %d kez üst üste hata verdi, önbellekte güncel analiz yok", d.aiFailures)
+	}
+
+	if tier != d.tier {
+		if d.tier != "" {
+			d.transitions++
+			d.history = append(d.history, TierTransition{Time: now, From: d.tier, To: tier, Reason: reason})
+			if len(d.history) > tierHistorySize {
+				d.history = append(d.history[:0:0], d.history[len(d.history)-tierHistorySize:]...)
+			}
+			if tierLevel(tier) > tierLevel(d.tier) {
+				logrus.Warnf("Scheduler bozulma seviyesi %s -> %s: %s", d.tier, tier, reason)
+			} else {
+				logrus.Infof("Scheduler bozulma seviyesi %s -> %s", d.tier, tier)
+			}
+		}
+		d.tier, d.since = tier, now
+	}
+	d.reason = reason
+
+	return DegradationStatus{
+		Enabled:        enabled,
+		Tier:           tier,
+		Level:          tierLevel(tier),
+		Since:          d.since,
+		Reason:         reason,
+		Transitions:    d.transitions,
+		AIFailures:     d.aiFailures,
+		LastAISuccess:  d.lastAISuccess,
+		CachedAnalyses: cached,
+		BudgetBreaches: d.budgetBreaches,
+		LastMetric:     d.lastMetric,
+	}
+}
+
+// Degradation güncel bozulma seviyesini ve koşullarını döndürür
+func (as *AIScheduler) Degradation() DegradationStatus {
+	return as.evaluateTier(as.now())
+}
+
+// TierTransitions son bozulma seviyesi geçişlerini eskiden yeniye döndürür
+func (as *AIScheduler) TierTransitions() []TierTransition {
+	d := &as.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]TierTransition(nil), d.history...)
+}
+
+// tierLevel seviyenin numarasını döndürür
+func tierLevel(tier string) int {
+	for i, name := range Tiers {
+		if name == tier {
+			return i
+		}
+	}
+	return 0
+}
+
+// latest iki zamandan sonrakini döndürür
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// freshAnalysesLocked süresi dolmamış AI analizi sayısını döndürür, dolanları siler
+func (d *degradationState) freshAnalysesLocked(now time.Time, ttl time.Duration) int {
+	for node, analysis := range d.analyses {
+		if now.Sub(analysis.at) > ttl {
+			delete(d.analyses, node)
+		}
+	}
+	return len(d.analyses)
+}
+
+// metricSeen metrik geldiğini kaydeder
+func (as *AIScheduler) metricSeen(now time.Time) {
+	d := &as.degradation
+	d.mutex.Lock()
+	d.lastMetric = now
+	d.mutex.Unlock()
+}
+
+// markStarted metrik tazeliği kontrolünün başlangıç anını kaydeder
+func (as *AIScheduler) markStarted(now time.Time) {
+	d := &as.degradation
+	d.mutex.Lock()
+	d.started = now
+	d.mutex.Unlock()
+}
+
+// recordScoringLatency skorlama süresinin gecikme bütçesinde kalıp kalmadığını kaydeder, bütçede kalan tahmin
+// ardışık aşım sayacını sıfırlar
+func (as *AIScheduler) recordScoringLatency(withinBudget bool) {
+	d := &as.degradation
+	d.mutex.Lock()
+	if withinBudget {
+		d.budgetBreaches = 0
+	} else {
+		d.budgetBreaches++
+	}
+	d.mutex.Unlock()
+}
+
+// probeDue bozulmuş seviyede toparlanma denemesi zamanı geldiyse true döner ve denemeyi kaydeder
+func (as *AIScheduler) probeDue(now time.Time) bool {
+	_, _, _, probeInterval, _, _ := as.degradationSettings()
+
+	d := &as.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if now.Sub(d.lastProbe) < probeInterval {
+		return false
+	}
+	d.lastProbe = now
+	return true
+}
+
+// liveAI AI analizinin canlı istenip istenmeyeceğini döndürür: AI hata eşiğinin altındaysa veya toparlanma
+// denemesi zamanı geldiyse canlı, aksi halde önbellekten
+func (as *AIScheduler) liveAI(status *DegradationStatus, now time.Time) bool {
+	enabled, failureThreshold, _, _, _, _ := as.degradationSettings()
+	if !enabled || status.AIFailures < failureThreshold {
+		return true
+	}
+	return as.probeDue(now)
+}
+
+// recordAIResult AI analizinin sonucunu kaydeder, başarılı analiz önbelleğe alınır ve hata sayacını sıfırlar
+func (as *AIScheduler) recordAIResult(nodeName string, score, confidence float64, err error) {
+	now := as.now()
+
+	d := &as.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err != nil {
+		d.aiFailures++
+		as.reportError(types.ErrorComponentAI, err)
+		return
+	}
+	d.aiFailures = 0
+	d.lastAISuccess = now
+	if d.analyses == nil {
+		d.analyses = make(map[string]cachedAnalysis)
+	}
+	d.analyses[nodeName] = cachedAnalysis{score: score, confidence: confidence, at: now}
+}
+
+// cachedAIAnalysis node'un süresi dolmamış son AI analizini döndürür, kademeli bozulma kapalıyken önbellek kullanılmaz
+func (as *AIScheduler) cachedAIAnalysis(nodeName string) (cachedAnalysis, bool) {
+	enabled, _, cacheTTL, _, _, _ := as.degradationSettings()
+	if !enabled {
+		return cachedAnalysis{}, false
+	}
+
+	d := &as.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	analysis, ok := d.analyses[nodeName]
+	if !ok || as.now().Sub(analysis.at) > cacheTTL {
+		return cachedAnalysis{}, false
+	}
+	return analysis, true
+}
+
+// roundRobinCandidates filtreden geçen node'lardan sıradakini skorlamadan aday yapar
+func (as *AIScheduler) roundRobinCandidates(feasible []*nodeInfo, reason string) []NodeScore {
+	if len(feasible) == 0 {
+		return nil
+	}
+	next := as.degradation.roundRobin.Add(1) - 1
+	node := feasible[next%uint64(len(feasible))].node
+	return []NodeScore{{NodeName: node.Name, Reason: "Sıralı seçim (" + TierRoundRobin + "): " + reason}}
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"math"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kesinti maliyetinin varsayılanları
+const (
+	defaultDisruptionPriorityWeight    = 40.0
+	defaultDisruptionBudgetWeight      = 30.0
+	defaultDisruptionRestartWeight     = 10.0
+	defaultDisruptionRestartSaturation = 10
+	defaultDisruptionStatefulWeight    = 30.0
+	// Kalıcı volume'lu (StatefulSet dışı) ve emptyDir'li pod'ların StatefulWeight'ten aldığı pay
+	persistentVolumeStatefulFactor = 0.5
+	emptyDirStatefulFactor         = 0.25
+)
+
+// DisruptionCost pod'u tahliye etmenin tahmini maliyeti ve bileşenleri, düşük maliyetli pod'lar önce taşınır
+type DisruptionCost struct {
+	Total    float64 `json:"total"`
+	Priority float64 `json:"priority"` // Pod önceliği (logaritmik)
+	Budget   float64 `json:"budget"`   // PDB'nin kalan kesinti hakkı azaldıkça artar
+	Restarts float64 `json:"restarts"` // Restart geçmişi: sık restart olan pod'un yeniden ayağa kalkması riskli
+	Stateful float64 `json:"stateful"` // StatefulSet, kalıcı volume veya emptyDir verisi
+}
+
+// disruptionCostSettings varsayılanları uygulanmış kesinti maliyeti ağırlıkları
+func (as *AIScheduler) disruptionCostSettings() types.DisruptionCostConfig {
+	cfg := as.currentConfig().DisruptionCost
+	if cfg.PriorityWeight == 0 {
+		cfg.PriorityWeight = defaultDisruptionPriorityWeight
+	}
+	if cfg.BudgetWeight == 0 {
+		cfg.BudgetWeight = defaultDisruptionBudgetWeight
+	}
+	if cfg.RestartWeight == 0 {
+		cfg.RestartWeight = defaultDisruptionRestartWeight
+	}
+	if cfg.RestartSaturation <= 0 {
+		cfg.RestartSaturation = defaultDisruptionRestartSaturation
+	}
+	if cfg.StatefulWeight == 0 {
+		cfg.StatefulWeight = defaultDisruptionStatefulWeight
+	}
+	return cfg
+}
+
+// disruptionCost pod'un tahliye maliyetini hesaplar. budgets pod'u seçen PDB'lerin güncel kalan haklarıdır;
+// hazır olmayan pod PDB hakkı tüketmediği için bütçe bileşeni almaz. Negatif ağırlıklı bileşenler hesaplanmaz
+func disruptionCost(cfg *types.DisruptionCostConfig, pod *corev1.Pod, budgets []*disruptionBudget, ready bool) DisruptionCost {
+	var cost DisruptionCost
+
+	if priority := podPriority(pod); priority > 0 && cfg.PriorityWeight > 0 {
+		scaled := math.Log10(1+float64(priority)) / math.Log10(1+systemCriticalPriority)
+		cost.Priority = cfg.PriorityWeight * math.Min(scaled, 1)
+	}
+
+	// En dar PDB belirler: son hakkı tüketen (veya hakkı kalmamış) tahliye tam ağırlık, iki hak kaldıysa yarısı
+	if ready && cfg.BudgetWeight > 0 {
+		for _, budget := range budgets {
+			cost.Budget = math.Max(cost.Budget, cfg.BudgetWeight/float64(max(budget.allowed, 1)))
+		}
+	}
+
+	if restarts := podRestarts(pod); restarts > 0 && cfg.RestartWeight > 0 {
+		cost.Restarts = cfg.RestartWeight * math.Min(float64(restarts)/float64(cfg.RestartSaturation), 1)
+	}
+
+	if cfg.StatefulWeight > 0 {
+		cost.Stateful = cfg.StatefulWeight * statefulFactor(pod)
+	}
+
+	cost.Total = cost.Priority + cost.Budget + cost.Restarts + cost.Stateful
+	return cost
+}
+
+// statefulFactor pod'un taşınınca kaybedilen veya taşınmayan durumunun payı: StatefulSet 1, kalıcı volume
+// persistentVolumeStatefulFactor, sadece emptyDir emptyDirStatefulFactor
+func statefulFactor(pod *corev1.Pod) float64 {
+	if controller := metav1.GetControllerOf(pod); controller != nil && controller.Kind == "StatefulSet" {
+		return 1
+	}
+	factor := 0.0
+	for i := range pod.Spec.Volumes {
+		switch {
+		case pod.Spec.Volumes[i].PersistentVolumeClaim != nil:
+			factor = math.Max(factor, persistentVolumeStatefulFactor)
+		case pod.Spec.Volumes[i].EmptyDir != nil:
+			factor = math.Max(factor, emptyDirStatefulFactor)
+		}
+	}
+	return factor
+}
@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DrainPlacement drain sırasında tahliye edilecek pod ve tahmini yeni node'u
+type DrainPlacement struct {
+	Namespace        string            `json:"namespace"`
+	Pod              string            `json:"pod"`
+	Workload         types.WorkloadRef `json:"workload"`
+	Priority         int32             `json:"priority"`
+	CPU              float64           `json:"cpu"`
+	Memory           float64           `json:"memory_gb"`
+	DisruptionCost   DisruptionCost    `json:"disruption_cost"`
+	Destination      string            `json:"destination"`
+	DestinationScore float64           `json:"destination_score"`
+	Warnings         []string          `json:"warnings,omitempty"`
+}
+
+// NodeCapacityImpact drain'in bir node'un istenen kaynaklarına etkisi
+type NodeCapacityImpact struct {
+	NodeName          string  `json:"node_name"`
+	AllocatableCPU    float64 `json:"allocatable_cpu"`
+	AllocatableMemory float64 `json:"allocatable_memory_gb"`
+	CPUBefore         float64 `json:"cpu_requested_before"`
+	CPUAfter          float64 `json:"cpu_requested_after"`
+	MemoryBefore      float64 `json:"memory_requested_before_gb"`
+	MemoryAfter       float64 `json:"memory_requested_after_gb"`
+	PodsBefore        int     `json:"pods_before"`
+	PodsAfter         int     `json:"pods_after"`
+}
+
+// DrainPlan node'daki tüm pod'ların tahliyesinin simülasyonu: yerleşimler, kapasite etkisi ve yeri olmayan pod'lar
+type DrainPlan struct {
+	NodeName    string               `json:"node_name"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Feasible    bool                 `json:"feasible"` // Tahliye edilen tüm pod'lar için yer var mı
+	Placements  []DrainPlacement     `json:"placements"`
+	Unplaceable []BlockedEviction    `json:"unplaceable,omitempty"`
+	Skipped     []BlockedEviction    `json:"skipped,omitempty"` // Drain'in tahliye etmediği pod'lar (DaemonSet, statik)
+	Capacity    []NodeCapacityImpact `json:"capacity_impact"`
+	// DisruptionCost yerleştirilen pod'ların kesinti maliyetlerinin toplamı
+	DisruptionCost float64 `json:"disruption_cost"`
+}
+
+// PlanDrain node'daki tüm pod'ların tahliyesini simüle eder ve kubectl drain'den önce incelenecek planı döndürür.
+// DaemonSet ve statik pod'lar kubectl drain gibi atlanır; PDB'ler tahliyeyi engellemez, drain'i bekletecekleri uyarı olarak eklenir.
+// Büyük ve yüksek öncelikli pod'lar önce yerleştirilir, böylece küçük pod'lar kalan boşluklara sığar
+func (as *AIScheduler) PlanDrain(nodeName string) (*DrainPlan, error) {
+	if _, err := as.getNode(nodeName); err != nil {
+		return nil, fmt.Errorf("%s: %w", nodeName, types.ErrNodeNotFound)
+	}
+
+	pods, err := as.listPods()
+	if err != nil {
+		return nil, fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+	pdbs, err := as.listPDBs()
+	if err != nil {
+		return nil, fmt.Errorf("PodDisruptionBudget listesi alınamadı: %v", err)
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("küme snapshot'ı alınamadı: %v", err)
+	}
+
+	budgets := make([]*disruptionBudget, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		budget, err := newDisruptionBudget(pdb, pods)
+		if err != nil {
+			return nil, fmt.Errorf("PDB %s/%s çözülemedi: %v", pdb.Namespace, pdb.Name, err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	plan := &DrainPlan{
+		NodeName:    nodeName,
+		GeneratedAt: as.now(),
+		Placements:  []DrainPlacement{},
+	}
+
+	var evicted []*corev1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName != nodeName || pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			plan.Skipped = append(plan.Skipped, BlockedEviction{Namespace: pod.Namespace, Pod: pod.Name, Reason: "statik pod, kubelet tarafından yönetilir"})
+			continue
+		}
+		if controller := metav1.GetControllerOf(pod); controller != nil && controller.Kind == "DaemonSet" {
+			plan.Skipped = append(plan.Skipped, BlockedEviction{Namespace: pod.Namespace, Pod: pod.Name, Reason: "DaemonSet pod'u, drain tarafından atlanır"})
+			continue
+		}
+		evicted = append(evicted, pod)
+	}
+	sort.SliceStable(evicted, func(i, j int) bool {
+		a, b := evicted[i], evicted[j]
+		if priorityA, priorityB := podPriority(a), podPriority(b); priorityA != priorityB {
+			return priorityA > priorityB
+		}
+		cpuA, memA := types.PodResourceRequests(a)
+		cpuB, memB := types.PodResourceRequests(b)
+		if cpuA+memA != cpuB+memB {
+			return cpuA+memA > cpuB+memB
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	costSettings := as.disruptionCostSettings()
+	planned := make(map[string]*nodeInfo)
+	for _, pod := range evicted {
+		cpu, memory := types.PodResourceRequests(pod)
+		request := podRequest{pod: pod, cpu: cpu, memory: memory}
+		destination, score, rejected := as.evictionDestination(snapshot, &request, nodeName, planned)
+		if destination == nil {
+			plan.Unplaceable = append(plan.Unplaceable, BlockedEviction{Namespace: pod.Namespace, Pod: pod.Name, Reason: fmt.Sprintf("başka uygun node yok: %v", rejected)})
+			continue
+		}
+
+		// Maliyet, drain'in önceki tahliyelerle tükettiği PDB haklarıyla hesaplanır
+		placement := DrainPlacement{
+			Namespace:        pod.Namespace,
+			Pod:              pod.Name,
+			Workload:         types.WorkloadOf(pod),
+			Priority:         podPriority(pod),
+			CPU:              cpu,
+			Memory:           memory,
+			DisruptionCost:   disruptionCost(&costSettings, pod, selectingBudgets(budgets, pod), podReady(pod)),
+			Destination:      destination.node.Name,
+			DestinationScore: score,
+			Warnings:         drainWarnings(pod, budgets),
+		}
+		plan.DisruptionCost += placement.DisruptionCost.Total
+		destination.requestedCPU += cpu
+		destination.requestedMemory += memory
+		destination.pods++
+		plan.Placements = append(plan.Placements, placement)
+	}
+	plan.Feasible = len(plan.Unplaceable) == 0
+
+	// Kaynak node ve yerleşim alan node'lar için önce/sonra kapasite
+	if source, ok := snapshot.node(nodeName); ok {
+		impact := capacityImpact(source, source)
+		for _, placement := range plan.Placements {
+			impact.CPUAfter -= placement.CPU
+			impact.MemoryAfter -= placement.Memory
+			impact.PodsAfter--
+		}
+		plan.Capacity = append(plan.Capacity, impact)
+	}
+	names := make([]string, 0, len(planned))
+	for name := range planned {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if before, ok := snapshot.node(name); ok {
+			plan.Capacity = append(plan.Capacity, capacityImpact(before, planned[name]))
+		}
+	}
+
+	return plan, nil
+}
+
+// drainWarnings tahliyenin yan etkileri ve drain'i bekletecek veya durduracak durumlar
+func drainWarnings(pod *corev1.Pod, budgets []*disruptionBudget) []string {
+	warnings := evictionWarnings(pod)
+	if metav1.GetControllerOf(pod) == nil {
+		warnings = append(warnings, "controller'ı yok, drain --force gerektirir ve pod yeniden oluşturulmaz")
+	}
+	if podPriority(pod) >= systemCriticalPriority {
+		warnings = append(warnings, "sistem kritik öncelikli pod")
+	}
+
+	// Drain pod'ları sırayla tahliye eder, her hazır pod PDB hakkından düşer
+	ready := podReady(pod)
+	matched, reason := matchingBudgets(budgets, pod, ready)
+	if reason != "" {
+		return append(warnings, reason+", drain bekler")
+	}
+	for _, budget := range matched {
+		if ready {
+			budget.allowed--
+		}
+	}
+	return warnings
+}
+
+// capacityImpact node'un drain öncesi ve sonrası istenen kaynakları
+func capacityImpact(before, after *nodeInfo) NodeCapacityImpact {
+	return NodeCapacityImpact{
+		NodeName:          before.node.Name,
+		AllocatableCPU:    before.allocatableCPU,
+		AllocatableMemory: before.allocatableMemory,
+		CPUBefore:         before.requestedCPU,
+		CPUAfter:          after.requestedCPU,
+		MemoryBefore:      before.requestedMemory,
+		MemoryAfter:       after.requestedMemory,
+		PodsBefore:        before.pods,
+		PodsAfter:         after.pods,
+	}
+}
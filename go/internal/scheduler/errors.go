@@ -0,0 +1,13 @@
+package scheduler
+
+import "ai-scheduler/internal/types"
+
+// AddErrorSink tahmin ve AI hatalarının alıcısını ekler (ör: Prometheus)
+func (as *AIScheduler) AddErrorSink(sink types.ErrorSink) {
+	as.errorSinks.Add(sink)
+}
+
+// reportError hatayı alıcılara iletir
+func (as *AIScheduler) reportError(component string, err error) {
+	as.errorSinks.ObserveError(component, err)
+}
@@ -0,0 +1,354 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// defaultEvictionLimit planda varsayılan en fazla pod sayısı
+	defaultEvictionLimit = 10
+	// maxEvictionLimit planda izin verilen en fazla pod sayısı
+	maxEvictionLimit = 100
+	// systemCriticalPriority system-cluster-critical ve system-node-critical sınıflarının alt sınırı
+	systemCriticalPriority = 2000000000
+	// mirrorPodAnnotation kubelet'in statik pod'lar için oluşturduğu mirror pod işareti
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// EvictionCandidate tahliyesi önerilen pod ve tahmini yeni node'u
+type EvictionCandidate struct {
+	Namespace        string            `json:"namespace"`
+	Pod              string            `json:"pod"`
+	Workload         types.WorkloadRef `json:"workload"`
+	Priority         int32             `json:"priority"`
+	Ready            bool              `json:"ready"`
+	CPU              float64           `json:"cpu"`
+	Memory           float64           `json:"memory_gb"`
+	DisruptionBudget []string          `json:"disruption_budgets,omitempty"` // Tahliyenin hakkını tükettiği PDB'ler
+	DisruptionCost   DisruptionCost    `json:"disruption_cost"`
+	Destination      string            `json:"destination"`
+	DestinationScore float64           `json:"destination_score"`
+	Warnings         []string          `json:"warnings,omitempty"`
+}
+
+// BlockedEviction tahliyesi önerilmeyen pod ve sebebi
+type BlockedEviction struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Reason    string `json:"reason"`
+}
+
+// EvictionPlan kararsız node'dan taşınması en güvenli pod'lar, önerilen sırayla
+type EvictionPlan struct {
+	NodeName       string              `json:"node_name"`
+	GeneratedAt    time.Time           `json:"generated_at"`
+	StabilityScore float64             `json:"stability_score"`
+	FailureRate    float64             `json:"failure_rate"`
+	Evict          []EvictionCandidate `json:"evict"`
+	Blocked        []BlockedEviction   `json:"blocked,omitempty"`
+}
+
+// disruptionBudget PDB'nin plan boyunca kalan kesinti hakkı
+type disruptionBudget struct {
+	name      string
+	namespace string
+	selector  labels.Selector
+	allowed   int
+}
+
+// AdviseEvictions node'daki pod'lardan tahliyesi en güvenli olanları seçer ve her biri için yeni node tahmin eder.
+// DaemonSet, statik, controller'sız ve sistem kritik pod'lar ile PDB'si izin vermeyen pod'lar önerilmez.
+// Kesinti maliyeti düşük pod'lar önce gelir (eşitlikte hazır olmayan ve küçük pod'lar); hedef node'lar plandaki
+// önceki taşımalarla birlikte kapasiteye göre seçilir
+func (as *AIScheduler) AdviseEvictions(nodeName string, limit int) (*EvictionPlan, error) {
+	if limit <= 0 {
+		limit = defaultEvictionLimit
+	}
+	if limit > maxEvictionLimit {
+		limit = maxEvictionLimit
+	}
+
+	if _, err := as.getNode(nodeName); err != nil {
+		return nil, fmt.Errorf("%s: %w", nodeName, types.ErrNodeNotFound)
+	}
+
+	pods, err := as.listPods()
+	if err != nil {
+		return nil, fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+	pdbs, err := as.listPDBs()
+	if err != nil {
+		return nil, fmt.Errorf("PodDisruptionBudget listesi alınamadı: %v", err)
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("küme snapshot'ı alınamadı: %v", err)
+	}
+
+	analysis := as.nodeAnalysis(nodeName)
+	plan := &EvictionPlan{
+		NodeName:       nodeName,
+		GeneratedAt:    as.now(),
+		StabilityScore: analysis.StabilityScore,
+		FailureRate:    analysis.FailureRate,
+		Evict:          []EvictionCandidate{},
+	}
+
+	budgets := make([]*disruptionBudget, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		budget, err := newDisruptionBudget(pdb, pods)
+		if err != nil {
+			return nil, fmt.Errorf("PDB %s/%s çözülemedi: %v", pdb.Namespace, pdb.Name, err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	// Tahliye edilebilir pod'lar kesinti maliyetine göre sıralanır
+	costSettings := as.disruptionCostSettings()
+	costs := make(map[*corev1.Pod]float64)
+	var candidates []*corev1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName != nodeName || pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if reason := evictionBlocker(pod); reason != "" {
+			plan.Blocked = append(plan.Blocked, BlockedEviction{Namespace: pod.Namespace, Pod: pod.Name, Reason: reason})
+			continue
+		}
+		candidates = append(candidates, pod)
+		costs[pod] = disruptionCost(&costSettings, pod, selectingBudgets(budgets, pod), podReady(pod)).Total
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if costs[a] != costs[b] {
+			return costs[a] < costs[b]
+		}
+		if readyA, readyB := podReady(a), podReady(b); readyA != readyB {
+			return !readyA
+		}
+		cpuA, memA := types.PodResourceRequests(a)
+		cpuB, memB := types.PodResourceRequests(b)
+		if cpuA+memA != cpuB+memB {
+			return cpuA+memA < cpuB+memB
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	// Plandaki taşımaların hedef node'larda ayırdığı kapasite
+	planned := make(map[string]*nodeInfo)
+	for _, pod := range candidates {
+		if len(plan.Evict) == limit {
+			break
+		}
+
+		ready := podReady(pod)
+		matched, reason := matchingBudgets(budgets, pod, ready)
+		if reason != "" {
+			plan.Blocked = append(plan.Blocked, BlockedEviction{Namespace: pod.Namespace, Pod: pod.Name, Reason: reason})
+			continue
+		}
+
+		cpu, memory := types.PodResourceRequests(pod)
+		request := podRequest{pod: pod, cpu: cpu, memory: memory}
+		destination, score, rejected := as.evictionDestination(snapshot, &request, nodeName, planned)
+		if destination == nil {
+			plan.Blocked = append(plan.Blocked, BlockedEviction{Namespace: pod.Namespace, Pod: pod.Name, Reason: fmt.Sprintf("başka uygun node yok: %v", rejected)})
+			continue
+		}
+
+		// Seçilen pod budget'ları ve hedef kapasiteyi tüketir, maliyet önceki seçimlerin tükettiği haklarla hesaplanır
+		candidate := EvictionCandidate{
+			Namespace:        pod.Namespace,
+			Pod:              pod.Name,
+			Workload:         types.WorkloadOf(pod),
+			Priority:         podPriority(pod),
+			Ready:            ready,
+			CPU:              cpu,
+			Memory:           memory,
+			DisruptionCost:   disruptionCost(&costSettings, pod, matched, ready),
+			Destination:      destination.node.Name,
+			DestinationScore: score,
+			Warnings:         evictionWarnings(pod),
+		}
+		for _, budget := range matched {
+			if ready {
+				budget.allowed--
+			}
+			candidate.DisruptionBudget = append(candidate.DisruptionBudget, budget.name)
+		}
+		destination.requestedCPU += cpu
+		destination.requestedMemory += memory
+		destination.pods++
+		plan.Evict = append(plan.Evict, candidate)
+	}
+
+	return plan, nil
+}
+
+// evictionDestination pod'un kaynak node dışında sığdığı en yüksek skorlu node'u döndürür, yoksa elenme sebeplerini.
+// planned, plandaki önceki taşımaları içeren node kopyalarıdır ve seçilen node için oluşturulur
+func (as *AIScheduler) evictionDestination(snapshot *clusterSnapshot, request *podRequest, source string, planned map[string]*nodeInfo) (*nodeInfo, float64, map[string]int) {
+	var best *nodeInfo
+	bestScore := math.Inf(-1)
+	rejected := make(map[string]int)
+	for _, info := range snapshot.nodes {
+		if info.node.Name == source {
+			continue
+		}
+		if reserved, ok := planned[info.node.Name]; ok {
+			info = reserved
+		}
+		if reason := filterNode(request, info); reason != "" {
+			rejected[reason]++
+			continue
+		}
+
+		score, _ := as.cachedNodeScore(info.node)
+		if score > bestScore {
+			best, bestScore = info, score
+		}
+	}
+	if best == nil {
+		return nil, 0, rejected
+	}
+
+	if _, ok := planned[best.node.Name]; !ok {
+		reserved := *best
+		planned[best.node.Name] = &reserved
+		best = &reserved
+	}
+	return best, bestScore, nil
+}
+
+// evictionBlocker pod tahliye için uygun değilse sebebini döndürür
+func evictionBlocker(pod *corev1.Pod) string {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "statik pod, kubelet tarafından yönetilir"
+	}
+	if podPriority(pod) >= systemCriticalPriority {
+		return "sistem kritik öncelikli pod"
+	}
+
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return "controller'ı yok, tahliye edilirse yeniden oluşturulmaz"
+	}
+	if controller.Kind == "DaemonSet" {
+		return "DaemonSet pod'u, aynı node'a geri gelir"
+	}
+	return ""
+}
+
+// evictionWarnings tahliyenin operatörün gözden geçirmesi gereken yan etkileri
+func evictionWarnings(pod *corev1.Pod) []string {
+	var warnings []string
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].EmptyDir != nil {
+			warnings = append(warnings, "emptyDir verisi silinir")
+			break
+		}
+	}
+	if controller := metav1.GetControllerOf(pod); controller != nil && controller.Kind == "StatefulSet" {
+		warnings = append(warnings, "StatefulSet pod'u aynı adla yeniden oluşturulur, volume'ları taşınmaz")
+	}
+	return warnings
+}
+
+// matchingBudgets pod'u seçen PDB'leri döndürür; hazır bir pod için PDB'lerden biri kesintiye izin vermiyorsa sebebi döner
+func matchingBudgets(budgets []*disruptionBudget, pod *corev1.Pod, ready bool) ([]*disruptionBudget, string) {
+	matched := selectingBudgets(budgets, pod)
+	for _, budget := range matched {
+		// Hazır olmayan pod uygulamanın sağlıklı pod sayısını düşürmez
+		if ready && budget.allowed <= 0 {
+			return nil, fmt.Sprintf("PDB %s kesintiye izin vermiyor", budget.name)
+		}
+	}
+	return matched, ""
+}
+
+// selectingBudgets pod'u seçen PDB'leri döndürür
+func selectingBudgets(budgets []*disruptionBudget, pod *corev1.Pod) []*disruptionBudget {
+	var matched []*disruptionBudget
+	podLabels := labels.Set(pod.Labels)
+	for _, budget := range budgets {
+		if budget.namespace == pod.Namespace && budget.selector.Matches(podLabels) {
+			matched = append(matched, budget)
+		}
+	}
+	return matched
+}
+
+// newDisruptionBudget PDB'nin kalan kesinti hakkını döndürür. PDB controller'ı durumu güncellediyse status'taki
+// hak kullanılır, güncellemediyse (ör: sentetik küme) seçilen pod'lardan spec'e göre hesaplanır
+func newDisruptionBudget(pdb *policyv1.PodDisruptionBudget, pods []*corev1.Pod) (*disruptionBudget, error) {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	budget := &disruptionBudget{name: pdb.Name, namespace: pdb.Namespace, selector: selector}
+
+	if pdb.Status.ObservedGeneration > 0 && pdb.Status.ObservedGeneration >= pdb.Generation {
+		budget.allowed = int(pdb.Status.DisruptionsAllowed)
+		return budget, nil
+	}
+
+	expected, healthy := 0, 0
+	for _, pod := range pods {
+		if pod.Namespace != pdb.Namespace || pod.DeletionTimestamp != nil || !selector.Matches(labels.Set(pod.Labels)) ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		expected++
+		if podReady(pod) {
+			healthy++
+		}
+	}
+
+	switch {
+	case pdb.Spec.MinAvailable != nil:
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, expected, true)
+		if err != nil {
+			return nil, err
+		}
+		budget.allowed = healthy - minAvailable
+	case pdb.Spec.MaxUnavailable != nil:
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MaxUnavailable, expected, true)
+		if err != nil {
+			return nil, err
+		}
+		budget.allowed = maxUnavailable - (expected - healthy)
+	default:
+		budget.allowed = healthy
+	}
+	return budget, nil
+}
+
+// podReady pod'un Ready koşulu True mu
+func podReady(pod *corev1.Pod) bool {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodReady {
+			return pod.Status.Conditions[i].Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podPriority pod'un önceliği, atanmamışsa 0
+func podPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
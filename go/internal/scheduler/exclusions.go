@@ -0,0 +1,195 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeExclusion filtrede elenen node, onu eleyen kısıt ve ayrıntısı
+type NodeExclusion struct {
+	Node    string `json:"node"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// ExclusionReport pod için filtre hattının node başına sonucu, kube-scheduler'ın "0/N nodes are available"
+// mesajının karşılığı
+type ExclusionReport struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Nodes     int    `json:"nodes"`
+	// Summary "uygun/toplam" ve sebep başına elenen node sayıları
+	Summary  string          `json:"summary"`
+	Feasible []string        `json:"feasible"`
+	Excluded []NodeExclusion `json:"excluded"`
+	Rejected map[string]int  `json:"rejected,omitempty"`
+}
+
+// ExplainExclusions pod'u güncel snapshot'taki her node için filtreler ve elenen node'ları eleyen kısıtla döndürür.
+// Tahmin yapılmaz, karar kaydedilmez
+func (as *AIScheduler) ExplainExclusions(ctx context.Context, podName, namespace string) (*ExclusionReport, error) {
+	if !as.currentConfig().Namespaces.Matches(namespace) {
+		return nil, fmt.Errorf("%s: %w", namespace, ErrNamespaceOutOfScope)
+	}
+	pod, err := as.getPod(ctx, namespace, podName)
+	if err != nil {
+		return nil, fmt.Errorf("pod alınamadı: %w", err)
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	request := as.newPodRequest(pod)
+	report := &ExclusionReport{
+		Namespace: namespace,
+		Pod:       podName,
+		Nodes:     len(snapshot.nodes),
+		Feasible:  make([]string, 0, len(snapshot.nodes)),
+		Excluded:  make([]NodeExclusion, 0),
+	}
+	for _, info := range snapshot.nodes {
+		reason := filterNode(&request, info)
+		if reason == "" {
+			report.Feasible = append(report.Feasible, info.node.Name)
+			continue
+		}
+		if report.Rejected == nil {
+			report.Rejected = make(map[string]int)
+		}
+		report.Rejected[reason]++
+		report.Excluded = append(report.Excluded, NodeExclusion{
+			Node:    info.node.Name,
+			Reason:  reason,
+			Message: exclusionMessage(&request, info, reason),
+		})
+	}
+	sort.Strings(report.Feasible)
+	sort.Slice(report.Excluded, func(i, j int) bool { return report.Excluded[i].Node < report.Excluded[j].Node })
+
+	report.Summary = fmt.Sprintf("%d/%d node uygun", len(report.Feasible), report.Nodes)
+	if len(report.Rejected) > 0 {
+		report.Summary += " (" + rejectedSummary(report.Rejected) + ")"
+	}
+	return report, nil
+}
+
+// exclusionMessage elenme sebebinin node'a özgü ayrıntısını üretir
+func exclusionMessage(request *podRequest, info *nodeInfo, reason string) string {
+	node := info.node
+	own := request.ownNode == node.Name
+	existing := 0
+	if own {
+		existing = 1
+	}
+
+	switch reason {
+	case filterUnschedulable:
+		return "node cordon'lanmış (spec.unschedulable)"
+
+	case filterTaint:
+		for i := range node.Spec.Taints {
+			taint := &node.Spec.Taints[i]
+			if (taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute) &&
+				!toleratesTaint(request.pod.Spec.Tolerations, taint) {
+				return "tolere edilmeyen taint: " + taint.ToString()
+			}
+		}
+
+	case filterNodeSelector:
+		keys := make([]string, 0, len(request.pod.Spec.NodeSelector))
+		for key := range request.pod.Spec.NodeSelector {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if actual, ok := node.Labels[key]; !ok {
+				return fmt.Sprintf("nodeSelector %s=%s eşleşmiyor (node'da label yok)", key, request.pod.Spec.NodeSelector[key])
+			} else if actual != request.pod.Spec.NodeSelector[key] {
+				return fmt.Sprintf("nodeSelector %s=%s eşleşmiyor (node'da: %s)", key, request.pod.Spec.NodeSelector[key], actual)
+			}
+		}
+
+	case filterPlatform:
+		return fmt.Sprintf("imajlar node platformunu desteklemiyor: %s", types.NodePlatform(node).String())
+
+	case filterRuntimeClass:
+		return "pod'un RuntimeClass'ı node'da desteklenmiyor"
+	case filterSeccomp:
+		return "pod'un seccomp profili node'da yok"
+	case filterAppArmor:
+		return "AppArmor veya pod'un AppArmor profili node'da yok"
+	case filterSysctl:
+		return "pod'un güvensiz sysctl'leri node'da izinli değil"
+	case filterHostAccess:
+		return "pod'un host erişimi node'da yasak veya desteklenmiyor"
+
+	case filterHostPort:
+		for _, port := range request.security.hostPorts {
+			if info.hostPorts[port] {
+				return "host portu node'da kullanımda: " + port
+			}
+		}
+
+	case filterTooManyPods:
+		return fmt.Sprintf("node pod sınırında: %d/%d", info.pods-existing, info.allocatablePods)
+
+	case filterWorkloadLimit:
+		guardrails := request.guardrails
+		return fmt.Sprintf("%s iş yükünün node başına pod sınırı dolu: %d/%d", guardrails.workload,
+			info.workloads[guardrails.workload]-existing, guardrails.maxPerWorkload)
+
+	case filterPlacementRate:
+		guardrails := request.guardrails
+		return fmt.Sprintf("node'un yerleşim hızı sınırı dolu: %d/%d", guardrails.placements[node.Name]-existing,
+			guardrails.maxPlacements)
+
+	case filterCooldown:
+		return "node cooldown'da: yerleşen pod'ları art arda kısa sürede başarısız oldu"
+
+	case filterVolumeLimit:
+		driver, attached, added, limit := volumeLimitExceeded(request, info)
+		if driver == "" {
+			return "node'un CSI volume bağlama sınırı dolu"
+		}
+		return volumeLimitMessage(driver, attached, added, limit)
+
+	case filterInsufficientCPU, filterInsufficientMemory:
+		requestedCPU, requestedMemory := info.requestedCPU, info.requestedMemory
+		if own {
+			requestedCPU -= request.cpu
+			requestedMemory -= request.memory
+		}
+		footprint := types.PodResourceFootprint(request.pod)
+		if reason == filterInsufficientCPU {
+			return fmt.Sprintf("yetersiz CPU: istek %.2f%s, ayrılmış %.2f, kapasite %.2f", request.cpu,
+				footprintDetail(footprint.ContainerCPU+footprint.SidecarCPU, footprint.InitCPU, footprint.OverheadCPU, ""),
+				requestedCPU, info.capacityCPU)
+		}
+		return fmt.Sprintf("yetersiz memory: istek %.2f GB%s, ayrılmış %.2f GB, kapasite %.2f GB", request.memory,
+			footprintDetail(footprint.ContainerMemory+footprint.SidecarMemory, footprint.InitMemory, footprint.OverheadMemory, " GB"),
+			requestedMemory, info.capacityMemory)
+	}
+	return reason
+}
+
+// footprintDetail etkin istek container toplamından farklıysa init tepesini ve overhead'i açıklar, değilse boş
+func footprintDetail(containers, init, overhead float64, unit string) string {
+	var parts []string
+	if init > containers {
+		parts = append(parts, fmt.Sprintf("init tepe %.2f%s", init, unit))
+	}
+	if overhead > 0 {
+		parts = append(parts, fmt.Sprintf("overhead %.2f%s", overhead, unit))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,263 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// MaxExtenderPriority kube-scheduler extender'larının verebileceği en yüksek node skoru
+const MaxExtenderPriority int64 = 10
+
+// ErrExtenderNoPod extender isteğinde pod yok
+var ErrExtenderNoPod = errors.New("extender isteğinde pod yok")
+
+// ExtenderArgs kube-scheduler'ın filter ve prioritize isteği (k8s.io/kube-scheduler/extender/v1). nodeCacheCapable
+// açıksa sadece NodeNames, kapalıysa Nodes gönderilir
+type ExtenderArgs struct {
+	Pod       *corev1.Pod      `json:"Pod"`
+	Nodes     *corev1.NodeList `json:"Nodes,omitempty"`
+	NodeNames *[]string        `json:"NodeNames,omitempty"`
+}
+
+// ExtenderFilterResult filter cevabı. FailedNodes'taki node'lar preemption ile uygun hale gelebilir,
+// FailedAndUnresolvableNodes'takiler gelemez
+type ExtenderFilterResult struct {
+	Nodes                      *corev1.NodeList  `json:"Nodes,omitempty"`
+	NodeNames                  *[]string         `json:"NodeNames,omitempty"`
+	FailedNodes                map[string]string `json:"FailedNodes,omitempty"`
+	FailedAndUnresolvableNodes map[string]string `json:"FailedAndUnresolvableNodes,omitempty"`
+	Error                      string            `json:"Error,omitempty"`
+}
+
+// HostPriority prioritize cevabında bir node'un skoru (0-MaxExtenderPriority)
+type HostPriority struct {
+	Host  string `json:"Host"`
+	Score int64  `json:"Score"`
+}
+
+// HostPriorityList prioritize cevabı
+type HostPriorityList []HostPriority
+
+// ExtenderBindingArgs bind isteği (kube-scheduler'da bindVerb tanımlıysa binding extender'a bırakılır)
+type ExtenderBindingArgs struct {
+	PodName      string       `json:"PodName"`
+	PodNamespace string       `json:"PodNamespace"`
+	PodUID       k8stypes.UID `json:"PodUID"`
+	Node         string       `json:"Node"`
+}
+
+// ExtenderBindingResult bind cevabı
+type ExtenderBindingResult struct {
+	Error string `json:"Error,omitempty"`
+}
+
+// nodeNames isteğin aday node adlarını döndürür
+func (args *ExtenderArgs) nodeNames() []string {
+	if args.NodeNames != nil {
+		return *args.NodeNames
+	}
+	if args.Nodes == nil {
+		return nil
+	}
+	names := make([]string, 0, len(args.Nodes.Items))
+	for i := range args.Nodes.Items {
+		names = append(names, args.Nodes.Items[i].Name)
+	}
+	return names
+}
+
+// ExtenderFilter kube-scheduler'ın filtreden geçirdiği node'ları scheduler'ın filtre hattından geçirir. Node nesnesine
+// bağlı kısıtlar (taint, seçici, platform, güvenlik), preemption feature flag'i kapalıysa tüm elemeler preemption ile
+// çözülemez sayılır. Scheduling kapsamı dışındaki
+// pod'lar ve snapshot'ta olmayan node'lar elenmez; karar kube-scheduler'a bırakılır
+func (as *AIScheduler) ExtenderFilter(args *ExtenderArgs) *ExtenderFilterResult {
+	if args.Pod == nil {
+		return &ExtenderFilterResult{Error: ErrExtenderNoPod.Error()}
+	}
+	pod := args.Pod
+	names := args.nodeNames()
+	passed := make(map[string]bool, len(names))
+
+	result := &ExtenderFilterResult{
+		FailedNodes:                make(map[string]string),
+		FailedAndUnresolvableNodes: make(map[string]string),
+	}
+	if !as.InScope(pod) {
+		for _, name := range names {
+			passed[name] = true
+		}
+		return extenderFilterResult(args, result, passed)
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		result.Error = fmt.Sprintf("snapshot alınamadı: %v", err)
+		return result
+	}
+
+	request := as.newPodRequest(pod)
+	for _, name := range names {
+		info, ok := snapshot.node(name)
+		if !ok {
+			logrus.Debugf("Extender filter: %s snapshot'ta yok, elenmedi", name)
+			passed[name] = true
+			continue
+		}
+		switch verdict := as.filterNodeVerdict(&request, info); {
+		case verdict.Reason == "":
+			passed[name] = true
+		case verdict.Unresolvable:
+			result.FailedAndUnresolvableNodes[name] = verdict.Message
+		default:
+			result.FailedNodes[name] = verdict.Message
+		}
+	}
+	return extenderFilterResult(args, result, passed)
+}
+
+// extenderFilterResult geçen node'ları isteğin biçiminde (NodeNames veya Nodes) sonuca yazar
+func extenderFilterResult(args *ExtenderArgs, result *ExtenderFilterResult, passed map[string]bool) *ExtenderFilterResult {
+	if args.NodeNames != nil {
+		names := make([]string, 0, len(passed))
+		for _, name := range *args.NodeNames {
+			if passed[name] {
+				names = append(names, name)
+			}
+		}
+		result.NodeNames = &names
+		return result
+	}
+
+	nodes := &corev1.NodeList{}
+	if args.Nodes != nil {
+		for i := range args.Nodes.Items {
+			if passed[args.Nodes.Items[i].Name] {
+				nodes.Items = append(nodes.Items, args.Nodes.Items[i])
+			}
+		}
+	}
+	result.Nodes = nodes
+	return result
+}
+
+// ExtenderPrioritize node'ları heuristik skorla (AI harmanlaması açıksa en iyi adaylar AI analiziyle harmanlanarak)
+// skorlar ve skorları en iyi node MaxExtenderPriority, en kötüsü 0 olacak şekilde ölçekler. Snapshot'ta olmayan
+// node'lar, kapsam dışındaki pod'lar ve sıralı seçim seviyesinde tüm node'lar 0 alır (sıralama kube-scheduler'a kalır).
+// Karar kaydedilmez, pod assume edilmez
+func (as *AIScheduler) ExtenderPrioritize(ctx context.Context, args *ExtenderArgs) (HostPriorityList, error) {
+	if args.Pod == nil {
+		return nil, ErrExtenderNoPod
+	}
+	pod := args.Pod
+	names := args.nodeNames()
+	priorities := make(HostPriorityList, 0, len(names))
+	for _, name := range names {
+		priorities = append(priorities, HostPriority{Host: name})
+	}
+
+	now := as.now()
+	degradation := as.evaluateTier(now)
+	if !as.InScope(pod) || degradation.Tier == TierRoundRobin {
+		return priorities, nil
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	request := as.newPodRequest(pod)
+	nodes := make([]*nodeInfo, 0, len(names))
+	for _, name := range names {
+		if info, ok := snapshot.node(name); ok {
+			nodes = append(nodes, info)
+		}
+	}
+	candidates := as.scoreCandidates(pod, snapshot, &request, nodes)
+	if len(candidates) == 0 {
+		return priorities, nil
+	}
+	if !as.HeuristicOnly() && as.featureGate.Enabled(features.AIBlending) {
+		if live := as.liveAI(&degradation, now); live || degradation.Tier == TierCachedAI {
+			as.blendExtenderScores(ctx, candidates, live)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	low, high := candidates[0].Score, candidates[0].Score
+	scores := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		low, high = math.Min(low, candidate.Score), math.Max(high, candidate.Score)
+		scores[candidate.NodeName] = candidate.Score
+	}
+	for i := range priorities {
+		score, ok := scores[priorities[i].Host]
+		switch {
+		case !ok:
+		case high == low:
+			priorities[i].Score = MaxExtenderPriority
+		default:
+			priorities[i].Score = int64(math.Round((score - low) / (high - low) * float64(MaxExtenderPriority)))
+		}
+	}
+	return priorities, nil
+}
+
+// blendExtenderScores en iyi heuristik adayların skorlarını AI analiziyle harmanlanmış final skorla değiştirir.
+// Canlı analizler scheduler.ai_budget içinde tamamlanmazsa kalan adaylar heuristik skorda kalır
+func (as *AIScheduler) blendExtenderScores(ctx context.Context, candidates []NodeScore, live bool) {
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+
+	budget := as.currentConfig().AIBudget
+	aiCtx := ctx
+	if live && budget > 0 {
+		var cancel context.CancelFunc
+		aiCtx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+	for i := 0; i < len(candidates) && i < aiBlendCandidates; i++ {
+		finalScore, reason := as.makeFinalDecision(aiCtx, candidates[i].NodeName, candidates[i].Score, live)
+		if aiCtx.Err() != nil {
+			if ctx.Err() == nil {
+				as.aiSkipped.Add(1)
+				logrus.Debugf("Extender prioritize: AI analizi bütçesinde dönmedi, %s heuristik skorda kaldı", candidates[i].NodeName)
+			}
+			return
+		}
+		candidates[i].Score, candidates[i].Reason = finalScore, reason
+	}
+}
+
+// ExtenderBind pod'u kube-scheduler'ın seçtiği node'a bağlar. Pod UID'i istekle eşleşmiyorsa (pod silinip aynı adla
+// yeniden oluşturulduysa) veya namespace scheduling kapsamı dışındaysa bağlanmaz
+func (as *AIScheduler) ExtenderBind(ctx context.Context, args *ExtenderBindingArgs) error {
+	if !as.currentConfig().Namespaces.Matches(args.PodNamespace) {
+		return fmt.Errorf("%s/%s: %w", args.PodNamespace, args.PodName, ErrNamespaceOutOfScope)
+	}
+	pod, err := as.getPod(ctx, args.PodNamespace, args.PodName)
+	if err != nil {
+		return fmt.Errorf("pod alınamadı: %w", err)
+	}
+	if args.PodUID != "" && pod.UID != "" && pod.UID != args.PodUID {
+		return fmt.Errorf("%s/%s UID'i değişti (istek: %s, küme: %s)", args.PodNamespace, args.PodName, args.PodUID, pod.UID)
+	}
+
+	if err := as.bind(ctx, pod, args.Node); err != nil {
+		as.binding.failures.Add(1)
+		as.reportError(types.ErrorComponentBinding, err)
+		return err
+	}
+	as.binding.bound.Add(1)
+	logrus.Infof("Extender bind: %s/%s %s node'una bağlandı", args.PodNamespace, args.PodName, args.Node)
+	return nil
+}
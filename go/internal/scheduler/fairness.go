@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TeamUsageStatus takımın tüketimi ve konfigüre edilen payı
+type TeamUsageStatus struct {
+	Team          string   `json:"team"`
+	Namespaces    []string `json:"namespaces"`
+	Pods          int      `json:"pods"`
+	CPURequest    float64  `json:"cpu_request"`
+	MemoryRequest float64  `json:"memory_request_gb"`
+	Share         float64  `json:"share"`
+	AllowedShare  float64  `json:"allowed_share"`
+	OverShare     bool     `json:"over_share"`
+}
+
+// teamOf namespace'in ait olduğu takımı döndürür
+func teamOf(fairness *types.FairnessConfig, namespace string) string {
+	for team, namespaces := range fairness.Teams {
+		for _, ns := range namespaces {
+			if ns == namespace {
+				return team
+			}
+		}
+	}
+	return namespace
+}
+
+// allowedShare takımın konfigüre edilen payını döndürür
+func allowedShare(fairness *types.FairnessConfig, team string) float64 {
+	if share, ok := fairness.Shares[team]; ok {
+		return share
+	}
+	return fairness.DefaultShare
+}
+
+// TeamUsage takım bazlı tüketimi payları ile birlikte döndürür
+func (as *AIScheduler) TeamUsage() []TeamUsageStatus {
+	cfg := as.currentConfig()
+	tracker := as.collector.GetNamespaceUsage()
+	clusterCPU, clusterMemory := tracker.ClusterCapacity()
+
+	teams := make(map[string]*TeamUsageStatus)
+	for _, usage := range tracker.Snapshot() {
+		team := teamOf(&cfg.Fairness, usage.Namespace)
+		status, ok := teams[team]
+		if !ok {
+			status = &TeamUsageStatus{Team: team, AllowedShare: allowedShare(&cfg.Fairness, team)}
+			teams[team] = status
+		}
+		status.Namespaces = append(status.Namespaces, usage.Namespace)
+		status.Pods += usage.Pods
+		status.CPURequest += usage.CPURequest
+		status.MemoryRequest += usage.MemoryRequest
+	}
+
+	result := make([]TeamUsageStatus, 0, len(teams))
+	for _, status := range teams {
+		// Pay, CPU ve memory oranlarının büyüğüdür (dominant resource)
+		if clusterCPU > 0 {
+			status.Share = status.CPURequest / clusterCPU
+		}
+		if clusterMemory > 0 && status.MemoryRequest/clusterMemory > status.Share {
+			status.Share = status.MemoryRequest / clusterMemory
+		}
+		status.OverShare = status.AllowedShare > 0 && status.Share > status.AllowedShare
+		result = append(result, *status)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Team < result[j].Team })
+	return result
+}
+
+// overShareTeam pod'un takımı payını aşmışsa takım durumunu döndürür, aksi halde nil
+func (as *AIScheduler) overShareTeam(pod *corev1.Pod) *TeamUsageStatus {
+	cfg := as.currentConfig()
+	if !cfg.Fairness.Enabled || pod == nil {
+		return nil
+	}
+
+	team := teamOf(&cfg.Fairness, pod.Namespace)
+	for _, usage := range as.TeamUsage() {
+		if usage.Team == team && usage.OverShare {
+			return &usage
+		}
+	}
+	return nil
+}
+
+// fairnessPenalty payını aşan takımın pod'u çekişmeli node'a yerleşecekse ceza döndürür
+func (as *AIScheduler) fairnessPenalty(team *TeamUsageStatus, node *corev1.Node) (float64, string) {
+	if team == nil {
+		return 0, ""
+	}
+	cfg := as.currentConfig()
+
+	// Sadece çekişmeli node'larda ceza uygulanır
+	cpuRatio, memRatio := as.nodeUtilization(node)
+	if cpuRatio < cfg.Fairness.ContentionThreshold && memRatio < cfg.Fairness.ContentionThreshold {
+		return 0, ""
+	}
+
+	excess := (team.Share - team.AllowedShare) / team.AllowedShare
+	if excess > 1 {
+		excess = 1
+	}
+	penalty := cfg.Fairness.Weight * excess
+
+	return penalty, fmt.Sprintf("Fairness cezası: %.1f (takım %s payı %.2f > %.2f)", penalty, team.Team, team.Share, team.AllowedShare)
+}
+
+// nodeUtilization node'un CPU ve memory kullanım oranlarını (0-1) döndürür
+func (as *AIScheduler) nodeUtilization(node *corev1.Node) (float64, float64) {
+	cpuUsage, memUsage, err := as.nodeUsage(node.Name)
+	if err != nil {
+		return 0, 0
+	}
+
+	var cpuRatio, memRatio float64
+	if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok && !cpu.IsZero() {
+		cpuRatio = cpuUsage / (float64(cpu.MilliValue()) / 1000.0)
+	}
+	if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok && !memory.IsZero() {
+		memRatio = memUsage / (float64(memory.Value()) / (1024 * 1024 * 1024))
+	}
+
+	return cpuRatio, memRatio
+}
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Filtreleme aşamasında node'un elenme sebepleri
+const (
+	filterUnschedulable      = "unschedulable"
+	filterTaint              = "taint"
+	filterNodeSelector       = "node_selector"
+	filterPlatform           = "platform"
+	filterRuntimeClass       = "runtime_class"
+	filterSeccomp            = "seccomp"
+	filterAppArmor           = "apparmor"
+	filterSysctl             = "sysctl"
+	filterHostAccess         = "host_access"
+	filterHostPort           = "host_port"
+	filterTooManyPods        = "too_many_pods"
+	filterWorkloadLimit      = "workload_limit"
+	filterPlacementRate      = "placement_rate"
+	filterCooldown           = "cooldown"
+	filterVolumeLimit        = "volume_limit"
+	filterInsufficientCPU    = "insufficient_cpu"
+	filterInsufficientMemory = "insufficient_memory"
+	// Toplu yerleşimde batch'in diğer pod'larıyla birlikte değerlendirilen kısıtlar
+	filterPodAntiAffinity = "pod_anti_affinity"
+	filterTopologySpread  = "topology_spread"
+)
+
+// podRequest filtrelenen pod'un istekleri ve halihazırda sayıldığı node
+type podRequest struct {
+	pod     *corev1.Pod
+	cpu     float64
+	memory  float64
+	ownNode string // Pod'un istekleri bu node'un toplamına zaten dahil (bağlı veya assume edilmiş)
+	// platforms pod'un çalışabildiği "os/arch" kümesi, nil ise kısıt yok
+	platforms map[string]bool
+	// security pod'un node'dan beklediği güvenlik yetenekleri, nil ise kısıt yok
+	security *podSecurity
+	// guardrails operatörün node başına yerleşim sınırları, nil ise sadece allocatable.pods uygulanır
+	guardrails *podGuardrails
+	// volumes pod'un node'a bağlanacak CSI volume'ları, bağlama sınırı kapalıysa nil
+	volumes []attachVolume
+}
+
+// newPodRequest filtreleme için pod isteğini hazırlar
+func (as *AIScheduler) newPodRequest(pod *corev1.Pod) podRequest {
+	cpu, memory := types.PodResourceRequests(pod)
+	ownNode := pod.Spec.NodeName
+	if ownNode == "" {
+		ownNode = as.assumedNode(pod.Namespace, pod.Name)
+	}
+	return podRequest{pod: pod, cpu: cpu, memory: memory, ownNode: ownNode, platforms: as.podPlatforms(pod),
+		security: as.podSecurity(pod), guardrails: as.podGuardrails(pod), volumes: as.podAttachVolumes(pod)}
+}
+
+// filterNode pod node'a yerleşebiliyorsa boş, aksi halde elenme sebebini döndürür
+func filterNode(request *podRequest, info *nodeInfo) string {
+	if reason := filterNodeStatic(request, info.node); reason != "" {
+		return reason
+	}
+	return filterNodeLoad(request, info)
+}
+
+// filterNodeStatic pod'un sadece node nesnesine bağlı kısıtlarını (taint, seçici, platform, güvenlik) kontrol eder.
+// Sonuç aynı şablondan oluşturulan pod'lar için aynıdır ve node değişene kadar yeniden kullanılabilir
+func filterNodeStatic(request *podRequest, node *corev1.Node) string {
+	if node.Spec.Unschedulable {
+		return filterUnschedulable
+	}
+
+	// NoSchedule ve NoExecute taint'leri tolere edilmelidir
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		if !toleratesTaint(request.pod.Spec.Tolerations, taint) {
+			return filterTaint
+		}
+	}
+
+	for key, value := range request.pod.Spec.NodeSelector {
+		if node.Labels[key] != value {
+			return filterNodeSelector
+		}
+	}
+
+	// İmajların desteklemediği işletim sistemi veya mimari (arm64/amd64, linux/windows)
+	if platformMismatch(request.platforms, node) {
+		return filterPlatform
+	}
+
+	// Kubelet admission'ın reddedeceği RuntimeClass, seccomp/AppArmor, sysctl ve host erişimi gereksinimleri
+	return securityMismatch(request.security, node)
+}
+
+// filterNodeLoad pod'un node'a yerleşmiş pod'lara bağlı kısıtlarını (host portları, pod sayısı, yerleşim sınırları,
+// kapasite) kontrol eder
+func filterNodeLoad(request *podRequest, info *nodeInfo) string {
+	node := info.node
+	if reason := hostPortConflict(request.security, info, request.ownNode == node.Name); reason != "" {
+		return reason
+	}
+
+	// Pod'un kendi istekleri node toplamında zaten varsa tekrar sayılmaz
+	requestedCPU, requestedMemory := info.requestedCPU, info.requestedMemory
+	if request.ownNode == node.Name {
+		requestedCPU -= request.cpu
+		requestedMemory -= request.memory
+	}
+	if reason := guardrailViolation(request.guardrails, info, request.ownNode == node.Name); reason != "" {
+		return reason
+	}
+	if driver, _, _, _ := volumeLimitExceeded(request, info); driver != "" {
+		return filterVolumeLimit
+	}
+	// Kapasite, node havuzunun overcommit oranı uygulanmış allocatable'ıdır
+	if info.allocatableCPU > 0 && requestedCPU+request.cpu > info.capacityCPU {
+		return filterInsufficientCPU
+	}
+	if info.allocatableMemory > 0 && requestedMemory+request.memory > info.capacityMemory {
+		return filterInsufficientMemory
+	}
+
+	return ""
+}
+
+// toleratesTaint toleration listesinden biri taint'i tolere ediyorsa true döner
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterNodes snapshot'tan pod'un yerleşebileceği node'ları ve sebebe göre elenen node sayılarını döndürür
+func filterNodes(snapshot *clusterSnapshot, request *podRequest) ([]*nodeInfo, map[string]int) {
+	feasible := make([]*nodeInfo, 0, len(snapshot.nodes))
+	var rejected map[string]int
+	for _, info := range snapshot.nodes {
+		if reason := filterNode(request, info); reason != "" {
+			if rejected == nil {
+				rejected = make(map[string]int)
+			}
+			rejected[reason]++
+			continue
+		}
+		feasible = append(feasible, info)
+	}
+	return feasible, rejected
+}
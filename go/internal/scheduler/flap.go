@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// Flap cezasının varsayılanları
+const (
+	defaultFlapHalfLife  = 15 * time.Minute
+	defaultFlapThreshold = 4.0
+)
+
+// flapSettings varsayılanları uygulanmış flap cezası konfigürasyonunu döndürür
+func (as *AIScheduler) flapSettings() types.FlapDampeningConfig {
+	cfg := as.currentConfig().FlapDampening
+	if cfg.HalfLife <= 0 {
+		cfg.HalfLife = defaultFlapHalfLife
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultFlapThreshold
+	}
+	return cfg
+}
+
+// flapState node'un at anındaki flap durumunu döndürür, geçişler tutulmuyorsa veya node gözlenmediyse false
+func (as *AIScheduler) flapState(nodeName string, at time.Time) (types.NodeFlapState, bool) {
+	flaps := as.collector.GetNodeFlaps()
+	if flaps == nil {
+		return types.NodeFlapState{}, false
+	}
+	return flaps.State(nodeName, at, as.flapSettings().HalfLife)
+}
+
+// flapPenaltyAt node at anına yakın NotReady↔Ready gidip geldiyse cezayı ve sönümlenmiş geçiş sayısını döndürür.
+// Ceza sönümlenmiş geçiş sayısıyla orantılıdır ve Threshold'da tam ağırlığa ulaşır
+func (as *AIScheduler) flapPenaltyAt(nodeName string, at time.Time) (float64, float64) {
+	cfg := as.flapSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 {
+		return 0, 0
+	}
+
+	state, ok := as.flapState(nodeName, at)
+	if !ok || state.Figure <= 0 {
+		return 0, 0
+	}
+
+	ratio := state.Figure / cfg.Threshold
+	if ratio > 1 {
+		ratio = 1
+	}
+	return cfg.Weight * ratio, state.Figure
+}
@@ -0,0 +1,392 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/forecast"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Node kullanım tahmininin sınırları ve varsayılanları
+const (
+	defaultForecastHorizon      = 6 * time.Hour
+	defaultForecastSeason       = 24 * time.Hour
+	defaultForecastHotThreshold = 0.8
+	maxForecastHorizon          = 7 * 24 * time.Hour
+	minForecastSamples          = 2
+)
+
+// Tahmin kaynakları
+const (
+	ForecastSourceAI          = "ai"
+	ForecastSourceHoltWinters = "holt_winters"
+)
+
+var (
+	// ErrInsufficientHistory tahmin için yeterli kullanım geçmişi yok
+	ErrInsufficientHistory = errors.New("tahmin için yeterli kullanım geçmişi yok")
+	// ErrForecastHorizon tahmin süresi desteklenen aralıkta değil
+	ErrForecastHorizon = errors.New("tahmin süresi desteklenen aralıkta değil")
+)
+
+// ForecastPoint tahmin edilen bir dilimdeki kullanım oranları (0-1)
+type ForecastPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	CPU       float64   `json:"cpu_utilization"`
+	Memory    float64   `json:"memory_utilization"`
+}
+
+// NodeForecast node'un horizon boyunca tahmini CPU/memory kullanımı
+type NodeForecast struct {
+	NodeName   string          `json:"node_name"`
+	Source     string          `json:"source"` // ai veya holt_winters
+	Horizon    string          `json:"horizon"`
+	Resolution string          `json:"resolution"`
+	Samples    int             `json:"samples"` // Tahminin dayandığı geçmiş dilim sayısı
+	PeakCPU    float64         `json:"peak_cpu_utilization"`
+	PeakMemory float64         `json:"peak_memory_utilization"`
+	Points     []ForecastPoint `json:"points"`
+}
+
+// utilizationSeries node'un tamamlanmış geçmiş dilimlerinden eşit aralıklı kullanım oranı serisi
+type utilizationSeries struct {
+	last       time.Time // Son tamamlanmış dilim
+	resolution time.Duration
+	cpu        []float64
+	memory     []float64
+}
+
+// cachedForecast yeni dilim tamamlanana kadar yeniden kullanılan yerel tahmin
+type cachedForecast struct {
+	last     time.Time
+	steps    int
+	forecast *NodeForecast
+}
+
+// forecastCache skorlamada node başına Holt-Winters tahminini tekrar hesaplamamak için cache
+type forecastCache struct {
+	mutex   sync.Mutex
+	entries map[string]cachedForecast
+}
+
+// get son tamamlanmış dilim ve adım sayısı aynıysa cache'lenmiş tahmini döndürür
+func (c *forecastCache) get(nodeName string, last time.Time, steps int) (*NodeForecast, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[nodeName]
+	if !ok || entry.steps != steps || !entry.last.Equal(last) {
+		return nil, false
+	}
+	return entry.forecast, true
+}
+
+// put tahmini cache'e yazar
+func (c *forecastCache) put(nodeName string, entry cachedForecast) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]cachedForecast)
+	}
+	c.entries[nodeName] = entry
+}
+
+// clear tüm tahminleri siler
+func (c *forecastCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = nil
+}
+
+// forecastSettings varsayılanları uygulanmış tahmin konfigürasyonunu döndürür
+func (as *AIScheduler) forecastSettings() types.ForecastConfig {
+	cfg := as.currentConfig().Forecast
+	if cfg.Horizon <= 0 {
+		cfg.Horizon = defaultForecastHorizon
+	}
+	if cfg.Season <= 0 {
+		cfg.Season = defaultForecastSeason
+	}
+	if cfg.HotThreshold <= 0 || cfg.HotThreshold >= 1 {
+		cfg.HotThreshold = defaultForecastHotThreshold
+	}
+	return cfg
+}
+
+// ForecastNode node'un horizon boyunca CPU/memory kullanım tahminini döndürür, horizon 0 ise konfigürasyondaki değer kullanılır.
+// use_ai açıksa tahmin önce AI servisinden istenir, hata olursa Holt-Winters kullanılır
+func (as *AIScheduler) ForecastNode(ctx context.Context, nodeName string, horizon time.Duration) (*NodeForecast, error) {
+	cfg := as.forecastSettings()
+	if horizon == 0 {
+		horizon = cfg.Horizon
+	}
+	if horizon < 0 || horizon > maxForecastHorizon {
+		return nil, fmt.Errorf("%s: %w", horizon, ErrForecastHorizon)
+	}
+
+	node, err := as.getNode(nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", nodeName, types.ErrNodeNotFound)
+	}
+
+	series, err := as.nodeUtilizationSeries(node, as.now())
+	if err != nil {
+		return nil, err
+	}
+	steps := forecastSteps(horizon, series.resolution)
+
+	if cfg.UseAI && !as.HeuristicOnly() {
+		result, err := as.aiForecast(ctx, node.Name, series, steps)
+		if err == nil {
+			return result, nil
+		}
+		// İstek iptal edildiyse yerel tahmine düşülmez
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		as.reportError(types.ErrorComponentAI, err)
+		logrus.Warnf("Node %s için AI tahmini alınamadı, Holt-Winters kullanılacak: %v", node.Name, err)
+	}
+	return as.localForecast(node.Name, series, steps, &cfg), nil
+}
+
+// forecastSteps horizon'u kapsayan dilim sayısını döndürür
+func forecastSteps(horizon, resolution time.Duration) int {
+	steps := int((horizon + resolution - 1) / resolution)
+	if steps < 1 {
+		steps = 1
+	}
+	return steps
+}
+
+// nodeUtilizationSeries node kullanım geçmişinden now anına kadar tamamlanmış dilimlerin kullanım oranlarını çıkarır.
+// Eksik dilimler (ör: yeniden başlatma) bir önceki değerle doldurulur
+func (as *AIScheduler) nodeUtilizationSeries(node *corev1.Node, now time.Time) (*utilizationSeries, error) {
+	history := as.collector.GetNodeHistory()
+	if history == nil {
+		return nil, fmt.Errorf("%s: %w", node.Name, ErrInsufficientHistory)
+	}
+
+	cpuCapacity, memCapacity := nodeCapacity(node)
+	if cpuCapacity <= 0 && memCapacity <= 0 {
+		return nil, fmt.Errorf("%s: %w", node.Name, ErrInsufficientHistory)
+	}
+
+	resolution := history.Resolution()
+	samples := history.Samples(node.Name, time.Time{})
+
+	// Devam eden dilim henüz tamamlanmadığı için seriye alınmaz
+	current := now.Truncate(resolution)
+	for len(samples) > 0 && !samples[len(samples)-1].Timestamp.Before(current) {
+		samples = samples[:len(samples)-1]
+	}
+	if len(samples) < minForecastSamples {
+		return nil, fmt.Errorf("%s: %w", node.Name, ErrInsufficientHistory)
+	}
+
+	first := samples[0].Timestamp
+	last := samples[len(samples)-1].Timestamp
+	length := int(last.Sub(first)/resolution) + 1
+	series := &utilizationSeries{
+		last:       last,
+		resolution: resolution,
+		cpu:        make([]float64, length),
+		memory:     make([]float64, length),
+	}
+
+	next := 0
+	for _, sample := range samples {
+		i := int(sample.Timestamp.Sub(first) / resolution)
+		for ; next < i; next++ {
+			series.cpu[next] = series.cpu[next-1]
+			series.memory[next] = series.memory[next-1]
+		}
+		series.cpu[i] = ratio(sample.CPU, cpuCapacity)
+		series.memory[i] = ratio(sample.Memory, memCapacity)
+		next = i + 1
+	}
+	return series, nil
+}
+
+// localForecast Holt-Winters ile tahmin yapar, sonuç yeni dilim tamamlanana kadar cache'lenir
+func (as *AIScheduler) localForecast(nodeName string, series *utilizationSeries, steps int, cfg *types.ForecastConfig) *NodeForecast {
+	if cached, ok := as.forecasts.get(nodeName, series.last, steps); ok {
+		return cached
+	}
+
+	result := holtWintersForecast(nodeName, series, steps, cfg)
+	as.forecasts.put(nodeName, cachedForecast{last: series.last, steps: steps, forecast: result})
+	return result
+}
+
+// holtWintersForecast seriyi Holt-Winters ile tahmin eder (cache'lenmez)
+func holtWintersForecast(nodeName string, series *utilizationSeries, steps int, cfg *types.ForecastConfig) *NodeForecast {
+	model := forecast.HoltWinters{
+		Alpha:  cfg.Alpha,
+		Beta:   cfg.Beta,
+		Gamma:  cfg.Gamma,
+		Season: int(cfg.Season / series.resolution),
+	}
+	return newNodeForecast(nodeName, ForecastSourceHoltWinters, series, model.Forecast(series.cpu, steps), model.Forecast(series.memory, steps))
+}
+
+// localNodeForecast node'un yerel Holt-Winters tahminini döndürür (AI'ya gidilmez)
+func (as *AIScheduler) localNodeForecast(node *corev1.Node, horizon time.Duration, cfg *types.ForecastConfig) (*NodeForecast, error) {
+	series, err := as.nodeUtilizationSeries(node, as.now())
+	if err != nil {
+		return nil, err
+	}
+	return as.localForecast(node.Name, series, forecastSteps(horizon, series.resolution), cfg), nil
+}
+
+// aiForecast tahmini AI servisinin /forecast endpoint'inden ister
+func (as *AIScheduler) aiForecast(ctx context.Context, nodeName string, series *utilizationSeries, steps int) (*NodeForecast, error) {
+	requestBody := map[string]interface{}{
+		"node_name":          nodeName,
+		"resolution_seconds": series.resolution.Seconds(),
+		"steps":              steps,
+		"cpu_utilization":    series.cpu,
+		"memory_utilization": series.memory,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("request JSON'a çevrilemedi: %v", err)
+	}
+
+	resp, err := as.postToAI(ctx, "/forecast", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("AI API'ye istek gönderilemedi: %v: %w", err, types.ErrAIUnavailable)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AI API hata döndürdü: %d: %w", resp.StatusCode, types.ErrAIUnavailable)
+	}
+
+	var aiResponse struct {
+		CPU    []float64 `json:"cpu_utilization"`
+		Memory []float64 `json:"memory_utilization"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&aiResponse); err != nil {
+		return nil, fmt.Errorf("AI response parse edilemedi: %v: %w", err, types.ErrAIUnavailable)
+	}
+	if len(aiResponse.CPU) != steps || len(aiResponse.Memory) != steps {
+		return nil, fmt.Errorf("AI tahmini %d adım yerine %d/%d adım döndürdü: %w", steps, len(aiResponse.CPU),
+			len(aiResponse.Memory), types.ErrAIUnavailable)
+	}
+
+	return newNodeForecast(nodeName, ForecastSourceAI, series, aiResponse.CPU, aiResponse.Memory), nil
+}
+
+// newNodeForecast tahmin değerlerinden dilim zamanlı sonucu oluşturur, oranlar 0-1 aralığına sınırlanır
+func newNodeForecast(nodeName, source string, series *utilizationSeries, cpu, memory []float64) *NodeForecast {
+	result := &NodeForecast{
+		NodeName:   nodeName,
+		Source:     source,
+		Horizon:    (time.Duration(len(cpu)) * series.resolution).String(),
+		Resolution: series.resolution.String(),
+		Samples:    len(series.cpu),
+		Points:     make([]ForecastPoint, len(cpu)),
+	}
+
+	for i := range cpu {
+		point := ForecastPoint{
+			Timestamp: series.last.Add(time.Duration(i+1) * series.resolution),
+			CPU:       clampRatio(cpu[i]),
+			Memory:    clampRatio(memory[i]),
+		}
+		result.Points[i] = point
+		if point.CPU > result.PeakCPU {
+			result.PeakCPU = point.CPU
+		}
+		if point.Memory > result.PeakMemory {
+			result.PeakMemory = point.Memory
+		}
+	}
+	return result
+}
+
+// forecastPenalty tahmini kullanımı horizon içinde yoğunluk eşiğini aşan node için skor cezasını döndürür.
+// Skorlama istek başına AI'ya gitmemek için sadece yerel Holt-Winters tahminini kullanır
+func (as *AIScheduler) forecastPenalty(node *corev1.Node) (float64, float64) {
+	cfg := as.forecastSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 {
+		return 0, 0
+	}
+
+	result, err := as.localNodeForecast(node, cfg.Horizon, &cfg)
+	if err != nil {
+		return 0, 0
+	}
+	return penaltyFor(result, &cfg)
+}
+
+// forecastPenaltyAt forecastPenalty'nin geçmişteki bir an için karşılığıdır, seri at anında kesilir ve sonuç cache'lenmez
+func (as *AIScheduler) forecastPenaltyAt(node *corev1.Node, at time.Time) (float64, float64) {
+	cfg := as.forecastSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 {
+		return 0, 0
+	}
+
+	series, err := as.nodeUtilizationSeries(node, at)
+	if err != nil {
+		return 0, 0
+	}
+	return penaltyFor(holtWintersForecast(node.Name, series, forecastSteps(cfg.Horizon, series.resolution), &cfg), &cfg)
+}
+
+// penaltyFor tahminin tepe kullanımına göre ceza ve tepe değerini döndürür
+func penaltyFor(result *NodeForecast, cfg *types.ForecastConfig) (float64, float64) {
+	peak := result.PeakCPU
+	if result.PeakMemory > peak {
+		peak = result.PeakMemory
+	}
+	if peak <= cfg.HotThreshold {
+		return 0, peak
+	}
+	return cfg.Weight * (peak - cfg.HotThreshold) / (1 - cfg.HotThreshold), peak
+}
+
+// nodeCapacity node'un ayrılabilir CPU (core) ve memory (GB) kapasitesini döndürür
+func nodeCapacity(node *corev1.Node) (float64, float64) {
+	var cpuCapacity, memCapacity float64
+	if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+		cpuCapacity = float64(cpu.MilliValue()) / 1000.0
+	}
+	if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+		memCapacity = float64(memory.Value()) / (1024 * 1024 * 1024) // GB
+	}
+	return cpuCapacity, memCapacity
+}
+
+// ratio kullanımın kapasiteye oranını döndürür
+func ratio(usage, capacity float64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return usage / capacity
+}
+
+// clampRatio oranı 0-1 aralığına sınırlar
+func clampRatio(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	if value > 1 {
+		return 1
+	}
+	return value
+}
@@ -0,0 +1,235 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Kaynak parçalanmasının varsayılanları
+const (
+	defaultMaxShapes = 20
+	shapePrecision   = 100 // Şekiller 0.01 core / 0.01 GB'a yuvarlanarak gruplanır
+)
+
+// podShape pod'un kaynak isteği şekli
+type podShape struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory_gb"`
+	Count  int     `json:"count,omitempty"` // Bu şekildeki bekleyen pod sayısı, referans şekillerde 0
+}
+
+// shapeCounter bekleyen pod'ların şekillerini sayar
+type shapeCounter struct {
+	counts map[podShape]int
+}
+
+// add şekli sayar, isteği olmayan (best-effort) pod'lar kapasite kullanmadığı için sayılmaz
+func (c *shapeCounter) add(cpu, memory float64) {
+	shape := podShape{CPU: math.Round(cpu*shapePrecision) / shapePrecision, Memory: math.Round(memory*shapePrecision) / shapePrecision}
+	if shape.CPU <= 0 && shape.Memory <= 0 {
+		return
+	}
+	if c.counts == nil {
+		c.counts = make(map[podShape]int)
+	}
+	c.counts[shape]++
+}
+
+// shapes şekilleri en sık görülen başta olacak şekilde döndürür
+func (c *shapeCounter) shapes() []podShape {
+	shapes := make([]podShape, 0, len(c.counts))
+	for shape, count := range c.counts {
+		shape.Count = count
+		shapes = append(shapes, shape)
+	}
+	sort.Slice(shapes, func(i, j int) bool {
+		if shapes[i].Count != shapes[j].Count {
+			return shapes[i].Count > shapes[j].Count
+		}
+		if shapes[i].CPU != shapes[j].CPU {
+			return shapes[i].CPU < shapes[j].CPU
+		}
+		return shapes[i].Memory < shapes[j].Memory
+	})
+	return shapes
+}
+
+// NodeFragmentation node'un boş ve hiçbir pod şekline sığmayan kapasitesi
+type NodeFragmentation struct {
+	Node           string  `json:"node"`
+	FreeCPU        float64 `json:"free_cpu"`
+	FreeMemory     float64 `json:"free_memory_gb"`
+	StrandedCPU    float64 `json:"stranded_cpu"`
+	StrandedMemory float64 `json:"stranded_memory_gb"`
+	Fragmentation  float64 `json:"fragmentation"` // Parçalanmış kapasitenin ayrılabilir kapasiteye oranı (0-1)
+}
+
+// FragmentationReport node ve küme bazında kaynak parçalanması
+type FragmentationReport struct {
+	Shapes            []podShape          `json:"shapes"`
+	Nodes             []NodeFragmentation `json:"nodes"`
+	AllocatableCPU    float64             `json:"allocatable_cpu"`
+	AllocatableMemory float64             `json:"allocatable_memory_gb"`
+	FreeCPU           float64             `json:"free_cpu"`
+	FreeMemory        float64             `json:"free_memory_gb"`
+	StrandedCPU       float64             `json:"stranded_cpu"`
+	StrandedMemory    float64             `json:"stranded_memory_gb"`
+	Fragmentation     float64             `json:"fragmentation"`
+}
+
+// fragmentationShapes parçalanmanın ölçüleceği şekilleri döndürür: en sık MaxShapes bekleyen pod şekli ve
+// referans şekiller. Kapalıysa nil
+func (as *AIScheduler) fragmentationShapes(snapshot *clusterSnapshot) []podShape {
+	cfg := as.currentConfig().Fragmentation
+	if !cfg.Enabled {
+		return nil
+	}
+	maxShapes := cfg.MaxShapes
+	if maxShapes <= 0 {
+		maxShapes = defaultMaxShapes
+	}
+
+	shapes := snapshot.shapes
+	if len(shapes) > maxShapes {
+		shapes = shapes[:maxShapes]
+	}
+	shapes = append([]podShape(nil), shapes...)
+	for _, reference := range cfg.ReferenceShapes {
+		if reference.CPU <= 0 && reference.Memory <= 0 {
+			continue
+		}
+		shape := podShape{CPU: reference.CPU, Memory: reference.Memory}
+		duplicate := false
+		for _, existing := range shapes {
+			if existing.CPU == shape.CPU && existing.Memory == shape.Memory {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			shapes = append(shapes, shape)
+		}
+	}
+	return shapes
+}
+
+// strandedCapacity boş kapasitenin hiçbir şekle sığmayan kısmını ve bunun ayrılabilir kapasiteye oranını döndürür.
+// Boş kapasite, en az parçalanma bırakan tek şekille doldurulmuş kabul edilir; şekil yoksa parçalanma ölçülmez
+func strandedCapacity(info *nodeInfo, freeCPU, freeMemory float64, shapes []podShape) (float64, float64, float64) {
+	if len(shapes) == 0 {
+		return 0, 0, 0
+	}
+	freeCPU, freeMemory = math.Max(0, freeCPU), math.Max(0, freeMemory)
+
+	bestCPU, bestMemory, bestRatio := freeCPU, freeMemory, math.Inf(1)
+	for _, shape := range shapes {
+		fits := math.Inf(1)
+		if shape.CPU > 0 {
+			fits = math.Floor(freeCPU / shape.CPU)
+		}
+		if shape.Memory > 0 {
+			fits = math.Min(fits, math.Floor(freeMemory/shape.Memory))
+		}
+		strandedCPU := freeCPU - fits*shape.CPU
+		strandedMemory := freeMemory - fits*shape.Memory
+		if ratio := fragmentationRatio(info, strandedCPU, strandedMemory); ratio < bestRatio {
+			bestCPU, bestMemory, bestRatio = strandedCPU, strandedMemory, ratio
+		}
+	}
+	return bestCPU, bestMemory, bestRatio
+}
+
+// fragmentationRatio parçalanmış kapasitenin ayrılabilir kapasiteye oranını, bilinen kaynakların ortalaması olarak döndürür
+func fragmentationRatio(info *nodeInfo, strandedCPU, strandedMemory float64) float64 {
+	var sum float64
+	var resources int
+	if info.allocatableCPU > 0 {
+		sum += strandedCPU / info.allocatableCPU
+		resources++
+	}
+	if info.allocatableMemory > 0 {
+		sum += strandedMemory / info.allocatableMemory
+		resources++
+	}
+	if resources == 0 {
+		return 0
+	}
+	return math.Min(1, sum/float64(resources))
+}
+
+// fragmentationPenalty pod node'a yerleşince node'da kalacak parçalanma oranına göre ceza döndürür.
+// Pod'un sığmayan dilimi doldurduğu node'lar cezalandırılmaz, büyük boş alanı kullanılamaz dilimlere bölenler cezalandırılır
+func (as *AIScheduler) fragmentationPenalty(shapes []podShape, request *podRequest, info *nodeInfo) (float64, string) {
+	if len(shapes) == 0 {
+		return 0, ""
+	}
+	weight := as.currentConfig().Fragmentation.Weight
+	if weight <= 0 {
+		return 0, ""
+	}
+
+	freeCPU, freeMemory := info.freeCapacity()
+	if request.ownNode != info.node.Name {
+		freeCPU -= request.cpu
+		freeMemory -= request.memory
+	}
+	strandedCPU, strandedMemory, ratio := strandedCapacity(info, freeCPU, freeMemory, shapes)
+	if ratio <= 0 {
+		return 0, ""
+	}
+
+	penalty := weight * ratio
+	return penalty, fmt.Sprintf("Parçalanma cezası: %.1f (kullanılamaz kalan: %.2f CPU, %.2f GB)", penalty, strandedCPU, strandedMemory)
+}
+
+// nodeFragmentation node'un şu anki parçalanma oranını döndürür, node snapshot'ta yoksa veya kapalıysa 0
+func (as *AIScheduler) nodeFragmentation(nodeName string) float64 {
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return 0
+	}
+	info, ok := snapshot.node(nodeName)
+	if !ok {
+		return 0
+	}
+	freeCPU, freeMemory := info.freeCapacity()
+	_, _, ratio := strandedCapacity(info, freeCPU, freeMemory, as.fragmentationShapes(snapshot))
+	return ratio
+}
+
+// Fragmentation node ve küme bazında, bekleyen pod şekillerine sığmayan kapasiteyi raporlar (kapasite ekipleri için)
+func (as *AIScheduler) Fragmentation() (*FragmentationReport, error) {
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("küme snapshot'ı alınamadı: %v", err)
+	}
+
+	shapes := as.fragmentationShapes(snapshot)
+	report := &FragmentationReport{Shapes: shapes, Nodes: make([]NodeFragmentation, 0, len(snapshot.nodes))}
+	for _, info := range snapshot.nodes {
+		freeCPU, freeMemory := info.freeCapacity()
+		freeCPU, freeMemory = math.Max(0, freeCPU), math.Max(0, freeMemory)
+		strandedCPU, strandedMemory, ratio := strandedCapacity(info, freeCPU, freeMemory, shapes)
+
+		report.Nodes = append(report.Nodes, NodeFragmentation{
+			Node:           info.node.Name,
+			FreeCPU:        freeCPU,
+			FreeMemory:     freeMemory,
+			StrandedCPU:    strandedCPU,
+			StrandedMemory: strandedMemory,
+			Fragmentation:  ratio,
+		})
+		report.AllocatableCPU += info.allocatableCPU
+		report.AllocatableMemory += info.allocatableMemory
+		report.FreeCPU += freeCPU
+		report.FreeMemory += freeMemory
+		report.StrandedCPU += strandedCPU
+		report.StrandedMemory += strandedMemory
+	}
+	report.Fragmentation = fragmentationRatio(&nodeInfo{allocatableCPU: report.AllocatableCPU, allocatableMemory: report.AllocatableMemory},
+		report.StrandedCPU, report.StrandedMemory)
+
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Fragmentation > report.Nodes[j].Fragmentation })
+	return report, nil
+}
@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultRateWindow yerleşim hızı sınırının varsayılan penceresi
+const defaultRateWindow = time.Minute
+
+// podGuardrails pod için geçerli node başına yerleşim sınırları
+type podGuardrails struct {
+	workload       string
+	maxPerWorkload int
+	placements     map[string]int // Pencere içinde node'lara yapılan yerleşimler
+	maxPlacements  int
+	cooldowns      map[string]bool // Exclude modunda cooldown'daki node'lar
+}
+
+// placementTracker node'lara yapılan son yerleşimlerin zamanlarını tutar
+type placementTracker struct {
+	mutex sync.Mutex
+	nodes map[string][]time.Time
+}
+
+// record node'a yerleşimi kaydeder, pencere dışına çıkanları atar
+func (t *placementTracker) record(nodeName string, at time.Time, window time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.nodes == nil {
+		t.nodes = make(map[string][]time.Time)
+	}
+	t.nodes[nodeName] = append(pruneBefore(t.nodes[nodeName], at.Add(-window)), at)
+}
+
+// counts since'ten sonraki yerleşim sayılarını node bazında döndürür, boşalan node'lar silinir
+func (t *placementTracker) counts(since time.Time) map[string]int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	counts := make(map[string]int, len(t.nodes))
+	for nodeName, times := range t.nodes {
+		times = pruneBefore(times, since)
+		if len(times) == 0 {
+			delete(t.nodes, nodeName)
+			continue
+		}
+		t.nodes[nodeName] = times
+		counts[nodeName] = len(times)
+	}
+	return counts
+}
+
+// pruneBefore zaman sıralı listeden cutoff'tan önceki (cutoff dahil) zamanları atar
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// workloadKey pod'un iş yükü anahtarını döndürür, sahipsiz pod'lar için boş
+func workloadKey(pod *corev1.Pod) string {
+	workload := types.WorkloadOf(pod)
+	if workload.Kind == "Pod" {
+		return ""
+	}
+	return workload.String()
+}
+
+// guardrailSettings varsayılanları uygulanmış yerleşim sınırlarını döndürür
+func (as *AIScheduler) guardrailSettings() types.GuardrailsConfig {
+	cfg := as.currentConfig().Guardrails
+	if cfg.RateWindow <= 0 {
+		cfg.RateWindow = defaultRateWindow
+	}
+	return cfg
+}
+
+// recordPlacement node'a yapılan yerleşimi hız sınırı için kaydeder
+func (as *AIScheduler) recordPlacement(nodeName string, at time.Time) {
+	cfg := as.guardrailSettings()
+	if cfg.MaxPlacementsPerNode <= 0 {
+		return
+	}
+	as.placements.record(nodeName, at, cfg.RateWindow)
+}
+
+// podGuardrails pod için geçerli sınırları hazırlar, sınır yoksa nil döner
+func (as *AIScheduler) podGuardrails(pod *corev1.Pod) *podGuardrails {
+	cfg := as.guardrailSettings()
+	guardrails := &podGuardrails{maxPlacements: cfg.MaxPlacementsPerNode}
+	if cfg.MaxPodsPerWorkload > 0 {
+		guardrails.workload = workloadKey(pod)
+		guardrails.maxPerWorkload = cfg.MaxPodsPerWorkload
+	}
+	if guardrails.maxPlacements > 0 {
+		guardrails.placements = as.placements.counts(as.now().Add(-cfg.RateWindow))
+	}
+	guardrails.cooldowns = as.excludedNodes()
+	if guardrails.workload == "" && guardrails.maxPlacements <= 0 && guardrails.cooldowns == nil {
+		return nil
+	}
+	return guardrails
+}
+
+// guardrailViolation node pod sayısı sınırını veya operatörün yerleşim sınırlarını aşacaksa elenme sebebini döndürür.
+// own true ise pod node'un sayılarına zaten dahildir
+func guardrailViolation(guardrails *podGuardrails, info *nodeInfo, own bool) string {
+	existing := 0
+	if own {
+		existing = 1
+	}
+
+	if info.allocatablePods > 0 && info.pods-existing >= info.allocatablePods {
+		return filterTooManyPods
+	}
+	if guardrails == nil {
+		return ""
+	}
+
+	name := info.node.Name
+	if guardrails.cooldowns[name] {
+		return filterCooldown
+	}
+	if guardrails.workload != "" && info.workloads[guardrails.workload]-existing >= guardrails.maxPerWorkload {
+		return filterWorkloadLimit
+	}
+	if guardrails.maxPlacements > 0 && guardrails.placements[name]-existing >= guardrails.maxPlacements {
+		return filterPlacementRate
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Karar günlüğü kayıt türleri
+const (
+	JournalDecision       = "decision"        // Verilen karar
+	JournalOutcomeTracked = "outcome_tracked" // Karar sonuç eşleştirmeye alındı (seçim anındaki özelliklerle)
+	JournalOutcome        = "outcome"         // Kararın sonucu etiketlendi
+	JournalAssume         = "assume"          // Pod node'a yerleşmiş varsayıldı
+	JournalForget         = "forget"          // Assume kaydı geri alındı
+	JournalMode           = "mode"            // Gözlem modu değişti
+	JournalWeights        = "weights"         // Skorlama ağırlıkları ayarlandı veya geri alındı
+)
+
+// JournalPod assume ve forget kayıtlarının pod bilgisi, forget kayıtlarında sadece pod dolu
+type JournalPod struct {
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Node      string    `json:"node,omitempty"`
+	CPU       float64   `json:"cpu,omitempty"`
+	Memory    float64   `json:"memory_gb,omitempty"`
+	Workload  string    `json:"workload,omitempty"`
+	Expires   time.Time `json:"expires,omitempty"`
+}
+
+// JournalEntry karar günlüğünün tek kaydı, Kind'a göre alanlardan biri doludur.
+// Seq günlüğe yazılırken verilir ve kayıtlar arasında kesintisiz artar
+type JournalEntry struct {
+	Seq      uint64         `json:"seq"`
+	Time     time.Time      `json:"time"`
+	Kind     string         `json:"kind"`
+	Decision *Decision      `json:"decision,omitempty"`
+	Outcome  *OutcomeRecord `json:"outcome,omitempty"`
+	Pod      *JournalPod    `json:"pod,omitempty"`
+	Mode     *ModeStatus    `json:"mode,omitempty"`
+	Weights  *WeightChange  `json:"weights,omitempty"`
+}
+
+// Journal kararları ve durum geçişlerini kalıcı, sadece eklenen bir günlüğe yazar (ör: dosya).
+// Sorgulanan geçmişten ayrıdır; çökme sonrası cache'leri yeniden kurmak ve simülatörü gerçek yükle çalıştırmak için okunur
+type Journal interface {
+	Append(entry *JournalEntry)
+}
+
+// SetJournal karar günlüğünü ayarlar
+func (as *AIScheduler) SetJournal(journal Journal) {
+	as.journal = journal
+}
+
+// appendJournal kaydı günlüğe yazar, günlük yoksa bir şey yapmaz
+func (as *AIScheduler) appendJournal(entry JournalEntry) {
+	if as.journal == nil {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = as.now()
+	}
+	as.journal.Append(&entry)
+}
+
+// RestoreJournal günlük kaydını scheduler durumuna uygular: karar geçmişi, deneme sayaçları, yerleşim hızı, izlenen
+// ve etiketlenen sonuçlar, assume kayıtları, gözlem modu ve ayarlı skorlama ağırlıkları yeniden kurulur. Uygulanan kayıtlar günlüğe tekrar yazılmaz.
+// Kayıtlar yazıldıkları sırayla verilmelidir
+func (as *AIScheduler) RestoreJournal(entry *JournalEntry) {
+	switch entry.Kind {
+	case JournalDecision:
+		if entry.Decision == nil {
+			return
+		}
+		as.decisions.add(*entry.Decision, as.currentConfig().DecisionHistorySize)
+		as.recordAttempt(entry.Decision)
+		if entry.Decision.Outcome == OutcomeScheduled {
+			as.recordPlacement(entry.Decision.Node, entry.Decision.Time)
+		}
+
+	case JournalOutcomeTracked:
+		if entry.Outcome == nil || !as.outcomeSettings().Enabled {
+			return
+		}
+		c := &as.outcomes
+		c.mutex.Lock()
+		if c.pending == nil {
+			c.pending = make(map[podKey]*pendingOutcome)
+		}
+		c.pending[podKey{namespace: entry.Outcome.Namespace, name: entry.Outcome.Pod}] = &pendingOutcome{record: *entry.Outcome}
+		c.mutex.Unlock()
+
+	case JournalOutcome:
+		if entry.Outcome == nil {
+			return
+		}
+		c := &as.outcomes
+		c.mutex.Lock()
+		delete(c.pending, podKey{namespace: entry.Outcome.Namespace, name: entry.Outcome.Pod})
+		c.records = append(c.records, *entry.Outcome)
+		as.recordTemplateOutcome(entry.Outcome)
+		if size := as.outcomeSettings().HistorySize; len(c.records) > size {
+			c.records = append(c.records[:0:0], c.records[len(c.records)-size:]...)
+		}
+		c.mutex.Unlock()
+
+	case JournalAssume:
+		// Süresi dolan assume kayıtları snapshot kurulurken zaten temizlenir
+		if assume := entry.Pod; assume != nil && as.now().Before(assume.Expires) {
+			as.cache.mutex.Lock()
+			if as.cache.assumed == nil {
+				as.cache.assumed = make(map[podKey]assumedPod)
+			}
+			as.cache.assumed[podKey{namespace: assume.Namespace, name: assume.Pod}] = assumedPod{
+				nodeName: assume.Node,
+				cpu:      assume.CPU,
+				memory:   assume.Memory,
+				workload: assume.Workload,
+				expires:  assume.Expires,
+			}
+			// Snapshot bir sonraki istekte assume kayıtlarıyla yeniden kurulur
+			as.cache.current.Store(nil)
+			as.cache.mutex.Unlock()
+		}
+
+	case JournalForget:
+		if assume := entry.Pod; assume != nil {
+			as.forgetPod(podKey{namespace: assume.Namespace, name: assume.Pod})
+		}
+
+	case JournalMode:
+		if entry.Mode != nil {
+			as.mode.mutex.Lock()
+			as.mode.status = ModeStatus{ObserveOnly: entry.Mode.ObserveOnly, Since: entry.Mode.Since, Reason: entry.Mode.Reason}
+			as.mode.mutex.Unlock()
+		}
+
+	case JournalWeights:
+		if entry.Weights != nil {
+			as.restoreWeightChange(entry.Weights)
+		}
+
+	default:
+		logrus.Debugf("Bilinmeyen günlük kaydı türü atlandı: %s", entry.Kind)
+	}
+}
+
+// StateEntries karar geçmişini, etiketli ve izlenen sonuçları, assume kayıtlarını, gözlem modunu ve ağırlık
+// değişikliklerini RestoreJournal ile geri yüklenebilecek günlük kayıtları olarak eskiden yeniye döndürür (yedekleme için)
+func (as *AIScheduler) StateEntries() []JournalEntry {
+	var entries []JournalEntry
+
+	decisions := as.decisions.recent(0)
+	for i := len(decisions) - 1; i >= 0; i-- {
+		entries = append(entries, JournalEntry{Time: decisions[i].Time, Kind: JournalDecision, Decision: &decisions[i]})
+	}
+
+	c := &as.outcomes
+	c.mutex.Lock()
+	for i := range c.records {
+		record := c.records[i]
+		entries = append(entries, JournalEntry{Time: record.ObservedAt, Kind: JournalOutcome, Outcome: &record})
+	}
+	for _, pending := range c.pending {
+		record := pending.record
+		entries = append(entries, JournalEntry{Time: record.DecidedAt, Kind: JournalOutcomeTracked, Outcome: &record})
+	}
+	c.mutex.Unlock()
+
+	as.cache.mutex.Lock()
+	for key, assumed := range as.cache.assumed {
+		entries = append(entries, JournalEntry{Time: as.now(), Kind: JournalAssume, Pod: &JournalPod{
+			Namespace: key.namespace,
+			Pod:       key.name,
+			Node:      assumed.nodeName,
+			CPU:       assumed.cpu,
+			Memory:    assumed.memory,
+			Workload:  assumed.workload,
+			Expires:   assumed.expires,
+		}})
+	}
+	as.cache.mutex.Unlock()
+
+	if mode := as.mode.get(); !mode.Since.IsZero() {
+		entries = append(entries, JournalEntry{Time: mode.Since, Kind: JournalMode, Mode: &mode})
+	}
+
+	changes := as.weightChanges()
+	for i := range changes {
+		entries = append(entries, JournalEntry{Time: changes[i].Time, Kind: JournalWeights, Weights: &changes[i]})
+	}
+	return entries
+}
@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatefulSet veri yerelliğinin varsayılanları
+const (
+	defaultLocalityDomainFactor = 0.5
+	defaultLocalityTopologyKey  = "topology.kubernetes.io/zone"
+	defaultLocalityRetention    = 7 * 24 * time.Hour
+	incarnationSweepInterval    = time.Hour
+)
+
+// incarnation StatefulSet pod kimliğinin en son çalıştığı node ve node'un topoloji alanı
+type incarnation struct {
+	node   string
+	domain string
+	at     time.Time
+}
+
+// incarnationTracker StatefulSet pod'larının son çalıştıkları node'ları tutar. Pod silinince kayıt kalır,
+// aynı adla yeniden oluşturulan pod önceki node'una yönlendirilebilir
+type incarnationTracker struct {
+	mutex sync.Mutex
+	pods  map[podKey]incarnation
+	swept time.Time
+}
+
+// record pod'un node'unu kaydeder, saatte bir retention'ı dolan kayıtları siler
+func (t *incarnationTracker) record(key podKey, entry incarnation, retention time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.pods == nil {
+		t.pods = make(map[podKey]incarnation)
+	}
+	t.pods[key] = entry
+
+	if entry.at.Sub(t.swept) < incarnationSweepInterval {
+		return
+	}
+	t.swept = entry.at
+	for other, previous := range t.pods {
+		if entry.at.Sub(previous.at) > retention {
+			delete(t.pods, other)
+		}
+	}
+}
+
+// get pod'un retention içindeki son node'unu döndürür
+func (t *incarnationTracker) get(key podKey, now time.Time, retention time.Duration) (incarnation, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	entry, ok := t.pods[key]
+	if !ok || now.Sub(entry.at) > retention {
+		return incarnation{}, false
+	}
+	return entry, true
+}
+
+// podLocality StatefulSet pod'unun verisinin ve önceki kopyasının yeri
+type podLocality struct {
+	nodes    []*corev1.NodeSelector // Node'a bağlı (local, hostPath) PV'lerin node affinity'leri
+	domains  []*corev1.NodeSelector // Topoloji alanına bağlı PV'lerin node affinity'leri
+	previous *incarnation
+}
+
+// statefulLocalitySettings varsayılanları uygulanmış veri yerelliği konfigürasyonunu döndürür
+func (as *AIScheduler) statefulLocalitySettings() types.StatefulLocalityConfig {
+	cfg := as.currentConfig().StatefulLocality
+	if cfg.DomainFactor <= 0 || cfg.DomainFactor > 1 {
+		cfg.DomainFactor = defaultLocalityDomainFactor
+	}
+	if cfg.TopologyKey == "" {
+		cfg.TopologyKey = defaultLocalityTopologyKey
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultLocalityRetention
+	}
+	return cfg
+}
+
+// isStatefulSetPod pod'un controller'ı StatefulSet ise true döner
+func isStatefulSetPod(pod *corev1.Pod) bool {
+	controller := metav1.GetControllerOf(pod)
+	return controller != nil && controller.Kind == "StatefulSet"
+}
+
+// observeIncarnation node'a bağlanmış StatefulSet pod'unun node'unu ve topoloji alanını kaydeder
+func (as *AIScheduler) observeIncarnation(pod *corev1.Pod, deleted bool) {
+	if deleted || pod.Spec.NodeName == "" || !isStatefulSetPod(pod) {
+		return
+	}
+	cfg := as.statefulLocalitySettings()
+	if !cfg.Enabled {
+		return
+	}
+
+	entry := incarnation{node: pod.Spec.NodeName, at: as.now()}
+	if node, ok := as.source.Node(pod.Spec.NodeName); ok {
+		entry.domain = node.Labels[cfg.TopologyKey]
+	}
+	as.incarnations.record(podKey{namespace: pod.Namespace, name: pod.Name}, entry, cfg.Retention)
+}
+
+// dataLocality StatefulSet pod'unun bağlı PV'lerinin node affinity'lerini ve önceki node'unu döndürür.
+// Özellik kapalıysa, pod StatefulSet'e ait değilse veya yer bilgisi yoksa nil döner
+func (as *AIScheduler) dataLocality(pod *corev1.Pod) *podLocality {
+	cfg := as.statefulLocalitySettings()
+	if !cfg.Enabled || cfg.Weight <= 0 || pod == nil || !isStatefulSetPod(pod) {
+		return nil
+	}
+
+	locality := &podLocality{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pv, err := as.boundVolume(pod.Namespace, volume.PersistentVolumeClaim.ClaimName)
+		if err != nil {
+			logrus.Debugf("%s/%s volume'u %s çözülemedi, veri yerelliğinde sayılmayacak: %v", pod.Namespace, pod.Name, volume.Name, err)
+			continue
+		}
+		if pv == nil || pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+		if pv.Spec.Local != nil || pv.Spec.HostPath != nil {
+			locality.nodes = append(locality.nodes, pv.Spec.NodeAffinity.Required)
+		} else {
+			locality.domains = append(locality.domains, pv.Spec.NodeAffinity.Required)
+		}
+	}
+
+	if previous, ok := as.incarnations.get(podKey{namespace: pod.Namespace, name: pod.Name}, as.now(), cfg.Retention); ok {
+		locality.previous = &previous
+	}
+
+	if len(locality.nodes) == 0 && len(locality.domains) == 0 && locality.previous == nil {
+		return nil
+	}
+	return locality
+}
+
+// boundVolume PVC'nin bağlı olduğu PV'yi döndürür, PVC henüz bağlanmadıysa nil
+func (as *AIScheduler) boundVolume(namespace, claimName string) (*corev1.PersistentVolume, error) {
+	claim, err := as.getPersistentVolumeClaim(namespace, claimName)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Spec.VolumeName == "" {
+		return nil, nil
+	}
+	return as.getPersistentVolume(claim.Spec.VolumeName)
+}
+
+// localityBonus node pod'un local PV'lerini tutuyorsa veya pod'un önceki kopyası bu node'da çalıştıysa Weight,
+// node pod'un PV'lerinin veya önceki node'unun topoloji alanındaysa Weight*DomainFactor bonus döndürür
+func (as *AIScheduler) localityBonus(locality *podLocality, node *corev1.Node) (float64, string) {
+	if locality == nil {
+		return 0, ""
+	}
+	cfg := as.statefulLocalitySettings()
+
+	if len(locality.nodes) > 0 && matchesAll(locality.nodes, node) {
+		return cfg.Weight, fmt.Sprintf("Veri yerelliği bonusu: %.1f (%d local volume bu node'da)", cfg.Weight, len(locality.nodes))
+	}
+	previous := locality.previous
+	if previous != nil && previous.node == node.Name {
+		return cfg.Weight, fmt.Sprintf("Veri yerelliği bonusu: %.1f (önceki kopya bu node'da çalıştı)", cfg.Weight)
+	}
+
+	bonus := cfg.Weight * cfg.DomainFactor
+	if len(locality.domains) > 0 && matchesAll(locality.domains, node) {
+		return bonus, fmt.Sprintf("Veri yerelliği bonusu: %.1f (%d volume bu topoloji alanında)", bonus, len(locality.domains))
+	}
+	if previous != nil && previous.domain != "" && node.Labels[cfg.TopologyKey] == previous.domain {
+		return bonus, fmt.Sprintf("Veri yerelliği bonusu: %.1f (önceki node %s ile aynı %s: %s)", bonus, previous.node, cfg.TopologyKey, previous.domain)
+	}
+	return 0, ""
+}
+
+// maxLocalityBonus pod'un herhangi bir node'da alabileceği en yüksek veri yerelliği bonusunu döndürür
+func (as *AIScheduler) maxLocalityBonus(locality *podLocality) float64 {
+	if locality == nil {
+		return 0
+	}
+	cfg := as.statefulLocalitySettings()
+	return math.Max(cfg.Weight, cfg.Weight*cfg.DomainFactor)
+}
+
+// matchesAll node tüm seçicileri sağlıyorsa true döner
+func matchesAll(selectors []*corev1.NodeSelector, node *corev1.Node) bool {
+	for _, selector := range selectors {
+		if !nodeSelectorMatches(selector, node) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeSelectorMatches node seçicinin terimlerinden birini sağlıyorsa true döner (terimler OR, ifadeler AND)
+func nodeSelectorMatches(selector *corev1.NodeSelector, node *corev1.Node) bool {
+	for _, term := range selector.NodeSelectorTerms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		matched := true
+		for _, requirement := range term.MatchExpressions {
+			value, present := node.Labels[requirement.Key]
+			if !requirementMatches(&requirement, value, present) {
+				matched = false
+				break
+			}
+		}
+		for _, requirement := range term.MatchFields {
+			// Desteklenen tek alan metadata.name
+			if !matched || requirement.Key != "metadata.name" || !requirementMatches(&requirement, node.Name, true) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// requirementMatches değerin node seçici ifadesini sağlayıp sağlamadığını döndürür
+func requirementMatches(requirement *corev1.NodeSelectorRequirement, value string, present bool) bool {
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		return present && slices.Contains(requirement.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !present || !slices.Contains(requirement.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return present
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !present
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !present || len(requirement.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		limit, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == corev1.NodeSelectorOpGt {
+			return actual > limit
+		}
+		return actual < limit
+	}
+	return false
+}
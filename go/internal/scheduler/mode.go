@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Scheduler çalışma modları (scheduler.mode)
+const (
+	ModeAI        = "ai"
+	ModeHeuristic = "heuristic"
+)
+
+// ErrHeuristicMode heuristic modda AI API çağrılmaz
+var ErrHeuristicMode = errors.New("scheduler heuristic modunda, AI API çağrılmaz")
+
+// ModeStatus scheduler çalışma modu bilgisi
+type ModeStatus struct {
+	ObserveOnly   bool      `json:"observe_only"`
+	HeuristicOnly bool      `json:"heuristic_only"`
+	Since         time.Time `json:"since"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+// modeState çalışma anında değiştirilebilen mod durumu
+type modeState struct {
+	mutex  sync.RWMutex
+	status ModeStatus
+}
+
+// get mod durumunu döndürür
+func (m *modeState) get() ModeStatus {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.status
+}
+
+// set mod durumunu değiştirir, değişiklik yoksa false döner
+func (m *modeState) set(observeOnly bool, reason string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.status.ObserveOnly == observeOnly && !m.status.Since.IsZero() {
+		return false
+	}
+	m.status = ModeStatus{
+		ObserveOnly: observeOnly,
+		Since:       time.Now(),
+		Reason:      reason,
+	}
+	return true
+}
+
+// SetObserveOnly sadece gözlem modunu açar veya kapatır
+func (as *AIScheduler) SetObserveOnly(observeOnly bool, reason string) {
+	if !as.mode.set(observeOnly, reason) {
+		return
+	}
+	status := as.mode.get()
+	as.appendJournal(JournalEntry{Kind: JournalMode, Mode: &status})
+
+	if observeOnly {
+		logrus.Warnf("Scheduler sadece gözlem moduna alındı: %s", reason)
+	} else {
+		logrus.Infof("Scheduler aktif moda döndü: %s", reason)
+	}
+}
+
+// Mode scheduler çalışma modunu döndürür
+func (as *AIScheduler) Mode() ModeStatus {
+	status := as.mode.get()
+	status.HeuristicOnly = as.HeuristicOnly()
+	return status
+}
+
+// HeuristicOnly scheduler.mode heuristic ise true döner, AI API'ye hiçbir istek gönderilmez
+func (as *AIScheduler) HeuristicOnly() bool {
+	return as.currentConfig().Mode == ModeHeuristic
+}
+
+// validMode bilinen bir çalışma modu mu, boş mod ai sayılır
+func validMode(mode string) bool {
+	return mode == "" || mode == ModeAI || mode == ModeHeuristic
+}
+
+// observeOnly sadece gözlem modu açıksa true döner
+func (as *AIScheduler) observeOnly() bool {
+	return as.mode.get().ObserveOnly
+}
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"ai-scheduler/internal/types"
+)
+
+// networkHealth node'un mesh telemetrisinden hesaplanan ağ sağlığını döndürür, ölçüm yoksa false
+func (as *AIScheduler) networkHealth(nodeName string) (types.NodeMeshHealth, bool) {
+	mesh := as.collector.GetMeshHealth()
+	if mesh == nil {
+		return types.NodeMeshHealth{}, false
+	}
+	health, ok := mesh.Node(nodeName)
+	if !ok || !health.Measured {
+		return types.NodeMeshHealth{}, false
+	}
+	return health, true
+}
+
+// networkPenalty east-west trafiği bozulmuş node için cezayı ve ağ sağlığını döndürür.
+// Ceza ağ sağlığı düştükçe lineer artar, trafiği ölçülmeyen node'lar cezalandırılmaz
+func (as *AIScheduler) networkPenalty(nodeName string) (float64, float64) {
+	cfg := as.currentConfig().NetworkHealth
+	if !cfg.Enabled || cfg.Weight <= 0 {
+		return 0, 1
+	}
+
+	health, ok := as.networkHealth(nodeName)
+	if !ok {
+		return 0, 1
+	}
+	return cfg.Weight * (1 - health.Health), health.Health
+}
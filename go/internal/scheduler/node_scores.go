@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Pod şablonu referans hataları
+var (
+	ErrInvalidTemplateRef = errors.New("geçersiz pod şablonu referansı, namespace/kind/name veya namespace/pod bekleniyor")
+	ErrTemplateNotFound   = errors.New("pod şablonu için kümede pod yok")
+)
+
+// NodeScoreRanking pod şablonundan oluşturulacak yeni bir replika için tüm uygun node'ların güncel sıralaması
+type NodeScoreRanking struct {
+	Template types.WorkloadRef `json:"template"`
+	Pod      string            `json:"pod"` // Şablonun okunduğu pod
+	Nodes    int               `json:"nodes"`
+	Scores   []NodeScore       `json:"scores"`
+	Rejected map[string]int    `json:"rejected,omitempty"` // Filtre sebebi başına elenen node sayısı
+	Stale    int               `json:"stale,omitempty"`    // Reject modunda bayat girdileri yüzünden çıkarılan node sayısı
+	ScoredAt time.Time         `json:"scored_at"`
+}
+
+// ParseTemplateRef namespace/kind/name (ör: team-a/Deployment/web) veya namespace/pod biçimindeki pod şablonu
+// referansını çözer
+func ParseTemplateRef(ref string) (types.WorkloadRef, error) {
+	parts := strings.Split(ref, "/")
+	for _, part := range parts {
+		if part == "" {
+			return types.WorkloadRef{}, fmt.Errorf("%q: %w", ref, ErrInvalidTemplateRef)
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return types.WorkloadRef{Namespace: parts[0], Kind: "Pod", Name: parts[1]}, nil
+	case 3:
+		return types.WorkloadRef{Namespace: parts[0], Kind: parts[1], Name: parts[2]}, nil
+	}
+	return types.WorkloadRef{}, fmt.Errorf("%q: %w", ref, ErrInvalidTemplateRef)
+}
+
+// NodeScores iş yükünün pod şablonundan oluşturulacak yeni bir replikayı güncel snapshot'ta filtreler ve skorlar,
+// tüm uygun node'ları skora göre sıralı döndürür. Heuristik skorlar kullanılır; AI'ya gidilmez, pod assume
+// edilmez ve karar kaydedilmez
+func (as *AIScheduler) NodeScores(ctx context.Context, template types.WorkloadRef) (*NodeScoreRanking, error) {
+	if !as.currentConfig().Namespaces.Matches(template.Namespace) {
+		return nil, fmt.Errorf("%s: %w", template.Namespace, ErrNamespaceOutOfScope)
+	}
+	pod, err := as.templatePod(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	// Yeni replika henüz bir node'a bağlı değildir, mevcut pod'un kendi node'undaki payı düşülmez
+	replica := pod.DeepCopy()
+	replica.Spec.NodeName = ""
+
+	request, feasible, rejected := as.filterPod(replica, snapshot)
+	candidates := as.scoreCandidates(replica, snapshot, &request, feasible)
+	candidates, stale := as.rejectStale(candidates)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].NodeName < candidates[j].NodeName
+	})
+
+	return &NodeScoreRanking{
+		Template: template,
+		Pod:      pod.Name,
+		Nodes:    len(snapshot.nodes),
+		Scores:   candidates,
+		Rejected: rejected,
+		Stale:    stale,
+		ScoredAt: as.now(),
+	}, nil
+}
+
+// templatePod şablonun okunacağı pod'u bulur: Pod referansında pod'un kendisi, iş yükünde en yeni oluşturulan pod'u
+// (rolling update sırasında güncel şablon)
+func (as *AIScheduler) templatePod(ctx context.Context, template types.WorkloadRef) (*corev1.Pod, error) {
+	if template.Kind == "Pod" {
+		pod, err := as.getPod(ctx, template.Namespace, template.Name)
+		if errors.Is(err, types.ErrPodNotFound) {
+			return nil, fmt.Errorf("%s: %w", template, ErrTemplateNotFound)
+		}
+		return pod, err
+	}
+
+	pods, err := as.listPods()
+	if err != nil {
+		return nil, err
+	}
+	var newest *corev1.Pod
+	for _, pod := range pods {
+		if pod.Namespace != template.Namespace || pod.DeletionTimestamp != nil || types.WorkloadOf(pod) != template {
+			continue
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			newest = pod
+		}
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("%s: %w", template, ErrTemplateNotFound)
+	}
+	return newest, nil
+}
@@ -0,0 +1,294 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Karar sonucu eşleştirmenin varsayılanları
+const (
+	defaultOutcomeWindow      = 10 * time.Minute
+	defaultOutcomeMaxPending  = 10000
+	defaultOutcomeHistorySize = 5000
+	outcomeSweepInterval      = 10 * time.Second
+)
+
+// Karar sonuç etiketleri
+const (
+	ResultSucceeded       = "succeeded"        // Pencere sonunda seçilen node'da yeniden başlamadan çalışıyor
+	ResultFailed          = "failed"           // Pencere içinde Failed oldu
+	ResultRestarted       = "restarted"        // Pencere içinde container yeniden başladı
+	ResultDeleted         = "deleted"          // Pencere içinde silindi
+	ResultPlacedElsewhere = "placed_elsewhere" // Pod başka node'a bağlandı, tahmin uygulanmadı
+	ResultNotStarted      = "not_started"      // Pencere sonunda node'a bağlı ama çalışmıyor
+	ResultUnbound         = "unbound"          // Pencere sonunda hâlâ node'a bağlanmadı
+)
+
+// OutcomeRecord kararın özellikleri ve pod yaşam döngüsünden çıkarılan sonucu (eğitim verisi için etiketli kayıt)
+type OutcomeRecord struct {
+	DecidedAt  time.Time         `json:"decided_at"`
+	ObservedAt time.Time         `json:"observed_at"`
+	Namespace  string            `json:"namespace"`
+	Pod        string            `json:"pod"`
+	Workload   types.WorkloadRef `json:"workload"`
+	Template   string            `json:"template,omitempty"`
+	Node       string            `json:"node"`
+	// NodeProperties seçim anında node'un şablon geçmişinde kullanılan özellikleri (ör: kernel=5.15.0)
+	NodeProperties []string               `json:"node_properties,omitempty"`
+	BoundNode      string                 `json:"bound_node,omitempty"`
+	Score          float64                `json:"score"`
+	Features       map[string]interface{} `json:"features"`
+	Result         string                 `json:"result"`
+}
+
+// OutcomeStats etiketli kayıtlardan doğruluk metrikleri
+type OutcomeStats struct {
+	Enabled bool           `json:"enabled"`
+	Pending int            `json:"pending"`
+	Labeled int            `json:"labeled"`
+	Results map[string]int `json:"results"`
+	// SuccessRate tahmin edilen node'a bağlanan pod'lardan başarılı olanların oranı
+	SuccessRate float64 `json:"success_rate"`
+	// NodeMatchRate node'a bağlanan pod'lardan tahmin edilen node'a bağlananların oranı
+	NodeMatchRate float64 `json:"node_match_rate"`
+}
+
+// pendingOutcome sonucu henüz belli olmayan karar
+type pendingOutcome struct {
+	record      OutcomeRecord
+	observed    bool // Node'a bağlı pod en az bir kez görüldü
+	restartBase int32
+	running     bool
+}
+
+// outcomeCorrelator izlenen kararları ve etiketli kayıtları tutar
+type outcomeCorrelator struct {
+	mutex   sync.Mutex
+	pending map[podKey]*pendingOutcome
+	records []OutcomeRecord
+}
+
+// outcomeSettings varsayılanları uygulanmış eşleştirme ayarları
+func (as *AIScheduler) outcomeSettings() types.OutcomeConfig {
+	cfg := as.currentConfig().Outcomes
+	if cfg.Window <= 0 {
+		cfg.Window = defaultOutcomeWindow
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = defaultOutcomeMaxPending
+	}
+	if cfg.HistorySize <= 0 {
+		cfg.HistorySize = defaultOutcomeHistorySize
+	}
+	return cfg
+}
+
+// trackOutcome node seçilen kararı, seçim anındaki node özellikleriyle birlikte izlemeye alır
+func (as *AIScheduler) trackOutcome(decision *Decision) {
+	cfg := as.outcomeSettings()
+	if !cfg.Enabled || decision.Node == "" {
+		return
+	}
+
+	features := make(map[string]interface{}, featureCount+10)
+	as.extractFeaturesForAI(decision.Node, features)
+	// Pod'un etkin kaynak isteği (init container, sidecar ve overhead dahil)
+	features["pod_cpu_request"] = decision.CPU
+	features["pod_memory_request_gb"] = decision.Memory
+	// Şablonun bu node'da ve node'un özelliklerinde geçmişte başarısız olma oranları
+	properties := as.nodePropertiesOf(decision.Node)
+	if as.templateHistorySettings().Enabled {
+		as.templateFeatures(decision.Template, decision.Node, properties, features)
+	}
+	// Pod'un tahmini yaşam süresi boyunca node'da döngüye göre beklenen tepe kullanım
+	if decision.Lifetime > 0 {
+		if node, err := as.getNode(decision.Node); err == nil {
+			as.seasonalFeatures(node, decision.Lifetime, features)
+		}
+	}
+
+	c := &as.outcomes
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := podKey{namespace: decision.Namespace, name: decision.Pod}
+	if _, ok := c.pending[key]; !ok && len(c.pending) >= cfg.MaxPending {
+		logrus.Debugf("Sonuç eşleştirme kapasitesi dolu (%d), %s/%s izlenmiyor", cfg.MaxPending, decision.Namespace, decision.Pod)
+		return
+	}
+	if c.pending == nil {
+		c.pending = make(map[podKey]*pendingOutcome)
+	}
+	// Aynı pod için yeni karar öncekinin yerini alır
+	pending := &pendingOutcome{record: OutcomeRecord{
+		DecidedAt:      decision.Time,
+		Namespace:      decision.Namespace,
+		Pod:            decision.Pod,
+		Workload:       decision.Workload,
+		Template:       decision.Template,
+		Node:           decision.Node,
+		NodeProperties: properties,
+		Score:          decision.Score,
+		Features:       features,
+	}}
+	c.pending[key] = pending
+	as.appendJournal(JournalEntry{Kind: JournalOutcomeTracked, Time: decision.Time, Outcome: &pending.record})
+}
+
+// observePod küme kaynağının pod olayını izlenen kararla eşleştirir, sonuç belli olduysa kaydı etiketler
+func (as *AIScheduler) observePod(pod *corev1.Pod, deleted bool) {
+	c := &as.outcomes
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := podKey{namespace: pod.Namespace, name: pod.Name}
+	pending, ok := c.pending[key]
+	if !ok {
+		return
+	}
+
+	if deleted {
+		as.finishOutcomeLocked(key, pending, ResultDeleted)
+		return
+	}
+	if pod.Spec.NodeName == "" {
+		return
+	}
+	pending.record.BoundNode = pod.Spec.NodeName
+	if pod.Spec.NodeName != pending.record.Node {
+		as.finishOutcomeLocked(key, pending, ResultPlacedElsewhere)
+		return
+	}
+
+	restarts := podRestarts(pod)
+	if !pending.observed {
+		pending.observed = true
+		pending.restartBase = restarts
+	}
+
+	switch {
+	case pod.Status.Phase == corev1.PodFailed:
+		as.finishOutcomeLocked(key, pending, ResultFailed)
+	case restarts > pending.restartBase:
+		as.finishOutcomeLocked(key, pending, ResultRestarted)
+	default:
+		pending.running = pod.Status.Phase == corev1.PodRunning
+	}
+}
+
+// podRestarts pod'un container'larının toplam restart sayısını döndürür
+func podRestarts(pod *corev1.Pod) int32 {
+	var restarts int32
+	for i := range pod.Status.ContainerStatuses {
+		restarts += pod.Status.ContainerStatuses[i].RestartCount
+	}
+	return restarts
+}
+
+// finishOutcomeLocked kararı izlemeden çıkarıp etiketli kayıt olarak saklar, kilit tutulurken çağrılır
+func (as *AIScheduler) finishOutcomeLocked(key podKey, pending *pendingOutcome, result string) {
+	c := &as.outcomes
+	delete(c.pending, key)
+
+	pending.record.Result = result
+	pending.record.ObservedAt = as.now()
+	c.records = append(c.records, pending.record)
+	as.appendJournal(JournalEntry{Kind: JournalOutcome, Time: pending.record.ObservedAt, Outcome: &pending.record})
+	as.recordTemplateOutcome(&pending.record)
+	if result == ResultFailed || result == ResultRestarted {
+		as.recordNodeFailure(pending.record.Node, pending.record.Namespace+"/"+pending.record.Pod, pending.record.ObservedAt)
+	}
+	if size := as.outcomeSettings().HistorySize; len(c.records) > size {
+		c.records = append(c.records[:0:0], c.records[len(c.records)-size:]...)
+	}
+}
+
+// sweepOutcomes penceresi dolan kararları son görülen duruma göre etiketler.
+// Eşleştirme kapatıldıysa izlenen kararlar bırakılır
+func (as *AIScheduler) sweepOutcomes(now time.Time) {
+	cfg := as.outcomeSettings()
+
+	c := &as.outcomes
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !cfg.Enabled {
+		c.pending = nil
+		return
+	}
+	for key, pending := range c.pending {
+		if now.Sub(pending.record.DecidedAt) < cfg.Window {
+			continue
+		}
+		switch {
+		case !pending.observed:
+			as.finishOutcomeLocked(key, pending, ResultUnbound)
+		case pending.running:
+			as.finishOutcomeLocked(key, pending, ResultSucceeded)
+		default:
+			as.finishOutcomeLocked(key, pending, ResultNotStarted)
+		}
+	}
+}
+
+// outcomeLoop penceresi dolan kararları periyodik olarak etiketler
+func (as *AIScheduler) outcomeLoop(ctx context.Context) {
+	ticker := time.NewTicker(outcomeSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			as.sweepOutcomes(as.now())
+		}
+	}
+}
+
+// Outcomes en yeniden eskiye en fazla limit etiketli kaydı döndürür, limit 0 ise tümü
+func (as *AIScheduler) Outcomes(limit int) []OutcomeRecord {
+	c := &as.outcomes
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	count := len(c.records)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+	result := make([]OutcomeRecord, 0, count)
+	for i := len(c.records) - 1; i >= len(c.records)-count; i-- {
+		result = append(result, c.records[i])
+	}
+	return result
+}
+
+// OutcomeStats etiketli kayıtlardan başarı ve node eşleşme oranlarını hesaplar
+func (as *AIScheduler) OutcomeStats() OutcomeStats {
+	stats := OutcomeStats{Enabled: as.currentConfig().Outcomes.Enabled, Results: make(map[string]int)}
+
+	c := &as.outcomes
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats.Pending = len(c.pending)
+	stats.Labeled = len(c.records)
+	for i := range c.records {
+		stats.Results[c.records[i].Result]++
+	}
+
+	onPredicted := stats.Results[ResultSucceeded] + stats.Results[ResultFailed] + stats.Results[ResultRestarted] + stats.Results[ResultNotStarted]
+	if onPredicted > 0 {
+		stats.SuccessRate = float64(stats.Results[ResultSucceeded]) / float64(onPredicted)
+	}
+	if bound := onPredicted + stats.Results[ResultPlacedElsewhere]; bound > 0 {
+		stats.NodeMatchRate = float64(onPredicted) / float64(bound)
+	}
+	return stats
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"strings"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultOvercommitPoolLabel node havuzunun okunduğu varsayılan label
+const defaultOvercommitPoolLabel = "node.kubernetes.io/pool"
+
+// overcommitRatios node'un havuzundaki CPU ve memory overcommit oranlarını döndürür. Havuz tanımlı değilse varsayılan
+// oranlar, özellik kapalıysa veya oran 0 ise 1 (istekler allocatable'ı aşamaz) kullanılır
+func overcommitRatios(cfg *types.OvercommitConfig, node *corev1.Node) (float64, float64) {
+	if !cfg.Enabled {
+		return 1, 1
+	}
+	label := cfg.PoolLabel
+	if label == "" {
+		label = defaultOvercommitPoolLabel
+	}
+
+	// Viper map anahtarlarını küçük harfe çevirdiği için havuz adı küçük harfle de aranır
+	ratio := cfg.Default
+	pool, ok := cfg.Pools[node.Labels[label]]
+	if !ok {
+		pool, ok = cfg.Pools[strings.ToLower(node.Labels[label])]
+	}
+	if ok {
+		if pool.CPU > 0 {
+			ratio.CPU = pool.CPU
+		}
+		if pool.Memory > 0 {
+			ratio.Memory = pool.Memory
+		}
+	}
+	if ratio.CPU <= 0 {
+		ratio.CPU = 1
+	}
+	if ratio.Memory <= 0 {
+		ratio.Memory = 1
+	}
+	return ratio.CPU, ratio.Memory
+}
+
+// freeCapacity node'da overcommit uygulanmış kapasiteye göre boş kalan CPU ve memory'yi döndürür
+func (info *nodeInfo) freeCapacity() (float64, float64) {
+	return info.capacityCPU - info.requestedCPU, info.capacityMemory - info.requestedMemory
+}
@@ -0,0 +1,234 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Deneme kayıtlarının temizlenmesi: pod olayları gelmeyen API modunda bağlanan pod'ların kayıtları süre dolunca silinir
+const (
+	pendingAttemptRetention = 24 * time.Hour
+	pendingSweepInterval    = time.Hour
+)
+
+// PendingPod bu scheduler'ın yerleştirmesini bekleyen pod ve scheduling denemelerinin özeti
+type PendingPod struct {
+	Namespace string            `json:"namespace"`
+	Pod       string            `json:"pod"`
+	Workload  types.WorkloadRef `json:"workload"`
+	Scheduler string            `json:"scheduler"`
+	CreatedAt time.Time         `json:"created_at"`
+	Age       float64           `json:"age_seconds"`
+	CPU       float64           `json:"cpu"`
+	Memory    float64           `json:"memory_gb"`
+	// Priority pod önceliği, QueuePosition öncelik ve namespace'ler arası sıraya göre işlenme sırası (1'den başlar)
+	Priority      int32  `json:"priority"`
+	PriorityClass string `json:"priority_class,omitempty"`
+	QueuePosition int    `json:"queue_position"`
+	// Attempts pod için verilen karar sayısı, Retries ilk denemeden sonrakiler
+	Attempts    int        `json:"attempts"`
+	Retries     int        `json:"retries"`
+	LastAttempt *time.Time `json:"last_attempt,omitempty"`
+	// LastFailure son uygun node bulunamayan denemenin sebebi, Rejected o denemede sebebe göre elenen node sayıları
+	LastFailure   string         `json:"last_failure,omitempty"`
+	LastFailureAt *time.Time     `json:"last_failure_at,omitempty"`
+	Rejected      map[string]int `json:"rejected,omitempty"`
+	// FeasibleNodes şu anki snapshot'ta filtreden geçen node sayısı
+	FeasibleNodes int `json:"feasible_nodes"`
+	// AssumedNode seçilen ama binding'i henüz görülmeyen node
+	AssumedNode string `json:"assumed_node,omitempty"`
+}
+
+// pendingAttempts bağlanmamış pod için verilen kararların sayacı
+type pendingAttempts struct {
+	attempts      int
+	lastAttempt   time.Time
+	lastFailure   string
+	lastFailureAt time.Time
+	rejected      map[string]int
+}
+
+// pendingTracker bağlanmamış pod'ların scheduling denemelerini tutar. Pod bir node'a bağlanınca veya silinince
+// kayıt silinir
+type pendingTracker struct {
+	mutex sync.Mutex
+	pods  map[podKey]*pendingAttempts
+	swept time.Time
+}
+
+// recordAttempt pod'un deneme sayacını günceller, saatte bir son denemesi retention'dan eski kayıtları siler
+func (as *AIScheduler) recordAttempt(decision *Decision) {
+	t := &as.pending
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.pods == nil {
+		t.pods = make(map[podKey]*pendingAttempts)
+	}
+	key := podKey{namespace: decision.Namespace, name: decision.Pod}
+	entry, ok := t.pods[key]
+	if !ok {
+		entry = &pendingAttempts{}
+		t.pods[key] = entry
+	}
+	entry.attempts++
+	entry.lastAttempt = decision.Time
+	if decision.Outcome == OutcomeUnschedulable {
+		entry.lastFailure = unschedulableReason(decision.Rejected)
+		entry.lastFailureAt = decision.Time
+		entry.rejected = decision.Rejected
+	}
+
+	if decision.Time.Sub(t.swept) < pendingSweepInterval {
+		return
+	}
+	t.swept = decision.Time
+	for other, previous := range t.pods {
+		if decision.Time.Sub(previous.lastAttempt) > pendingAttemptRetention {
+			delete(t.pods, other)
+		}
+	}
+}
+
+// observePending bağlanan veya silinen pod'un deneme kaydını siler
+func (as *AIScheduler) observePending(pod *corev1.Pod, deleted bool) {
+	if !deleted && pod.Spec.NodeName == "" {
+		return
+	}
+	t := &as.pending
+	t.mutex.Lock()
+	delete(t.pods, podKey{namespace: pod.Namespace, name: pod.Name})
+	t.mutex.Unlock()
+}
+
+// unschedulableReason elenen node sayılarını en çok elenenden başlayarak okunur sebebe çevirir
+func unschedulableReason(rejected map[string]int) string {
+	if len(rejected) == 0 {
+		return "uygun node yok"
+	}
+	return "uygun node yok (" + rejectedSummary(rejected) + ")"
+}
+
+// rejectedSummary elenen node sayılarını en çok elenenden başlayarak "sebep: sayı" listesine çevirir
+func rejectedSummary(rejected map[string]int) string {
+	reasons := make([]string, 0, len(rejected))
+	for reason := range rejected {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if rejected[reasons[i]] != rejected[reasons[j]] {
+			return rejected[reasons[i]] > rejected[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%s: %d", reason, rejected[reason])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// handlesPod pod'un schedulerName'i bekleyen pod listesine alınan scheduler'lardan biriyse true döner,
+// liste boşsa tüm pod'lar sayılır
+func handlesPod(schedulerNames []string, pod *corev1.Pod) bool {
+	if len(schedulerNames) == 0 {
+		return true
+	}
+	name := pod.Spec.SchedulerName
+	if name == "" {
+		name = defaultComparisonScheduler
+	}
+	return containsString(schedulerNames, name)
+}
+
+// PendingPods kapsamdaki bağlanmamış pod'ları işlenme sırasıyla (bkz. queueOrder) deneme geçmişleri ve şu an uygun
+// node sayılarıyla döndürür. namespace boş değilse sadece o namespace listelenir
+func (as *AIScheduler) PendingPods(namespace string) ([]PendingPod, error) {
+	cfg := as.currentConfig()
+	pods, err := as.listPods()
+	if err != nil {
+		return nil, fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	now := as.now()
+	result := make([]PendingPod, 0)
+	waiting := make(map[podKey]bool)
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" || pod.Status.Phase != corev1.PodPending || pod.DeletionTimestamp != nil {
+			continue
+		}
+		key := podKey{namespace: pod.Namespace, name: pod.Name}
+		waiting[key] = true
+		if (namespace != "" && pod.Namespace != namespace) || !cfg.Namespaces.Matches(pod.Namespace) ||
+			!handlesPod(cfg.Pending.SchedulerNames, pod) {
+			continue
+		}
+
+		request, feasible, _ := as.filterPod(pod, snapshot)
+		entry := PendingPod{
+			Namespace:     pod.Namespace,
+			Pod:           pod.Name,
+			Workload:      types.WorkloadOf(pod),
+			Scheduler:     pod.Spec.SchedulerName,
+			CreatedAt:     pod.CreationTimestamp.Time,
+			CPU:           request.cpu,
+			Memory:        request.memory,
+			Priority:      podPriority(pod),
+			PriorityClass: pod.Spec.PriorityClassName,
+			FeasibleNodes: len(feasible),
+			AssumedNode:   request.ownNode,
+		}
+		if !entry.CreatedAt.IsZero() {
+			entry.Age = now.Sub(entry.CreatedAt).Seconds()
+		}
+		as.fillAttempts(&entry, key)
+		result = append(result, entry)
+	}
+	as.prunePending(waiting)
+
+	return queueOrder(result), nil
+}
+
+// fillAttempts pod'un deneme kaydını listeleme satırına kopyalar
+func (as *AIScheduler) fillAttempts(entry *PendingPod, key podKey) {
+	t := &as.pending
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	attempts, ok := t.pods[key]
+	if !ok {
+		return
+	}
+	entry.Attempts = attempts.attempts
+	entry.Retries = max(attempts.attempts-1, 0)
+	lastAttempt := attempts.lastAttempt
+	entry.LastAttempt = &lastAttempt
+	if attempts.lastFailure != "" {
+		lastFailureAt := attempts.lastFailureAt
+		entry.LastFailure, entry.LastFailureAt, entry.Rejected = attempts.lastFailure, &lastFailureAt, attempts.rejected
+	}
+}
+
+// prunePending artık beklemeyen pod'ların deneme kayıtlarını siler
+func (as *AIScheduler) prunePending(waiting map[podKey]bool) {
+	t := &as.pending
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for key := range t.pods {
+		if !waiting[key] {
+			delete(t.pods, key)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ErrInvalidWorkload yerleştirilecek iş yükünün istekleri geçersiz
+var ErrInvalidWorkload = errors.New("iş yükü için pozitif CPU veya memory isteği gerekli")
+
+// WorkloadSpec henüz oluşturulmamış bir iş yükünün replika başına istekleri ve yerleşim kısıtları
+type WorkloadSpec struct {
+	Namespace    string              `json:"namespace,omitempty" binding:"omitempty,k8s_namespace"`
+	CPU          float64             `json:"cpu" binding:"gte=0"`       // Replika başına CPU (core)
+	Memory       float64             `json:"memory_gb" binding:"gte=0"` // Replika başına memory (GB)
+	Replicas     int                 `json:"replicas,omitempty" binding:"gte=0"`
+	NodeSelector map[string]string   `json:"node_selector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ClusterPlacement iş yükünün bu kümedeki en iyi node'u ve kümenin toplam kapasite/kararlılık özeti
+type ClusterPlacement struct {
+	Node              string         `json:"node,omitempty"` // Boşsa hiçbir node uygun değil
+	NodeScore         float64        `json:"node_score"`
+	FeasibleNodes     int            `json:"feasible_nodes"`
+	FittingReplicas   int            `json:"fitting_replicas"` // Boş kapasiteye sığan replika sayısı
+	AllocatableCPU    float64        `json:"allocatable_cpu"`
+	RequestedCPU      float64        `json:"requested_cpu"`
+	AllocatableMemory float64        `json:"allocatable_memory_gb"`
+	RequestedMemory   float64        `json:"requested_memory_gb"`
+	Stability         float64        `json:"stability"` // Node'ların son 24 saatlik ortalama kararlılık skoru (0-1)
+	Rejected          map[string]int `json:"rejected,omitempty"`
+}
+
+// PlaceWorkload iş yükünü kümede filtreleyip skorlar, en iyi node'u ve kümenin kapasite özetini döndürür.
+// Pod henüz olmadığı için kararlar geçmişe yazılmaz ve assume yapılmaz
+func (as *AIScheduler) PlaceWorkload(spec *WorkloadSpec) (*ClusterPlacement, error) {
+	if spec.CPU < 0 || spec.Memory < 0 || spec.CPU+spec.Memory <= 0 {
+		return nil, ErrInvalidWorkload
+	}
+	replicas := spec.Replicas
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("küme snapshot'ı alınamadı: %v", err)
+	}
+
+	pod := &corev1.Pod{}
+	pod.Namespace = spec.Namespace
+	pod.Spec.NodeSelector = spec.NodeSelector
+	pod.Spec.Tolerations = spec.Tolerations
+	request := podRequest{pod: pod, cpu: spec.CPU, memory: spec.Memory}
+
+	placement := &ClusterPlacement{NodeScore: math.Inf(-1)}
+	for _, info := range snapshot.nodes {
+		placement.AllocatableCPU += info.allocatableCPU
+		placement.RequestedCPU += info.requestedCPU
+		placement.AllocatableMemory += info.allocatableMemory
+		placement.RequestedMemory += info.requestedMemory
+		placement.Stability += as.nodeAnalysis(info.node.Name).StabilityScore
+	}
+	if len(snapshot.nodes) > 0 {
+		placement.Stability /= float64(len(snapshot.nodes))
+	}
+
+	feasible, rejected := filterNodes(snapshot, &request)
+	placement.FeasibleNodes = len(feasible)
+	placement.Rejected = rejected
+	maxPerNode := as.guardrailSettings().MaxPodsPerWorkload
+	for _, info := range feasible {
+		fitting := fittingReplicas(info, spec)
+		// Yeni iş yükünün node'da pod'u yoktur, node başına sınır doğrudan uygulanır
+		if maxPerNode > 0 && fitting > maxPerNode {
+			fitting = maxPerNode
+		}
+		placement.FittingReplicas += fitting
+
+		score, _ := as.cachedNodeScore(info.node)
+		if score > placement.NodeScore {
+			placement.Node = info.node.Name
+			placement.NodeScore = score
+		}
+	}
+	if placement.Node == "" {
+		placement.NodeScore = 0
+	}
+	return placement, nil
+}
+
+// fittingReplicas node'un (overcommit uygulanmış) boş kapasitesine ve pod sayısı sınırına kaç replikanın sığdığını döndürür,
+// kapasitesi bilinmeyen kaynak sınırlamaz
+func fittingReplicas(info *nodeInfo, spec *WorkloadSpec) int {
+	count := math.MaxInt32
+	if info.allocatablePods > 0 {
+		count = info.allocatablePods - info.pods
+	}
+	freeCPU, freeMemory := info.freeCapacity()
+	if spec.CPU > 0 && info.allocatableCPU > 0 {
+		if byCPU := int(freeCPU / spec.CPU); byCPU < count {
+			count = byCPU
+		}
+	}
+	if spec.Memory > 0 && info.allocatableMemory > 0 {
+		if byMemory := int(freeMemory / spec.Memory); byMemory < count {
+			count = byMemory
+		}
+	}
+	if count < 0 {
+		return 0
+	}
+	return count
+}
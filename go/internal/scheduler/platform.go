@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PlatformResolver imajların desteklediği platformları sağlar (ör: registry manifest listeleri).
+// Çağrı bloklamamalı, platformlar henüz bilinmiyorsa false dönmelidir
+type PlatformResolver interface {
+	Platforms(image string) ([]types.Platform, bool)
+}
+
+// SetPlatformResolver imaj platformu çözümleyicisini ayarlar
+func (as *AIScheduler) SetPlatformResolver(resolver PlatformResolver) {
+	as.platforms = resolver
+}
+
+// podPlatforms pod'un çalışabildiği "os/arch" kümesini döndürür. Annotation varsa o, yoksa platformu bilinen tüm
+// imajların ortak platformları kullanılır. Kısıt yoksa nil, hiçbir platform tüm imajlara uymuyorsa boş küme döner
+func (as *AIScheduler) podPlatforms(pod *corev1.Pod) map[string]bool {
+	if !as.currentConfig().Platform.Enabled {
+		return nil
+	}
+
+	if value := pod.Annotations[types.AnnotationPlatforms]; value != "" {
+		platforms, err := types.ParsePlatforms(value)
+		if err == nil && len(platforms) > 0 {
+			return platformSet(platforms)
+		}
+		logrus.Warnf("%s/%s: geçersiz %s annotation'ı yok sayıldı: %s", pod.Namespace, pod.Name, types.AnnotationPlatforms, value)
+	}
+	if as.platforms == nil {
+		return nil
+	}
+
+	var allowed map[string]bool
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			platforms, ok := as.platforms.Platforms(containers[i].Image)
+			if !ok {
+				continue
+			}
+			supported := platformSet(platforms)
+			if allowed == nil {
+				allowed = supported
+				continue
+			}
+			for key := range allowed {
+				if !supported[key] {
+					delete(allowed, key)
+				}
+			}
+		}
+	}
+	return allowed
+}
+
+// platformSet platformları variant'sız "os/arch" kümesine çevirir
+func platformSet(platforms []types.Platform) map[string]bool {
+	set := make(map[string]bool, len(platforms))
+	for _, platform := range platforms {
+		set[platform.String()] = true
+	}
+	return set
+}
+
+// platformMismatch node'un platformu biliniyorsa ve pod'un platformlarında yoksa true döner
+func platformMismatch(platforms map[string]bool, node *corev1.Node) bool {
+	if platforms == nil {
+		return false
+	}
+	platform := types.NodePlatform(node)
+	if platform.OS == "" || platform.Architecture == "" {
+		return false
+	}
+	return !platforms[platform.String()]
+}
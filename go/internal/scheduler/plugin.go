@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeFilterResult pod'un tek bir node için filtre sonucu. Reason boşsa node uygundur; Unresolvable node nesnesine
+// bağlı kısıtlarda (taint, seçici, platform, güvenlik) true olur, preemption bu node'u uygun hale getiremez
+type NodeFilterResult struct {
+	Reason       string
+	Message      string
+	Unresolvable bool
+}
+
+// filterNodeVerdict pod isteğini node için filtreler, extender ve framework plugin'i aynı sonucu üretir. preemption
+// feature flag'i kapalıysa kapasite/yük elemeleri de çözülemez sayılır, kube-scheduler pod için kurban aramaz
+func (as *AIScheduler) filterNodeVerdict(request *podRequest, info *nodeInfo) NodeFilterResult {
+	if reason := filterNodeStatic(request, info.node); reason != "" {
+		return NodeFilterResult{Reason: reason, Message: exclusionMessage(request, info, reason), Unresolvable: true}
+	}
+	if reason := filterNodeLoad(request, info); reason != "" {
+		return NodeFilterResult{Reason: reason, Message: exclusionMessage(request, info, reason), Unresolvable: !as.featureGate.Enabled(features.Preemption)}
+	}
+	return NodeFilterResult{}
+}
+
+// FilterNode pod'u güncel snapshot'taki node için filtre hattından geçirir (kube-scheduler framework plugin'inin
+// Filter uzantı noktası). Snapshot'ta olmayan node types.ErrNodeNotFound döner
+func (as *AIScheduler) FilterNode(pod *corev1.Pod, nodeName string) (NodeFilterResult, error) {
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return NodeFilterResult{}, err
+	}
+	info, ok := snapshot.node(nodeName)
+	if !ok {
+		return NodeFilterResult{}, fmt.Errorf("%s: %w", nodeName, types.ErrNodeNotFound)
+	}
+	request := as.newPodRequest(pod)
+	return as.filterNodeVerdict(&request, info), nil
+}
+
+// ScoreNode pod'un node'daki heuristik skorunu HTTP API'deki tahminle aynı skorlama hattıyla (calculateNodeScore
+// ve pod'a özgü cezalar) hesaplar (kube-scheduler framework plugin'inin Score uzantı noktası). Node başına
+// çağrıldığından AI harmanlaması yapılmaz; karar kaydedilmez, pod assume edilmez
+func (as *AIScheduler) ScoreNode(ctx context.Context, pod *corev1.Pod, nodeName string) (float64, error) {
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	info, ok := snapshot.node(nodeName)
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", nodeName, types.ErrNodeNotFound)
+	}
+	request := as.newPodRequest(pod)
+	candidates := as.scoreCandidates(pod, snapshot, &request, []*nodeInfo{info})
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return candidates[0].Score, nil
+}
+
+// InScope pod'un namespace'i scheduling kapsamındaysa true döner
+func (as *AIScheduler) InScope(pod *corev1.Pod) bool {
+	return as.currentConfig().Namespaces.Matches(pod.Namespace)
+}
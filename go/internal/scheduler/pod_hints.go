@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Pod annotation ipuçları
+const (
+	HintStrategy     = "ai-scheduler/strategy"      // spread veya binpack
+	HintAvoidNodes   = "ai-scheduler/avoid-nodes"   // Virgülle ayrılmış node adları veya glob desenleri
+	HintMinStability = "ai-scheduler/min-stability" // Node'un 24 saatlik kararlılık skoru alt sınırı (0-1)
+)
+
+// Pod ipuçlarının varsayılanları
+const (
+	defaultHintPenalty   = 50.0
+	defaultMaxAvoidNodes = 10
+)
+
+// podHints pod annotation'larından çözülmüş skorlama tercihleri
+type podHints struct {
+	strategy     string
+	avoid        []string
+	minStability float64
+	penalty      float64
+}
+
+// hintsFor pod'un annotation ipuçlarını çözer. İpuçları kapalıysa, namespace izinli değilse veya geçerli ipucu yoksa nil döner.
+// Geçersiz değerler loglanıp atlanır
+func (as *AIScheduler) hintsFor(pod *corev1.Pod) *podHints {
+	cfg := as.currentConfig().PodHints
+	if !cfg.Enabled || pod == nil || len(pod.Annotations) == 0 || !cfg.Namespaces.Matches(pod.Namespace) {
+		return nil
+	}
+	if cfg.Penalty <= 0 {
+		cfg.Penalty = defaultHintPenalty
+	}
+	if cfg.MaxAvoidNodes <= 0 {
+		cfg.MaxAvoidNodes = defaultMaxAvoidNodes
+	}
+
+	hints := &podHints{penalty: cfg.Penalty}
+	found := false
+
+	if value, ok := pod.Annotations[HintStrategy]; ok {
+		strategy := strings.ToLower(strings.TrimSpace(value))
+		if validStrategy(strategy) {
+			hints.strategy = strategy
+			found = true
+		} else {
+			logrus.Warnf("%s/%s: geçersiz %s ipucu yok sayıldı: %s", pod.Namespace, pod.Name, HintStrategy, value)
+		}
+	}
+
+	if value, ok := pod.Annotations[HintAvoidNodes]; ok {
+		for _, pattern := range strings.Split(value, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern == "" {
+				continue
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				logrus.Warnf("%s/%s: geçersiz %s deseni yok sayıldı: %s", pod.Namespace, pod.Name, HintAvoidNodes, pattern)
+				continue
+			}
+			if len(hints.avoid) == cfg.MaxAvoidNodes {
+				logrus.Warnf("%s/%s: %s en fazla %d desen içerebilir, fazlası yok sayıldı", pod.Namespace, pod.Name, HintAvoidNodes, cfg.MaxAvoidNodes)
+				break
+			}
+			hints.avoid = append(hints.avoid, pattern)
+		}
+		found = found || len(hints.avoid) > 0
+	}
+
+	if value, ok := pod.Annotations[HintMinStability]; ok {
+		minStability, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || minStability <= 0 || minStability > 1 {
+			logrus.Warnf("%s/%s: geçersiz %s ipucu yok sayıldı: %s", pod.Namespace, pod.Name, HintMinStability, value)
+		} else {
+			hints.minStability = minStability
+			found = true
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	return hints
+}
+
+// avoids node pod'un kaçındığı node'lardan mı
+func (h *podHints) avoids(nodeName string) bool {
+	for _, pattern := range h.avoid {
+		if matched, _ := path.Match(pattern, nodeName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// hintPenalty node pod'un kaçındığı node'lardansa veya kararlılığı pod'un alt sınırının altındaysa ceza döndürür
+func (as *AIScheduler) hintPenalty(hints *podHints, node *corev1.Node) (float64, string) {
+	if hints == nil {
+		return 0, ""
+	}
+
+	if hints.avoids(node.Name) {
+		return hints.penalty, fmt.Sprintf("Pod ipucu cezası: %.1f (node %s listesinde)", hints.penalty, HintAvoidNodes)
+	}
+	if hints.minStability > 0 {
+		stability := as.nodeAnalysis(node.Name).StabilityScore
+		if stability < hints.minStability {
+			return hints.penalty, fmt.Sprintf("Pod ipucu cezası: %.1f (kararlılık: %.2f < %.2f)", hints.penalty, stability, hints.minStability)
+		}
+	}
+	return 0, ""
+}
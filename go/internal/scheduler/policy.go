@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ai-scheduler/internal/cron"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Node seçim stratejileri
+const (
+	StrategySpread  = "spread"  // Boş node'lar tercih edilir, yük dağıtılır
+	StrategyBinPack = "binpack" // Dolu node'lar tercih edilir, boşalan node'lar kapatılabilir
+)
+
+const (
+	// policyInterval etkin politikanın yeniden değerlendirilme aralığı (cron dakika çözünürlüğünde)
+	policyInterval = 30 * time.Second
+	// maxPolicyDuration politika penceresinin üst sınırı
+	maxPolicyDuration = 31 * 24 * time.Hour
+)
+
+// PolicyStatus etkin zamanlanmış politika ve yüklü politikalar
+type PolicyStatus struct {
+	Active   string     `json:"active,omitempty"` // Boşsa temel konfigürasyon geçerli
+	Schedule string     `json:"schedule,omitempty"`
+	Strategy string     `json:"strategy"`
+	Since    time.Time  `json:"since"`
+	Until    *time.Time `json:"until,omitempty"`
+	Policies []string   `json:"policies"`
+}
+
+// scheduledPolicy cron ifadesi çözülmüş politika
+type scheduledPolicy struct {
+	types.ScheduledPolicy
+	schedule *cron.Schedule
+}
+
+// validStrategy bilinen bir strateji mi
+func validStrategy(strategy string) bool {
+	return strategy == StrategySpread || strategy == StrategyBinPack
+}
+
+// strategyOf konfigürasyonun stratejisini döndürür, boş veya bilinmeyen strateji spread sayılır
+func strategyOf(cfg *types.SchedulerConfig) string {
+	if cfg.Strategy == StrategyBinPack {
+		return StrategyBinPack
+	}
+	return StrategySpread
+}
+
+// compilePolicies politikaların cron ifadelerini çözer, geçersiz politikalar loglanıp atlanır
+func compilePolicies(policies []types.ScheduledPolicy) []scheduledPolicy {
+	compiled := make([]scheduledPolicy, 0, len(policies))
+	for i, policy := range policies {
+		if policy.Name == "" {
+			policy.Name = fmt.Sprintf("policy-%d", i)
+		}
+
+		schedule, err := cron.Parse(policy.Schedule)
+		if err != nil {
+			logrus.Warnf("Politika %s yok sayıldı: %v", policy.Name, err)
+			continue
+		}
+		if policy.Duration <= 0 || policy.Duration > maxPolicyDuration {
+			logrus.Warnf("Politika %s yok sayıldı: süre 0-%s aralığında olmalı: %s", policy.Name, maxPolicyDuration, policy.Duration)
+			continue
+		}
+		if policy.Strategy != "" && !validStrategy(policy.Strategy) {
+			logrus.Warnf("Politika %s yok sayıldı: bilinmeyen strateji: %s", policy.Name, policy.Strategy)
+			continue
+		}
+
+		compiled = append(compiled, scheduledPolicy{ScheduledPolicy: policy, schedule: schedule})
+	}
+	return compiled
+}
+
+// activePolicy now anında penceresi açık ilk politikayı ve pencerenin başlangıcını döndürür, yoksa nil
+func activePolicy(policies []scheduledPolicy, now time.Time) (*scheduledPolicy, time.Time) {
+	for i := range policies {
+		start, ok := policies[i].schedule.Last(now, policies[i].Duration)
+		if ok && now.Sub(start) < policies[i].Duration {
+			return &policies[i], start
+		}
+	}
+	return nil, time.Time{}
+}
+
+// withPolicy temel konfigürasyonun ayarlı ağırlıkları, profil ve politika uygulanmış kopyasını döndürür. Profilin
+// ağırlıkları ayarlı ağırlıkların, politikanın ağırlıkları profilinkilerin yerine geçer
+func withPolicy(base *types.SchedulerConfig, profile *types.SchedulingProfile, policy *scheduledPolicy, tuned map[string]float64) *types.SchedulerConfig {
+	cfg := *base
+	if cfg.ScoringTuning.Enabled {
+		applyTunedWeights(&cfg.Scoring, tuned, cfg.ScoringTuning.Bounds)
+	}
+	withProfile(&cfg, profile)
+	if policy != nil {
+		if policy.Strategy != "" {
+			cfg.Strategy = policy.Strategy
+		}
+		if policy.Scoring != nil {
+			cfg.Scoring = *policy.Scoring
+		}
+	}
+	return &cfg
+}
+
+// resolvePolicy now anında geçerli politikayı seçer ve strateji ile ağırlıkları tek konfigürasyon değişimiyle uygular.
+// force false ise politika değişmediğinde hiçbir şey yapmaz. configMu yazma için tutulurken çağrılmalıdır
+func (as *AIScheduler) resolvePolicy(now time.Time, force bool) bool {
+	policy, start := activePolicy(as.policies, now.In(as.calendar.location))
+
+	name := ""
+	if policy != nil {
+		name = policy.Name
+	}
+	if !force && name == as.policy.Active && (policy == nil || start.Equal(as.policy.Since)) {
+		return false
+	}
+
+	profiles := &as.profiles
+	as.config = withPolicy(as.baseConfig, profiles.profiles[profiles.bindings.Active], policy, as.tunedWeights)
+	profiles.configs = profileConfigs(as.baseConfig, as.config, profiles.bindings, profiles.profiles, policy, as.tunedWeights)
+
+	status := PolicyStatus{Active: name, Strategy: strategyOf(as.config), Since: now}
+	if policy != nil {
+		until := start.Add(policy.Duration)
+		status.Schedule = policy.Schedule
+		status.Since = start
+		status.Until = &until
+	} else if as.policy.Active == "" && !as.policy.Since.IsZero() {
+		// Temel konfigürasyon zaten geçerliydi
+		status.Since = as.policy.Since
+	}
+	as.policy = status
+	return true
+}
+
+// applyPolicies etkin politika değiştiyse konfigürasyonu değiştirir ve skor cache'lerini temizler
+func (as *AIScheduler) applyPolicies(now time.Time) {
+	as.configMu.Lock()
+	changed := as.resolvePolicy(now, false)
+	status := as.policy
+	as.configMu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	// Önceki politikanın ağırlıklarıyla hesaplanan skorlar geçersiz
+	as.scores.clear()
+	as.ranking.reset()
+	as.smoothing.reset()
+
+	if status.Active == "" {
+		logrus.Infof("Zamanlanmış politika sona erdi, temel konfigürasyon geçerli (strateji: %s)", status.Strategy)
+		return
+	}
+	logrus.Infof("Zamanlanmış politika %s devreye girdi (strateji: %s, bitiş: %s)", status.Active, status.Strategy, status.Until.Format(time.RFC3339))
+}
+
+// policyLoop etkin politikayı periyodik olarak yeniden değerlendirir
+func (as *AIScheduler) policyLoop(ctx context.Context) {
+	ticker := time.NewTicker(policyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			as.applyPolicies(as.now())
+		}
+	}
+}
+
+// Policy etkin zamanlanmış politikayı ve yüklü politikaları döndürür
+func (as *AIScheduler) Policy() PolicyStatus {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	status := as.policy
+	status.Policies = make([]string, 0, len(as.policies))
+	for _, policy := range as.policies {
+		status.Policies = append(status.Policies, policy.Name)
+	}
+	return status
+}
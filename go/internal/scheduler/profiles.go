@@ -0,0 +1,528 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// profileHistorySize bellekte tutulan profil değişikliği sayısı
+const profileHistorySize = 100
+
+// Profil değişikliği kaynakları
+const (
+	ProfileSourceConfig = "config" // Konfigürasyon yüklendi veya değişti
+	ProfileSourceAPI    = "api"    // Operatörün çalışma anındaki değişikliği
+)
+
+// ErrUnknownProfile konfigürasyonda tanımlı olmayan profil
+var ErrUnknownProfile = errors.New("bilinmeyen profil")
+
+// ProfileBindings etkin profil ve namespace bağları, boş profil temel strateji ve ağırlıklar demektir
+type ProfileBindings struct {
+	Active     string            `json:"active"`
+	Namespaces map[string]string `json:"namespaces"`
+}
+
+// ProfileChange etkin profilin veya namespace bağlarının tek değişikliği (denetim kaydı)
+type ProfileChange struct {
+	ID       uint64          `json:"id"`
+	Time     time.Time       `json:"time"`
+	Source   string          `json:"source"`
+	Reason   string          `json:"reason,omitempty"`
+	Previous ProfileBindings `json:"previous"`
+	Bindings ProfileBindings `json:"bindings"`
+}
+
+// ProfileStatus tanımlı profiller, geçerli bağlar ve değişiklik geçmişi
+type ProfileStatus struct {
+	ProfileBindings
+	Profiles []string `json:"profiles"`
+	// Changes en yeniden eskiye değişiklik geçmişi
+	Changes []ProfileChange `json:"changes"`
+}
+
+// ProfileUpdate profil değişikliği isteği, nil alanlar değişmez
+type ProfileUpdate struct {
+	Active *string
+	// Namespaces verilirse bağların tamamının yerine geçer
+	Namespaces map[string]string
+	Reason     string
+}
+
+// NodeRankChange node'un geçerli ve önerilen profillerle skoru ve sıralamadaki yeri (1 en iyi)
+type NodeRankChange struct {
+	Node          string  `json:"node"`
+	CurrentScore  float64 `json:"current_score"`
+	CurrentRank   int     `json:"current_rank"`
+	ProposedScore float64 `json:"proposed_score"`
+	ProposedRank  int     `json:"proposed_rank"`
+	// Shift sıralamadaki yükselme (pozitif) veya düşme (negatif)
+	Shift int `json:"shift"`
+}
+
+// RankingPreview bir kapsamın (etkin profil veya bağlı namespace) node sıralamasının değişimi
+type RankingPreview struct {
+	// Namespace boşsa etkin profili kullanan (bağı olmayan) tüm namespace'ler
+	Namespace       string           `json:"namespace,omitempty"`
+	CurrentProfile  string           `json:"current_profile"`
+	ProposedProfile string           `json:"proposed_profile"`
+	Nodes           []NodeRankChange `json:"nodes"`
+}
+
+// ProfilePreview profil değişikliğinin uygulanmadan önizlemesi. Skorlar yumuşatılmamış heuristik skorlardır,
+// pod'a özgü filtre ve cezalar dahil değildir
+type ProfilePreview struct {
+	Current  ProfileBindings  `json:"current"`
+	Proposed ProfileBindings  `json:"proposed"`
+	Rankings []RankingPreview `json:"rankings"`
+}
+
+// profileState tanımlı profiller, etkin profil, namespace bağları ve bağlı namespace'lerin çözülmüş konfigürasyonları.
+// configMu ile korunur
+type profileState struct {
+	profiles map[string]*types.SchedulingProfile
+	bindings ProfileBindings
+	// configs etkin profilden farklı profile bağlı namespace'lerin politika ve ayarlı ağırlıklar uygulanmış konfigürasyonu
+	configs map[string]*types.SchedulerConfig
+	// api bağlar API ile değiştirildi, konfigürasyondaki bağlar değişmedikçe yeniden yüklemede korunur
+	api     bool
+	changes []ProfileChange
+	nextID  uint64
+}
+
+// scoreOverride pod'un skorlamasında etkin konfigürasyonun yerine geçen strateji ve ağırlıklar (namespace profili,
+// strateji ipucu). Sıfır değeri etkin konfigürasyon demektir
+type scoreOverride struct {
+	strategy string               // Boş değilse etkin stratejinin yerine geçer
+	scoring  *types.ScoringConfig // nil değilse etkin ağırlıkların yerine geçer
+}
+
+// empty etkin konfigürasyondan farkı yok mu
+func (o scoreOverride) empty() bool {
+	return o.strategy == "" && o.scoring == nil
+}
+
+// overrideBetween target konfigürasyonun stratejisinin ve ağırlıklarının current'tan farklı olanlarını döndürür
+func overrideBetween(current, target *types.SchedulerConfig) scoreOverride {
+	var override scoreOverride
+	if strategy := strategyOf(target); strategy != strategyOf(current) {
+		override.strategy = strategy
+	}
+	if target.Scoring != current.Scoring {
+		scoring := target.Scoring
+		override.scoring = &scoring
+	}
+	return override
+}
+
+// compileProfiles profilleri ada göre indeksler, adsız, tekrarlanan veya geçersiz stratejili profiller loglanıp atlanır
+func compileProfiles(profiles []types.SchedulingProfile) map[string]*types.SchedulingProfile {
+	compiled := make(map[string]*types.SchedulingProfile, len(profiles))
+	for i := range profiles {
+		profile := profiles[i]
+		switch {
+		case profile.Name == "":
+			logrus.Warnf("Adsız profil yok sayıldı (sıra: %d)", i)
+			continue
+		case compiled[profile.Name] != nil:
+			logrus.Warnf("Profil %s birden fazla tanımlı, ilki kullanılacak", profile.Name)
+			continue
+		case profile.Strategy != "" && !validStrategy(profile.Strategy):
+			logrus.Warnf("Profil %s yok sayıldı: bilinmeyen strateji: %s", profile.Name, profile.Strategy)
+			continue
+		}
+		compiled[profile.Name] = &profile
+	}
+	return compiled
+}
+
+// validateBindings bağlardaki profillerin tanımlı olduğunu kontrol eder
+func validateBindings(profiles map[string]*types.SchedulingProfile, bindings ProfileBindings) error {
+	if bindings.Active != "" && profiles[bindings.Active] == nil {
+		return fmt.Errorf("%s: %w", bindings.Active, ErrUnknownProfile)
+	}
+	for namespace, name := range bindings.Namespaces {
+		if profiles[name] == nil {
+			return fmt.Errorf("namespace %s: %s: %w", namespace, name, ErrUnknownProfile)
+		}
+	}
+	return nil
+}
+
+// configBindings konfigürasyondaki bağları döndürür, tanımlı olmayan profillere bağlar loglanıp atlanır
+func configBindings(cfg *types.SchedulerConfig, profiles map[string]*types.SchedulingProfile) ProfileBindings {
+	bindings := ProfileBindings{Namespaces: make(map[string]string, len(cfg.NamespaceProfiles))}
+	if cfg.Profile != "" {
+		if profiles[cfg.Profile] == nil {
+			logrus.Warnf("Etkin profil %s tanımlı değil, temel strateji ve ağırlıklar kullanılacak", cfg.Profile)
+		} else {
+			bindings.Active = cfg.Profile
+		}
+	}
+	for namespace, name := range cfg.NamespaceProfiles {
+		if profiles[name] == nil {
+			logrus.Warnf("Namespace %s profili %s tanımlı değil, bağ yok sayıldı", namespace, name)
+			continue
+		}
+		bindings.Namespaces[namespace] = name
+	}
+	return bindings
+}
+
+// sameBindings iki bağ kümesi aynı mı
+func sameBindings(a, b ProfileBindings) bool {
+	if a.Active != b.Active || len(a.Namespaces) != len(b.Namespaces) {
+		return false
+	}
+	for namespace, name := range a.Namespaces {
+		if b.Namespaces[namespace] != name {
+			return false
+		}
+	}
+	return true
+}
+
+// copyBindings bağların kopyasını döndürür
+func copyBindings(bindings ProfileBindings) ProfileBindings {
+	namespaces := make(map[string]string, len(bindings.Namespaces))
+	for namespace, name := range bindings.Namespaces {
+		namespaces[namespace] = name
+	}
+	return ProfileBindings{Active: bindings.Active, Namespaces: namespaces}
+}
+
+// withProfile temel konfigürasyona profilin stratejisini ve ağırlıklarını uygular, profil nil ise değişmez
+func withProfile(cfg *types.SchedulerConfig, profile *types.SchedulingProfile) {
+	if profile == nil {
+		return
+	}
+	if profile.Strategy != "" {
+		cfg.Strategy = profile.Strategy
+	}
+	if profile.Scoring != nil {
+		cfg.Scoring = *profile.Scoring
+	}
+}
+
+// loadProfiles konfigürasyonun profillerini yükler. Bağlar API ile değiştirildiyse ve konfigürasyondaki bağlar
+// değişmediyse API'nin bağları korunur, kaldırılan profillere bağlar düşürülür. configMu yazma için tutulurken
+// çağrılmalıdır
+func (as *AIScheduler) loadProfiles(previous, cfg *types.SchedulerConfig) {
+	p := &as.profiles
+	profiles := compileProfiles(cfg.Profiles)
+	bindings := configBindings(cfg, profiles)
+
+	configured := func(cfg *types.SchedulerConfig) ProfileBindings {
+		return ProfileBindings{Active: cfg.Profile, Namespaces: cfg.NamespaceProfiles}
+	}
+	if p.api && previous != nil && sameBindings(configured(previous), configured(cfg)) {
+		kept := copyBindings(p.bindings)
+		if kept.Active != "" && profiles[kept.Active] == nil {
+			logrus.Warnf("API ile seçilen profil %s konfigürasyondan kaldırıldı, temel strateji ve ağırlıklar kullanılacak", kept.Active)
+			kept.Active = ""
+		}
+		for namespace, name := range kept.Namespaces {
+			if profiles[name] == nil {
+				logrus.Warnf("Namespace %s profili %s konfigürasyondan kaldırıldı, bağ düşürüldü", namespace, name)
+				delete(kept.Namespaces, namespace)
+			}
+		}
+		bindings = kept
+	} else {
+		p.api = false
+	}
+
+	p.profiles = profiles
+	if !sameBindings(p.bindings, bindings) {
+		as.recordProfileChangeLocked(as.now(), ProfileSourceConfig, "", bindings)
+	}
+	p.bindings = bindings
+}
+
+// recordProfileChangeLocked bağ değişikliğini denetim geçmişine ekler ve loglar. configMu tutulurken çağrılmalıdır
+func (as *AIScheduler) recordProfileChangeLocked(now time.Time, source, reason string, bindings ProfileBindings) {
+	p := &as.profiles
+	p.nextID++
+	change := ProfileChange{
+		ID:       p.nextID,
+		Time:     now,
+		Source:   source,
+		Reason:   reason,
+		Previous: copyBindings(p.bindings),
+		Bindings: copyBindings(bindings),
+	}
+	p.changes = append(p.changes, change)
+	if len(p.changes) > profileHistorySize {
+		p.changes = append([]ProfileChange(nil), p.changes[len(p.changes)-profileHistorySize:]...)
+	}
+
+	logrus.Infof("Skorlama profili değişti (%s): etkin: %s, namespace bağları: %v, gerekçe: %s", source,
+		profileLabel(bindings.Active), bindings.Namespaces, reason)
+}
+
+// profileLabel profil adını loglar ve özetler için döndürür, boş profil temel konfigürasyondur
+func profileLabel(name string) string {
+	if name == "" {
+		return "(temel)"
+	}
+	return name
+}
+
+// profileConfigs etkin profilden farklı profile bağlı namespace'lerin konfigürasyonlarını kurar
+func profileConfigs(base, current *types.SchedulerConfig, bindings ProfileBindings, profiles map[string]*types.SchedulingProfile,
+	policy *scheduledPolicy, tuned map[string]float64) map[string]*types.SchedulerConfig {
+	var configs map[string]*types.SchedulerConfig
+	for namespace, name := range bindings.Namespaces {
+		if name == bindings.Active {
+			continue
+		}
+		cfg := withPolicy(base, profiles[name], policy, tuned)
+		if overrideBetween(current, cfg).empty() {
+			continue
+		}
+		if configs == nil {
+			configs = make(map[string]*types.SchedulerConfig)
+		}
+		configs[namespace] = cfg
+	}
+	return configs
+}
+
+// scoreOverrideFor pod'un namespace profilinin ve strateji ipucunun etkin konfigürasyondan farkını döndürür.
+// Strateji ipucu namespace profilinin stratejisinden önceliklidir
+func (as *AIScheduler) scoreOverrideFor(pod *corev1.Pod, hints *podHints) scoreOverride {
+	as.configMu.RLock()
+	current, target := as.config, as.profiles.configs[pod.Namespace]
+	as.configMu.RUnlock()
+
+	if target == nil {
+		target = current
+	}
+	override := overrideBetween(current, target)
+	if hints != nil && hints.strategy != "" {
+		override.strategy = ""
+		if hints.strategy != strategyOf(current) {
+			override.strategy = hints.strategy
+		}
+	}
+	return override
+}
+
+// overriddenNodeScore pod'un skorlaması etkin konfigürasyondan farklıysa node'u (cache'siz) yeniden skorlar,
+// değilse cache'li skoru döndürür
+func (as *AIScheduler) overriddenNodeScore(override scoreOverride, node *corev1.Node) (float64, string) {
+	if override.empty() {
+		return as.cachedNodeScore(node)
+	}
+	return as.calculateNodeScore(node, override)
+}
+
+// Profiles tanımlı profilleri, geçerli bağları ve değişiklik geçmişini döndürür
+func (as *AIScheduler) Profiles() ProfileStatus {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	p := &as.profiles
+	status := ProfileStatus{ProfileBindings: copyBindings(p.bindings), Profiles: make([]string, 0, len(p.profiles))}
+	for name := range p.profiles {
+		status.Profiles = append(status.Profiles, name)
+	}
+	sort.Strings(status.Profiles)
+	status.Changes = make([]ProfileChange, 0, len(p.changes))
+	for i := len(p.changes) - 1; i >= 0; i-- {
+		status.Changes = append(status.Changes, p.changes[i])
+	}
+	return status
+}
+
+// profileChanges profil değişikliği geçmişini eskiden yeniye döndürür
+func (as *AIScheduler) profileChanges() []ProfileChange {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	return append([]ProfileChange(nil), as.profiles.changes...)
+}
+
+// proposedBindings güncellemenin uygulandığı bağları döndürür
+func proposedBindings(current ProfileBindings, update ProfileUpdate) ProfileBindings {
+	proposed := copyBindings(current)
+	if update.Active != nil {
+		proposed.Active = *update.Active
+	}
+	if update.Namespaces != nil {
+		proposed.Namespaces = make(map[string]string, len(update.Namespaces))
+		for namespace, name := range update.Namespaces {
+			proposed.Namespaces[namespace] = name
+		}
+	}
+	return proposed
+}
+
+// SetProfile etkin profili ve namespace bağlarını değiştirir, değişikliği denetim geçmişine ekler ve skor
+// cache'lerini temizler. Bilinmeyen profil ErrUnknownProfile döndürür
+func (as *AIScheduler) SetProfile(update ProfileUpdate) (ProfileBindings, error) {
+	now := as.now()
+	as.configMu.Lock()
+	proposed := proposedBindings(as.profiles.bindings, update)
+	if err := validateBindings(as.profiles.profiles, proposed); err != nil {
+		as.configMu.Unlock()
+		return ProfileBindings{}, err
+	}
+	if sameBindings(as.profiles.bindings, proposed) {
+		as.configMu.Unlock()
+		return proposed, nil
+	}
+
+	reason := update.Reason
+	if reason == "" {
+		reason = "API"
+	}
+	as.recordProfileChangeLocked(now, ProfileSourceAPI, reason, proposed)
+	as.profiles.bindings = proposed
+	as.profiles.api = true
+	as.resolvePolicy(now, true)
+	as.configMu.Unlock()
+
+	// Önceki profilin ağırlıklarıyla hesaplanan skorlar geçersiz
+	as.scores.clear()
+	as.ranking.reset()
+	as.smoothing.reset()
+	return copyBindings(proposed), nil
+}
+
+// PreviewProfile güncellemeyi uygulamadan node sıralamalarının nasıl değişeceğini hesaplar: etkin profil
+// değişiyorsa bağı olmayan namespace'lerin, bağı değişen her namespace için o namespace'in sıralaması
+func (as *AIScheduler) PreviewProfile(update ProfileUpdate) (*ProfilePreview, error) {
+	now := as.now()
+	as.configMu.RLock()
+	current := copyBindings(as.profiles.bindings)
+	proposed := proposedBindings(current, update)
+	profiles := as.profiles.profiles
+	base, tuned := as.baseConfig, as.tunedWeights
+	policy, _ := activePolicy(as.policies, now.In(as.calendar.location))
+	as.configMu.RUnlock()
+
+	if err := validateBindings(profiles, proposed); err != nil {
+		return nil, err
+	}
+
+	configFor := func(name string) *types.SchedulerConfig {
+		return withPolicy(base, profiles[name], policy, tuned)
+	}
+	type scope struct {
+		namespace         string
+		current, proposed string
+	}
+	var scopes []scope
+	if current.Active != proposed.Active {
+		scopes = append(scopes, scope{current: current.Active, proposed: proposed.Active})
+	}
+	namespaces := make(map[string]bool)
+	for namespace := range current.Namespaces {
+		namespaces[namespace] = true
+	}
+	for namespace := range proposed.Namespaces {
+		namespaces[namespace] = true
+	}
+	names := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		names = append(names, namespace)
+	}
+	sort.Strings(names)
+	for _, namespace := range names {
+		before, ok := current.Namespaces[namespace]
+		if !ok {
+			before = current.Active
+		}
+		after, ok := proposed.Namespaces[namespace]
+		if !ok {
+			after = proposed.Active
+		}
+		if before != after {
+			scopes = append(scopes, scope{namespace: namespace, current: before, proposed: after})
+		}
+	}
+
+	preview := &ProfilePreview{Current: current, Proposed: proposed, Rankings: make([]RankingPreview, 0, len(scopes))}
+	if len(scopes) == 0 {
+		return preview, nil
+	}
+
+	nodes, err := as.listNodes()
+	if err != nil {
+		return nil, fmt.Errorf("node listesi alınamadı: %v", err)
+	}
+	inputs := make([]scoreInputs, len(nodes))
+	for i, node := range nodes {
+		inputs[i], _ = as.currentScoreInputs(node)
+	}
+
+	effective := as.currentConfig()
+	for _, s := range scopes {
+		currentScores := as.previewScores(nodes, inputs, overrideBetween(effective, configFor(s.current)))
+		proposedScores := as.previewScores(nodes, inputs, overrideBetween(effective, configFor(s.proposed)))
+		preview.Rankings = append(preview.Rankings, RankingPreview{
+			Namespace:       s.namespace,
+			CurrentProfile:  s.current,
+			ProposedProfile: s.proposed,
+			Nodes:           rankChanges(nodes, currentScores, proposedScores),
+		})
+	}
+	return preview, nil
+}
+
+// previewScores node'ları verilen farkla (yumuşatmasız) skorlar
+func (as *AIScheduler) previewScores(nodes []*corev1.Node, inputs []scoreInputs, override scoreOverride) []float64 {
+	scores := make([]float64, len(nodes))
+	for i, node := range nodes {
+		nodeInputs := inputs[i]
+		nodeInputs.override = override
+		reasons := getReasonBuilder()
+		scores[i] = as.scoreNode(node, &nodeInputs, reasons, nil)
+		reasons.release()
+	}
+	return scores
+}
+
+// rankChanges iki skor kümesine göre node'ların sıralamadaki yerlerini karşılaştırır, önerilen sıralamaya göre döner
+func rankChanges(nodes []*corev1.Node, current, proposed []float64) []NodeRankChange {
+	ranks := func(scores []float64) []int {
+		order := make([]int, len(scores))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			if scores[order[a]] != scores[order[b]] {
+				return scores[order[a]] > scores[order[b]]
+			}
+			return nodes[order[a]].Name < nodes[order[b]].Name
+		})
+		rank := make([]int, len(scores))
+		for position, i := range order {
+			rank[i] = position + 1
+		}
+		return rank
+	}
+	currentRanks, proposedRanks := ranks(current), ranks(proposed)
+
+	changes := make([]NodeRankChange, len(nodes))
+	for i, node := range nodes {
+		changes[i] = NodeRankChange{
+			Node:          node.Name,
+			CurrentScore:  current[i],
+			CurrentRank:   currentRanks[i],
+			ProposedScore: proposed[i],
+			ProposedRank:  proposedRanks[i],
+			Shift:         currentRanks[i] - proposedRanks[i],
+		}
+	}
+	sort.Slice(changes, func(a, b int) bool { return changes[a].ProposedRank < changes[b].ProposedRank })
+	return changes
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import "sort"
+
+// PodPriority pod'un önceliğini küme kaynağının cache'inden döndürür (tahmin kuyruğunda sıralama için). API'ye
+// gidilmez, pod cache'te yoksa 0
+func (as *AIScheduler) PodPriority(namespace, podName string) int32 {
+	if as.source == nil {
+		return 0
+	}
+	pod, ok := as.source.Pod(namespace, podName)
+	if !ok {
+		return 0
+	}
+	return podPriority(pod)
+}
+
+// queueOrder bekleyen pod'ları işlenme sırasına dizer: önce yüksek öncelik (PriorityClass), aynı öncelikte
+// namespace'ler arasında sırayla, namespace içinde en eskiden başlayarak. Namespace'lerin sırası en eski
+// pod'larına göredir. QueuePosition 1'den başlayarak doldurulur
+func queueOrder(pods []PendingPod) []PendingPod {
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].Priority != pods[j].Priority {
+			return pods[i].Priority > pods[j].Priority
+		}
+		return pods[i].Age > pods[j].Age
+	})
+
+	result := make([]PendingPod, 0, len(pods))
+	for start := 0; start < len(pods); {
+		end := start
+		for end < len(pods) && pods[end].Priority == pods[start].Priority {
+			end++
+		}
+
+		// Sınıf içinde namespace kuyrukları, ilk görülen (en eski pod'u olan) namespace önce
+		var namespaces []string
+		queues := make(map[string][]PendingPod)
+		for _, pod := range pods[start:end] {
+			if _, ok := queues[pod.Namespace]; !ok {
+				namespaces = append(namespaces, pod.Namespace)
+			}
+			queues[pod.Namespace] = append(queues[pod.Namespace], pod)
+		}
+		for len(namespaces) > 0 {
+			next := namespaces[:0]
+			for _, namespace := range namespaces {
+				queue := queues[namespace]
+				result = append(result, queue[0])
+				if queues[namespace] = queue[1:]; len(queue) > 1 {
+					next = append(next, namespace)
+				}
+			}
+			namespaces = next
+		}
+		start = end
+	}
+
+	for i := range result {
+		result[i].QueuePosition = i + 1
+	}
+	return result
+}
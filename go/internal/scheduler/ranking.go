@@ -0,0 +1,295 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// rankedIndex metrik değişimlerinde artımlı güncellenen, skora göre azalan sıralı node indeksi
+type rankedIndex struct {
+	mutex sync.RWMutex
+	order []NodeScore
+	dirty map[string]bool
+	built bool
+}
+
+// position node'un sıralamadaki yerini döndürür, yoksa -1
+func (r *rankedIndex) position(nodeName string) int {
+	for i := range r.order {
+		if r.order[i].NodeName == nodeName {
+			return i
+		}
+	}
+	return -1
+}
+
+// update node'un skorunu sıralamada günceller, eşit skorlarda node adına göre sıralanır
+func (r *rankedIndex) update(entry NodeScore) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if i := r.position(entry.NodeName); i >= 0 {
+		r.order = append(r.order[:i], r.order[i+1:]...)
+	}
+
+	i := sort.Search(len(r.order), func(i int) bool {
+		if r.order[i].Score != entry.Score {
+			return r.order[i].Score < entry.Score
+		}
+		return r.order[i].NodeName > entry.NodeName
+	})
+	r.order = append(r.order, NodeScore{})
+	copy(r.order[i+1:], r.order[i:])
+	r.order[i] = entry
+}
+
+// remove node'u sıralamadan çıkarır
+func (r *rankedIndex) remove(nodeName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if i := r.position(nodeName); i >= 0 {
+		r.order = append(r.order[:i], r.order[i+1:]...)
+	}
+}
+
+// replace sıralamayı baştan kurar. Kurulum sırasında işaretlenen node'lar kurulumdaki skoru bayat olabileceği için
+// işaretli kalır, sonraki yenilemede yeniden skorlanır
+func (r *rankedIndex) replace(entries []NodeScore) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].NodeName < entries[j].NodeName
+	})
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.order = entries
+	r.built = true
+}
+
+// reset sıralamayı geçersiz kılar, sonraki yenilemede baştan kurulur
+func (r *rankedIndex) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.order = nil
+	r.dirty = nil
+	r.built = false
+}
+
+// isBuilt sıralama kurulmuşsa true döner
+func (r *rankedIndex) isBuilt() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.built
+}
+
+// markDirty node'u yeniden skorlanmak üzere işaretler
+func (r *rankedIndex) markDirty(nodeName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.dirty == nil {
+		r.dirty = make(map[string]bool)
+	}
+	r.dirty[nodeName] = true
+}
+
+// takeDirty işaretli node'ları döndürür ve işaretleri temizler
+func (r *rankedIndex) takeDirty() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := make([]string, 0, len(r.dirty))
+	for name := range r.dirty {
+		names = append(names, name)
+	}
+	r.dirty = nil
+	return names
+}
+
+// each sıralamayı en yüksek skordan başlayarak gezer, fn false dönerse durur
+func (r *rankedIndex) each(fn func(entry NodeScore) bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, entry := range r.order {
+		if !fn(entry) {
+			return
+		}
+	}
+}
+
+// incrementalScoring artımlı skorlama açıksa true döner
+func (as *AIScheduler) incrementalScoring() bool {
+	return as.currentConfig().IncrementalScoring
+}
+
+// rebuildRanking snapshot'taki tüm node'ları skorlayarak sıralamayı baştan kurar. Kurulumdan önceki işaretler
+// kurulumla karşılandığı için temizlenir, kurulum sürerken gelenler korunur
+func (as *AIScheduler) rebuildRanking() error {
+	as.ranking.takeDirty()
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]NodeScore, 0, len(snapshot.nodes))
+	for _, info := range snapshot.nodes {
+		score, reason := as.calculateNodeScore(info.node, scoreOverride{})
+		entries = append(entries, NodeScore{NodeName: info.node.Name, Score: score, Reason: reason})
+	}
+	as.ranking.replace(entries)
+	return nil
+}
+
+// rescoreNode node'u yeniden skorlar, node artık yoksa sıralamadan çıkarır
+func (as *AIScheduler) rescoreNode(nodeName string) {
+	node, err := as.getNode(nodeName)
+	if err != nil {
+		as.ranking.remove(nodeName)
+		return
+	}
+
+	score, reason := as.calculateNodeScore(node, scoreOverride{})
+	as.ranking.update(NodeScore{NodeName: node.Name, Score: score, Reason: reason})
+}
+
+// refreshRanking metrikleri değişen node'ları yeniden skorlar, sıralama hiç kurulmadıysa baştan kurar
+func (as *AIScheduler) refreshRanking() {
+	if !as.ranking.isBuilt() {
+		if err := as.rebuildRanking(); err != nil {
+			logrus.Warnf("Node sıralaması kurulamadı: %v", err)
+		}
+		return
+	}
+
+	for _, nodeName := range as.ranking.takeDirty() {
+		as.rescoreNode(nodeName)
+	}
+}
+
+// metricNodeName metriğin ait olduğu node'u döndürür
+func metricNodeName(metric interface{}) (string, bool) {
+	switch m := metric.(type) {
+	case types.NodeMetrics:
+		return m.NodeName, true
+	case types.PodMetrics:
+		return m.NodeName, m.NodeName != ""
+	}
+	return "", false
+}
+
+// PredictFromRanking tahmini metrik değişimlerinde güncellenen hazır sıralamadan yapar (gecikmeye duyarlı çağıranlar için).
+// Node'lar istek anında skorlanmaz ve AI harmanlaması yapılmaz; artımlı skorlama kapalıysa PredictBestNode kullanılır
+func (as *AIScheduler) PredictFromRanking(ctx context.Context, podName, namespace string) (*NodeScore, error) {
+	result, err := as.predictFromRanking(ctx, podName, namespace)
+	if err != nil {
+		as.reportError(types.ErrorComponentPredict, err)
+	}
+	return result, err
+}
+
+// predictFromRanking pod'u hazır sıralamadaki node'larla eşleştirir
+func (as *AIScheduler) predictFromRanking(ctx context.Context, podName, namespace string) (*NodeScore, error) {
+	if !as.incrementalScoring() {
+		return as.predictBestNode(ctx, podName, namespace)
+	}
+
+	// Namespace kapsam kontrolü
+	if !as.currentConfig().Namespaces.Matches(namespace) {
+		return nil, fmt.Errorf("%s: %w", namespace, ErrNamespaceOutOfScope)
+	}
+
+	pod, err := as.getPod(ctx, namespace, podName)
+	if err != nil {
+		return nil, fmt.Errorf("pod alınamadı: %w", err)
+	}
+
+	// Farklı strateji veya ağırlıklarla skorlanan pod'un sıralaması hazır sıralamayla aynı değildir
+	hints := as.hintsFor(pod)
+	if !as.scoreOverrideFor(pod, hints).empty() {
+		return as.predictBestNode(ctx, podName, namespace)
+	}
+
+	// İlk istek sıralamayı kurar
+	if !as.ranking.isBuilt() {
+		if err := as.rebuildRanking(); err != nil {
+			return nil, err
+		}
+	}
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pod'a özgü cezalar skoru sadece düşürür, yükseltebilen tek ayar veri yerelliği bonusudur. Sıralamada en yüksek
+	// bonusla bile mevcut en iyiyi geçemeyecek node'a gelince durulur.
+	// Snapshot'ta olmayan veya filtreden geçmeyen node'lar atlanır
+	request := as.newPodRequest(pod)
+	adjustments := as.podAdjustmentsFor(pod, snapshot, &request, hints)
+	maxBonus := as.maxBonus(adjustments)
+	var best *NodeScore
+	var stale int
+	as.ranking.each(func(entry NodeScore) bool {
+		if best != nil && entry.Score+maxBonus <= best.Score {
+			return false
+		}
+
+		info, ok := snapshot.node(entry.NodeName)
+		if !ok || filterNode(&request, info) != "" {
+			return true
+		}
+		if as.isStale(entry.NodeName) {
+			stale++
+			return true
+		}
+
+		entry.Score, entry.Reason = as.applyAdjustments(adjustments, info, entry.Score, entry.Reason)
+		if best == nil || entry.Score > best.Score {
+			candidate := entry
+			best = &candidate
+		}
+		return true
+	})
+	if best == nil && stale > 0 {
+		return nil, fmt.Errorf("%s/%s: %d uygun node'un skorlama girdileri bayat: %w", namespace, podName, stale, types.ErrMetricsStale)
+	}
+	if best == nil {
+		// Elenme sebepleri sadece uygun node olmadığında tam filtrelemeyle hesaplanır
+		_, rejected := filterNodes(snapshot, &request)
+		as.recordUnschedulable(&request, rejected)
+		return nil, fmt.Errorf("%s/%s: %w", namespace, podName, types.ErrNoFeasibleNode)
+	}
+	best.Ranked = true
+	as.flagStale(best)
+
+	// İstek iptal edildiyse veya süresi dolduysa karar uygulanmaz
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Sadece gözlem modunda karar loglanır ama binding için kullanılmamalıdır
+	if as.observeOnly() {
+		best.ObserveOnly = true
+		logrus.Infof("[observe-only] %s/%s için sıralamadan tahmin: %s (skor: %.2f), binding yapılmayacak",
+			namespace, podName, best.NodeName, best.Score)
+	}
+
+	if ttl := as.currentConfig().AssumeTTL; ttl > 0 && !best.ObserveOnly && pod.Spec.NodeName == "" {
+		as.AssumePod(pod, best.NodeName, ttl)
+	}
+
+	as.recordDecision(as.decisionFor(pod, best, nil))
+	return best, nil
+}
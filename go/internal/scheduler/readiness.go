@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeReady node'un Ready koşulu True mu
+func nodeReady(node *corev1.Node) bool {
+	ready, _ := types.NodeIsReady(node)
+	return ready
+}
+
+// readinessAt node'un at anına kadarki Ready oranlarını döndürür. Geçmiş tutulmuyorsa veya node gözlenmediyse
+// güncel Ready durumu tüm pencerelere yazılır ve false döner
+func (as *AIScheduler) readinessAt(nodeName string, ready bool, at time.Time) (types.NodeReadiness, bool) {
+	if tracker := as.collector.GetNodeReadiness(); tracker != nil {
+		if readiness, ok := tracker.Readiness(nodeName, at); ok {
+			return readiness, true
+		}
+	}
+	fraction := 0.0
+	if ready {
+		fraction = 1
+	}
+	return types.NodeReadiness{Hour: fraction, Day: fraction, Week: fraction}, false
+}
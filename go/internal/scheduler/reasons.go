@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// reasonPool skor gerekçesi buffer'larını tahminler arasında yeniden kullanır
+var reasonPool = sync.Pool{
+	New: func() interface{} { return &reasonBuilder{buf: make([]byte, 0, 512)} },
+}
+
+// reasonBuilder skor gerekçelerini ara string üretmeden tek buffer'da biriktirir.
+// Çıktı eski fmt.Sprintf("Toplam skor: %.2f - %s", score, reasons) biçimiyle aynıdır
+type reasonBuilder struct {
+	buf   []byte
+	items int
+}
+
+// getReasonBuilder havuzdan boş bir builder alır
+func getReasonBuilder() *reasonBuilder {
+	r := reasonPool.Get().(*reasonBuilder)
+	r.buf = r.buf[:0]
+	r.items = 0
+	return r
+}
+
+// release builder'ı havuza geri bırakır
+func (r *reasonBuilder) release() {
+	// Aşırı büyümüş buffer'lar havuzda tutulmaz
+	if cap(r.buf) > 64*1024 {
+		return
+	}
+	reasonPool.Put(r)
+}
+
+// item yeni bir gerekçe başlatır
+func (r *reasonBuilder) item() *reasonBuilder {
+	if r.items > 0 {
+		r.buf = append(r.buf, ' ')
+	}
+	r.items++
+	return r
+}
+
+// add tek parça gerekçe ekler
+func (r *reasonBuilder) add(reason string) {
+	r.item().text(reason)
+}
+
+// text gerekçeye metin ekler
+func (r *reasonBuilder) text(s string) *reasonBuilder {
+	r.buf = append(r.buf, s...)
+	return r
+}
+
+// float gerekçeye %.<prec>f biçiminde sayı ekler
+func (r *reasonBuilder) float(v float64, prec int) *reasonBuilder {
+	r.buf = strconv.AppendFloat(r.buf, v, 'f', prec, 64)
+	return r
+}
+
+// total toplam skorla birlikte nihai gerekçe metnini tek allocation ile üretir
+func (r *reasonBuilder) total(score float64) string {
+	var scratch [32]byte
+	formatted := strconv.AppendFloat(scratch[:0], score, 'f', 2, 64)
+
+	var out strings.Builder
+	out.Grow(len("Toplam skor: ") + len(formatted) + len(" - []") + len(r.buf))
+	out.WriteString("Toplam skor: ")
+	out.Write(formatted)
+	out.WriteString(" - [")
+	out.Write(r.buf)
+	out.WriteByte(']')
+	return out.String()
+}
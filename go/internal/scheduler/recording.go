@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeUsage node'un tahmin anındaki CPU (core) ve memory (GB) kullanımı
+type NodeUsage struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory_gb"`
+}
+
+// PredictionRecord tahminin tüm girdileri ve sonucu, trace kaydı ve replay için
+type PredictionRecord struct {
+	Namespace      string                      `json:"namespace"`
+	PodName        string                      `json:"pod_name"`
+	Pod            *corev1.Pod                 `json:"pod"`
+	Nodes          []corev1.Node               `json:"nodes"`
+	NodeUsage      map[string]NodeUsage        `json:"node_usage"`
+	NodeRequests   map[string]NodeUsage        `json:"node_requests,omitempty"`  // Snapshot'taki pod istek toplamları (assume dahil)
+	PendingShapes  []podShape                  `json:"pending_shapes,omitempty"` // Bekleyen pod'ların istek şekilleri (parçalanma girdisi)
+	NodeTrackers   map[string]NodeTrackerState `json:"node_trackers,omitempty"`  // Node takipçilerindeki durum (Ready, flap, başlatma, disk, komşu)
+	NodeLatencies  []types.NodeLatency         `json:"node_latencies,omitempty"` // Süresi dolmamış node'lar arası gecikmeler
+	NamespaceUsage []types.NamespaceUsage      `json:"namespace_usage"`
+	ClusterCPU     float64                     `json:"cluster_cpu"`
+	ClusterMemory  float64                     `json:"cluster_memory_gb"`
+	Result         *NodeScore                  `json:"result,omitempty"`
+}
+
+// NodeTrackerState node'un takipçilerdeki tahmin anı durumu, replay takipçileri bu durumdan yeniden kurar.
+// Takipçi tutulmuyorsa veya node için kayıt yoksa alan boş kalır
+type NodeTrackerState struct {
+	Readiness []types.ReadinessSegment  `json:"readiness,omitempty"`
+	Flaps     *types.NodeFlapHistory    `json:"flaps,omitempty"`
+	Startup   *types.NodeStartupLatency `json:"startup,omitempty"`
+	Storage   *types.NodeStorageIO      `json:"storage,omitempty"`
+	Neighbors []types.NeighborSeries    `json:"neighbors,omitempty"` // Sadece noisy neighbor riski olan node'larda
+}
+
+// Recorder toplanan metrikleri ve tahmin girdilerini kaydeder (ör: trace dosyası)
+type Recorder interface {
+	RecordMetric(at time.Time, metric interface{})
+	RecordPrediction(at time.Time, record *PredictionRecord)
+}
+
+// SetRecorder metrik ve tahmin kaydedicisini ayarlar
+func (as *AIScheduler) SetRecorder(recorder Recorder) {
+	as.recorder = recorder
+}
+
+// SetEventPublisher metrik ve karar olaylarının yayınlanacağı olay yolunu ayarlar
+func (as *AIScheduler) SetEventPublisher(publisher types.EventPublisher) {
+	as.events = publisher
+}
+
+// publishMetric metrik örneğini olay yoluna iletir
+func (as *AIScheduler) publishMetric(metric interface{}) {
+	if as.events == nil {
+		return
+	}
+
+	event := types.Event{Time: as.now(), Data: metric}
+	switch m := metric.(type) {
+	case types.NodeMetrics:
+		event.Kind, event.Key = types.EventNodeMetrics, m.NodeName
+	case types.PodMetrics:
+		event.Kind, event.Key = types.EventPodMetrics, m.Namespace+"/"+m.PodName
+	default:
+		return
+	}
+	as.events.Publish(event)
+}
+
+// SetClock scheduler'ın zaman kaynağını değiştirir (ör: replay sırasında kaydedilen zaman)
+func (as *AIScheduler) SetClock(clock types.Clock) {
+	as.clock = clock
+}
+
+// now scheduler'ın zaman kaynağına göre şu anki zamanı döndürür
+func (as *AIScheduler) now() time.Time {
+	return as.clock.Now()
+}
+
+// newPredictionRecord tahmin girdilerini kayıt için toplar
+func (as *AIScheduler) newPredictionRecord(namespace, podName string, pod *corev1.Pod, snapshot *clusterSnapshot) *PredictionRecord {
+	tracker := as.collector.GetNamespaceUsage()
+	clusterCPU, clusterMemory := tracker.ClusterCapacity()
+
+	record := &PredictionRecord{
+		Namespace:      namespace,
+		PodName:        podName,
+		Pod:            pod,
+		Nodes:          make([]corev1.Node, len(snapshot.nodes)),
+		NodeUsage:      make(map[string]NodeUsage, len(snapshot.nodes)),
+		NodeRequests:   make(map[string]NodeUsage, len(snapshot.nodes)),
+		PendingShapes:  snapshot.shapes,
+		NamespaceUsage: tracker.Snapshot(),
+		ClusterCPU:     clusterCPU,
+		ClusterMemory:  clusterMemory,
+	}
+	for i, info := range snapshot.nodes {
+		node := info.node
+		record.Nodes[i] = *node
+		record.NodeRequests[node.Name] = NodeUsage{CPU: info.requestedCPU, Memory: info.requestedMemory}
+		if cpuUsage, memUsage, err := as.nodeUsage(node.Name); err == nil {
+			record.NodeUsage[node.Name] = NodeUsage{CPU: cpuUsage, Memory: memUsage}
+		}
+		if state, ok := as.nodeTrackerState(node.Name); ok {
+			if record.NodeTrackers == nil {
+				record.NodeTrackers = make(map[string]NodeTrackerState)
+			}
+			record.NodeTrackers[node.Name] = state
+		}
+	}
+	if matrix := as.collector.GetNodeLatency(); matrix != nil {
+		record.NodeLatencies = matrix.Snapshot(as.now())
+	}
+
+	return record
+}
+
+// nodeTrackerState node'un takipçilerdeki durumunu toplar, hiçbir takipçide kaydı yoksa false
+func (as *AIScheduler) nodeTrackerState(nodeName string) (NodeTrackerState, bool) {
+	var state NodeTrackerState
+	if tracker := as.collector.GetNodeReadiness(); tracker != nil {
+		state.Readiness = tracker.History(nodeName)
+	}
+	if tracker := as.collector.GetNodeFlaps(); tracker != nil {
+		if flaps, ok := tracker.History(nodeName); ok {
+			state.Flaps = &flaps
+		}
+	}
+	if tracker := as.collector.GetStartupLatency(); tracker != nil {
+		if latency, ok := tracker.Node(nodeName); ok {
+			state.Startup = &latency
+		}
+	}
+	if tracker := as.collector.GetStoragePressure(); tracker != nil {
+		if io, ok := tracker.Node(nodeName); ok {
+			state.Storage = &io
+		}
+	}
+	// Pencere node başına yüzlerce örnek tutar; riski olmayan node'un serileri replay'de de 0 riske yol açtığından yazılmaz
+	if tracker := as.collector.GetNeighborUsage(); tracker != nil && tracker.NodeRisk(nodeName) > 0 {
+		state.Neighbors = tracker.NodeSeries(nodeName)
+	}
+
+	ok := state.Readiness != nil || state.Flaps != nil || state.Startup != nil || state.Storage != nil || state.Neighbors != nil
+	return state, ok
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// Güvenilirlik analizinin varsayılanları
+const (
+	analysisWindow           = 24 * time.Hour // Azalma ve çok pencereli analiz kapalıyken kullanılan eşit ağırlıklı pencere
+	defaultDecayHalfLife     = 24 * time.Hour
+	defaultConfidenceSamples = 20
+	defaultPriorFailureRate  = 0.05
+	defaultPriorRestartCount = 1.0
+)
+
+// defaultAnalysisWindows çok pencereli analizin varsayılan pencereleri (pod cache'te artımlı tutulanlar)
+var defaultAnalysisWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// decayHalfLife konfigürasyondaki güvenilirlik azalmasının yarı ömrünü döndürür, kapalıysa 0
+func decayHalfLife(cfg *types.ReliabilityDecayConfig) time.Duration {
+	if !cfg.Enabled {
+		return 0
+	}
+	if cfg.HalfLife <= 0 {
+		return defaultDecayHalfLife
+	}
+	return cfg.HalfLife
+}
+
+// multiWindowSettings varsayılanları uygulanmış çok pencereli analiz ayarları, pencereler kısadan uzuna sıralıdır
+func multiWindowSettings(cfg types.MultiWindowAnalysisConfig) types.MultiWindowAnalysisConfig {
+	windows := make([]time.Duration, 0, len(cfg.Windows))
+	for _, window := range cfg.Windows {
+		if window > 0 {
+			windows = append(windows, window)
+		}
+	}
+	if len(windows) == 0 {
+		windows = append(windows, defaultAnalysisWindows...)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	cfg.Windows = windows
+
+	if cfg.ConfidenceSamples <= 0 {
+		cfg.ConfidenceSamples = defaultConfidenceSamples
+	}
+	if cfg.PriorFailureRate <= 0 {
+		cfg.PriorFailureRate = defaultPriorFailureRate
+	}
+	if cfg.PriorRestartCount <= 0 {
+		cfg.PriorRestartCount = defaultPriorRestartCount
+	}
+	return cfg
+}
+
+// nodeAnalysis skorlama için node analizini döndürür: çok pencereli analiz açıksa pencerelerin harmanı, azalma
+// açıksa ağırlıklı 7 günlük, ikisi de kapalıysa son 24 saat
+func (as *AIScheduler) nodeAnalysis(nodeName string) types.NodeAnalysis {
+	cfg := as.currentConfig()
+	if cfg.MultiWindowAnalysis.Enabled {
+		return blendedAnalysis(nodeName, cfg, func(window time.Duration, longest bool) types.NodeAnalysis {
+			if longest && cfg.ReliabilityDecay.Enabled {
+				return as.podCache.GetDecayedNodeAnalysis(nodeName)
+			}
+			return as.podCache.GetNodeAnalysis(nodeName, window)
+		})
+	}
+	if cfg.ReliabilityDecay.Enabled {
+		return as.podCache.GetDecayedNodeAnalysis(nodeName)
+	}
+	return as.podCache.GetNodeAnalysis(nodeName, analysisWindow)
+}
+
+// nodeAnalysisAt nodeAnalysis'in geçmişteki bir ana göre hesaplanmış hali
+func (as *AIScheduler) nodeAnalysisAt(nodeName string, at time.Time) types.NodeAnalysis {
+	cfg := as.currentConfig()
+	if cfg.MultiWindowAnalysis.Enabled {
+		return blendedAnalysis(nodeName, cfg, func(window time.Duration, longest bool) types.NodeAnalysis {
+			if longest && cfg.ReliabilityDecay.Enabled {
+				return as.podCache.GetDecayedNodeAnalysisAt(nodeName, at)
+			}
+			return as.podCache.GetNodeAnalysisAt(nodeName, window, at)
+		})
+	}
+	if cfg.ReliabilityDecay.Enabled {
+		return as.podCache.GetDecayedNodeAnalysisAt(nodeName, at)
+	}
+	return as.podCache.GetNodeAnalysisAt(nodeName, analysisWindow, at)
+}
+
+// blendedAnalysis pencerelerin analizlerini analyze ile alıp örnek sayılarına göre harmanlar. Azalma açıksa en uzun
+// pencere ağırlıklı analizle hesaplanır
+func blendedAnalysis(nodeName string, cfg *types.SchedulerConfig, analyze func(window time.Duration, longest bool) types.NodeAnalysis) types.NodeAnalysis {
+	settings := multiWindowSettings(cfg.MultiWindowAnalysis)
+	analyses := make([]types.NodeAnalysis, len(settings.Windows))
+	for i, window := range settings.Windows {
+		analyses[i] = analyze(window, i == len(settings.Windows)-1)
+	}
+	prior := types.NodeAnalysis{FailureRate: settings.PriorFailureRate, AverageRestartCount: settings.PriorRestartCount}
+	return types.BlendNodeAnalyses(nodeName, analyses, prior, settings.ConfidenceSamples)
+}
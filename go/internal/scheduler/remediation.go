@@ -0,0 +1,365 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Taint önerisi modları
+const (
+	RemediationPropose = "propose" // Taint sadece önerilir, operatör uygular
+	RemediationApply   = "apply"   // Taint Kubernetes API ile node'a eklenir ve toparlanınca kaldırılır
+)
+
+// Taint önerisi durumları
+const (
+	TaintProposed  = "proposed"  // Taint öneriliyor, node'da yok
+	TaintApplied   = "applied"   // Taint node'da
+	TaintRecovered = "recovered" // Node toparlandı, taint'in kaldırılması öneriliyor (propose modu)
+)
+
+// Taint önerilerinin varsayılanları
+const (
+	defaultRemediationStabilityThreshold = 0.5
+	defaultRemediationRecoveryThreshold  = 0.7
+	defaultRemediationDuration           = 15 * time.Minute
+	defaultRemediationInterval           = time.Minute
+	defaultRemediationMinPods            = 5
+	defaultRemediationTaintKey           = "ai-scheduler/low-stability"
+	defaultRemediationMaxTaintedRatio    = 0.2
+)
+
+// TaintProposal kararlılık skoru uzun süre eşiğin altında kalan node için PreferNoSchedule taint önerisi
+type TaintProposal struct {
+	NodeName   string       `json:"node_name"`
+	State      string       `json:"state"`
+	Stability  float64      `json:"stability"`
+	Threshold  float64      `json:"threshold"`
+	TotalPods  int          `json:"total_pods"`
+	BelowSince time.Time    `json:"below_since"`
+	ProposedAt time.Time    `json:"proposed_at"`
+	AppliedAt  *time.Time   `json:"applied_at,omitempty"`
+	Taint      corev1.Taint `json:"taint"`
+	Reason     string       `json:"reason"`
+	Command    string       `json:"command"` // Öneriyi elle uygulayan (toparlanınca kaldıran) kubectl komutu
+}
+
+// RemediationReport taint önerilerinin özeti
+type RemediationReport struct {
+	Enabled   bool            `json:"enabled"`
+	Mode      string          `json:"mode"`
+	Proposals []TaintProposal `json:"proposals"`
+	Applied   uint64          `json:"applied_total"`
+	Removed   uint64          `json:"removed_total"`
+}
+
+// remediationTracker node'ların eşiğin altında kaldığı süreleri ve açık önerileri tutar. Sadece remediationLoop yazar
+type remediationTracker struct {
+	mutex     sync.Mutex
+	below     map[string]time.Time // Node → kararlılığın eşiğin altına indiği an
+	proposals map[string]*TaintProposal
+	applied   atomic.Uint64
+	removed   atomic.Uint64
+}
+
+// taintAction node'a uygulanacak taint değişikliği
+type taintAction struct {
+	nodeName string
+	present  bool
+}
+
+// remediationSettings varsayılanları uygulanmış taint önerisi ayarları
+func (as *AIScheduler) remediationSettings() types.RemediationConfig {
+	cfg := as.currentConfig().Remediation
+	if cfg.Mode == "" {
+		cfg.Mode = RemediationPropose
+	}
+	if cfg.StabilityThreshold <= 0 {
+		cfg.StabilityThreshold = defaultRemediationStabilityThreshold
+	}
+	if cfg.RecoveryThreshold <= 0 {
+		cfg.RecoveryThreshold = defaultRemediationRecoveryThreshold
+	}
+	cfg.RecoveryThreshold = max(cfg.RecoveryThreshold, cfg.StabilityThreshold)
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultRemediationDuration
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultRemediationInterval
+	}
+	if cfg.MinPods <= 0 {
+		cfg.MinPods = defaultRemediationMinPods
+	}
+	if cfg.TaintKey == "" {
+		cfg.TaintKey = defaultRemediationTaintKey
+	}
+	if cfg.MaxTaintedRatio <= 0 {
+		cfg.MaxTaintedRatio = defaultRemediationMaxTaintedRatio
+	}
+	return cfg
+}
+
+// remediationTaint önerilen taint
+func remediationTaint(cfg types.RemediationConfig) corev1.Taint {
+	return corev1.Taint{Key: cfg.TaintKey, Effect: corev1.TaintEffectPreferNoSchedule}
+}
+
+// hasTaint node'da aynı anahtar ve etkili taint varsa true döner
+func hasTaint(node *corev1.Node, taint *corev1.Taint) bool {
+	for i := range node.Spec.Taints {
+		if node.Spec.Taints[i].MatchTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// remediationLoop node'ların kararlılığını Interval aralıkla değerlendirir
+func (as *AIScheduler) remediationLoop(ctx context.Context) {
+	interval := as.remediationSettings().Interval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			as.remediate(ctx)
+			if next := as.remediationSettings().Interval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// remediate node'ların kararlılık skorlarına göre önerileri günceller. Eşiğin altında Duration boyunca kalan node için
+// öneri açılır (apply modunda taint eklenir), RecoveryThreshold'a ulaşan node'un önerisi kapanır ve taint'i kaldırılır.
+// İki eşik arasındaki node'lar durumunu korur. TaintKey anahtarlı taint'ler scheduler'ın kabul edilir, yeniden
+// başlatmadan sonra da kaldırılır
+func (as *AIScheduler) remediate(ctx context.Context) {
+	cfg := as.remediationSettings()
+	if !cfg.Enabled {
+		return
+	}
+	nodes, err := as.listNodes()
+	if err != nil {
+		logrus.Warnf("Taint önerileri değerlendirilemedi: node listesi alınamadı: %v", err)
+		return
+	}
+
+	now := as.now()
+	taint := remediationTaint(cfg)
+	apply := cfg.Mode == RemediationApply
+	limit := max(1, int(float64(len(nodes))*cfg.MaxTaintedRatio))
+	tainted := 0
+	for _, node := range nodes {
+		if hasTaint(node, &taint) {
+			tainted++
+		}
+	}
+
+	r := &as.remediation
+	r.mutex.Lock()
+	if r.below == nil {
+		r.below = make(map[string]time.Time)
+		r.proposals = make(map[string]*TaintProposal)
+	}
+	seen := make(map[string]bool, len(nodes))
+	var actions []taintAction
+	for _, node := range nodes {
+		seen[node.Name] = true
+		analysis := as.nodeAnalysis(node.Name)
+		present := hasTaint(node, &taint)
+		proposal := r.proposals[node.Name]
+		if proposal != nil {
+			proposal.Stability, proposal.TotalPods = analysis.StabilityScore, analysis.TotalPods
+		}
+
+		switch {
+		case analysis.TotalPods < cfg.MinPods:
+			// Yeterli örnek yok, süre yeniden başlar; açık öneri ve taint korunur
+			delete(r.below, node.Name)
+		case analysis.StabilityScore < cfg.StabilityThreshold:
+			since, ok := r.below[node.Name]
+			if !ok {
+				since = now
+				r.below[node.Name] = now
+			}
+			if now.Sub(since) < cfg.Duration {
+				continue
+			}
+			if proposal == nil {
+				proposal = &TaintProposal{
+					NodeName:   node.Name,
+					Stability:  analysis.StabilityScore,
+					Threshold:  cfg.StabilityThreshold,
+					TotalPods:  analysis.TotalPods,
+					BelowSince: since,
+					ProposedAt: now,
+					Taint:      taint,
+				}
+				r.proposals[node.Name] = proposal
+				logrus.Infof("%s node'u için %s taint'i önerildi: kararlılık %.2f, %s'dir %.2f eşiğinin altında",
+					node.Name, taint.ToString(), analysis.StabilityScore, now.Sub(since).Round(time.Second), cfg.StabilityThreshold)
+			}
+			proposal.Reason = fmt.Sprintf("kararlılık skoru %.2f, %s'dir %.2f eşiğinin altında",
+				analysis.StabilityScore, now.Sub(since).Round(time.Second), cfg.StabilityThreshold)
+			proposal.Command = fmt.Sprintf("kubectl taint nodes %s %s", node.Name, taint.ToString())
+			switch {
+			case present:
+				proposal.State = TaintApplied
+			case apply && tainted >= limit:
+				proposal.State = TaintProposed
+				proposal.Reason += fmt.Sprintf("; taint'li node sınırı (%d) dolu", limit)
+			case apply:
+				proposal.State = TaintProposed
+				actions = append(actions, taintAction{nodeName: node.Name, present: true})
+				tainted++
+			default:
+				proposal.State = TaintProposed
+			}
+		case analysis.StabilityScore >= cfg.RecoveryThreshold:
+			delete(r.below, node.Name)
+			switch {
+			case present && apply:
+				actions = append(actions, taintAction{nodeName: node.Name})
+			case present:
+				// Propose modunda taint'i operatör kaldırır, öneri kaldırma önerisine döner
+				if proposal == nil {
+					proposal = &TaintProposal{NodeName: node.Name, Stability: analysis.StabilityScore, TotalPods: analysis.TotalPods, ProposedAt: now, Taint: taint}
+					r.proposals[node.Name] = proposal
+				}
+				proposal.State = TaintRecovered
+				proposal.Threshold = cfg.RecoveryThreshold
+				proposal.Reason = fmt.Sprintf("kararlılık skoru %.2f, %.2f toparlanma eşiğine ulaştı", analysis.StabilityScore, cfg.RecoveryThreshold)
+				proposal.Command = fmt.Sprintf("kubectl taint nodes %s %s-", node.Name, taint.ToString())
+			case proposal != nil:
+				delete(r.proposals, node.Name)
+				logrus.Infof("%s node'u toparlandı (kararlılık %.2f), taint önerisi kapandı", node.Name, analysis.StabilityScore)
+			}
+		default:
+			// Eşikler arasında: süre yeniden başlar, açık öneri ve taint korunur
+			delete(r.below, node.Name)
+			if proposal != nil && present {
+				proposal.State = TaintApplied
+			}
+		}
+	}
+	for name := range r.below {
+		if !seen[name] {
+			delete(r.below, name)
+		}
+	}
+	for name := range r.proposals {
+		if !seen[name] {
+			delete(r.proposals, name)
+		}
+	}
+	r.mutex.Unlock()
+
+	for _, action := range actions {
+		as.applyTaintAction(ctx, action, taint, now)
+	}
+}
+
+// applyTaintAction taint'i node'a ekler veya kaldırır ve öneriyi sonuca göre günceller
+func (as *AIScheduler) applyTaintAction(ctx context.Context, action taintAction, taint corev1.Taint, now time.Time) {
+	if err := as.setNodeTaint(ctx, action.nodeName, taint, action.present); err != nil {
+		verb := "eklenemedi"
+		if !action.present {
+			verb = "kaldırılamadı"
+		}
+		logrus.Warnf("%s node'una %s taint'i %s: %v", action.nodeName, taint.ToString(), verb, err)
+		return
+	}
+
+	r := &as.remediation
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !action.present {
+		r.removed.Add(1)
+		delete(r.proposals, action.nodeName)
+		logrus.Infof("%s node'u toparlandı, %s taint'i kaldırıldı", action.nodeName, taint.ToString())
+		return
+	}
+	r.applied.Add(1)
+	if proposal, ok := r.proposals[action.nodeName]; ok {
+		appliedAt := now
+		proposal.State, proposal.AppliedAt = TaintApplied, &appliedAt
+	}
+	logrus.Infof("%s node'una %s taint'i eklendi", action.nodeName, taint.ToString())
+}
+
+// setNodeTaint taint'i küme kaynağıyla (destekliyorsa) veya Kubernetes API ile node'a ekler ya da kaldırır.
+// API'de eşzamanlı güncelleme çakışmalarında yeniden denenir
+func (as *AIScheduler) setNodeTaint(ctx context.Context, nodeName string, taint corev1.Taint, present bool) error {
+	if tainter, ok := as.source.(types.NodeTainter); ok {
+		return tainter.SetNodeTaint(nodeName, taint, present)
+	}
+	if !as.hasAPI() {
+		return errors.New("Kubernetes client yok")
+	}
+
+	nodes := as.k8sClient.GetClientset().CoreV1().Nodes()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := nodes.Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		node.Spec.Taints = types.SetTaint(node.Spec.Taints, taint, present)
+		_, err = nodes.Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// TaintProposals açık taint önerilerini, kararlılığı en düşük node başta olmak üzere döndürür
+func (as *AIScheduler) TaintProposals() RemediationReport {
+	cfg := as.remediationSettings()
+	r := &as.remediation
+	report := RemediationReport{
+		Enabled:   cfg.Enabled,
+		Mode:      cfg.Mode,
+		Proposals: []TaintProposal{},
+		Applied:   r.applied.Load(),
+		Removed:   r.removed.Load(),
+	}
+
+	r.mutex.Lock()
+	for _, proposal := range r.proposals {
+		report.Proposals = append(report.Proposals, *proposal)
+	}
+	r.mutex.Unlock()
+
+	sort.Slice(report.Proposals, func(i, j int) bool {
+		a, b := &report.Proposals[i], &report.Proposals[j]
+		if a.Stability != b.Stability {
+			return a.Stability < b.Stability
+		}
+		return a.NodeName < b.NodeName
+	})
+	return report
+}
+
+// RemediationStats açık öneri sayısını, eklenen ve kaldırılan taint sayılarını döndürür
+func (as *AIScheduler) RemediationStats() (proposals int, applied, removed uint64) {
+	r := &as.remediation
+	r.mutex.Lock()
+	proposals = len(r.proposals)
+	r.mutex.Unlock()
+	return proposals, r.applied.Load(), r.removed.Load()
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// cachedScore TTL süresince yeniden kullanılan heuristik node skoru
+type cachedScore struct {
+	score   float64
+	reason  string
+	expires time.Time
+}
+
+// scoreCache ardışık tahminlerde heuristik skorları paylaşan kısa ömürlü cache
+type scoreCache struct {
+	mutex   sync.Mutex
+	entries map[string]cachedScore
+}
+
+// get node'un süresi dolmamış skorunu döndürür
+func (c *scoreCache) get(nodeName string, now time.Time) (cachedScore, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[nodeName]
+	if !ok || !now.Before(entry.expires) {
+		return cachedScore{}, false
+	}
+	return entry, true
+}
+
+// put node skorunu cache'e yazar
+func (c *scoreCache) put(nodeName string, entry cachedScore) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]cachedScore)
+	}
+	c.entries[nodeName] = entry
+}
+
+// invalidate node'un skorunu cache'ten siler
+func (c *scoreCache) invalidate(nodeName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, nodeName)
+}
+
+// clear tüm skorları siler
+func (c *scoreCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = nil
+}
+
+// cachedNodeScore node skorunu TTL içindeyse cache'ten, değilse hesaplayarak döndürür
+func (as *AIScheduler) cachedNodeScore(node *corev1.Node) (float64, string) {
+	ttl := as.currentConfig().ScoreCacheTTL
+	if ttl <= 0 {
+		return as.calculateNodeScore(node, scoreOverride{})
+	}
+
+	now := as.now()
+	if entry, ok := as.scores.get(node.Name, now); ok {
+		return entry.score, entry.reason
+	}
+
+	score, reason := as.calculateNodeScore(node, scoreOverride{})
+	as.scores.put(node.Name, cachedScore{score: score, reason: reason, expires: now.Add(ttl)})
+	return score, reason
+}
+
+// InvalidateNode node için yeni metrik geldiğinde cache'lenmiş skorunu geçersiz kılar
+func (as *AIScheduler) InvalidateNode(nodeName string) {
+	as.scores.invalidate(nodeName)
+}
+
+// invalidateForMetric metriğin ait olduğu node'un skorunu geçersiz kılar
+func (as *AIScheduler) invalidateForMetric(metric interface{}) {
+	if nodeName, ok := metricNodeName(metric); ok {
+		as.InvalidateNode(nodeName)
+	}
+}
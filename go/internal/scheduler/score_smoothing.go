@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// defaultSmoothingHalfLife skor yumuşatmasının varsayılan yarı ömrü
+const defaultSmoothingHalfLife = 2 * time.Minute
+
+// smoothedScore node'un yumuşatılmış skoru ve son güncellendiği an
+type smoothedScore struct {
+	value float64
+	at    time.Time
+}
+
+// scoreSmoother node skorlarının üstel hareketli ortalamasını tutar
+type scoreSmoother struct {
+	mutex sync.Mutex
+	nodes map[string]smoothedScore
+}
+
+// reset tüm yumuşatılmış skorları siler (ör: ağırlıklar değiştiğinde eski ölçekteki skorlar geçersizdir)
+func (s *scoreSmoother) reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nodes = nil
+}
+
+// scoreSmoothingSettings varsayılanları uygulanmış skor yumuşatma ayarları
+func (as *AIScheduler) scoreSmoothingSettings() types.ScoreSmoothingConfig {
+	cfg := as.currentConfig().ScoreSmoothing
+	if cfg.HalfLife <= 0 {
+		cfg.HalfLife = defaultSmoothingHalfLife
+	}
+	if cfg.Hysteresis < 0 {
+		cfg.Hysteresis = 0
+	}
+	return cfg
+}
+
+// smoothScore ham skoru node'un önceki yumuşatılmış skoruyla geçen süreye göre harmanlar: ham skorun ağırlığı
+// HalfLife'ta yarıya ulaşır. Değişim Hysteresis'ten küçükse önceki skor korunur, böylece anlık sıçramalar
+// sıralamayı ileri geri çevirmez. update false ise durum değişmez (açıklamalar için). Yumuşatma kapalıysa
+// veya node'un önceki skoru yoksa ham skor ve false döner
+func (as *AIScheduler) smoothScore(nodeName string, raw float64, update bool) (float64, bool) {
+	cfg := as.scoreSmoothingSettings()
+	if !cfg.Enabled {
+		return raw, false
+	}
+	now := as.now()
+
+	s := &as.smoothing
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, ok := s.nodes[nodeName]
+	if !ok {
+		if update {
+			if s.nodes == nil {
+				s.nodes = make(map[string]smoothedScore)
+			}
+			s.nodes[nodeName] = smoothedScore{value: raw, at: now}
+		}
+		return raw, false
+	}
+
+	weight := 1 - math.Exp2(-float64(now.Sub(previous.at))/float64(cfg.HalfLife))
+	value := previous.value + math.Max(0, weight)*(raw-previous.value)
+	if math.Abs(value-previous.value) < cfg.Hysteresis {
+		// Zaman ilerletilmez, süren değişim bir sonraki skorlamada daha büyük ağırlık alır
+		return previous.value, true
+	}
+	if update {
+		s.nodes[nodeName] = smoothedScore{value: value, at: now}
+	}
+	return value, true
+}
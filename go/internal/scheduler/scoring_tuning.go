@@ -0,0 +1,488 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Ağırlık ayarının varsayılanları
+const (
+	defaultTuningInterval   = time.Hour
+	defaultTuningMinSamples = 50
+	defaultTuningStep       = 0.1
+	defaultTuningTolerance  = 0.02
+	// tuningCheckInterval değerlendirme zamanının geldiğinin kontrol aralığı
+	tuningCheckInterval = time.Minute
+	// tuningRiskGap başarısız ve başarılı kararların node risk ortalamaları arasında ağırlığı değiştiren en küçük fark
+	tuningRiskGap = 0.05
+	// weightHistorySize bellekte tutulan ağırlık değişikliği sayısı
+	weightHistorySize = 500
+)
+
+// Ağırlık değişikliği kaynakları
+const (
+	WeightSourceTuner = "tuner" // Otomatik ayar veya kalite düşünce geri alma
+	WeightSourceAPI   = "api"   // Operatörün geri alması
+)
+
+var (
+	// ErrNoWeightChanges geri alınacak ağırlık değişikliği yok
+	ErrNoWeightChanges = errors.New("ağırlık değişikliği yok")
+	// ErrWeightChangeNotFound istenen ağırlık değişikliği geçmişte yok
+	ErrWeightChangeNotFound = errors.New("ağırlık değişikliği bulunamadı")
+)
+
+// scoringWeightNames skorlama ağırlıklarının scoring altındaki anahtarları
+var scoringWeightNames = []string{"cpu_weight", "memory_weight", "node_ready_weight", "taint_weight", "failed_pods_weight", "restart_weight"}
+
+// weightRisk ağırlığın skorladığı riskin karar anındaki node özelliği ve skorlamanın yüksek saydığı değeri
+type weightRisk struct {
+	feature string
+	high    float64
+}
+
+// weightRisks ağırlık başına risk özelliği. Yüksek ağırlık (spread'de) riskli node'u daha çok cezalandırır;
+// node_ready_weight ve taint_weight için özelliklerde sinyal yoktur
+var weightRisks = map[string]weightRisk{
+	"cpu_weight":         {feature: "cpu_usage_ratio", high: 1},
+	"memory_weight":      {feature: "memory_usage_ratio", high: 1},
+	"failed_pods_weight": {feature: "failed_pods_ratio", high: 0.1},
+	"restart_weight":     {feature: "avg_restart_count", high: 2},
+}
+
+// WeightChange skorlama ağırlıklarının tek değişikliği (denetim kaydı). Weights ve Previous temel konfigürasyonun
+// üzerine uygulanan ayarlı ağırlıklardır, boşsa temel ağırlıklar geçerlidir
+type WeightChange struct {
+	ID       uint64             `json:"id"`
+	Time     time.Time          `json:"time"`
+	Source   string             `json:"source"`
+	Reason   string             `json:"reason"`
+	Previous map[string]float64 `json:"previous"`
+	Weights  map[string]float64 `json:"weights"`
+	// Quality ve Samples değişikliğe karar verilen dönemin başarı oranı ve etiketli karar sayısı
+	Quality float64 `json:"quality,omitempty"`
+	Samples int     `json:"samples,omitempty"`
+	// Signals ağırlık başına başarısız ve başarılı kararların node risk ortalamaları farkı
+	Signals  map[string]float64 `json:"signals,omitempty"`
+	RevertOf uint64             `json:"revert_of,omitempty"`
+}
+
+// TuningStatus ağırlık ayarının durumu
+type TuningStatus struct {
+	Enabled bool `json:"enabled"`
+	// Weights ayarlı ağırlıklar uygulanmış temel ağırlıklar (zamanlanmış politika hariç)
+	Weights map[string]float64 `json:"weights"`
+	Tuned   map[string]float64 `json:"tuned"`
+	// WindowStart geçerli ağırlıklarla verilen kararların değerlendirildiği dönemin başlangıcı
+	WindowStart time.Time `json:"window_start"`
+	// Baseline son değişiklikten önceki dönemin başarı oranı, değişiklik bununla karşılaştırılır
+	Baseline *float64 `json:"baseline_quality,omitempty"`
+	// Changes en yeniden eskiye değişiklik geçmişi
+	Changes []WeightChange `json:"changes"`
+}
+
+// weightTuner ağırlık değişikliği geçmişi ve değerlendirme durumu. Ayarlı ağırlıklar configMu ile korunan
+// tunedWeights alanındadır; kilit sırası önce mutex sonra configMu
+type weightTuner struct {
+	mutex    sync.Mutex
+	changes  []WeightChange
+	nextID   uint64
+	since    time.Time
+	checked  time.Time
+	baseline float64
+	evaluate bool // Son değişiklik tuner'ındı, sonraki dönemin başarı oranı baseline ile karşılaştırılır
+}
+
+// tuningSettings varsayılanları uygulanmış ağırlık ayarı ayarları
+func (as *AIScheduler) tuningSettings() types.ScoringTuningConfig {
+	cfg := as.BaseConfig().ScoringTuning
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultTuningInterval
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultTuningMinSamples
+	}
+	if cfg.Step <= 0 {
+		cfg.Step = defaultTuningStep
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = defaultTuningTolerance
+	}
+	return cfg
+}
+
+// weightField ağırlık adının ScoringConfig alanını döndürür, bilinmeyen ad için nil
+func weightField(scoring *types.ScoringConfig, name string) *float64 {
+	switch name {
+	case "cpu_weight":
+		return &scoring.CPUWeight
+	case "memory_weight":
+		return &scoring.MemoryWeight
+	case "node_ready_weight":
+		return &scoring.NodeReadyWeight
+	case "taint_weight":
+		return &scoring.TaintWeight
+	case "failed_pods_weight":
+		return &scoring.FailedPodsWeight
+	case "restart_weight":
+		return &scoring.RestartWeight
+	}
+	return nil
+}
+
+// scoringWeights ağırlıkları ad -> değer olarak döndürür
+func scoringWeights(scoring types.ScoringConfig) map[string]float64 {
+	weights := make(map[string]float64, len(scoringWeightNames))
+	for _, name := range scoringWeightNames {
+		weights[name] = *weightField(&scoring, name)
+	}
+	return weights
+}
+
+// applyTunedWeights ayarlı ağırlıkları sınırları içinde skorlamaya uygular, sınırı kaldırılan ağırlık uygulanmaz
+func applyTunedWeights(scoring *types.ScoringConfig, tuned map[string]float64, bounds map[string]types.WeightBounds) {
+	for name, value := range tuned {
+		field := weightField(scoring, name)
+		limit, ok := bounds[name]
+		if field == nil || !ok || limit.Max < limit.Min {
+			continue
+		}
+		*field = math.Max(limit.Min, math.Min(limit.Max, value))
+	}
+}
+
+// value özelliği high'a bölüp 0-1 aralığına sıkıştırır, özellik yoksa false döner
+func (r weightRisk) value(features map[string]interface{}) (float64, bool) {
+	value, ok := features[r.feature].(float64)
+	if !ok {
+		return 0, false
+	}
+	return math.Max(0, math.Min(1, value/r.high)), true
+}
+
+// copyWeights ağırlık haritasını kopyalar, nil için boş harita döner
+func copyWeights(weights map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(weights))
+	for name, value := range weights {
+		result[name] = value
+	}
+	return result
+}
+
+// tunedSnapshot ayarlı ağırlıkların kopyasını döndürür
+func (as *AIScheduler) tunedSnapshot() map[string]float64 {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	return copyWeights(as.tunedWeights)
+}
+
+// setTunedWeights ayarlı ağırlıkları değiştirir, konfigürasyonu yeniden çözer ve skor cache'lerini temizler
+func (as *AIScheduler) setTunedWeights(weights map[string]float64) {
+	as.configMu.Lock()
+	as.tunedWeights = weights
+	as.resolvePolicy(as.now(), true)
+	as.configMu.Unlock()
+
+	// Önceki ağırlıklarla hesaplanan skorlar geçersiz
+	as.scores.clear()
+	as.ranking.reset()
+	as.smoothing.reset()
+}
+
+// tunedOutcome değerlendirmeye giren etiketli kararın sonucu ve karar anındaki node özellikleri
+type tunedOutcome struct {
+	success  bool
+	features map[string]interface{}
+}
+
+// outcomesSince since'den sonra verilip tahmin edilen node'da sonucu belli olan kararları döndürür
+func (as *AIScheduler) outcomesSince(since time.Time) []tunedOutcome {
+	c := &as.outcomes
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var result []tunedOutcome
+	for i := range c.records {
+		record := &c.records[i]
+		if record.DecidedAt.Before(since) {
+			continue
+		}
+		switch record.Result {
+		case ResultSucceeded:
+			result = append(result, tunedOutcome{success: true, features: record.Features})
+		case ResultFailed, ResultRestarted, ResultNotStarted:
+			result = append(result, tunedOutcome{features: record.Features})
+		}
+	}
+	return result
+}
+
+// outcomeQuality kararların başarı oranını döndürür
+func outcomeQuality(outcomes []tunedOutcome) float64 {
+	if len(outcomes) == 0 {
+		return 0
+	}
+	succeeded := 0
+	for i := range outcomes {
+		if outcomes[i].success {
+			succeeded++
+		}
+	}
+	return float64(succeeded) / float64(len(outcomes))
+}
+
+// riskGap başarısız kararların node'larının ortalama riskinden başarılılarınkini çıkarır. İki gruptan biri boşsa
+// false döner
+func riskGap(outcomes []tunedOutcome, risk weightRisk) (float64, bool) {
+	var failedSum, succeededSum float64
+	var failed, succeeded int
+	for i := range outcomes {
+		value, ok := risk.value(outcomes[i].features)
+		if !ok {
+			continue
+		}
+		if outcomes[i].success {
+			succeededSum += value
+			succeeded++
+		} else {
+			failedSum += value
+			failed++
+		}
+	}
+	if failed == 0 || succeeded == 0 {
+		return 0, false
+	}
+	return failedSum/float64(failed) - succeededSum/float64(succeeded), true
+}
+
+// tuneWeights değerlendirme zamanı geldiyse son dönemin etiketli kararlarıyla ağırlıkları ayarlar. Son değişiklikten
+// sonra başarı oranı tolerance'tan fazla düştüyse değişiklik geri alınır; aksi halde başarısız kararların node'ları
+// bir ağırlığın riskinde belirgin şekilde kötüyse ağırlık artırılır, iyiyse azaltılır
+func (as *AIScheduler) tuneWeights(now time.Time) {
+	cfg := as.tuningSettings()
+	if !cfg.Enabled {
+		return
+	}
+	if !as.outcomeSettings().Enabled {
+		logrus.Debug("Ağırlık ayarı için karar sonucu eşleştirme kapalı")
+		return
+	}
+
+	t := &as.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.since.IsZero() {
+		t.since, t.checked = now, now
+		return
+	}
+	if now.Sub(t.checked) < cfg.Interval {
+		return
+	}
+	t.checked = now
+
+	outcomes := as.outcomesSince(t.since)
+	if len(outcomes) < cfg.MinSamples {
+		return
+	}
+	quality := outcomeQuality(outcomes)
+	current := as.tunedSnapshot()
+
+	if t.evaluate && quality < t.baseline-cfg.Tolerance && len(t.changes) > 0 {
+		last := t.changes[len(t.changes)-1]
+		as.recordWeightChangeLocked(WeightChange{
+			Source:   WeightSourceTuner,
+			Reason:   fmt.Sprintf("başarı oranı %.3f'ten %.3f'e düştü, değişiklik %d geri alındı", t.baseline, quality, last.ID),
+			Previous: current,
+			Weights:  copyWeights(last.Previous),
+			Quality:  quality,
+			Samples:  len(outcomes),
+			RevertOf: last.ID,
+		}, now)
+		t.evaluate = false
+		return
+	}
+	t.baseline = quality
+	t.evaluate = false
+
+	base := as.BaseConfig()
+	effective := base.Scoring
+	applyTunedWeights(&effective, current, cfg.Bounds)
+	binPack := strategyOf(&base) == StrategyBinPack
+
+	next := copyWeights(current)
+	signals := make(map[string]float64)
+	var changed []string
+	for _, name := range scoringWeightNames {
+		limit, bounded := cfg.Bounds[name]
+		risk, ok := weightRisks[name]
+		if !bounded || !ok || limit.Max < limit.Min {
+			continue
+		}
+		gap, ok := riskGap(outcomes, risk)
+		if !ok || math.Abs(gap) < tuningRiskGap {
+			continue
+		}
+		signals[name] = gap
+		// Bin-pack'te kullanım ağırlığı dolu node'u tercih ettirir, riskli node'dan kaçmak için azaltılır
+		if binPack && (name == "cpu_weight" || name == "memory_weight") {
+			gap = -gap
+		}
+		old := *weightField(&effective, name)
+		value := old + cfg.Step*math.Max(-1, math.Min(1, gap))*(limit.Max-limit.Min)
+		value = math.Round(math.Max(limit.Min, math.Min(limit.Max, value))*100) / 100
+		if value == old {
+			continue
+		}
+		next[name] = value
+		changed = append(changed, fmt.Sprintf("%s %.2f -> %.2f", name, old, value))
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	as.recordWeightChangeLocked(WeightChange{
+		Source:   WeightSourceTuner,
+		Reason:   fmt.Sprintf("başarı oranı %.3f: %s", quality, strings.Join(changed, ", ")),
+		Previous: current,
+		Weights:  next,
+		Quality:  quality,
+		Samples:  len(outcomes),
+		Signals:  signals,
+	}, now)
+	t.evaluate = true
+}
+
+// recordWeightChangeLocked değişikliğe numara verip geçmişe ve günlüğe yazar, ağırlıkları uygular ve değerlendirme
+// dönemini yeniden başlatır. tuning.mutex tutulurken çağrılır
+func (as *AIScheduler) recordWeightChangeLocked(change WeightChange, now time.Time) WeightChange {
+	t := &as.tuning
+	t.nextID++
+	change.ID = t.nextID
+	change.Time = now
+	t.changes = append(t.changes, change)
+	if len(t.changes) > weightHistorySize {
+		t.changes = append(t.changes[:0:0], t.changes[len(t.changes)-weightHistorySize:]...)
+	}
+	t.since = now
+
+	as.setTunedWeights(copyWeights(change.Weights))
+	as.appendJournal(JournalEntry{Kind: JournalWeights, Time: now, Weights: &change})
+	logrus.Infof("Skorlama ağırlıkları değişti (%d, %s): %s", change.ID, change.Source, change.Reason)
+	return change
+}
+
+// restoreWeightChange günlükteki ağırlık değişikliğini geçmişe ekler ve ağırlıklarını uygular
+func (as *AIScheduler) restoreWeightChange(change *WeightChange) {
+	t := &as.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.changes = append(t.changes, *change)
+	if len(t.changes) > weightHistorySize {
+		t.changes = append(t.changes[:0:0], t.changes[len(t.changes)-weightHistorySize:]...)
+	}
+	t.nextID = max(t.nextID, change.ID)
+	t.since, t.evaluate = change.Time, false
+	as.setTunedWeights(copyWeights(change.Weights))
+}
+
+// RevertWeights id numaralı değişiklikten önceki ağırlıklara döner, id 0 ise son değişiklik geri alınır.
+// Geri alma da geçmişe yeni değişiklik olarak yazılır
+func (as *AIScheduler) RevertWeights(id uint64, reason string) (*WeightChange, error) {
+	t := &as.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.changes) == 0 {
+		return nil, ErrNoWeightChanges
+	}
+	var target *WeightChange
+	if id == 0 {
+		target = &t.changes[len(t.changes)-1]
+	} else {
+		for i := range t.changes {
+			if t.changes[i].ID == id {
+				target = &t.changes[i]
+				break
+			}
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%d: %w", id, ErrWeightChangeNotFound)
+	}
+
+	text := fmt.Sprintf("değişiklik %d geri alındı", target.ID)
+	if reason != "" {
+		text += ": " + reason
+	}
+	change := as.recordWeightChangeLocked(WeightChange{
+		Source:   WeightSourceAPI,
+		Reason:   text,
+		Previous: as.tunedSnapshot(),
+		Weights:  copyWeights(target.Previous),
+		RevertOf: target.ID,
+	}, as.now())
+	t.evaluate = false
+	return &change, nil
+}
+
+// ScoringTuning ağırlık ayarının durumunu ve değişiklik geçmişini döndürür
+func (as *AIScheduler) ScoringTuning() TuningStatus {
+	cfg := as.tuningSettings()
+	base := as.BaseConfig()
+	tuned := as.tunedSnapshot()
+	if cfg.Enabled {
+		applyTunedWeights(&base.Scoring, tuned, cfg.Bounds)
+	}
+	status := TuningStatus{Enabled: cfg.Enabled, Weights: scoringWeights(base.Scoring), Tuned: tuned}
+
+	t := &as.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	status.WindowStart = t.since
+	if t.evaluate {
+		baseline := t.baseline
+		status.Baseline = &baseline
+	}
+	status.Changes = make([]WeightChange, 0, len(t.changes))
+	for i := len(t.changes) - 1; i >= 0; i-- {
+		status.Changes = append(status.Changes, t.changes[i])
+	}
+	return status
+}
+
+// weightChanges değişiklik geçmişini eskiden yeniye döndürür
+func (as *AIScheduler) weightChanges() []WeightChange {
+	t := &as.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return append([]WeightChange(nil), t.changes...)
+}
+
+// tuningLoop değerlendirme zamanı gelince ağırlıkları ayarlar
+func (as *AIScheduler) tuningLoop(ctx context.Context) {
+	ticker := time.NewTicker(tuningCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			as.tuneWeights(as.now())
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/forecast"
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LifetimeAnnotation pod'un tahmini yaşam süresi (Go süre biçiminde, ör: 30m, 72h)
+const LifetimeAnnotation = "ai-scheduler/expected-lifetime"
+
+// Dönemsel yük skorlamasının varsayılanları
+const (
+	defaultSeasonalityWeight       = 15.0
+	defaultSeasonalityMinCycles    = 2
+	defaultSeasonalityMinStrength  = 0.3
+	defaultSeasonalityHotThreshold = 0.8
+	defaultBatchLifetime           = time.Hour
+	defaultLongLivedLifetime       = 7 * 24 * time.Hour
+	defaultPodLifetime             = 24 * time.Hour
+)
+
+// defaultSeasonalityPeriods konfigürasyonda aday yoksa denenen dönemler (günlük ve haftalık)
+var defaultSeasonalityPeriods = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour}
+
+// nodeSeasonality node'un CPU ve memory kullanım oranlarındaki dönemsel düzen. Döngü bulunamayan kaynağın Period'u 0'dır
+type nodeSeasonality struct {
+	last       time.Time // Serinin son tamamlanmış dilimi
+	start      time.Time // Serinin ilk dilimi, evreler buna göre hesaplanır
+	resolution time.Duration
+	cpu        forecast.Seasonality
+	memory     forecast.Seasonality
+}
+
+// seasonalLoad node'da bir zaman aralığı boyunca döngüye göre beklenen kullanım oranları
+type seasonalLoad struct {
+	placementCPU    float64
+	placementMemory float64
+	peakCPU         float64
+	peakMemory      float64
+}
+
+// seasonalityCache node başına dönemsel düzeni yeni dilim tamamlanana kadar yeniden kullanır
+type seasonalityCache struct {
+	mutex   sync.Mutex
+	entries map[string]*nodeSeasonality
+}
+
+// get son tamamlanmış dilim aynıysa cache'lenmiş düzeni döndürür
+func (c *seasonalityCache) get(nodeName string, last time.Time) (*nodeSeasonality, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[nodeName]
+	if !ok || !entry.last.Equal(last) {
+		return nil, false
+	}
+	return entry, true
+}
+
+// put düzeni cache'e yazar
+func (c *seasonalityCache) put(nodeName string, entry *nodeSeasonality) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]*nodeSeasonality)
+	}
+	c.entries[nodeName] = entry
+}
+
+// clear tüm düzenleri siler
+func (c *seasonalityCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = nil
+}
+
+// seasonalitySettings varsayılanları uygulanmış dönemsel yük konfigürasyonunu döndürür
+func (as *AIScheduler) seasonalitySettings() types.SeasonalityConfig {
+	cfg := as.currentConfig().Seasonality
+	if cfg.Weight <= 0 {
+		cfg.Weight = defaultSeasonalityWeight
+	}
+	if len(cfg.Periods) == 0 {
+		cfg.Periods = defaultSeasonalityPeriods
+	}
+	if cfg.MinCycles < 2 {
+		cfg.MinCycles = defaultSeasonalityMinCycles
+	}
+	if cfg.MinStrength <= 0 || cfg.MinStrength >= 1 {
+		cfg.MinStrength = defaultSeasonalityMinStrength
+	}
+	if cfg.HotThreshold <= 0 || cfg.HotThreshold >= 1 {
+		cfg.HotThreshold = defaultSeasonalityHotThreshold
+	}
+	if cfg.BatchLifetime <= 0 {
+		cfg.BatchLifetime = defaultBatchLifetime
+	}
+	if cfg.LongLivedLifetime <= 0 {
+		cfg.LongLivedLifetime = defaultLongLivedLifetime
+	}
+	if cfg.DefaultLifetime <= 0 {
+		cfg.DefaultLifetime = defaultPodLifetime
+	}
+	return cfg
+}
+
+// nodeSeasonalityOf node'un kullanım geçmişindeki dönemsel düzeni döndürür. Geçmiş yetersizse veya iki kaynakta da
+// döngü bulunamazsa nil
+func (as *AIScheduler) nodeSeasonalityOf(node *corev1.Node, cfg *types.SeasonalityConfig) *nodeSeasonality {
+	series, err := as.nodeUtilizationSeries(node, as.now())
+	if err != nil {
+		return nil
+	}
+
+	seasonality, ok := as.seasonality.get(node.Name, series.last)
+	if !ok {
+		candidates := make([]int, 0, len(cfg.Periods))
+		for _, period := range cfg.Periods {
+			candidates = append(candidates, int(period/series.resolution))
+		}
+		seasonality = &nodeSeasonality{
+			last:       series.last,
+			start:      series.last.Add(-time.Duration(len(series.cpu)-1) * series.resolution),
+			resolution: series.resolution,
+		}
+		seasonality.cpu, _ = forecast.DetectSeasonality(series.cpu, candidates, cfg.MinCycles, cfg.MinStrength)
+		seasonality.memory, _ = forecast.DetectSeasonality(series.memory, candidates, cfg.MinCycles, cfg.MinStrength)
+		as.seasonality.put(node.Name, seasonality)
+	}
+	if seasonality.cpu.Period == 0 && seasonality.memory.Period == 0 {
+		return nil
+	}
+	return seasonality
+}
+
+// strength CPU ve memory döngülerinden güçlü olanın gücü ve dönemi
+func (s *nodeSeasonality) strength() (float64, time.Duration) {
+	strongest := s.cpu
+	if s.memory.Strength > strongest.Strength {
+		strongest = s.memory
+	}
+	return strongest.Strength, time.Duration(strongest.Period) * s.resolution
+}
+
+// expected at anında ve at'tan itibaren lifetime boyunca döngüye göre beklenen kullanımı döndürür. Aralık en uzun
+// dönemle sınırlanır, ötesi döngünün tekrarıdır. Döngüsü bulunmayan kaynak 0 kabul edilir
+func (s *nodeSeasonality) expected(at time.Time, lifetime time.Duration) seasonalLoad {
+	index := int(at.Sub(s.start) / s.resolution)
+	slots := max(1, int((lifetime+s.resolution-1)/s.resolution))
+	slots = min(slots, max(s.cpu.Period, s.memory.Period))
+
+	load := seasonalLoad{placementCPU: s.cpu.At(index), placementMemory: s.memory.At(index)}
+	for i := 0; i < slots; i++ {
+		load.peakCPU = math.Max(load.peakCPU, s.cpu.At(index+i))
+		load.peakMemory = math.Max(load.peakMemory, s.memory.At(index+i))
+	}
+	return load
+}
+
+// expectedLifetime pod'un tahmini yaşam süresini döndürür: annotation, activeDeadlineSeconds, ardından pod'un
+// controller türü (Job'lar kısa, Deployment/StatefulSet/DaemonSet uzun yaşar)
+func expectedLifetime(pod *corev1.Pod, cfg *types.SeasonalityConfig) time.Duration {
+	if value, ok := pod.Annotations[LifetimeAnnotation]; ok {
+		if lifetime, err := time.ParseDuration(value); err == nil && lifetime > 0 {
+			return lifetime
+		}
+	}
+	if deadline := pod.Spec.ActiveDeadlineSeconds; deadline != nil && *deadline > 0 {
+		return time.Duration(*deadline) * time.Second
+	}
+
+	switch types.WorkloadOf(pod).Kind {
+	case "Job", "CronJob":
+		return cfg.BatchLifetime
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController":
+		return cfg.LongLivedLifetime
+	default:
+		return cfg.DefaultLifetime
+	}
+}
+
+// seasonalContext pod'un yerleşim anı ve tahmini yaşam süresi
+type seasonalContext struct {
+	at       time.Time
+	lifetime time.Duration
+	cfg      types.SeasonalityConfig
+}
+
+// seasonalContextFor pod'un dönemsel skorlama bağlamını döndürür, özellik kapalıysa nil
+func (as *AIScheduler) seasonalContextFor(pod *corev1.Pod) *seasonalContext {
+	cfg := as.seasonalitySettings()
+	if !cfg.Enabled || pod == nil {
+		return nil
+	}
+	return &seasonalContext{at: as.now(), lifetime: expectedLifetime(pod, &cfg), cfg: cfg}
+}
+
+// seasonalPenalty pod'un yaşam süresi boyunca döngüye göre beklenen tepe kullanım eşiği aşan node'lara, aşımla ve
+// döngünün gücüyle orantılı ceza döndürür. Şu an boş olsa da düzenli yoğunlaşan node'lar uzun yaşayan pod'lardan
+// uzak tutulur
+func (as *AIScheduler) seasonalPenalty(ctx *seasonalContext, node *corev1.Node) (float64, string) {
+	if ctx == nil {
+		return 0, ""
+	}
+	seasonality := as.nodeSeasonalityOf(node, &ctx.cfg)
+	if seasonality == nil {
+		return 0, ""
+	}
+
+	load := seasonality.expected(ctx.at, ctx.lifetime)
+	peak := math.Max(load.peakCPU, load.peakMemory)
+	if peak <= ctx.cfg.HotThreshold {
+		return 0, ""
+	}
+	strength, period := seasonality.strength()
+	over := math.Min(1, (peak-ctx.cfg.HotThreshold)/(1-ctx.cfg.HotThreshold))
+	penalty := ctx.cfg.Weight * strength * over
+	return penalty, fmt.Sprintf("Dönemsel yük cezası: %.1f (%s yaşam süresinde beklenen tepe kullanım %.0f%%, %s döngü, güç %.2f)",
+		penalty, ctx.lifetime, peak*100, period, strength)
+}
+
+// seasonalFeatures node'un dönemsel düzeninden AI özelliklerini features'a yazar; lifetime 0 değilse pod'un yaşam
+// süresi boyunca beklenen tepe kullanım da eklenir. Özellik kapalıysa veya döngü yoksa değerler 0'dır
+func (as *AIScheduler) seasonalFeatures(node *corev1.Node, lifetime time.Duration, features map[string]interface{}) {
+	var (
+		load             seasonalLoad
+		strength, period float64
+	)
+	if cfg := as.seasonalitySettings(); cfg.Enabled && node != nil {
+		if seasonality := as.nodeSeasonalityOf(node, &cfg); seasonality != nil {
+			load = seasonality.expected(as.now(), lifetime)
+			s, p := seasonality.strength()
+			strength, period = s, p.Hours()
+		}
+	}
+
+	features["seasonal_strength"] = strength
+	features["seasonal_period_hours"] = period
+	features["expected_cpu_at_placement"] = load.placementCPU
+	features["expected_memory_at_placement"] = load.placementMemory
+	if lifetime > 0 {
+		features["expected_lifetime_hours"] = lifetime.Hours()
+		features["expected_peak_cpu_lifetime"] = load.peakCPU
+		features["expected_peak_memory_lifetime"] = load.peakMemory
+	}
+}
+
+// podLifetime pod'un tahmini yaşam süresini döndürür, dönemsel skorlama kapalıysa 0
+func (as *AIScheduler) podLifetime(pod *corev1.Pod) time.Duration {
+	cfg := as.seasonalitySettings()
+	if !cfg.Enabled {
+		return 0
+	}
+	return expectedLifetime(pod, &cfg)
+}
@@ -0,0 +1,289 @@
+package scheduler
+
+import (
+	"strconv"
+	"strings"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Node'un güvenlik yeteneklerini bildiren annotation'lar, değerler virgülle ayrılmış listedir.
+// Annotation'ı olmayan node'da ilgili kontrol yapılmaz
+const (
+	AnnotationRuntimeHandlers      = "ai-scheduler/runtime-handlers"       // ör: "runc,runsc"
+	AnnotationSeccompProfiles      = "ai-scheduler/seccomp-profiles"       // kubelet seccomp dizinine göre: "profiles/audit.json"
+	AnnotationAppArmorProfiles     = "ai-scheduler/apparmor-profiles"      // yüklü profiller: "k8s-nginx"
+	AnnotationAllowedUnsafeSysctls = "ai-scheduler/allowed-unsafe-sysctls" // kubelet --allowed-unsafe-sysctls: "kernel.msg*"
+)
+
+// Pod güvenliği uygunluğunun sabitleri
+const (
+	defaultHostAccessLabel   = "ai-scheduler.io/deny-host-access"
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+	appArmorEnabledMessage   = "AppArmor enabled" // Kubelet AppArmor destekliyorsa Ready mesajına ekler
+)
+
+// safeSysctls kubelet'in her node'da izin verdiği namespace'li sysctl'lar
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_local_reserved_ports":    true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.ping_group_range":           true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.tcp_keepalive_time":         true,
+	"net.ipv4.tcp_fin_timeout":            true,
+	"net.ipv4.tcp_keepalive_intvl":        true,
+	"net.ipv4.tcp_keepalive_probes":       true,
+}
+
+// podSecurity pod'un node'dan beklediği güvenlik ve runtime yetenekleri
+type podSecurity struct {
+	runtimeHandler   string
+	runtimeSelector  map[string]string // RuntimeClass scheduling.nodeSelector
+	seccompProfiles  []string          // Localhost seccomp profilleri
+	appArmor         bool              // unconfined dışında AppArmor profili isteniyor
+	appArmorProfiles []string          // localhost/ AppArmor profilleri
+	unsafeSysctls    []string          // Tüm kubelet'lerde izin verilmeyen sysctl'lar
+	hostAccess       bool              // hostPath, hostNetwork, hostPID veya hostIPC
+	hostNetwork      bool
+	hostPorts        []string
+	hostAccessLabel  string
+}
+
+// podSecurity pod'un güvenlik gereksinimlerini hazırlar, kontrol kapalıysa veya gereksinim yoksa nil döner
+func (as *AIScheduler) podSecurity(pod *corev1.Pod) *podSecurity {
+	cfg := as.currentConfig().Security
+	if !cfg.Enabled {
+		return nil
+	}
+
+	security := &podSecurity{
+		hostNetwork:     pod.Spec.HostNetwork,
+		hostAccess:      pod.Spec.HostNetwork || pod.Spec.HostPID || pod.Spec.HostIPC,
+		hostPorts:       podHostPorts(pod),
+		hostAccessLabel: cfg.HostAccessLabel,
+	}
+	if security.hostAccessLabel == "" {
+		security.hostAccessLabel = defaultHostAccessLabel
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			security.hostAccess = true
+		}
+	}
+
+	if name := pod.Spec.RuntimeClassName; name != nil && *name != "" {
+		runtimeClass, err := as.getRuntimeClass(*name)
+		if err != nil {
+			// Pod admission'dan geçtiyse RuntimeClass vardır; okunamazsa kısıt uygulanmaz
+			logrus.Debugf("%s/%s: runtime class %s okunamadı, kontrol atlandı: %v", pod.Namespace, pod.Name, *name, err)
+		} else {
+			security.runtimeHandler = runtimeClass.Handler
+			if runtimeClass.Scheduling != nil {
+				security.runtimeSelector = runtimeClass.Scheduling.NodeSelector
+			}
+		}
+	}
+
+	if profile := seccompLocalhostProfile(pod.Spec.SecurityContext); profile != "" {
+		security.seccompProfiles = append(security.seccompProfiles, profile)
+	}
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			if context := containers[i].SecurityContext; context != nil && context.SeccompProfile != nil &&
+				context.SeccompProfile.Type == corev1.SeccompProfileTypeLocalhost && context.SeccompProfile.LocalhostProfile != nil {
+				security.seccompProfiles = append(security.seccompProfiles, *context.SeccompProfile.LocalhostProfile)
+			}
+		}
+	}
+
+	for key, value := range pod.Annotations {
+		if !strings.HasPrefix(key, appArmorAnnotationPrefix) || value == "unconfined" || value == "" {
+			continue
+		}
+		security.appArmor = true
+		if profile, ok := strings.CutPrefix(value, "localhost/"); ok {
+			security.appArmorProfiles = append(security.appArmorProfiles, profile)
+		}
+	}
+
+	if pod.Spec.SecurityContext != nil {
+		for _, sysctl := range pod.Spec.SecurityContext.Sysctls {
+			name := strings.ReplaceAll(sysctl.Name, "/", ".")
+			if !safeSysctls[name] && !matchesSysctl(cfg.AllowedUnsafeSysctls, name) {
+				security.unsafeSysctls = append(security.unsafeSysctls, name)
+			}
+		}
+	}
+
+	if security.runtimeHandler == "" && security.runtimeSelector == nil && len(security.seccompProfiles) == 0 && !security.appArmor &&
+		len(security.unsafeSysctls) == 0 && !security.hostAccess && len(security.hostPorts) == 0 {
+		return nil
+	}
+	return security
+}
+
+// seccompLocalhostProfile pod seviyesindeki Localhost seccomp profilini döndürür, yoksa boş
+func seccompLocalhostProfile(context *corev1.PodSecurityContext) string {
+	if context == nil || context.SeccompProfile == nil || context.SeccompProfile.Type != corev1.SeccompProfileTypeLocalhost ||
+		context.SeccompProfile.LocalhostProfile == nil {
+		return ""
+	}
+	return *context.SeccompProfile.LocalhostProfile
+}
+
+// podHostPorts pod'un node'da ayırdığı "protokol/port" listesini döndürür; hostNetwork pod'larda container portları
+// host portudur. Host IP'si dikkate alınmaz, farklı IP'lere bağlanan aynı portlar da çakışma sayılır
+func podHostPorts(pod *corev1.Pod) []string {
+	var ports []string
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			for _, port := range containers[i].Ports {
+				hostPort := port.HostPort
+				if pod.Spec.HostNetwork && hostPort == 0 {
+					hostPort = port.ContainerPort
+				}
+				if hostPort <= 0 {
+					continue
+				}
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = corev1.ProtocolTCP
+				}
+				ports = append(ports, string(protocol)+"/"+strconv.Itoa(int(hostPort)))
+			}
+		}
+	}
+	return ports
+}
+
+// securityMismatch pod'un güvenlik gereksinimleri node'da karşılanmıyorsa elenme sebebini, aksi halde boş döndürür.
+// Sadece node'un kendisine bakılır, node'daki pod'ların ayırdığı host portları hostPortConflict kontrol eder
+func securityMismatch(security *podSecurity, node *corev1.Node) string {
+	if security == nil {
+		return ""
+	}
+
+	for key, value := range security.runtimeSelector {
+		if node.Labels[key] != value {
+			return filterRuntimeClass
+		}
+	}
+	if security.runtimeHandler != "" {
+		if handlers, ok := annotationList(node, AnnotationRuntimeHandlers); ok && !handlers[security.runtimeHandler] {
+			return filterRuntimeClass
+		}
+	}
+
+	if len(security.seccompProfiles) > 0 {
+		if types.IsWindowsNode(node) {
+			return filterSeccomp
+		}
+		if profiles, ok := annotationList(node, AnnotationSeccompProfiles); ok && !containsAll(profiles, security.seccompProfiles) {
+			return filterSeccomp
+		}
+	}
+
+	if security.appArmor {
+		if !appArmorSupported(node) {
+			return filterAppArmor
+		}
+		if profiles, ok := annotationList(node, AnnotationAppArmorProfiles); ok && !containsAll(profiles, security.appArmorProfiles) {
+			return filterAppArmor
+		}
+	}
+
+	if len(security.unsafeSysctls) > 0 {
+		allowed, _ := annotationList(node, AnnotationAllowedUnsafeSysctls)
+		patterns := make([]string, 0, len(allowed))
+		for pattern := range allowed {
+			patterns = append(patterns, pattern)
+		}
+		for _, name := range security.unsafeSysctls {
+			if !matchesSysctl(patterns, name) {
+				return filterSysctl
+			}
+		}
+	}
+
+	if security.hostAccess && node.Labels[security.hostAccessLabel] == "true" {
+		return filterHostAccess
+	}
+	// Windows node'larında hostNetwork desteklenmez
+	if security.hostNetwork && types.IsWindowsNode(node) {
+		return filterHostAccess
+	}
+	return ""
+}
+
+// hostPortConflict pod'un host portlarından biri node'da ayrılmışsa elenme sebebini döndürür.
+// own true ise pod'un host portları node'un toplamına zaten dahildir
+func hostPortConflict(security *podSecurity, info *nodeInfo, own bool) string {
+	if security == nil || own {
+		return ""
+	}
+	for _, port := range security.hostPorts {
+		if info.hostPorts[port] {
+			return filterHostPort
+		}
+	}
+	return ""
+}
+
+// appArmorSupported node AppArmor profillerini uygulayabiliyorsa true döner. Kubelet AppArmor destekliyorsa
+// Ready mesajına "AppArmor enabled" ekler; mesajı olmayan node desteklenir kabul edilir
+func appArmorSupported(node *corev1.Node) bool {
+	if types.IsWindowsNode(node) {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue && condition.Message != "" {
+			return strings.Contains(condition.Message, appArmorEnabledMessage)
+		}
+	}
+	return true
+}
+
+// annotationList node'un virgülle ayrılmış annotation değerini kümeye çevirir, annotation yoksa false
+func annotationList(node *corev1.Node, key string) (map[string]bool, bool) {
+	value, ok := node.Annotations[key]
+	if !ok {
+		return nil, false
+	}
+	set := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			set[item] = true
+		}
+	}
+	return set, true
+}
+
+// containsAll tüm değerler kümede varsa true döner
+func containsAll(set map[string]bool, values []string) bool {
+	for _, value := range values {
+		if !set[value] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesSysctl sysctl izin listesindeki bir kalıba uyuyorsa true döner, "*" ile biten kalıplar önek eşleşmesidir
+func matchesSysctl(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ReplaceAll(pattern, "/", ".")
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if pattern == name {
+			return true
+		}
+	}
+	return false
+}
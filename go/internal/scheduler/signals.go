@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeSignal node için scheduler'ın hesapladığı ve dışarıya (ör: HPA external metrics) sunulan sinyaller
+type NodeSignal struct {
+	Node      *corev1.Node
+	Stability float64       // Son 24 saatin pod kararlılık skoru (0-1)
+	Forecast  *NodeForecast // Yerel kullanım tahmini, geçmiş yetersizse nil
+}
+
+// NodeSignals snapshot'taki node'ların kararlılık skorlarını ve horizon boyunca yerel kullanım tahminlerini döndürür.
+// Tahminler AI servisine gitmez, yeni geçmiş dilimi tamamlanana kadar cache'ten gelir
+func (as *AIScheduler) NodeSignals(horizon time.Duration) ([]NodeSignal, error) {
+	snapshot, err := as.currentSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := as.forecastSettings()
+	if horizon <= 0 {
+		horizon = cfg.Horizon
+	}
+
+	signals := make([]NodeSignal, len(snapshot.nodes))
+	for i, info := range snapshot.nodes {
+		signals[i] = NodeSignal{
+			Node:      info.node,
+			Stability: as.nodeAnalysis(info.node.Name).StabilityScore,
+		}
+		if forecast, err := as.localNodeForecast(info.node, horizon, &cfg); err == nil {
+			signals[i].Forecast = forecast
+		}
+	}
+	return signals, nil
+}
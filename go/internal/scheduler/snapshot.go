@@ -0,0 +1,358 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeInfo node'un snapshot anındaki görünümü ve üzerine yerleşmiş pod'ların istekleri
+type nodeInfo struct {
+	node              *corev1.Node
+	allocatableCPU    float64
+	allocatableMemory float64
+	capacityCPU       float64 // Overcommit oranı uygulanmış allocatable, isteklerin toplamı bunu aşamaz
+	capacityMemory    float64
+	requestedCPU      float64
+	requestedMemory   float64
+	pods              int
+	allocatablePods   int                        // status.allocatable.pods, 0 ise sınır bilinmiyor
+	hostPorts         map[string]bool            // Node'daki pod'ların ayırdığı "protokol/port"lar
+	workloads         map[string]int             // İş yükü başına node'daki pod sayısı (sahipsiz pod'lar hariç)
+	attachLimits      map[string]int             // CSI sürücüsü başına bağlanabilir volume sınırı, nil ise sınır yok
+	attached          map[string]map[string]bool // CSI sürücüsü başına node'a bağlı volume'lar
+}
+
+// clusterSnapshot scheduling döngüsünün okuduğu değiştirilemez küme görünümü (kube-scheduler Snapshot benzeri).
+// Oluşturulduktan sonra değiştirilmez, assume/forget değişen node'u kopyalayarak yeni snapshot üretir
+type clusterSnapshot struct {
+	generation uint64
+	versioned  bool
+	builtAt    time.Time
+	nodes      []*nodeInfo
+	index      map[string]int
+	expires    time.Time  // En erken dolan assume süresi, sıfırsa yok
+	shapes     []podShape // Bekleyen pod'ların istek şekilleri, en sık olan başta
+}
+
+// node verilen node'un bilgisini döndürür
+func (s *clusterSnapshot) node(name string) (*nodeInfo, bool) {
+	i, ok := s.index[name]
+	if !ok {
+		return nil, false
+	}
+	return s.nodes[i], true
+}
+
+// withRequests node'un isteklerini değiştirilmiş kopya snapshot'ı döndürür, workload boş değilse iş yükünün
+// node'daki pod sayısı da pods kadar değişir
+func (s *clusterSnapshot) withRequests(nodeName string, cpu, memory float64, pods int, workload string) *clusterSnapshot {
+	i, ok := s.index[nodeName]
+	if !ok {
+		return s
+	}
+
+	info := *s.nodes[i]
+	info.requestedCPU += cpu
+	info.requestedMemory += memory
+	info.pods += pods
+	if workload != "" {
+		// Harita önceki snapshot'larla paylaşıldığı için kopyalanır
+		workloads := make(map[string]int, len(info.workloads)+1)
+		for key, count := range info.workloads {
+			workloads[key] = count
+		}
+		workloads[workload] += pods
+		if workloads[workload] <= 0 {
+			delete(workloads, workload)
+		}
+		info.workloads = workloads
+	}
+
+	next := *s
+	next.nodes = make([]*nodeInfo, len(s.nodes))
+	copy(next.nodes, s.nodes)
+	next.nodes[i] = &info
+	return &next
+}
+
+// podKey assume kayıtlarının anahtarı
+type podKey struct {
+	namespace string
+	name      string
+}
+
+// assumedPod binding'i henüz görülmemiş, node'a yerleşmiş varsayılan pod
+type assumedPod struct {
+	nodeName string
+	cpu      float64
+	memory   float64
+	workload string
+	expires  time.Time
+}
+
+// schedulerCache güncel snapshot'ı ve assume edilen pod'ları tutar.
+// Okuyucular snapshot'a kilitsiz erişir; yeniden kurma ve assume/forget mutex ile sıralanır
+type schedulerCache struct {
+	mutex   sync.Mutex
+	current atomic.Pointer[clusterSnapshot]
+	assumed map[podKey]assumedPod
+}
+
+// sourceGeneration küme kaynağının generation'ını döndürür, kaynak yoksa false
+func (as *AIScheduler) sourceGeneration() (uint64, bool) {
+	if as.source == nil {
+		return 0, false
+	}
+	return as.source.Generation(), true
+}
+
+// snapshotStale snapshot yeniden kurulmalıysa true döner.
+// Değişiklik (veya sürüm bilgisi olmayan kaynak) ve dolan assume kayıtları yenilemeyi gerektirir,
+// yenileme snapshot_refresh aralığından sık yapılmaz
+func (as *AIScheduler) snapshotStale(snapshot *clusterSnapshot, now time.Time) bool {
+	if snapshot == nil {
+		return true
+	}
+
+	generation, versioned := as.sourceGeneration()
+	changed := !versioned || !snapshot.versioned || generation != snapshot.generation
+	expired := !snapshot.expires.IsZero() && !now.Before(snapshot.expires)
+	if !changed && !expired {
+		return false
+	}
+	return now.Sub(snapshot.builtAt) >= as.currentConfig().SnapshotRefresh
+}
+
+// currentSnapshot skorlamanın okuyacağı güncel snapshot'ı döndürür, gerekiyorsa yeniden kurar
+func (as *AIScheduler) currentSnapshot() (*clusterSnapshot, error) {
+	now := as.now()
+	if snapshot := as.cache.current.Load(); !as.snapshotStale(snapshot, now) {
+		return snapshot, nil
+	}
+
+	as.cache.mutex.Lock()
+	defer as.cache.mutex.Unlock()
+
+	// Başka bir istek beklerken kurmuş olabilir
+	if snapshot := as.cache.current.Load(); !as.snapshotStale(snapshot, now) {
+		return snapshot, nil
+	}
+
+	snapshot, err := as.buildSnapshot(now)
+	if err != nil {
+		// Kurulamazsa varsa eski snapshot ile devam edilir
+		if previous := as.cache.current.Load(); previous != nil {
+			return previous, nil
+		}
+		return nil, err
+	}
+	as.cache.current.Store(snapshot)
+	return snapshot, nil
+}
+
+// buildSnapshot kaynaktaki node ve pod'lardan snapshot kurar, onaylanan ve süresi dolan assume kayıtlarını temizler.
+// cache.mutex tutulurken çağrılmalıdır
+func (as *AIScheduler) buildSnapshot(now time.Time) (*clusterSnapshot, error) {
+	// Generation listelemeden önce okunur, arada gelen değişiklik sonraki istekte yakalanır
+	generation, versioned := as.sourceGeneration()
+
+	nodes, err := as.listNodes()
+	if err != nil {
+		return nil, fmt.Errorf("node listesi alınamadı: %v", err)
+	}
+	pods, err := as.listPods()
+	if err != nil {
+		return nil, fmt.Errorf("pod listesi alınamadı: %v", err)
+	}
+
+	infos := make([]nodeInfo, len(nodes))
+	snapshot := &clusterSnapshot{
+		generation: generation,
+		versioned:  versioned,
+		builtAt:    now,
+		nodes:      make([]*nodeInfo, len(nodes)),
+		index:      make(map[string]int, len(nodes)),
+	}
+	overcommit := as.currentConfig().Overcommit
+	var csiLimits map[string]map[string]int
+	volumeLimits := as.currentConfig().VolumeLimits.Enabled
+	if volumeLimits {
+		csiLimits = as.csiNodeLimits(now)
+	}
+	for i, node := range nodes {
+		infos[i].node = node
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			infos[i].allocatableCPU = float64(cpu.MilliValue()) / 1000.0
+		}
+		if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			infos[i].allocatableMemory = float64(memory.Value()) / (1024 * 1024 * 1024) // GB
+		}
+		cpuRatio, memoryRatio := overcommitRatios(&overcommit, node)
+		infos[i].capacityCPU = infos[i].allocatableCPU * cpuRatio
+		infos[i].capacityMemory = infos[i].allocatableMemory * memoryRatio
+		if pods, ok := node.Status.Allocatable[corev1.ResourcePods]; ok {
+			infos[i].allocatablePods = int(pods.Value())
+		}
+		if volumeLimits {
+			infos[i].attachLimits = nodeAttachLimits(node, csiLimits[node.Name])
+			infos[i].attached = attachedVolumes(node)
+		}
+		snapshot.nodes[i] = &infos[i]
+		snapshot.index[node.Name] = i
+	}
+
+	var pending shapeCounter
+	for _, pod := range pods {
+		// Kaynak yoksa binding'i görülen pod'un assume kaydı liste üzerinden onaylanır
+		if as.source == nil && len(as.cache.assumed) > 0 && pod.Spec.NodeName != "" {
+			delete(as.cache.assumed, podKey{namespace: pod.Namespace, name: pod.Name})
+		}
+		if pod.Spec.NodeName == "" && pod.Status.Phase == corev1.PodPending {
+			pending.add(types.PodResourceRequests(pod))
+		}
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		i, ok := snapshot.index[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		cpu, memory := types.PodResourceRequests(pod)
+		infos[i].requestedCPU += cpu
+		infos[i].requestedMemory += memory
+		infos[i].pods++
+		for _, port := range podHostPorts(pod) {
+			if infos[i].hostPorts == nil {
+				infos[i].hostPorts = make(map[string]bool)
+			}
+			infos[i].hostPorts[port] = true
+		}
+		if workload := workloadKey(pod); workload != "" {
+			if infos[i].workloads == nil {
+				infos[i].workloads = make(map[string]int)
+			}
+			infos[i].workloads[workload]++
+		}
+	}
+
+	// Henüz onaylanmamış assume kayıtları snapshot'a eklenir
+	for key, assumed := range as.cache.assumed {
+		if !now.Before(assumed.expires) || as.assumeConfirmed(key) {
+			delete(as.cache.assumed, key)
+			continue
+		}
+		if i, ok := snapshot.index[assumed.nodeName]; ok {
+			infos[i].requestedCPU += assumed.cpu
+			infos[i].requestedMemory += assumed.memory
+			infos[i].pods++
+			if assumed.workload != "" {
+				if infos[i].workloads == nil {
+					infos[i].workloads = make(map[string]int)
+				}
+				infos[i].workloads[assumed.workload]++
+			}
+		}
+		if snapshot.expires.IsZero() || assumed.expires.Before(snapshot.expires) {
+			snapshot.expires = assumed.expires
+		}
+	}
+	snapshot.shapes = pending.shapes()
+
+	return snapshot, nil
+}
+
+// assumeConfirmed pod'un binding'i küme kaynağında görülmüşse true döner
+func (as *AIScheduler) assumeConfirmed(key podKey) bool {
+	if as.source == nil {
+		return false
+	}
+	pod, ok := as.source.Pod(key.namespace, key.name)
+	return ok && pod.Spec.NodeName != ""
+}
+
+// assumedNode pod assume edilmişse node'unu döndürür
+func (as *AIScheduler) assumedNode(namespace, name string) string {
+	as.cache.mutex.Lock()
+	defer as.cache.mutex.Unlock()
+
+	return as.cache.assumed[podKey{namespace: namespace, name: name}].nodeName
+}
+
+// AssumePod pod'u binding görülene veya süre dolana kadar node'a yerleşmiş sayar, sonraki tahminler
+// node'un kapasitesini buna göre görür. Pod zaten assume edildiyse yeni node'a taşınır
+func (as *AIScheduler) AssumePod(pod *corev1.Pod, nodeName string, ttl time.Duration) {
+	cpu, memory := types.PodResourceRequests(pod)
+	workload := workloadKey(pod)
+	key := podKey{namespace: pod.Namespace, name: pod.Name}
+	expires := as.now().Add(ttl)
+
+	as.cache.mutex.Lock()
+	defer as.cache.mutex.Unlock()
+
+	if as.cache.assumed == nil {
+		as.cache.assumed = make(map[podKey]assumedPod)
+	}
+	snapshot := as.cache.current.Load()
+	if previous, ok := as.cache.assumed[key]; ok && snapshot != nil {
+		snapshot = snapshot.withRequests(previous.nodeName, -previous.cpu, -previous.memory, -1, previous.workload)
+	}
+	as.cache.assumed[key] = assumedPod{nodeName: nodeName, cpu: cpu, memory: memory, workload: workload, expires: expires}
+
+	if snapshot != nil {
+		snapshot = snapshot.withRequests(nodeName, cpu, memory, 1, workload)
+		if snapshot.expires.IsZero() || expires.Before(snapshot.expires) {
+			snapshot.expires = expires
+		}
+		as.cache.current.Store(snapshot)
+	}
+
+	as.appendJournal(JournalEntry{Kind: JournalAssume, Pod: &JournalPod{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Node:      nodeName,
+		CPU:       cpu,
+		Memory:    memory,
+		Workload:  workload,
+		Expires:   expires,
+	}})
+}
+
+// ForgetPod assume kaydını geri alır (ör: binding başarısız oldu), kayıt yoksa false döner
+func (as *AIScheduler) ForgetPod(namespace, name string) bool {
+	if !as.forgetPod(podKey{namespace: namespace, name: name}) {
+		return false
+	}
+	as.appendJournal(JournalEntry{Kind: JournalForget, Pod: &JournalPod{Namespace: namespace, Pod: name}})
+	return true
+}
+
+// forgetPod assume kaydını siler ve snapshot'tan düşer, kayıt yoksa false döner
+func (as *AIScheduler) forgetPod(key podKey) bool {
+	as.cache.mutex.Lock()
+	defer as.cache.mutex.Unlock()
+
+	assumed, ok := as.cache.assumed[key]
+	if !ok {
+		return false
+	}
+	delete(as.cache.assumed, key)
+
+	if snapshot := as.cache.current.Load(); snapshot != nil {
+		as.cache.current.Store(snapshot.withRequests(assumed.nodeName, -assumed.cpu, -assumed.memory, -1, assumed.workload))
+	}
+	return true
+}
+
+// AssumedPods binding'i beklenen assume kaydı sayısını döndürür
+func (as *AIScheduler) AssumedPods() int {
+	as.cache.mutex.Lock()
+	defer as.cache.mutex.Unlock()
+
+	return len(as.cache.assumed)
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultStalenessMaxAge skorlama girdisinin bayat sayılacağı varsayılan yaş
+const defaultStalenessMaxAge = 2 * time.Minute
+
+// Bayat girdi davranışları
+const (
+	StalenessFlag   = "flag"   // Karar yapılır, bayat girdiler ve düşürülmüş güvenle işaretlenir
+	StalenessReject = "reject" // Bayat girdili node'lar aday olmaz
+)
+
+// Tazeliği denetlenen skorlama girdileri
+const (
+	InputNodeMetrics = "node_metrics" // Node'un kullanım geçmişi (tahmin, ısınma)
+	InputPodMetrics  = "pod_metrics"  // Pod metrik cache'i (güvenilirlik analizi)
+)
+
+// StaleInput yaşı tazelik sınırını aşan skorlama girdisi
+type StaleInput struct {
+	Input string  `json:"input"`
+	Age   float64 `json:"age_seconds"`
+}
+
+// Staleness kararın bayat girdileri ve girdilerin yaşına göre düşürülmüş güveni (0-1)
+type Staleness struct {
+	Inputs     []StaleInput `json:"inputs"`
+	Confidence float64      `json:"confidence"`
+}
+
+// stalenessSettings varsayılanları uygulanmış tazelik ayarları
+func (as *AIScheduler) stalenessSettings() types.StalenessConfig {
+	cfg := as.currentConfig().Staleness
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = defaultStalenessMaxAge
+	}
+	if cfg.Action != StalenessReject {
+		cfg.Action = StalenessFlag
+	}
+	return cfg
+}
+
+// staleInputs node'un skorlama girdilerinden yaşı maxAge'i aşanları döndürür. Hiç güncellenmemiş girdinin yaşı
+// scheduler'ın başlangıcından sayılır; Start çağrılmadıysa (bench, replay) denetim yapılmaz
+func (as *AIScheduler) staleInputs(nodeName string, now time.Time, maxAge time.Duration) []StaleInput {
+	d := &as.degradation
+	d.mutex.Lock()
+	started := d.started
+	d.mutex.Unlock()
+	if started.IsZero() {
+		return nil
+	}
+
+	var stale []StaleInput
+	check := func(input string, updated time.Time) {
+		if age := now.Sub(latest(updated, started)); age > maxAge {
+			stale = append(stale, StaleInput{Input: input, Age: age.Seconds()})
+		}
+	}
+	if history := as.collector.GetNodeHistory(); history != nil {
+		check(InputNodeMetrics, history.LastSample(nodeName))
+	}
+	if as.podCache != nil {
+		check(InputPodMetrics, as.podCache.LastUpdate())
+	}
+	return stale
+}
+
+// staleConfidence her bayat girdinin güveni maxAge/yaş oranında düşürdüğü karar güvenini döndürür
+func staleConfidence(stale []StaleInput, maxAge time.Duration) float64 {
+	confidence := 1.0
+	for _, input := range stale {
+		confidence *= maxAge.Seconds() / input.Age
+	}
+	return confidence
+}
+
+// rejectStale reject modunda bayat girdili adayları çıkarır, çıkarılan aday sayısını da döndürür
+func (as *AIScheduler) rejectStale(candidates []NodeScore) ([]NodeScore, int) {
+	cfg := as.stalenessSettings()
+	if !cfg.Enabled || cfg.Action != StalenessReject {
+		return candidates, 0
+	}
+
+	now := as.now()
+	fresh := candidates[:0]
+	for _, candidate := range candidates {
+		if len(as.staleInputs(candidate.NodeName, now, cfg.MaxAge)) == 0 {
+			fresh = append(fresh, candidate)
+		}
+	}
+	return fresh, len(candidates) - len(fresh)
+}
+
+// isStale reject modunda node'un skorlama girdilerinden biri bayatsa true döner
+func (as *AIScheduler) isStale(nodeName string) bool {
+	cfg := as.stalenessSettings()
+	return cfg.Enabled && cfg.Action == StalenessReject && len(as.staleInputs(nodeName, as.now(), cfg.MaxAge)) > 0
+}
+
+// flagStale flag modunda seçilen node'un bayat girdilerini ve düşürülmüş güveni karara ekler
+func (as *AIScheduler) flagStale(score *NodeScore) {
+	cfg := as.stalenessSettings()
+	if !cfg.Enabled || cfg.Action != StalenessFlag {
+		return
+	}
+
+	stale := as.staleInputs(score.NodeName, as.now(), cfg.MaxAge)
+	if len(stale) == 0 {
+		return
+	}
+	score.Stale = &Staleness{Inputs: stale, Confidence: staleConfidence(stale, cfg.MaxAge)}
+	logrus.Warnf("Node %s kararı bayat girdilerle verildi (güven: %.2f): %v", score.NodeName, score.Stale.Confidence, stale)
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Başlatma gecikmesi skorlamasının varsayılanları
+const (
+	defaultStartupTarget     = 10 * time.Second
+	defaultStartupMinSamples = 3
+	defaultSensitiveLabel    = "ai-scheduler.io/latency-sensitive"
+)
+
+// startupSettings varsayılanları uygulanmış başlatma gecikmesi konfigürasyonunu döndürür
+func (as *AIScheduler) startupSettings() types.StartupLatencyScoringConfig {
+	cfg := as.currentConfig().StartupLatency
+	if cfg.Target <= 0 {
+		cfg.Target = defaultStartupTarget
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultStartupMinSamples
+	}
+	if cfg.SensitiveLabel == "" {
+		cfg.SensitiveLabel = defaultSensitiveLabel
+	}
+	return cfg
+}
+
+// expectedStartup node'un beklenen pod başlatma süresi aşamalarını döndürür, yeterli ölçüm yoksa false
+func (as *AIScheduler) expectedStartup(nodeName string, minSamples int) (types.NodeStartupLatency, bool) {
+	tracker := as.collector.GetStartupLatency()
+	if tracker == nil {
+		return types.NodeStartupLatency{}, false
+	}
+
+	latency, ok := tracker.Node(nodeName)
+	if !ok || latency.Samples < minSamples {
+		return types.NodeStartupLatency{}, false
+	}
+	return latency, true
+}
+
+// latencySensitive skorlama açıksa ve pod label veya annotation ile gecikmeye duyarlı işaretlenmişse true döner
+func (as *AIScheduler) latencySensitive(pod *corev1.Pod) bool {
+	cfg := as.startupSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 || pod == nil {
+		return false
+	}
+	return pod.Labels[cfg.SensitiveLabel] == "true" || pod.Annotations[cfg.SensitiveLabel] == "true"
+}
+
+// startupLatencyPenalty gecikmeye duyarlı pod yavaş başlatan node'a yerleşecekse ceza döndürür.
+// Ceza hedef süreyi aşan kısımla orantılıdır, ölçümü olmayan node cezalandırılmaz
+func (as *AIScheduler) startupLatencyPenalty(sensitive bool, node *corev1.Node) (float64, string) {
+	if !sensitive {
+		return 0, ""
+	}
+	cfg := as.startupSettings()
+
+	latency, ok := as.expectedStartup(node.Name, cfg.MinSamples)
+	if !ok || latency.Total <= cfg.Target {
+		return 0, ""
+	}
+
+	excess := float64(latency.Total-cfg.Target) / float64(cfg.Target)
+	if excess > 1 {
+		excess = 1
+	}
+	penalty := cfg.Weight * excess
+
+	return penalty, fmt.Sprintf("Başlatma gecikmesi cezası: %.1f (beklenen: %s > %s)", penalty, latency.Total.Round(100*time.Millisecond), cfg.Target)
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Disk doygunluğu cezasının varsayılanları
+const (
+	defaultStorageThreshold = 0.6
+	defaultIOHeavyLabel     = "ai-scheduler.io/io-heavy"
+)
+
+// storagePressureSettings varsayılanları uygulanmış disk doygunluğu cezası konfigürasyonunu döndürür
+func (as *AIScheduler) storagePressureSettings() types.StoragePressureConfig {
+	cfg := as.currentConfig().StoragePressure
+	if cfg.Threshold <= 0 || cfg.Threshold >= 1 {
+		cfg.Threshold = defaultStorageThreshold
+	}
+	if cfg.IOHeavyLabel == "" {
+		cfg.IOHeavyLabel = defaultIOHeavyLabel
+	}
+	return cfg
+}
+
+// storageIO node'un son toplamadaki disk IO durumunu döndürür, ölçüm yoksa false
+func (as *AIScheduler) storageIO(nodeName string) (types.NodeStorageIO, bool) {
+	tracker := as.collector.GetStoragePressure()
+	if tracker == nil {
+		return types.NodeStorageIO{}, false
+	}
+	return tracker.Node(nodeName)
+}
+
+// ioHeavy ceza açıksa ve pod label/annotation ile (veya ayarlıysa PVC bağladığı için) IO yoğunsa true döner
+func (as *AIScheduler) ioHeavy(pod *corev1.Pod) bool {
+	cfg := as.storagePressureSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 || pod == nil {
+		return false
+	}
+	if pod.Labels[cfg.IOHeavyLabel] == "true" || pod.Annotations[cfg.IOHeavyLabel] == "true" {
+		return true
+	}
+	if cfg.IncludePVCPods {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// storagePressurePenalty IO yoğun pod diskleri doygun node'a yerleşecekse ceza döndürür.
+// Ceza eşiği aşan doygunlukla lineer artar, ölçümü olmayan node cezalandırılmaz
+func (as *AIScheduler) storagePressurePenalty(heavy bool, node *corev1.Node) (float64, string) {
+	if !heavy {
+		return 0, ""
+	}
+	cfg := as.storagePressureSettings()
+
+	io, ok := as.storageIO(node.Name)
+	if !ok || io.Saturation <= cfg.Threshold {
+		return 0, ""
+	}
+
+	excess := math.Min(1, (io.Saturation-cfg.Threshold)/(1-cfg.Threshold))
+	penalty := cfg.Weight * excess
+
+	return penalty, fmt.Sprintf("Disk doygunluğu cezası: %.1f (doygunluk: %.2f, %.0f IOPS, %.1f MB/s)", penalty, io.Saturation, io.IOPS, io.ThroughputMBps)
+}
@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Şablon filtre cache'inin varsayılanları
+const (
+	defaultTemplateFilterTTL        = 30 * time.Second
+	defaultTemplateFilterMaxEntries = 1000
+	// Controller'ların pod şablonunun hash'ini yazdığı label'lar
+	podTemplateHashLabel        = "pod-template-hash"        // Deployment -> ReplicaSet
+	controllerRevisionHashLabel = "controller-revision-hash" // StatefulSet
+)
+
+// templateFilter aynı şablondan oluşturulan pod'lar için node'a bağlı filtre sonucu. Kapasite ve yerleşim sınırları
+// her pod için güncel snapshot'ta yeniden kontrol edilir
+type templateFilter struct {
+	nodes     []*corev1.Node // Sonucun hesaplandığı node nesneleri, biri değişirse sonuç geçersizdir
+	passed    []int          // Node'a bağlı kısıtlardan geçen node'ların snapshot indeksleri
+	rejected  map[string]int // Node'a bağlı kısıtlarda elenen node sayıları
+	platforms map[string]bool
+	security  *podSecurity
+	expires   time.Time
+}
+
+// templateFilterCache pod şablonu başına node'a bağlı filtre sonuçları
+type templateFilterCache struct {
+	mutex   sync.Mutex
+	entries map[string]*templateFilter
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+// templateKey pod'un controller'ı ve şablon hash'inden cache anahtarı üretir. Şablon hash'i olmayan, controller'sız
+// ve DaemonSet pod'ları (her biri farklı node'a bağlı) için boş döner
+func templateKey(pod *corev1.Pod) string {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil || controller.Kind == "DaemonSet" {
+		return ""
+	}
+	hash := pod.Labels[podTemplateHashLabel]
+	if hash == "" {
+		hash = pod.Labels[controllerRevisionHashLabel]
+	}
+	if hash == "" {
+		return ""
+	}
+	return pod.Namespace + "/" + string(controller.UID) + "/" + hash
+}
+
+// get şablonun süresi dolmamış ve snapshot'taki node'larla hesaplanmış sonucunu döndürür
+func (c *templateFilterCache) get(key string, snapshot *clusterSnapshot, now time.Time) *templateFilter {
+	c.mutex.Lock()
+	entry, ok := c.entries[key]
+	c.mutex.Unlock()
+	if !ok || !now.Before(entry.expires) || len(entry.nodes) != len(snapshot.nodes) {
+		return nil
+	}
+	for i, info := range snapshot.nodes {
+		if info.node != entry.nodes[i] {
+			return nil
+		}
+	}
+	return entry
+}
+
+// put sonucu cache'e yazar, cache doluysa önce süresi dolanlar, yine doluysa rastgele bir kayıt silinir
+func (c *templateFilterCache) put(key string, entry *templateFilter, maxEntries int, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]*templateFilter)
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxEntries {
+		for other, cached := range c.entries {
+			if !now.Before(cached.expires) {
+				delete(c.entries, other)
+			}
+		}
+		for other := range c.entries {
+			if len(c.entries) < maxEntries {
+				break
+			}
+			delete(c.entries, other)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// templateFilterSettings varsayılanları uygulanmış şablon filtre cache'i ayarları
+func (as *AIScheduler) templateFilterSettings() types.TemplateFilterCacheConfig {
+	cfg := as.currentConfig().TemplateFilterCache
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTemplateFilterTTL
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultTemplateFilterMaxEntries
+	}
+	return cfg
+}
+
+// filterPod pod'un filtre isteğini hazırlar ve snapshot'ı filtreler. Aynı şablondan gelen pod'lar için node'a bağlı
+// kısıtların sonucu (ve imaj platformu, güvenlik gereksinimleri) cache'ten alınır, sadece node'daki yüke bağlı
+// kısıtlar yeniden kontrol edilir. Sonuç filterNodes ile aynıdır
+func (as *AIScheduler) filterPod(pod *corev1.Pod, snapshot *clusterSnapshot) (podRequest, []*nodeInfo, map[string]int) {
+	cfg := as.templateFilterSettings()
+	key := ""
+	if cfg.Enabled {
+		key = templateKey(pod)
+	}
+	if key == "" {
+		request := as.newPodRequest(pod)
+		feasible, rejected := filterNodes(snapshot, &request)
+		return request, feasible, rejected
+	}
+
+	now := as.now()
+	var request podRequest
+	entry := as.templates.get(key, snapshot, now)
+	if entry != nil {
+		as.templates.hits.Add(1)
+		cpu, memory := types.PodResourceRequests(pod)
+		ownNode := pod.Spec.NodeName
+		if ownNode == "" {
+			ownNode = as.assumedNode(pod.Namespace, pod.Name)
+		}
+		request = podRequest{pod: pod, cpu: cpu, memory: memory, ownNode: ownNode, platforms: entry.platforms,
+			security: entry.security, guardrails: as.podGuardrails(pod), volumes: as.podAttachVolumes(pod)}
+	} else {
+		as.templates.misses.Add(1)
+		request = as.newPodRequest(pod)
+		entry = staticFilter(snapshot, &request)
+		entry.expires = now.Add(cfg.TTL)
+		as.templates.put(key, entry, cfg.MaxEntries, now)
+	}
+
+	feasible := make([]*nodeInfo, 0, len(entry.passed))
+	var rejected map[string]int
+	if len(entry.rejected) > 0 {
+		rejected = make(map[string]int, len(entry.rejected))
+		for reason, count := range entry.rejected {
+			rejected[reason] = count
+		}
+	}
+	for _, i := range entry.passed {
+		info := snapshot.nodes[i]
+		if reason := filterNodeLoad(&request, info); reason != "" {
+			if rejected == nil {
+				rejected = make(map[string]int)
+			}
+			rejected[reason]++
+			continue
+		}
+		feasible = append(feasible, info)
+	}
+	return request, feasible, rejected
+}
+
+// staticFilter snapshot'taki node'ları pod'un node'a bağlı kısıtlarıyla filtreler
+func staticFilter(snapshot *clusterSnapshot, request *podRequest) *templateFilter {
+	entry := &templateFilter{
+		nodes:     make([]*corev1.Node, len(snapshot.nodes)),
+		passed:    make([]int, 0, len(snapshot.nodes)),
+		platforms: request.platforms,
+		security:  request.security,
+	}
+	for i, info := range snapshot.nodes {
+		entry.nodes[i] = info.node
+		if reason := filterNodeStatic(request, info.node); reason != "" {
+			if entry.rejected == nil {
+				entry.rejected = make(map[string]int)
+			}
+			entry.rejected[reason]++
+			continue
+		}
+		entry.passed = append(entry.passed, i)
+	}
+	return entry
+}
+
+// TemplateFilterStats şablon filtre cache'inin isabet ve ıska sayılarını döndürür
+func (as *AIScheduler) TemplateFilterStats() (hits, misses uint64) {
+	return as.templates.hits.Load(), as.templates.misses.Load()
+}
@@ -0,0 +1,350 @@
+package scheduler
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Şablon geçmişinin varsayılanları
+const (
+	defaultTemplateHistoryMaxTemplates = 2000
+	defaultTemplateHistoryMinSamples   = 5
+	templateHistoryWorkloads           = 10   // Şablon başına tutulan iş yükü sayısı
+	templateSuspectMargin              = 0.25 // Özellikli node'lardaki başarısızlık oranının diğerlerini aşması gereken fark
+)
+
+// ErrTemplateHistoryNotFound şablon özeti için sonuç geçmişi yok
+var ErrTemplateHistoryNotFound = errors.New("şablon için sonuç geçmişi yok")
+
+// TemplateNodeStats şablonun bir node'daki yerleşim sonuçları
+type TemplateNodeStats struct {
+	NodeName    string  `json:"node_name"`
+	Placed      int     `json:"placed"`
+	Failed      int     `json:"failed"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// TemplatePropertyStats şablonun bir node özelliğine (ör: kernel=5.15.0) sahip node'lardaki yerleşim sonuçları.
+// Suspect, özellikli node'lardaki başarısızlık oranı diğer node'lardakini belirgin şekilde aşıyorsa true olur
+type TemplatePropertyStats struct {
+	Property         string  `json:"property"`
+	Placed           int     `json:"placed"`
+	Failed           int     `json:"failed"`
+	FailureRate      float64 `json:"failure_rate"`
+	OtherFailureRate float64 `json:"other_failure_rate"` // Özelliği olmayan node'lardaki oran
+	Suspect          bool    `json:"suspect,omitempty"`
+}
+
+// TemplateHistory pod şablonunun (bkz. types.TemplateFingerprint) node'lar ve node özellikleri bazında sonuç geçmişi
+type TemplateHistory struct {
+	Template    string                  `json:"template"`
+	Workloads   []types.WorkloadRef     `json:"workloads"`
+	Placed      int                     `json:"placed"`
+	Failed      int                     `json:"failed"` // Başarısız, yeniden başlamış veya başlamamış sonuçlar
+	FailureRate float64                 `json:"failure_rate"`
+	LastSeen    time.Time               `json:"last_seen"`
+	Nodes       []TemplateNodeStats     `json:"nodes"`
+	Properties  []TemplatePropertyStats `json:"properties"`
+}
+
+// templateCount yerleşim ve başarısızlık sayıları
+type templateCount struct {
+	placed int
+	failed int
+}
+
+// rate başarısızlık oranı
+func (c templateCount) rate() float64 {
+	if c.placed == 0 {
+		return 0
+	}
+	return float64(c.failed) / float64(c.placed)
+}
+
+// templateRecord bir şablonun birikmiş sonuçları
+type templateRecord struct {
+	total      templateCount
+	workloads  []types.WorkloadRef
+	nodes      map[string]*templateCount
+	properties map[string]*templateCount
+	lastSeen   time.Time
+}
+
+// templateHistory şablon özeti başına sonuç geçmişi. Etiketlenen karar sonuçlarından beslenir
+type templateHistory struct {
+	mutex     sync.Mutex
+	templates map[string]*templateRecord
+}
+
+// templateHistorySettings varsayılanları uygulanmış şablon geçmişi ayarları
+func (as *AIScheduler) templateHistorySettings() types.TemplateHistoryConfig {
+	cfg := as.currentConfig().TemplateHistory
+	if cfg.MaxTemplates <= 0 {
+		cfg.MaxTemplates = defaultTemplateHistoryMaxTemplates
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultTemplateHistoryMinSamples
+	}
+	return cfg
+}
+
+// nodeProperties başarısızlıkların ilişkilendirildiği node özelliklerini "anahtar=değer" olarak döndürür
+func nodeProperties(node *corev1.Node) []string {
+	platform := types.NodePlatform(node)
+	info := &node.Status.NodeInfo
+	candidates := [][2]string{
+		{"zone", node.Labels[corev1.LabelTopologyZone]},
+		{"instance_type", node.Labels[corev1.LabelInstanceTypeStable]},
+		{"os", platform.OS},
+		{"arch", platform.Architecture},
+		{"os_image", info.OSImage},
+		{"kernel", info.KernelVersion},
+		{"runtime", info.ContainerRuntimeVersion},
+		{"kubelet", info.KubeletVersion},
+	}
+	properties := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate[1] != "" {
+			properties = append(properties, candidate[0]+"="+candidate[1])
+		}
+	}
+	return properties
+}
+
+// nodePropertiesOf node'un özelliklerini döndürür, node okunamazsa nil
+func (as *AIScheduler) nodePropertiesOf(nodeName string) []string {
+	node, err := as.getNode(nodeName)
+	if err != nil {
+		return nil
+	}
+	return nodeProperties(node)
+}
+
+// recordTemplateOutcome tahmin edilen node'da sonuçlanan kaydı şablonun geçmişine yazar. Node'a bağlanmayan,
+// başka node'a bağlanan veya silinen pod'lar sayılmaz
+func (as *AIScheduler) recordTemplateOutcome(record *OutcomeRecord) {
+	cfg := as.templateHistorySettings()
+	if !cfg.Enabled || record.Template == "" {
+		return
+	}
+	switch record.Result {
+	case ResultSucceeded, ResultFailed, ResultRestarted, ResultNotStarted:
+	default:
+		return
+	}
+	failed := 0
+	if badResult(record.Result) {
+		failed = 1
+	}
+
+	h := &as.templateHistory
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.templates == nil {
+		h.templates = make(map[string]*templateRecord)
+	}
+	entry, ok := h.templates[record.Template]
+	if !ok {
+		if len(h.templates) >= cfg.MaxTemplates {
+			h.evictOldestLocked()
+		}
+		entry = &templateRecord{nodes: make(map[string]*templateCount), properties: make(map[string]*templateCount)}
+		h.templates[record.Template] = entry
+	}
+
+	entry.total.placed++
+	entry.total.failed += failed
+	if record.ObservedAt.After(entry.lastSeen) {
+		entry.lastSeen = record.ObservedAt
+	}
+	count := entry.nodes[record.Node]
+	if count == nil {
+		count = &templateCount{}
+		entry.nodes[record.Node] = count
+	}
+	count.placed++
+	count.failed += failed
+	for _, property := range record.NodeProperties {
+		count := entry.properties[property]
+		if count == nil {
+			count = &templateCount{}
+			entry.properties[property] = count
+		}
+		count.placed++
+		count.failed += failed
+	}
+
+	known := false
+	for _, workload := range entry.workloads {
+		if workload == record.Workload {
+			known = true
+			break
+		}
+	}
+	if !known && len(entry.workloads) < templateHistoryWorkloads {
+		entry.workloads = append(entry.workloads, record.Workload)
+	}
+}
+
+// evictOldestLocked en uzun süredir sonuç görülmeyen şablonu siler, kilit tutulurken çağrılır
+func (h *templateHistory) evictOldestLocked() {
+	var oldest string
+	var oldestSeen time.Time
+	for template, entry := range h.templates {
+		if oldest == "" || entry.lastSeen.Before(oldestSeen) {
+			oldest, oldestSeen = template, entry.lastSeen
+		}
+	}
+	delete(h.templates, oldest)
+}
+
+// templateFeatures şablonun geçmişinden node'a özgü AI özelliklerini features'a yazar: şablonun genel, node'daki ve
+// node özelliklerindeki başarısızlık oranları ile node'un şüpheli özellik sayısı. Geçmiş yoksa özellikler 0'dır
+func (as *AIScheduler) templateFeatures(template string, nodeName string, properties []string, features map[string]interface{}) {
+	cfg := as.templateHistorySettings()
+	placements, failureRate, nodeRate, propertyRate, suspects := 0, 0.0, 0.0, 0.0, 0
+
+	h := &as.templateHistory
+	h.mutex.Lock()
+	if entry, ok := h.templates[template]; ok && cfg.Enabled {
+		placements, failureRate = entry.total.placed, entry.total.rate()
+		if count, ok := entry.nodes[nodeName]; ok {
+			nodeRate = count.rate()
+		}
+		for _, property := range properties {
+			stats, ok := entry.propertyStats(property, cfg.MinSamples)
+			if !ok || stats.Placed < cfg.MinSamples {
+				continue
+			}
+			propertyRate = max(propertyRate, stats.FailureRate)
+			if stats.Suspect {
+				suspects++
+			}
+		}
+	}
+	h.mutex.Unlock()
+
+	features["template_placements"] = float64(placements)
+	features["template_failure_rate"] = failureRate
+	features["template_node_failure_rate"] = nodeRate
+	features["template_property_failure_rate"] = propertyRate
+	features["template_suspect_properties"] = float64(suspects)
+}
+
+// propertyStats şablonun özellikli ve özelliksiz node'lardaki sonuçlarını karşılaştırır
+func (r *templateRecord) propertyStats(property string, minSamples int) (TemplatePropertyStats, bool) {
+	count, ok := r.properties[property]
+	if !ok {
+		return TemplatePropertyStats{}, false
+	}
+	other := templateCount{placed: r.total.placed - count.placed, failed: r.total.failed - count.failed}
+	stats := TemplatePropertyStats{
+		Property:         property,
+		Placed:           count.placed,
+		Failed:           count.failed,
+		FailureRate:      count.rate(),
+		OtherFailureRate: other.rate(),
+	}
+	stats.Suspect = count.placed >= minSamples && count.failed > 0 &&
+		stats.FailureRate-stats.OtherFailureRate >= templateSuspectMargin
+	return stats, true
+}
+
+// history şablon kaydının dışa açık görünümünü oluşturur
+func (r *templateRecord) history(template string, minSamples int) TemplateHistory {
+	history := TemplateHistory{
+		Template:    template,
+		Workloads:   append([]types.WorkloadRef{}, r.workloads...),
+		Placed:      r.total.placed,
+		Failed:      r.total.failed,
+		FailureRate: r.total.rate(),
+		LastSeen:    r.lastSeen,
+		Nodes:       make([]TemplateNodeStats, 0, len(r.nodes)),
+		Properties:  make([]TemplatePropertyStats, 0, len(r.properties)),
+	}
+	for nodeName, count := range r.nodes {
+		history.Nodes = append(history.Nodes, TemplateNodeStats{
+			NodeName:    nodeName,
+			Placed:      count.placed,
+			Failed:      count.failed,
+			FailureRate: count.rate(),
+		})
+	}
+	sort.Slice(history.Nodes, func(i, j int) bool {
+		if history.Nodes[i].Failed != history.Nodes[j].Failed {
+			return history.Nodes[i].Failed > history.Nodes[j].Failed
+		}
+		return history.Nodes[i].NodeName < history.Nodes[j].NodeName
+	})
+	for property := range r.properties {
+		stats, _ := r.propertyStats(property, minSamples)
+		history.Properties = append(history.Properties, stats)
+	}
+	// Şüpheli özellikler başta, sonra başarısızlık oranı yüksek olanlar
+	sort.Slice(history.Properties, func(i, j int) bool {
+		a, b := &history.Properties[i], &history.Properties[j]
+		if a.Suspect != b.Suspect {
+			return a.Suspect
+		}
+		if a.FailureRate != b.FailureRate {
+			return a.FailureRate > b.FailureRate
+		}
+		return a.Property < b.Property
+	})
+	return history
+}
+
+// TemplateHistory şablon özetinin node ve node özelliği bazında sonuç geçmişini döndürür
+func (as *AIScheduler) TemplateHistory(template string) (*TemplateHistory, error) {
+	minSamples := as.templateHistorySettings().MinSamples
+
+	h := &as.templateHistory
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	entry, ok := h.templates[template]
+	if !ok {
+		return nil, ErrTemplateHistoryNotFound
+	}
+	history := entry.history(template, minSamples)
+	return &history, nil
+}
+
+// TemplateHistories en fazla limit şablonun geçmişini döndürür (limit 0 ise tümü). Şüpheli node özelliği olanlar
+// başta, sonra başarısız sonucu çok olanlar gelir
+func (as *AIScheduler) TemplateHistories(limit int) []TemplateHistory {
+	minSamples := as.templateHistorySettings().MinSamples
+
+	h := &as.templateHistory
+	h.mutex.Lock()
+	histories := make([]TemplateHistory, 0, len(h.templates))
+	for template, entry := range h.templates {
+		histories = append(histories, entry.history(template, minSamples))
+	}
+	h.mutex.Unlock()
+
+	suspect := func(history *TemplateHistory) bool {
+		return len(history.Properties) > 0 && history.Properties[0].Suspect
+	}
+	sort.Slice(histories, func(i, j int) bool {
+		a, b := &histories[i], &histories[j]
+		if suspect(a) != suspect(b) {
+			return suspect(a)
+		}
+		if a.Failed != b.Failed {
+			return a.Failed > b.Failed
+		}
+		return a.Template < b.Template
+	})
+	if limit > 0 && limit < len(histories) {
+		histories = histories[:limit]
+	}
+	return histories
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"strings"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// holidayLayout tatil günlerinin konfigürasyondaki formatı
+const holidayLayout = "2006-01-02"
+
+// weekdays konfigürasyondaki gün isimleri
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// temporalCalendar zaman bazlı özellikler için çözülmüş saat dilimi ve takvim
+type temporalCalendar struct {
+	location *time.Location
+	weekend  map[time.Weekday]bool
+	holidays map[string]bool
+}
+
+// newTemporalCalendar konfigürasyondan takvim oluşturur, geçersiz değerler loglanıp atlanır. Saat dilimi
+// verilmezse sunucunun yerel saat dilimi kullanılır, verilen dilim yüklenemezse UTC
+func newTemporalCalendar(temporalConfig *types.TemporalConfig) *temporalCalendar {
+	calendar := &temporalCalendar{
+		location: time.Local,
+		weekend:  make(map[time.Weekday]bool),
+		holidays: make(map[string]bool),
+	}
+
+	if temporalConfig.Timezone != "" {
+		location, err := time.LoadLocation(temporalConfig.Timezone)
+		if err != nil {
+			logrus.Warnf("Saat dilimi %s yüklenemedi, UTC kullanılacak: %v", temporalConfig.Timezone, err)
+			calendar.location = time.UTC
+		} else {
+			calendar.location = location
+		}
+	}
+
+	for _, day := range temporalConfig.WeekendDays {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			logrus.Warnf("Bilinmeyen hafta sonu günü yok sayıldı: %s", day)
+			continue
+		}
+		calendar.weekend[weekday] = true
+	}
+
+	for _, holiday := range temporalConfig.Holidays {
+		if _, err := time.Parse(holidayLayout, holiday); err != nil {
+			logrus.Warnf("Geçersiz tatil günü yok sayıldı: %s", holiday)
+			continue
+		}
+		calendar.holidays[holiday] = true
+	}
+
+	return calendar
+}
+
+// addFeatures verilen anın saat dilimindeki zaman bazlı özelliklerini features'a ekler
+func (c *temporalCalendar) addFeatures(features map[string]interface{}, now time.Time) {
+	local := now.In(c.location)
+
+	isWeekend := 0.0
+	if c.weekend[local.Weekday()] {
+		isWeekend = 1.0
+	}
+	isHoliday := 0.0
+	if c.holidays[local.Format(holidayLayout)] {
+		isHoliday = 1.0
+	}
+
+	features["hour_of_day"] = float64(local.Hour()) / 24.0
+	features["day_of_week"] = float64(local.Weekday()) / 7.0
+	features["is_weekend"] = isWeekend
+	features["is_holiday"] = isHoliday
+}
+
+// temporalCalendar geçerli takvimi döndürür
+func (as *AIScheduler) temporalCalendar() *temporalCalendar {
+	as.configMu.RLock()
+	defer as.configMu.RUnlock()
+
+	return as.calendar
+}
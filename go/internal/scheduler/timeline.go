@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// Zaman çizelgesi olay türleri
+const (
+	TimelineDecision      = "decision"                      // Scheduler kararı
+	TimelineOutcome       = "outcome"                       // Kararın sonucu etiketlendi
+	TimelineNodeCondition = types.ClusterEventNodeCondition // Node koşulu değişti
+	TimelineCollectorGap  = types.ClusterEventCollectorGap  // Metrik toplamasında boşluk
+	TimelineModel         = "model"                         // Skorlama ağırlıkları, profil, bozulma seviyesi veya mod değişti
+)
+
+// TimelineKinds zaman çizelgesindeki olay türleri
+var TimelineKinds = []string{TimelineDecision, TimelineOutcome, TimelineNodeCondition, TimelineCollectorGap, TimelineModel}
+
+// ErrTimelineKind bilinmeyen zaman çizelgesi olay türü
+var ErrTimelineKind = errors.New("bilinmeyen olay türü")
+
+// TimelineEvent zaman çizelgesinin tek olayı, Data kaynağın kaydıdır (karar, sonuç, küme olayı, ağırlık değişikliği...)
+type TimelineEvent struct {
+	Time      time.Time   `json:"time"`
+	Kind      string      `json:"kind"`
+	Node      string      `json:"node,omitempty"`
+	Namespace string      `json:"namespace,omitempty"`
+	Pod       string      `json:"pod,omitempty"`
+	Summary   string      `json:"summary"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// TimelineQuery zaman çizelgesi filtreleri, sıfır değerli alanlar uygulanmaz
+type TimelineQuery struct {
+	Since     time.Time
+	Until     time.Time
+	Kinds     []string
+	Node      string
+	Namespace string
+	// Limit aralıktaki en yeni Limit olay döndürülür
+	Limit int
+}
+
+// Timeline kararları, sonuçları, node koşulu değişikliklerini, toplama boşluklarını ve model değişikliklerini tek
+// zaman sırasında (eskiden yeniye) birleştirir. Node ve namespace filtreleri node'a veya pod'a bağlı olmayan
+// olayları (toplama boşlukları, model değişiklikleri) elemez, böylece olay incelemesinde bağlam korunur
+func (as *AIScheduler) Timeline(query TimelineQuery) ([]TimelineEvent, error) {
+	kinds := make(map[string]bool, len(TimelineKinds))
+	for _, kind := range query.Kinds {
+		if !containsString(TimelineKinds, kind) {
+			return nil, fmt.Errorf("%s: %w", kind, ErrTimelineKind)
+		}
+		kinds[kind] = true
+	}
+	if len(kinds) == 0 {
+		for _, kind := range TimelineKinds {
+			kinds[kind] = true
+		}
+	}
+
+	var events []TimelineEvent
+	add := func(event TimelineEvent, nodes ...string) {
+		if (!query.Since.IsZero() && event.Time.Before(query.Since)) || (!query.Until.IsZero() && event.Time.After(query.Until)) {
+			return
+		}
+		if query.Namespace != "" && event.Pod != "" && event.Namespace != query.Namespace {
+			return
+		}
+		if query.Node != "" && len(nodes) > 0 && !containsString(nodes, query.Node) {
+			return
+		}
+		events = append(events, event)
+	}
+
+	if kinds[TimelineDecision] {
+		for _, decision := range as.Decisions(0) {
+			decision := decision
+			add(TimelineEvent{
+				Time:      decision.Time,
+				Kind:      TimelineDecision,
+				Node:      decision.Node,
+				Namespace: decision.Namespace,
+				Pod:       decision.Pod,
+				Summary:   decisionSummary(&decision),
+				Data:      decision,
+			}, decision.Node)
+		}
+	}
+	if kinds[TimelineOutcome] {
+		for _, record := range as.Outcomes(0) {
+			summary := fmt.Sprintf("%s/%s %s node'unda: %s", record.Namespace, record.Pod, record.Node, record.Result)
+			if record.BoundNode != "" && record.BoundNode != record.Node {
+				summary += " (bağlanan node: " + record.BoundNode + ")"
+			}
+			add(TimelineEvent{
+				Time:      record.ObservedAt,
+				Kind:      TimelineOutcome,
+				Node:      record.Node,
+				Namespace: record.Namespace,
+				Pod:       record.Pod,
+				Summary:   summary,
+				Data:      record,
+			}, record.Node, record.BoundNode)
+		}
+	}
+	if kinds[TimelineNodeCondition] || kinds[TimelineCollectorGap] {
+		if log := as.collector.GetClusterEvents(); log != nil {
+			for _, event := range log.Events(query.Since, query.Until) {
+				if !kinds[event.Kind] {
+					continue
+				}
+				if event.Kind == types.ClusterEventCollectorGap {
+					add(TimelineEvent{
+						Time:    event.Time,
+						Kind:    TimelineCollectorGap,
+						Summary: fmt.Sprintf("Metrik toplaması %s boyunca yapılamadı", event.Gap.Round(time.Second)),
+						Data:    event,
+					})
+					continue
+				}
+				add(TimelineEvent{
+					Time: event.Time,
+					Kind: TimelineNodeCondition,
+					Node: event.Node,
+					Summary: fmt.Sprintf("Node %s koşulu %s: %s -> %s", event.Node, event.Condition,
+						event.Previous, event.Status),
+					Data: event,
+				}, event.Node)
+			}
+		}
+	}
+	if kinds[TimelineModel] {
+		for _, change := range as.weightChanges() {
+			summary := fmt.Sprintf("Skorlama ağırlıkları değişti (%s)", change.Source)
+			if change.Reason != "" {
+				summary += ": " + change.Reason
+			}
+			add(TimelineEvent{Time: change.Time, Kind: TimelineModel, Summary: summary, Data: change})
+		}
+		for _, change := range as.profileChanges() {
+			summary := fmt.Sprintf("Skorlama profili değişti (%s): %s", change.Source, profileLabel(change.Bindings.Active))
+			if change.Reason != "" {
+				summary += ": " + change.Reason
+			}
+			add(TimelineEvent{Time: change.Time, Kind: TimelineModel, Summary: summary, Data: change})
+		}
+		for _, transition := range as.TierTransitions() {
+			summary := fmt.Sprintf("Bozulma seviyesi %s -> %s", transition.From, transition.To)
+			if transition.Reason != "" {
+				summary += ": " + transition.Reason
+			}
+			add(TimelineEvent{Time: transition.Time, Kind: TimelineModel, Summary: summary, Data: transition})
+		}
+		// Mod geçmişi tutulmaz, sadece son mod değişikliği eklenir
+		if mode := as.Mode(); !mode.Since.IsZero() {
+			summary := "Scheduler modu: aktif"
+			if mode.ObserveOnly {
+				summary = "Scheduler modu: sadece gözlem"
+			}
+			if mode.Reason != "" {
+				summary += ": " + mode.Reason
+			}
+			add(TimelineEvent{Time: mode.Since, Kind: TimelineModel, Summary: summary, Data: mode})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	if query.Limit > 0 && len(events) > query.Limit {
+		events = events[len(events)-query.Limit:]
+	}
+	if events == nil {
+		events = make([]TimelineEvent, 0)
+	}
+	return events, nil
+}
+
+// decisionSummary kararın tek satırlık özeti
+func decisionSummary(decision *Decision) string {
+	summary := fmt.Sprintf("%s/%s %s", decision.Namespace, decision.Pod, decision.Outcome)
+	if decision.Node != "" {
+		summary += fmt.Sprintf(" -> %s (skor: %.1f)", decision.Node, decision.Score)
+	}
+	if decision.Outcome == OutcomeUnschedulable && decision.Reason != "" {
+		summary += ": " + decision.Reason
+	}
+	return summary
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CSI volume bağlama sınırları
+const (
+	// csiVolumePrefix node.status.volumesAttached'daki CSI volume adlarının öneki ("kubernetes.io/csi/<driver>^<handle>")
+	csiVolumePrefix = "kubernetes.io/csi/"
+	// attachableVolumesPrefix CSINode olmayan kümelerde sürücü sınırının node allocatable'daki öneki
+	attachableVolumesPrefix = "attachable-volumes-csi-"
+	// csiNodeRefresh Kubernetes API'den okunan CSINode sınırlarının yeniden kullanılma süresi
+	csiNodeRefresh = time.Minute
+)
+
+// attachVolume pod'un node'a bağlanması gereken CSI volume'u
+type attachVolume struct {
+	driver string
+	handle string
+}
+
+// csiLimitCache CSINode'lardan okunan node ve sürücü başına bağlanabilir volume sınırları
+type csiLimitCache struct {
+	mutex   sync.Mutex
+	limits  map[string]map[string]int
+	fetched time.Time
+}
+
+// csiNodeLimits node ve sürücü başına CSINode sınırlarını döndürür. Küme kaynağı CSINode sağlamıyorsa API'den
+// okunan sınırlar csiNodeRefresh boyunca yeniden kullanılır, okunamazsa nil
+func (as *AIScheduler) csiNodeLimits(now time.Time) map[string]map[string]int {
+	c := &as.csiLimits
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.limits != nil && now.Sub(c.fetched) < csiNodeRefresh {
+		return c.limits
+	}
+	csiNodes, err := as.listCSINodes()
+	if err != nil {
+		logrus.Debugf("CSINode'lar alınamadı, node allocatable sınırları kullanılacak: %v", err)
+		return c.limits
+	}
+
+	limits := make(map[string]map[string]int, len(csiNodes))
+	for _, csiNode := range csiNodes {
+		for _, driver := range csiNode.Spec.Drivers {
+			if driver.Allocatable == nil || driver.Allocatable.Count == nil {
+				continue
+			}
+			if limits[csiNode.Name] == nil {
+				limits[csiNode.Name] = make(map[string]int)
+			}
+			limits[csiNode.Name][driver.Name] = int(*driver.Allocatable.Count)
+		}
+	}
+	c.limits, c.fetched = limits, now
+	return limits
+}
+
+// nodeAttachLimits node'un sürücü başına bağlanabilir volume sınırlarını döndürür: CSINode'daki sınır yoksa
+// node allocatable'daki attachable-volumes-csi-<sürücü> kullanılır
+func nodeAttachLimits(node *corev1.Node, csiLimits map[string]int) map[string]int {
+	var limits map[string]int
+	for name, quantity := range node.Status.Allocatable {
+		driver, ok := strings.CutPrefix(string(name), attachableVolumesPrefix)
+		if !ok {
+			continue
+		}
+		if limits == nil {
+			limits = make(map[string]int)
+		}
+		limits[driver] = int(quantity.Value())
+	}
+	for driver, count := range csiLimits {
+		if limits == nil {
+			limits = make(map[string]int, len(csiLimits))
+		}
+		limits[driver] = count
+	}
+	return limits
+}
+
+// attachedVolumes node'a bağlı veya kullanımdaki CSI volume'larını sürücü başına "sürücü^handle" kümesi olarak döndürür
+func attachedVolumes(node *corev1.Node) map[string]map[string]bool {
+	var attached map[string]map[string]bool
+	add := func(name string) {
+		unique, ok := strings.CutPrefix(name, csiVolumePrefix)
+		if !ok {
+			return
+		}
+		driver, _, ok := strings.Cut(unique, "^")
+		if !ok {
+			return
+		}
+		if attached == nil {
+			attached = make(map[string]map[string]bool)
+		}
+		if attached[driver] == nil {
+			attached[driver] = make(map[string]bool)
+		}
+		attached[driver][unique] = true
+	}
+	for _, volume := range node.Status.VolumesAttached {
+		add(string(volume.Name))
+	}
+	for _, volume := range node.Status.VolumesInUse {
+		add(string(volume))
+	}
+	return attached
+}
+
+// podAttachVolumes pod'un PVC'lerinin (generic ephemeral volume'lar dahil) bağlı olduğu CSI PV'lerini döndürür.
+// Özellik kapalıysa nil. Henüz bağlanmamış PVC'ler (WaitForFirstConsumer) sürücüsü bilinmediğinden sayılmaz
+func (as *AIScheduler) podAttachVolumes(pod *corev1.Pod) []attachVolume {
+	if !as.currentConfig().VolumeLimits.Enabled {
+		return nil
+	}
+
+	var volumes []attachVolume
+	for _, volume := range pod.Spec.Volumes {
+		claimName := ""
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.Ephemeral != nil:
+			claimName = pod.Name + "-" + volume.Name
+		default:
+			continue
+		}
+		pv, err := as.boundVolume(pod.Namespace, claimName)
+		if err != nil {
+			logrus.Debugf("%s/%s volume'u %s çözülemedi, bağlama sınırında sayılmayacak: %v", pod.Namespace, pod.Name, volume.Name, err)
+			continue
+		}
+		if pv == nil || pv.Spec.CSI == nil {
+			continue
+		}
+		volumes = append(volumes, attachVolume{driver: pv.Spec.CSI.Driver, handle: pv.Spec.CSI.VolumeHandle})
+	}
+	return volumes
+}
+
+// volumeLimitExceeded pod'un node'a henüz bağlı olmayan volume'ları bir sürücünün sınırını aşacaksa sürücüyü,
+// bağlı volume sayısını, pod'un yeni volume sayısını ve sınırı döndürür; aşmıyorsa sürücü boştur
+func volumeLimitExceeded(request *podRequest, info *nodeInfo) (string, int, int, int) {
+	if len(request.volumes) == 0 || len(info.attachLimits) == 0 {
+		return "", 0, 0, 0
+	}
+
+	added := make(map[string]map[string]bool)
+	for _, volume := range request.volumes {
+		unique := volume.driver + "^" + volume.handle
+		if info.attached[volume.driver][unique] {
+			continue
+		}
+		if added[volume.driver] == nil {
+			added[volume.driver] = make(map[string]bool)
+		}
+		added[volume.driver][unique] = true
+	}
+
+	drivers := make([]string, 0, len(added))
+	for driver := range added {
+		drivers = append(drivers, driver)
+	}
+	sort.Strings(drivers)
+	for _, driver := range drivers {
+		limit, ok := info.attachLimits[driver]
+		if !ok {
+			continue
+		}
+		if attached := len(info.attached[driver]); attached+len(added[driver]) > limit {
+			return driver, attached, len(added[driver]), limit
+		}
+	}
+	return "", 0, 0, 0
+}
+
+// volumeLimitMessage sınırı aşılan sürücünün açıklaması
+func volumeLimitMessage(driver string, attached, added, limit int) string {
+	return fmt.Sprintf("%s sürücüsünün volume bağlama sınırı dolu: bağlı %d, pod'un yeni volume'u %d, sınır %d",
+		driver, attached, added, limit)
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"math"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Isınma cezasının varsayılanları
+const (
+	defaultWarmUpPeriod  = 30 * time.Minute
+	defaultWarmUpMinPods = 5
+)
+
+// warmUpSettings varsayılanları uygulanmış ısınma cezası konfigürasyonunu döndürür
+func (as *AIScheduler) warmUpSettings() types.WarmUpConfig {
+	cfg := as.currentConfig().WarmUp
+	if cfg.Period <= 0 {
+		cfg.Period = defaultWarmUpPeriod
+	}
+	if cfg.MinPods <= 0 {
+		cfg.MinPods = defaultWarmUpMinPods
+	}
+	return cfg
+}
+
+// nodeWarmth node'un at anındaki ısınma oranını (0-1) döndürür: yaşın Period'a ve gözlenen pod sayısının
+// MinPods'a oranlarından büyük olanı. Oluşturulma zamanı bilinmeyen node ısınmış kabul edilir
+func nodeWarmth(node *corev1.Node, observedPods int, at time.Time, cfg *types.WarmUpConfig) float64 {
+	created := node.CreationTimestamp.Time
+	if created.IsZero() {
+		return 1
+	}
+	age := float64(at.Sub(created)) / float64(cfg.Period)
+	data := float64(observedPods) / float64(cfg.MinPods)
+	return math.Max(0, math.Min(1, math.Max(age, data)))
+}
+
+// warmUpPenaltyAt node at anında ısınma süresindeyse cezayı ve ısınma oranını döndürür
+func (as *AIScheduler) warmUpPenaltyAt(node *corev1.Node, analysis *types.NodeAnalysis, at time.Time) (float64, float64) {
+	cfg := as.warmUpSettings()
+	if !cfg.Enabled || cfg.Weight <= 0 {
+		return 0, 1
+	}
+
+	warmth := nodeWarmth(node, analysis.TotalPods, at, &cfg)
+	return cfg.Weight * (1 - warmth), warmth
+}
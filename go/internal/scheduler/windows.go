@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"math"
+
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxWindowsMemoryHeadroom Windows bellek payının üst sınırı
+const maxWindowsMemoryHeadroom = 0.5
+
+// defaultOSTaintKeys işletim sistemi ayırma için yaygın kullanılan taint anahtarları
+var defaultOSTaintKeys = []string{"os", "node.kubernetes.io/os", types.LabelOS}
+
+// windowsSettings varsayılanları uygulanmış Windows skorlama konfigürasyonunu döndürür
+func (as *AIScheduler) windowsSettings() types.WindowsScoringConfig {
+	cfg := as.currentConfig().Windows
+	if len(cfg.OSTaintKeys) == 0 {
+		cfg.OSTaintKeys = defaultOSTaintKeys
+	}
+	cfg.MemoryHeadroom = math.Max(0, math.Min(cfg.MemoryHeadroom, maxWindowsMemoryHeadroom))
+	return cfg
+}
+
+// countedTaints taint bileşeninde sayılan taint sayısını döndürür. Değeri node'un işletim sistemi olan
+// (veya boş) işletim sistemi ayırma taint'leri sayılmaz, pod filtreden geçtiyse bunları zaten tolere eder
+func countedTaints(node *corev1.Node, osTaintKeys []string) int {
+	os := types.NodePlatform(node).OS
+	counted := 0
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if os != "" && (taint.Value == os || taint.Value == "") && containsString(osTaintKeys, taint.Key) {
+			continue
+		}
+		counted++
+	}
+	return counted
+}
+
+// usableMemory memory skorunda kullanılan kapasiteyi döndürür, Windows'ta headroom payı düşülür
+func usableMemory(node *corev1.Node, capacity, headroom float64) float64 {
+	if headroom > 0 && types.IsWindowsNode(node) {
+		return capacity * (1 - headroom)
+	}
+	return capacity
+}
+
+// containsString değer listede varsa true döner
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
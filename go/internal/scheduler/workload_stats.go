@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrWorkloadNotFound karar geçmişinde iş yüküne ait karar yok
+var ErrWorkloadNotFound = errors.New("iş yükü için karar geçmişi yok")
+
+// WorkloadNodeCount iş yükünün bir node'a yerleştirilme sayısı
+type WorkloadNodeCount struct {
+	NodeName string `json:"node_name"`
+	Count    int    `json:"count"`
+}
+
+// WorkloadStats iş yükünün karar geçmişindeki yerleşim sonuçları
+type WorkloadStats struct {
+	Namespace     string              `json:"namespace"`
+	Name          string              `json:"name"`
+	Kinds         []string            `json:"kinds"`
+	Decisions     int                 `json:"decisions"`
+	Scheduled     int                 `json:"scheduled"` // Gözlem modunda seçilen node'lar dahil
+	Unschedulable int                 `json:"unschedulable"`
+	SuccessRate   float64             `json:"success_rate"`
+	AverageScore  float64             `json:"average_score"` // Seçilen node'ların ortalama skoru
+	Relocations   int                 `json:"relocations"`   // Daha önce başka node'a yerleştirilmiş pod'un yeniden yerleştirilmesi
+	Nodes         []WorkloadNodeCount `json:"nodes"`
+	FirstDecision time.Time           `json:"first_decision"`
+	LastDecision  time.Time           `json:"last_decision"`
+}
+
+// WorkloadStats namespace'teki iş yükünün (Deployment, StatefulSet vb.) karar geçmişindeki yerleşim istatistiklerini döndürür.
+// kind boşsa aynı adlı tüm iş yükü türleri birlikte sayılır. Sadece geçmişte tutulan kararlar kapsanır
+func (as *AIScheduler) WorkloadStats(namespace, name, kind string) (*WorkloadStats, error) {
+	decisions := as.decisions.recent(0)
+
+	stats := &WorkloadStats{Namespace: namespace, Name: name, Kinds: []string{}, Nodes: []WorkloadNodeCount{}}
+	kinds := make(map[string]bool)
+	nodes := make(map[string]int)
+	lastNode := make(map[string]string)
+	var scoreSum float64
+
+	// Geçmiş en yeniden eskiye döner, yeniden yerleşimler için eskiden yeniye gezilir
+	for i := len(decisions) - 1; i >= 0; i-- {
+		decision := &decisions[i]
+		workload := decision.Workload
+		if workload.Namespace != namespace || workload.Name != name || (kind != "" && workload.Kind != kind) {
+			continue
+		}
+
+		if stats.Decisions == 0 {
+			stats.FirstDecision = decision.Time
+		}
+		stats.LastDecision = decision.Time
+		stats.Decisions++
+		kinds[workload.Kind] = true
+
+		switch decision.Outcome {
+		case OutcomeScheduled, OutcomeObserveOnly:
+			stats.Scheduled++
+			scoreSum += decision.Score
+			nodes[decision.Node]++
+			if previous, ok := lastNode[decision.Pod]; ok && previous != decision.Node {
+				stats.Relocations++
+			}
+			lastNode[decision.Pod] = decision.Node
+		case OutcomeUnschedulable:
+			stats.Unschedulable++
+		}
+	}
+	if stats.Decisions == 0 {
+		return nil, ErrWorkloadNotFound
+	}
+
+	stats.SuccessRate = float64(stats.Scheduled) / float64(stats.Decisions)
+	if stats.Scheduled > 0 {
+		stats.AverageScore = scoreSum / float64(stats.Scheduled)
+	}
+	for workloadKind := range kinds {
+		stats.Kinds = append(stats.Kinds, workloadKind)
+	}
+	sort.Strings(stats.Kinds)
+	for nodeName, count := range nodes {
+		stats.Nodes = append(stats.Nodes, WorkloadNodeCount{NodeName: nodeName, Count: count})
+	}
+	sort.Slice(stats.Nodes, func(i, j int) bool {
+		if stats.Nodes[i].Count != stats.Nodes[j].Count {
+			return stats.Nodes[i].Count > stats.Nodes[j].Count
+		}
+		return stats.Nodes[i].NodeName < stats.Nodes[j].NodeName
+	})
+	return stats, nil
+}
@@ -0,0 +1,722 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ProfileLabel sentetik node'un profilini taşıyan label
+const ProfileLabel = "ai-scheduler.io/synthetic-profile"
+
+// podRequestShare node'a yerleşen pod isteklerinin ortalamada node kapasitesine oranı
+const podRequestShare = 0.7
+
+// defaultStartupSeconds profilde başlatma süresi yoksa kullanılan ortalama
+const defaultStartupSeconds = 3.0
+
+// Noisy iş yüklerinin patlama davranışı
+const (
+	noisyBurstRate     = 0.25 // Adım başına patlama olasılığı
+	noisyBurstFactor   = 3.0  // Patlayan pod'un kullanım çarpanı
+	noisyStarvedFactor = 0.5  // Patlama sırasında komşu pod'ların kullanım çarpanı
+)
+
+// podTemplateHash sentetik ReplicaSet'lerin pod-template-hash değeri
+const podTemplateHash = "5d9c7b8f6"
+
+// defaultProfiles konfigürasyonda profil yoksa kullanılan profiller
+var defaultProfiles = []types.NodeProfileConfig{
+	{Name: "general", Weight: 1, CPU: 4, MemoryGB: 16, Utilization: 0.4, FailureRate: 0.01, RestartRate: 0.2, StartupSeconds: 4},
+}
+
+// nodeState sentetik node'un simülasyon durumu
+type nodeState struct {
+	profile  types.NodeProfileConfig
+	ready    bool
+	cpuUsage float64
+	memUsage float64
+}
+
+// Cluster Kubernetes olmadan pipeline'ı çalıştırmak için sentetik küme
+type Cluster struct {
+	mutex      sync.RWMutex
+	config     types.SyntheticClusterConfig
+	rng        *rand.Rand
+	nodes      []corev1.Node
+	state      map[string]*nodeState
+	pods       map[string]*corev1.Pod // namespace/name -> pod
+	bursting   map[string]bool        // Bu adımda kullanımı patlayan noisy pod'lar
+	nextPodID  int
+	namespaces []string
+
+	// Okuyuculara verilen değiştirilemez görünüm, her adım sonunda yeniden üretilir
+	nodeSnapshot []*corev1.Node
+	podSnapshot  []*corev1.Pod
+	nodeIndex    map[string]*corev1.Node
+	podIndex     map[string]*corev1.Pod
+	generation   uint64
+
+	// Her adım sonunda pod olaylarının bildirildiği fonksiyonlar
+	handlers   []func(pod *corev1.Pod, deleted bool)
+	handlersMu sync.RWMutex
+}
+
+// NewCluster konfigürasyona göre yeni sentetik küme üretir
+func NewCluster(clusterConfig *types.SyntheticClusterConfig) *Cluster {
+	cfg := *clusterConfig
+	if cfg.Nodes <= 0 {
+		cfg.Nodes = 10
+	}
+	if cfg.PodsPerNode <= 0 {
+		cfg.PodsPerNode = 8
+	}
+	if len(cfg.Profiles) == 0 {
+		cfg.Profiles = defaultProfiles
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	namespaces := cfg.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{"default"}
+	}
+
+	c := &Cluster{
+		config:     cfg,
+		rng:        rand.New(rand.NewSource(seed)),
+		state:      make(map[string]*nodeState),
+		pods:       make(map[string]*corev1.Pod),
+		bursting:   make(map[string]bool),
+		namespaces: namespaces,
+	}
+
+	for i := 0; i < cfg.Nodes; i++ {
+		profile := c.pickProfile()
+		node := newNode(fmt.Sprintf("synthetic-%s-%d", profile.Name, i), profile)
+		c.nodes = append(c.nodes, node)
+		c.state[node.Name] = &nodeState{profile: profile, ready: true}
+	}
+
+	for _, node := range c.nodes {
+		for j := 0; j < cfg.PodsPerNode; j++ {
+			c.addPod(node.Name, time.Duration(c.rng.Intn(48*60))*time.Minute)
+		}
+	}
+	for i := 0; i < cfg.PendingPods; i++ {
+		c.addPod("", 0)
+	}
+	c.updateUsage()
+	c.snapshot()
+
+	logrus.Infof("Sentetik küme oluşturuldu: %d node, %d pod (seed=%d)", len(c.nodes), len(c.pods), seed)
+	return c
+}
+
+// Start simülasyonu step_interval aralıklarla ilerletir
+func (c *Cluster) Start(ctx context.Context) {
+	interval := c.config.StepInterval
+	if interval == 0 {
+		interval = 10 * time.Second // Default değer
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Step()
+		}
+	}
+}
+
+// Step simülasyonu bir adım ilerletir: churn, restart, hata enjeksiyonu ve kullanım değişimi.
+// Adım sonunda pod'lar (silinenler dahil) kayıtlı olay fonksiyonlarına bildirilir
+func (c *Cluster) Step() {
+	previous, current := c.step()
+	c.notifyPods(previous, current)
+}
+
+// step adımı kilit altında uygular, önceki ve yeni pod görünümünü döndürür
+func (c *Cluster) step() (map[string]*corev1.Pod, []*corev1.Pod) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	previous := c.podIndex
+
+	failures := c.config.Failures
+
+	// Node hazır durumu (hata enjeksiyonu ve iyileşme)
+	for i := range c.nodes {
+		node := &c.nodes[i]
+		state := c.state[node.Name]
+		if state.ready && c.rng.Float64() < failures.NodeNotReadyRate {
+			state.ready = false
+			logrus.Debugf("Sentetik node %s NotReady oldu", node.Name)
+		} else if !state.ready && c.rng.Float64() < failures.NodeRecoveryRate {
+			state.ready = true
+		}
+		setReady(node, state.ready)
+	}
+
+	// Aynı seed ile aynı sonucu üretmek için pod'lar sıralı dolaşılır
+	c.bursting = make(map[string]bool)
+	for _, key := range c.podKeys() {
+		pod := c.pods[key]
+
+		// Bekleyen pod'lar rastgele hazır bir node'a yerleşir, scheduler'a atanmış pod'lar binding'i bekler
+		if pod.Spec.NodeName == "" {
+			if c.config.SchedulerName != "" && pod.Spec.SchedulerName == c.config.SchedulerName {
+				continue
+			}
+			if node := c.randomReadyNode(); node != "" {
+				pod.Spec.NodeName = node
+				pod.Status.Phase = corev1.PodRunning
+				c.startPod(pod, time.Now())
+			}
+			continue
+		}
+
+		state := c.state[pod.Spec.NodeName]
+
+		// Churn: pod silinir, yerine bekleyen yeni pod oluşturulur
+		if c.rng.Float64() < c.config.ChurnRate {
+			delete(c.pods, key)
+			c.addPod("", 0)
+			continue
+		}
+
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		// Hata enjeksiyonu: genel oran + profile özgü oran, NotReady node'da daha yüksek
+		failureRate := failures.PodFailureRate + state.profile.FailureRate/100
+		if !state.ready {
+			failureRate *= 5
+		}
+		if c.rng.Float64() < failureRate {
+			pod.Status.Phase = corev1.PodFailed
+			continue
+		}
+
+		// Restart: profildeki restart_rate çalışan pod'un adım başına restart olasılığıdır (yüzde)
+		if c.rng.Float64() < state.profile.RestartRate/100 {
+			pod.Status.ContainerStatuses[0].RestartCount++
+		}
+
+		// Noisy iş yükleri ara ara patlar
+		if c.noisy(pod) && c.rng.Float64() < noisyBurstRate {
+			c.bursting[key] = true
+		}
+	}
+
+	c.updateUsage()
+	c.snapshot()
+	return previous, c.podSnapshot
+}
+
+// AddPodHandler adım sonlarında pod olaylarının bildirileceği fonksiyonu ekler.
+// Sentetik kümede her adımda tüm pod'lar güncelleme olarak, adımda silinenler silme olarak bildirilir
+func (c *Cluster) AddPodHandler(handler func(pod *corev1.Pod, deleted bool)) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+
+	c.handlers = append(c.handlers, handler)
+}
+
+// notifyPods adımın pod olaylarını kilit dışında bildirir, fonksiyonlar kümeyi okuyabilir
+func (c *Cluster) notifyPods(previous map[string]*corev1.Pod, current []*corev1.Pod) {
+	c.handlersMu.RLock()
+	handlers := c.handlers
+	c.handlersMu.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+
+	remaining := make(map[string]bool, len(current))
+	for _, pod := range current {
+		remaining[pod.Namespace+"/"+pod.Name] = true
+		for _, handler := range handlers {
+			handler(pod, false)
+		}
+	}
+	for key, pod := range previous {
+		if !remaining[key] {
+			for _, handler := range handlers {
+				handler(pod, true)
+			}
+		}
+	}
+}
+
+// snapshot okuyucular için node ve pod kopyalarından değiştirilemez görünüm üretir.
+// Okumalar adımlar arasında aynı nesneleri paylaşır, tahmin başına kopyalama yapılmaz.
+func (c *Cluster) snapshot() {
+	c.generation++
+	c.nodeSnapshot = make([]*corev1.Node, len(c.nodes))
+	c.nodeIndex = make(map[string]*corev1.Node, len(c.nodes))
+	for i := range c.nodes {
+		node := c.nodes[i].DeepCopy()
+		c.nodeSnapshot[i] = node
+		c.nodeIndex[node.Name] = node
+	}
+
+	keys := c.podKeys()
+	c.podSnapshot = make([]*corev1.Pod, len(keys))
+	c.podIndex = make(map[string]*corev1.Pod, len(keys))
+	for i, key := range keys {
+		pod := c.pods[key].DeepCopy()
+		c.podSnapshot[i] = pod
+		c.podIndex[key] = pod
+	}
+}
+
+// AddPods dışarıdan verilen pod'ları kümeye ekler (ör: karar günlüğünden üretilen gerçek yük),
+// aynı isimli pod'un yerine geçer. Bekleyen pod'lar sonraki adımlarda rastgele node'lara yerleşir
+func (c *Cluster) AddPods(pods []*corev1.Pod) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, pod := range pods {
+		pod = pod.DeepCopy()
+		if len(pod.Status.ContainerStatuses) == 0 {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app"}}
+		}
+		if pod.Spec.NodeName != "" {
+			c.startPod(pod, pod.CreationTimestamp.Time)
+		}
+		c.pods[pod.Namespace+"/"+pod.Name] = pod
+	}
+	c.snapshot()
+}
+
+// BindPod bekleyen pod'u node'a bağlar ve başlatır (scheduler.binding için Binding API'nin karşılığı)
+func (c *Cluster) BindPod(namespace, name, nodeName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	pod, ok := c.pods[namespace+"/"+name]
+	if !ok {
+		return fmt.Errorf("pod %s/%s bulunamadı", namespace, name)
+	}
+	if pod.Spec.NodeName != "" {
+		return fmt.Errorf("pod %s/%s zaten %s node'una bağlı", namespace, name, pod.Spec.NodeName)
+	}
+	if _, ok := c.state[nodeName]; !ok {
+		return fmt.Errorf("node %s bulunamadı", nodeName)
+	}
+
+	pod.Spec.NodeName = nodeName
+	pod.Status.Phase = corev1.PodRunning
+	c.startPod(pod, time.Now())
+	c.snapshot()
+	return nil
+}
+
+// SetNodeTaint node'a taint ekler (present true) veya aynı anahtar ve etkili taint'i kaldırır
+func (c *Cluster) SetNodeTaint(nodeName string, taint corev1.Taint, present bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i := range c.nodes {
+		node := &c.nodes[i]
+		if node.Name != nodeName {
+			continue
+		}
+		node.Spec.Taints = types.SetTaint(node.Spec.Taints, taint, present)
+		c.snapshot()
+		return nil
+	}
+	return fmt.Errorf("node %s bulunamadı", nodeName)
+}
+
+// Nodes kümedeki node'ları döndürür
+func (c *Cluster) Nodes() []*corev1.Node {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.nodeSnapshot
+}
+
+// Node verilen node'u döndürür
+func (c *Cluster) Node(name string) (*corev1.Node, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	node, ok := c.nodeIndex[name]
+	return node, ok
+}
+
+// Pods kümedeki pod'ları isme göre sıralı döndürür
+func (c *Cluster) Pods() []*corev1.Pod {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.podSnapshot
+}
+
+// PodDisruptionBudgets her sentetik iş yükü için en fazla bir pod'un aynı anda kesintiye uğramasına izin veren PDB'ler döndürür.
+// Status doldurulmaz, kesinti hakkı pod'lardan hesaplanır
+func (c *Cluster) PodDisruptionBudgets() []*policyv1.PodDisruptionBudget {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	var pdbs []*policyv1.PodDisruptionBudget
+	for _, pod := range c.podSnapshot {
+		app := pod.Labels["app"]
+		if app == "" || seen[pod.Namespace+"/"+app] {
+			continue
+		}
+		seen[pod.Namespace+"/"+app] = true
+
+		maxUnavailable := intstr.FromInt(1)
+		pdbs = append(pdbs, &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: pod.Namespace},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailable,
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+		})
+	}
+	return pdbs
+}
+
+// Pod verilen pod'u döndürür
+func (c *Cluster) Pod(namespace, name string) (*corev1.Pod, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	pod, ok := c.podIndex[namespace+"/"+name]
+	return pod, ok
+}
+
+// Generation her simülasyon adımında artan sayacı döndürür
+func (c *Cluster) Generation() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.generation
+}
+
+// NodeUsage node'un CPU (core) ve memory (GB) kullanımını döndürür
+func (c *Cluster) NodeUsage(nodeName string) (float64, float64, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	state, ok := c.state[nodeName]
+	if !ok {
+		return 0, 0, false
+	}
+	return state.cpuUsage, state.memUsage, true
+}
+
+// ListPodUsage çalışan pod'ların kullanımını döndürür. Her iş yükü isteklerinin sabit bir oranını kullanır
+// (rightsizing için bazıları fazla istekli), oran node kullanımındaki dalgalanmayla ölçeklenir
+func (c *Cluster) ListPodUsage() (map[string][]types.ContainerUsage, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Patlayan noisy pod'ların node'larındaki diğer pod'lar kaynak açlığı yaşar
+	starved := make(map[string]bool)
+	for key := range c.bursting {
+		if pod, ok := c.pods[key]; ok {
+			starved[pod.Spec.NodeName] = true
+		}
+	}
+
+	result := make(map[string][]types.ContainerUsage, len(c.pods))
+	for key, pod := range c.pods {
+		state, ok := c.state[pod.Spec.NodeName]
+		if !ok || !state.ready || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		factor := workloadUsageFactor(pod.Namespace + "/" + pod.Labels["app"])
+		switch {
+		case c.bursting[key]:
+			factor *= noisyBurstFactor
+		case starved[pod.Spec.NodeName]:
+			factor *= noisyStarvedFactor
+		}
+		cpuScale, memScale := 1.0, 1.0
+		if state.profile.Utilization > 0 {
+			cpuScale = state.cpuUsage / (state.profile.CPU * state.profile.Utilization)
+			memScale = state.memUsage / (state.profile.MemoryGB * state.profile.Utilization)
+		}
+
+		usage := make([]types.ContainerUsage, len(pod.Spec.Containers))
+		for i := range pod.Spec.Containers {
+			container := &pod.Spec.Containers[i]
+			cpu := container.Resources.Requests[corev1.ResourceCPU]
+			memory := container.Resources.Requests[corev1.ResourceMemory]
+			usage[i] = types.ContainerUsage{
+				Container: container.Name,
+				CPU:       float64(cpu.MilliValue()) / 1000.0 * factor * cpuScale,
+				Memory:    float64(memory.Value()) / (1024 * 1024 * 1024) * factor * memScale,
+			}
+		}
+		result[key] = usage
+	}
+	return result, nil
+}
+
+// noisy pod'un iş yükü noisy_workloads listesinde mi
+func (c *Cluster) noisy(pod *corev1.Pod) bool {
+	for _, app := range c.config.NoisyWorkloads {
+		if pod.Labels["app"] == app {
+			return true
+		}
+	}
+	return false
+}
+
+// workloadUsageFactor iş yükü için 0.2-1.1 arasında deterministik kullanım/istek oranı döndürür
+func workloadUsageFactor(workload string) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(workload))
+	return 0.2 + float64(h.Sum32()%90)/100.0
+}
+
+// pickProfile ağırlıklara göre rastgele profil seçer
+func (c *Cluster) pickProfile() types.NodeProfileConfig {
+	total := 0.0
+	for _, profile := range c.config.Profiles {
+		total += profile.Weight
+	}
+	if total <= 0 {
+		return c.config.Profiles[0]
+	}
+
+	pick := c.rng.Float64() * total
+	for _, profile := range c.config.Profiles {
+		pick -= profile.Weight
+		if pick < 0 {
+			return profile
+		}
+	}
+	return c.config.Profiles[len(c.config.Profiles)-1]
+}
+
+// podKeys pod anahtarlarını sıralı döndürür
+func (c *Cluster) podKeys() []string {
+	keys := make([]string, 0, len(c.pods))
+	for key := range c.pods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// randomReadyNode rastgele hazır bir node adı döndürür
+func (c *Cluster) randomReadyNode() string {
+	var ready []string
+	for _, node := range c.nodes {
+		if c.state[node.Name].ready {
+			ready = append(ready, node.Name)
+		}
+	}
+	if len(ready) == 0 {
+		return ""
+	}
+	return ready[c.rng.Intn(len(ready))]
+}
+
+// addPod yeni sentetik pod ekler, nodeName boşsa pod bekleyen durumdadır
+func (c *Cluster) addPod(nodeName string, age time.Duration) {
+	c.nextPodID++
+	namespace := c.namespaces[c.rng.Intn(len(c.namespaces))]
+	name := fmt.Sprintf("synthetic-pod-%d", c.nextPodID)
+
+	phase := corev1.PodRunning
+	if nodeName == "" {
+		phase = corev1.PodPending
+	}
+
+	// İstekler node kapasitesine göre ölçeklenir: pods_per_node pod ortalamada kapasitenin podRequestShare kadarını ister
+	profile := c.config.Profiles[0]
+	if state, ok := c.state[nodeName]; ok {
+		profile = state.profile
+	}
+	cpuBudget := int(profile.CPU*1000*podRequestShare/float64(c.config.PodsPerNode)) + 1
+	memBudget := int(profile.MemoryGB*1024*podRequestShare/float64(c.config.PodsPerNode)) + 1
+	cpuMilli := int64(cpuBudget/2 + c.rng.Intn(cpuBudget))
+	memMi := int64(memBudget/2 + c.rng.Intn(memBudget))
+
+	app := fmt.Sprintf("synthetic-app-%d", c.rng.Intn(10))
+	controller := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			Labels:            map[string]string{"app": app, "pod-template-hash": podTemplateHash},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       app + "-" + podTemplateHash,
+				Controller: &controller,
+			}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      nodeName,
+			SchedulerName: c.config.SchedulerName,
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "synthetic:latest",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    *resource.NewMilliQuantity(cpuMilli, resource.DecimalSI),
+						corev1.ResourceMemory: *resource.NewQuantity(memMi*1024*1024, resource.BinarySI),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app"}},
+		},
+	}
+
+	if nodeName != "" {
+		c.startPod(pod, pod.CreationTimestamp.Time)
+	}
+	c.pods[namespace+"/"+name] = pod
+}
+
+// startPod node'a bağlanan pod'un başlatma aşamalarını profilin başlatma süresine göre koşul ve container durumlarına yazar
+func (c *Cluster) startPod(pod *corev1.Pod, boundAt time.Time) {
+	startup := defaultStartupSeconds
+	if state, ok := c.state[pod.Spec.NodeName]; ok && state.profile.StartupSeconds > 0 {
+		startup = state.profile.StartupSeconds
+	}
+	phase := func(share float64) time.Duration {
+		return time.Duration(startup * share * (0.5 + c.rng.Float64()) * float64(time.Second))
+	}
+
+	initialized := boundAt.Add(phase(0.1))
+	network := initialized.Add(phase(0.3))
+	started := network.Add(phase(0.6))
+	condition := func(conditionType corev1.PodConditionType, at time.Time) corev1.PodCondition {
+		return corev1.PodCondition{Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(at)}
+	}
+
+	pod.Status.Conditions = []corev1.PodCondition{
+		condition(corev1.PodScheduled, boundAt),
+		condition(corev1.PodInitialized, initialized),
+		condition(types.PodReadyToStartContainers, network),
+		condition(corev1.ContainersReady, started),
+		condition(corev1.PodReady, started),
+	}
+	pod.Status.ContainerStatuses[0].State.Running = &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}
+}
+
+// updateUsage node kullanımlarını profil ortalaması etrafında dalgalandırır
+func (c *Cluster) updateUsage() {
+	for _, node := range c.nodes {
+		state := c.state[node.Name]
+		jitter := (c.rng.Float64() - 0.5) * 0.2
+
+		utilization := clamp(state.profile.Utilization+jitter, 0, 1)
+		state.cpuUsage = state.profile.CPU * utilization
+		state.memUsage = state.profile.MemoryGB * clamp(utilization+(c.rng.Float64()-0.5)*0.1, 0, 1)
+	}
+}
+
+// syntheticWindowsBuild sentetik Windows node'larının build numarası (Windows Server 2022)
+const syntheticWindowsBuild = "10.0.20348"
+
+// newNode profile göre sentetik node nesnesi oluşturur
+func newNode(name string, profile types.NodeProfileConfig) corev1.Node {
+	os := profile.OS
+	if os == "" {
+		os = "linux"
+	}
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-7 * 24 * time.Hour)),
+			Labels: map[string]string{
+				ProfileLabel:             profile.Name,
+				"kubernetes.io/hostname": name,
+				"kubernetes.io/os":       os,
+				"kubernetes.io/arch":     "amd64",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(profile.CPU*1000), resource.DecimalSI),
+				corev1.ResourceMemory: *resource.NewQuantity(int64(profile.MemoryGB*1024*1024*1024), resource.BinarySI),
+				corev1.ResourcePods:   *resource.NewQuantity(110, resource.DecimalSI),
+			},
+		},
+	}
+	node.Status.Capacity = node.Status.Allocatable.DeepCopy()
+
+	if profile.Tainted {
+		node.Spec.Taints = []corev1.Taint{{Key: ProfileLabel, Value: profile.Name, Effect: corev1.TaintEffectNoSchedule}}
+	}
+	if os == types.OSWindows {
+		node.Labels[types.LabelWindowsBuild] = syntheticWindowsBuild
+		node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: "os", Value: os, Effect: corev1.TaintEffectNoSchedule})
+	}
+	node.Status.NodeInfo.OperatingSystem = os
+	node.Status.NodeInfo.Architecture = "amd64"
+
+	setReady(&node, true)
+	return node
+}
+
+// setReady node'un Ready condition'ını ayarlar
+func setReady(node *corev1.Node, ready bool) {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == corev1.NodeReady {
+			if node.Status.Conditions[i].Status != status {
+				node.Status.Conditions[i].Status = status
+				node.Status.Conditions[i].LastTransitionTime = metav1.Now()
+			}
+			return
+		}
+	}
+	node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+		Type:               corev1.NodeReady,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// clamp değeri [min, max] aralığına sınırlar
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
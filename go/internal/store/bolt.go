@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltKeySize anahtar uzunluğu: 8 byte zaman + 8 byte eklenme sırası
+const boltKeySize = 16
+
+// Bolt akışları tek bbolt dosyasında, akış başına bir bucket'ta tutar. Anahtarlar big-endian zaman ve bucket'ın
+// eklenme sırasıdır, böylece cursor kayıtları zamana ve eşit zamanlılarda eklenme sırasına göre dolaşır. Her işlem
+// tek transaction'dır; çökme yarım yazılmış kayıt bırakmaz
+type Bolt struct {
+	db *bolt.DB
+}
+
+// boltValue bucket'ta anahtar dışında saklanan kayıt alanları
+type boltValue struct {
+	Key  string          `json:"key,omitempty"`
+	Data json.RawMessage `json:"data"`
+}
+
+// openBolt dosyayı açar, başka süreç dosyayı kilitlediyse timeout sonunda hata verir
+func openBolt(cfg *types.BoltStoreConfig) (*Bolt, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0755); err != nil {
+		return nil, fmt.Errorf("geçmiş deposu dizini oluşturulamadı: %v", err)
+	}
+	db, err := bolt.Open(cfg.Path, 0600, &bolt.Options{Timeout: cfg.Timeout})
+	if err != nil {
+		return nil, fmt.Errorf("bolt dosyası açılamadı: %v", err)
+	}
+	return &Bolt{db: db}, nil
+}
+
+// boltTime zamanı sıralanabilir 8 byte'a çevirir (işaret biti çevrilir, 1970 öncesi zamanlar da sıralı kalır)
+func boltTime(at time.Time) []byte {
+	key := make([]byte, 8, boltKeySize)
+	binary.BigEndian.PutUint64(key, uint64(at.UnixNano())^(1<<63))
+	return key
+}
+
+// boltKeyTime anahtardaki zamanı döndürür
+func boltKeyTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])^(1<<63)))
+}
+
+// put kayıtları bucket'a ekler
+func (b *Bolt) put(bucket *bolt.Bucket, records []Record) error {
+	for _, record := range records {
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return fmt.Errorf("kayıt sırası alınamadı: %v", err)
+		}
+		value, err := json.Marshal(boltValue{Key: record.Key, Data: record.Data})
+		if err != nil {
+			return fmt.Errorf("kayıt kodlanamadı: %v", err)
+		}
+		key := binary.BigEndian.AppendUint64(boltTime(record.Time), seq)
+		if err := bucket.Put(key, value); err != nil {
+			return fmt.Errorf("kayıt eklenemedi: %v", err)
+		}
+	}
+	return nil
+}
+
+// Put kayıtları tek transaction'da ekler
+func (b *Bolt) Put(ctx context.Context, stream string, records ...Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(stream))
+		if err != nil {
+			return fmt.Errorf("akış bucket'ı oluşturulamadı: %v", err)
+		}
+		return b.put(bucket, records)
+	})
+}
+
+// Query aralıktaki kayıtları zaman ve eklenme sırasına göre döndürür
+func (b *Bolt) Query(ctx context.Context, stream string, from, to time.Time) ([]Record, error) {
+	if err := validStream(stream); err != nil {
+		return nil, err
+	}
+	var records []Record
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(stream))
+		if bucket == nil {
+			return nil
+		}
+		cursor := bucket.Cursor()
+		key, value := cursor.First()
+		if !from.IsZero() {
+			key, value = cursor.Seek(boltTime(from))
+		}
+		for ; key != nil; key, value = cursor.Next() {
+			at := boltKeyTime(key)
+			if !to.IsZero() && at.After(to) {
+				break
+			}
+			var stored boltValue
+			if err := json.Unmarshal(value, &stored); err != nil {
+				return fmt.Errorf("kayıt okunamadı: %v", err)
+			}
+			records = append(records, Record{Time: at, Key: stored.Key, Data: stored.Data})
+		}
+		return nil
+	})
+	return records, err
+}
+
+// Prune before'dan eski kayıtları siler
+func (b *Bolt) Prune(ctx context.Context, stream string, before time.Time) (int, error) {
+	if err := validStream(stream); err != nil {
+		return 0, err
+	}
+	deleted := 0
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(stream))
+		if bucket == nil {
+			return nil
+		}
+		// Cursor üzerinde silmek sonraki anahtarı atlatabildiğinden anahtarlar önce toplanır
+		var keys [][]byte
+		cursor := bucket.Cursor()
+		for key, _ := cursor.First(); key != nil && boltKeyTime(key).Before(before); key, _ = cursor.Next() {
+			keys = append(keys, append([]byte(nil), key...))
+		}
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return fmt.Errorf("kayıt silinemedi: %v", err)
+			}
+		}
+		deleted = len(keys)
+		return nil
+	})
+	return deleted, err
+}
+
+// Snapshot akışın bucket'ını tek transaction'da silip verilen kayıtlarla yeniden oluşturur
+func (b *Bolt) Snapshot(ctx context.Context, stream string, records []Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	snapshot := append([]Record(nil), records...)
+	sortRecords(snapshot)
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket([]byte(stream)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return fmt.Errorf("akış silinemedi: %v", err)
+		}
+		bucket, err := tx.CreateBucket([]byte(stream))
+		if err != nil {
+			return fmt.Errorf("akış bucket'ı oluşturulamadı: %v", err)
+		}
+		return b.put(bucket, snapshot)
+	})
+}
+
+// Close dosyayı kapatır ve kilidi bırakır
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fileBufferSize akış dosyaları okunurken kullanılan tampon (uzun kayıtlar için)
+const fileBufferSize = 1024 * 1024
+
+// File her akışı dizinde "<akış>.jsonl" dosyasında satır başına bir kayıt olarak tutar. Put dosyaya ekler;
+// Prune ve Snapshot dosyayı geçici dosyaya yazıp yeniden adlandırır, böylece yazma sırasında çökme akışı bozmaz
+type File struct {
+	mutex sync.Mutex
+	dir   string
+	files map[string]*os.File // Ekleme için açık akış dosyaları
+}
+
+// openFile dizini oluşturup dosya deposunu açar
+func openFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("geçmiş deposu dizini oluşturulamadı: %v", err)
+	}
+	return &File{dir: dir, files: make(map[string]*os.File)}, nil
+}
+
+// path akış dosyasının yolu
+func (f *File) path(stream string) string {
+	return filepath.Join(f.dir, stream+".jsonl")
+}
+
+// Put kayıtları akış dosyasına ekler
+func (f *File) Put(ctx context.Context, stream string, records ...Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for i := range records {
+		if err := encoder.Encode(&records[i]); err != nil {
+			return fmt.Errorf("kayıt kodlanamadı: %v", err)
+		}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	file, ok := f.files[stream]
+	if !ok {
+		var err error
+		file, err = os.OpenFile(f.path(stream), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("akış dosyası açılamadı: %v", err)
+		}
+		// Çökmede yarım kalan son satır yeni kayıtla birleşmesin diye satır sonlandırılır
+		if err := terminateLine(file); err != nil {
+			file.Close()
+			return err
+		}
+		f.files[stream] = file
+	}
+	if _, err := file.Write(buffer.Bytes()); err != nil {
+		return fmt.Errorf("akış dosyasına yazılamadı: %v", err)
+	}
+	return nil
+}
+
+// terminateLine dosya boş değilse ve yeni satırla bitmiyorsa sonuna yeni satır ekler
+func terminateLine(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return fmt.Errorf("akış dosyası okunamadı: %v", err)
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	if _, err := file.Write([]byte{'\n'}); err != nil {
+		return fmt.Errorf("akış dosyasına yazılamadı: %v", err)
+	}
+	return nil
+}
+
+// Query akış dosyasını okuyup aralıktaki kayıtları döndürür. Parse edilemeyen satırlar (ör: çökmede yarım kalan
+// son satır) uyarıyla atlanır
+func (f *File) Query(ctx context.Context, stream string, from, to time.Time) ([]Record, error) {
+	if err := validStream(stream); err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	records, err := f.readLocked(stream)
+	if err != nil {
+		return nil, err
+	}
+	result := records[:0]
+	for _, record := range records {
+		if inRange(record.Time, from, to) {
+			result = append(result, record)
+		}
+	}
+	sortRecords(result)
+	return result, nil
+}
+
+// readLocked akışın tüm kayıtlarını dosya sırasıyla okur, dosya yoksa boş döner
+func (f *File) readLocked(stream string) ([]Record, error) {
+	file, err := os.Open(f.path(stream))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("akış dosyası açılamadı: %v", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), fileBufferSize)
+	for line := 1; scanner.Scan(); line++ {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logrus.Warnf("%s akışının %d. satırı okunamadı, atlanıyor: %v", stream, line, err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("akış dosyası okunamadı: %v", err)
+	}
+	return records, nil
+}
+
+// Prune before'dan eski kayıtları silip dosyayı yeniden yazar
+func (f *File) Prune(ctx context.Context, stream string, before time.Time) (int, error) {
+	if err := validStream(stream); err != nil {
+		return 0, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	records, err := f.readLocked(stream)
+	if err != nil {
+		return 0, err
+	}
+	kept := make([]Record, 0, len(records))
+	for _, record := range records {
+		if !record.Time.Before(before) {
+			kept = append(kept, record)
+		}
+	}
+	if len(kept) == len(records) {
+		return 0, nil
+	}
+	if err := f.rewriteLocked(stream, kept); err != nil {
+		return 0, err
+	}
+	return len(records) - len(kept), nil
+}
+
+// Snapshot akış dosyasını verilen kayıtlarla değiştirir
+func (f *File) Snapshot(ctx context.Context, stream string, records []Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	snapshot := append([]Record(nil), records...)
+	sortRecords(snapshot)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.rewriteLocked(stream, snapshot)
+}
+
+// rewriteLocked kayıtları geçici dosyaya yazıp akış dosyasının yerine koyar, ekleme için açık dosya kapatılır
+func (f *File) rewriteLocked(stream string, records []Record) error {
+	temp, err := os.CreateTemp(f.dir, stream+".jsonl.tmp-*")
+	if err != nil {
+		return fmt.Errorf("geçici akış dosyası oluşturulamadı: %v", err)
+	}
+	defer os.Remove(temp.Name())
+
+	writer := bufio.NewWriter(temp)
+	encoder := json.NewEncoder(writer)
+	for i := range records {
+		if err := encoder.Encode(&records[i]); err != nil {
+			temp.Close()
+			return fmt.Errorf("kayıt kodlanamadı: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		temp.Close()
+		return fmt.Errorf("geçici akış dosyası yazılamadı: %v", err)
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return fmt.Errorf("geçici akış dosyası diske yazılamadı: %v", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("geçici akış dosyası kapatılamadı: %v", err)
+	}
+
+	if file, ok := f.files[stream]; ok {
+		file.Close()
+		delete(f.files, stream)
+	}
+	if err := os.Rename(temp.Name(), f.path(stream)); err != nil {
+		return fmt.Errorf("akış dosyası değiştirilemedi: %v", err)
+	}
+	return nil
+}
+
+// Close açık akış dosyalarını kapatır
+func (f *File) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var firstErr error
+	for stream, file := range f.files {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(f.files, stream)
+	}
+	return firstErr
+}
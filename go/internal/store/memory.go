@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory akışları bellekte tutar; süreç yeniden başlayınca kaybolur (tek replikalı denemeler ve geliştirme için)
+type Memory struct {
+	mutex   sync.RWMutex
+	streams map[string][]Record
+}
+
+// NewMemory boş bellek deposu oluşturur
+func NewMemory() *Memory {
+	return &Memory{streams: make(map[string][]Record)}
+}
+
+// Put kayıtları zaman sırasını koruyarak ekler
+func (m *Memory) Put(ctx context.Context, stream string, records ...Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing := m.streams[stream]
+	for _, record := range records {
+		// Eşit zamanlıların sonuna eklenir; kayıtlar çoğunlukla sırayla geldiğinden genelde sona eklenir
+		i := sort.Search(len(existing), func(i int) bool { return existing[i].Time.After(record.Time) })
+		existing = append(existing, Record{})
+		copy(existing[i+1:], existing[i:])
+		existing[i] = record
+	}
+	m.streams[stream] = existing
+	return nil
+}
+
+// Query aralıktaki kayıtların kopyasını döndürür
+func (m *Memory) Query(ctx context.Context, stream string, from, to time.Time) ([]Record, error) {
+	if err := validStream(stream); err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var result []Record
+	for _, record := range m.streams[stream] {
+		if inRange(record.Time, from, to) {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+// Prune before'dan eski kayıtları siler
+func (m *Memory) Prune(ctx context.Context, stream string, before time.Time) (int, error) {
+	if err := validStream(stream); err != nil {
+		return 0, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing := m.streams[stream]
+	i := sort.Search(len(existing), func(i int) bool { return !existing[i].Time.Before(before) })
+	m.streams[stream] = append([]Record(nil), existing[i:]...)
+	return i, nil
+}
+
+// Snapshot akışı verilen kayıtlarla değiştirir
+func (m *Memory) Snapshot(ctx context.Context, stream string, records []Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	snapshot := append([]Record(nil), records...)
+	sortRecords(snapshot)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.streams[stream] = snapshot
+	return nil
+}
+
+// Close bir şey yapmaz
+func (m *Memory) Close() error {
+	return nil
+}
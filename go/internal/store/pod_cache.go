@@ -0,0 +1,339 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Paylaşılan pod metrik cache'i varsayılanları
+const (
+	defaultPodCachePrefix        = "ai-scheduler:pod-cache:"
+	defaultPodCacheSyncInterval  = 5 * time.Second
+	defaultPodCacheFlushInterval = time.Second
+	defaultPodCacheDedupWindow   = 27 * time.Second // Varsayılan 30s toplama aralığının %90'ı
+	podCacheDedupRatio           = 0.9
+	podCacheSyncBatch            = 5000
+	podCachePruneBatch           = 1000
+	podCachePruneInterval        = 10 * time.Minute
+	podCacheSyncSlack            = 2 * time.Second // Flush gecikmesine eklenen Redis gecikmesi ve replika saat farkı payı
+)
+
+// podCacheAppendScript örnekleri tekilleştirip sıra numarasıyla ekler. KEYS[1] örnek set'i, KEYS[2] sıra sayacı;
+// ARGV[1] tekilleştirme süresi (ms), ardından (tekilleştirme anahtarı, üye) çiftleri. Tekilleştirme anahtarı
+// KEYS'te verilmediği için script tek Redis sunucusunda (cluster olmadan) çalışır
+const podCacheAppendScript = `
+local added = 0
+for i = 2, #ARGV, 2 do
+  if redis.call('SET', ARGV[i], '1', 'NX', 'PX', ARGV[1]) then
+    local seq = redis.call('INCR', KEYS[2])
+    redis.call('ZADD', KEYS[1], 'NX', seq, ARGV[i + 1])
+    added = added + 1
+  end
+end
+return added`
+
+// RedisPodCache replikalar arasında paylaşılan pod metrik cache'i. Örnekler Redis'te sıra numarasıyla skorlanan
+// tek sorted set'te tutulur; her replika sync aralığıyla son okuduğu sıradan sonrakileri yerel PodMetricsCache
+// kopyasına ekler ve sorguları bu kopyadan cevaplar, böylece skorlama yolu Redis'e gitmez. UpdateCache örneği
+// sadece Redis'e yazılmak üzere kuyruğa alır, kendi örnekleri de yerel kopyaya sync ile gelir. Farklı
+// replikaların örnekleri flush gecikmesi kadar zaman sırası dışında gelebildiği için son holdback() içindeki
+// örnekler bekletilir ve yerel kopyaya zaman sırasıyla eklenir
+type RedisPodCache struct {
+	*types.PodMetricsCache
+
+	client        *redisClient
+	prefix        string
+	dedupWindow   time.Duration
+	syncInterval  time.Duration
+	flushInterval time.Duration
+
+	pendingMu sync.Mutex
+	pending   []types.PodMetrics
+
+	// syncMu sync ve prune'u sıralar ve held'i korur, cursor son okunan sıra numarası. held geç gelen örneklerle
+	// sırası değişebilecek, henüz yerel kopyaya eklenmemiş örnekler; applied son eklenen örneğin zamanı
+	syncMu     sync.Mutex
+	cursor     int64
+	held       []types.PodMetrics
+	applied    time.Time
+	clock      types.Clock
+	prunedAt   time.Time
+	closeOnce  sync.Once
+	flushErrMu sync.Mutex
+	flushErr   bool // Son flush başarısızdı, tekrar eden uyarıları tek loglamak için
+}
+
+// NewRedisPodCache Redis'e bağlanır ve mevcut paylaşılan geçmişi yerel kopyaya yükler. collectionInterval
+// DedupWindow verilmezse varsayılanını belirler
+func NewRedisPodCache(cfg *types.SharedCacheConfig, collectionInterval time.Duration) (*RedisPodCache, error) {
+	redisConfig := cfg.Redis
+	if redisConfig.Prefix == "" {
+		redisConfig.Prefix = defaultPodCachePrefix
+	}
+	client := newRedisClient(&redisConfig)
+
+	dedupWindow := cfg.DedupWindow
+	if dedupWindow <= 0 {
+		dedupWindow = defaultPodCacheDedupWindow
+		if collectionInterval > 0 {
+			dedupWindow = time.Duration(float64(collectionInterval) * podCacheDedupRatio)
+		}
+	}
+	cache := &RedisPodCache{
+		PodMetricsCache: types.NewPodMetricsCache(),
+		client:          client,
+		prefix:          redisConfig.Prefix,
+		dedupWindow:     dedupWindow,
+		syncInterval:    cfg.SyncInterval,
+		flushInterval:   cfg.FlushInterval,
+		clock:           types.RealClock,
+	}
+	if cache.syncInterval <= 0 {
+		cache.syncInterval = defaultPodCacheSyncInterval
+	}
+	if cache.flushInterval <= 0 {
+		cache.flushInterval = defaultPodCacheFlushInterval
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.timeout)
+	defer cancel()
+	if _, err := client.do(ctx, "PING"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("Redis'e bağlanılamadı: %v", err)
+	}
+	loaded, err := cache.Sync(context.Background())
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("paylaşılan pod metrik cache'i yüklenemedi: %v", err)
+	}
+	logrus.Infof("Paylaşılan pod metrik cache'i Redis'ten yüklendi: %d örnek", loaded)
+	return cache, nil
+}
+
+// samplesKey örneklerin sorted set anahtarı
+func (c *RedisPodCache) samplesKey() string {
+	return c.prefix + "samples"
+}
+
+// seqKey sıra sayacının anahtarı
+func (c *RedisPodCache) seqKey() string {
+	return c.prefix + "seq"
+}
+
+// dedupKey aynı pod durumunun tekilleştirme anahtarı. Durum veya restart sayısı değişince anahtar değiştiği için
+// geçişler her zaman yazılır
+func (c *RedisPodCache) dedupKey(sample *types.PodMetrics) string {
+	return c.prefix + "seen:" + sample.Namespace + "/" + sample.PodName + ":" + sample.NodeName + ":" + sample.Status + ":" + strconv.Itoa(sample.RestartCount)
+}
+
+// UpdateCache örneği Redis'e yazılmak üzere kuyruğa alır, yerel kopyaya sync ile eklenir
+func (c *RedisPodCache) UpdateCache(podMetrics types.PodMetrics) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	c.pending = append(c.pending, podMetrics)
+}
+
+// Import arşiv örneklerini yüklemez: paylaşılan geçmiş Redis'tedir, örnekler sadece bu replikanın kopyasına eklenirse
+// replikaların cevapları ayrışır
+func (c *RedisPodCache) Import(samples []types.PodMetrics) int {
+	return 0
+}
+
+// Start flush ve sync döngülerini context bitene kadar çalıştırır
+func (c *RedisPodCache) Start(ctx context.Context) {
+	flushTicker := time.NewTicker(c.flushInterval)
+	defer flushTicker.Stop()
+	syncTicker := time.NewTicker(c.syncInterval)
+	defer syncTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-flushTicker.C:
+			c.Flush(ctx)
+		case <-syncTicker.C:
+			if _, err := c.Sync(ctx); err != nil {
+				logrus.Warnf("Paylaşılan pod metrik cache'i senkronize edilemedi: %v", err)
+			}
+			if err := c.prune(ctx); err != nil {
+				logrus.Warnf("Paylaşılan pod metrik cache'inden eski örnekler silinemedi: %v", err)
+			}
+		}
+	}
+}
+
+// Flush kuyruktaki örnekleri tekilleştirerek Redis'e yazar. Yazılamazsa örnekler kaybolmasın diye sadece yerel
+// kopyaya eklenmek üzere bekletilir
+func (c *RedisPodCache) Flush(ctx context.Context) {
+	c.pendingMu.Lock()
+	batch := c.pending
+	c.pending = nil
+	c.pendingMu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+
+	args := make([]string, 0, 5+2*len(batch))
+	args = append(args, "EVAL", podCacheAppendScript, "2", c.samplesKey(), c.seqKey(), strconv.FormatInt(c.dedupWindow.Milliseconds(), 10))
+	for i := range batch {
+		member, err := json.Marshal(&batch[i])
+		if err != nil {
+			logrus.Warnf("Pod metrik örneği kodlanamadı, atlanıyor: %v", err)
+			continue
+		}
+		args = append(args, c.dedupKey(&batch[i]), string(member))
+	}
+	_, err := c.client.do(ctx, args...)
+
+	c.flushErrMu.Lock()
+	defer c.flushErrMu.Unlock()
+	if err != nil {
+		if !c.flushErr {
+			logrus.Warnf("Pod metrik örnekleri Redis'e yazılamadı, sadece yerel cache'e ekleniyor: %v", err)
+		}
+		c.flushErr = true
+		c.syncMu.Lock()
+		c.held = append(c.held, batch...)
+		c.release()
+		c.syncMu.Unlock()
+		return
+	}
+	if c.flushErr {
+		logrus.Info("Pod metrik örnekleri tekrar Redis'e yazılıyor")
+	}
+	c.flushErr = false
+}
+
+// Sync son okunan sıradan sonra Redis'e yazılan örnekleri okur ve holdback()'ten eski olanları zaman sırasıyla
+// yerel kopyaya ekler, eklenen örnek sayısını döndürür
+func (c *RedisPodCache) Sync(ctx context.Context) (int, error) {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	err := c.fetch(ctx)
+	return c.release(), err
+}
+
+// fetch son okunan sıradan sonraki örnekleri held'e ekler
+func (c *RedisPodCache) fetch(ctx context.Context) error {
+	for {
+		reply, err := c.client.do(ctx, "ZRANGEBYSCORE", c.samplesKey(), "("+strconv.FormatInt(c.cursor, 10), "+inf",
+			"WITHSCORES", "LIMIT", "0", strconv.Itoa(podCacheSyncBatch))
+		if err != nil {
+			return err
+		}
+		values, _ := reply.([]interface{})
+		for i := 0; i+1 < len(values); i += 2 {
+			member, _ := values[i].([]byte)
+			score, _ := values[i+1].([]byte)
+			seq, err := strconv.ParseInt(string(score), 10, 64)
+			if err != nil {
+				return fmt.Errorf("geçersiz sıra numarası %q: %v", score, err)
+			}
+			if seq > c.cursor {
+				c.cursor = seq
+			}
+			var sample types.PodMetrics
+			if err := json.Unmarshal(member, &sample); err != nil {
+				logrus.Warnf("Paylaşılan pod metrik örneği okunamadı, atlanıyor: %v", err)
+				continue
+			}
+			c.held = append(c.held, sample)
+		}
+
+		if len(values)/2 < podCacheSyncBatch {
+			return nil
+		}
+	}
+}
+
+// holdback bir örneğin Redis'te görünmesi için beklenen en uzun süre: başka replikanın kuyruğundaki örnek en geç
+// flush aralığı ve gecikme payı sonra yazılır, bu süreden eski örneklerden önce gelecek örnek kalmaz
+func (c *RedisPodCache) holdback() time.Duration {
+	return c.flushInterval + podCacheSyncSlack
+}
+
+// release held'deki holdback()'ten eski örnekleri zaman sırasıyla yerel kopyaya ekler, eklenen örnek sayısını
+// döndürür. Yerel kopyanın pencereleri zaman sırası beklediğinden payı aşarak geç gelen örneğin zamanı son
+// eklenen örneğin zamanına çekilir. syncMu tutulurken çağrılır
+func (c *RedisPodCache) release() int {
+	sort.SliceStable(c.held, func(i, j int) bool { return c.held[i].Timestamp.Before(c.held[j].Timestamp) })
+	watermark := c.clock.Now().Add(-c.holdback())
+	ready := sort.Search(len(c.held), func(i int) bool { return c.held[i].Timestamp.After(watermark) })
+
+	for i := 0; i < ready; i++ {
+		sample := c.held[i]
+		if sample.Timestamp.Before(c.applied) {
+			logrus.Debugf("%s/%s örneği %s geç geldi, son eklenen örneğin zamanına çekiliyor", sample.Namespace, sample.PodName, c.applied.Sub(sample.Timestamp))
+			sample.Timestamp = c.applied
+		}
+		c.PodMetricsCache.UpdateCache(sample)
+		c.applied = sample.Timestamp
+	}
+	c.held = append(c.held[:0], c.held[ready:]...)
+	return ready
+}
+
+// prune en fazla podCachePruneInterval'da bir saklama süresini aşan örnekleri Redis'ten siler. Örnekler yaklaşık
+// zaman sırasıyla eklendiği için en eski sıralardan başlanır ve saklama süresi içindeki ilk örnekte durulur
+func (c *RedisPodCache) prune(ctx context.Context) error {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.prunedAt) < podCachePruneInterval {
+		return nil
+	}
+	c.prunedAt = now
+	cutoff := now.Add(-types.PodMetricsRetention)
+
+	for {
+		reply, err := c.client.do(ctx, "ZRANGE", c.samplesKey(), "0", strconv.Itoa(podCachePruneBatch-1))
+		if err != nil {
+			return err
+		}
+		values, _ := reply.([]interface{})
+		args := []string{"ZREM", c.samplesKey()}
+		for _, value := range values {
+			member, _ := value.([]byte)
+			var sample types.PodMetrics
+			if err := json.Unmarshal(member, &sample); err == nil && sample.Timestamp.After(cutoff) {
+				break
+			}
+			args = append(args, string(member))
+		}
+		if len(args) == 2 {
+			return nil
+		}
+		if _, err := c.client.do(ctx, args...); err != nil {
+			return err
+		}
+		logrus.Debugf("Paylaşılan pod metrik cache'inden %d eski örnek silindi", len(args)-2)
+		if len(args)-2 < len(values) || len(values) < podCachePruneBatch {
+			return nil
+		}
+	}
+}
+
+// Close kuyruktaki örnekleri yazar ve bağlantıyı kapatır
+func (c *RedisPodCache) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.client.timeout)
+		defer cancel()
+		c.Flush(ctx)
+		err = c.client.Close()
+	})
+	return err
+}
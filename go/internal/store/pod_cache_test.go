@@ -0,0 +1,212 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// fakeRedis RedisPodCache'in kullandığı komutların (PING, append script'i için EVAL, ZRANGEBYSCORE) süreç içi
+// uygulaması
+type fakeRedis struct {
+	mutex   sync.Mutex
+	seen    map[string]bool
+	seq     int64
+	members []fakeMember
+}
+
+// fakeMember sorted set üyesi
+type fakeMember struct {
+	score  int64
+	member string
+}
+
+// startFakeRedis fake sunucuyu başlatır ve adresini döndürür
+func startFakeRedis(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("fake Redis dinleyemedi: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{seen: make(map[string]bool)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// serve bağlantıdaki komutları yanıtlar
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader, writer := bufio.NewReader(conn), bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		writer.WriteString(f.execute(args))
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// readCommand RESP dizisi olarak gelen komutu okur
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	reply, err := readReply(reader)
+	if err != nil {
+		return nil, err
+	}
+	values, _ := reply.([]interface{})
+	args := make([]string, len(values))
+	for i, value := range values {
+		data, _ := value.([]byte)
+		args[i] = string(data)
+	}
+	return args, nil
+}
+
+// execute komutu çalıştırıp RESP yanıtını döndürür
+func (f *fakeRedis) execute(args []string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "EVAL":
+		// podCacheAppendScript: ARGV[1] tekilleştirme süresi, ardından (tekilleştirme anahtarı, üye) çiftleri
+		added := 0
+		for i := 6; i+1 < len(args); i += 2 {
+			if f.seen[args[i]] {
+				continue
+			}
+			f.seen[args[i]] = true
+			f.seq++
+			f.members = append(f.members, fakeMember{score: f.seq, member: args[i+1]})
+			added++
+		}
+		return ":" + strconv.Itoa(added) + "\r\n"
+	case "ZRANGEBYSCORE":
+		// ZRANGEBYSCORE key (min +inf WITHSCORES LIMIT 0 count
+		low, _ := strconv.ParseInt(strings.TrimPrefix(args[2], "("), 10, 64)
+		count, _ := strconv.Atoi(args[7])
+		sort.Slice(f.members, func(i, j int) bool { return f.members[i].score < f.members[j].score })
+		var reply []string
+		for _, m := range f.members {
+			if m.score > low && len(reply)/2 < count {
+				reply = append(reply, m.member, strconv.FormatInt(m.score, 10))
+			}
+		}
+		var builder strings.Builder
+		builder.WriteString("*" + strconv.Itoa(len(reply)) + "\r\n")
+		for _, value := range reply {
+			builder.WriteString("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n")
+		}
+		return builder.String()
+	default:
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	}
+}
+
+func TestRedisPodCacheSyncOrdersReplicas(t *testing.T) {
+	address := startFakeRedis(t)
+	ctx := context.Background()
+	base := time.Now().Truncate(time.Second)
+	clock := types.NewManualClock(base)
+
+	replica := func() *RedisPodCache {
+		cache, err := NewRedisPodCache(&types.SharedCacheConfig{
+			Redis:         types.RedisStoreConfig{Address: address, Timeout: time.Second},
+			FlushInterval: time.Second,
+		}, 30*time.Second)
+		if err != nil {
+			t.Fatalf("NewRedisPodCache: %v", err)
+		}
+		cache.clock = clock
+		t.Cleanup(func() { cache.Close() })
+		return cache
+	}
+	sample := func(pod string, at time.Duration) types.PodMetrics {
+		return types.PodMetrics{PodName: pod, Namespace: "default", NodeName: "node-1", Status: "Running", Timestamp: base.Add(at)}
+	}
+	syncAll := func(replicas ...*RedisPodCache) []int {
+		applied := make([]int, len(replicas))
+		for i, cache := range replicas {
+			n, err := cache.Sync(ctx)
+			if err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+			applied[i] = n
+		}
+		return applied
+	}
+	a, b := replica(), replica()
+
+	// a'nın örneği b'nin kuyrukta bekleyen daha eski örneğinden önce Redis'e yazılır
+	a.UpdateCache(sample("a-1", 2*time.Second))
+	a.Flush(ctx)
+	clock.Set(base.Add(2500 * time.Millisecond))
+	if applied := syncAll(a, b); applied[0] != 0 || applied[1] != 0 {
+		t.Fatalf("holdback içindeki örnek eklenmemeliydi, eklenen: %v", applied)
+	}
+	b.UpdateCache(sample("b-1", time.Second))
+	b.Flush(ctx)
+	a.UpdateCache(sample("a-2", 3*time.Second))
+	a.Flush(ctx)
+
+	clock.Set(base.Add(10 * time.Second))
+	if applied := syncAll(a, b); applied[0] != 3 || applied[1] != 3 {
+		t.Fatalf("eklenen örnekler %v, her replikada 3 bekleniyordu", applied)
+	}
+	for name, cache := range map[string]*RedisPodCache{"a": a, "b": b} {
+		assertPods(t, name, cache.GetNodeMetrics("node-1"), "b-1", "a-1", "a-2")
+	}
+
+	// Payı aşarak geç gelen örnek son eklenen örneğin zamanına çekilir, sıra bozulmaz
+	b.UpdateCache(sample("b-2", 500*time.Millisecond))
+	b.Flush(ctx)
+	syncAll(a, b)
+	for name, cache := range map[string]*RedisPodCache{"a": a, "b": b} {
+		samples := cache.GetNodeMetrics("node-1")
+		assertPods(t, name, samples, "b-1", "a-1", "a-2", "b-2")
+		if last := samples[len(samples)-1]; !last.Timestamp.Equal(base.Add(3 * time.Second)) {
+			t.Errorf("%s: geç örneğin zamanı %s, beklenen %s", name, last.Timestamp, base.Add(3*time.Second))
+		}
+		analysis := cache.GetNodeAnalysisAt("node-1", time.Hour, base.Add(10*time.Second))
+		if analysis.TotalPods != 4 {
+			t.Errorf("%s: analizdeki pod sayısı %d, beklenen 4", name, analysis.TotalPods)
+		}
+	}
+}
+
+// assertPods örneklerin verilen pod sırasında ve zamana göre sıralı olduğunu doğrular
+func assertPods(t *testing.T, replica string, samples []types.PodMetrics, pods ...string) {
+	t.Helper()
+	got := make([]string, len(samples))
+	for i := range samples {
+		got[i] = samples[i].PodName
+		if i > 0 && samples[i].Timestamp.Before(samples[i-1].Timestamp) {
+			t.Errorf("%s: örnekler zaman sırasında değil: %v", replica, got)
+		}
+	}
+	if strings.Join(got, ",") != strings.Join(pods, ",") {
+		t.Errorf("%s: örnek sırası %v, beklenen %v", replica, got, pods)
+	}
+}
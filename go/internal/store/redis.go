@@ -0,0 +1,372 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// defaultRedisAddress adres verilmezse bağlanılan Redis
+const defaultRedisAddress = "localhost:6379"
+
+// Redis her akışı skoru kayıt zamanı (unix mikrosaniye) olan bir sorted set'te tutar. Birden fazla replika aynı
+// Redis'i kullanarak geçmişi paylaşabilir. İstemci RESP2 protokolünün gereken alt kümesini uygular
+type Redis struct {
+	client *redisClient
+	prefix string
+	seq    atomic.Uint64 // Aynı içerikli kayıtların set'te tekilleşmemesi için üye kimliği
+}
+
+// redisMember sorted set üyesi: kayıt ve eşit zamanlılarda eklenme sırası
+type redisMember struct {
+	Seq uint64 `json:"seq"`
+	Record
+}
+
+// openRedis Redis'e bağlanıp bağlantıyı PING ile doğrular
+func openRedis(cfg *types.RedisStoreConfig) (*Redis, error) {
+	client := newRedisClient(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), client.timeout)
+	defer cancel()
+	if _, err := client.do(ctx, "PING"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("Redis'e bağlanılamadı: %v", err)
+	}
+
+	r := &Redis{client: client, prefix: cfg.Prefix}
+	r.seq.Store(uint64(time.Now().UnixNano()))
+	return r, nil
+}
+
+// key akışın sorted set anahtarı
+func (r *Redis) key(stream string) string {
+	return r.prefix + stream
+}
+
+// zaddArgs kayıtları ZADD argümanlarına çevirir
+func (r *Redis) zaddArgs(stream string, records []Record) ([]string, error) {
+	args := make([]string, 0, 2+2*len(records))
+	args = append(args, "ZADD", r.key(stream))
+	for _, record := range records {
+		member, err := json.Marshal(redisMember{Seq: r.seq.Add(1), Record: record})
+		if err != nil {
+			return nil, fmt.Errorf("kayıt kodlanamadı: %v", err)
+		}
+		args = append(args, strconv.FormatInt(record.Time.UnixMicro(), 10), string(member))
+	}
+	return args, nil
+}
+
+// Put kayıtları tek ZADD ile ekler
+func (r *Redis) Put(ctx context.Context, stream string, records ...Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	args, err := r.zaddArgs(stream, records)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.do(ctx, args...)
+	return err
+}
+
+// Query aralıktaki kayıtları ZRANGEBYSCORE ile okur
+func (r *Redis) Query(ctx context.Context, stream string, from, to time.Time) ([]Record, error) {
+	if err := validStream(stream); err != nil {
+		return nil, err
+	}
+	low, high := "-inf", "+inf"
+	if !from.IsZero() {
+		low = strconv.FormatInt(from.UnixMicro(), 10)
+	}
+	if !to.IsZero() {
+		high = strconv.FormatInt(to.UnixMicro()+1, 10)
+	}
+	reply, err := r.client.do(ctx, "ZRANGEBYSCORE", r.key(stream), low, high)
+	if err != nil {
+		return nil, err
+	}
+	values, _ := reply.([]interface{})
+
+	members := make([]redisMember, 0, len(values))
+	for _, value := range values {
+		data, _ := value.([]byte)
+		var member redisMember
+		if err := json.Unmarshal(data, &member); err != nil {
+			return nil, fmt.Errorf("kayıt okunamadı: %v", err)
+		}
+		// Skor mikrosaniye hassasiyetinde olduğu için sınırlar kayıt zamanıyla yeniden kontrol edilir
+		if inRange(member.Time, from, to) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if !members[i].Time.Equal(members[j].Time) {
+			return members[i].Time.Before(members[j].Time)
+		}
+		return members[i].Seq < members[j].Seq
+	})
+
+	records := make([]Record, len(members))
+	for i := range members {
+		records[i] = members[i].Record
+	}
+	return records, nil
+}
+
+// Prune before'dan eski kayıtları ZREMRANGEBYSCORE ile siler
+func (r *Redis) Prune(ctx context.Context, stream string, before time.Time) (int, error) {
+	if err := validStream(stream); err != nil {
+		return 0, err
+	}
+	reply, err := r.client.do(ctx, "ZREMRANGEBYSCORE", r.key(stream), "-inf", "("+strconv.FormatInt(before.UnixMicro(), 10))
+	if err != nil {
+		return 0, err
+	}
+	deleted, _ := reply.(int64)
+	return int(deleted), nil
+}
+
+// Snapshot akışı MULTI/EXEC içinde silip verilen kayıtlarla yeniden yazar
+func (r *Redis) Snapshot(ctx context.Context, stream string, records []Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	commands := [][]string{{"MULTI"}, {"DEL", r.key(stream)}}
+	if len(records) > 0 {
+		args, err := r.zaddArgs(stream, records)
+		if err != nil {
+			return err
+		}
+		commands = append(commands, args)
+	}
+	commands = append(commands, []string{"EXEC"})
+
+	replies, err := r.client.pipeline(ctx, commands)
+	if err != nil {
+		return err
+	}
+	if results, ok := replies[len(replies)-1].([]interface{}); ok {
+		for _, result := range results {
+			if err, ok := result.(redisError); ok {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("Redis transaction'ı uygulanmadı")
+}
+
+// Close bağlantıyı kapatır
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
+
+// redisError sunucunun döndürdüğü hata yanıtı (-ERR ...)
+type redisError string
+
+// Error hata mesajını döndürür
+func (e redisError) Error() string {
+	return "Redis hata döndürdü: " + string(e)
+}
+
+// redisClient tek bağlantılı RESP2 istemcisi. Komutlar sırayla gönderilir; ağ hatasında bağlantı kapatılır ve
+// sonraki komutta yeniden kurulur
+type redisClient struct {
+	mutex    sync.Mutex
+	address  string
+	password string
+	database int
+	timeout  time.Duration
+	conn     net.Conn
+	reader   *bufio.Reader
+	writer   *bufio.Writer
+}
+
+// newRedisClient istemciyi oluşturur, bağlantı ilk komutta kurulur
+func newRedisClient(cfg *types.RedisStoreConfig) *redisClient {
+	address := cfg.Address
+	if address == "" {
+		address = defaultRedisAddress
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &redisClient{address: address, password: cfg.Password, database: cfg.DB, timeout: timeout}
+}
+
+// do tek komut gönderip yanıtını döndürür, hata yanıtı error olarak döner
+func (c *redisClient) do(ctx context.Context, args ...string) (interface{}, error) {
+	replies, err := c.pipeline(ctx, [][]string{args})
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := replies[0].(redisError); ok {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// pipeline komutları tek seferde yazıp yanıtlarını sırayla okur. Hata yanıtları redisError değeri olarak döner
+func (c *redisClient) pipeline(ctx context.Context, commands [][]string) ([]interface{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.connectLocked(ctx); err != nil {
+		return nil, err
+	}
+	replies, err := c.roundTripLocked(ctx, commands)
+	if err != nil {
+		c.closeLocked()
+		return nil, err
+	}
+	return replies, nil
+}
+
+// connectLocked bağlantı yoksa kurar, parola ve veritabanı seçimini yapar
+func (c *redisClient) connectLocked(ctx context.Context) error {
+	if c.conn != nil {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return fmt.Errorf("Redis'e bağlanılamadı: %v", err)
+	}
+	c.conn, c.reader, c.writer = conn, bufio.NewReader(conn), bufio.NewWriter(conn)
+
+	var setup [][]string
+	if c.password != "" {
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.database != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.database)})
+	}
+	if len(setup) == 0 {
+		return nil
+	}
+	replies, err := c.roundTripLocked(ctx, setup)
+	if err == nil {
+		for _, reply := range replies {
+			if replyErr, ok := reply.(redisError); ok {
+				err = replyErr
+				break
+			}
+		}
+	}
+	if err != nil {
+		c.closeLocked()
+		return fmt.Errorf("Redis bağlantısı hazırlanamadı: %v", err)
+	}
+	return nil
+}
+
+// roundTripLocked komutları yazar ve her biri için bir yanıt okur
+func (c *redisClient) roundTripLocked(ctx context.Context, commands [][]string) ([]interface{}, error) {
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	for _, args := range commands {
+		c.writer.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+		for _, arg := range args {
+			c.writer.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n")
+			c.writer.WriteString(arg)
+			c.writer.WriteString("\r\n")
+		}
+	}
+	if err := c.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("Redis'e yazılamadı: %v", err)
+	}
+
+	replies := make([]interface{}, len(commands))
+	for i := range commands {
+		reply, err := readReply(c.reader)
+		if err != nil {
+			return nil, fmt.Errorf("Redis yanıtı okunamadı: %v", err)
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// readReply tek RESP2 yanıtını okur: basit string, hata (redisError), tam sayı (int64), bulk string ([]byte, yoksa
+// nil) veya dizi ([]interface{})
+func readReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("geçersiz RESP satırı: %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return redisError(payload), nil
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, count)
+		for i := range values {
+			if values[i], err = readReply(reader); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen RESP türü: %q", kind)
+	}
+}
+
+// closeLocked bağlantıyı kapatır
+func (c *redisClient) closeLocked() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn, c.reader, c.writer = nil, nil, nil
+	}
+}
+
+// Close bağlantıyı kapatır
+func (c *redisClient) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.closeLocked()
+	return nil
+}
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	_ "modernc.org/sqlite" // "sqlite" sürücüsü (saf Go, cgo gerektirmez)
+)
+
+// tablePattern SQL ifadelerine doğrudan yazılan tablo adı için izin verilen biçim
+var tablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
+// sqliteDriver binary'ye gömülü SQLite sürücüsünün adı
+const sqliteDriver = "sqlite"
+
+// SQL akışları database/sql üzerinden tek tabloda tutar. Binary'de sadece "sqlite" sürücüsü kayıtlıdır (DSN dosya
+// yoludur); diğer veritabanları için sürücü (ör: postgres, mysql) ayrıca eklenmelidir, kayıtlı olmayan sürücüyle
+// açılış hata verir
+type SQL struct {
+	db      *sql.DB
+	table   string
+	dollar  bool // Yer tutucular $1, $2 (PostgreSQL) veya ?
+	timeout time.Duration
+	seq     atomic.Int64 // Eşit zamanlı kayıtların eklenme sırası
+}
+
+// openSQL veritabanına bağlanır ve tabloyu yoksa oluşturur
+func openSQL(cfg *types.SQLStoreConfig) (*SQL, error) {
+	if cfg.Driver == "" || cfg.DSN == "" {
+		return nil, fmt.Errorf("sql driver ve dsn tanımlı değil")
+	}
+	if !tablePattern.MatchString(cfg.Table) {
+		return nil, fmt.Errorf("geçersiz sql tablo adı: %q", cfg.Table)
+	}
+	if cfg.Driver == sqliteDriver && !strings.HasPrefix(cfg.DSN, "file:") && cfg.DSN != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(cfg.DSN), 0755); err != nil {
+			return nil, fmt.Errorf("geçmiş deposu dizini oluşturulamadı: %v", err)
+		}
+	}
+	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("veritabanı açılamadı: %v", err)
+	}
+
+	if cfg.Driver == sqliteDriver {
+		// SQLite aynı anda tek yazıcıya izin verir, bağlantı havuzundaki eşzamanlı transaction'lar SQLITE_BUSY alır
+		db.SetMaxOpenConns(1)
+	}
+
+	s := &SQL{db: db, table: cfg.Table, dollar: strings.Contains(cfg.Driver, "postgres") || cfg.Driver == "pgx", timeout: cfg.Timeout}
+	s.seq.Store(time.Now().UnixNano())
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("veritabanına bağlanılamadı: %v", err)
+	}
+	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	stream VARCHAR(64) NOT NULL,
+	ts BIGINT NOT NULL,
+	seq BIGINT NOT NULL,
+	record_key VARCHAR(512) NOT NULL,
+	data TEXT NOT NULL
+)`, s.table)
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("geçmiş tablosu oluşturulamadı: %v", err)
+	}
+	// Her veritabanı CREATE INDEX IF NOT EXISTS desteklemediğinden indeks zaten varsa hata yok sayılır
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE INDEX %s_stream_ts ON %s (stream, ts)", s.table, s.table)); err != nil {
+		logrus.Debugf("Geçmiş tablosu indeksi oluşturulmadı (zaten var olabilir): %v", err)
+	}
+	return s, nil
+}
+
+// query yer tutucuları sürücünün biçimine çevirir ("?" -> "$n")
+func (s *SQL) query(statement string) string {
+	statement = strings.ReplaceAll(statement, "{table}", s.table)
+	if !s.dollar {
+		return statement
+	}
+	var builder strings.Builder
+	n := 0
+	for _, r := range statement {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// execer tek kayıt eklemek için ExecContext sağlayan *sql.DB veya *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insert kayıtları ekler
+func (s *SQL) insert(ctx context.Context, db execer, stream string, records []Record) error {
+	statement := s.query("INSERT INTO {table} (stream, ts, seq, record_key, data) VALUES (?, ?, ?, ?, ?)")
+	for _, record := range records {
+		if _, err := db.ExecContext(ctx, statement, stream, record.Time.UnixNano(), s.seq.Add(1), record.Key, string(record.Data)); err != nil {
+			return fmt.Errorf("kayıt eklenemedi: %v", err)
+		}
+	}
+	return nil
+}
+
+// Put kayıtları tek transaction'da ekler
+func (s *SQL) Put(ctx context.Context, stream string, records ...Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("transaction başlatılamadı: %v", err)
+	}
+	if err := s.insert(ctx, tx, stream, records); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Query aralıktaki kayıtları zaman ve eklenme sırasına göre döndürür
+func (s *SQL) Query(ctx context.Context, stream string, from, to time.Time) ([]Record, error) {
+	if err := validStream(stream); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	statement := "SELECT ts, record_key, data FROM {table} WHERE stream = ?"
+	args := []interface{}{stream}
+	if !from.IsZero() {
+		statement += " AND ts >= ?"
+		args = append(args, from.UnixNano())
+	}
+	if !to.IsZero() {
+		statement += " AND ts <= ?"
+		args = append(args, to.UnixNano())
+	}
+	rows, err := s.db.QueryContext(ctx, s.query(statement+" ORDER BY ts, seq"), args...)
+	if err != nil {
+		return nil, fmt.Errorf("kayıtlar sorgulanamadı: %v", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var (
+			ts   int64
+			key  string
+			data string
+		)
+		if err := rows.Scan(&ts, &key, &data); err != nil {
+			return nil, fmt.Errorf("kayıt okunamadı: %v", err)
+		}
+		records = append(records, Record{Time: time.Unix(0, ts), Key: key, Data: []byte(data)})
+	}
+	return records, rows.Err()
+}
+
+// Prune before'dan eski kayıtları siler
+func (s *SQL) Prune(ctx context.Context, stream string, before time.Time) (int, error) {
+	if err := validStream(stream); err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, s.query("DELETE FROM {table} WHERE stream = ? AND ts < ?"), stream, before.UnixNano())
+	if err != nil {
+		return 0, fmt.Errorf("kayıtlar silinemedi: %v", err)
+	}
+	deleted, _ := result.RowsAffected()
+	return int(deleted), nil
+}
+
+// Snapshot akışı tek transaction'da silip verilen kayıtlarla yeniden yazar
+func (s *SQL) Snapshot(ctx context.Context, stream string, records []Record) error {
+	if err := validStream(stream); err != nil {
+		return err
+	}
+	snapshot := append([]Record(nil), records...)
+	sortRecords(snapshot)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("transaction başlatılamadı: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, s.query("DELETE FROM {table} WHERE stream = ?"), stream); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("akış silinemedi: %v", err)
+	}
+	if err := s.insert(ctx, tx, stream, snapshot); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close veritabanı bağlantılarını kapatır
+func (s *SQL) Close() error {
+	return s.db.Close()
+}
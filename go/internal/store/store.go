@@ -0,0 +1,140 @@
+// Package store pod metrik cache'i, karar günlüğü gibi zaman sıralı geçmişlerin ortak saklama soyutlamasıdır.
+// Her geçmiş adlı bir akışta (stream) tutulur; backend konfigürasyonla seçilir (memory, file, bolt, sql, redis)
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// Desteklenen backend'ler
+const (
+	BackendMemory = "memory"
+	BackendFile   = "file"
+	BackendBolt   = "bolt"
+	BackendSQL    = "sql"
+	BackendRedis  = "redis"
+)
+
+// Ortak akış adları
+const (
+	StreamPodMetrics = "pod_metrics"
+	StreamJournal    = "journal"
+	StreamDecisions  = "decisions"
+)
+
+// Backend varsayılanları
+const (
+	defaultFileDir     = "./data"
+	defaultBoltPath    = "./data/history.db"
+	defaultSQLTable    = "ai_scheduler_history"
+	defaultRedisPrefix = "ai-scheduler:history:"
+	defaultTimeout     = 5 * time.Second
+)
+
+// ErrInvalidStream akış adı geçersiz (sadece küçük harf, rakam, '_' ve '-')
+var ErrInvalidStream = errors.New("geçersiz akış adı")
+
+// streamPattern dosya adı ve anahtar olarak güvenle kullanılabilen akış adları
+var streamPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// Record akıştaki zaman damgalı kayıt. Data çağıranın JSON'a çevirdiği değerdir
+type Record struct {
+	Time time.Time       `json:"time"`
+	Key  string          `json:"key,omitempty"`
+	Data json.RawMessage `json:"data"`
+}
+
+// HistoryStore zaman sıralı kayıt akışlarını saklar. Query kayıtları zamana, eşit zamanlılarda eklenme sırasına
+// göre döndürür; sıfır from/to sınırsız demektir. Prune before'dan eski kayıtları, Snapshot akışın tüm içeriğini
+// verilen kayıtlarla atomik olarak değiştirir
+type HistoryStore interface {
+	Put(ctx context.Context, stream string, records ...Record) error
+	Query(ctx context.Context, stream string, from, to time.Time) ([]Record, error)
+	Prune(ctx context.Context, stream string, before time.Time) (int, error)
+	Snapshot(ctx context.Context, stream string, records []Record) error
+	Close() error
+}
+
+// Open konfigürasyondaki backend'i açar
+func Open(storeConfig *types.HistoryStoreConfig) (HistoryStore, error) {
+	cfg := settings(storeConfig)
+	switch cfg.Backend {
+	case BackendMemory:
+		return NewMemory(), nil
+	case BackendFile:
+		return openFile(cfg.File.Dir)
+	case BackendBolt:
+		return openBolt(&cfg.Bolt)
+	case BackendSQL:
+		return openSQL(&cfg.SQL)
+	case BackendRedis:
+		return openRedis(&cfg.Redis)
+	default:
+		return nil, fmt.Errorf("bilinmeyen geçmiş deposu backend'i: %s", cfg.Backend)
+	}
+}
+
+// settings varsayılanları uygulanmış depo ayarları
+func settings(storeConfig *types.HistoryStoreConfig) types.HistoryStoreConfig {
+	cfg := *storeConfig
+	if cfg.Backend == "" {
+		cfg.Backend = BackendFile
+	}
+	if cfg.File.Dir == "" {
+		cfg.File.Dir = defaultFileDir
+	}
+	if cfg.Bolt.Path == "" {
+		cfg.Bolt.Path = defaultBoltPath
+	}
+	if cfg.Bolt.Timeout <= 0 {
+		cfg.Bolt.Timeout = defaultTimeout
+	}
+	if cfg.SQL.Table == "" {
+		cfg.SQL.Table = defaultSQLTable
+	}
+	if cfg.SQL.Timeout <= 0 {
+		cfg.SQL.Timeout = defaultTimeout
+	}
+	if cfg.Redis.Prefix == "" {
+		cfg.Redis.Prefix = defaultRedisPrefix
+	}
+	if cfg.Redis.Timeout <= 0 {
+		cfg.Redis.Timeout = defaultTimeout
+	}
+	return cfg
+}
+
+// NewRecord değeri JSON'a çevirerek kayıt oluşturur
+func NewRecord(at time.Time, key string, value interface{}) (Record, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Record{}, fmt.Errorf("kayıt JSON'a çevrilemedi: %v", err)
+	}
+	return Record{Time: at, Key: key, Data: data}, nil
+}
+
+// validStream akış adını doğrular
+func validStream(stream string) error {
+	if !streamPattern.MatchString(stream) {
+		return fmt.Errorf("%q: %w", stream, ErrInvalidStream)
+	}
+	return nil
+}
+
+// inRange kaydın [from, to] aralığında olup olmadığını döndürür, sıfır sınırlar açıktır
+func inRange(at, from, to time.Time) bool {
+	return (from.IsZero() || !at.Before(from)) && (to.IsZero() || !at.After(to))
+}
+
+// sortRecords kayıtları zamana göre, eşit zamanlılarda mevcut sırayı koruyarak sıralar
+func sortRecords(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+}
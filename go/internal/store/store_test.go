@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-scheduler/internal/types"
+)
+
+// storeBackends her backend için t.TempDir altında kalıcı depo konfigürasyonu
+func storeBackends(t *testing.T) map[string]*types.HistoryStoreConfig {
+	dir := t.TempDir()
+	return map[string]*types.HistoryStoreConfig{
+		BackendFile: {Backend: BackendFile, File: types.FileStoreConfig{Dir: filepath.Join(dir, "file")}},
+		BackendBolt: {Backend: BackendBolt, Bolt: types.BoltStoreConfig{Path: filepath.Join(dir, "bolt", "history.db")}},
+		BackendSQL:  {Backend: BackendSQL, SQL: types.SQLStoreConfig{Driver: sqliteDriver, DSN: filepath.Join(dir, "sql", "history.sqlite")}},
+	}
+}
+
+// openStore depoyu açar
+func openStore(t *testing.T, cfg *types.HistoryStoreConfig) HistoryStore {
+	t.Helper()
+	history, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Open(%s): %v", cfg.Backend, err)
+	}
+	return history
+}
+
+// assertRecords kayıtların anahtarlarını ve sırasını doğrular
+func assertRecords(t *testing.T, records []Record, keys ...string) {
+	t.Helper()
+	got := make([]string, len(records))
+	for i, record := range records {
+		got[i] = record.Key
+	}
+	if strings.Join(got, ",") != strings.Join(keys, ",") {
+		t.Fatalf("kayıtlar %v, beklenen %v", got, keys)
+	}
+}
+
+func TestHistoryStoreBackends(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	record := func(key string, at time.Duration) Record {
+		r, err := NewRecord(base.Add(at), key, map[string]string{"key": key})
+		if err != nil {
+			t.Fatalf("NewRecord: %v", err)
+		}
+		return r
+	}
+
+	for backend, cfg := range storeBackends(t) {
+		t.Run(backend, func(t *testing.T) {
+			history := openStore(t, cfg)
+
+			// Eşit zamanlı kayıtlar eklenme sırasını korur, Put'lar arasında da zaman sırası geçerlidir
+			if err := history.Put(ctx, StreamJournal, record("c", 2*time.Second), record("a", 0), record("b", 0)); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if err := history.Put(ctx, StreamJournal, record("d", time.Second)); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if err := history.Put(ctx, StreamPodMetrics, record("other", 0)); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			all, err := history.Query(ctx, StreamJournal, time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			assertRecords(t, all, "a", "b", "d", "c")
+			if !all[3].Time.Equal(base.Add(2*time.Second)) || string(all[3].Data) != `{"key":"c"}` {
+				t.Errorf("kayıt = %s %s", all[3].Time, all[3].Data)
+			}
+
+			ranged, err := history.Query(ctx, StreamJournal, base.Add(time.Second), base.Add(2*time.Second))
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			assertRecords(t, ranged, "d", "c")
+
+			deleted, err := history.Prune(ctx, StreamJournal, base.Add(time.Second))
+			if err != nil || deleted != 2 {
+				t.Fatalf("Prune = %d, %v; beklenen 2", deleted, err)
+			}
+
+			if err := history.Snapshot(ctx, StreamPodMetrics, []Record{record("y", time.Second), record("x", 0)}); err != nil {
+				t.Fatalf("Snapshot: %v", err)
+			}
+			if err := history.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			// Yeniden açılışta kalan kayıtlar okunur
+			history = openStore(t, cfg)
+			defer history.Close()
+			journal, err := history.Query(ctx, StreamJournal, time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			assertRecords(t, journal, "d", "c")
+			metrics, err := history.Query(ctx, StreamPodMetrics, time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			assertRecords(t, metrics, "x", "y")
+
+			if _, err := history.Query(ctx, "Geçersiz", time.Time{}, time.Time{}); err == nil {
+				t.Error("geçersiz akış adı için hata bekleniyordu")
+			}
+		})
+	}
+}
+
+func TestOpenSQLUnknownDriver(t *testing.T) {
+	_, err := Open(&types.HistoryStoreConfig{Backend: BackendSQL, SQL: types.SQLStoreConfig{Driver: "postgres", DSN: "postgres://localhost/history"}})
+	if err == nil {
+		t.Fatal("kayıtlı olmayan sürücü için hata bekleniyordu")
+	}
+}
@@ -0,0 +1,279 @@
+package telemetry
+
+import (
+	"net/http"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace tüm scheduler metriklerinin ön eki
+const namespace = "ai_scheduler"
+
+// Registry scheduler metriklerinin kaydedildiği Prometheus registry'si
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler Prometheus scrape endpoint'i için handler döndürür
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// RegisterPodCache PodMetrics cache'inin bellek kullanımı, bütçesi ve kırpma sayacını kaydeder
+func RegisterPodCache(cache types.PodCache) {
+	Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pod_cache_memory_bytes",
+			Help:      "PodMetrics cache'inin tahmini bellek kullanımı",
+		}, func() float64 {
+			used, _ := cache.MemoryUsage()
+			return float64(used)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pod_cache_memory_budget_bytes",
+			Help:      "PodMetrics cache'inin bellek bütçesi (0 = sınırsız)",
+		}, func() float64 {
+			_, budget := cache.MemoryUsage()
+			return float64(budget)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pod_cache_trimmed_samples_total",
+			Help:      "Bellek bütçesi nedeniyle atılan PodMetrics örnekleri",
+		}, func() float64 {
+			return float64(cache.TrimmedSamples())
+		}),
+	)
+}
+
+// RegisterDegradation kademeli bozulma seviyesini, seviye geçişi ve AI bütçesi aşımı sayaçlarını kaydeder. Seviye başına bir gauge
+// serisi vardır, etkin seviyeninki 1 diğerleri 0'dır
+func RegisterDegradation(aiScheduler *scheduler.AIScheduler) {
+	for level, tier := range scheduler.Tiers {
+		level := level
+		Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "degradation_tier",
+			Help:        "Etkin kademeli bozulma seviyesi (1 = etkin)",
+			ConstLabels: prometheus.Labels{"tier": tier},
+		}, func() float64 {
+			if aiScheduler.Degradation().Level == level {
+				return 1
+			}
+			return 0
+		}))
+	}
+	Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "degradation_level",
+			Help:      "Kademeli bozulma seviyesinin numarası (0 = tam AI, 3 = sıralı seçim)",
+		}, func() float64 {
+			return float64(aiScheduler.Degradation().Level)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "degradation_transitions_total",
+			Help:      "Kademeli bozulma seviyesi geçişleri",
+		}, func() float64 {
+			return float64(aiScheduler.Degradation().Transitions)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "degradation_ai_consecutive_failures",
+			Help:      "AI analizinin üst üste hata sayısı",
+		}, func() float64 {
+			return float64(aiScheduler.Degradation().AIFailures)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ai_budget_exceeded_total",
+			Help:      "AI analizi bütçesinde dönmediği için heuristik skorla verilen kararlar",
+		}, func() float64 {
+			return float64(aiScheduler.AIBudgetExceeded())
+		}),
+	)
+}
+
+// CapacityHintCounter kapasite olaylarını node havuzu ve zone bazında sayan Prometheus alıcısı
+type CapacityHintCounter struct {
+	events *prometheus.CounterVec
+	cpu    *prometheus.CounterVec
+	memory *prometheus.CounterVec
+}
+
+// RegisterCapacityHints kapasite olayı sayaçlarını kaydeder ve scheduler'a eklenecek alıcıyı döndürür
+func RegisterCapacityHints() *CapacityHintCounter {
+	labels := []string{"node_pool", "zone"}
+	counter := &CapacityHintCounter{
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "capacity_needed_total",
+			Help:      "Uygun node bulunamadığı için üretilen kapasite olayları",
+		}, labels),
+		cpu: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "capacity_needed_cpu_cores_total",
+			Help:      "Kapasite olaylarında istenen toplam CPU (core)",
+		}, labels),
+		memory: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "capacity_needed_memory_gb_total",
+			Help:      "Kapasite olaylarında istenen toplam memory (GB)",
+		}, labels),
+	}
+	Registry.MustRegister(counter.events, counter.cpu, counter.memory)
+	return counter
+}
+
+// CapacityNeeded olayı sayaçlara ekler
+func (c *CapacityHintCounter) CapacityNeeded(hint *types.CapacityHint) {
+	c.events.WithLabelValues(hint.NodePool, hint.Zone).Inc()
+	c.cpu.WithLabelValues(hint.NodePool, hint.Zone).Add(hint.CPU)
+	c.memory.WithLabelValues(hint.NodePool, hint.Zone).Add(hint.Memory)
+}
+
+// RegisterTemplateFilterCache pod şablonu filtre cache'inin isabet ve ıska sayaçlarını kaydeder
+func RegisterTemplateFilterCache(aiScheduler *scheduler.AIScheduler) {
+	Registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "template_filter_cache_hits_total",
+			Help:      "Filtre sonucu şablon cache'inden alınan pod'lar",
+		}, func() float64 {
+			hits, _ := aiScheduler.TemplateFilterStats()
+			return float64(hits)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "template_filter_cache_misses_total",
+			Help:      "Şablon cache'inde bulunmayıp filtresi baştan hesaplanan pod'lar",
+		}, func() float64 {
+			_, misses := aiScheduler.TemplateFilterStats()
+			return float64(misses)
+		}),
+	)
+}
+
+// RegisterBinding bağlanan pod ve başarısız binding isteği sayaçlarını kaydeder
+func RegisterBinding(aiScheduler *scheduler.AIScheduler) {
+	Registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pods_bound_total",
+			Help:      "Scheduler'ın tahmin ettiği node'a bağladığı pod'lar",
+		}, func() float64 {
+			bound, _ := aiScheduler.BindingStats()
+			return float64(bound)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "binding_failures_total",
+			Help:      "Başarısız binding istekleri",
+		}, func() float64 {
+			_, failures := aiScheduler.BindingStats()
+			return float64(failures)
+		}),
+	)
+}
+
+// RegisterAudit karar denetiminde AI'nın heuristik seçimle uyuşma oranını ve eleştiri sayaçlarını kaydeder
+func RegisterAudit(aiScheduler *scheduler.AIScheduler) {
+	Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "audit_agreement_rate",
+			Help:      "Son denetim eleştirilerinde AI'nın heuristik kararla aynı node'u önerme oranı",
+		}, func() float64 {
+			rate, _, _ := aiScheduler.AuditAgreement()
+			return rate
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audit_critiques_total",
+			Help:      "AI'nın eleştirdiği heuristik kararlar",
+		}, func() float64 {
+			_, critiqued, _ := aiScheduler.AuditAgreement()
+			return float64(critiqued)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audit_agreements_total",
+			Help:      "AI'nın heuristik kararla aynı node'u önerdiği eleştiriler",
+		}, func() float64 {
+			_, _, agreed := aiScheduler.AuditAgreement()
+			return float64(agreed)
+		}),
+	)
+}
+
+// RegisterRemediation açık taint önerilerini ve kararsız node'lara eklenen/kaldırılan taint'leri Prometheus'a kaydeder
+func RegisterRemediation(aiScheduler *scheduler.AIScheduler) {
+	Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "remediation_taint_proposals",
+			Help:      "Kararlılığı eşiğin altında kalan node'lar için açık taint önerileri",
+		}, func() float64 {
+			proposals, _, _ := aiScheduler.RemediationStats()
+			return float64(proposals)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "remediation_taints_applied_total",
+			Help:      "Kararsız node'lara eklenen PreferNoSchedule taint'leri",
+		}, func() float64 {
+			_, applied, _ := aiScheduler.RemediationStats()
+			return float64(applied)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "remediation_taints_removed_total",
+			Help:      "Toparlanan node'lardan kaldırılan taint'ler",
+		}, func() float64 {
+			_, _, removed := aiScheduler.RemediationStats()
+			return float64(removed)
+		}),
+	)
+}
+
+// ErrorCounter modül hatalarını bileşen ve hata sınıfı bazında sayan Prometheus alıcısı
+type ErrorCounter struct {
+	errors *prometheus.CounterVec
+}
+
+// RegisterErrors hata sayacını kaydeder ve scheduler ile collector'a eklenecek alıcıyı döndürür. Bilinen
+// bileşen/sınıf serileri 0 ile başlar
+func RegisterErrors() *ErrorCounter {
+	counter := &ErrorCounter{
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Bileşen ve hata sınıfı bazında hatalar",
+		}, []string{"component", "class"}),
+	}
+	for _, component := range types.ErrorComponents {
+		for _, class := range types.ErrorClasses() {
+			counter.errors.WithLabelValues(component, class)
+		}
+	}
+	Registry.MustRegister(counter.errors)
+	return counter
+}
+
+// ObserveError hatayı sınıfıyla sayar
+func (c *ErrorCounter) ObserveError(component string, err error) {
+	c.errors.WithLabelValues(component, types.ErrorClass(err)).Inc()
+}
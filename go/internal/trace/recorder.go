@@ -0,0 +1,112 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Trace olay türleri
+const (
+	KindNodeMetrics = "node_metrics"
+	KindPodMetrics  = "pod_metrics"
+	KindPrediction  = "prediction"
+)
+
+// Event trace dosyasındaki tek satır
+type Event struct {
+	Time        time.Time                   `json:"time"`
+	Kind        string                      `json:"kind"`
+	NodeMetrics *types.NodeMetrics          `json:"node_metrics,omitempty"`
+	PodMetrics  *types.PodMetrics           `json:"pod_metrics,omitempty"`
+	Prediction  *scheduler.PredictionRecord `json:"prediction,omitempty"`
+}
+
+// FileRecorder olayları JSON Lines formatında dosyaya yazar
+type FileRecorder struct {
+	mutex   sync.Mutex
+	file    *os.File
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewFileRecorder trace dosyasını oluşturur, dosya varsa sonuna eklenir
+func NewFileRecorder(path string) (*FileRecorder, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("trace dizini oluşturulamadı: %v", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("trace dosyası açılamadı: %v", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	return &FileRecorder{
+		file:    file,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}, nil
+}
+
+// RecordMetric toplanan node veya pod metriğini kaydeder
+func (r *FileRecorder) RecordMetric(at time.Time, metric interface{}) {
+	event := Event{Time: at}
+	switch m := metric.(type) {
+	case types.NodeMetrics:
+		event.Kind = KindNodeMetrics
+		event.NodeMetrics = &m
+	case types.PodMetrics:
+		event.Kind = KindPodMetrics
+		event.PodMetrics = &m
+	default:
+		logrus.Debugf("Bilinmeyen metrik tipi trace'e yazılmadı: %T", metric)
+		return
+	}
+
+	r.write(&event)
+}
+
+// RecordPrediction tahmin girdilerini ve sonucunu kaydeder
+func (r *FileRecorder) RecordPrediction(at time.Time, record *scheduler.PredictionRecord) {
+	r.write(&Event{Time: at, Kind: KindPrediction, Prediction: record})
+
+	// Tahminler seyrek ve değerli, çökme durumunda kaybolmamaları için hemen diske yazılır
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err := r.writer.Flush(); err != nil {
+		logrus.Warnf("Trace dosyası yazılamadı: %v", err)
+	}
+}
+
+// write olayı dosyaya yazar
+func (r *FileRecorder) write(event *Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.encoder.Encode(event); err != nil {
+		logrus.Warnf("Trace olayı yazılamadı: %v", err)
+	}
+}
+
+// Close tamponu boşaltır ve dosyayı kapatır
+func (r *FileRecorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.writer.Flush(); err != nil {
+		return fmt.Errorf("trace dosyası yazılamadı: %v", err)
+	}
+	return r.file.Close()
+}
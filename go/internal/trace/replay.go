@@ -0,0 +1,368 @@
+package trace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// scoreTolerance kaydedilen ve tekrar hesaplanan skorların eşit sayılacağı fark
+const scoreTolerance = 1e-9
+
+// Mismatch kaydedilen kararla replay kararının farklı olduğu tahmin
+type Mismatch struct {
+	Time          time.Time `json:"time"`
+	Namespace     string    `json:"namespace"`
+	PodName       string    `json:"pod_name"`
+	RecordedNode  string    `json:"recorded_node"`
+	RecordedScore float64   `json:"recorded_score"`
+	ReplayedNode  string    `json:"replayed_node"`
+	ReplayedScore float64   `json:"replayed_score"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// Report replay sonucu
+type Report struct {
+	Events      int        `json:"events"`
+	Predictions int        `json:"predictions"`
+	Matches     int        `json:"matches"`
+	Mismatches  []Mismatch `json:"mismatches"`
+}
+
+// Replay trace dosyasını kaydedildiği sırayla ve kaydedilen zamanla scoring hattından geçirir.
+// AI harmanlaması replay'de kullanılmaz, karar deterministik heuristik skorla üretilir.
+// Node takipçileri her tahminde kaydedilen durumdan metricsConfig'teki ayarlarla yeniden kurulur
+func Replay(path string, schedulerConfig *types.SchedulerConfig, metricsConfig *types.MetricsConfig) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("trace dosyası açılamadı: %v", err)
+	}
+	defer file.Close()
+
+	clock := types.NewManualClock(time.Time{})
+	collector := &replayCollector{
+		metricsConfig: metricsConfig,
+		podCache:      types.NewPodMetricsCache(),
+		usage:         types.NewNamespaceUsageTracker(),
+	}
+	collector.podCache.SetClock(clock)
+
+	// Her tahmin kendi kaydedilen snapshot'ıyla hesaplanır; assume kayıtları trace'teki istek toplamlarında zaten vardır
+	replayConfig := *schedulerConfig
+	replayConfig.SnapshotRefresh = 0
+	replayConfig.AssumeTTL = 0
+
+	source := &replaySource{}
+	aiScheduler := scheduler.NewAIScheduler(nil, collector, &replayConfig)
+	aiScheduler.SetClusterSource(source)
+	aiScheduler.SetClock(clock)
+
+	report := &Report{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("trace satırı %d parse edilemedi: %v", report.Events+1, err)
+		}
+		report.Events++
+		clock.Set(event.Time)
+
+		switch event.Kind {
+		case KindNodeMetrics:
+			if event.NodeMetrics != nil {
+				aiScheduler.InvalidateNode(event.NodeMetrics.NodeName)
+			}
+		case KindPodMetrics:
+			if event.PodMetrics != nil {
+				collector.podCache.UpdateCache(*event.PodMetrics)
+				aiScheduler.InvalidateNode(event.PodMetrics.NodeName)
+			}
+		case KindPrediction:
+			if event.Prediction != nil {
+				report.Predictions++
+				if mismatch := replayPrediction(aiScheduler, collector, source, event.Time, event.Prediction); mismatch != nil {
+					report.Mismatches = append(report.Mismatches, *mismatch)
+				} else {
+					report.Matches++
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("trace dosyası okunamadı: %v", err)
+	}
+
+	return report, nil
+}
+
+// replayPrediction kaydedilen girdilerle tahmini tekrar hesaplar, sonuç farklıysa Mismatch döner
+func replayPrediction(aiScheduler *scheduler.AIScheduler, collector *replayCollector, source *replaySource, at time.Time, record *scheduler.PredictionRecord) *Mismatch {
+	source.load(record)
+	collector.load(record, at)
+
+	usage := make(map[string]types.NamespaceUsage, len(record.NamespaceUsage))
+	for _, nsUsage := range record.NamespaceUsage {
+		usage[nsUsage.Namespace] = nsUsage
+	}
+	collector.usage.Update(usage)
+	collector.usage.SetClusterCapacity(record.ClusterCPU, record.ClusterMemory)
+
+	mismatch := &Mismatch{Time: at, Namespace: record.Namespace, PodName: record.PodName}
+	if record.Result != nil {
+		mismatch.RecordedNode = record.Result.NodeName
+		mismatch.RecordedScore = record.Result.Score
+	}
+
+	// Uygun node olmaması kayıttaki boş sonuçla karşılaştırılır
+	result, err := aiScheduler.PredictBestNode(context.Background(), record.PodName, record.Namespace)
+	if err != nil && !errors.Is(err, types.ErrNoFeasibleNode) {
+		mismatch.Error = err.Error()
+		return mismatch
+	}
+	if result != nil {
+		mismatch.ReplayedNode = result.NodeName
+		mismatch.ReplayedScore = result.Score
+	}
+
+	if mismatch.RecordedNode == mismatch.ReplayedNode && math.Abs(mismatch.RecordedScore-mismatch.ReplayedScore) <= scoreTolerance {
+		return nil
+	}
+	return mismatch
+}
+
+// replayCollector replay sırasında scheduler'a kaydedilen metrikleri ve tahmin anındaki takipçi durumlarını sağlar
+type replayCollector struct {
+	metricsConfig *types.MetricsConfig
+	podCache      *types.PodMetricsCache
+	usage         *types.NamespaceUsageTracker
+	readiness     *types.NodeReadinessTracker
+	flaps         *types.NodeFlapTracker
+	startup       *types.StartupLatencyTracker
+	neighbors     *types.NeighborUsageTracker
+	latency       *types.NodeLatencyMatrix
+	storage       *types.StoragePressureTracker
+}
+
+// load node takipçilerini kaydedilen tahmin anındaki durumlarıyla yeniden kurar. Eski kayıtlarda takipçiler boş kalır,
+// skorlama takipçi yokmuş gibi davranır
+func (c *replayCollector) load(record *scheduler.PredictionRecord, at time.Time) {
+	c.readiness = types.NewNodeReadinessTracker()
+	c.flaps = types.NewNodeFlapTracker(&c.metricsConfig.NodeFlaps)
+	c.startup = types.NewStartupLatencyTracker(&c.metricsConfig.StartupLatency)
+	c.neighbors = types.NewNeighborUsageTracker(&c.metricsConfig.NoisyNeighbor)
+	c.latency = types.NewNodeLatencyMatrix(&c.metricsConfig.LatencyMatrix)
+	c.storage = types.NewStoragePressureTracker()
+
+	for nodeName, state := range record.NodeTrackers {
+		c.readiness.SetHistory(nodeName, state.Readiness, at)
+		if state.Flaps != nil {
+			c.flaps.SetHistory(nodeName, *state.Flaps, at)
+		}
+		if state.Startup != nil {
+			c.startup.SetNode(*state.Startup)
+		}
+		if state.Storage != nil {
+			c.storage.SetNode(*state.Storage)
+		}
+		c.neighbors.SetNodeSeries(nodeName, state.Neighbors)
+	}
+	for _, latency := range record.NodeLatencies {
+		c.latency.Set(latency)
+	}
+}
+
+// GetMetricsChannel replay'de metrikler kanal yerine doğrudan cache'e yazılır
+func (c *replayCollector) GetMetricsChannel() <-chan interface{} {
+	return nil
+}
+
+// GetPodCache PodMetricsCache'i döndürür
+func (c *replayCollector) GetPodCache() types.PodCache {
+	return c.podCache
+}
+
+// GetNamespaceUsage namespace tüketim takipçisini döndürür
+func (c *replayCollector) GetNamespaceUsage() *types.NamespaceUsageTracker {
+	return c.usage
+}
+
+// GetNodeHistory replay'de node kullanım geçmişi tutulmaz, tahmin bileşeni devre dışı kalır
+func (c *replayCollector) GetNodeHistory() *types.NodeMetricsHistory {
+	return nil
+}
+
+// GetStartupLatency kaydedilen başlatma gecikmesi ortalamalarını döndürür
+func (c *replayCollector) GetStartupLatency() *types.StartupLatencyTracker {
+	return c.startup
+}
+
+// GetClusterEvents replay'de küme olayları tutulmaz
+func (c *replayCollector) GetClusterEvents() *types.ClusterEventLog {
+	return nil
+}
+
+// GetNodeFlaps kaydedilen Ready geçişlerini döndürür
+func (c *replayCollector) GetNodeFlaps() *types.NodeFlapTracker {
+	return c.flaps
+}
+
+// GetNodeReadiness kaydedilen Ready geçmişini döndürür
+func (c *replayCollector) GetNodeReadiness() *types.NodeReadinessTracker {
+	return c.readiness
+}
+
+// GetExternalSignals replay'de dış sinyaller tutulmaz
+func (c *replayCollector) GetExternalSignals() *types.ExternalSignalStore {
+	return nil
+}
+
+// GetAppSLI replay'de uygulama SLI'ları tutulmaz
+func (c *replayCollector) GetAppSLI() *types.AppSLITracker {
+	return nil
+}
+
+// GetMeshHealth replay'de mesh telemetrisi tutulmaz, ağ sağlığı cezası devre dışı kalır
+func (c *replayCollector) GetMeshHealth() *types.MeshHealthTracker {
+	return nil
+}
+
+// GetNodeLatency kaydedilen node'lar arası gecikmeleri döndürür
+func (c *replayCollector) GetNodeLatency() *types.NodeLatencyMatrix {
+	return c.latency
+}
+
+// GetStoragePressure kaydedilen disk IO durumunu döndürür
+func (c *replayCollector) GetStoragePressure() *types.StoragePressureTracker {
+	return c.storage
+}
+
+// GetNeighborUsage kaydedilen pod kullanım pencerelerini döndürür
+func (c *replayCollector) GetNeighborUsage() *types.NeighborUsageTracker {
+	return c.neighbors
+}
+
+// replaySource kaydedilen tahmin girdilerini küme kaynağı olarak sunar
+type replaySource struct {
+	record     *scheduler.PredictionRecord
+	nodes      []*corev1.Node
+	pods       []*corev1.Pod
+	generation uint64
+}
+
+// load küme durumunu kaydedilen tahmin girdileriyle değiştirir
+func (s *replaySource) load(record *scheduler.PredictionRecord) {
+	s.record = record
+	s.nodes = make([]*corev1.Node, len(record.Nodes))
+	for i := range record.Nodes {
+		s.nodes[i] = &record.Nodes[i]
+	}
+
+	// Kaydedilen istek toplamları node başına tek bir yer tutucu pod ile temsil edilir
+	s.pods = nil
+	if record.NodeRequests == nil {
+		if record.Pod != nil {
+			s.pods = []*corev1.Pod{record.Pod}
+		}
+	} else {
+		for _, node := range s.nodes {
+			if requests, ok := record.NodeRequests[node.Name]; ok {
+				s.pods = append(s.pods, requestsPod(node.Name, requests))
+			}
+		}
+	}
+
+	// Bekleyen pod'lar (tahmin edilen pod dahil) parçalanma şekillerini veren yer tutucu pod'larla temsil edilir
+	for i, shape := range record.PendingShapes {
+		for n := 0; n < shape.Count; n++ {
+			s.pods = append(s.pods, pendingPod(fmt.Sprintf("replay-pending-%d-%d", i, n), scheduler.NodeUsage{CPU: shape.CPU, Memory: shape.Memory}))
+		}
+	}
+	s.generation++
+}
+
+// requestsPod node'un kaydedilen istek toplamını taşıyan yer tutucu pod üretir
+func requestsPod(nodeName string, requests scheduler.NodeUsage) *corev1.Pod {
+	pod := placeholderPod("replay-requests-"+nodeName, requests)
+	pod.Spec.NodeName = nodeName
+	pod.Status.Phase = corev1.PodRunning
+	return pod
+}
+
+// pendingPod kaydedilen bekleyen pod şeklini taşıyan, node'a bağlanmamış yer tutucu pod üretir
+func pendingPod(name string, requests scheduler.NodeUsage) *corev1.Pod {
+	pod := placeholderPod(name, requests)
+	pod.Status.Phase = corev1.PodPending
+	return pod
+}
+
+// placeholderPod verilen CPU (core) ve memory (GB) isteğini taşıyan yer tutucu pod üretir
+func placeholderPod(name string, requests scheduler.NodeUsage) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "requests",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(math.Round(requests.CPU*1000)), resource.DecimalSI),
+						corev1.ResourceMemory: *resource.NewQuantity(int64(math.Round(requests.Memory*1024*1024*1024)), resource.BinarySI),
+					},
+				},
+			}},
+		},
+	}
+}
+
+// Nodes kaydedilen node'ları kaydedildiği sırayla döndürür
+func (s *replaySource) Nodes() []*corev1.Node {
+	return s.nodes
+}
+
+// Node kaydedilen node'u döndürür
+func (s *replaySource) Node(name string) (*corev1.Node, bool) {
+	for _, node := range s.nodes {
+		if node.Name == name {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// Pods kaydedilen istek toplamlarını ve bekleyen pod şekillerini taşıyan pod'ları döndürür (eski kayıtlarda sadece
+// tahmin edilen pod)
+func (s *replaySource) Pods() []*corev1.Pod {
+	return s.pods
+}
+
+// Pod kaydedilen pod'u döndürür
+func (s *replaySource) Pod(namespace, name string) (*corev1.Pod, bool) {
+	pod := s.record.Pod
+	if pod == nil || pod.Namespace != namespace || pod.Name != name {
+		return nil, false
+	}
+	return pod, true
+}
+
+// Generation her kayıt yüklendiğinde artar
+func (s *replaySource) Generation() uint64 {
+	return s.generation
+}
+
+// NodeUsage node'un kaydedilen kullanımını döndürür
+func (s *replaySource) NodeUsage(nodeName string) (float64, float64, bool) {
+	usage, ok := s.record.NodeUsage[nodeName]
+	return usage.CPU, usage.Memory, ok
+}
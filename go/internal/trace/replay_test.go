@@ -0,0 +1,121 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/simulator"
+	"ai-scheduler/internal/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// labeledPendingPod verilen label'la işaretlenmiş bekleyen pod
+func labeledPendingPod(name, label string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{label: "true"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}},
+		}}},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+}
+
+// liveCollector node takipçileri örnek durumlarla doldurulmuş canlı collector
+func liveCollector(nodes []*corev1.Node, now time.Time) *replayCollector {
+	collector := &replayCollector{
+		metricsConfig: &types.MetricsConfig{},
+		podCache:      types.NewPodMetricsCache(),
+		usage:         types.NewNamespaceUsageTracker(),
+		readiness:     types.NewNodeReadinessTracker(),
+		flaps:         types.NewNodeFlapTracker(&types.NodeFlapConfig{}),
+		startup:       types.NewStartupLatencyTracker(&types.StartupLatencyConfig{}),
+		neighbors:     types.NewNeighborUsageTracker(&types.NoisyNeighborConfig{}),
+		latency:       types.NewNodeLatencyMatrix(&types.LatencyMatrixConfig{}),
+		storage:       types.NewStoragePressureTracker(),
+	}
+
+	// Her node'un her takipçideki durumu farklıdır, böylece her takipçinin durumu skorları değiştirir
+	for i, node := range nodes {
+		offset := time.Duration(i) * time.Minute
+		collector.readiness.SetHistory(node.Name, []types.ReadinessSegment{
+			{Since: now.Add(-48 * time.Hour), Ready: true},
+			{Since: now.Add(-30*time.Minute - offset), Ready: false},
+			{Since: now.Add(-20 * time.Minute), Ready: true},
+		}, now)
+		collector.flaps.SetHistory(node.Name, types.NodeFlapHistory{
+			Ready:          true,
+			LastTransition: now.Add(-offset),
+			Transitions:    []time.Time{now.Add(-40 * time.Minute), now.Add(-offset)},
+		}, now)
+		collector.startup.SetNode(types.NodeStartupLatency{NodeName: node.Name, Samples: 5, Total: 10*time.Second + 2*offset/time.Minute*time.Second, Updated: now})
+		saturation := 0.8 + 0.015*float64(i)
+		collector.storage.SetNode(types.NodeStorageIO{NodeName: node.Name, Utilization: saturation, Saturation: saturation})
+	}
+	return collector
+}
+
+func TestRecordReplayRoundTrip(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cluster := simulator.NewCluster(&types.SyntheticClusterConfig{Nodes: 12, PodsPerNode: 6, PendingPods: 15, Seed: 42})
+	cluster.AddPods([]*corev1.Pod{
+		labeledPendingPod("latency-sensitive", "ai-scheduler.io/latency-sensitive"),
+		labeledPendingPod("io-heavy", "ai-scheduler.io/io-heavy"),
+	})
+	cfg := types.SchedulerConfig{
+		Mode:            scheduler.ModeHeuristic,
+		Scoring:         types.ScoringConfig{CPUWeight: 30, MemoryWeight: 30, NodeReadyWeight: 20},
+		Fragmentation:   types.FragmentationConfig{Enabled: true, Weight: 40},
+		FlapDampening:   types.FlapDampeningConfig{Enabled: true, Weight: 30},
+		StartupLatency:  types.StartupLatencyScoringConfig{Enabled: true, Weight: 40},
+		StoragePressure: types.StoragePressureConfig{Enabled: true, Weight: 40, Threshold: 0.8},
+	}
+	metricsConfig := types.MetricsConfig{}
+
+	// Canlı scheduler tahminlerini trace dosyasına kaydeder
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	recorder, err := NewFileRecorder(path)
+	if err != nil {
+		t.Fatalf("NewFileRecorder: %v", err)
+	}
+	aiScheduler := scheduler.NewAIScheduler(nil, liveCollector(cluster.Nodes(), now), &cfg)
+	aiScheduler.SetClusterSource(cluster)
+	aiScheduler.SetClock(types.NewManualClock(now))
+	aiScheduler.SetRecorder(recorder)
+
+	predictions := 0
+	for _, pod := range cluster.Pods() {
+		if pod.Spec.NodeName != "" {
+			continue
+		}
+		if _, err := aiScheduler.PredictBestNode(context.Background(), pod.Name, pod.Namespace); err != nil && !errors.Is(err, types.ErrNoFeasibleNode) {
+			t.Fatalf("PredictBestNode(%s): %v", pod.Name, err)
+		}
+		predictions++
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	report, err := Replay(path, &cfg, &metricsConfig)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if report.Predictions != predictions {
+		t.Fatalf("replay %d tahmin okudu, beklenen %d", report.Predictions, predictions)
+	}
+	for _, mismatch := range report.Mismatches {
+		t.Errorf("%s/%s: kaydedilen %s (%.4f), replay %s (%.4f) %s", mismatch.Namespace, mismatch.PodName,
+			mismatch.RecordedNode, mismatch.RecordedScore, mismatch.ReplayedNode, mismatch.ReplayedScore, mismatch.Error)
+	}
+}
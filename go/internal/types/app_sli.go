@@ -0,0 +1,141 @@
+package types
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Uygulama SLI'larını tanımlayan pod annotation'ları
+const (
+	// AnnotationSLIEndpoint {"latency_ms": .., "error_rate": ..} döndüren JSON endpoint'i.
+	// ":8080/sli" veya "/sli" pod IP'sine göre çözülür, tam URL olduğu gibi kullanılır
+	AnnotationSLIEndpoint = "ai-scheduler/sli-endpoint"
+	// AnnotationSLILatencyQuery milisaniye gecikme döndüren PromQL sorgusu ($namespace ve $pod yer tutucuları)
+	AnnotationSLILatencyQuery = "ai-scheduler/sli-latency-query"
+	// AnnotationSLIErrorRateQuery 0-1 arası hata oranı döndüren PromQL sorgusu ($namespace ve $pod yer tutucuları)
+	AnnotationSLIErrorRateQuery = "ai-scheduler/sli-error-rate-query"
+)
+
+// defaultAppSLIMaxAge yenilenmeyen SLI ölçümünün varsayılan geçerlilik süresi
+const defaultAppSLIMaxAge = 5 * time.Minute
+
+// AppSLISample pod'un uygulama SLI ölçümü, ölçülemeyen değerler nil'dir
+type AppSLISample struct {
+	Namespace string
+	Pod       string
+	NodeName  string
+	LatencyMs *float64
+	ErrorRate *float64
+	Timestamp time.Time
+}
+
+// NodeAppSLI node'daki SLI tanımlı pod'ların ortalama gecikmesi ve hata oranı
+type NodeAppSLI struct {
+	NodeName  string  `json:"node_name"`
+	Pods      int     `json:"pods"`
+	LatencyMs float64 `json:"latency_ms"` // Gecikme ölçülen pod'ların ortalaması
+	ErrorRate float64 `json:"error_rate"` // Hata oranı ölçülen pod'ların ortalaması
+}
+
+// AppSLITracker pod'ların son SLI ölçümlerini tutar ve node bazında birleştirir
+type AppSLITracker struct {
+	mutex   sync.RWMutex
+	samples map[string]AppSLISample // namespace/pod -> son ölçüm
+	nodes   map[string]NodeAppSLI   // Son kayıtta hesaplanan node ortalamaları
+	maxAge  time.Duration
+}
+
+// NewAppSLITracker yeni SLI takipçisi oluşturur
+func NewAppSLITracker(sliConfig *AppSLIConfig) *AppSLITracker {
+	t := &AppSLITracker{samples: make(map[string]AppSLISample), nodes: make(map[string]NodeAppSLI)}
+	t.Configure(sliConfig)
+	return t
+}
+
+// Configure ölçümlerin geçerlilik süresini değiştirir
+func (t *AppSLITracker) Configure(sliConfig *AppSLIConfig) {
+	maxAge := sliConfig.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultAppSLIMaxAge
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.maxAge = maxAge
+}
+
+// Record toplama turunun ölçümlerini kaydeder, süresi dolan ölçümleri siler ve node ortalamalarını yeniden hesaplar
+func (t *AppSLITracker) Record(samples []AppSLISample, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, sample := range samples {
+		t.samples[sample.Namespace+"/"+sample.Pod] = sample
+	}
+
+	type sums struct {
+		pods                     int
+		latency, errors          float64
+		latencyCount, errorCount int
+	}
+	byNode := make(map[string]*sums)
+	for key, sample := range t.samples {
+		if now.Sub(sample.Timestamp) > t.maxAge {
+			delete(t.samples, key)
+			continue
+		}
+		s := byNode[sample.NodeName]
+		if s == nil {
+			s = &sums{}
+			byNode[sample.NodeName] = s
+		}
+		s.pods++
+		if sample.LatencyMs != nil {
+			s.latency += *sample.LatencyMs
+			s.latencyCount++
+		}
+		if sample.ErrorRate != nil {
+			s.errors += *sample.ErrorRate
+			s.errorCount++
+		}
+	}
+
+	t.nodes = make(map[string]NodeAppSLI, len(byNode))
+	for nodeName, s := range byNode {
+		node := NodeAppSLI{NodeName: nodeName, Pods: s.pods}
+		if s.latencyCount > 0 {
+			node.LatencyMs = s.latency / float64(s.latencyCount)
+		}
+		if s.errorCount > 0 {
+			node.ErrorRate = s.errors / float64(s.errorCount)
+		}
+		t.nodes[nodeName] = node
+	}
+}
+
+// Node node'un son toplama turundaki ortalamalarını döndürür, ölçüm yoksa Pods 0'dır
+func (t *AppSLITracker) Node(nodeName string) NodeAppSLI {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	node, ok := t.nodes[nodeName]
+	if !ok {
+		node.NodeName = nodeName
+	}
+	return node
+}
+
+// Nodes ölçümü olan tüm node'ların ortalamalarını node adına göre sıralı döndürür
+func (t *AppSLITracker) Nodes() []NodeAppSLI {
+	t.mutex.RLock()
+	result := make([]NodeAppSLI, 0, len(t.nodes))
+	for _, node := range t.nodes {
+		result = append(result, node)
+	}
+	t.mutex.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].NodeName < result[j].NodeName })
+	return result
+}
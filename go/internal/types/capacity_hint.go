@@ -0,0 +1,51 @@
+package types
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CapacityHint filtrelemeden hiçbir node geçemediğinde üretilen "kapasite gerekli" olayı.
+// Cluster Autoscaler veya bir provisioning controller'ın hangi şekilde kapasite ekleyeceğini bilmesi için pod'un ihtiyacını taşır
+type CapacityHint struct {
+	Time         time.Time         `json:"time"`
+	Namespace    string            `json:"namespace"`
+	Pod          string            `json:"pod"`
+	Workload     WorkloadRef       `json:"workload"`
+	CPU          float64           `json:"cpu"`       // İstenen CPU (core)
+	Memory       float64           `json:"memory_gb"` // İstenen memory (GB)
+	NodePool     string            `json:"node_pool,omitempty"`
+	Zone         string            `json:"zone,omitempty"`
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	Rejected     map[string]int    `json:"rejected"` // Elenme sebebi -> node sayısı
+}
+
+// CapacityHintSink kapasite olaylarını dışarıya (webhook, Prometheus) iletir
+type CapacityHintSink interface {
+	CapacityNeeded(hint *CapacityHint)
+}
+
+// PodPlacementLabel pod'un label için istediği tek değeri nodeSelector'dan veya
+// zorunlu node affinity'deki tek değerli In ifadesinden döndürür, kısıt yoksa boş
+func PodPlacementLabel(pod *corev1.Pod, key string) string {
+	if key == "" {
+		return ""
+	}
+	if value, ok := pod.Spec.NodeSelector[key]; ok {
+		return value
+	}
+
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == key && expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) == 1 {
+				return expression.Values[0]
+			}
+		}
+	}
+	return ""
+}
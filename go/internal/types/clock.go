@@ -0,0 +1,49 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock zaman kaynağı, replay sırasında kaydedilen zamanın kullanılabilmesi için soyutlanmıştır
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock sistem saatini kullanan clock
+type realClock struct{}
+
+// Now sistem saatini döndürür
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// RealClock sistem saatini kullanan varsayılan clock
+var RealClock Clock = realClock{}
+
+// ManualClock elle ilerletilen clock (ör: trace replay)
+type ManualClock struct {
+	mutex sync.RWMutex
+	now   time.Time
+}
+
+// NewManualClock verilen zamandan başlayan clock oluşturur
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now clock'un gösterdiği zamanı döndürür
+func (c *ManualClock) Now() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.now
+}
+
+// Set clock'u verilen zamana ayarlar
+func (c *ManualClock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = now
+}
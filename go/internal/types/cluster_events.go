@@ -0,0 +1,179 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Küme olayı türleri
+const (
+	ClusterEventNodeCondition = "node_condition" // Node koşulunun durumu değişti
+	ClusterEventCollectorGap  = "collector_gap"  // Metrik toplaması beklenenden uzun süre yapılamadı
+	ClusterEventRestartStorm  = "restart_storm"  // Küme genelinde restart fırtınası, kararlılık güncellemeleri donduruldu
+)
+
+// Küme olay günlüğünün varsayılanları
+const (
+	defaultClusterEventRetention = 24 * time.Hour
+	defaultClusterEventMax       = 10000
+	// collectorGapFactor toplama aralığının bu katından uzun süren boşluk kaydedilir
+	collectorGapFactor = 2
+)
+
+// ClusterEvent scheduler'ın gözlediği küme olayı. Boşluk olaylarında Time boşluğun başlangıcıdır, restart
+// fırtınalarında Gap dondurma süresidir
+type ClusterEvent struct {
+	Time      time.Time     `json:"time"`
+	Kind      string        `json:"kind"`
+	Node      string        `json:"node,omitempty"`
+	Condition string        `json:"condition,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	Previous  string        `json:"previous,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Gap       time.Duration `json:"gap,omitempty"`
+}
+
+// ClusterEventLog node koşulu değişikliklerini ve toplama boşluklarını saklama süresi boyunca zaman sırasıyla tutar
+type ClusterEventLog struct {
+	mutex          sync.RWMutex
+	conditions     map[string]map[corev1.NodeConditionType]corev1.ConditionStatus
+	events         []ClusterEvent
+	lastCollection time.Time
+	retention      time.Duration
+	maxEvents      int
+}
+
+// NewClusterEventLog yeni küme olay günlüğü oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewClusterEventLog(eventsConfig *ClusterEventsConfig) *ClusterEventLog {
+	l := &ClusterEventLog{conditions: make(map[string]map[corev1.NodeConditionType]corev1.ConditionStatus)}
+	l.Configure(eventsConfig)
+	return l
+}
+
+// Configure saklama süresini ve en fazla olay sayısını değiştirir, mevcut olaylar korunur
+func (l *ClusterEventLog) Configure(eventsConfig *ClusterEventsConfig) {
+	retention := eventsConfig.Retention
+	if retention <= 0 {
+		retention = defaultClusterEventRetention
+	}
+	maxEvents := eventsConfig.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = defaultClusterEventMax
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.retention = retention
+	l.maxEvents = maxEvents
+}
+
+// ObserveNode node koşullarını önceki gözlemle karşılaştırıp değişenleri kaydeder. İlk gözlem değişiklik sayılmaz
+func (l *ClusterEventLog) ObserveNode(node *corev1.Node, now time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	previous, seen := l.conditions[node.Name]
+	current := make(map[corev1.NodeConditionType]corev1.ConditionStatus, len(node.Status.Conditions))
+	for i := range node.Status.Conditions {
+		condition := &node.Status.Conditions[i]
+		current[condition.Type] = condition.Status
+		if !seen || previous[condition.Type] == condition.Status {
+			continue
+		}
+
+		at := condition.LastTransitionTime.Time
+		if at.IsZero() || at.After(now) {
+			at = now
+		}
+		reason := condition.Reason
+		if condition.Message != "" {
+			reason += ": " + condition.Message
+		}
+		l.addLocked(ClusterEvent{
+			Time:      at,
+			Kind:      ClusterEventNodeCondition,
+			Node:      node.Name,
+			Condition: string(condition.Type),
+			Status:    string(condition.Status),
+			Previous:  string(previous[condition.Type]),
+			Reason:    reason,
+		})
+	}
+	l.conditions[node.Name] = current
+}
+
+// ObserveCollection başarılı toplamayı işaretler. Önceki toplamadan bu yana aralığın iki katından uzun süre
+// geçtiyse aradaki süre toplama boşluğu olarak kaydedilir
+func (l *ClusterEventLog) ObserveCollection(now time.Time, interval time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if last := l.lastCollection; !last.IsZero() && interval > 0 && now.Sub(last) > collectorGapFactor*interval {
+		l.addLocked(ClusterEvent{
+			Time:   last,
+			Kind:   ClusterEventCollectorGap,
+			Gap:    now.Sub(last),
+			Reason: "beklenen toplama aralığı: " + interval.String(),
+		})
+	}
+	l.lastCollection = now
+}
+
+// ObserveRestartStorm restart fırtınasının başlangıcını ve dondurma süresini kaydeder
+func (l *ClusterEventLog) ObserveRestartStorm(start, until time.Time, affected, nodes int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.addLocked(ClusterEvent{
+		Time:   start,
+		Kind:   ClusterEventRestartStorm,
+		Gap:    until.Sub(start),
+		Reason: fmt.Sprintf("%d/%d node'da pod restart'ı, kararlılık güncellemeleri donduruldu", affected, nodes),
+	})
+}
+
+// addLocked olayı zaman sırasını koruyarak ekler, en fazla olay sayısı aşılırsa en eskiler silinir
+func (l *ClusterEventLog) addLocked(event ClusterEvent) {
+	i := sort.Search(len(l.events), func(i int) bool { return l.events[i].Time.After(event.Time) })
+	l.events = append(l.events, ClusterEvent{})
+	copy(l.events[i+1:], l.events[i:])
+	l.events[i] = event
+	if len(l.events) > l.maxEvents {
+		l.events = append(l.events[:0:0], l.events[len(l.events)-l.maxEvents:]...)
+	}
+}
+
+// Expire saklama süresinden eski olayları ve nodes arasında olmayan (silinmiş) node'ların koşullarını siler
+func (l *ClusterEventLog) Expire(now time.Time, nodes map[string]bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	cutoff := now.Add(-l.retention)
+	i := sort.Search(len(l.events), func(i int) bool { return !l.events[i].Time.Before(cutoff) })
+	l.events = l.events[i:]
+	for name := range l.conditions {
+		if !nodes[name] {
+			delete(l.conditions, name)
+		}
+	}
+}
+
+// Events [since, until] aralığındaki olayları eskiden yeniye döndürür, sıfır sınırlar uygulanmaz
+func (l *ClusterEventLog) Events(since, until time.Time) []ClusterEvent {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	result := make([]ClusterEvent, 0)
+	for _, event := range l.events {
+		if (!since.IsZero() && event.Time.Before(since)) || (!until.IsZero() && event.Time.After(until)) {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// ClusterSource Kubernetes API yerine kullanılabilen küme kaynağı (ör: informer cache, sentetik küme)
+// Dönen nesneler kaynakla paylaşılır, çağıran tarafından değiştirilmemelidir
+type ClusterSource interface {
+	// Nodes kümedeki node'ları döndürür
+	Nodes() []*corev1.Node
+	// Node verilen node'u döndürür
+	Node(name string) (*corev1.Node, bool)
+	// Pods kümedeki pod'ları döndürür
+	Pods() []*corev1.Pod
+	// Pod verilen pod'u döndürür
+	Pod(namespace, name string) (*corev1.Pod, bool)
+	// NodeUsage node'un CPU (core) ve memory (GB) kullanımını döndürür
+	NodeUsage(nodeName string) (float64, float64, bool)
+	// Generation node veya pod'lar her değiştiğinde artan sayaç (snapshot'ın yenilenmesi gerekip gerekmediği için)
+	Generation() uint64
+}
+
+// DisruptionBudgetSource PodDisruptionBudget'ları da sağlayan küme kaynağı (opsiyonel).
+// Desteklemeyen kaynaklarda PDB'ler Kubernetes API'den okunur
+type DisruptionBudgetSource interface {
+	PodDisruptionBudgets() []*policyv1.PodDisruptionBudget
+}
+
+// ServiceSource Service'leri de sağlayan küme kaynağı (opsiyonel).
+// Desteklemeyen kaynaklarda Service'ler Kubernetes API'den okunur
+type ServiceSource interface {
+	Services() []*corev1.Service
+}
+
+// RuntimeClassSource RuntimeClass'ları da sağlayan küme kaynağı (opsiyonel).
+// Desteklemeyen kaynaklarda RuntimeClass'lar Kubernetes API'den okunur
+type RuntimeClassSource interface {
+	RuntimeClass(name string) (*nodev1.RuntimeClass, bool)
+}
+
+// VolumeSource PersistentVolumeClaim ve PersistentVolume'ları da sağlayan küme kaynağı (opsiyonel).
+// Desteklemeyen kaynaklarda volume'lar Kubernetes API'den okunur
+type VolumeSource interface {
+	PersistentVolumeClaim(namespace, name string) (*corev1.PersistentVolumeClaim, bool)
+	PersistentVolume(name string) (*corev1.PersistentVolume, bool)
+}
+
+// CSINodeSource CSINode'ları da sağlayan küme kaynağı (opsiyonel).
+// Desteklemeyen kaynaklarda CSINode'lar Kubernetes API'den okunur
+type CSINodeSource interface {
+	CSINodes() []*storagev1.CSINode
+}
+
+// PodBinder pod'ları node'lara kendisi bağlayan küme kaynağı (opsiyonel, ör: sentetik küme).
+// Desteklemeyen kaynaklarda pod'lar Kubernetes Binding API ile bağlanır
+type PodBinder interface {
+	BindPod(namespace, name, nodeName string) error
+}
+
+// NodeTainter node taint'lerini kendisi değiştiren küme kaynağı (opsiyonel, ör: sentetik küme).
+// Desteklemeyen kaynaklarda taint'ler Kubernetes API ile güncellenir. present false ise aynı anahtar ve etkili taint
+// kaldırılır
+type NodeTainter interface {
+	SetNodeTaint(nodeName string, taint corev1.Taint, present bool) error
+}
+
+// PodEventSource pod ekleme, güncelleme ve silme olaylarını bildiren küme kaynağı (opsiyonel, ör: informer)
+type PodEventSource interface {
+	// AddPodHandler olay başına çağrılacak fonksiyonu ekler, silinen pod'lar için deleted true'dur
+	AddPodHandler(handler func(pod *corev1.Pod, deleted bool))
+}
+
+// NodeEventSource node ekleme, güncelleme ve silme olaylarını bildiren küme kaynağı (opsiyonel, ör: informer)
+type NodeEventSource interface {
+	// AddNodeHandler olay başına çağrılacak fonksiyonu ekler, silinen node'lar için deleted true'dur
+	AddNodeHandler(handler func(node *corev1.Node, deleted bool))
+}
+
+// SetTaint taint listesinin aynı anahtar ve etkili taint'i çıkarılmış (present true ise taint'le değiştirilmiş)
+// kopyasını döndürür
+func SetTaint(taints []corev1.Taint, taint corev1.Taint, present bool) []corev1.Taint {
+	result := make([]corev1.Taint, 0, len(taints)+1)
+	for _, existing := range taints {
+		if !existing.MatchTaint(&taint) {
+			result = append(result, existing)
+		}
+	}
+	if present {
+		result = append(result, taint)
+	}
+	return result
+}
@@ -1,6 +1,10 @@
 package types
 
-import "time"
+import (
+	"path"
+	"strings"
+	"time"
+)
 
 // Config ana konfigürasyon struct'ı
 type Config struct {
@@ -11,6 +15,15 @@ type Config struct {
 	Logging     LoggingConfig     `mapstructure:"logging"`
 	Monitoring  MonitoringConfig  `mapstructure:"monitoring"`
 	Development DevelopmentConfig `mapstructure:"development"`
+	Secrets     SecretsConfig     `mapstructure:"secrets"`
+	Reports     ReportsConfig     `mapstructure:"reports"`
+	Federation  FederationConfig  `mapstructure:"federation"`
+	EventBus    EventBusConfig    `mapstructure:"event_bus"`
+	OTLPLogs    OTLPLogsConfig    `mapstructure:"otlp_logs"`
+	// HistoryStore pod metrik cache'i kalıcılığı ve karar günlüğünün ortak saklama backend'i
+	HistoryStore HistoryStoreConfig `mapstructure:"history_store"`
+	Handoff      HandoffConfig      `mapstructure:"handoff"`
+	Features     map[string]bool    `mapstructure:"features"`
 }
 
 // ServerConfig server ayarları
@@ -19,27 +32,997 @@ type ServerConfig struct {
 	Host         string        `mapstructure:"host"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	TLS          TLSConfig     `mapstructure:"tls"`
+	// RequestTimeout isteğin tahmin, metrik ve AI çağrılarına tanınan süre (0 = sınırsız), aşılırsa 504 döner
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// Auth API anahtarı doğrulaması ve namespace kapsamı
+	Auth AuthConfig `mapstructure:"auth"`
+	// Extender kube-scheduler extender webhook'ları (/extender/filter, /extender/prioritize, /extender/bind)
+	Extender ExtenderConfig `mapstructure:"extender"`
+}
+
+// ExtenderConfig kube-scheduler extender protokolü ayarları. kube-scheduler extender isteklerine API anahtarı
+// ekleyemediğinden webhook'lar API anahtarıyla doğrulanmaz; filter/prioritize erişimi ağ politikasıyla
+// sınırlanmalıdır, bind TLS istemci sertifikasıyla doğrulanır
+type ExtenderConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Bind açıksa /extender/bind pod'ları bağlar (kube-scheduler'da bindVerb tanımlıysa), kapalıysa endpoint yoktur.
+	// Bind sadece server.tls açık ve ClientCAFile tanımlıyken sunulur, istekler istemci sertifikasıyla doğrulanır
+	Bind bool `mapstructure:"bind"`
+	// ClientCAFile kube-scheduler'ın istemci sertifikasını imzalayan CA paketi (PEM)
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// AllowedNames kabul edilen istemci sertifikası ortak adları (CN), boşsa CA'nın imzaladığı her sertifika
+	AllowedNames []string `mapstructure:"allowed_names"`
+}
+
+// AuthConfig API anahtarı ayarları. Açıksa /api/v1 istekleri "Authorization: Bearer <token>" ile doğrulanır
+// (dış metrik alımı kendi token'ıyla doğrulanır)
+type AuthConfig struct {
+	Enabled bool           `mapstructure:"enabled"`
+	Keys    []APIKeyConfig `mapstructure:"keys"`
+}
+
+// APIKeyConfig API anahtarı. Namespaces boşsa anahtar tüm kümeyi görür; doluysa yalnız bu namespace'lerin
+// (glob, ör: team-*) tahmin ve istatistiklerine erişebilir, küme geneli ve yönetim endpoint'lerine erişemez
+type APIKeyConfig struct {
+	Name       string   `mapstructure:"name"`
+	Token      string   `mapstructure:"token"`
+	Namespaces []string `mapstructure:"namespaces"`
+	// Admin yönetim endpoint'lerine (/admin, profil değiştirme, model eğitimi) erişim verir, namespace kapsamlı
+	// anahtarlarda yok sayılır
+	Admin bool `mapstructure:"admin"`
+}
+
+// TLSConfig HTTPS ayarları
+type TLSConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
 }
 
 // KubernetesConfig Kubernetes ayarları
 type KubernetesConfig struct {
-	InCluster      bool          `mapstructure:"in_cluster"`
-	KubeconfigPath string        `mapstructure:"kubeconfig_path"`
-	APITimeout     time.Duration `mapstructure:"api_timeout"`
+	InCluster      bool                  `mapstructure:"in_cluster"`
+	KubeconfigPath string                `mapstructure:"kubeconfig_path"`
+	APITimeout     time.Duration         `mapstructure:"api_timeout"`
+	InformerCache  bool                  `mapstructure:"informer_cache"`
+	Protobuf       bool                  `mapstructure:"protobuf"`
+	ConfigMap      ConfigMapSourceConfig `mapstructure:"config_map"`
+}
+
+// ConfigMapSourceConfig ConfigMap tabanlı konfigürasyon kaynağı ayarları
+type ConfigMapSourceConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Namespace string `mapstructure:"namespace"`
+	Name      string `mapstructure:"name"`
+	Key       string `mapstructure:"key"`
 }
 
 // MetricsConfig metrics ayarları
 type MetricsConfig struct {
-	CollectionInterval time.Duration `mapstructure:"collection_interval"`
-	APITimeout         time.Duration `mapstructure:"api_timeout"`
-	EnableFallback     bool          `mapstructure:"enable_fallback"`
+	CollectionInterval time.Duration   `mapstructure:"collection_interval"`
+	APITimeout         time.Duration   `mapstructure:"api_timeout"`
+	EnableFallback     bool            `mapstructure:"enable_fallback"`
+	Namespaces         NamespaceFilter `mapstructure:"namespaces"`
+	// CacheMaxMemoryMB PodMetrics cache'inin tahmini bellek bütçesi, 0 ise sınırsız
+	CacheMaxMemoryMB int `mapstructure:"cache_max_memory_mb"`
+	// History node kullanım geçmişi (kapasite planlaması için)
+	History NodeHistoryConfig `mapstructure:"history"`
+	// WorkloadUsage iş yükü bazında pod kullanım geçmişi (rightsizing önerileri için)
+	WorkloadUsage WorkloadUsageConfig `mapstructure:"workload_usage"`
+	// StartupLatency node bazında pod başlatma süresi ortalamaları
+	StartupLatency StartupLatencyConfig `mapstructure:"startup_latency"`
+	// NoisyNeighbor aynı node'daki pod kullanımlarından noisy neighbor tespiti
+	NoisyNeighbor NoisyNeighborConfig `mapstructure:"noisy_neighbor"`
+	// NodeFlaps node'ların NotReady↔Ready geçiş geçmişi
+	NodeFlaps NodeFlapConfig `mapstructure:"node_flaps"`
+	// ClusterEvents node koşulu değişiklikleri ve toplama boşlukları günlüğü (zaman çizelgesi için)
+	ClusterEvents ClusterEventsConfig `mapstructure:"cluster_events"`
+	// Chaos deneyi altındaki pod ve node'ların hataları kararlılık istatistiklerine sayılmaz
+	Chaos ChaosConfig `mapstructure:"chaos"`
+	// RestartStorm küme genelindeki restart fırtınalarının node kararlılık geçmişine yazılmaması
+	RestartStorm RestartStormConfig `mapstructure:"restart_storm"`
+	// Ingest dış ajanların POST /api/v1/ingest/metrics ile gönderdiği node sinyalleri
+	Ingest IngestConfig `mapstructure:"ingest"`
+	// MQTT edge node'ların MQTT ile yayınladığı telemetrinin dış sinyal olarak alınması
+	MQTT MQTTConfig `mapstructure:"mqtt"`
+	// SLI pod annotation'larıyla tanımlanan uygulama gecikmesi ve hata oranı
+	SLI AppSLIConfig `mapstructure:"sli"`
+	// Mesh Istio/Linkerd telemetrisinden node bazında servis gecikmesi ve hata oranı
+	Mesh MeshConfig `mapstructure:"mesh"`
+	// LatencyMatrix probe DaemonSet'i veya Prometheus'tan okunan node'lar arası gecikme matrisi
+	LatencyMatrix LatencyMatrixConfig `mapstructure:"latency_matrix"`
+	// Storage node-exporter veya CSI sürücüsü metriklerinden node disklerinin IO doygunluğu
+	Storage StorageConfig `mapstructure:"storage"`
+	// Persistence pod metrik cache'inin yeniden başlatmalarda korunması için geçmiş deposuna yazılması
+	Persistence PersistenceConfig `mapstructure:"persistence"`
+	// SharedCache pod metrik cache'inin replikalar arasında Redis üzerinden paylaşılması
+	SharedCache SharedCacheConfig `mapstructure:"shared_cache"`
+}
+
+// SharedCacheConfig paylaşılan pod metrik cache'i ayarları. Örnekler Redis'e yazılır, her replika SyncInterval
+// aralıkla diğerlerinin yazdıklarını yerel kopyasına alır; böylece tüm replikalar aynı node geçmişiyle skorlar.
+// Aynı pod'un aynı durumunu DedupWindow içinde gözleyen replikalardan sadece ilki yazar
+type SharedCacheConfig struct {
+	Enabled       bool             `mapstructure:"enabled"`
+	Redis         RedisStoreConfig `mapstructure:"redis"`
+	SyncInterval  time.Duration    `mapstructure:"sync_interval"`
+	FlushInterval time.Duration    `mapstructure:"flush_interval"`
+	// DedupWindow 0 ise collection_interval'ın %90'ı
+	DedupWindow time.Duration `mapstructure:"dedup_window"`
+}
+
+// PersistenceConfig pod metrik cache'inin kalıcılık ayarları. Cache SnapshotInterval aralıkla ve kapanışta
+// history_store'a yazılır, başlangıçta geri yüklenir
+type PersistenceConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
+}
+
+// StorageConfig disk IO doygunluğu toplama ayarları. Sorgular node label'ına göre toplanmış seriler döndürmelidir
+// ($node ve $window yer tutucuları); boş bırakılan sorgular için node-exporter sorguları kullanılır
+type StorageConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	PrometheusURL string        `mapstructure:"prometheus_url"`
+	Interval      time.Duration `mapstructure:"interval"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+	Window        time.Duration `mapstructure:"window"` // rate() penceresi
+	// NodeLabel serilerde node'u tanımlayan label, değeri node adı veya node IP'si (instance) olabilir
+	NodeLabel        string `mapstructure:"node_label"`
+	UtilizationQuery string `mapstructure:"utilization_query"` // 0-1 disk meşguliyet oranı
+	IOPSQuery        string `mapstructure:"iops_query"`        // Saniyedeki okuma+yazma işlemi
+	ThroughputQuery  string `mapstructure:"throughput_query"`  // Saniyedeki okunan+yazılan byte
+	// MaxIOPS ve MaxThroughputMBps node disklerinin kapasitesi, tanımlıysa bunlara oran da doygunluğa sayılır
+	MaxIOPS           float64 `mapstructure:"max_iops"`
+	MaxThroughputMBps float64 `mapstructure:"max_throughput_mbps"`
+}
+
+// LatencyMatrixConfig node'lar arası gecikme matrisi ayarları. Probe ajanları ölçümleri
+// POST /api/v1/ingest/latency ile gönderir (metrics.ingest token'ı gerekir), PrometheusURL ve Query
+// tanımlıysa kaynak ve hedef node label'lı seriler (ör: mesh metriklerinden kayıt kuralı) ayrıca okunur
+type LatencyMatrixConfig struct {
+	// MaxAge yenilenmeyen ölçümün geçerlilik süresi
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// Smoothing yeni ölçümün üstel ortalamadaki ağırlığı (0-1]
+	Smoothing     float64       `mapstructure:"smoothing"`
+	PrometheusURL string        `mapstructure:"prometheus_url"`
+	Query         string        `mapstructure:"query"` // Milisaniye gecikme döndüren PromQL sorgusu
+	SourceLabel   string        `mapstructure:"source_label"`
+	TargetLabel   string        `mapstructure:"target_label"`
+	Interval      time.Duration `mapstructure:"interval"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+}
+
+// MeshConfig servis mesh telemetrisi ayarları. Pod bazında gelen (east-west) trafik Prometheus'tan okunur,
+// pod'ların node'larına göre toplanır ve küme medyanına göre node'un ağ sağlığı hesaplanır
+type MeshConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Provider istio veya linkerd
+	Provider      string        `mapstructure:"provider"`
+	PrometheusURL string        `mapstructure:"prometheus_url"`
+	Interval      time.Duration `mapstructure:"interval"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+	Window        time.Duration `mapstructure:"window"` // rate() penceresi
+	// PodLabel ve NamespaceLabel mesh metriklerinde pod'u tanımlayan label'lar
+	PodLabel       string `mapstructure:"pod_label"`
+	NamespaceLabel string `mapstructure:"namespace_label"`
+	// LatencyDegradation node gecikmesinin küme medyanına oranı bu değere ulaştığında ağ sağlığı 0 olur
+	LatencyDegradation float64 `mapstructure:"latency_degradation"`
+	// ErrorRateThreshold node hata oranı bu değere ulaştığında ağ sağlığı 0 olur
+	ErrorRateThreshold float64 `mapstructure:"error_rate_threshold"`
+	// MinRequestRate bu saniyelik istek hızının altındaki node'ların ağ sağlığı hesaplanmaz (yetersiz trafik)
+	MinRequestRate float64 `mapstructure:"min_request_rate"`
+}
+
+// AppSLIConfig uygulama SLI toplama ayarları. Pod'lar SLI endpoint'ini veya PromQL sorgularını
+// ai-scheduler/sli-* annotation'larıyla tanımlar, ölçümler node bazında AI özelliklerine eklenir
+type AppSLIConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Interval    time.Duration `mapstructure:"interval"`
+	Timeout     time.Duration `mapstructure:"timeout"` // Pod başına endpoint/sorgu süre sınırı
+	MaxAge      time.Duration `mapstructure:"max_age"` // Yenilenmeyen ölçümün geçerlilik süresi
+	Concurrency int           `mapstructure:"concurrency"`
+	// PrometheusURL sorgu annotation'ları için Prometheus adresi, boşsa sadece endpoint'ler toplanır
+	PrometheusURL string `mapstructure:"prometheus_url"`
+}
+
+// IngestConfig dış metrik alımı ayarları. Gönderilen sinyaller AI özelliklerine ext_<ad> olarak eklenir
+type IngestConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Token istemcilerin Bearer olarak göndereceği token (secrets.ingest_token tanımlıysa o da kabul edilir)
+	Token             string        `mapstructure:"token"`
+	TTL               time.Duration `mapstructure:"ttl"` // Yenilenmeyen sinyalin geçerlilik süresi
+	MaxSignalsPerNode int           `mapstructure:"max_signals_per_node"`
+}
+
+// MQTTConfig edge node'ların kısıtlı bağlantılar üzerinden MQTT broker'ına yayınladığı telemetriye abone olunması.
+// Eşlenen değerler ingest ile aynı depoya (TTL ve node başına sinyal sınırı) yazılır ve AI özelliklerine ext_ ön
+// ekiyle eklenir; metrics-server verisinin eksik olduğu edge kümeleri için
+type MQTTConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Broker tcp://host:1883 veya tls://host:8883 (ssl:// ve mqtts:// de kabul edilir)
+	Broker   string `mapstructure:"broker"`
+	ClientID string `mapstructure:"client_id"`
+	Username string `mapstructure:"username"`
+	// Password sadece Username ile kullanılabilir (MQTT 3.1.1 kullanıcı adsız parolaya izin vermez)
+	Password string `mapstructure:"password"`
+	// QoS abonelik QoS'u, 0 veya 1
+	QoS               int           `mapstructure:"qos"`
+	KeepAlive         time.Duration `mapstructure:"keep_alive"`
+	ConnectTimeout    time.Duration `mapstructure:"connect_timeout"`
+	ReconnectInterval time.Duration `mapstructure:"reconnect_interval"`
+	// Source sinyallerin kaynak adı
+	Source string             `mapstructure:"source"`
+	Topics []MQTTTopicMapping `mapstructure:"topics"`
+}
+
+// MQTTTopicMapping topic desenini node sinyallerine eşler. Desenin {node} segmenti node adı, {signal} segmenti sinyal
+// adıdır; {signal} yoksa Signal kullanılır. Yük sayı veya JSON nesnesidir: Field verilmişse nesnenin o alanı (nokta
+// ile iç içe), sinyal adı varsa "value" alanı, yoksa tüm sayısal alanlar alan adlarıyla sinyal olur. Değer Scale ile
+// çarpılır (0 ise 1). TimestampField verilmişse ölçüm zamanı yükten (unix saniye veya RFC3339) okunur
+type MQTTTopicMapping struct {
+	Topic          string  `mapstructure:"topic"`
+	Signal         string  `mapstructure:"signal"`
+	Field          string  `mapstructure:"field"`
+	Scale          float64 `mapstructure:"scale"`
+	TimestampField string  `mapstructure:"timestamp_field"`
+}
+
+// ChaosConfig chaos deneyi işaretleri (ör: Chaos Mesh, Litmus). İşaretler "anahtar" (var olması yeterli)
+// veya "anahtar=değer" biçimindedir; pod'da veya pod'un node'unda biri varsa pod deney altında sayılır
+type ChaosConfig struct {
+	Enabled     bool     `mapstructure:"enabled"`
+	Labels      []string `mapstructure:"labels"`
+	Annotations []string `mapstructure:"annotations"`
+}
+
+// RestartStormConfig restart fırtınası tespiti. Bir toplama turunda pod'ları restart olan (veya Failed'a geçen)
+// node sayısı hem MinNodes'a hem de node'ların NodeRatio oranına ulaşırsa fırtına sayılır
+type RestartStormConfig struct {
+	Enabled   bool    `mapstructure:"enabled"`
+	NodeRatio float64 `mapstructure:"node_ratio"`
+	MinNodes  int     `mapstructure:"min_nodes"`
+	// Freeze son fırtına turundan sonra restart'ların affedilmeye devam ettiği süre
+	Freeze time.Duration `mapstructure:"freeze"`
+}
+
+// Matches label veya annotation'lardan biri chaos işaretiyle eşleşiyorsa true döner
+func (c ChaosConfig) Matches(labels, annotations map[string]string) bool {
+	if !c.Enabled {
+		return false
+	}
+	return matchesMarker(c.Labels, labels) || matchesMarker(c.Annotations, annotations)
+}
+
+// matchesMarker "anahtar" veya "anahtar=değer" işaretlerinden biri değerlerde var mı
+func matchesMarker(markers []string, values map[string]string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, marker := range markers {
+		key, expected, hasValue := strings.Cut(marker, "=")
+		if value, ok := values[key]; ok && (!hasValue || value == expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeHistoryConfig node kullanım geçmişinin çözünürlüğü ve saklama süresi
+type NodeHistoryConfig struct {
+	Resolution time.Duration `mapstructure:"resolution"`
+	Retention  time.Duration `mapstructure:"retention"`
+}
+
+// WorkloadUsageConfig iş yükü kullanım histogramlarının sönümlenme ve saklama süresi
+type WorkloadUsageConfig struct {
+	// HalfLife örnek ağırlığının yarıya indiği süre (yeni kullanım eskisinden daha etkili olur)
+	HalfLife time.Duration `mapstructure:"half_life"`
+	// Retention bu süre boyunca görülmeyen iş yükleri silinir
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// StartupLatencyConfig pod başlatma süresi (kubelet kabulü, CNI, image çekme) ortalamalarının ayarları
+type StartupLatencyConfig struct {
+	// SmoothingFactor yeni ölçümün üstel ortalamadaki ağırlığı (0-1)
+	SmoothingFactor float64 `mapstructure:"smoothing_factor"`
+	// Retention bu süre boyunca yeni ölçüm gelmeyen node'ların ortalaması silinir
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// NodeFlapConfig node Ready geçişlerinin takip ayarları
+type NodeFlapConfig struct {
+	// Retention bu süreden eski geçişler silinir
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// ClusterEventsConfig küme olay günlüğü ayarları
+type ClusterEventsConfig struct {
+	// Retention bu süreden eski olaylar silinir
+	Retention time.Duration `mapstructure:"retention"`
+	// MaxEvents saklanan en fazla olay sayısı, aşılırsa en eskiler silinir
+	MaxEvents int `mapstructure:"max_events"`
+}
+
+// NoisyNeighborConfig noisy neighbor tespiti ayarları
+type NoisyNeighborConfig struct {
+	// Window node başına tutulan toplama turu sayısı
+	Window int `mapstructure:"window"`
+	// MinSamples pod'un değerlendirilmesi için penceredeki en az örnek
+	MinSamples int `mapstructure:"min_samples"`
+	// CorrelationThreshold kullanım ile komşu bozulması arasında bu korelasyonu aşan pod noisy sayılır (0-1)
+	CorrelationThreshold float64 `mapstructure:"correlation_threshold"`
+	// MinBursts noisy sayılmak için penceredeki en az kullanım patlaması
+	MinBursts int `mapstructure:"min_bursts"`
 }
 
 // SchedulerConfig scheduler ayarları
 type SchedulerConfig struct {
-	AIAPIURL   string          `mapstructure:"ai_api_url"`
-	Scoring    ScoringConfig   `mapstructure:"scoring"`
-	Thresholds ThresholdConfig `mapstructure:"thresholds"`
+	AIAPIURL    string          `mapstructure:"ai_api_url"`
+	AIAPIToken  string          `mapstructure:"ai_api_token"`
+	Mode        string          `mapstructure:"mode"` // ai (varsayılan) veya heuristic: heuristic modda AI API çağrılmaz
+	ObserveOnly bool            `mapstructure:"observe_only"`
+	Scoring     ScoringConfig   `mapstructure:"scoring"`
+	Thresholds  ThresholdConfig `mapstructure:"thresholds"`
+	Namespaces  NamespaceFilter `mapstructure:"namespaces"`
+	Fairness    FairnessConfig  `mapstructure:"fairness"`
+	Temporal    TemporalConfig  `mapstructure:"temporal"`
+	Forecast    ForecastConfig  `mapstructure:"forecast"`
+	// Seasonality node kullanımındaki günlük/haftalık döngülere göre pod'un yaşam süresi boyunca beklenen yükü
+	// skorlar, uzun yaşayan pod'lar şu an boş ama düzenli yoğunlaşan node'lardan uzak tutulur
+	Seasonality SeasonalityConfig `mapstructure:"seasonality"`
+	// StartupLatency gecikmeye duyarlı pod'ları hızlı başlatan node'lara yönlendirir
+	StartupLatency StartupLatencyScoringConfig `mapstructure:"startup_latency"`
+	// FlapDampening yakın zamanda NotReady↔Ready gidip gelen node'ları, Ready olsalar da bir süre cezalandırır
+	FlapDampening FlapDampeningConfig `mapstructure:"flap_dampening"`
+	// ReliabilityDecay başarısızlık ve restart oranlarında eski örneklerin ağırlığını üstel olarak azaltır
+	ReliabilityDecay ReliabilityDecayConfig `mapstructure:"reliability_decay"`
+	// MultiWindowAnalysis başarısızlık ve restart oranlarını tek pencere yerine birden fazla pencereden, örnek
+	// sayısına göre güvenle harmanlayarak hesaplar
+	MultiWindowAnalysis MultiWindowAnalysisConfig `mapstructure:"multi_window_analysis"`
+	// WarmUp kümeye yeni katılmış, geçmişi olmayan node'ları ısınana kadar cezalandırır
+	WarmUp WarmUpConfig `mapstructure:"warm_up"`
+	// NetworkHealth servis mesh telemetrisine göre east-west trafiği bozulmuş node'ları cezalandırır
+	NetworkHealth NetworkHealthConfig `mapstructure:"network_health"`
+	// Communication birbiriyle konuşan pod'ları aralarındaki gecikmesi düşük node'lara yerleştirir
+	Communication CommunicationConfig `mapstructure:"communication"`
+	// StoragePressure IO yoğun pod'ları diskleri doygun node'lardan uzak tutar
+	StoragePressure StoragePressureConfig `mapstructure:"storage_pressure"`
+	// StatefulLocality StatefulSet pod'larını verisinin bulunduğu veya önceki kopyasının çalıştığı node'a yönlendirir
+	StatefulLocality StatefulLocalityConfig `mapstructure:"stateful_locality"`
+	// CronBursts CronJob tetiklenme düzenlerini öğrenip patlamalardan önce kapasite ayırır ve patlamaları yayar
+	CronBursts CronBurstConfig `mapstructure:"cron_bursts"`
+	// Overcommit node havuzu başına isteklerin allocatable'ı ne kadar aşabileceği (filtre bu kapasiteyi kullanır)
+	Overcommit OvercommitConfig `mapstructure:"overcommit"`
+	// Platform pod'ları imajlarının desteklemediği işletim sistemi veya mimarideki node'lardan eler
+	Platform PlatformConfig `mapstructure:"platform"`
+	// Security pod'ları RuntimeClass, seccomp/AppArmor profili, sysctl ve host erişimi gereksinimlerini
+	// karşılamayan (kubelet admission'ın reddedeceği) node'lardan eler
+	Security PodSecurityConfig `mapstructure:"security"`
+	// Journal kararları ve durum geçişlerini çökme sonrası replay için dosyaya yazar
+	Journal JournalConfig `mapstructure:"journal"`
+	// DisruptionCost tahliye ve drain planlarında pod'ların kesinti maliyeti ağırlıkları
+	DisruptionCost DisruptionCostConfig `mapstructure:"disruption_cost"`
+	// TemplateFilterCache aynı şablondan (controller + pod-template-hash) gelen pod'ların node'a bağlı filtre
+	// sonucunu kısa süre saklar, sadece kapasite ve yerleşim sınırları pod başına yeniden kontrol edilir
+	TemplateFilterCache TemplateFilterCacheConfig `mapstructure:"template_filter_cache"`
+	// Batch toplu tahminlerde pod'ların yerleşimini birlikte çözen optimizasyonun ayarları
+	Batch BatchConfig `mapstructure:"batch"`
+	// Pending /api/v1/pending'de listelenen, bu scheduler'ın yerleştirmesini bekleyen pod'lar
+	Pending PendingConfig `mapstructure:"pending"`
+	// Binding spec.schedulerName'i bu scheduler olan bekleyen pod'ları tahmin edilen node'a bağlar
+	Binding BindingConfig `mapstructure:"binding"`
+	// Comparison varsayılan scheduler'ın yerleştirdiği pod'lar için AI seçimini hesaplayıp sonuçları karşılaştırır
+	Comparison ComparisonConfig `mapstructure:"comparison"`
+	// TemplateHistory pod şablonu özeti başına node ve node özelliği bazında sonuç geçmişi tutar
+	TemplateHistory TemplateHistoryConfig `mapstructure:"template_history"`
+	// Audit sadece heuristik skorla verilen kararlardan örnekleri AI'ya eleştiri için gönderir ve uyuşma oranını ölçer
+	Audit AuditConfig `mapstructure:"audit"`
+	// Remediation kararlılık skoru uzun süre eşiğin altında kalan node'lara PreferNoSchedule taint'i önerir veya uygular
+	Remediation RemediationConfig `mapstructure:"remediation"`
+	// Degradation AI, gecikme bütçesi ve metrik akışının durumuna göre kademeli bozulma seviyelerini yönetir
+	Degradation DegradationConfig `mapstructure:"degradation"`
+	// Admission tahmin isteklerinin eşzamanlılık sınırı ve namespace'ler arası adil kuyruğu
+	Admission AdmissionConfig `mapstructure:"admission"`
+	// Guardrails operatörün node başına yerleşim sınırları (iş yükü başına pod, yerleşim hızı)
+	Guardrails GuardrailsConfig `mapstructure:"guardrails"`
+	// Cooldown yerleşen pod'ları art arda kısa sürede başarısız olan node'ları geçici olarak eler veya cezalandırır
+	Cooldown CooldownConfig `mapstructure:"cooldown"`
+	// VolumeLimits pod'un CSI volume'larını bağlayamayacak (sürücünün bağlama sınırı dolu) node'ları eler
+	VolumeLimits VolumeLimitsConfig `mapstructure:"volume_limits"`
+	// Fragmentation bekleyen pod şekillerine sığmayan (kullanılamaz kalan) kapasiteyi artıran yerleşimleri cezalandırır
+	Fragmentation FragmentationConfig `mapstructure:"fragmentation"`
+	// Windows karışık işletim sistemli kümelerde Windows node'larının skorlanması
+	Windows WindowsScoringConfig `mapstructure:"windows"`
+	// Staleness skorlama girdilerinin (collector'ın node ve pod metrikleri) yaşını denetler, bayat girdilerle
+	// verilen kararları işaretler veya reddeder
+	Staleness StalenessConfig `mapstructure:"staleness"`
+	// Strategy node seçim stratejisi: spread (boş node'lar tercih edilir, varsayılan) veya binpack (dolu node'lar)
+	Strategy string `mapstructure:"strategy"`
+	// Profiles adlandırılmış skorlama profilleri (strateji ve ağırlıklar)
+	Profiles []SchedulingProfile `mapstructure:"profiles"`
+	// Profile etkin profil, boşsa temel strateji ve ağırlıklar geçerli. Çalışma anında /api/v1/config/profile ile değiştirilebilir
+	Profile string `mapstructure:"profile"`
+	// NamespaceProfiles namespace başına profil, bağlı namespace'lerin pod'ları etkin profil yerine bununla skorlanır
+	NamespaceProfiles map[string]string `mapstructure:"namespace_profiles"`
+	// Policies zaman penceresine göre strateji ve ağırlıkları değiştiren kurallar, ilk eşleşen uygulanır
+	Policies []ScheduledPolicy `mapstructure:"policies"`
+	// PodHints pod annotation'larıyla verilen, etkin politikanın üzerine uygulanan skorlama tercihleri
+	PodHints PodHintsConfig `mapstructure:"pod_hints"`
+	// ScoreCacheTTL heuristik node skorlarının yeniden kullanılma süresi, 0 ise cache kapalı
+	ScoreCacheTTL time.Duration `mapstructure:"score_cache_ttl"`
+	// ScoreSmoothing node skorlarına üstel yumuşatma ve histerezis uygular, anlık metrik sıçramaları sıralamayı
+	// ileri geri çevirmez
+	ScoreSmoothing ScoreSmoothingConfig `mapstructure:"score_smoothing"`
+	// IncrementalScoring node skorları metrik değiştikçe güncellenen sıralı indekste tutulur
+	IncrementalScoring bool `mapstructure:"incremental_scoring"`
+	// LatencyBudget filtreleme+skorlama hattının tahmin başına süre hedefi, 0 ise takip edilmez
+	LatencyBudget time.Duration `mapstructure:"latency_budget"`
+	// AIBudget canlı AI analizinin tahmin başına süresi; aşılırsa karar heuristik skorla verilir ve AI atlandı
+	// olarak kaydedilir, 0 ise sınır yok
+	AIBudget time.Duration `mapstructure:"ai_budget"`
+	// SnapshotRefresh küme snapshot'ının en fazla hangi sıklıkla yeniden kurulacağı
+	SnapshotRefresh time.Duration `mapstructure:"snapshot_refresh"`
+	// AssumeTTL tahmin edilen node'a pod'un varsayılı yerleşik sayılma süresi, 0 ise assume yapılmaz
+	AssumeTTL time.Duration `mapstructure:"assume_ttl"`
+	// DecisionHistorySize bellekte tutulan son karar sayısı
+	DecisionHistorySize int `mapstructure:"decision_history_size"`
+	// DecisionStore karar geçmişinin history_store'a snapshot'ları, yeniden başlatmada son kararlar geri yüklenir
+	DecisionStore PersistenceConfig   `mapstructure:"decision_store"`
+	CapacityHints CapacityHintsConfig `mapstructure:"capacity_hints"`
+	// Outcomes kararları sonraki pod yaşam döngüsü olaylarıyla eşleştirip etiketli kayıt üretir
+	Outcomes OutcomeConfig `mapstructure:"outcomes"`
+	// ScoringTuning skorlama ağırlıklarını etiketli karar sonuçlarına göre operatörün verdiği sınırlar içinde ayarlar
+	ScoringTuning ScoringTuningConfig `mapstructure:"scoring_tuning"`
+}
+
+// ScoringTuningConfig skorlama ağırlıklarının otomatik ayarı. Sadece Bounds'ta sınırı verilen ağırlıklar değiştirilir
+type ScoringTuningConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval değerlendirme aralığı
+	Interval time.Duration `mapstructure:"interval"`
+	// MinSamples son değişiklikten sonra verilip tahmin edilen node'da etiketlenen en az karar sayısı
+	MinSamples int `mapstructure:"min_samples"`
+	// Step tek değerlendirmede ağırlığın sınır aralığına göre en fazla değişim oranı (0-1)
+	Step float64 `mapstructure:"step"`
+	// Tolerance başarı oranı son değişiklikten önceki dönemden bu kadar düşerse değişiklik geri alınır
+	Tolerance float64 `mapstructure:"tolerance"`
+	// Bounds ağırlık adı (scoring altındaki anahtar, ör: cpu_weight) -> izin verilen aralık
+	Bounds map[string]WeightBounds `mapstructure:"bounds"`
+}
+
+// WeightBounds ayarlanan ağırlığın alt ve üst sınırı
+type WeightBounds struct {
+	Min float64 `mapstructure:"min"`
+	Max float64 `mapstructure:"max"`
+}
+
+// OutcomeConfig karar sonucu eşleştirme ayarları
+type OutcomeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Window karardan sonra pod olaylarının izlendiği süre, sonunda çalışan pod başarılı sayılır
+	Window time.Duration `mapstructure:"window"`
+	// MaxPending aynı anda izlenen en fazla karar, doluysa yeni kararlar izlenmez
+	MaxPending int `mapstructure:"max_pending"`
+	// HistorySize bellekte tutulan etiketli kayıt sayısı
+	HistorySize int `mapstructure:"history_size"`
+}
+
+// CapacityHintsConfig uygun node kalmadığında üretilen "kapasite gerekli" olaylarının ayarları
+type CapacityHintsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// WebhookURL olayların JSON olarak POST edildiği adres, boşsa webhook gönderilmez
+	WebhookURL     string        `mapstructure:"webhook_url"`
+	WebhookTimeout time.Duration `mapstructure:"webhook_timeout"`
+	// PoolLabel ve ZoneLabel pod'un istediği node havuzu ve zone'un okunduğu label'lar
+	PoolLabel string `mapstructure:"pool_label"`
+	ZoneLabel string `mapstructure:"zone_label"`
+	// Cooldown aynı iş yükü ve kaynak şekli için olayın tekrar gönderilmeyeceği süre
+	Cooldown time.Duration `mapstructure:"cooldown"`
+}
+
+// StartupLatencyScoringConfig başlatma gecikmesi skorlama ayarları
+type StartupLatencyScoringConfig struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+	// Target bu süreden yavaş başlatan node'lar cezalandırılır, ceza Target'ın iki katında tam ağırlığa ulaşır
+	Target time.Duration `mapstructure:"target"`
+	// SensitiveLabel pod'u gecikmeye duyarlı işaretleyen label veya annotation ("true")
+	SensitiveLabel string `mapstructure:"sensitive_label"`
+	// MinSamples node ortalamasının kullanılması için gereken en az ölçüm
+	MinSamples int `mapstructure:"min_samples"`
+}
+
+// FlapDampeningConfig node flap cezası ayarları
+type FlapDampeningConfig struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+	// HalfLife her geçişin cezaya katkısı bu sürede yarıya iner
+	HalfLife time.Duration `mapstructure:"half_life"`
+	// Threshold sönümlenmiş geçiş sayısı bu değere ulaştığında ceza tam ağırlığa ulaşır
+	Threshold float64 `mapstructure:"threshold"`
+}
+
+// ReliabilityDecayConfig güvenilirlik geçmişinin azalma ayarları. Açıkken node analizi son 24 saatin eşit ağırlıklı
+// ortalaması yerine saklama penceresindeki (7 gün) örneklerin HalfLife yarı ömürle azalan ağırlıklı ortalamasıdır
+type ReliabilityDecayConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	HalfLife time.Duration `mapstructure:"half_life"`
+}
+
+// MultiWindowAnalysisConfig node güvenilirlik analizinin birden fazla pencereden örnek sayısına göre güvenle
+// harmanlanması. Güvenilirlik azalması açıksa en uzun pencere yerine ağırlıklı saklama penceresi analizi kullanılır
+type MultiWindowAnalysisConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Windows analiz pencereleri; 1h, 6h, 24h ve 168h artımlı tutulur, diğerleri her analizde taranır
+	Windows []time.Duration `mapstructure:"windows"`
+	// ConfidenceSamples pencerenin yarım güven aldığı örnek sayısı: n örnekli pencerenin güveni n/(n+ConfidenceSamples)
+	ConfidenceSamples int `mapstructure:"confidence_samples"`
+	// PriorFailureRate ve PriorRestartCount az örnekli node'un oranlarının çekildiği değerler
+	PriorFailureRate  float64 `mapstructure:"prior_failure_rate"`
+	PriorRestartCount float64 `mapstructure:"prior_restart_count"`
+}
+
+// WarmUpConfig yeni node ısınma cezası ayarları. Geçmişi olmayan node'un kararlılık skoru yanıltıcı biçimde iyi
+// göründüğünden ceza Weight'ten başlar; Period dolduğunda veya node'da MinPods pod gözlendiğinde (hangisi önceyse)
+// lineer olarak 0'a iner
+type WarmUpConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Weight  float64       `mapstructure:"weight"`
+	Period  time.Duration `mapstructure:"period"`
+	MinPods int           `mapstructure:"min_pods"` // Node analizinde gözlenen pod sayısı
+}
+
+// NetworkHealthConfig ağ sağlığı cezası ayarları, ceza Weight × (1 - ağ sağlığı) olarak uygulanır
+type NetworkHealthConfig struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+}
+
+// CommunicationConfig iletişim farkındalıklı yerleşim ayarları. Pod'un eşleri annotation'da adı geçen
+// Service'lerin pod'larıdır; ceza Weight × min(1, eşlere ortalama gecikme / MaxLatency) olarak uygulanır
+type CommunicationConfig struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+	// MaxLatency cezanın tam uygulandığı ortalama gecikme (ms)
+	MaxLatency float64 `mapstructure:"max_latency_ms"`
+	// DiscoverFromServices açıksa Service annotation'ları pod'lara miras kalır ve pod'un Service'lerini
+	// çağırdığını bildiren pod'lar da eş sayılır
+	DiscoverFromServices bool `mapstructure:"discover_from_services"`
+}
+
+// StoragePressureConfig disk doygunluğu cezası ayarları. IO yoğun pod'lara, doygunluğu Threshold'u aşan
+// node'larda aşan kısımla orantılı en fazla Weight kadar ceza uygulanır
+type StoragePressureConfig struct {
+	Enabled   bool    `mapstructure:"enabled"`
+	Weight    float64 `mapstructure:"weight"`
+	Threshold float64 `mapstructure:"threshold"` // 0-1
+	// IOHeavyLabel pod'u IO yoğun işaretleyen label veya annotation ("true")
+	IOHeavyLabel string `mapstructure:"io_heavy_label"`
+	// IncludePVCPods açıksa PersistentVolumeClaim bağlayan pod'lar da IO yoğun sayılır
+	IncludePVCPods bool `mapstructure:"include_pvc_pods"`
+}
+
+// StatefulLocalityConfig StatefulSet veri yerelliği ayarları. Pod'un bağlı PV'si node'a bağlıysa (local PV) o node'a,
+// pod kimliği (namespace/ad) daha önce bir node'da çalıştıysa o node'a Weight kadar bonus verilir. Node'a değil
+// topoloji alanına bağlı PV'lerin alanındaki ve önceki node'un TopologyKey alanındaki node'lar Weight*DomainFactor alır
+type StatefulLocalityConfig struct {
+	Enabled      bool    `mapstructure:"enabled"`
+	Weight       float64 `mapstructure:"weight"`
+	DomainFactor float64 `mapstructure:"domain_factor"` // 0-1
+	TopologyKey  string  `mapstructure:"topology_key"`
+	// Retention pod'un önceki node'unun hatırlanma süresi
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// CronBurstConfig CronJob patlaması öngörüsü ayarları. Tetiklenmeler Job adlarındaki planlanan zamandan öğrenilir;
+// en az MinFirings tetiklenmenin aralıkları medyana Tolerance içinde yakınsa sonraki tetiklenme tahmin edilir.
+// Tetiklenmeden Lead önce node'larda patlamanın eşit payı kadar boş kapasite ayrılır, tetiklenmeden sonraki Window
+// boyunca patlama pod'ları node başına adil paydan fazlasını alan node'larda cezalandırılır
+type CronBurstConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Weight      float64       `mapstructure:"weight"`
+	Lead        time.Duration `mapstructure:"lead"`
+	Window      time.Duration `mapstructure:"window"`
+	MinFirings  int           `mapstructure:"min_firings"`
+	HistorySize int           `mapstructure:"history_size"`
+	Tolerance   float64       `mapstructure:"tolerance"` // 0-1, periyoda oranla
+}
+
+// OvercommitConfig CPU/memory overcommit politikası. Filtre, node'daki pod isteklerinin toplamını allocatable*oran ile
+// karşılaştırır: 1'den büyük oran overcommit'e izin verir, 1'den küçük oran boşluk bırakır. Node'un havuzu
+// PoolLabel'dan okunur, Pools'ta olmayan havuzlara Default uygulanır; 0 olan oranlar 1 sayılır
+type OvercommitConfig struct {
+	Enabled   bool                       `mapstructure:"enabled"`
+	PoolLabel string                     `mapstructure:"pool_label"`
+	Default   OvercommitRatio            `mapstructure:"default"`
+	Pools     map[string]OvercommitRatio `mapstructure:"pools"`
+}
+
+// OvercommitRatio istek/allocatable oranları
+type OvercommitRatio struct {
+	CPU    float64 `mapstructure:"cpu"`
+	Memory float64 `mapstructure:"memory"`
+}
+
+// PlatformConfig işletim sistemi ve mimari eşleştirme ayarları. Pod'un platformları ai-scheduler/platforms
+// annotation'ından veya ResolveImages açıksa imajların registry manifest listelerinden okunur
+type PlatformConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ResolveImages imaj platformlarını registry'den arka planda çözer, çözülene kadar imaj kısıt getirmez
+	ResolveImages bool          `mapstructure:"resolve_images"`
+	CacheTTL      time.Duration `mapstructure:"cache_ttl"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+}
+
+// PodSecurityConfig pod güvenliği ve RuntimeClass uygunluk kontrolü ayarları
+type PodSecurityConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedUnsafeSysctls tüm kubelet'lerde --allowed-unsafe-sysctls ile izin verilen sysctl'lar ("kernel.msg*" gibi)
+	AllowedUnsafeSysctls []string `mapstructure:"allowed_unsafe_sysctls"`
+	// HostAccessLabel değeri "true" olan node'lara hostPath, hostNetwork, hostPID veya hostIPC kullanan pod yerleşmez
+	HostAccessLabel string `mapstructure:"host_access_label"`
+}
+
+// JournalConfig karar günlüğü ayarları. Kararlar, sonuçlar, assume/forget ve mod değişiklikleri sadece eklenen
+// dosyaya yazılır; başlangıçta replay edilerek cache'ler yeniden kurulur
+type JournalConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Backend file (File'a yazılır, boyutla döndürülür) veya history_store (history_store'un journal akışına yazılır,
+	// Retention'dan eski kayıtlar açılışta silinir)
+	Backend   string        `mapstructure:"backend"`
+	Retention time.Duration `mapstructure:"retention"`
+	File      string        `mapstructure:"file"`
+	// MaxSizeMB aşılınca dosya döndürülür, en fazla MaxFiles eski dosya tutulur
+	MaxSizeMB int `mapstructure:"max_size_mb"`
+	MaxFiles  int `mapstructure:"max_files"`
+	// Fsync her kayıttan sonra dosyayı diske senkronize eder (node çökmesine karşı, yazma maliyeti yüksek)
+	Fsync bool `mapstructure:"fsync"`
+	// Restore başlangıçta günlüğü replay edip karar geçmişini, sonuçları, assume kayıtlarını ve modu geri yükler
+	Restore bool `mapstructure:"restore"`
+}
+
+// DegradationConfig kademeli bozulma ayarları. Seviyeler: tam AI -> önbellekteki AI analizi (AI üst üste
+// AIFailureThreshold kez hata verdi) -> sadece heuristik (önbellekte güncel analiz yok) -> sıralı seçim
+// (gecikme bütçesi BudgetBreachThreshold kez üst üste aşıldı veya MetricsStaleAfter süredir metrik gelmiyor)
+type DegradationConfig struct {
+	Enabled            bool `mapstructure:"enabled"`
+	AIFailureThreshold int  `mapstructure:"ai_failure_threshold"`
+	// AICacheTTL node'un son başarılı AI analizinin önbellekte kullanılabileceği süre
+	AICacheTTL time.Duration `mapstructure:"ai_cache_ttl"`
+	// RecoveryProbeInterval bozulmuş seviyede AI'nın ve skorlamanın yeniden denenme aralığı
+	RecoveryProbeInterval time.Duration `mapstructure:"recovery_probe_interval"`
+	// BudgetBreachThreshold ve MetricsStaleAfter negatifse ilgili koşul kontrol edilmez
+	BudgetBreachThreshold int           `mapstructure:"budget_breach_threshold"`
+	MetricsStaleAfter     time.Duration `mapstructure:"metrics_stale_after"`
+}
+
+// DisruptionCostConfig pod tahliyesinin kesinti maliyeti bileşenlerinin ağırlıkları, negatif ağırlıklı bileşen
+// hesaplanmaz
+type DisruptionCostConfig struct {
+	// PriorityWeight sistem kritik önceliğe (logaritmik) ulaşan pod'un öncelik maliyeti
+	PriorityWeight float64 `mapstructure:"priority_weight"`
+	// BudgetWeight PDB'nin son hakkını tüketen tahliyenin maliyeti, kalan hak arttıkça azalır (ağırlık/hak)
+	BudgetWeight float64 `mapstructure:"budget_weight"`
+	// RestartWeight RestartSaturation ve üzeri restart olan pod'un maliyeti
+	RestartWeight     float64 `mapstructure:"restart_weight"`
+	RestartSaturation int     `mapstructure:"restart_saturation"`
+	// StatefulWeight StatefulSet pod'unun maliyeti; kalıcı volume'lu pod'lar yarısını, emptyDir'liler dörtte birini alır
+	StatefulWeight float64 `mapstructure:"stateful_weight"`
+}
+
+// TemplateFilterCacheConfig pod şablonu başına filtre sonucu cache'i ayarları
+type TemplateFilterCacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTL sonucun yeniden kullanılabildiği süre, node'lardan biri değişirse sonuç daha önce geçersiz olur
+	TTL time.Duration `mapstructure:"ttl"`
+	// MaxEntries cache'teki en fazla şablon
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// BatchConfig toplu yerleşim optimizasyonu ayarları
+type BatchConfig struct {
+	// MaxPods bir toplu istekteki en fazla pod
+	MaxPods int `mapstructure:"max_pods"`
+	// MaxRounds yerel arama turu sınırı, negatifse yerel arama yapılmaz
+	MaxRounds int `mapstructure:"max_rounds"`
+	// SpreadPenalty ScheduleAnyway topology spread kısıtlarında maxSkew'i aşan her pod için ceza
+	SpreadPenalty float64 `mapstructure:"spread_penalty"`
+	// LoadWeight node'un batch sonrası istek doluluğunun (0-1) ağırlığı: spread stratejisinde ceza, binpack'te
+	// bonus. Negatifse kullanılmaz
+	LoadWeight float64 `mapstructure:"load_weight"`
+}
+
+// PendingConfig bekleyen pod listesi ayarları
+type PendingConfig struct {
+	// SchedulerNames listelenen pod'ların spec.schedulerName değerleri (boş schedulerName = default-scheduler),
+	// liste boşsa kapsamdaki tüm bağlanmamış pod'lar listelenir
+	SchedulerNames []string `mapstructure:"scheduler_names"`
+}
+
+// BindingConfig bağımsız scheduler olarak çalışma ayarları. spec.schedulerName'i SchedulerName olan bekleyen pod'lar
+// için node tahmin edilir ve pod Binding API ile bağlanır; başarısız denemeler Backoff'tan başlayıp her denemede
+// ikiye katlanan (MaxBackoff ile sınırlı) süre sonra tekrarlanır
+type BindingConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	SchedulerName string `mapstructure:"scheduler_name"`
+	// ResyncInterval olayı kaçan veya olay bildirmeyen küme kaynaklarında bekleyen pod'ların tarama aralığı
+	ResyncInterval time.Duration `mapstructure:"resync_interval"`
+	Backoff        time.Duration `mapstructure:"backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// LeaderElection birden fazla replikada sadece Lease'i tutan replikanın bağlaması için lider seçimi ayarları
+	LeaderElection LeaderElectionConfig `mapstructure:"leader_election"`
+}
+
+// LeaderElectionConfig coordination.k8s.io Lease ile lider seçimi ayarları. Lider LeaseDuration boyunca Lease'i
+// tutar, RenewDeadline içinde yenileyemezse liderliği bırakır; diğer replikalar RetryPeriod aralıkla dener
+type LeaderElectionConfig struct {
+	Namespace     string        `mapstructure:"namespace"`
+	Name          string        `mapstructure:"name"`
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+	RenewDeadline time.Duration `mapstructure:"renew_deadline"`
+	RetryPeriod   time.Duration `mapstructure:"retry_period"`
+}
+
+// ComparisonConfig varsayılan scheduler ile yan yana karşılaştırma (benchmark modu) ayarları. Diğer scheduler'ın
+// bağladığı her pod için AI'nın seçeceği node hesaplanır, pod Window boyunca izlenip sonucu etiketlenir
+type ComparisonConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// SchedulerNames karşılaştırılan pod'ların spec.schedulerName değerleri (boş = default-scheduler)
+	SchedulerNames []string      `mapstructure:"scheduler_names"`
+	Window         time.Duration `mapstructure:"window"`
+	MaxPending     int           `mapstructure:"max_pending"`
+	HistorySize    int           `mapstructure:"history_size"`
+	// MinSamples karar (verdict) üretmek için gereken en az ayrışan karşılaştırma
+	MinSamples int `mapstructure:"min_samples"`
+}
+
+// TemplateHistoryConfig pod şablonu geçmişi ayarları. Etiketlenen karar sonuçları (scheduler.outcomes) şablonun
+// içerik özetine (imajlar, kaynaklar, probe'lar) göre node ve node özelliği (zone, instance tipi, kernel, runtime vb.)
+// bazında biriktirilir; özellikli node'lardaki başarısızlık oranı diğerlerini belirgin aşan özellikler şüpheli sayılır
+// ve şablonun node'daki geçmişi AI özelliklerine eklenir
+type TemplateHistoryConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxTemplates tutulan en fazla şablon, dolunca en uzun süredir sonuç görülmeyen silinir
+	MaxTemplates int `mapstructure:"max_templates"`
+	// MinSamples bir özelliğin şüpheli sayılması için gereken en az yerleşim
+	MinSamples int `mapstructure:"min_samples"`
+}
+
+// AuditConfig karar denetimi ayarları. AI harmanlaması kullanılmadan verilen kararlar SampleRate olasılıkla aday
+// node'ların özellikleriyle birlikte örneklenir ve Interval aralıkla en fazla BatchSize örnek AI'ya gönderilir.
+// AI'nın önerdiği node'un heuristik seçimle uyuşma oranı son Window eleştiri üzerinden hesaplanır
+type AuditConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	SampleRate float64       `mapstructure:"sample_rate"`
+	Interval   time.Duration `mapstructure:"interval"`
+	BatchSize  int           `mapstructure:"batch_size"`
+	// MaxPending gönderilmeyi bekleyen en fazla örnek, dolunca en eskiler atılır
+	MaxPending int `mapstructure:"max_pending"`
+	// Candidates örneğe yazılan en yüksek skorlu aday node sayısı
+	Candidates int `mapstructure:"candidates"`
+	Window     int `mapstructure:"window"`
+}
+
+// RemediationConfig kararsız node'ların taint ile işaretlenmesi ayarları. Kararlılık skoru en az Duration boyunca
+// StabilityThreshold'un altında kalan (ve en az MinPods örneği olan) node için TaintKey anahtarlı PreferNoSchedule
+// taint'i önerilir; Mode "apply" ise taint Kubernetes API ile node'a eklenir. Skor RecoveryThreshold'a ulaşınca taint
+// kaldırılır ve öneri kapanır. Aynı anda taint'li node oranı MaxTaintedRatio'yu aşamaz
+type RemediationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Mode "propose" (sadece öneri, varsayılan) veya "apply"
+	Mode               string        `mapstructure:"mode"`
+	StabilityThreshold float64       `mapstructure:"stability_threshold"`
+	RecoveryThreshold  float64       `mapstructure:"recovery_threshold"`
+	Duration           time.Duration `mapstructure:"duration"`
+	Interval           time.Duration `mapstructure:"interval"`
+	MinPods            int           `mapstructure:"min_pods"`
+	TaintKey           string        `mapstructure:"taint_key"`
+	MaxTaintedRatio    float64       `mapstructure:"max_tainted_ratio"`
+}
+
+// AdmissionConfig tahmin isteklerini kabul eden limiter ayarları. Pod fırtınalarında AI backend'ini korumak için
+// aynı anda en fazla MaxInFlight tahmin yapılır, fazlası kuyrukta bekler; kuyruk doluysa 429 ve Retry-After döner
+type AdmissionConfig struct {
+	Enabled     bool `mapstructure:"enabled"`
+	MaxInFlight int  `mapstructure:"max_in_flight"`
+	// MaxQueue bekleyebilecek en fazla istek, negatifse kuyruk yok (slot yoksa hemen reddedilir)
+	MaxQueue int `mapstructure:"max_queue"`
+	// MaxQueuePerNamespace bir namespace'in kuyruktaki en fazla isteği, 0 ise sınır yok
+	MaxQueuePerNamespace int           `mapstructure:"max_queue_per_namespace"`
+	QueueTimeout         time.Duration `mapstructure:"queue_timeout"`
+}
+
+// FragmentationConfig kaynak parçalanması ayarları. Node'un boş kapasitesinin hiçbir pod şekline sığmayan kısmı
+// parçalanmış sayılır; yerleşim sonrası parçalanma oranı Weight ile çarpılarak skordan düşülür
+type FragmentationConfig struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+	// ReferenceShapes bekleyen pod'lara ek olarak her zaman dikkate alınan tipik pod şekilleri
+	ReferenceShapes []PodShapeConfig `mapstructure:"reference_shapes"`
+	// MaxShapes bekleyen pod'lardan alınan en fazla şekil sayısı (en sık görülenler)
+	MaxShapes int `mapstructure:"max_shapes"`
+}
+
+// PodShapeConfig pod'un kaynak isteği şekli
+type PodShapeConfig struct {
+	CPU    float64 `mapstructure:"cpu"`    // core
+	Memory float64 `mapstructure:"memory"` // GB
+}
+
+// GuardrailsConfig filtrelemede uygulanan node başına yerleşim sınırları, 0 olan sınır kapalıdır.
+// Node'un status.allocatable.pods sınırı bu ayarlardan bağımsız olarak her zaman uygulanır
+type GuardrailsConfig struct {
+	// MaxPodsPerWorkload aynı iş yükünün (Deployment, StatefulSet vb.) bir node'daki en fazla pod sayısı
+	MaxPodsPerWorkload int `mapstructure:"max_pods_per_workload"`
+	// MaxPlacementsPerNode RateWindow içinde bir node'a yerleştirilebilecek en fazla pod sayısı
+	MaxPlacementsPerNode int           `mapstructure:"max_placements_per_node"`
+	RateWindow           time.Duration `mapstructure:"rate_window"`
+}
+
+// ScoreSmoothingConfig skor yumuşatma ayarları. Pod strateji ipucuyla hesaplanan skorlar yumuşatılmaz
+type ScoreSmoothingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// HalfLife yeni ham skorun ağırlığının yarıya ulaştığı süre, uzadıkça skorlar daha yavaş değişir
+	HalfLife time.Duration `mapstructure:"half_life"`
+	// Hysteresis yumuşatılmış skorun bundan küçük değişimleri uygulanmaz (skor puanı), 0 ise kapalı
+	Hysteresis float64 `mapstructure:"hysteresis"`
+}
+
+// CooldownConfig node cooldown ayarları. Başarısızlıklar karar-sonuç eşleştirmesinden (outcomes) alınır
+type CooldownConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Failures Window içinde cooldown'u başlatan başarısız (Failed veya restart) pod sayısı
+	Failures int           `mapstructure:"failures"`
+	Window   time.Duration `mapstructure:"window"`
+	// Duration cooldown süresi, dolunca node kendiliğinden geri döner
+	Duration time.Duration `mapstructure:"duration"`
+	// Mode "exclude" (node filtrede elenir) veya "penalize" (skordan Penalty düşülür)
+	Mode    string  `mapstructure:"mode"`
+	Penalty float64 `mapstructure:"penalty"`
+}
+
+// VolumeLimitsConfig CSI volume bağlama sınırı ayarları. Sınırlar CSINode'lardan (spec.drivers[].allocatable.count),
+// yoksa node allocatable'daki attachable-volumes-csi-<sürücü> kaynaklarından okunur
+type VolumeLimitsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// StalenessConfig skorlama girdisi tazelik denetimi ayarları
+type StalenessConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxAge girdinin son güncellemesinden sonra bayat sayılacağı süre
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// Action "flag" (karar bayat girdiler ve düşürülmüş güvenle işaretlenir) veya "reject" (bayat girdili node'lar
+	// aday olmaz, aday kalmazsa tahmin reddedilir)
+	Action string `mapstructure:"action"`
+}
+
+// WindowsScoringConfig Windows node'larına özgü skorlama ayarları
+type WindowsScoringConfig struct {
+	// OSTaintKeys işletim sistemi ayırma taint'lerinin anahtarları (ör: os=windows:NoSchedule).
+	// Filtreden geçen pod bu taint'leri tolere ettiğinden taint bileşeninde "taint var" sayılmazlar
+	OSTaintKeys []string `mapstructure:"os_taint_keys"`
+	// MemoryHeadroom Windows bellek overcommit desteklemediğinden ayrılabilir belleğin bu oranı kullanılamaz kabul edilir (0-0.5)
+	MemoryHeadroom float64 `mapstructure:"memory_headroom"`
+}
+
+// SchedulingProfile adlandırılmış skorlama profili. Etkin zamanlanmış politika profilin üzerine uygulanır
+type SchedulingProfile struct {
+	Name string `mapstructure:"name"`
+	// Strategy boşsa temel strateji korunur
+	Strategy string `mapstructure:"strategy"`
+	// Scoring tanımlıysa tüm skorlama ağırlıklarının (ve otomatik ayarlı ağırlıkların) yerini alır
+	Scoring *ScoringConfig `mapstructure:"scoring"`
+}
+
+// ScheduledPolicy cron ifadesiyle başlayıp Duration boyunca geçerli olan skorlama politikası.
+// Zamanlar temporal.timezone saat diliminde değerlendirilir
+type ScheduledPolicy struct {
+	Name     string        `mapstructure:"name"`
+	Schedule string        `mapstructure:"schedule"` // 5 alanlı cron ifadesi (ör: "0 22 * * *")
+	Duration time.Duration `mapstructure:"duration"`
+	// Strategy boşsa temel strateji korunur
+	Strategy string `mapstructure:"strategy"`
+	// Scoring tanımlıysa tüm skorlama ağırlıklarının yerini alır
+	Scoring *ScoringConfig `mapstructure:"scoring"`
+}
+
+// PodHintsConfig uygulama ekiplerinin pod annotation'larıyla verebildiği tercihlerin sınırları
+type PodHintsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Namespaces ipuçlarının dikkate alındığı namespace'ler (boş include = hepsi)
+	Namespaces NamespaceFilter `mapstructure:"namespaces"`
+	// Penalty kaçınılan veya kararlılık alt sınırının altındaki node'ların skorundan düşülür
+	Penalty float64 `mapstructure:"penalty"`
+	// MaxAvoidNodes avoid-nodes listesinde dikkate alınan en fazla desen
+	MaxAvoidNodes int `mapstructure:"max_avoid_nodes"`
+}
+
+// TemporalConfig AI modeline gönderilen zaman bazlı özelliklerin ayarları
+type TemporalConfig struct {
+	// Timezone iş yüklerinin bağlı olduğu IANA saat dilimi (ör: Europe/Istanbul), boşsa sunucunun yerel saat dilimi
+	Timezone string `mapstructure:"timezone"`
+	// WeekendDays hafta sonu sayılan günler (ör: saturday, sunday)
+	WeekendDays []string `mapstructure:"weekend_days"`
+	// Holidays tatil günleri (YYYY-MM-DD, saat diliminde)
+	Holidays []string `mapstructure:"holidays"`
+}
+
+// ForecastConfig node kullanım tahmini ayarları
+type ForecastConfig struct {
+	// Enabled tahmini skorlamada kullanır: horizon içinde yoğunlaşması beklenen node'lar cezalandırılır
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+	// Horizon skorlamadaki ve API'deki varsayılan tahmin süresi
+	Horizon time.Duration `mapstructure:"horizon"`
+	// HotThreshold node'un yoğun sayıldığı tahmini CPU/memory kullanım oranı (0-1)
+	HotThreshold float64 `mapstructure:"hot_threshold"`
+	// UseAI API tahminini önce AI servisinden ister, hata olursa Holt-Winters kullanılır
+	UseAI bool `mapstructure:"use_ai"`
+	// Season Holt-Winters mevsimsellik dönemi (ör: 24h günlük döngü)
+	Season time.Duration `mapstructure:"season"`
+	// Alpha, Beta, Gamma Holt-Winters seviye, eğilim ve mevsimsellik katsayıları (0-1)
+	Alpha float64 `mapstructure:"alpha"`
+	Beta  float64 `mapstructure:"beta"`
+	Gamma float64 `mapstructure:"gamma"`
+}
+
+// SeasonalityConfig node kullanımındaki dönemsel düzenin ayarları. Dönem metrics.history geçmişinin Periods
+// adayları arasından otokorelasyonla seçilir; en az MinCycles tam dönem ve MinStrength otokorelasyon gerekir.
+// Pod'un tahmini yaşam süresi boyunca beklenen tepe kullanım HotThreshold'u aşarsa Weight ve döngünün gücüyle
+// orantılı ceza verilir
+type SeasonalityConfig struct {
+	Enabled      bool            `mapstructure:"enabled"`
+	Weight       float64         `mapstructure:"weight"`
+	Periods      []time.Duration `mapstructure:"periods"`
+	MinCycles    int             `mapstructure:"min_cycles"`
+	MinStrength  float64         `mapstructure:"min_strength"`
+	HotThreshold float64         `mapstructure:"hot_threshold"`
+	// BatchLifetime Job pod'larının, LongLivedLifetime controller'lı (Deployment, StatefulSet, DaemonSet) pod'ların,
+	// DefaultLifetime diğerlerinin tahmini yaşam süresi. ai-scheduler/expected-lifetime annotation'ı ve
+	// activeDeadlineSeconds önceliklidir
+	BatchLifetime     time.Duration `mapstructure:"batch_lifetime"`
+	LongLivedLifetime time.Duration `mapstructure:"long_lived_lifetime"`
+	DefaultLifetime   time.Duration `mapstructure:"default_lifetime"`
+}
+
+// FairnessConfig takım bazlı adil paylaşım ayarları
+type FairnessConfig struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Weight  float64 `mapstructure:"weight"`
+	// Teams takım -> namespace listesi, eşleşmeyen namespace kendi takımı sayılır
+	Teams map[string][]string `mapstructure:"teams"`
+	// DefaultShare takımın küme kapasitesindeki varsayılan payı (0-1)
+	DefaultShare float64            `mapstructure:"default_share"`
+	Shares       map[string]float64 `mapstructure:"shares"`
+	// ContentionThreshold node'un çekişmeli sayıldığı CPU/memory kullanım oranı (0-1)
+	ContentionThreshold float64 `mapstructure:"contention_threshold"`
+}
+
+// NamespaceFilter namespace kapsamı (glob desenleri desteklenir, exclude önceliklidir)
+type NamespaceFilter struct {
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
+}
+
+// Matches namespace kapsam içindeyse true döner, include boşsa tüm namespace'ler dahildir
+func (f NamespaceFilter) Matches(namespace string) bool {
+	for _, pattern := range f.Exclude {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, pattern := range f.Include {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
 }
 
 // ScoringConfig skorlama ağırlıkları
@@ -73,11 +1056,266 @@ type MonitoringConfig struct {
 	HealthCheck     bool `mapstructure:"health_check"`
 	MetricsEndpoint bool `mapstructure:"metrics_endpoint"`
 	Prometheus      bool `mapstructure:"prometheus"`
+	// ExternalMetrics HPA'lar için Kubernetes external metrics API'si
+	ExternalMetrics ExternalMetricsConfig `mapstructure:"external_metrics"`
+}
+
+// ExternalMetricsConfig external metrics API (external.metrics.k8s.io) ayarları
+type ExternalMetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ForecastHorizon tahmin metriklerinin kapsadığı süre, 0 ise scheduler.forecast.horizon kullanılır
+	ForecastHorizon time.Duration `mapstructure:"forecast_horizon"`
+	// ClientCAFile aggregator'ın front-proxy istemci sertifikasını imzalayan CA; boşsa kube-system'deki
+	// extension-apiserver-authentication ConfigMap'inden okunur
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// AllowedNames kabul edilen istemci sertifikası ortak adları; boşsa ConfigMap'teki requestheader-allowed-names
+	AllowedNames []string `mapstructure:"allowed_names"`
 }
 
 // DevelopmentConfig development ayarları
 type DevelopmentConfig struct {
-	Debug     bool `mapstructure:"debug"`
-	HotReload bool `mapstructure:"hot_reload"`
-	MockData  bool `mapstructure:"mock_data"`
+	Debug     bool                   `mapstructure:"debug"`
+	HotReload bool                   `mapstructure:"hot_reload"`
+	MockData  bool                   `mapstructure:"mock_data"`
+	Synthetic SyntheticClusterConfig `mapstructure:"synthetic"`
+	Trace     TraceConfig            `mapstructure:"trace"`
+}
+
+// TraceConfig metrik ve tahmin girdilerinin dosyaya kaydı ayarları
+type TraceConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	File    string `mapstructure:"file"`
+}
+
+// SyntheticClusterConfig mock modda üretilen sentetik küme ayarları
+type SyntheticClusterConfig struct {
+	Nodes        int           `mapstructure:"nodes"`
+	PodsPerNode  int           `mapstructure:"pods_per_node"`
+	PendingPods  int           `mapstructure:"pending_pods"`
+	Namespaces   []string      `mapstructure:"namespaces"`
+	Seed         int64         `mapstructure:"seed"`
+	StepInterval time.Duration `mapstructure:"step_interval"`
+	ChurnRate    float64       `mapstructure:"churn_rate"`
+	// SchedulerName üretilen pod'ların spec.schedulerName'i. Boş değilse bekleyen pod'lar rastgele yerleşmez,
+	// scheduler'ın binding'ini bekler (scheduler.binding ile)
+	SchedulerName string `mapstructure:"scheduler_name"`
+	// NoisyWorkloads kullanımı ara ara patlayıp aynı node'daki pod'ları aç bırakan iş yükleri (app label'ı)
+	NoisyWorkloads []string            `mapstructure:"noisy_workloads"`
+	Failures       FailureInjection    `mapstructure:"failures"`
+	Profiles       []NodeProfileConfig `mapstructure:"profiles"`
+}
+
+// FailureInjection sentetik kümede hata enjeksiyonu olasılıkları (adım başına)
+type FailureInjection struct {
+	PodFailureRate   float64 `mapstructure:"pod_failure_rate"`
+	NodeNotReadyRate float64 `mapstructure:"node_not_ready_rate"`
+	NodeRecoveryRate float64 `mapstructure:"node_recovery_rate"`
+}
+
+// NodeProfileConfig sentetik node profili
+type NodeProfileConfig struct {
+	Name        string  `mapstructure:"name"`
+	Weight      float64 `mapstructure:"weight"`
+	CPU         float64 `mapstructure:"cpu"`
+	MemoryGB    float64 `mapstructure:"memory_gb"`
+	Utilization float64 `mapstructure:"utilization"`
+	// FailureRate ve RestartRate çalışan pod'un adım başına hata ve restart olasılığı (yüzde)
+	FailureRate float64 `mapstructure:"failure_rate"`
+	RestartRate float64 `mapstructure:"restart_rate"`
+	Tainted     bool    `mapstructure:"tainted"`
+	// OS node'ların işletim sistemi (linux veya windows, varsayılan linux). Windows node'larına os=windows:NoSchedule taint'i konur
+	OS string `mapstructure:"os"`
+	// StartupSeconds pod'ların ortalama başlatma süresi (kabul + CNI + image çekme)
+	StartupSeconds float64 `mapstructure:"startup_seconds"`
+}
+
+// ReportsConfig raporlama ayarları
+type ReportsConfig struct {
+	Capacity    CapacityReportConfig `mapstructure:"capacity"`
+	Rightsizing RightsizingConfig    `mapstructure:"rightsizing"`
+}
+
+// CapacityReportConfig kapasite planlama raporu ayarları
+type CapacityReportConfig struct {
+	// PoolLabel ve ZoneLabel node'ları gruplamak için kullanılan label'lar
+	PoolLabel string `mapstructure:"pool_label"`
+	ZoneLabel string `mapstructure:"zone_label"`
+	// HistoryWindow büyüme eğiliminin hesaplandığı geçmiş
+	HistoryWindow time.Duration `mapstructure:"history_window"`
+	// Horizon kullanımın projekte edildiği süre
+	Horizon time.Duration `mapstructure:"horizon"`
+	// TargetUtilization headroom'un hesaplandığı hedef kullanım oranı (0-1)
+	TargetUtilization float64 `mapstructure:"target_utilization"`
+}
+
+// RightsizingConfig pod istek (request) önerileri ayarları
+type RightsizingConfig struct {
+	// CPUPercentile ve MemoryPercentile hedef isteğin dayandığı kullanım yüzdelikleri (0-1)
+	CPUPercentile    float64 `mapstructure:"cpu_percentile"`
+	MemoryPercentile float64 `mapstructure:"memory_percentile"`
+	// SafetyMargin hedef isteğe eklenen pay (0.15 = %15)
+	SafetyMargin float64 `mapstructure:"safety_margin"`
+	// MinCPU ve MinMemoryGB önerilebilecek en düşük istekler
+	MinCPU      float64 `mapstructure:"min_cpu"`
+	MinMemoryGB float64 `mapstructure:"min_memory_gb"`
+	// Tolerance istek hedeften bu oranda farklıysa değişiklik önerilir
+	Tolerance float64 `mapstructure:"tolerance"`
+	// MinSamples bu sayıdan az örneği olan container'ların önerisi düşük güvenli işaretlenir
+	MinSamples int `mapstructure:"min_samples"`
+}
+
+// FederationConfig kümeler arası yerleşim ayarları. Her küme kendi ai-scheduler'ını çalıştırır,
+// federasyon katmanı üye kümelerin yerleşim özetlerini API üzerinden toplayıp hedef kümeyi seçer
+type FederationConfig struct {
+	// ClusterName bu kümenin federasyondaki adı
+	ClusterName string `mapstructure:"cluster_name"`
+	// CostPerCoreHour ve CostPerGBHour bu kümenin birim maliyetleri
+	CostPerCoreHour float64 `mapstructure:"cost_per_core_hour"`
+	CostPerGBHour   float64 `mapstructure:"cost_per_gb_hour"`
+	// Timeout üye kümelere yapılan isteklerin zaman aşımı
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Ağırlıklar küme skorunu oluşturur (toplamları 1 olmak zorunda değil)
+	CapacityWeight  float64 `mapstructure:"capacity_weight"`
+	CostWeight      float64 `mapstructure:"cost_weight"`
+	StabilityWeight float64 `mapstructure:"stability_weight"`
+	NodeWeight      float64 `mapstructure:"node_weight"`
+	// Members diğer kümelerin ai-scheduler API'leri
+	Members []FederationMember `mapstructure:"members"`
+}
+
+// FederationMember federasyondaki uzak küme
+type FederationMember struct {
+	Name            string  `mapstructure:"name"`
+	URL             string  `mapstructure:"url"`   // ai-scheduler API adresi (ör: https://ai-scheduler.eu-west.example.com)
+	Token           string  `mapstructure:"token"` // Bearer token, boşsa gönderilmez
+	CostPerCoreHour float64 `mapstructure:"cost_per_core_hour"`
+	CostPerGBHour   float64 `mapstructure:"cost_per_gb_hour"`
+}
+
+// HandoffConfig replikalar arası durum devri ayarları. Yeni başlayan replika istek almadan önce çalışan bir
+// replikanın karar geçmişini, assume kayıtlarını, pod metrik cache'ini ve node kullanım geçmişini alır
+type HandoffConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Peers durumun alınacağı replikaların API adresleri, sırayla denenir (ör: headless service adresi)
+	Peers []string `mapstructure:"peers"`
+	// Token peer'ların admin yetkili API anahtarı (server.auth açıksa), boşsa gönderilmez
+	Token string `mapstructure:"token"`
+	// Timeout devrin toplam süresi; dolarsa replika boş cache'lerle başlar
+	Timeout time.Duration `mapstructure:"timeout"`
+	// RetryInterval peer'lardan hiçbiri cevap vermezse tekrar deneme aralığı
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+}
+
+// SecretsConfig Kubernetes Secret kaynaklı kimlik bilgisi ayarları
+type SecretsConfig struct {
+	Namespace         string        `mapstructure:"namespace"`
+	RefreshInterval   time.Duration `mapstructure:"refresh_interval"`
+	AIAPIToken        SecretKeyRef  `mapstructure:"ai_api_token"`
+	TLS               SecretKeyRef  `mapstructure:"tls"`
+	WebhookSigningKey SecretKeyRef  `mapstructure:"webhook_signing_key"`
+	IngestToken       SecretKeyRef  `mapstructure:"ingest_token"`
+}
+
+// SecretKeyRef Secret içindeki bir anahtara referans
+type SecretKeyRef struct {
+	Name string `mapstructure:"name"`
+	Key  string `mapstructure:"key"`
+}
+
+// EventBusConfig metrik ve karar olaylarının yayınlandığı olay yolu (Kafka veya NATS) ayarları
+type EventBusConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Backend nats veya kafka (Kafka REST Proxy v2 üzerinden)
+	Backend string `mapstructure:"backend"`
+	// Serialization json (tip/zaman/anahtar zarfı) veya cloudevents (CloudEvents 1.0 structured JSON)
+	Serialization string         `mapstructure:"serialization"`
+	Topics        EventBusTopics `mapstructure:"topics"`
+	// BufferSize yayınlanmayı bekleyen en fazla olay, doluysa yeni olaylar atılır
+	BufferSize int `mapstructure:"buffer_size"`
+	// BatchSize ve FlushInterval olaylar topic başına bu sayıya ulaşınca veya bu sürede bir gönderilir
+	BatchSize     int           `mapstructure:"batch_size"`
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	NATS          NATSConfig    `mapstructure:"nats"`
+	Kafka         KafkaConfig   `mapstructure:"kafka"`
+}
+
+// HistoryStoreConfig zaman sıralı geçmişlerin (pod metrik cache'i snapshot'ı, karar günlüğü) ortak saklama ayarları
+type HistoryStoreConfig struct {
+	// Backend memory, file, bolt, sql veya redis
+	Backend string           `mapstructure:"backend"`
+	File    FileStoreConfig  `mapstructure:"file"`
+	Bolt    BoltStoreConfig  `mapstructure:"bolt"`
+	SQL     SQLStoreConfig   `mapstructure:"sql"`
+	Redis   RedisStoreConfig `mapstructure:"redis"`
+}
+
+// FileStoreConfig dosya backend'i: her akış dizinde "<akış>.jsonl" dosyasıdır
+type FileStoreConfig struct {
+	Dir string `mapstructure:"dir"`
+}
+
+// BoltStoreConfig bbolt backend'i: akışlar tek dosyada bucket'lardır. Dosyayı aynı anda tek süreç açabilir, Timeout
+// kilidin bırakılması için beklenen süredir
+type BoltStoreConfig struct {
+	Path    string        `mapstructure:"path"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// SQLStoreConfig database/sql backend'i. Driver binary'ye eklenmiş bir sürücünün adıdır; "sqlite" gömülüdür ve DSN
+// dosya yoludur
+type SQLStoreConfig struct {
+	Driver  string        `mapstructure:"driver"`
+	DSN     string        `mapstructure:"dsn"`
+	Table   string        `mapstructure:"table"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// RedisStoreConfig Redis backend'i, akışlar Prefix ile başlayan sorted set'lerdir
+type RedisStoreConfig struct {
+	Address  string        `mapstructure:"address"` // host:port
+	Password string        `mapstructure:"password"`
+	DB       int           `mapstructure:"db"`
+	Prefix   string        `mapstructure:"prefix"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// OTLPLogsConfig karar olaylarının OpenTelemetry log kaydı olarak OTLP/HTTP (JSON) ile gönderilmesi
+type OTLPLogsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Endpoint OTLP/HTTP alıcısının adresi (ör: http://otel-collector:4318), yol yoksa /v1/logs eklenir
+	Endpoint string `mapstructure:"endpoint"`
+	// Headers her isteğe eklenen başlıklar (ör: kimlik doğrulama)
+	Headers map[string]string `mapstructure:"headers"`
+	// ServiceName ve ResourceAttributes kayıtların resource'una yazılır. ResourceAttributes OTEL_RESOURCE_ATTRIBUTES
+	// biçimindedir (ör: deployment.environment=production,team=platform)
+	ServiceName        string `mapstructure:"service_name"`
+	ResourceAttributes string `mapstructure:"resource_attributes"`
+	// BufferSize gönderilmeyi bekleyen en fazla karar, doluysa yeni kararlar atılır
+	BufferSize int `mapstructure:"buffer_size"`
+	// BatchSize ve FlushInterval kayıtlar bu sayıya ulaşınca veya bu sürede bir gönderilir
+	BatchSize     int           `mapstructure:"batch_size"`
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+}
+
+// EventBusTopics olay türlerinin yayınlandığı topic (NATS subject) adları
+type EventBusTopics struct {
+	Metrics   string `mapstructure:"metrics"`
+	Decisions string `mapstructure:"decisions"`
+}
+
+// NATSConfig NATS sunucu bağlantısı
+type NATSConfig struct {
+	URL   string `mapstructure:"url"` // nats://host:port
+	Token string `mapstructure:"token"`
+	// User ve Password token yerine kullanıcı/parola ile kimlik doğrulama
+	User     string        `mapstructure:"user"`
+	Password string        `mapstructure:"password"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// KafkaConfig Kafka REST Proxy bağlantısı
+type KafkaConfig struct {
+	RESTURL string        `mapstructure:"rest_url"` // ör: http://kafka-rest:8082
+	Timeout time.Duration `mapstructure:"timeout"`
 }
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Modüller arası hata sınıfları. Hatalar fmt.Errorf("...: %w", Err...) ile sarılarak taşınır; API durum kodları
+// ve hata sayaçları errors.Is ile bu sınıflara göre belirlenir
+var (
+	// ErrNodeNotFound node küme kaynağında yok
+	ErrNodeNotFound = errors.New("node bulunamadı")
+	// ErrPodNotFound pod küme kaynağında veya Kubernetes API'de yok
+	ErrPodNotFound = errors.New("pod bulunamadı")
+	// ErrAIUnavailable AI API'ye ulaşılamadı, hata döndürdü veya anlaşılmayan yanıt verdi
+	ErrAIUnavailable = errors.New("AI API kullanılamıyor")
+	// ErrNoFeasibleNode filtreden geçen node yok
+	ErrNoFeasibleNode = errors.New("pod için uygun node yok")
+	// ErrMetricsStale node'un güncel kullanım metriği yok
+	ErrMetricsStale = errors.New("güncel metrik yok")
+)
+
+// Hata sayaçlarının bileşen etiketleri
+const (
+	ErrorComponentPredict   = "predict"
+	ErrorComponentAI        = "ai"
+	ErrorComponentCollector = "collector"
+	ErrorComponentBinding   = "binding"
+)
+
+// ErrorComponents hata bildiren bileşenler
+var ErrorComponents = []string{ErrorComponentPredict, ErrorComponentAI, ErrorComponentCollector, ErrorComponentBinding}
+
+// Hata sayaçlarının sınıf etiketleri
+const (
+	ErrorClassNodeNotFound   = "node_not_found"
+	ErrorClassPodNotFound    = "pod_not_found"
+	ErrorClassAIUnavailable  = "ai_unavailable"
+	ErrorClassNoFeasibleNode = "no_feasible_node"
+	ErrorClassMetricsStale   = "metrics_stale"
+	ErrorClassTimeout        = "timeout"
+	ErrorClassCanceled       = "canceled"
+	ErrorClassOther          = "other"
+)
+
+// errorClasses sınıflar ve sarılı hataları, ilk eşleşen sınıf kullanılır
+var errorClasses = []struct {
+	err   error
+	class string
+}{
+	{ErrNodeNotFound, ErrorClassNodeNotFound},
+	{ErrPodNotFound, ErrorClassPodNotFound},
+	{ErrAIUnavailable, ErrorClassAIUnavailable},
+	{ErrNoFeasibleNode, ErrorClassNoFeasibleNode},
+	{ErrMetricsStale, ErrorClassMetricsStale},
+	// İsteğin süresinin dolması veya iptali çağrıyı hangi modülde keserse kessin bu sınıflarla sayılır
+	{context.DeadlineExceeded, ErrorClassTimeout},
+	{context.Canceled, ErrorClassCanceled},
+}
+
+// ErrorClasses bilinen hata sınıfları (sayaçların başlangıç serileri için)
+func ErrorClasses() []string {
+	classes := make([]string, 0, len(errorClasses)+1)
+	for _, class := range errorClasses {
+		classes = append(classes, class.class)
+	}
+	return append(classes, ErrorClassOther)
+}
+
+// ErrorClass hatanın sınıf etiketini döndürür, bilinen bir sınıfı sarmayan hatalar "other" sayılır
+func ErrorClass(err error) string {
+	for _, class := range errorClasses {
+		if errors.Is(err, class.err) {
+			return class.class
+		}
+	}
+	return ErrorClassOther
+}
+
+// ErrorSink modüllerin hatalarını dışarıya (Prometheus) iletir. component hatanın oluştuğu bileşendir
+type ErrorSink interface {
+	ObserveError(component string, err error)
+}
+
+// ErrorSinks hata alıcılarının listesi, eşzamanlı kullanılabilir
+type ErrorSinks struct {
+	mutex sync.RWMutex
+	sinks []ErrorSink
+}
+
+// Add alıcıyı ekler
+func (s *ErrorSinks) Add(sink ErrorSink) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sinks = append(s.sinks, sink)
+}
+
+// ObserveError hatayı tüm alıcılara iletir
+func (s *ErrorSinks) ObserveError(component string, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, sink := range s.sinks {
+		sink.ObserveError(component, err)
+	}
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// Olay türleri
+const (
+	EventNodeMetrics = "node_metrics"
+	EventPodMetrics  = "pod_metrics"
+	EventDecision    = "decision"
+)
+
+// Event dış sistemlere yayınlanan metrik veya karar olayı
+type Event struct {
+	Kind string
+	Time time.Time
+	Key  string // Bölümleme anahtarı (ör: node adı, namespace/pod)
+	Data interface{}
+}
+
+// EventPublisher olayları dış sistemlere iletir (ör: Kafka/NATS olay yolu).
+// Publish çağıranı bekletmemelidir
+type EventPublisher interface {
+	Publish(event Event)
+}
+
+// EventPublishers olayı sırayla tüm yayıncılara iletir (ör: olay yolu ve OTLP log exporter'ı)
+type EventPublishers []EventPublisher
+
+// Publish olayı her yayıncıya iletir
+func (p EventPublishers) Publish(event Event) {
+	for _, publisher := range p {
+		publisher.Publish(event)
+	}
+}
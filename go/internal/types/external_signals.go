@@ -0,0 +1,188 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ExternalSignalFeaturePrefix AI özelliklerinde dış sinyallerin ön eki
+const ExternalSignalFeaturePrefix = "ext_"
+
+// Dış sinyallerin varsayılanları ve sınırları
+const (
+	defaultExternalSignalTTL      = 5 * time.Minute
+	defaultMaxSignalsPerNode      = 32
+	maxExternalSignalNameLength   = 63
+	maxExternalSignalSourceLength = 63
+)
+
+// ErrInvalidExternalSignal dış sinyal adı veya değeri geçersiz
+var ErrInvalidExternalSignal = errors.New("geçersiz dış sinyal")
+
+// externalSignalName sinyal adları özellik anahtarına dönüştüğü için küçük harf, rakam ve alt çizgiyle sınırlıdır
+var externalSignalName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ExternalSignal dış ajanın (edge cihazı, özel exporter, sentetik probe) node için gönderdiği sinyal
+type ExternalSignal struct {
+	Name      string    `json:"name"`
+	Value     float64   `json:"value"`
+	Source    string    `json:"source"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ExternalSignalStore node başına en son gönderilen dış sinyalleri TTL süresince tutar
+type ExternalSignalStore struct {
+	mutex      sync.RWMutex
+	nodes      map[string]map[string]ExternalSignal
+	ttl        time.Duration
+	maxPerNode int
+}
+
+// NewExternalSignalStore yeni dış sinyal deposu oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewExternalSignalStore(ingestConfig *IngestConfig) *ExternalSignalStore {
+	s := &ExternalSignalStore{nodes: make(map[string]map[string]ExternalSignal)}
+	s.Configure(ingestConfig)
+	return s
+}
+
+// Configure TTL ve node başına sinyal sınırını değiştirir, mevcut sinyaller korunur
+func (s *ExternalSignalStore) Configure(ingestConfig *IngestConfig) {
+	ttl := ingestConfig.TTL
+	if ttl <= 0 {
+		ttl = defaultExternalSignalTTL
+	}
+	maxPerNode := ingestConfig.MaxSignalsPerNode
+	if maxPerNode <= 0 {
+		maxPerNode = defaultMaxSignalsPerNode
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.ttl = ttl
+	s.maxPerNode = maxPerNode
+}
+
+// TTL sinyallerin geçerlilik süresini döndürür
+func (s *ExternalSignalStore) TTL() time.Duration {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.ttl
+}
+
+// ValidateExternalSignal sinyal adının ve değerinin kabul edilebilir olduğunu doğrular
+func ValidateExternalSignal(name string, value float64) error {
+	if len(name) > maxExternalSignalNameLength || !externalSignalName.MatchString(name) {
+		return fmt.Errorf("%w: ad %q küçük harf, rakam ve alt çizgiden oluşmalı (en fazla %d karakter)", ErrInvalidExternalSignal, name, maxExternalSignalNameLength)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("%w: %s değeri sonlu olmalı", ErrInvalidExternalSignal, name)
+	}
+	return nil
+}
+
+// Put node'un sinyallerini günceller. Aynı addaki eski sinyalin yerini alır; node'un sinyal sınırı
+// süresi dolmamış sinyallerle aşılıyorsa yeni adlar reddedilir ve reddedilen adlar döner
+func (s *ExternalSignalStore) Put(nodeName, source string, values map[string]float64, at time.Time) ([]string, error) {
+	if len(source) > maxExternalSignalSourceLength {
+		return nil, fmt.Errorf("%w: kaynak adı en fazla %d karakter olabilir", ErrInvalidExternalSignal, maxExternalSignalSourceLength)
+	}
+	for name, value := range values {
+		if err := ValidateExternalSignal(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	signals := s.nodes[nodeName]
+	if signals == nil {
+		signals = make(map[string]ExternalSignal, len(values))
+		s.nodes[nodeName] = signals
+	}
+	s.expireLocked(signals, at)
+
+	// Deterministik sınır uygulaması için adlar sıralı işlenir
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rejected []string
+	for _, name := range names {
+		previous, exists := signals[name]
+		if !exists && len(signals) >= s.maxPerNode {
+			rejected = append(rejected, name)
+			continue
+		}
+		// Sıra dışı gelen eski örnek yenisinin üzerine yazılmaz
+		if exists && previous.Timestamp.After(at) {
+			continue
+		}
+		signals[name] = ExternalSignal{Name: name, Value: values[name], Source: source, Timestamp: at}
+	}
+	return rejected, nil
+}
+
+// expireLocked node'un süresi dolan sinyallerini siler, kilit tutulurken çağrılır
+func (s *ExternalSignalStore) expireLocked(signals map[string]ExternalSignal, now time.Time) {
+	for name, signal := range signals {
+		if now.Sub(signal.Timestamp) > s.ttl {
+			delete(signals, name)
+		}
+	}
+}
+
+// Get node'un now anında geçerli sinyallerini ada göre sıralı döndürür
+func (s *ExternalSignalStore) Get(nodeName string, now time.Time) []ExternalSignal {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]ExternalSignal, 0, len(s.nodes[nodeName]))
+	for _, signal := range s.nodes[nodeName] {
+		if now.Sub(signal.Timestamp) <= s.ttl {
+			result = append(result, signal)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Nodes now anında geçerli sinyali olan tüm node'ların sinyallerini döndürür
+func (s *ExternalSignalStore) Nodes(now time.Time) map[string][]ExternalSignal {
+	s.mutex.RLock()
+	names := make([]string, 0, len(s.nodes))
+	for name := range s.nodes {
+		names = append(names, name)
+	}
+	s.mutex.RUnlock()
+
+	result := make(map[string][]ExternalSignal, len(names))
+	for _, name := range names {
+		if signals := s.Get(name, now); len(signals) > 0 {
+			result[name] = signals
+		}
+	}
+	return result
+}
+
+// Prune süresi dolan sinyalleri ve sinyali kalmayan node'ları siler
+func (s *ExternalSignalStore) Prune(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for name, signals := range s.nodes {
+		s.expireLocked(signals, now)
+		if len(signals) == 0 {
+			delete(s.nodes, name)
+		}
+	}
+}
@@ -3,20 +3,31 @@ package types
 import (
 	"os"
 	"path/filepath"
+	"sync"
 
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // K8sClient Kubernetes client wrapper
 type K8sClient struct {
-	Clientset *kubernetes.Clientset
+	Clientset kubernetes.Interface
 	Config    *rest.Config
+	// MetricsClientset doğrudan verilen metrics clientset (ör: fake), boşsa Config'ten oluşturulur
+	MetricsClientset metricsv1beta1.Interface
+
+	informerOnce    sync.Once
+	informerFactory informers.SharedInformerFactory
 }
 
+// protobufContentType Kubernetes API'nin protobuf kodlaması
+const protobufContentType = "application/vnd.kubernetes.protobuf"
+
 // NewK8sClient yeni Kubernetes client oluşturur
-func NewK8sClient() (*K8sClient, error) {
+func NewK8sClient(kubernetesConfig *KubernetesConfig) (*K8sClient, error) {
 	var config *rest.Config
 	var err error
 
@@ -39,6 +50,13 @@ func NewK8sClient() (*K8sClient, error) {
 		}
 	}
 
+	// Protobuf: built-in tipler için list/watch trafiği JSON'a göre çok daha ucuz kodlanır,
+	// protobuf desteklemeyen yanıtlar için JSON kabul edilmeye devam eder
+	if kubernetesConfig != nil && kubernetesConfig.Protobuf {
+		config.ContentType = protobufContentType
+		config.AcceptContentTypes = protobufContentType + ",application/json"
+	}
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -50,7 +68,29 @@ func NewK8sClient() (*K8sClient, error) {
 	}, nil
 }
 
+// NewK8sClientFromClientset verilen clientset'lerle client oluşturur (ör: k8s.io/client-go/kubernetes/fake)
+// metricsClientset nil olabilir, bu durumda node/pod kullanım metrikleri alınamaz
+func NewK8sClientFromClientset(clientset kubernetes.Interface, metricsClientset metricsv1beta1.Interface) *K8sClient {
+	return &K8sClient{
+		Clientset:        clientset,
+		MetricsClientset: metricsClientset,
+	}
+}
+
 // GetClientset clientset'i döndürür
-func (k *K8sClient) GetClientset() *kubernetes.Clientset {
+func (k *K8sClient) GetClientset() kubernetes.Interface {
 	return k.Clientset
 }
+
+// InformerFactory tüm bileşenlerin paylaştığı informer factory'yi döndürür, clientset yoksa nil
+// Informer'lar factory.Start çağrılana kadar çalışmaz; aynı tip için tek watch açılır
+func (k *K8sClient) InformerFactory() informers.SharedInformerFactory {
+	if k == nil || k.Clientset == nil {
+		return nil
+	}
+
+	k.informerOnce.Do(func() {
+		k.informerFactory = informers.NewSharedInformerFactory(k.Clientset, 0)
+	})
+	return k.informerFactory
+}
@@ -0,0 +1,172 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Gecikme matrisinin varsayılanları
+const (
+	defaultLatencyMaxAge    = 10 * time.Minute
+	defaultLatencySmoothing = 0.3
+	maxLatencyRTTMs         = 60000.0
+)
+
+// ErrInvalidLatency geçersiz node'lar arası gecikme ölçümü
+var ErrInvalidLatency = errors.New("geçersiz gecikme ölçümü")
+
+// NodeLatency iki node arasındaki ölçülen gidiş-dönüş gecikmesi
+type NodeLatency struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	LatencyMs float64   `json:"latency_ms"` // Ölçümlerin üstel ortalaması
+	Samples   int       `json:"samples"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// nodePair yönlü node çifti
+type nodePair struct {
+	from, to string
+}
+
+// NodeLatencyMatrix probe DaemonSet'i veya mesh verisinden gelen node'lar arası gecikmeleri tutar.
+// Ölçümler yönlüdür, ters yöndeki ölçüm sadece doğrudan ölçüm yoksa kullanılır
+type NodeLatencyMatrix struct {
+	mutex     sync.RWMutex
+	pairs     map[nodePair]NodeLatency
+	maxAge    time.Duration
+	smoothing float64
+}
+
+// NewNodeLatencyMatrix yeni gecikme matrisi oluşturur
+func NewNodeLatencyMatrix(latencyConfig *LatencyMatrixConfig) *NodeLatencyMatrix {
+	m := &NodeLatencyMatrix{pairs: make(map[nodePair]NodeLatency)}
+	m.Configure(latencyConfig)
+	return m
+}
+
+// Configure ölçümlerin geçerlilik süresini ve yumuşatma katsayısını değiştirir
+func (m *NodeLatencyMatrix) Configure(latencyConfig *LatencyMatrixConfig) {
+	maxAge := latencyConfig.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultLatencyMaxAge
+	}
+	smoothing := latencyConfig.Smoothing
+	if smoothing <= 0 || smoothing > 1 {
+		smoothing = defaultLatencySmoothing
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.maxAge = maxAge
+	m.smoothing = smoothing
+}
+
+// MaxAge ölçümlerin geçerlilik süresini döndürür
+func (m *NodeLatencyMatrix) MaxAge() time.Duration {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.maxAge
+}
+
+// ValidateLatency ölçümün kaydedilebilir olup olmadığını kontrol eder
+func ValidateLatency(from, to string, rttMs float64) error {
+	if from == "" || to == "" {
+		return fmt.Errorf("%w: node adı boş", ErrInvalidLatency)
+	}
+	if from == to {
+		return fmt.Errorf("%w: node kendisine ölçülemez", ErrInvalidLatency)
+	}
+	if math.IsNaN(rttMs) || math.IsInf(rttMs, 0) || rttMs < 0 || rttMs > maxLatencyRTTMs {
+		return fmt.Errorf("%w: gecikme 0-%.0fms aralığında olmalı", ErrInvalidLatency, maxLatencyRTTMs)
+	}
+	return nil
+}
+
+// Observe from node'undan to node'una ölçülen gecikmeyi kaydeder. Süresi dolmamış önceki değer üstel ortalamayla yumuşatılır
+func (m *NodeLatencyMatrix) Observe(from, to string, rttMs float64, at time.Time) error {
+	if err := ValidateLatency(from, to, rttMs); err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := nodePair{from: from, to: to}
+	entry, ok := m.pairs[key]
+	if !ok || at.Sub(entry.UpdatedAt) > m.maxAge {
+		entry = NodeLatency{From: from, To: to, LatencyMs: rttMs}
+	} else if at.Before(entry.UpdatedAt) {
+		// Sıra dışı gelen eski ölçüm yeni değeri bozmamalı
+		return nil
+	} else {
+		entry.LatencyMs += m.smoothing * (rttMs - entry.LatencyMs)
+	}
+	entry.Samples++
+	entry.UpdatedAt = at
+	m.pairs[key] = entry
+	return nil
+}
+
+// Set ölçümü yumuşatmadan ve güncelleme zamanını koruyarak kaydeder (ör: replay'de kaydedilen matris)
+func (m *NodeLatencyMatrix) Set(latency NodeLatency) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.pairs[nodePair{from: latency.From, to: latency.To}] = latency
+}
+
+// Latency iki node arasındaki gecikmeyi döndürür, aynı node için 0. Ölçüm yoksa veya süresi dolduysa false
+func (m *NodeLatencyMatrix) Latency(from, to string, now time.Time) (float64, bool) {
+	if from == to {
+		return 0, true
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, key := range []nodePair{{from: from, to: to}, {from: to, to: from}} {
+		if entry, ok := m.pairs[key]; ok && now.Sub(entry.UpdatedAt) <= m.maxAge {
+			return entry.LatencyMs, true
+		}
+	}
+	return 0, false
+}
+
+// Prune süresi dolan ölçümleri siler
+func (m *NodeLatencyMatrix) Prune(now time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for key, entry := range m.pairs {
+		if now.Sub(entry.UpdatedAt) > m.maxAge {
+			delete(m.pairs, key)
+		}
+	}
+}
+
+// Snapshot süresi dolmamış ölçümleri kaynak ve hedef node adına göre sıralı döndürür
+func (m *NodeLatencyMatrix) Snapshot(now time.Time) []NodeLatency {
+	m.mutex.RLock()
+	result := make([]NodeLatency, 0, len(m.pairs))
+	for _, entry := range m.pairs {
+		if now.Sub(entry.UpdatedAt) <= m.maxAge {
+			result = append(result, entry)
+		}
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].From != result[j].From {
+			return result[i].From < result[j].From
+		}
+		return result[i].To < result[j].To
+	})
+	return result
+}
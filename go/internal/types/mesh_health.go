@@ -0,0 +1,164 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Ağ sağlığı hesaplamasının varsayılanları
+const (
+	defaultMeshLatencyDegradation = 3.0
+	defaultMeshErrorRateThreshold = 0.05
+	defaultMeshMinRequestRate     = 1.0
+)
+
+// MeshPodTraffic pod'un mesh telemetrisinden okunan gelen trafiği
+type MeshPodTraffic struct {
+	NodeName    string
+	RequestRate float64 // İstek/saniye
+	ErrorRate   float64 // Hatalı istek/saniye
+	LatencyMs   float64 // p99 gecikme, ölçülemediyse NaN
+}
+
+// NodeMeshHealth node'daki pod'ların gelen trafiği ve buna göre hesaplanan ağ sağlığı
+type NodeMeshHealth struct {
+	NodeName    string  `json:"node_name"`
+	Pods        int     `json:"pods"`
+	RequestRate float64 `json:"request_rate"`
+	ErrorRate   float64 `json:"error_rate"`     // Hatalı isteklerin oranı (0-1)
+	LatencyMs   float64 `json:"latency_p99_ms"` // Pod p99 gecikmelerinin istek ağırlıklı ortalaması
+	// Health 0-1, 1 sağlıklı. Trafiği yetersiz node'lar ölçülmez ve 1 kabul edilir
+	Health   float64 `json:"health"`
+	Measured bool    `json:"measured"`
+}
+
+// MeshHealthTracker son mesh toplamasının node bazında sonuçlarını tutar
+type MeshHealthTracker struct {
+	mutex   sync.RWMutex
+	nodes   map[string]NodeMeshHealth
+	median  float64 // Ölçülen node'ların medyan gecikmesi
+	updated time.Time
+}
+
+// NewMeshHealthTracker yeni ağ sağlığı takipçisi oluşturur
+func NewMeshHealthTracker() *MeshHealthTracker {
+	return &MeshHealthTracker{nodes: make(map[string]NodeMeshHealth)}
+}
+
+// Record pod trafiğini node'lara göre toplar ve her node'un ağ sağlığını küme medyanına göre hesaplar
+func (t *MeshHealthTracker) Record(traffic []MeshPodTraffic, meshConfig *MeshConfig, now time.Time) {
+	degradation := meshConfig.LatencyDegradation
+	if degradation <= 1 {
+		degradation = defaultMeshLatencyDegradation
+	}
+	errorThreshold := meshConfig.ErrorRateThreshold
+	if errorThreshold <= 0 {
+		errorThreshold = defaultMeshErrorRateThreshold
+	}
+	minRequestRate := meshConfig.MinRequestRate
+	if minRequestRate <= 0 {
+		minRequestRate = defaultMeshMinRequestRate
+	}
+
+	type sums struct {
+		pods                       int
+		requests, errors           float64
+		latencyWeighted, latencyRq float64
+	}
+	byNode := make(map[string]*sums)
+	for _, pod := range traffic {
+		s := byNode[pod.NodeName]
+		if s == nil {
+			s = &sums{}
+			byNode[pod.NodeName] = s
+		}
+		s.pods++
+		s.requests += pod.RequestRate
+		s.errors += pod.ErrorRate
+		if !math.IsNaN(pod.LatencyMs) && !math.IsInf(pod.LatencyMs, 0) && pod.RequestRate > 0 {
+			s.latencyWeighted += pod.LatencyMs * pod.RequestRate
+			s.latencyRq += pod.RequestRate
+		}
+	}
+
+	nodes := make(map[string]NodeMeshHealth, len(byNode))
+	var latencies []float64
+	for nodeName, s := range byNode {
+		node := NodeMeshHealth{NodeName: nodeName, Pods: s.pods, RequestRate: s.requests, Health: 1}
+		if s.requests > 0 {
+			node.ErrorRate = math.Min(1, s.errors/s.requests)
+		}
+		if s.latencyRq > 0 {
+			node.LatencyMs = s.latencyWeighted / s.latencyRq
+		}
+		node.Measured = s.requests >= minRequestRate
+		if node.Measured && s.latencyRq > 0 {
+			latencies = append(latencies, node.LatencyMs)
+		}
+		nodes[nodeName] = node
+	}
+
+	median := 0.0
+	if len(latencies) > 0 {
+		sort.Float64s(latencies)
+		median = latencies[len(latencies)/2]
+		if len(latencies)%2 == 0 {
+			median = (latencies[len(latencies)/2-1] + median) / 2
+		}
+	}
+
+	// Gecikme medyanın degradation katına, hata oranı eşiğe ulaştığında sağlık 0 olur; kötü olan belirler
+	for nodeName, node := range nodes {
+		if !node.Measured {
+			continue
+		}
+		latencyFactor := 0.0
+		if median > 0 && node.LatencyMs > 0 {
+			latencyFactor = (node.LatencyMs/median - 1) / (degradation - 1)
+		}
+		errorFactor := node.ErrorRate / errorThreshold
+		node.Health = 1 - math.Max(0, math.Min(1, math.Max(latencyFactor, errorFactor)))
+		nodes[nodeName] = node
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes = nodes
+	t.median = median
+	t.updated = now
+}
+
+// Clear tüm sonuçları siler (toplama kapatıldığında veya başarısız olduğunda ceza uygulanmaz)
+func (t *MeshHealthTracker) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes = make(map[string]NodeMeshHealth)
+	t.median = 0
+}
+
+// Node node'un son toplamadaki ağ sağlığını döndürür, mesh trafiği yoksa false
+func (t *MeshHealthTracker) Node(nodeName string) (NodeMeshHealth, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	node, ok := t.nodes[nodeName]
+	return node, ok
+}
+
+// Nodes tüm node'ların ağ sağlığını node adına göre sıralı, küme medyan gecikmesi ve son toplama zamanıyla döndürür
+func (t *MeshHealthTracker) Nodes() ([]NodeMeshHealth, float64, time.Time) {
+	t.mutex.RLock()
+	result := make([]NodeMeshHealth, 0, len(t.nodes))
+	for _, node := range t.nodes {
+		result = append(result, node)
+	}
+	median, updated := t.median, t.updated
+	t.mutex.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].NodeName < result[j].NodeName })
+	return result, median, updated
+}
@@ -5,6 +5,7 @@ import "time"
 // NodeMetrics node metrikleri
 type NodeMetrics struct {
 	NodeName    string    `json:"node_name"`
+	OS          string    `json:"os,omitempty"` // kubernetes.io/os (linux, windows)
 	CPUUsage    float64   `json:"cpu_usage"`
 	MemoryUsage float64   `json:"memory_usage"`
 	PodCount    int       `json:"pod_count"`
@@ -21,4 +22,5 @@ type PodMetrics struct {
 	RestartCount int       `json:"restart_count"`
 	CreatedAt    time.Time `json:"created_at"`
 	Timestamp    time.Time `json:"timestamp"`
+	Chaos        bool      `json:"chaos,omitempty"` // Pod veya node'u chaos deneyi altında ya da pod restart fırtınasında Failed oldu, hataları kararlılığa sayılmaz
 }
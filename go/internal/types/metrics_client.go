@@ -5,16 +5,24 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // MetricsClient Kubernetes metrics API client wrapper
 type MetricsClient struct {
-	metricsClient *metricsv1beta1.Clientset
+	metricsClient metricsv1beta1.Interface
 }
 
 // NewMetricsClient yeni metrics client oluşturur
 func NewMetricsClient(k8sClient *K8sClient) (*MetricsClient, error) {
+	// Doğrudan verilen metrics clientset (ör: fake)
+	if k8sClient != nil && k8sClient.MetricsClientset != nil {
+		return &MetricsClient{
+			metricsClient: k8sClient.MetricsClientset,
+		}, nil
+	}
+
 	// Kubernetes client kontrolü
 	if k8sClient == nil || k8sClient.Config == nil {
 		return &MetricsClient{
@@ -22,7 +30,12 @@ func NewMetricsClient(k8sClient *K8sClient) (*MetricsClient, error) {
 		}, nil
 	}
 
-	metricsClient, err := metricsv1beta1.NewForConfig(k8sClient.Config)
+	// Metrics API protobuf desteklemez, Kubernetes client'ı protobuf kullansa bile JSON ile konuşulur
+	metricsConfig := rest.CopyConfig(k8sClient.Config)
+	metricsConfig.ContentType = ""
+	metricsConfig.AcceptContentTypes = ""
+
+	metricsClient, err := metricsv1beta1.NewForConfig(metricsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("metrics client oluşturulamadı: %v", err)
 	}
@@ -32,14 +45,27 @@ func NewMetricsClient(k8sClient *K8sClient) (*MetricsClient, error) {
 	}, nil
 }
 
+// Ping Metrics API'nin node metriği sunduğunu doğrular ve metriği olan node sayısını döndürür
+func (mc *MetricsClient) Ping(ctx context.Context) (int, error) {
+	if mc == nil || mc.metricsClient == nil {
+		return 0, fmt.Errorf("metrics client kullanılamıyor")
+	}
+
+	nodeMetrics, err := mc.metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("node metrics listelenemedi: %v", err)
+	}
+	return len(nodeMetrics.Items), nil
+}
+
 // GetNodeMetrics node'un CPU ve memory kullanımını döndürür
-func (mc *MetricsClient) GetNodeMetrics(nodeName string) (float64, float64, error) {
+func (mc *MetricsClient) GetNodeMetrics(ctx context.Context, nodeName string) (float64, float64, error) {
 	// Metrics client kontrolü
 	if mc == nil || mc.metricsClient == nil {
 		return 0.0, 0.0, fmt.Errorf("metrics client kullanılamıyor")
 	}
 
-	nodeMetrics, err := mc.metricsClient.MetricsV1beta1().NodeMetricses().Get(context.Background(), nodeName, metav1.GetOptions{})
+	nodeMetrics, err := mc.metricsClient.MetricsV1beta1().NodeMetricses().Get(ctx, nodeName, metav1.GetOptions{})
 	if err != nil {
 		return 0, 0, fmt.Errorf("node metrics alınamadı: %v", err)
 	}
@@ -55,13 +81,13 @@ func (mc *MetricsClient) GetNodeMetrics(nodeName string) (float64, float64, erro
 }
 
 // GetPodMetrics pod'un CPU ve memory kullanımını döndürür
-func (mc *MetricsClient) GetPodMetrics(namespace, podName string) (float64, float64, error) {
+func (mc *MetricsClient) GetPodMetrics(ctx context.Context, namespace, podName string) (float64, float64, error) {
 	// Metrics client kontrolü
 	if mc == nil || mc.metricsClient == nil {
 		return 0.0, 0.0, fmt.Errorf("metrics client kullanılamıyor")
 	}
 
-	podMetrics, err := mc.metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	podMetrics, err := mc.metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return 0, 0, fmt.Errorf("pod metrics alınamadı: %v", err)
 	}
@@ -82,3 +108,32 @@ func (mc *MetricsClient) GetNodeCapacity(nodeName string) (float64, float64, err
 	// Metrics API sadece kullanım bilgisi verir
 	return 0, 0, fmt.Errorf("node capacity için normal k8s API kullanılmalı")
 }
+
+// ListPodUsage tüm pod'ların container kullanımlarını tek istekte namespace/name anahtarıyla döndürür
+func (mc *MetricsClient) ListPodUsage(ctx context.Context) (map[string][]ContainerUsage, error) {
+	// Metrics client kontrolü
+	if mc == nil || mc.metricsClient == nil {
+		return nil, fmt.Errorf("metrics client kullanılamıyor")
+	}
+
+	podMetricsList, err := mc.metricsClient.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("pod metrics listelenemedi: %v", err)
+	}
+
+	result := make(map[string][]ContainerUsage, len(podMetricsList.Items))
+	for i := range podMetricsList.Items {
+		podMetrics := &podMetricsList.Items[i]
+		usage := make([]ContainerUsage, len(podMetrics.Containers))
+		for j, container := range podMetrics.Containers {
+			usage[j] = ContainerUsage{
+				Container: container.Name,
+				CPU:       float64(container.Usage.Cpu().MilliValue()) / 1000.0,
+				Memory:    float64(container.Usage.Memory().Value()) / (1024 * 1024 * 1024), // GB
+			}
+		}
+		result[podMetrics.Namespace+"/"+podMetrics.Name] = usage
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NamespaceUsage namespace'in kaynak isteği tüketimi
+type NamespaceUsage struct {
+	Namespace     string  `json:"namespace"`
+	Pods          int     `json:"pods"`
+	CPURequest    float64 `json:"cpu_request"`
+	MemoryRequest float64 `json:"memory_request_gb"`
+}
+
+// NamespaceUsageTracker namespace bazlı tüketimi ve küme kapasitesini tutar
+type NamespaceUsageTracker struct {
+	mutex         sync.RWMutex
+	usage         map[string]NamespaceUsage
+	clusterCPU    float64
+	clusterMemory float64
+	lastUpdated   time.Time
+}
+
+// NewNamespaceUsageTracker yeni tüketim takipçisi oluşturur
+func NewNamespaceUsageTracker() *NamespaceUsageTracker {
+	return &NamespaceUsageTracker{
+		usage: make(map[string]NamespaceUsage),
+	}
+}
+
+// SetClusterCapacity küme toplam allocatable kapasitesini günceller
+func (t *NamespaceUsageTracker) SetClusterCapacity(cpu, memory float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.clusterCPU = cpu
+	t.clusterMemory = memory
+}
+
+// Update namespace tüketimlerini toplama döngüsünün sonucuyla değiştirir
+func (t *NamespaceUsageTracker) Update(usage map[string]NamespaceUsage) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.usage = usage
+	t.lastUpdated = time.Now()
+}
+
+// ClusterCapacity küme toplam CPU (core) ve memory (GB) kapasitesini döndürür
+func (t *NamespaceUsageTracker) ClusterCapacity() (float64, float64) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.clusterCPU, t.clusterMemory
+}
+
+// Snapshot tüm namespace tüketimlerini isme göre sıralı döndürür
+func (t *NamespaceUsageTracker) Snapshot() []NamespaceUsage {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	result := make([]NamespaceUsage, 0, len(t.usage))
+	for _, usage := range t.usage {
+		result = append(result, usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Namespace < result[j].Namespace })
+	return result
+}
@@ -0,0 +1,199 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultFlapRetention Ready geçişlerinin varsayılan saklama süresi
+const defaultFlapRetention = 24 * time.Hour
+
+// NodeFlapState node'un belirli bir andaki Ready/NotReady geçiş durumu
+type NodeFlapState struct {
+	NodeName       string    `json:"node_name"`
+	Transitions    int       `json:"transitions"` // at anına kadar saklanan geçiş sayısı
+	LastTransition time.Time `json:"last_transition,omitempty"`
+	Figure         float64   `json:"figure"` // Yarı ömürle sönümlenmiş geçiş sayısı
+}
+
+// NodeFlapHistory node'un son gözlenen Ready durumu ve saklanan geçiş zamanları (trace kaydı ve replay için)
+type NodeFlapHistory struct {
+	Ready          bool        `json:"ready"`
+	LastTransition time.Time   `json:"last_transition"`
+	Transitions    []time.Time `json:"transitions,omitempty"`
+}
+
+// nodeFlaps node'un son gözlenen Ready durumu ve geçiş zamanları (sıralı)
+type nodeFlaps struct {
+	ready          bool
+	lastTransition time.Time
+	lastSeen       time.Time
+	transitions    []time.Time
+}
+
+// NodeFlapTracker node'ların NotReady↔Ready geçişlerini saklama süresi boyunca tutar
+type NodeFlapTracker struct {
+	mutex     sync.RWMutex
+	nodes     map[string]*nodeFlaps
+	retention time.Duration
+}
+
+// NewNodeFlapTracker yeni flap takipçisi oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewNodeFlapTracker(flapConfig *NodeFlapConfig) *NodeFlapTracker {
+	t := &NodeFlapTracker{nodes: make(map[string]*nodeFlaps)}
+	t.Configure(flapConfig)
+	return t
+}
+
+// Configure saklama süresini değiştirir, mevcut geçişler korunur
+func (t *NodeFlapTracker) Configure(flapConfig *NodeFlapConfig) {
+	retention := flapConfig.Retention
+	if retention <= 0 {
+		retention = defaultFlapRetention
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.retention = retention
+}
+
+// NodeIsReady node'un Ready koşulu True mu, koşulun son geçiş zamanıyla birlikte
+func NodeIsReady(node *corev1.Node) (bool, time.Time) {
+	for i := range node.Status.Conditions {
+		condition := &node.Status.Conditions[i]
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue, condition.LastTransitionTime.Time
+		}
+	}
+	return false, time.Time{}
+}
+
+// Observe node'un Ready durumunu önceki gözlemle karşılaştırıp geçişleri kaydeder. İlk gözlem geçiş sayılmaz.
+// Durum aynı olduğu halde koşulun geçiş zamanı ilerlemişse node iki toplama arasında gidip gelmiştir
+func (t *NodeFlapTracker) Observe(node *corev1.Node, now time.Time) {
+	t.observe(node, now, true)
+}
+
+// Sync node'un Ready durumunu geçiş kaydetmeden günceller (ör: chaos deneyi altındaki node).
+// Deney bitince sadece deneyden sonraki geçişler sayılır
+func (t *NodeFlapTracker) Sync(node *corev1.Node, now time.Time) {
+	t.observe(node, now, false)
+}
+
+// observe node'un durumunu günceller, record false ise geçişler kaydedilmez
+func (t *NodeFlapTracker) observe(node *corev1.Node, now time.Time, record bool) {
+	ready, transitioned := NodeIsReady(node)
+	if transitioned.IsZero() || transitioned.After(now) {
+		transitioned = now
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	flaps, ok := t.nodes[node.Name]
+	if !ok {
+		t.nodes[node.Name] = &nodeFlaps{ready: ready, lastTransition: transitioned, lastSeen: now}
+		return
+	}
+	flaps.lastSeen = now
+	if !record {
+		flaps.ready = ready
+		if transitioned.After(flaps.lastTransition) {
+			flaps.lastTransition = transitioned
+		}
+		return
+	}
+
+	switch {
+	case ready != flaps.ready:
+		flaps.transitions = append(flaps.transitions, transitioned)
+	case transitioned.After(flaps.lastTransition):
+		// Arada kaçırılan ters geçişin zamanı bilinmez, iki geçiş de son geçiş zamanına yazılır
+		flaps.transitions = append(flaps.transitions, transitioned, transitioned)
+	default:
+		return
+	}
+	flaps.ready = ready
+	flaps.lastTransition = transitioned
+
+	// Koşul zamanları saatten bağımsız gelebilir, sıra korunur
+	sort.Slice(flaps.transitions, func(i, j int) bool { return flaps.transitions[i].Before(flaps.transitions[j]) })
+}
+
+// Expire saklama süresinden eski geçişleri ve bu süre boyunca görülmeyen node'ları siler
+func (t *NodeFlapTracker) Expire(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cutoff := now.Add(-t.retention)
+	for name, flaps := range t.nodes {
+		if flaps.lastSeen.Before(cutoff) {
+			delete(t.nodes, name)
+			continue
+		}
+		i := sort.Search(len(flaps.transitions), func(i int) bool { return !flaps.transitions[i].Before(cutoff) })
+		flaps.transitions = flaps.transitions[i:]
+	}
+}
+
+// History node'un son Ready durumunu ve geçişlerini döndürür (kopya), node gözlenmediyse false
+func (t *NodeFlapTracker) History(nodeName string) (NodeFlapHistory, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	flaps, ok := t.nodes[nodeName]
+	if !ok {
+		return NodeFlapHistory{}, false
+	}
+	return NodeFlapHistory{
+		Ready:          flaps.ready,
+		LastTransition: flaps.lastTransition,
+		Transitions:    append([]time.Time(nil), flaps.transitions...),
+	}, true
+}
+
+// SetHistory node'un Ready durumunu ve geçişlerini verilenle değiştirir (ör: replay'de kaydedilen geçmiş)
+func (t *NodeFlapTracker) SetHistory(nodeName string, history NodeFlapHistory, now time.Time) {
+	transitions := append([]time.Time(nil), history.Transitions...)
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].Before(transitions[j]) })
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes[nodeName] = &nodeFlaps{
+		ready:          history.Ready,
+		lastTransition: history.LastTransition,
+		lastSeen:       now,
+		transitions:    transitions,
+	}
+}
+
+// State node'un at anındaki flap durumunu döndürür. Her geçiş 1 ile başlayıp halfLife'ta yarıya iner,
+// at'ten sonraki geçişler sayılmaz. Node hiç gözlenmediyse false
+func (t *NodeFlapTracker) State(nodeName string, at time.Time, halfLife time.Duration) (NodeFlapState, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	flaps, ok := t.nodes[nodeName]
+	if !ok {
+		return NodeFlapState{}, false
+	}
+
+	state := NodeFlapState{NodeName: nodeName}
+	for _, transition := range flaps.transitions {
+		if transition.After(at) {
+			break
+		}
+		state.Transitions++
+		state.LastTransition = transition
+		if halfLife > 0 {
+			state.Figure += math.Exp2(-float64(at.Sub(transition)) / float64(halfLife))
+		}
+	}
+	return state, true
+}
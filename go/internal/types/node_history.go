@@ -0,0 +1,181 @@
+package types
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Node kullanım geçmişinin varsayılan çözünürlüğü ve saklama süresi
+const (
+	defaultHistoryResolution = time.Hour
+	defaultHistoryRetention  = 14 * 24 * time.Hour
+)
+
+// NodeUsageSample node'un bir zaman dilimindeki ortalama CPU (core) ve memory (GB) kullanımı
+type NodeUsageSample struct {
+	Timestamp time.Time `json:"timestamp"` // Dilimin başlangıcı
+	CPU       float64   `json:"cpu"`
+	Memory    float64   `json:"memory_gb"`
+	count     int
+}
+
+// NodeMetricsHistory node kullanımını sabit çözünürlüklü dilimlerde ortalamalar halinde tutar
+// (kapasite planlaması ve tahmin için). Ham örnek saklanmaz, bellek node başına retention/resolution ile sınırlıdır
+type NodeMetricsHistory struct {
+	mutex      sync.RWMutex
+	nodes      map[string][]NodeUsageSample
+	last       map[string]time.Time // Node'un son örneğinin zamanı (dilim başlangıcı değil)
+	resolution time.Duration
+	retention  time.Duration
+}
+
+// NewNodeMetricsHistory yeni node kullanım geçmişi oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewNodeMetricsHistory(historyConfig *NodeHistoryConfig) *NodeMetricsHistory {
+	h := &NodeMetricsHistory{nodes: make(map[string][]NodeUsageSample), last: make(map[string]time.Time)}
+	h.Configure(historyConfig)
+	return h
+}
+
+// Configure çözünürlüğü ve saklama süresini değiştirir, mevcut dilimler korunur
+func (h *NodeMetricsHistory) Configure(historyConfig *NodeHistoryConfig) {
+	resolution := historyConfig.Resolution
+	if resolution <= 0 {
+		resolution = defaultHistoryResolution
+	}
+	retention := historyConfig.Retention
+	if retention <= 0 {
+		retention = defaultHistoryRetention
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.resolution = resolution
+	h.retention = retention
+}
+
+// Record node metriğini ait olduğu dilimin ortalamasına ekler
+func (h *NodeMetricsHistory) Record(metrics NodeMetrics) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if metrics.Timestamp.After(h.last[metrics.NodeName]) {
+		h.last[metrics.NodeName] = metrics.Timestamp
+	}
+	bucket := metrics.Timestamp.Truncate(h.resolution)
+	samples := h.nodes[metrics.NodeName]
+
+	// Son dilime ait örnek ortalamaya katılır, eski dilime gelen geç örnekler yok sayılır
+	if n := len(samples); n > 0 {
+		last := &samples[n-1]
+		if last.Timestamp.Equal(bucket) {
+			last.count++
+			last.CPU += (metrics.CPUUsage - last.CPU) / float64(last.count)
+			last.Memory += (metrics.MemoryUsage - last.Memory) / float64(last.count)
+			return
+		}
+		if bucket.Before(last.Timestamp) {
+			return
+		}
+	}
+
+	samples = append(samples, NodeUsageSample{
+		Timestamp: bucket,
+		CPU:       metrics.CPUUsage,
+		Memory:    metrics.MemoryUsage,
+		count:     1,
+	})
+
+	// Saklama süresi dışına düşen dilimler atılır
+	cutoff := bucket.Add(-h.retention)
+	drop := sort.Search(len(samples), func(i int) bool { return samples[i].Timestamp.After(cutoff) })
+	if drop > 0 {
+		samples = append(samples[:0], samples[drop:]...)
+	}
+	h.nodes[metrics.NodeName] = samples
+}
+
+// Samples node'un since'ten sonraki dilimlerini zaman sırasıyla döndürür (kopya)
+func (h *NodeMetricsHistory) Samples(nodeName string, since time.Time) []NodeUsageSample {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	samples := h.nodes[nodeName]
+	start := sort.Search(len(samples), func(i int) bool { return !samples[i].Timestamp.Before(since) })
+	result := make([]NodeUsageSample, len(samples)-start)
+	copy(result, samples[start:])
+	return result
+}
+
+// NodeHistorySeries node'un kullanım geçmişi dilimleri (yedek ve replikalar arası devir için)
+type NodeHistorySeries struct {
+	Node    string            `json:"node"`
+	Samples []NodeUsageSample `json:"samples"`
+}
+
+// Export tüm node'ların dilimlerini node adına göre sıralı döndürür (kopya)
+func (h *NodeMetricsHistory) Export() []NodeHistorySeries {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	series := make([]NodeHistorySeries, 0, len(h.nodes))
+	for node, samples := range h.nodes {
+		series = append(series, NodeHistorySeries{Node: node, Samples: append([]NodeUsageSample(nil), samples...)})
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Node < series[j].Node })
+	return series
+}
+
+// Import dilimleri geçmişe ekler ve eklenen dilim sayısını döndürür. Node'un mevcut ilk diliminden eski olanlar
+// eklenir, mevcut dilimler değişmez; saklama süresinin dışındakiler atılır. Son örnek zamanı güncellenmez,
+// içe aktarılan geçmiş tazelik denetiminde güncel sayılmaz
+func (h *NodeMetricsHistory) Import(series []NodeHistorySeries) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	imported := 0
+	for _, s := range series {
+		existing := h.nodes[s.Node]
+		var latest time.Time
+		if len(existing) > 0 {
+			latest = existing[len(existing)-1].Timestamp
+		}
+		older := make([]NodeUsageSample, 0, len(s.Samples)+len(existing))
+		for _, sample := range s.Samples {
+			if len(existing) > 0 && !sample.Timestamp.Before(existing[0].Timestamp) {
+				break
+			}
+			if !latest.IsZero() && !sample.Timestamp.After(latest.Add(-h.retention)) {
+				continue
+			}
+			if n := len(older); n > 0 && !sample.Timestamp.After(older[n-1].Timestamp) {
+				continue
+			}
+			sample.count = 1
+			older = append(older, sample)
+		}
+		if len(older) == 0 {
+			continue
+		}
+		imported += len(older)
+		h.nodes[s.Node] = append(older, existing...)
+	}
+	return imported
+}
+
+// LastSample node'un son kaydedilen örneğinin zamanını döndürür, hiç örnek yoksa sıfır
+func (h *NodeMetricsHistory) LastSample(nodeName string) time.Time {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.last[nodeName]
+}
+
+// Resolution dilim çözünürlüğünü döndürür
+func (h *NodeMetricsHistory) Resolution() time.Duration {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.resolution
+}
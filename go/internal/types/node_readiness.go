@@ -0,0 +1,191 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReadinessRetention Ready geçmişinin saklama süresi (en uzun pencere)
+const ReadinessRetention = 7 * 24 * time.Hour
+
+// NodeReadiness node'un son 1 saat, 24 saat ve 7 günde Ready kaldığı sürenin oranı (0-1). Node pencereden kısa
+// süredir biliniyorsa oran bilinen süre üzerinden hesaplanır
+type NodeReadiness struct {
+	Hour float64 `json:"hour"`
+	Day  float64 `json:"day"`
+	Week float64 `json:"week"`
+}
+
+// Score pencerelerin ortalaması; kısa pencere yeni kesintileri, uzun pencereler süregelen kararsızlığı yansıtır
+func (r NodeReadiness) Score() float64 {
+	return (r.Hour + r.Day + r.Week) / 3
+}
+
+// ReadinessSegment node'un Since anından sonraki dilimin başına kadar süren Ready durumu (trace kaydı ve replay için)
+type ReadinessSegment struct {
+	Since time.Time `json:"since"`
+	Ready bool      `json:"ready"`
+}
+
+// readinessSegment node'un since anından sonraki dilimin başına kadar süren Ready durumu
+type readinessSegment struct {
+	since time.Time
+	ready bool
+}
+
+// nodeReadiness node'un zaman sırasıyla Ready dilimleri
+type nodeReadiness struct {
+	segments []readinessSegment
+	lastSeen time.Time
+}
+
+// NodeReadinessTracker node'ların Ready/NotReady dilimlerini ReadinessRetention boyunca tutar. İlk gözlemde
+// Ready koşulunun son geçiş zamanından itibaren aynı durumda kalındığı kabul edilir
+type NodeReadinessTracker struct {
+	mutex sync.RWMutex
+	nodes map[string]*nodeReadiness
+}
+
+// NewNodeReadinessTracker yeni Ready geçmişi takipçisi oluşturur
+func NewNodeReadinessTracker() *NodeReadinessTracker {
+	return &NodeReadinessTracker{nodes: make(map[string]*nodeReadiness)}
+}
+
+// Observe node'un Ready durumu değiştiyse koşulun geçiş zamanından itibaren yeni dilim başlatır
+func (t *NodeReadinessTracker) Observe(node *corev1.Node, now time.Time) {
+	ready, transitioned := NodeIsReady(node)
+	if transitioned.IsZero() || transitioned.After(now) {
+		transitioned = now
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	history, ok := t.nodes[node.Name]
+	if !ok {
+		t.nodes[node.Name] = &nodeReadiness{
+			segments: []readinessSegment{{since: transitioned, ready: ready}},
+			lastSeen: now,
+		}
+		return
+	}
+	history.lastSeen = now
+
+	last := history.segments[len(history.segments)-1]
+	if last.ready == ready {
+		return
+	}
+	// Koşul zamanları saatten bağımsız gelebilir, dilimler sıralı kalır
+	if transitioned.Before(last.since) {
+		transitioned = last.since
+	}
+	history.segments = append(history.segments, readinessSegment{since: transitioned, ready: ready})
+}
+
+// Expire saklama süresinden önce biten dilimleri ve bu süre boyunca görülmeyen node'ları siler
+func (t *NodeReadinessTracker) Expire(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cutoff := now.Add(-ReadinessRetention)
+	for name, history := range t.nodes {
+		if history.lastSeen.Before(cutoff) {
+			delete(t.nodes, name)
+			continue
+		}
+		// Saklama süresinin başını kapsayan dilim korunur
+		i := 0
+		for i+1 < len(history.segments) && !history.segments[i+1].since.After(cutoff) {
+			i++
+		}
+		history.segments = history.segments[i:]
+	}
+}
+
+// History node'un Ready dilimlerini zaman sırasıyla döndürür (kopya), node gözlenmediyse nil
+func (t *NodeReadinessTracker) History(nodeName string) []ReadinessSegment {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	history, ok := t.nodes[nodeName]
+	if !ok {
+		return nil
+	}
+	segments := make([]ReadinessSegment, len(history.segments))
+	for i, segment := range history.segments {
+		segments[i] = ReadinessSegment{Since: segment.since, Ready: segment.ready}
+	}
+	return segments
+}
+
+// SetHistory node'un Ready dilimlerini verilen sıralı dilimlerle değiştirir (ör: replay'de kaydedilen geçmiş).
+// Dilim yoksa node silinir
+func (t *NodeReadinessTracker) SetHistory(nodeName string, segments []ReadinessSegment, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(segments) == 0 {
+		delete(t.nodes, nodeName)
+		return
+	}
+	history := &nodeReadiness{segments: make([]readinessSegment, len(segments)), lastSeen: now}
+	for i, segment := range segments {
+		history.segments[i] = readinessSegment{since: segment.Since, ready: segment.Ready}
+	}
+	t.nodes[nodeName] = history
+}
+
+// Readiness node'un at anına kadarki Ready oranlarını döndürür. Node hiç gözlenmediyse veya at ilk bilinen
+// dilimden önceyse false
+func (t *NodeReadinessTracker) Readiness(nodeName string, at time.Time) (NodeReadiness, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	history, ok := t.nodes[nodeName]
+	if !ok || at.Before(history.segments[0].since) {
+		return NodeReadiness{}, false
+	}
+	return NodeReadiness{
+		Hour: history.fraction(at.Add(-time.Hour), at),
+		Day:  history.fraction(at.Add(-24*time.Hour), at),
+		Week: history.fraction(at.Add(-ReadinessRetention), at),
+	}, true
+}
+
+// fraction [start, end] aralığının bilinen kısmında Ready kalınan sürenin oranı. Bilinen süre sıfırsa end
+// anındaki durum döner
+func (h *nodeReadiness) fraction(start, end time.Time) float64 {
+	var ready, known time.Duration
+	current := false
+	for i, segment := range h.segments {
+		if segment.since.After(end) {
+			break
+		}
+		current = segment.ready
+
+		from, to := segment.since, end
+		if i+1 < len(h.segments) && h.segments[i+1].since.Before(end) {
+			to = h.segments[i+1].since
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if !to.After(from) {
+			continue
+		}
+		known += to.Sub(from)
+		if segment.ready {
+			ready += to.Sub(from)
+		}
+	}
+
+	if known <= 0 {
+		if current {
+			return 1
+		}
+		return 0
+	}
+	return float64(ready) / float64(known)
+}
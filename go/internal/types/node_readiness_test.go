@@ -0,0 +1,192 @@
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// readinessObservation at anında gözlenen Ready durumu ve koşulun geçiş zamanı
+type readinessObservation struct {
+	at         time.Duration
+	ready      bool
+	transition time.Duration
+}
+
+// readinessNode Ready koşulu verilen durum ve geçiş zamanında olan node
+func readinessNode(ready bool, transition time.Time) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+			Type:               corev1.NodeReady,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(transition),
+		}}},
+	}
+}
+
+func TestNodeReadinessTrackerReadiness(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		observations []readinessObservation
+		at           time.Duration
+		want         NodeReadiness
+		ok           bool
+	}{
+		{
+			name:         "kararlı node",
+			observations: []readinessObservation{{at: -time.Hour, ready: true, transition: -10 * 24 * time.Hour}, {at: 0, ready: true, transition: -10 * 24 * time.Hour}},
+			want:         NodeReadiness{Hour: 1, Day: 1, Week: 1},
+			ok:           true,
+		},
+		{
+			name: "gidip gelen node",
+			observations: []readinessObservation{
+				{at: -2 * time.Hour, ready: true, transition: -2 * time.Hour},
+				{at: -90 * time.Minute, ready: false, transition: -90 * time.Minute},
+				{at: -80 * time.Minute, ready: true, transition: -80 * time.Minute},
+				{at: -30 * time.Minute, ready: false, transition: -30 * time.Minute},
+				{at: -20 * time.Minute, ready: true, transition: -20 * time.Minute},
+			},
+			// Son saat: 30 dk Ready, 10 dk NotReady, 20 dk Ready; bilinen 2 saat: 100 dk Ready
+			want: NodeReadiness{Hour: 50.0 / 60, Day: 100.0 / 120, Week: 100.0 / 120},
+			ok:   true,
+		},
+		{
+			name: "pencerenin bir kısmı biliniyor",
+			observations: []readinessObservation{
+				{at: -30 * time.Minute, ready: true, transition: -30 * time.Minute},
+				{at: -10 * time.Minute, ready: false, transition: -10 * time.Minute},
+			},
+			// Sadece bilinen 30 dk sayılır
+			want: NodeReadiness{Hour: 20.0 / 30, Day: 20.0 / 30, Week: 20.0 / 30},
+			ok:   true,
+		},
+		{
+			name: "dilim pencere başını kapsıyor",
+			observations: []readinessObservation{
+				{at: -3 * time.Hour, ready: true, transition: -3 * time.Hour},
+				{at: -30 * time.Minute, ready: false, transition: -30 * time.Minute},
+			},
+			// Ready dilimi saatlik pencerenin başından kesilir
+			want: NodeReadiness{Hour: 0.5, Day: 150.0 / 180, Week: 150.0 / 180},
+			ok:   true,
+		},
+		{
+			name: "geçiş zamanı gelecekte",
+			observations: []readinessObservation{
+				{at: -time.Hour, ready: true, transition: -time.Hour},
+				{at: -30 * time.Minute, ready: false, transition: 10 * time.Minute},
+			},
+			// Gelecekteki geçiş gözlem anına çekilir
+			want: NodeReadiness{Hour: 0.5, Day: 0.5, Week: 0.5},
+			ok:   true,
+		},
+		{
+			name: "geçiş zamanı önceki dilimden eski",
+			observations: []readinessObservation{
+				{at: -time.Hour, ready: true, transition: -time.Hour},
+				{at: -30 * time.Minute, ready: false, transition: -2 * time.Hour},
+			},
+			// Geriye kaymış geçiş önceki dilimin başına çekilir, dilimler sıralı kalır
+			want: NodeReadiness{Hour: 0, Day: 0, Week: 0},
+			ok:   true,
+		},
+		{
+			name:         "ilk gözlemde geçiş zamanı gelecekte",
+			observations: []readinessObservation{{at: 0, ready: true, transition: time.Hour}},
+			// Bilinen süre sıfır, güncel durum döner
+			want: NodeReadiness{Hour: 1, Day: 1, Week: 1},
+			ok:   true,
+		},
+		{
+			name:         "ilk dilimden önce",
+			observations: []readinessObservation{{at: 0, ready: true, transition: -time.Hour}},
+			at:           -2 * time.Hour,
+			ok:           false,
+		},
+		{
+			name: "gözlenmemiş node",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewNodeReadinessTracker()
+			for _, observation := range tt.observations {
+				tracker.Observe(readinessNode(observation.ready, base.Add(observation.transition)), base.Add(observation.at))
+			}
+
+			got, ok := tracker.Readiness("node-1", base.Add(tt.at))
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, beklenen %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			for _, window := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"hour", got.Hour, tt.want.Hour},
+				{"day", got.Day, tt.want.Day},
+				{"week", got.Week, tt.want.Week},
+			} {
+				if math.Abs(window.got-window.want) > 1e-9 {
+					t.Errorf("%s = %.4f, beklenen %.4f", window.name, window.got, window.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeReadinessFlappingScoresBelowStable(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	stable, flapping := NewNodeReadinessTracker(), NewNodeReadinessTracker()
+	stable.Observe(readinessNode(true, base.Add(-24*time.Hour)), base.Add(-24*time.Hour))
+
+	// Her saat 5 dk NotReady kalan node
+	for hour := 24; hour > 0; hour-- {
+		down := base.Add(-time.Duration(hour) * time.Hour)
+		flapping.Observe(readinessNode(true, down.Add(-time.Hour)), down.Add(-time.Hour))
+		flapping.Observe(readinessNode(false, down), down)
+		flapping.Observe(readinessNode(true, down.Add(5*time.Minute)), down.Add(5*time.Minute))
+	}
+
+	stableReadiness, _ := stable.Readiness("node-1", base)
+	flappingReadiness, _ := flapping.Readiness("node-1", base)
+	if flappingReadiness.Score() >= stableReadiness.Score() {
+		t.Fatalf("gidip gelen node skoru %.3f, kararlı node %.3f'dan düşük olmalı", flappingReadiness.Score(), stableReadiness.Score())
+	}
+	if math.Abs(flappingReadiness.Hour-55.0/60) > 1e-9 {
+		t.Errorf("hour = %.4f, beklenen %.4f", flappingReadiness.Hour, 55.0/60)
+	}
+}
+
+func TestNodeReadinessTrackerExpire(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewNodeReadinessTracker()
+	tracker.Observe(readinessNode(false, base.Add(-10*24*time.Hour)), base.Add(-10*24*time.Hour))
+	tracker.Observe(readinessNode(true, base.Add(-8*24*time.Hour)), base)
+
+	tracker.Expire(base)
+	// Saklama süresinin başını kapsayan Ready dilimi korunur, NotReady dilimi silinir
+	got, ok := tracker.Readiness("node-1", base)
+	if !ok || got.Week != 1 {
+		t.Fatalf("Readiness = %+v, %v; beklenen tam Ready hafta", got, ok)
+	}
+
+	tracker.Expire(base.Add(ReadinessRetention + time.Second))
+	if _, ok := tracker.Readiness("node-1", base.Add(ReadinessRetention+time.Second)); ok {
+		t.Fatal("saklama süresi boyunca görülmeyen node silinmeliydi")
+	}
+}
@@ -0,0 +1,390 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Noisy neighbor tespitinin varsayılanları
+const (
+	defaultNeighborWindow      = 60
+	defaultNeighborMinSamples  = 20
+	defaultNeighborCorrelation = 0.4
+	defaultNeighborMinBursts   = 3
+	neighborBurstDeviations    = 1.0 // Ortalamanın bu kadar standart sapma üstü patlama sayılır
+	neighborStarvedDeviations  = 0.5 // Ortalamanın bu kadar standart sapma altı kaynak açlığı sayılır
+	neighborMinVariation       = 0.1 // Bu varyasyon katsayısının altındaki seriler sabit kabul edilir
+)
+
+// PodUsageSample pod'un bir toplama turundaki toplam CPU kullanımı (core) ve restart sayısı
+type PodUsageSample struct {
+	Namespace string
+	Pod       string
+	NodeName  string
+	Workload  WorkloadRef
+	CPU       float64
+	Restarts  int
+}
+
+// NoisyPod patlamaları aynı node'daki pod'ların bozulmasıyla ilişkili pod
+type NoisyPod struct {
+	Namespace   string      `json:"namespace"`
+	Pod         string      `json:"pod"`
+	NodeName    string      `json:"node_name"`
+	Workload    WorkloadRef `json:"workload"`
+	Correlation float64     `json:"correlation"` // Kullanım ile komşu bozulması arasındaki Pearson korelasyonu
+	Bursts      int         `json:"bursts"`      // Penceredeki patlama sayısı
+	Variation   float64     `json:"variation"`   // Kullanımın varyasyon katsayısı
+	Samples     int         `json:"samples"`
+}
+
+// NeighborSlot pod'un pencere dilimindeki kullanımı ve restart artışı
+type NeighborSlot struct {
+	Index    int     `json:"index"`
+	CPU      float64 `json:"cpu"`
+	Restarts float64 `json:"restarts,omitempty"`
+}
+
+// NeighborSeries pod'un penceredeki görüldüğü dilimler (trace kaydı ve replay için)
+type NeighborSeries struct {
+	Namespace string         `json:"namespace"`
+	Pod       string         `json:"pod"`
+	Workload  WorkloadRef    `json:"workload"`
+	Slots     []NeighborSlot `json:"slots"`
+}
+
+// neighborSeries pod'un penceredeki kullanım ve restart artışı halka tamponları (NaN = görülmedi)
+type neighborSeries struct {
+	namespace    string
+	pod          string
+	workload     WorkloadRef
+	cpu          []float64
+	restarts     []float64
+	lastRestarts int
+	lastSeen     uint64
+}
+
+// NeighborUsageTracker node bazında co-located pod'ların son toplama turlarındaki kullanımını tutar.
+// Bir pod'un kullanım patlamaları aynı node'daki diğer pod'ların kaynak açlığı ve restart'larıyla
+// birlikte artıyorsa pod noisy neighbor kabul edilir
+type NeighborUsageTracker struct {
+	mutex  sync.RWMutex
+	config NoisyNeighborConfig
+	tick   uint64
+	nodes  map[string]map[string]*neighborSeries // node -> namespace/pod -> seri
+}
+
+// NewNeighborUsageTracker yeni komşu kullanım takipçisi oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewNeighborUsageTracker(neighborConfig *NoisyNeighborConfig) *NeighborUsageTracker {
+	t := &NeighborUsageTracker{nodes: make(map[string]map[string]*neighborSeries)}
+	t.Configure(neighborConfig)
+	return t
+}
+
+// Configure pencere ve tespit eşiklerini değiştirir. Pencere boyutu değişirse geçmiş silinir
+func (t *NeighborUsageTracker) Configure(neighborConfig *NoisyNeighborConfig) {
+	cfg := *neighborConfig
+	if cfg.Window <= 1 {
+		cfg.Window = defaultNeighborWindow
+	}
+	if cfg.MinSamples <= 1 || cfg.MinSamples > cfg.Window {
+		cfg.MinSamples = defaultNeighborMinSamples
+		if cfg.MinSamples > cfg.Window {
+			cfg.MinSamples = cfg.Window
+		}
+	}
+	if cfg.CorrelationThreshold <= 0 || cfg.CorrelationThreshold > 1 {
+		cfg.CorrelationThreshold = defaultNeighborCorrelation
+	}
+	if cfg.MinBursts <= 0 {
+		cfg.MinBursts = defaultNeighborMinBursts
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if cfg.Window != t.config.Window {
+		t.nodes = make(map[string]map[string]*neighborSeries)
+	}
+	t.config = cfg
+}
+
+// Observe bir toplama turundaki pod kullanımlarını ekler. Turda görülmeyen pod'ların dilimi boş kalır,
+// pencere boyunca görülmeyen pod'lar silinir
+func (t *NeighborUsageTracker) Observe(samples []PodUsageSample) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.tick++
+	window := t.config.Window
+	pos := int(t.tick % uint64(window))
+
+	for i := range samples {
+		sample := &samples[i]
+		if sample.NodeName == "" {
+			continue
+		}
+
+		pods, ok := t.nodes[sample.NodeName]
+		if !ok {
+			pods = make(map[string]*neighborSeries)
+			t.nodes[sample.NodeName] = pods
+		}
+		key := sample.Namespace + "/" + sample.Pod
+		series, ok := pods[key]
+		if !ok {
+			series = &neighborSeries{
+				namespace:    sample.Namespace,
+				pod:          sample.Pod,
+				cpu:          make([]float64, window),
+				restarts:     make([]float64, window),
+				lastRestarts: sample.Restarts,
+			}
+			for j := range series.cpu {
+				series.cpu[j] = math.NaN()
+			}
+			pods[key] = series
+		}
+
+		series.workload = sample.Workload
+		series.cpu[pos] = sample.CPU
+		series.restarts[pos] = math.Max(float64(sample.Restarts-series.lastRestarts), 0)
+		series.lastRestarts = sample.Restarts
+		series.lastSeen = t.tick
+	}
+
+	for nodeName, pods := range t.nodes {
+		for key, series := range pods {
+			if series.lastSeen == t.tick {
+				continue
+			}
+			if t.tick-series.lastSeen >= uint64(window) {
+				delete(pods, key)
+				continue
+			}
+			series.cpu[pos] = math.NaN()
+			series.restarts[pos] = 0
+		}
+		if len(pods) == 0 {
+			delete(t.nodes, nodeName)
+		}
+	}
+}
+
+// NodeRisk node'un noisy neighbor riskini (0-1) döndürür: yeterince patlayan pod'ların komşu bozulmasıyla en yüksek korelasyonu
+func (t *NeighborUsageTracker) NodeRisk(nodeName string) float64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	risk := 0.0
+	for _, candidate := range t.analyzeNode(nodeName) {
+		if candidate.Bursts >= t.config.MinBursts && candidate.Correlation > risk {
+			risk = candidate.Correlation
+		}
+	}
+	return risk
+}
+
+// NodeSeries node'daki pod'ların pencere serilerini namespace/pod sırasıyla döndürür, görülmeyen dilimler atlanır
+func (t *NeighborUsageTracker) NodeSeries(nodeName string) []NeighborSeries {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	pods := t.nodes[nodeName]
+	result := make([]NeighborSeries, 0, len(pods))
+	for _, series := range pods {
+		exported := NeighborSeries{Namespace: series.namespace, Pod: series.pod, Workload: series.workload}
+		for j, cpu := range series.cpu {
+			if !math.IsNaN(cpu) {
+				exported.Slots = append(exported.Slots, NeighborSlot{Index: j, CPU: cpu, Restarts: series.restarts[j]})
+			}
+		}
+		result = append(result, exported)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace+"/"+result[i].Pod < result[j].Namespace+"/"+result[j].Pod
+	})
+	return result
+}
+
+// SetNodeSeries node'daki pod serilerini verilenlerle değiştirir (ör: replay'de kaydedilen pencere). Pencere dışındaki
+// dilimler atlanır, seri yoksa node silinir
+func (t *NeighborUsageTracker) SetNodeSeries(nodeName string, series []NeighborSeries) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(series) == 0 {
+		delete(t.nodes, nodeName)
+		return
+	}
+	window := t.config.Window
+	pods := make(map[string]*neighborSeries, len(series))
+	for _, s := range series {
+		imported := &neighborSeries{
+			namespace: s.Namespace,
+			pod:       s.Pod,
+			workload:  s.Workload,
+			cpu:       make([]float64, window),
+			restarts:  make([]float64, window),
+			lastSeen:  t.tick,
+		}
+		for j := range imported.cpu {
+			imported.cpu[j] = math.NaN()
+		}
+		for _, slot := range s.Slots {
+			if slot.Index >= 0 && slot.Index < window {
+				imported.cpu[slot.Index] = slot.CPU
+				imported.restarts[slot.Index] = slot.Restarts
+			}
+		}
+		pods[s.Namespace+"/"+s.Pod] = imported
+	}
+	t.nodes[nodeName] = pods
+}
+
+// NoisyPods eşikleri aşan noisy neighbor pod'larını namespace filtresine göre, en yüksek korelasyon önce döndürür
+func (t *NeighborUsageTracker) NoisyPods(namespaces NamespaceFilter) []NoisyPod {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var result []NoisyPod
+	for nodeName := range t.nodes {
+		for _, candidate := range t.analyzeNode(nodeName) {
+			if candidate.Bursts < t.config.MinBursts || candidate.Correlation < t.config.CorrelationThreshold {
+				continue
+			}
+			if !namespaces.Matches(candidate.Namespace) {
+				continue
+			}
+			result = append(result, candidate)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Correlation != result[j].Correlation {
+			return result[i].Correlation > result[j].Correlation
+		}
+		return result[i].Namespace+"/"+result[i].Pod < result[j].Namespace+"/"+result[j].Pod
+	})
+	return result
+}
+
+// seriesStats serinin geçerli dilimlerdeki ortalaması, standart sapması ve örnek sayısı
+func seriesStats(values []float64) (float64, float64, int) {
+	var sum, sumSquares float64
+	n := 0
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		sum += v
+		sumSquares += v * v
+		n++
+	}
+	if n == 0 {
+		return 0, 0, 0
+	}
+	mean := sum / float64(n)
+	variance := sumSquares/float64(n) - mean*mean
+	if variance < 0 {
+		variance = 0
+	}
+	return mean, math.Sqrt(variance), n
+}
+
+// analyzeNode node'daki yeterli örneği olan pod'lar için kullanım ile komşu bozulması korelasyonunu hesaplar.
+// Bozulma, diğer pod'lardan kaynak açlığı yaşayanların (ortalamanın altına düşen kullanım) ve restart'ların sayısıdır.
+// mutex okuma için tutulurken çağrılmalıdır
+func (t *NeighborUsageTracker) analyzeNode(nodeName string) []NoisyPod {
+	pods := t.nodes[nodeName]
+	if len(pods) < 2 {
+		return nil
+	}
+	window := t.config.Window
+
+	// Her dilimde bozulan pod sayısı ve pod'un kendi katkısı
+	type podStats struct {
+		series    *neighborSeries
+		mean      float64
+		std       float64
+		samples   int
+		degraded  []float64
+		variation float64
+	}
+	stats := make([]podStats, 0, len(pods))
+	total := make([]float64, window)
+	for _, series := range pods {
+		mean, std, n := seriesStats(series.cpu)
+		s := podStats{series: series, mean: mean, std: std, samples: n, degraded: make([]float64, window)}
+		if mean > 0 {
+			s.variation = std / mean
+		}
+		for j := 0; j < window; j++ {
+			d := series.restarts[j]
+			if !math.IsNaN(series.cpu[j]) && s.variation >= neighborMinVariation && series.cpu[j] < mean-neighborStarvedDeviations*std {
+				d++
+			}
+			s.degraded[j] = d
+			total[j] += d
+		}
+		stats = append(stats, s)
+	}
+
+	result := make([]NoisyPod, 0, len(stats))
+	neighbors := make([]float64, window)
+	for i := range stats {
+		s := &stats[i]
+		if s.samples < t.config.MinSamples || s.variation < neighborMinVariation {
+			continue
+		}
+
+		bursts := 0
+		for j := 0; j < window; j++ {
+			neighbors[j] = total[j] - s.degraded[j]
+			if !math.IsNaN(s.series.cpu[j]) && s.series.cpu[j] > s.mean+neighborBurstDeviations*s.std {
+				bursts++
+			}
+		}
+
+		result = append(result, NoisyPod{
+			Namespace:   s.series.namespace,
+			Pod:         s.series.pod,
+			NodeName:    nodeName,
+			Workload:    s.series.workload,
+			Correlation: pearson(s.series.cpu, neighbors),
+			Bursts:      bursts,
+			Variation:   s.variation,
+			Samples:     s.samples,
+		})
+	}
+	return result
+}
+
+// pearson x'in geçerli dilimlerinde x ve y arasındaki korelasyonu döndürür, serilerden biri sabitse 0
+func pearson(x, y []float64) float64 {
+	var sumX, sumY, sumXY, sumXX, sumYY float64
+	n := 0.0
+	for i := range x {
+		if math.IsNaN(x[i]) {
+			continue
+		}
+		sumX += x[i]
+		sumY += y[i]
+		sumXY += x[i] * y[i]
+		sumXX += x[i] * x[i]
+		sumYY += y[i] * y[i]
+		n++
+	}
+	if n < 2 {
+		return 0
+	}
+
+	covariance := sumXY - sumX*sumY/n
+	varianceX := sumXX - sumX*sumX/n
+	varianceY := sumYY - sumY*sumY/n
+	if varianceX <= 0 || varianceY <= 0 {
+		return 0
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNeighborNodeSeriesRoundTrip(t *testing.T) {
+	cfg := NoisyNeighborConfig{Window: 30, MinSamples: 10, MinBursts: 2}
+	tracker := NewNeighborUsageTracker(&cfg)
+
+	// noisy her beş turda bir patlar, aynı turlarda victim aç kalır; quiet sadece turların yarısında görülür
+	for tick := 0; tick < 40; tick++ {
+		noisy, victim := 0.5, 1.0
+		if tick%5 == 0 {
+			noisy, victim = 2.0, 0.1
+		}
+		samples := []PodUsageSample{
+			{Namespace: "default", Pod: "noisy", NodeName: "node-1", CPU: noisy},
+			{Namespace: "default", Pod: "victim", NodeName: "node-1", CPU: victim, Restarts: tick / 10},
+		}
+		if tick%2 == 0 {
+			samples = append(samples, PodUsageSample{Namespace: "default", Pod: "quiet", NodeName: "node-1", CPU: 0.3})
+		}
+		tracker.Observe(samples)
+	}
+	risk := tracker.NodeRisk("node-1")
+	if risk <= 0 {
+		t.Fatalf("node riski %.3f, pozitif bekleniyordu", risk)
+	}
+
+	// Görülmeyen dilimler atlanır, seriler JSON'dan aynı pencereyi kurar
+	data, err := json.Marshal(tracker.NodeSeries("node-1"))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var series []NeighborSeries
+	if err := json.Unmarshal(data, &series); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(series) != 3 || series[1].Pod != "quiet" || len(series[1].Slots) != cfg.Window/2 {
+		t.Fatalf("seriler beklenen gibi değil: %d seri", len(series))
+	}
+
+	restored := NewNeighborUsageTracker(&cfg)
+	restored.SetNodeSeries("node-1", series)
+	if got := restored.NodeRisk("node-1"); got != risk {
+		t.Errorf("yeniden kurulan risk %.6f, beklenen %.6f", got, risk)
+	}
+	restored.SetNodeSeries("node-1", nil)
+	if got := restored.NodeRisk("node-1"); got != 0 {
+		t.Errorf("seriler silindikten sonra risk %.3f, beklenen 0", got)
+	}
+}
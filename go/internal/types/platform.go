@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Node platform label'ları
+const (
+	LabelOS   = "kubernetes.io/os"
+	LabelArch = "kubernetes.io/arch"
+)
+
+// LabelWindowsBuild Windows node'larının işletim sistemi build numarası (ör: 10.0.20348)
+const LabelWindowsBuild = "node.kubernetes.io/windows-build"
+
+// OSWindows Windows node'larının kubernetes.io/os değeri
+const OSWindows = "windows"
+
+// AnnotationPlatforms pod'un çalışabildiği platformlar ("linux/amd64,linux/arm64"), imaj çözümlemesinin yerine geçer
+const AnnotationPlatforms = "ai-scheduler/platforms"
+
+// Platform imajın veya node'un işletim sistemi ve mimarisi
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"` // ör: arm64 için v8, eşleşmede dikkate alınmaz
+}
+
+// String platformu "os/arch" biçiminde döndürür
+func (p Platform) String() string {
+	return p.OS + "/" + p.Architecture
+}
+
+// ParsePlatforms "os/arch[/variant]" listesini çözer
+func ParsePlatforms(value string) ([]Platform, error) {
+	var platforms []Platform
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(strings.ToLower(item), "/")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("geçersiz platform: %s", item)
+		}
+		platform := Platform{OS: parts[0], Architecture: parts[1]}
+		if len(parts) == 3 {
+			platform.Variant = parts[2]
+		}
+		platforms = append(platforms, platform)
+	}
+	return platforms, nil
+}
+
+// IsWindowsNode node Windows ise true döner
+func IsWindowsNode(node *corev1.Node) bool {
+	return NodePlatform(node).OS == OSWindows
+}
+
+// NodePlatform node'un platformunu label'lardan, yoksa node bilgisinden döndürür
+func NodePlatform(node *corev1.Node) Platform {
+	platform := Platform{OS: node.Labels[LabelOS], Architecture: node.Labels[LabelArch]}
+	if platform.OS == "" {
+		platform.OS = node.Status.NodeInfo.OperatingSystem
+	}
+	if platform.Architecture == "" {
+		platform.Architecture = node.Status.NodeInfo.Architecture
+	}
+	return platform
+}
@@ -1,123 +1,697 @@
 package types
 
 import (
+	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unsafe"
 )
 
+// PodMetricsRetention PodMetrics geçmişinin tutulduğu süre
+const PodMetricsRetention = 7 * 24 * time.Hour
+
+// analysisWindows GetNodeAnalysis için örnek geldikçe artımlı tutulan pencereler,
+// saklama penceresi en geniş olduğu için sonda tutulur
+var analysisWindows = [...]time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, PodMetricsRetention}
+
+// retentionWindow saklama penceresinin analysisWindows içindeki indeksi
+const retentionWindow = len(analysisWindows) - 1
+
+// minDecayHalfLife azalma yarı ömrünün alt sınırı, ağırlıkların taşmaması için
+const minDecayHalfLife = time.Hour
+
+// budgetTrimTarget bütçe aşıldığında inilecek kullanım oranı, her örnekte tekrar kırpmamak için
+const budgetTrimTarget = 0.9
+
+// PodCache node kararlılık geçmişini tutan PodMetrics cache'i. PodMetricsCache süreç içinde tutar; replikalar
+// geçmişi paylaşacaksa paylaşılan bir backend'e (ör: Redis) yazan uygulaması kullanılır
+type PodCache interface {
+	UpdateCache(podMetrics PodMetrics)
+	Import(samples []PodMetrics) int
+	LastUpdate() time.Time
+	GetNodeMetrics(nodeName string) []PodMetrics
+	Samples() []PodMetrics
+	GetFailureRate(nodeName string) float64
+	GetRestartRate(nodeName string) float64
+	GetNodeAnalysis(nodeName string, timeWindow time.Duration) NodeAnalysis
+	GetNodeAnalysisAt(nodeName string, timeWindow time.Duration, at time.Time) NodeAnalysis
+	GetDecayedNodeAnalysis(nodeName string) NodeAnalysis
+	GetDecayedNodeAnalysisAt(nodeName string, at time.Time) NodeAnalysis
+	GetNodeSurvival(nodeName string) SurvivalEstimate
+	SetMemoryBudget(bytes int64)
+	SetDecayHalfLife(halfLife time.Duration)
+	MemoryUsage() (int64, int64)
+	TrimmedSamples() uint64
+}
+
 // PodMetricsCache PodMetrics için cache sistemi
+// Kilitleme node bazındadır: collector'ın bir node'a yazması diğer node'ların skorlanmasını bloklamaz.
+// Cache seviyesindeki kilit sadece node haritasını ve clock'u korur.
 type PodMetricsCache struct {
-	nodePodHistory map[string][]PodMetrics
-	failureRates   map[string]float64
-	restartRates   map[string]float64
-	lastUpdated    map[string]time.Time
-	mutex          sync.RWMutex
+	nodes map[string]*nodeHistory
+	clock Clock
+	mutex sync.RWMutex
+
+	// Bellek bütçesi: aşılınca en uzun süredir okunmayan node'ların en eski örnekleri atılır
+	budget  atomic.Int64
+	bytes   atomic.Int64
+	trimmed atomic.Uint64
+	trimMu  sync.Mutex
+
+	// lastUpdate son örneğin eklendiği an (unix nano), skorlama girdisi tazeliği için
+	lastUpdate atomic.Int64
+
+	// Güvenilirlik azalması: örnekler yaşlandıkça ağırlıkları yarı ömre göre üstel azalır, 0 ise kapalı
+	decayHalfLife atomic.Int64
+}
+
+// nodeHistory node'un zaman sıralı örnekleri ve pencere toplamları
+type nodeHistory struct {
+	mutex       sync.RWMutex
+	samples     []PodMetrics
+	windows     [len(analysisWindows)]rollingWindow // örnek başına map erişimi olmaması için dizi
+	decay       decayedWindow                       // saklama penceresinin yarı ömre göre ağırlıklı toplamları
+	lastUpdated time.Time
+	lastAccess  atomic.Int64 // son okuma (unix nano), LRU kırpma sırası için
+	survival    survivalCache
+}
+
+// rollingWindow pencere içindeki örneklerin toplamları, pencere dışına çıkan örnekler düşülür
+type rollingWindow struct {
+	start      int // pencere içindeki ilk örneğin indeksi
+	count      int
+	failed     int
+	restarts   int
+	createdSum float64 // CreatedAt unix saniye toplamı
+}
+
+// decayedWindow saklama penceresindeki örneklerin ağırlıklı toplamları. Ağırlık 2^((t-ref)/halfLife) olduğundan
+// oranlar ref'ten bağımsızdır; ref sadece ağırlıkların büyümemesi için ara sıra yeniden kurulurken ileri alınır
+type decayedWindow struct {
+	ref        time.Time
+	halfLife   time.Duration
+	count      float64
+	failed     float64
+	restarts   float64
+	createdSum float64 // (CreatedAt - ref) saniyesinin ağırlıklı toplamı
 }
 
 // NewPodMetricsCache yeni cache oluşturur
 func NewPodMetricsCache() *PodMetricsCache {
 	return &PodMetricsCache{
-		nodePodHistory: make(map[string][]PodMetrics),
-		failureRates:   make(map[string]float64),
-		restartRates:   make(map[string]float64),
-		lastUpdated:    make(map[string]time.Time),
+		nodes: make(map[string]*nodeHistory),
+		clock: RealClock,
 	}
 }
 
-// UpdateCache cache'i günceller
-func (pmc *PodMetricsCache) UpdateCache(podMetrics PodMetrics) {
+// SetClock cache'in zaman kaynağını değiştirir (ör: replay sırasında kaydedilen zaman)
+func (pmc *PodMetricsCache) SetClock(clock Clock) {
+	pmc.mutex.Lock()
+	defer pmc.mutex.Unlock()
+
+	pmc.clock = clock
+}
+
+// node node'un geçmişini ve cache'in şu anki zamanını döndürür, create true ise yoksa oluşturur
+func (pmc *PodMetricsCache) node(nodeName string, create bool) (*nodeHistory, time.Time) {
+	pmc.mutex.RLock()
+	history, ok := pmc.nodes[nodeName]
+	now := pmc.clock.Now()
+	pmc.mutex.RUnlock()
+	if ok || !create {
+		return history, now
+	}
+
 	pmc.mutex.Lock()
 	defer pmc.mutex.Unlock()
 
-	nodeName := podMetrics.NodeName
-	pmc.nodePodHistory[nodeName] = append(pmc.nodePodHistory[nodeName], podMetrics)
+	// Kilit beklenirken başka bir yazıcı oluşturmuş olabilir
+	if history, ok = pmc.nodes[nodeName]; !ok {
+		history = &nodeHistory{}
+		history.lastAccess.Store(now.UnixNano())
+		pmc.nodes[nodeName] = history
+	}
+	return history, now
+}
+
+// SetMemoryBudget cache'in tahmini bellek bütçesini byte olarak ayarlar, 0 ise sınırsız
+func (pmc *PodMetricsCache) SetMemoryBudget(bytes int64) {
+	pmc.budget.Store(bytes)
+	pmc.enforceBudget()
+}
+
+// SetDecayHalfLife örnek ağırlıklarının yarılanma süresini ayarlar, 0 ise azalma kapalıdır
+func (pmc *PodMetricsCache) SetDecayHalfLife(halfLife time.Duration) {
+	if halfLife > 0 && halfLife < minDecayHalfLife {
+		halfLife = minDecayHalfLife
+	}
+	if halfLife < 0 {
+		halfLife = 0
+	}
+	if time.Duration(pmc.decayHalfLife.Swap(int64(halfLife))) == halfLife {
+		return
+	}
+
+	pmc.mutex.RLock()
+	histories := make([]*nodeHistory, 0, len(pmc.nodes))
+	for _, history := range pmc.nodes {
+		histories = append(histories, history)
+	}
+	now := pmc.clock.Now()
+	pmc.mutex.RUnlock()
+
+	for _, history := range histories {
+		history.mutex.Lock()
+		history.rebuildDecay(halfLife, now)
+		history.mutex.Unlock()
+	}
+}
+
+// MemoryUsage cache'in tahmini bellek kullanımını ve bütçesini byte olarak döndürür
+func (pmc *PodMetricsCache) MemoryUsage() (int64, int64) {
+	return pmc.bytes.Load(), pmc.budget.Load()
+}
+
+// TrimmedSamples bütçe nedeniyle atılan toplam örnek sayısını döndürür
+func (pmc *PodMetricsCache) TrimmedSamples() uint64 {
+	return pmc.trimmed.Load()
+}
+
+// enforceBudget bütçe aşıldıysa en uzun süredir okunmayan node'lardan başlayarak
+// her node'un en eski örneklerinin yarısını atar, kullanım hedefin altına inene kadar tekrarlar
+func (pmc *PodMetricsCache) enforceBudget() {
+	budget := pmc.budget.Load()
+	if budget <= 0 || pmc.bytes.Load() <= budget {
+		return
+	}
+	// Aynı anda tek kırpma yeterli, diğer yazıcılar beklemez
+	if !pmc.trimMu.TryLock() {
+		return
+	}
+	defer pmc.trimMu.Unlock()
+
+	pmc.mutex.RLock()
+	histories := make([]*nodeHistory, 0, len(pmc.nodes))
+	for _, history := range pmc.nodes {
+		histories = append(histories, history)
+	}
+	pmc.mutex.RUnlock()
+
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].lastAccess.Load() < histories[j].lastAccess.Load()
+	})
+
+	target := int64(float64(budget) * budgetTrimTarget)
+	for pmc.bytes.Load() > target {
+		freedAny := false
+		for _, history := range histories {
+			history.mutex.Lock()
+			dropped, freed := history.trimOldest((history.live() + 1) / 2)
+			history.mutex.Unlock()
+
+			if dropped > 0 {
+				freedAny = true
+				pmc.bytes.Add(-freed)
+				pmc.trimmed.Add(uint64(dropped))
+			}
+			if pmc.bytes.Load() <= target {
+				return
+			}
+		}
+		if !freedAny {
+			return
+		}
+	}
+}
+
+// UpdateCache cache'i günceller
+func (pmc *PodMetricsCache) UpdateCache(podMetrics PodMetrics) {
+	history, now := pmc.node(podMetrics.NodeName, true)
+
+	history.mutex.Lock()
+
+	// Örnekler collector'dan zaman sırasıyla gelir, pencereler sadece ileri kayar
+	history.samples = append(history.samples, podMetrics)
+	size := sampleSize(&podMetrics)
+	for i := range history.windows {
+		history.windows[i].add(&podMetrics)
+	}
+	history.decay.add(&podMetrics)
+
+	// Pencere dışına çıkanları düş, saklama süresini aşanları temizle
+	freed := history.advance(now)
+	// Yarı ömür değiştiyse veya ref çok eskidiyse ağırlıklı toplamlar yeniden kurulur
+	if halfLife := time.Duration(pmc.decayHalfLife.Load()); history.decay.halfLife != halfLife || now.Sub(history.decay.ref) > PodMetricsRetention {
+		history.rebuildDecay(halfLife, now)
+	}
+	history.lastUpdated = now
+	history.mutex.Unlock()
+	pmc.lastUpdate.Store(now.UnixNano())
+
+	pmc.bytes.Add(size - freed)
+	pmc.enforceBudget()
+}
+
+// Import arşivdeki örnekleri (ör: yedekten) cache'e ekler ve eklenen örnek sayısını döndürür. Örnekler node
+// bazında zamana göre sıralanır; node'un mevcut ilk örneğinden eski olanlar başa eklenir, mevcut örnekler değişmez,
+// saklama süresinin dışındakiler atılır. Pencereler birleşen örneklerden yeniden kurulduğu için canlı cache'e
+// uygulandığında da örnekler zaman sıralı kalır. Son güncelleme zamanı değişmez, içe aktarılan örnekler tazelik
+// denetiminde güncel sayılmaz
+func (pmc *PodMetricsCache) Import(samples []PodMetrics) int {
+	pmc.mutex.RLock()
+	now := pmc.clock.Now()
+	pmc.mutex.RUnlock()
+	cutoffTime := now.Add(-PodMetricsRetention)
+
+	byNode := make(map[string][]PodMetrics)
+	for _, sample := range samples {
+		if sample.Timestamp.After(cutoffTime) {
+			byNode[sample.NodeName] = append(byNode[sample.NodeName], sample)
+		}
+	}
 
-	// Eski verileri temizle (son 7 gün)
-	pmc.cleanOldData(nodeName, 7*24*time.Hour)
+	imported := 0
+	halfLife := time.Duration(pmc.decayHalfLife.Load())
+	for nodeName, nodeSamples := range byNode {
+		sort.SliceStable(nodeSamples, func(i, j int) bool { return nodeSamples[i].Timestamp.Before(nodeSamples[j].Timestamp) })
+		history, _ := pmc.node(nodeName, true)
+
+		history.mutex.Lock()
+		freed := history.compact()
+		var first time.Time
+		if len(history.samples) > 0 {
+			first = history.samples[0].Timestamp
+		}
+		var size int64
+		older := make([]PodMetrics, 0, len(nodeSamples)+len(history.samples))
+		for i := range nodeSamples {
+			if !first.IsZero() && !nodeSamples[i].Timestamp.Before(first) {
+				break
+			}
+			older = append(older, nodeSamples[i])
+			size += sampleSize(&nodeSamples[i])
+		}
+		if len(older) > 0 {
+			history.samples = append(older, history.samples...)
+			freed += history.rebuild(halfLife, now)
+			imported += len(older)
+		}
+		history.mutex.Unlock()
+		pmc.bytes.Add(size - freed)
+	}
+	pmc.enforceBudget()
+	return imported
+}
 
-	// İstatistikleri güncelle
-	pmc.updateStatistics(nodeName)
+// LastUpdate cache'e son örneğin eklendiği anı döndürür, hiç örnek yoksa sıfır
+func (pmc *PodMetricsCache) LastUpdate() time.Time {
+	if at := pmc.lastUpdate.Load(); at != 0 {
+		return time.Unix(0, at)
+	}
+	return time.Time{}
 }
 
 // GetNodeMetrics node için metrikleri döndürür
 func (pmc *PodMetricsCache) GetNodeMetrics(nodeName string) []PodMetrics {
-	pmc.mutex.RLock()
-	defer pmc.mutex.RUnlock()
+	history, now := pmc.node(nodeName, false)
+	if history == nil {
+		return nil
+	}
+
+	history.lastAccess.Store(now.UnixNano())
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
 
-	return pmc.nodePodHistory[nodeName]
+	return history.samples[history.windows[retentionWindow].start:]
+}
+
+// Samples tüm node'ların saklama penceresindeki örneklerini node adına, node içinde zamana göre sıralı kopyalar (yedekleme için)
+func (pmc *PodMetricsCache) Samples() []PodMetrics {
+	pmc.mutex.RLock()
+	names := make([]string, 0, len(pmc.nodes))
+	for name := range pmc.nodes {
+		names = append(names, name)
+	}
+	pmc.mutex.RUnlock()
+	sort.Strings(names)
+
+	var samples []PodMetrics
+	for _, name := range names {
+		history, _ := pmc.node(name, false)
+		if history == nil {
+			continue
+		}
+		history.mutex.RLock()
+		samples = append(samples, history.samples[history.windows[retentionWindow].start:]...)
+		history.mutex.RUnlock()
+	}
+	return samples
 }
 
 // GetFailureRate node'un başarısızlık oranını döndürür
 func (pmc *PodMetricsCache) GetFailureRate(nodeName string) float64 {
-	pmc.mutex.RLock()
-	defer pmc.mutex.RUnlock()
+	history, _ := pmc.node(nodeName, false)
+	if history == nil {
+		return 0
+	}
+
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
 
-	return pmc.failureRates[nodeName]
+	window := history.windows[retentionWindow]
+	if window.count == 0 {
+		return 0
+	}
+	return float64(window.failed) / float64(window.count)
 }
 
 // GetRestartRate node'un restart oranını döndürür
 func (pmc *PodMetricsCache) GetRestartRate(nodeName string) float64 {
-	pmc.mutex.RLock()
-	defer pmc.mutex.RUnlock()
+	history, _ := pmc.node(nodeName, false)
+	if history == nil {
+		return 0
+	}
+
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	window := history.windows[retentionWindow]
+	if window.count == 0 {
+		return 0
+	}
+	return float64(window.restarts) / float64(window.count)
+}
+
+// GetNodeAnalysis node analizi döndürür
+func (pmc *PodMetricsCache) GetNodeAnalysis(nodeName string, timeWindow time.Duration) NodeAnalysis {
+	history, now := pmc.node(nodeName, false)
+	if history == nil {
+		return NodeAnalysis{}
+	}
 
-	return pmc.restartRates[nodeName]
+	history.lastAccess.Store(now.UnixNano())
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	cutoffTime := now.Add(-timeWindow)
+
+	// Artımlı tutulan pencere: son güncellemeden beri dışarı düşen örnekler kopya üzerinde çıkarılır
+	for i, size := range analysisWindows {
+		if size != timeWindow {
+			continue
+		}
+		window := history.windows[i]
+		for window.start < len(history.samples) && !history.samples[window.start].Timestamp.After(cutoffTime) {
+			window.remove(&history.samples[window.start])
+			window.start++
+		}
+		return window.analysis(nodeName, now)
+	}
+
+	// Takip edilmeyen pencereler için tam tarama
+	var window rollingWindow
+	for i := range history.samples {
+		if history.samples[i].Timestamp.After(cutoffTime) {
+			window.add(&history.samples[i])
+		}
+	}
+	return window.analysis(nodeName, now)
+}
+
+// GetNodeAnalysisAt node analizini geçmişteki bir ana göre (at'ten önceki timeWindow içindeki örneklerle) hesaplar.
+// Saklama süresi dışına düşmüş örnekler artık olmadığı için eski anlar eksik örnekle hesaplanır
+func (pmc *PodMetricsCache) GetNodeAnalysisAt(nodeName string, timeWindow time.Duration, at time.Time) NodeAnalysis {
+	history, _ := pmc.node(nodeName, false)
+	if history == nil {
+		return NodeAnalysis{}
+	}
+
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	cutoffTime := at.Add(-timeWindow)
+	start := sort.Search(len(history.samples), func(i int) bool { return history.samples[i].Timestamp.After(cutoffTime) })
+
+	var window rollingWindow
+	for i := start; i < len(history.samples) && !history.samples[i].Timestamp.After(at); i++ {
+		window.add(&history.samples[i])
+	}
+	return window.analysis(nodeName, at)
+}
+
+// GetDecayedNodeAnalysis saklama penceresindeki örnekleri yaşlarına göre üstel azalan ağırlıklarla toplayarak
+// node analizi döndürür: eskiden sorun yaşamış ama günlerdir temiz olan node'un oranları kademeli iyileşir.
+// TotalPods ağırlıksız örnek sayısıdır. Azalma kapalıysa saklama penceresinin eşit ağırlıklı analizi döner
+func (pmc *PodMetricsCache) GetDecayedNodeAnalysis(nodeName string) NodeAnalysis {
+	halfLife := time.Duration(pmc.decayHalfLife.Load())
+	if halfLife <= 0 {
+		return pmc.GetNodeAnalysis(nodeName, PodMetricsRetention)
+	}
+
+	history, now := pmc.node(nodeName, false)
+	if history == nil {
+		return NodeAnalysis{}
+	}
+
+	history.lastAccess.Store(now.UnixNano())
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	live := history.samples[history.windows[retentionWindow].start:]
+	decay := history.decay
+	// Yarı ömür değişip henüz yeni örnek gelmediyse toplamlar kopya üzerinde kurulur
+	if decay.halfLife != halfLife {
+		decay = newDecayedWindow(live, halfLife, now)
+	}
+	return decay.analysis(nodeName, len(live), now)
 }
 
-// cleanOldData eski verileri temizler
-func (pmc *PodMetricsCache) cleanOldData(nodeName string, maxAge time.Duration) {
-	cutoffTime := time.Now().Add(-maxAge)
-	var filteredMetrics []PodMetrics
+// GetDecayedNodeAnalysisAt ağırlıklı node analizini geçmişteki bir ana göre (at'ten önceki saklama penceresindeki
+// örneklerle) hesaplar. Ağırlıklar at'e göre verilir
+func (pmc *PodMetricsCache) GetDecayedNodeAnalysisAt(nodeName string, at time.Time) NodeAnalysis {
+	halfLife := time.Duration(pmc.decayHalfLife.Load())
+	if halfLife <= 0 {
+		return pmc.GetNodeAnalysisAt(nodeName, PodMetricsRetention, at)
+	}
+
+	history, _ := pmc.node(nodeName, false)
+	if history == nil {
+		return NodeAnalysis{}
+	}
+
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
 
-	for _, metric := range pmc.nodePodHistory[nodeName] {
-		if metric.Timestamp.After(cutoffTime) {
-			filteredMetrics = append(filteredMetrics, metric)
+	cutoffTime := at.Add(-PodMetricsRetention)
+	start := sort.Search(len(history.samples), func(i int) bool { return history.samples[i].Timestamp.After(cutoffTime) })
+	end := sort.Search(len(history.samples), func(i int) bool { return history.samples[i].Timestamp.After(at) })
+	if end < start {
+		end = start
+	}
+
+	decay := newDecayedWindow(history.samples[start:end], halfLife, at)
+	return decay.analysis(nodeName, end-start, at)
+}
+
+// rebuild pencereleri ve ağırlıklı toplamları tüm örneklerden baştan kurar, ardından verilen ana göre ileri kaydırır;
+// serbest kalan tahmini byte'ı döndürür
+func (h *nodeHistory) rebuild(halfLife time.Duration, now time.Time) int64 {
+	for i := range h.windows {
+		h.windows[i] = rollingWindow{}
+		for j := range h.samples {
+			h.windows[i].add(&h.samples[j])
 		}
 	}
+	h.decay = newDecayedWindow(h.samples, halfLife, now)
+	freed := h.advance(now)
+	h.rebuildDecay(halfLife, now)
+	return freed
+}
 
-	pmc.nodePodHistory[nodeName] = filteredMetrics
+// rebuildDecay ağırlıklı toplamları saklama penceresindeki örneklerden ref=now ile yeniden kurar
+func (h *nodeHistory) rebuildDecay(halfLife time.Duration, now time.Time) {
+	h.decay = newDecayedWindow(h.samples[h.windows[retentionWindow].start:], halfLife, now)
 }
 
-// updateStatistics node istatistiklerini günceller
-func (pmc *PodMetricsCache) updateStatistics(nodeName string) {
-	metrics := pmc.nodePodHistory[nodeName]
-	if len(metrics) == 0 {
-		return
+// advance pencereleri verilen ana göre ileri kaydırır ve saklama süresi dışındaki örnekleri atar,
+// serbest kalan tahmini byte'ı döndürür
+func (h *nodeHistory) advance(now time.Time) int64 {
+	for i, size := range analysisWindows {
+		window := &h.windows[i]
+		cutoffTime := now.Add(-size)
+		for window.start < len(h.samples) && !h.samples[window.start].Timestamp.After(cutoffTime) {
+			window.remove(&h.samples[window.start])
+			if i == retentionWindow {
+				h.decay.remove(&h.samples[window.start])
+			}
+			window.start++
+		}
+	}
+
+	// Slice'ı her örnekte kopyalamamak için atılacak kısım yarıyı geçince sıkıştırılır
+	if h.windows[retentionWindow].start < len(h.samples)/2 {
+		return 0
 	}
+	return h.compact()
+}
+
+// live saklama penceresindeki örnek sayısını döndürür
+func (h *nodeHistory) live() int {
+	return len(h.samples) - h.windows[retentionWindow].start
+}
 
-	// Başarısızlık oranı hesapla
-	failedPods := 0
-	totalRestarts := 0
-	for _, metric := range metrics {
-		if metric.Status == "Failed" {
-			failedPods++
+// trimOldest pencere durumundan bağımsız olarak en eski n örneği atar,
+// atılan örnek sayısını ve serbest kalan tahmini byte'ı döndürür
+func (h *nodeHistory) trimOldest(n int) (int, int64) {
+	retention := &h.windows[retentionWindow]
+	dropped := 0
+	for ; dropped < n && retention.start < len(h.samples); dropped++ {
+		// Saklama penceresi en geniş penceredir, en eski örneği içeren pencerelerin başı ona eşittir
+		index := retention.start
+		h.decay.remove(&h.samples[index])
+		for i := range h.windows {
+			if window := &h.windows[i]; window.start == index {
+				window.remove(&h.samples[index])
+				window.start++
+			}
 		}
-		totalRestarts += metric.RestartCount
 	}
+	return dropped, h.compact()
+}
 
-	failureRate := float64(failedPods) / float64(len(metrics))
-	restartRate := float64(totalRestarts) / float64(len(metrics))
+// compact saklama penceresi dışında kalan örnekleri slice'tan atar, serbest kalan tahmini byte'ı döndürür
+// Saklama penceresi en geniş penceredir, öncesindeki örnekler hiçbir pencerede değildir
+func (h *nodeHistory) compact() int64 {
+	dropped := h.windows[retentionWindow].start
+	if dropped == 0 {
+		return 0
+	}
 
-	pmc.failureRates[nodeName] = failureRate
-	pmc.restartRates[nodeName] = restartRate
-	pmc.lastUpdated[nodeName] = time.Now()
+	var freed int64
+	for i := 0; i < dropped; i++ {
+		freed += sampleSize(&h.samples[i])
+	}
+	h.samples = append([]PodMetrics(nil), h.samples[dropped:]...)
+	for i := range h.windows {
+		h.windows[i].start -= dropped
+	}
+	// Çıkarma işlemlerinde biriken yuvarlama hataları sıkıştırmada sıfırlanır
+	if len(h.samples) > 0 {
+		h.rebuildDecay(h.decay.halfLife, h.samples[len(h.samples)-1].Timestamp)
+	}
+	return freed
 }
 
-// GetNodeAnalysis node analizi döndürür
-func (pmc *PodMetricsCache) GetNodeAnalysis(nodeName string, timeWindow time.Duration) NodeAnalysis {
-	pmc.mutex.RLock()
-	defer pmc.mutex.RUnlock()
+// sampleSize örneğin bellekteki tahmini boyutunu döndürür (struct + string içerikleri)
+func sampleSize(metric *PodMetrics) int64 {
+	return int64(unsafe.Sizeof(*metric)) + int64(len(metric.PodName)+len(metric.NodeName)+len(metric.Namespace)+len(metric.Status))
+}
 
-	metrics := pmc.nodePodHistory[nodeName]
-	cutoffTime := time.Now().Add(-timeWindow)
+// add örneği pencere toplamlarına ekler, chaos deneyi altındaki örneklerin hata ve restart'ları sayılmaz
+func (w *rollingWindow) add(metric *PodMetrics) {
+	w.count++
+	w.createdSum += float64(metric.CreatedAt.UnixNano()) / float64(time.Second)
+	if metric.Chaos {
+		return
+	}
+	if metric.Status == "Failed" {
+		w.failed++
+	}
+	w.restarts += metric.RestartCount
+}
 
-	var recentMetrics []PodMetrics
-	for _, metric := range metrics {
-		if metric.Timestamp.After(cutoffTime) {
-			recentMetrics = append(recentMetrics, metric)
-		}
+// remove örneği pencere toplamlarından çıkarır
+func (w *rollingWindow) remove(metric *PodMetrics) {
+	w.count--
+	w.createdSum -= float64(metric.CreatedAt.UnixNano()) / float64(time.Second)
+	if metric.Chaos {
+		return
+	}
+	if metric.Status == "Failed" {
+		w.failed--
+	}
+	w.restarts -= metric.RestartCount
+}
+
+// analysis pencere toplamlarından node analizi hesaplar
+func (w *rollingWindow) analysis(nodeName string, now time.Time) NodeAnalysis {
+	if w.count == 0 {
+		return NodeAnalysis{}
+	}
+
+	// Ortalama yaşam süresi = şimdi - ortalama oluşturulma zamanı
+	avgCreated := w.createdSum / float64(w.count)
+	avgLifetime := time.Duration((float64(now.UnixNano())/float64(time.Second) - avgCreated) * float64(time.Second))
+
+	return calculateNodeAnalysis(nodeName, w.count, w.failed, w.restarts, avgLifetime)
+}
+
+// newDecayedWindow örneklerin ref'e göre ağırlıklı toplamlarını kurar
+func newDecayedWindow(samples []PodMetrics, halfLife time.Duration, ref time.Time) decayedWindow {
+	w := decayedWindow{ref: ref, halfLife: halfLife}
+	if halfLife <= 0 {
+		return w
+	}
+	for i := range samples {
+		w.add(&samples[i])
+	}
+	return w
+}
+
+// weight örneğin ref'e göre ağırlığı; ref'ten bir yarı ömür önceki örnek 0.5 sayılır
+func (w *decayedWindow) weight(metric *PodMetrics) float64 {
+	return math.Exp2(float64(metric.Timestamp.Sub(w.ref)) / float64(w.halfLife))
+}
+
+// add örneği ağırlığıyla toplamlara ekler, chaos deneyi altındaki örneklerin hata ve restart'ları sayılmaz
+func (w *decayedWindow) add(metric *PodMetrics) {
+	if w.halfLife <= 0 {
+		return
+	}
+	weight := w.weight(metric)
+	w.count += weight
+	w.createdSum += weight * metric.CreatedAt.Sub(w.ref).Seconds()
+	if metric.Chaos {
+		return
+	}
+	if metric.Status == "Failed" {
+		w.failed += weight
+	}
+	w.restarts += weight * float64(metric.RestartCount)
+}
+
+// remove örneği ağırlığıyla toplamlardan çıkarır
+func (w *decayedWindow) remove(metric *PodMetrics) {
+	if w.halfLife <= 0 {
+		return
+	}
+	weight := w.weight(metric)
+	w.count -= weight
+	w.createdSum -= weight * metric.CreatedAt.Sub(w.ref).Seconds()
+	if metric.Chaos {
+		return
+	}
+	if metric.Status == "Failed" {
+		w.failed -= weight
 	}
+	w.restarts -= weight * float64(metric.RestartCount)
+}
+
+// analysis ağırlıklı toplamlardan node analizi hesaplar, totalPods ağırlıksız örnek sayısıdır
+func (w *decayedWindow) analysis(nodeName string, totalPods int, now time.Time) NodeAnalysis {
+	if totalPods == 0 || w.count <= 0 {
+		return NodeAnalysis{}
+	}
+
+	// Çıkarmalardaki yuvarlama hataları oranları 0-1 dışına taşımasın
+	failureRate := math.Max(0, math.Min(1, w.failed/w.count))
+	avgRestartCount := math.Max(0, w.restarts/w.count)
+	avgCreated := w.ref.Add(time.Duration(w.createdSum / w.count * float64(time.Second)))
+	failedPods := int(math.Round(failureRate * float64(totalPods)))
 
-	return calculateNodeAnalysis(recentMetrics)
+	return nodeAnalysisFromRates(nodeName, totalPods, failedPods, failureRate, avgRestartCount, now.Sub(avgCreated))
 }
 
 // NodeAnalysis node analiz sonucu
@@ -133,28 +707,41 @@ type NodeAnalysis struct {
 	Recommendations     []string
 }
 
-// calculateNodeAnalysis node analizi hesaplar
-func calculateNodeAnalysis(metrics []PodMetrics) NodeAnalysis {
-	if len(metrics) == 0 {
+// BlendNodeAnalyses kısadan uzuna sıralı pencere analizlerini örnek sayılarına göre güvenle harmanlar. Oranlar
+// prior'dan başlar, uzun pencereden kısaya her pencere n/(n+confidenceSamples) güveniyle kendi oranına çeker:
+// az örnekli kısa pencere uzun pencerenin, az örnekli node prior'un oranlarında kalır. TotalPods ve FailedPods en
+// uzun penceredendir; hiç örnek yoksa boş analiz döner
+func BlendNodeAnalyses(nodeName string, analyses []NodeAnalysis, prior NodeAnalysis, confidenceSamples int) NodeAnalysis {
+	if len(analyses) == 0 || analyses[len(analyses)-1].TotalPods == 0 {
 		return NodeAnalysis{}
 	}
-
-	failedPods := 0
-	totalRestarts := 0
-	var totalLifetime time.Duration
-
-	for _, metric := range metrics {
-		if metric.Status == "Failed" {
-			failedPods++
+	longest := analyses[len(analyses)-1]
+
+	failureRate, avgRestartCount := prior.FailureRate, prior.AverageRestartCount
+	avgLifetime := float64(longest.AverageLifetime)
+	for i := len(analyses) - 1; i >= 0; i-- {
+		analysis := &analyses[i]
+		if analysis.TotalPods == 0 {
+			continue
 		}
-		totalRestarts += metric.RestartCount
-		totalLifetime += time.Since(metric.CreatedAt)
+		confidence := float64(analysis.TotalPods) / float64(analysis.TotalPods+confidenceSamples)
+		failureRate += confidence * (analysis.FailureRate - failureRate)
+		avgRestartCount += confidence * (analysis.AverageRestartCount - avgRestartCount)
+		avgLifetime += confidence * (float64(analysis.AverageLifetime) - avgLifetime)
 	}
+	return nodeAnalysisFromRates(nodeName, longest.TotalPods, longest.FailedPods, failureRate, avgRestartCount, time.Duration(avgLifetime))
+}
 
-	failureRate := float64(failedPods) / float64(len(metrics))
-	avgRestartCount := float64(totalRestarts) / float64(len(metrics))
-	avgLifetime := totalLifetime / time.Duration(len(metrics))
+// calculateNodeAnalysis node analizi hesaplar
+func calculateNodeAnalysis(nodeName string, totalPods, failedPods, totalRestarts int, avgLifetime time.Duration) NodeAnalysis {
+	failureRate := float64(failedPods) / float64(totalPods)
+	avgRestartCount := float64(totalRestarts) / float64(totalPods)
+
+	return nodeAnalysisFromRates(nodeName, totalPods, failedPods, failureRate, avgRestartCount, avgLifetime)
+}
 
+// nodeAnalysisFromRates başarısızlık ve ortalama restart oranlarından kararlılık skorunu ve önerileri hesaplar
+func nodeAnalysisFromRates(nodeName string, totalPods, failedPods int, failureRate, avgRestartCount float64, avgLifetime time.Duration) NodeAnalysis {
 	// Kararlılık skoru (0-1 arası)
 	stabilityScore := 1.0 - failureRate - (avgRestartCount * 0.1)
 
@@ -171,10 +758,10 @@ func calculateNodeAnalysis(metrics []PodMetrics) NodeAnalysis {
 	}
 
 	return NodeAnalysis{
-		NodeName:            metrics[0].NodeName,
-		TotalPods:           len(metrics),
+		NodeName:            nodeName,
+		TotalPods:           totalPods,
 		FailedPods:          failedPods,
-		SuccessfulPods:      len(metrics) - failedPods,
+		SuccessfulPods:      totalPods - failedPods,
 		FailureRate:         failureRate,
 		AverageRestartCount: avgRestartCount,
 		AverageLifetime:     avgLifetime,
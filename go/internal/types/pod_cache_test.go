@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// podSample node-1'de at anında gözlenen pod örneği
+func podSample(pod, status string, at time.Time) PodMetrics {
+	return PodMetrics{PodName: pod, Namespace: "default", NodeName: "node-1", Status: status, Timestamp: at, CreatedAt: at}
+}
+
+func TestPodMetricsCacheImportIntoLiveCache(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewManualClock(base.Add(-2 * time.Hour))
+	cache := NewPodMetricsCache()
+	cache.SetClock(clock)
+
+	// Canlı cache son iki saatte toplanmış örnekleri içerir
+	for _, at := range []time.Duration{-2 * time.Hour, -30 * time.Minute, -10 * time.Minute} {
+		clock.Set(base.Add(at))
+		cache.UpdateCache(podSample("live", "Running", base.Add(at)))
+	}
+	lastUpdate := cache.LastUpdate()
+	before := cache.GetNodeAnalysis("node-1", time.Hour)
+
+	// Yedek sırasız; canlı örneklerle çakışan ve saklama süresi dışındaki örnekler eklenmez
+	archive := []PodMetrics{
+		podSample("archived-2", "Failed", base.Add(-3*time.Hour)),
+		podSample("archived-1", "Failed", base.Add(-5*time.Hour)),
+		podSample("overlap", "Failed", base.Add(-20*time.Minute)),
+		podSample("expired", "Failed", base.Add(-PodMetricsRetention-time.Hour)),
+	}
+	if imported := cache.Import(archive); imported != 2 {
+		t.Fatalf("Import = %d, beklenen 2", imported)
+	}
+
+	samples := cache.GetNodeMetrics("node-1")
+	want := []string{"archived-1", "archived-2", "live", "live", "live"}
+	if len(samples) != len(want) {
+		t.Fatalf("%d örnek, beklenen %d", len(samples), len(want))
+	}
+	for i := range samples {
+		if samples[i].PodName != want[i] {
+			t.Errorf("örnek %d = %s, beklenen %s", i, samples[i].PodName, want[i])
+		}
+		if i > 0 && samples[i].Timestamp.Before(samples[i-1].Timestamp) {
+			t.Errorf("örnekler zaman sırasında değil: %s, %s", samples[i-1].Timestamp, samples[i].Timestamp)
+		}
+	}
+
+	// Son saatin penceresi eski örneklerden etkilenmez, daha geniş pencereler onları sayar
+	if after := cache.GetNodeAnalysis("node-1", time.Hour); after.TotalPods != before.TotalPods || after.FailedPods != 0 {
+		t.Errorf("son saat: %d pod, %d başarısız; beklenen %d pod, 0 başarısız", after.TotalPods, after.FailedPods, before.TotalPods)
+	}
+	if day := cache.GetNodeAnalysis("node-1", 24*time.Hour); day.TotalPods != 5 || day.FailedPods != 2 {
+		t.Errorf("son gün: %d pod, %d başarısız; beklenen 5 pod, 2 başarısız", day.TotalPods, day.FailedPods)
+	}
+	if rate := cache.GetFailureRate("node-1"); rate != 0.4 {
+		t.Errorf("başarısızlık oranı %.2f, beklenen 0.40", rate)
+	}
+	if !cache.LastUpdate().Equal(lastUpdate) {
+		t.Errorf("son güncelleme %s oldu, içe aktarma değiştirmemeliydi", cache.LastUpdate())
+	}
+
+	// Pencereler sonraki güncellemelerde eski örnekleri doğru sırayla düşer
+	clock.Set(base.Add(4*time.Hour + 30*time.Minute))
+	cache.UpdateCache(podSample("later", "Running", base.Add(4*time.Hour+30*time.Minute)))
+	if window := cache.GetNodeAnalysis("node-1", 6*time.Hour); window.TotalPods != 3 || window.FailedPods != 0 {
+		t.Errorf("son 6 saat: %d pod, %d başarısız; beklenen 3 pod, 0 başarısız", window.TotalPods, window.FailedPods)
+	}
+	if day := cache.GetNodeAnalysis("node-1", 24*time.Hour); day.TotalPods != 6 || day.FailedPods != 2 {
+		t.Errorf("son gün: %d pod, %d başarısız; beklenen 6 pod, 2 başarısız", day.TotalPods, day.FailedPods)
+	}
+}
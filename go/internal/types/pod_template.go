@@ -0,0 +1,62 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// templateContainer şablon özetine giren container alanları
+type templateContainer struct {
+	Init      bool                `json:"init,omitempty"`
+	Name      string              `json:"name"`
+	Image     string              `json:"image"`
+	Requests  corev1.ResourceList `json:"requests,omitempty"`
+	Limits    corev1.ResourceList `json:"limits,omitempty"`
+	Liveness  *corev1.Probe       `json:"liveness,omitempty"`
+	Readiness *corev1.Probe       `json:"readiness,omitempty"`
+	Startup   *corev1.Probe       `json:"startup,omitempty"`
+}
+
+// TemplateFingerprint pod şablonunun içerik özetini döndürür: container imajları, kaynak istek/limitleri ve
+// probe'lar. Controller'dan ve revizyondan bağımsızdır; aynı içerikli şablonlar farklı iş yüklerinde de aynı özeti
+// alır, label veya annotation değişikliği özeti değiştirmez
+func TemplateFingerprint(pod *corev1.Pod) string {
+	containers := make([]templateContainer, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	add := func(container *corev1.Container, init bool) {
+		containers = append(containers, templateContainer{
+			Init:      init,
+			Name:      container.Name,
+			Image:     container.Image,
+			Requests:  container.Resources.Requests,
+			Limits:    container.Resources.Limits,
+			Liveness:  container.LivenessProbe,
+			Readiness: container.ReadinessProbe,
+			Startup:   container.StartupProbe,
+		})
+	}
+	for i := range pod.Spec.InitContainers {
+		add(&pod.Spec.InitContainers[i], true)
+	}
+	for i := range pod.Spec.Containers {
+		add(&pod.Spec.Containers[i], false)
+	}
+	// Container sırası şablonun içeriğini değiştirmez (init container'lar sıralı çalıştığından kendi sıralarında kalır)
+	sort.SliceStable(containers, func(i, j int) bool {
+		if containers[i].Init || containers[j].Init {
+			return containers[i].Init && !containers[j].Init
+		}
+		return containers[i].Name < containers[j].Name
+	})
+
+	// Quantity'ler kanonik biçimde, map anahtarları sıralı kodlanır
+	data, err := json.Marshal(containers)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodFootprint pod'un Kubernetes kurallarına göre node'da ayırdığı kaynaklar ve bileşenleri (CPU core, memory GB)
+type PodFootprint struct {
+	// CPU ve Memory node'a yerleşim için etkin istek: max(uygulama + sidecar, init tepe) + overhead
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory_gb"`
+	// Uygulama container'larının toplamı
+	ContainerCPU    float64 `json:"container_cpu"`
+	ContainerMemory float64 `json:"container_memory_gb"`
+	// Sidecar'lar (restartPolicy: Always olan init container'lar) pod boyunca çalışır
+	SidecarCPU    float64 `json:"sidecar_cpu,omitempty"`
+	SidecarMemory float64 `json:"sidecar_memory_gb,omitempty"`
+	// Init tepe: sıradaki init container'ın isteği ile o ana kadar başlamış sidecar'ların toplamının en büyüğü
+	InitCPU    float64 `json:"init_cpu,omitempty"`
+	InitMemory float64 `json:"init_memory_gb,omitempty"`
+	// Overhead pod.spec.overhead (RuntimeClass'ın sandbox maliyeti)
+	OverheadCPU    float64 `json:"overhead_cpu,omitempty"`
+	OverheadMemory float64 `json:"overhead_memory_gb,omitempty"`
+}
+
+// PodResourceFootprint pod'un etkin kaynak isteğini kube-scheduler ile aynı kurallarla hesaplar: init container'lar
+// sırayla çalıştığından en büyüğü, uygulama container'ları birlikte çalıştığından toplamı alınır. Sidecar'lar
+// kendilerinden sonraki init container'larla ve uygulama container'larıyla birlikte çalışır. Overhead eklenir
+func PodResourceFootprint(pod *corev1.Pod) PodFootprint {
+	var footprint PodFootprint
+	for i := range pod.Spec.Containers {
+		cpu, memory := requestsOf(pod.Spec.Containers[i].Resources.Requests)
+		footprint.ContainerCPU += cpu
+		footprint.ContainerMemory += memory
+	}
+
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		cpu, memory := requestsOf(container.Resources.Requests)
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			footprint.SidecarCPU += cpu
+			footprint.SidecarMemory += memory
+			cpu, memory = 0, 0
+		}
+		footprint.InitCPU = math.Max(footprint.InitCPU, footprint.SidecarCPU+cpu)
+		footprint.InitMemory = math.Max(footprint.InitMemory, footprint.SidecarMemory+memory)
+	}
+
+	footprint.OverheadCPU, footprint.OverheadMemory = requestsOf(pod.Spec.Overhead)
+	footprint.CPU = math.Max(footprint.ContainerCPU+footprint.SidecarCPU, footprint.InitCPU) + footprint.OverheadCPU
+	footprint.Memory = math.Max(footprint.ContainerMemory+footprint.SidecarMemory, footprint.InitMemory) +
+		footprint.OverheadMemory
+	return footprint
+}
+
+// PodResourceRequests pod'un etkin CPU (core) ve memory (GB) isteklerini döndürür (bkz. PodResourceFootprint)
+func PodResourceRequests(pod *corev1.Pod) (float64, float64) {
+	footprint := PodResourceFootprint(pod)
+	return footprint.CPU, footprint.Memory
+}
+
+// requestsOf kaynak listesindeki CPU (core) ve memory (GB) değerlerini döndürür
+func requestsOf(resources corev1.ResourceList) (float64, float64) {
+	var cpu, memory float64
+	if request, ok := resources[corev1.ResourceCPU]; ok {
+		cpu = float64(request.MilliValue()) / 1000.0
+	}
+	if request, ok := resources[corev1.ResourceMemory]; ok {
+		memory = float64(request.Value()) / (1024 * 1024 * 1024) // GB
+	}
+	return cpu, memory
+}
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// Restart fırtınası tespitinin varsayılanları
+const (
+	defaultStormNodeRatio = 0.5
+	defaultStormMinNodes  = 3
+	defaultStormFreeze    = 10 * time.Minute
+)
+
+// PodRestartSample bir toplama turunda pod'un toplam restart sayısı ve durumu
+type PodRestartSample struct {
+	Key      string // namespace/pod
+	NodeName string
+	Restarts int
+	Failed   bool
+}
+
+// RestartStormStatus fırtına tespitinin durumu
+type RestartStormStatus struct {
+	Frozen        bool      `json:"frozen"`
+	FrozenUntil   time.Time `json:"frozen_until,omitempty"`
+	LastStorm     time.Time `json:"last_storm,omitempty"`
+	AffectedNodes int       `json:"affected_nodes,omitempty"` // Son fırtınada pod'ları restart olan node sayısı
+	Storms        uint64    `json:"storms"`
+}
+
+// podRestarts pod'un son gözlenen restart sayısı ve fırtına sırasında olduğu için kararlılığa sayılmayan kısmı
+type podRestarts struct {
+	last      int
+	failed    bool
+	forgiven  int  // Fırtınalarda artan restart'lar, sonraki örneklerden de düşülür
+	excused   bool // Fırtına sırasında Failed oldu, hatası kararlılığa sayılmaz
+	seenRound uint64
+}
+
+// RestartStormDetector küme genelinde aynı anda çok sayıda node'da pod restart'ı görülmesini (ör: control-plane
+// kesintisi) tespit eder. Fırtına sürerken ve sonrasında Freeze boyunca artan restart'lar ve Failed olan pod'lar
+// node kararlılık geçmişine yazılmaz; böylece geçici küresel bir olay tüm node'ların geçmişini kalıcı bozmaz
+type RestartStormDetector struct {
+	mutex   sync.Mutex
+	config  RestartStormConfig
+	pods    map[string]*podRestarts
+	round   uint64 // Pod'ların son görüldüğü turu ayırt etmek için
+	status  RestartStormStatus
+	onStorm func(start, until time.Time, affected, nodes int)
+}
+
+// NewRestartStormDetector yeni fırtına dedektörü oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewRestartStormDetector(stormConfig *RestartStormConfig) *RestartStormDetector {
+	d := &RestartStormDetector{pods: make(map[string]*podRestarts)}
+	d.Configure(stormConfig)
+	return d
+}
+
+// Configure tespit eşiklerini değiştirir, süren dondurma korunur
+func (d *RestartStormDetector) Configure(stormConfig *RestartStormConfig) {
+	cfg := *stormConfig
+	if cfg.NodeRatio <= 0 || cfg.NodeRatio > 1 {
+		cfg.NodeRatio = defaultStormNodeRatio
+	}
+	if cfg.MinNodes <= 0 {
+		cfg.MinNodes = defaultStormMinNodes
+	}
+	if cfg.Freeze <= 0 {
+		cfg.Freeze = defaultStormFreeze
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.config = cfg
+}
+
+// OnStorm yeni fırtına başladığında çağrılacak fonksiyonu ayarlar (ör: küme olay günlüğü)
+func (d *RestartStormDetector) OnStorm(fn func(start, until time.Time, affected, nodes int)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.onStorm = fn
+}
+
+// Observe toplama turundaki pod'ların restart sayılarını önceki turla karşılaştırır. Pod'u restart olan (veya
+// Failed'a geçen) node'ların oranı eşiği aşarsa dondurma başlar ya da uzar. Dondurma sürerken artan restart'lar
+// affedilir; bu turda görülmeyen pod'lar unutulur. Dondurma sürüyorsa true döner
+func (d *RestartStormDetector) Observe(samples []PodRestartSample, now time.Time) bool {
+	d.mutex.Lock()
+	cfg := d.config
+	if !cfg.Enabled {
+		d.mutex.Unlock()
+		return false
+	}
+
+	d.round++
+	nodes := make(map[string]bool)
+	affected := make(map[string]bool)
+	type increase struct {
+		pod    *podRestarts
+		delta  int
+		failed bool
+	}
+	increases := make([]increase, 0)
+	for _, sample := range samples {
+		if sample.NodeName == "" {
+			continue
+		}
+		nodes[sample.NodeName] = true
+
+		// İlk gözlemde artış sayılmaz
+		pod, seen := d.pods[sample.Key]
+		if !seen {
+			pod = &podRestarts{last: sample.Restarts, failed: sample.Failed}
+			d.pods[sample.Key] = pod
+		}
+		pod.seenRound = d.round
+
+		delta := sample.Restarts - pod.last
+		failed := sample.Failed && !pod.failed
+		pod.last, pod.failed = sample.Restarts, sample.Failed
+		if delta > 0 || failed {
+			affected[sample.NodeName] = true
+			increases = append(increases, increase{pod: pod, delta: delta, failed: failed})
+		}
+	}
+	for key, pod := range d.pods {
+		if pod.seenRound != d.round {
+			delete(d.pods, key)
+		}
+	}
+
+	var started bool
+	if len(affected) >= cfg.MinNodes && float64(len(affected)) >= cfg.NodeRatio*float64(len(nodes)) {
+		started = !d.frozenLocked(now)
+		d.status.FrozenUntil = now.Add(cfg.Freeze)
+		d.status.LastStorm = now
+		d.status.AffectedNodes = len(affected)
+		if started {
+			d.status.Storms++
+		}
+	}
+
+	frozen := d.frozenLocked(now)
+	if frozen {
+		for _, inc := range increases {
+			if inc.delta > 0 {
+				inc.pod.forgiven += inc.delta
+			}
+			if inc.failed {
+				inc.pod.excused = true
+			}
+		}
+	}
+	onStorm, until := d.onStorm, d.status.FrozenUntil
+	d.mutex.Unlock()
+
+	if started && onStorm != nil {
+		onStorm(now, until, len(affected), len(nodes))
+	}
+	return frozen
+}
+
+// Adjust pod'un örneğe yazılacak restart sayısını (fırtınalarda artanlar düşülmüş) ve hatasının kararlılığa
+// sayılmaması gerekip gerekmediğini döndürür
+func (d *RestartStormDetector) Adjust(key string, restarts int) (int, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	pod, ok := d.pods[key]
+	if !ok {
+		return restarts, false
+	}
+	if restarts -= pod.forgiven; restarts < 0 {
+		restarts = 0
+	}
+	return restarts, pod.excused
+}
+
+// Status fırtına tespitinin durumunu döndürür
+func (d *RestartStormDetector) Status(now time.Time) RestartStormStatus {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status := d.status
+	status.Frozen = d.frozenLocked(now)
+	return status
+}
+
+// frozenLocked dondurma sürüyorsa true döner
+func (d *RestartStormDetector) frozenLocked(now time.Time) bool {
+	return now.Before(d.status.FrozenUntil)
+}
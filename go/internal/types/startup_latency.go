@@ -0,0 +1,233 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Başlatma gecikmesi takibinin varsayılanları
+const (
+	defaultStartupSmoothing = 0.2
+	defaultStartupRetention = 7 * 24 * time.Hour
+)
+
+// Sandbox ve ağ (CNI) kurulumu tamamlandığında kubelet'in yazdığı pod koşulları.
+// PodHasNetwork 1.25-1.28 sürümlerindeki eski adıdır
+const (
+	PodReadyToStartContainers corev1.PodConditionType = "PodReadyToStartContainers"
+	PodHasNetwork             corev1.PodConditionType = "PodHasNetwork"
+)
+
+// PodStartup pod'un binding'den container'ların başlamasına kadar geçen sürenin aşamaları
+type PodStartup struct {
+	Admission time.Duration // Binding -> kubelet kabulü (Initialized), init container'lı pod'larda 0
+	Network   time.Duration // Sandbox ve CNI kurulumu, koşul yoksa 0
+	ImagePull time.Duration // Image çekme ve container oluşturma (son container başlangıcına kadar)
+	Total     time.Duration
+}
+
+// PodStartupOf pod koşulları ve container durumlarından başlatma aşamalarını çıkarır.
+// Binding zamanı bilinmeyen, container'ları başlamamış veya restart etmiş pod'lar için false döner
+func PodStartupOf(pod *corev1.Pod) (PodStartup, bool) {
+	if pod.Spec.NodeName == "" || len(pod.Status.ContainerStatuses) == 0 {
+		return PodStartup{}, false
+	}
+
+	// Restart sonrası StartedAt son başlangıcı gösterir, ilk başlatma ölçülemez
+	var started time.Time
+	for i := range pod.Status.ContainerStatuses {
+		status := &pod.Status.ContainerStatuses[i]
+		if status.RestartCount > 0 {
+			return PodStartup{}, false
+		}
+
+		var startedAt time.Time
+		switch {
+		case status.State.Running != nil:
+			startedAt = status.State.Running.StartedAt.Time
+		case status.State.Terminated != nil:
+			startedAt = status.State.Terminated.StartedAt.Time
+		}
+		if startedAt.IsZero() {
+			return PodStartup{}, false
+		}
+		if startedAt.After(started) {
+			started = startedAt
+		}
+	}
+
+	scheduled := podConditionTime(pod, corev1.PodScheduled)
+	if scheduled.IsZero() || started.Before(scheduled) {
+		return PodStartup{}, false
+	}
+
+	startup := PodStartup{Total: started.Sub(scheduled)}
+	ready := scheduled
+
+	// Init container yoksa Initialized kubelet pod'u kabul edip ilk durumu yazdığında set edilir
+	if len(pod.Spec.InitContainers) == 0 {
+		if initialized := podConditionTime(pod, corev1.PodInitialized); !initialized.Before(ready) {
+			startup.Admission = initialized.Sub(ready)
+			ready = initialized
+		}
+	}
+
+	network := podConditionTime(pod, PodReadyToStartContainers)
+	if network.IsZero() {
+		network = podConditionTime(pod, PodHasNetwork)
+	}
+	if !network.IsZero() && !network.Before(ready) && !network.After(started) {
+		startup.Network = network.Sub(ready)
+		ready = network
+	}
+
+	startup.ImagePull = started.Sub(ready)
+	return startup, true
+}
+
+// podConditionTime pod koşulu True ise son geçiş zamanını, değilse sıfır döndürür
+func podConditionTime(pod *corev1.Pod, conditionType corev1.PodConditionType) time.Time {
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+// NodeStartupLatency node'daki pod başlatma aşamalarının üstel ortalaması
+type NodeStartupLatency struct {
+	NodeName  string        `json:"node_name"`
+	Samples   int           `json:"samples"`
+	Admission time.Duration `json:"admission"`
+	Network   time.Duration `json:"network"`
+	ImagePull time.Duration `json:"image_pull"`
+	Total     time.Duration `json:"total"`
+	Updated   time.Time     `json:"updated"`
+}
+
+// StartupLatencyTracker her pod'un ilk başlatma süresini bir kez ölçüp node bazında ortalar
+type StartupLatencyTracker struct {
+	mutex     sync.RWMutex
+	nodes     map[string]*NodeStartupLatency
+	seen      map[string]time.Time // Ölçülmüş pod'lar -> son görülme
+	smoothing float64
+	retention time.Duration
+}
+
+// NewStartupLatencyTracker yeni başlatma gecikmesi takipçisi oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewStartupLatencyTracker(startupConfig *StartupLatencyConfig) *StartupLatencyTracker {
+	t := &StartupLatencyTracker{
+		nodes: make(map[string]*NodeStartupLatency),
+		seen:  make(map[string]time.Time),
+	}
+	t.Configure(startupConfig)
+	return t
+}
+
+// Configure ortalama katsayısını ve saklama süresini değiştirir, mevcut ortalamalar korunur
+func (t *StartupLatencyTracker) Configure(startupConfig *StartupLatencyConfig) {
+	smoothing := startupConfig.SmoothingFactor
+	if smoothing <= 0 || smoothing > 1 {
+		smoothing = defaultStartupSmoothing
+	}
+	retention := startupConfig.Retention
+	if retention <= 0 {
+		retention = defaultStartupRetention
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.smoothing = smoothing
+	t.retention = retention
+}
+
+// Record pod daha önce ölçülmediyse başlatma süresini node ortalamasına ekler
+func (t *StartupLatencyTracker) Record(pod *corev1.Pod, now time.Time) {
+	key := string(pod.UID)
+	if key == "" {
+		key = pod.Namespace + "/" + pod.Name
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.seen[key]; ok {
+		t.seen[key] = now
+		return
+	}
+
+	startup, ok := PodStartupOf(pod)
+	if !ok {
+		return
+	}
+	t.seen[key] = now
+
+	node, ok := t.nodes[pod.Spec.NodeName]
+	if !ok {
+		t.nodes[pod.Spec.NodeName] = &NodeStartupLatency{
+			NodeName:  pod.Spec.NodeName,
+			Samples:   1,
+			Admission: startup.Admission,
+			Network:   startup.Network,
+			ImagePull: startup.ImagePull,
+			Total:     startup.Total,
+			Updated:   now,
+		}
+		return
+	}
+
+	node.Samples++
+	node.Admission = smooth(node.Admission, startup.Admission, t.smoothing)
+	node.Network = smooth(node.Network, startup.Network, t.smoothing)
+	node.ImagePull = smooth(node.ImagePull, startup.ImagePull, t.smoothing)
+	node.Total = smooth(node.Total, startup.Total, t.smoothing)
+	node.Updated = now
+}
+
+// smooth üstel ortalamayı yeni örnekle günceller
+func smooth(average, sample time.Duration, factor float64) time.Duration {
+	return average + time.Duration(factor*float64(sample-average))
+}
+
+// Expire saklama süresi boyunca görülmeyen pod kayıtlarını ve güncellenmeyen node'ları siler
+func (t *StartupLatencyTracker) Expire(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cutoff := now.Add(-t.retention)
+	for key, seen := range t.seen {
+		if seen.Before(cutoff) {
+			delete(t.seen, key)
+		}
+	}
+	for name, node := range t.nodes {
+		if node.Updated.Before(cutoff) {
+			delete(t.nodes, name)
+		}
+	}
+}
+
+// Node node'un başlatma gecikmesi ortalamasını döndürür, ölçüm yoksa false
+func (t *StartupLatencyTracker) Node(nodeName string) (NodeStartupLatency, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	node, ok := t.nodes[nodeName]
+	if !ok {
+		return NodeStartupLatency{}, false
+	}
+	return *node, true
+}
+
+// SetNode node'un ortalamasını verilenle değiştirir (ör: replay'de kaydedilen ortalama)
+func (t *StartupLatencyTracker) SetNode(latency NodeStartupLatency) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes[latency.NodeName] = &latency
+}
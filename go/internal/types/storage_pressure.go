@@ -0,0 +1,91 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NodeStorageIO node disklerinin IO kullanımı ve buna göre hesaplanan doygunluk
+type NodeStorageIO struct {
+	NodeName       string  `json:"node_name"`
+	Utilization    float64 `json:"utilization"` // En meşgul diskin IO'da geçirdiği zaman oranı (0-1)
+	IOPS           float64 `json:"iops"`
+	ThroughputMBps float64 `json:"throughput_mbps"`
+	// Saturation 0-1, kullanım oranı ile tanımlıysa IOPS ve throughput limitlerine oranların en büyüğü
+	Saturation float64 `json:"saturation"`
+}
+
+// StoragePressureTracker son disk IO toplamasının node bazında sonuçlarını tutar
+type StoragePressureTracker struct {
+	mutex   sync.RWMutex
+	nodes   map[string]NodeStorageIO
+	updated time.Time
+}
+
+// NewStoragePressureTracker yeni disk IO takipçisi oluşturur
+func NewStoragePressureTracker() *StoragePressureTracker {
+	return &StoragePressureTracker{nodes: make(map[string]NodeStorageIO)}
+}
+
+// Record node ölçümlerinin doygunluğunu hesaplar ve önceki toplamanın yerine kaydeder
+func (t *StoragePressureTracker) Record(samples []NodeStorageIO, storageConfig *StorageConfig, now time.Time) {
+	nodes := make(map[string]NodeStorageIO, len(samples))
+	for _, node := range samples {
+		saturation := node.Utilization
+		if storageConfig.MaxIOPS > 0 {
+			saturation = math.Max(saturation, node.IOPS/storageConfig.MaxIOPS)
+		}
+		if storageConfig.MaxThroughputMBps > 0 {
+			saturation = math.Max(saturation, node.ThroughputMBps/storageConfig.MaxThroughputMBps)
+		}
+		node.Saturation = math.Max(0, math.Min(1, saturation))
+		nodes[node.NodeName] = node
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes = nodes
+	t.updated = now
+}
+
+// SetNode node'un disk IO durumunu doygunluğu yeniden hesaplamadan kaydeder (ör: replay'de kaydedilen durum)
+func (t *StoragePressureTracker) SetNode(node NodeStorageIO) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes[node.NodeName] = node
+}
+
+// Clear tüm sonuçları siler (toplama kapatıldığında veya başarısız olduğunda ceza uygulanmaz)
+func (t *StoragePressureTracker) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.nodes = make(map[string]NodeStorageIO)
+}
+
+// Node node'un son toplamadaki disk IO durumunu döndürür, ölçüm yoksa false
+func (t *StoragePressureTracker) Node(nodeName string) (NodeStorageIO, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	node, ok := t.nodes[nodeName]
+	return node, ok
+}
+
+// Nodes tüm node'ların disk IO durumunu node adına göre sıralı ve son toplama zamanıyla döndürür
+func (t *StoragePressureTracker) Nodes() ([]NodeStorageIO, time.Time) {
+	t.mutex.RLock()
+	result := make([]NodeStorageIO, 0, len(t.nodes))
+	for _, node := range t.nodes {
+		result = append(result, node)
+	}
+	updated := t.updated
+	t.mutex.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].NodeName < result[j].NodeName })
+	return result, updated
+}
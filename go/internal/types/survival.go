@@ -0,0 +1,125 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// Sağkalım eğrisinin raporlanan ufukları
+const (
+	SurvivalShortHorizon = time.Hour
+	SurvivalLongHorizon  = 24 * time.Hour
+)
+
+// SurvivalEstimate node'daki pod'ların yaşam sürelerinden Kaplan-Meier yöntemiyle tahmin edilen sağkalım eğrisi.
+// Hâlâ çalışan veya başarıyla biten pod'lar gözlendikleri son ana kadar "hayatta" sayılır (sansürlü gözlem), chaos
+// deneyi altındaki başarısızlıklar sayılmaz
+type SurvivalEstimate struct {
+	// Pods gözlenen pod sayısı, Failures bunlardan başarısız olanlar
+	Pods     int `json:"pods"`
+	Failures int `json:"failures"`
+	// Survival1h ve Survival24h pod'un node'da 1 ve 24 saat başarısız olmadan çalışma olasılığı. Gözlem yoksa 1
+	Survival1h  float64 `json:"survival_1h"`
+	Survival24h float64 `json:"survival_24h"`
+}
+
+// survivalObservation pod'un node'daki yaşam süresi ve başarısız olup olmadığı
+type survivalObservation struct {
+	duration time.Duration
+	failed   bool
+}
+
+// survivalCache node'un son hesaplanan sağkalım tahmini, örnekler değişince yeniden hesaplanır
+type survivalCache struct {
+	estimate *SurvivalEstimate
+	samples  int
+	updated  time.Time
+}
+
+// GetNodeSurvival node'un saklama penceresindeki pod geçmişinden sağkalım tahminini döndürür
+func (pmc *PodMetricsCache) GetNodeSurvival(nodeName string) SurvivalEstimate {
+	history, now := pmc.node(nodeName, false)
+	if history == nil {
+		return estimateSurvival(nil)
+	}
+
+	history.lastAccess.Store(now.UnixNano())
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	cache := &history.survival
+	if cache.estimate != nil && cache.samples == history.live() && cache.updated.Equal(history.lastUpdated) {
+		return *cache.estimate
+	}
+	estimate := estimateSurvival(podObservations(history.samples[history.windows[retentionWindow].start:]))
+	*cache = survivalCache{estimate: &estimate, samples: history.live(), updated: history.lastUpdated}
+	return estimate
+}
+
+// podObservations örnekleri pod başına tek gözleme indirger: pod ilk Failed görüldüğü anda başarısız, değilse son
+// görüldüğü anda sansürlü sayılır. Aynı adla yeniden oluşturulan pod'lar CreatedAt ile ayrılır
+func podObservations(samples []PodMetrics) []survivalObservation {
+	type podKey struct {
+		namespace, name string
+		created         int64
+	}
+	index := make(map[podKey]int)
+	var observations []survivalObservation
+	for i := range samples {
+		sample := &samples[i]
+		if sample.CreatedAt.IsZero() {
+			continue
+		}
+		key := podKey{namespace: sample.Namespace, name: sample.PodName, created: sample.CreatedAt.UnixNano()}
+		j, ok := index[key]
+		if !ok {
+			j = len(observations)
+			index[key] = j
+			observations = append(observations, survivalObservation{})
+		}
+		observation := &observations[j]
+		if observation.failed {
+			continue
+		}
+		if duration := sample.Timestamp.Sub(sample.CreatedAt); duration > observation.duration {
+			observation.duration = duration
+		}
+		if sample.Status == "Failed" && !sample.Chaos {
+			observation.failed = true
+		}
+	}
+	return observations
+}
+
+// estimateSurvival gözlemlerden Kaplan-Meier sağkalım eğrisini hesaplar ve ufuklardaki değerlerini döndürür.
+// Eğri son gözlemden sonra sabit kalır
+func estimateSurvival(observations []survivalObservation) SurvivalEstimate {
+	estimate := SurvivalEstimate{Pods: len(observations), Survival1h: 1, Survival24h: 1}
+	sort.Slice(observations, func(i, j int) bool { return observations[i].duration < observations[j].duration })
+
+	survival := 1.0
+	atRisk := len(observations)
+	for i := 0; i < len(observations); {
+		// Aynı süredeki gözlemler birlikte işlenir, başarısızlıklar sansürlülerden önce risk kümesinden çıkar
+		duration := observations[i].duration
+		failures, total := 0, 0
+		for ; i < len(observations) && observations[i].duration == duration; i++ {
+			total++
+			if observations[i].failed {
+				failures++
+			}
+		}
+		if failures > 0 {
+			survival *= 1 - float64(failures)/float64(atRisk)
+			estimate.Failures += failures
+			if duration <= SurvivalShortHorizon {
+				estimate.Survival1h = survival
+			}
+			if duration <= SurvivalLongHorizon {
+				estimate.Survival24h = survival
+			}
+		}
+		atRisk -= total
+	}
+	return estimate
+}
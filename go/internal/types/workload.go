@@ -0,0 +1,38 @@
+package types
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WorkloadRef pod'un bağlı olduğu iş yükü (Deployment, StatefulSet, DaemonSet, Job veya sahipsiz Pod)
+type WorkloadRef struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+}
+
+// String iş yükünü namespace/kind/name olarak döndürür
+func (w WorkloadRef) String() string {
+	return w.Namespace + "/" + w.Kind + "/" + w.Name
+}
+
+// WorkloadOf pod'un controller sahibinden iş yükünü çözer.
+// ReplicaSet sahipleri pod-template-hash etiketiyle Deployment'a indirgenir, sahibi olmayan pod kendi iş yüküdür
+func WorkloadOf(pod *corev1.Pod) WorkloadRef {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+
+		if owner.Kind == "ReplicaSet" {
+			if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+				return WorkloadRef{Namespace: pod.Namespace, Kind: "Deployment", Name: strings.TrimSuffix(owner.Name, "-"+hash)}
+			}
+		}
+		return WorkloadRef{Namespace: pod.Namespace, Kind: owner.Kind, Name: owner.Name}
+	}
+
+	return WorkloadRef{Namespace: pod.Namespace, Kind: "Pod", Name: pod.Name}
+}
@@ -0,0 +1,254 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// İş yükü kullanım geçmişinin varsayılanları ve histogram ölçeği
+const (
+	defaultUsageHalfLife  = 24 * time.Hour
+	defaultUsageRetention = 8 * 24 * time.Hour
+	histogramGrowth       = 1.1   // Komşu kovaların oranı (%10 çözünürlük)
+	histogramMaxBuckets   = 200   // 1m core / 1MB'den başlayarak ~190 bin core / GB'a kadar
+	cpuHistogramMin       = 0.001 // core
+	memoryHistogramMin    = 0.001 // GB
+)
+
+// ContainerUsage container'ın anlık CPU (core) ve memory (GB) kullanımı
+type ContainerUsage struct {
+	Container string  `json:"container"`
+	CPU       float64 `json:"cpu"`
+	Memory    float64 `json:"memory_gb"`
+}
+
+// PodUsageLister pod kullanımlarını toplu sağlayabilen kaynak (ör: sentetik küme), Metrics API yerine kullanılır
+type PodUsageLister interface {
+	// ListPodUsage pod'ların container kullanımlarını namespace/name anahtarıyla döndürür
+	ListPodUsage() (map[string][]ContainerUsage, error)
+}
+
+// UsageHistogram üstel kovalarda zamanla sönümlenen ağırlıklı kullanım dağılımı (VPA benzeri)
+type UsageHistogram struct {
+	min     float64
+	weights []float64 // Sadece kullanılan en yüksek kovaya kadar ayrılır
+	total   float64
+	updated time.Time
+}
+
+// bucket değerin kova indeksini döndürür, 0. kova [0, min) aralığıdır
+func (h *UsageHistogram) bucket(value float64) int {
+	if value < h.min {
+		return 0
+	}
+	i := int(math.Log(value/h.min)/math.Log(histogramGrowth)) + 1
+	if i >= histogramMaxBuckets {
+		i = histogramMaxBuckets - 1
+	}
+	return i
+}
+
+// add değeri ekler, önceki ağırlıklar geçen süreye göre yarılanma süresiyle sönümlenir
+func (h *UsageHistogram) add(value float64, now time.Time, halfLife time.Duration) {
+	if !h.updated.IsZero() && now.After(h.updated) {
+		decay := math.Pow(0.5, float64(now.Sub(h.updated))/float64(halfLife))
+		for i := range h.weights {
+			h.weights[i] *= decay
+		}
+		h.total *= decay
+	}
+	if now.After(h.updated) {
+		h.updated = now
+	}
+
+	i := h.bucket(value)
+	if i >= len(h.weights) {
+		grown := make([]float64, i+1)
+		copy(grown, h.weights)
+		h.weights = grown
+	}
+	h.weights[i]++
+	h.total++
+}
+
+// Percentile ağırlıkların p oranını (0-1) kapsayan kovanın üst sınırını döndürür, boşsa 0
+func (h *UsageHistogram) Percentile(p float64) float64 {
+	if h.total <= 0 {
+		return 0
+	}
+
+	threshold := p * h.total
+	var sum float64
+	for i, weight := range h.weights {
+		sum += weight
+		if sum >= threshold && weight > 0 {
+			return h.min * math.Pow(histogramGrowth, float64(i))
+		}
+	}
+	return h.min * math.Pow(histogramGrowth, float64(len(h.weights)-1))
+}
+
+// ContainerUsageSummary iş yükündeki bir container'ın istekleri ve kullanım dağılımı
+type ContainerUsageSummary struct {
+	Container     string
+	Samples       int
+	CPURequest    float64 // Son görülen istek (core)
+	MemoryRequest float64 // Son görülen istek (GB)
+	CPU           UsageHistogram
+	Memory        UsageHistogram
+}
+
+// WorkloadUsageSummary iş yükünün container bazında kullanım özeti
+type WorkloadUsageSummary struct {
+	Workload   WorkloadRef
+	Pods       int // Son toplamada görülen pod sayısı
+	LastSeen   time.Time
+	Containers []ContainerUsageSummary
+}
+
+// workloadUsage iş yükünün pod ve container bazında kayıtları
+type workloadUsage struct {
+	containers map[string]*ContainerUsageSummary
+	pods       map[string]time.Time // pod adı -> son görülme
+	lastSeen   time.Time
+}
+
+// WorkloadUsageTracker pod kullanımlarını iş yükü ve container bazında sönümlenen histogramlarda tutar
+// (rightsizing önerileri için). Pod'lar değişse de geçmiş iş yükü düzeyinde korunur
+type WorkloadUsageTracker struct {
+	mutex     sync.RWMutex
+	workloads map[WorkloadRef]*workloadUsage
+	halfLife  time.Duration
+	retention time.Duration
+}
+
+// NewWorkloadUsageTracker yeni iş yükü kullanım takipçisi oluşturur, sıfır değerler için varsayılanlar kullanılır
+func NewWorkloadUsageTracker(usageConfig *WorkloadUsageConfig) *WorkloadUsageTracker {
+	t := &WorkloadUsageTracker{workloads: make(map[WorkloadRef]*workloadUsage)}
+	t.Configure(usageConfig)
+	return t
+}
+
+// Configure yarılanma ve saklama sürelerini değiştirir, mevcut histogramlar korunur
+func (t *WorkloadUsageTracker) Configure(usageConfig *WorkloadUsageConfig) {
+	halfLife := usageConfig.HalfLife
+	if halfLife <= 0 {
+		halfLife = defaultUsageHalfLife
+	}
+	retention := usageConfig.Retention
+	if retention <= 0 {
+		retention = defaultUsageRetention
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.halfLife = halfLife
+	t.retention = retention
+}
+
+// Record pod'un container kullanımlarını iş yükünün histogramlarına ekler
+func (t *WorkloadUsageTracker) Record(pod *corev1.Pod, usage []ContainerUsage, now time.Time) {
+	ref := WorkloadOf(pod)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	workload, ok := t.workloads[ref]
+	if !ok {
+		workload = &workloadUsage{
+			containers: make(map[string]*ContainerUsageSummary),
+			pods:       make(map[string]time.Time),
+		}
+		t.workloads[ref] = workload
+	}
+	workload.pods[pod.Name] = now
+	workload.lastSeen = now
+
+	for i := range usage {
+		container := workload.containers[usage[i].Container]
+		if container == nil {
+			container = &ContainerUsageSummary{
+				Container: usage[i].Container,
+				CPU:       UsageHistogram{min: cpuHistogramMin},
+				Memory:    UsageHistogram{min: memoryHistogramMin},
+			}
+			workload.containers[usage[i].Container] = container
+		}
+
+		container.CPURequest, container.MemoryRequest = containerRequests(pod, usage[i].Container)
+		container.CPU.add(usage[i].CPU, now, t.halfLife)
+		container.Memory.add(usage[i].Memory, now, t.halfLife)
+		container.Samples++
+	}
+}
+
+// Expire saklama süresi boyunca görülmeyen pod ve iş yüklerini siler
+func (t *WorkloadUsageTracker) Expire(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cutoff := now.Add(-t.retention)
+	for ref, workload := range t.workloads {
+		if workload.lastSeen.Before(cutoff) {
+			delete(t.workloads, ref)
+			continue
+		}
+		for name, seen := range workload.pods {
+			if seen.Before(cutoff) {
+				delete(workload.pods, name)
+			}
+		}
+	}
+}
+
+// Summaries namespace filtresine uyan iş yüklerinin özetlerini döndürür (kopya, namespace/kind/name sıralı)
+func (t *WorkloadUsageTracker) Summaries(namespaces NamespaceFilter) []WorkloadUsageSummary {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	result := make([]WorkloadUsageSummary, 0, len(t.workloads))
+	for ref, workload := range t.workloads {
+		if !namespaces.Matches(ref.Namespace) {
+			continue
+		}
+
+		summary := WorkloadUsageSummary{
+			Workload:   ref,
+			LastSeen:   workload.lastSeen,
+			Containers: make([]ContainerUsageSummary, 0, len(workload.containers)),
+		}
+		for _, seen := range workload.pods {
+			if seen.Equal(workload.lastSeen) {
+				summary.Pods++
+			}
+		}
+		for _, container := range workload.containers {
+			copied := *container
+			copied.CPU.weights = append([]float64(nil), container.CPU.weights...)
+			copied.Memory.weights = append([]float64(nil), container.Memory.weights...)
+			summary.Containers = append(summary.Containers, copied)
+		}
+		sort.Slice(summary.Containers, func(i, j int) bool { return summary.Containers[i].Container < summary.Containers[j].Container })
+		result = append(result, summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Workload.String() < result[j].Workload.String() })
+	return result
+}
+
+// containerRequests pod'daki container'ın (sidecar dahil) CPU (core) ve memory (GB) isteklerini döndürür
+func containerRequests(pod *corev1.Pod, name string) (float64, float64) {
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for i := range containers {
+			if containers[i].Name == name {
+				return requestsOf(containers[i].Resources.Requests)
+			}
+		}
+	}
+	return 0, 0
+}
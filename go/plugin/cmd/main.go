@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+
+	plugin "ai-scheduler/plugin"
+
+	"k8s.io/component-base/cli"
+	// JSON log biçimi ve Prometheus metrik kaydı kube-scheduler'daki gibi
+	_ "k8s.io/component-base/logs/json/register"
+	_ "k8s.io/component-base/metrics/prometheus/clientgo"
+	_ "k8s.io/component-base/metrics/prometheus/version"
+	"k8s.io/kubernetes/cmd/kube-scheduler/app"
+)
+
+// kube-scheduler'ı AIScheduler plugin'i kayıtlı olarak çalıştırır. Plugin profillerde KubeSchedulerConfiguration ile
+// etkinleştirilir (bkz. scheduler-config.yaml)
+func main() {
+	command := app.NewSchedulerCommand(app.WithPlugin(plugin.Name, plugin.New))
+	os.Exit(cli.Run(command))
+}
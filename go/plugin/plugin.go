@@ -0,0 +1,232 @@
+// Package plugin AI scheduler'ın filtre ve skorlama hattını kube-scheduler framework plugin'i (Filter ve Score
+// uzantı noktaları) olarak sunar. Skorlama HTTP API'deki tahminle aynı AIScheduler koduyla yapılır
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"ai-scheduler/internal/collector"
+	"ai-scheduler/internal/features"
+	"ai-scheduler/internal/platform"
+	"ai-scheduler/internal/scheduler"
+	"ai-scheduler/internal/store"
+	"ai-scheduler/internal/types"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+)
+
+// Name KubeSchedulerConfiguration'daki plugin adı
+const Name = "AIScheduler"
+
+// defaultConfigPath plugin argümanında dizin verilmezse config.yaml'ın arandığı dizin
+const defaultConfigPath = "./config"
+
+// scorePrecision skorun tam sayıya çevrilirken korunan ondalık hassasiyeti
+const scorePrecision = 100
+
+// handleCheckInterval framework handle'ının informer'larının durup durmadığının denetim aralığı
+const handleCheckInterval = time.Second
+
+// Args plugin argümanları (pluginConfig[].args)
+type Args struct {
+	// ConfigPath ai-scheduler config.yaml dosyasının bulunduğu dizin
+	ConfigPath string `json:"configPath"`
+}
+
+// Plugin AIScheduler filtre ve skorlamasını framework uzantı noktalarına bağlar
+type Plugin struct {
+	scheduler *scheduler.AIScheduler
+}
+
+var (
+	_ framework.FilterPlugin    = &Plugin{}
+	_ framework.ScorePlugin     = &Plugin{}
+	_ framework.ScoreExtensions = &Plugin{}
+)
+
+// New plugin'i oluşturur: ai-scheduler konfigürasyonunu okur, metrik toplayıcıyı ve AI scheduler'ı framework
+// handle'ı çalıştıkça çalışacak şekilde başlatır
+func New(configuration runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	args := Args{ConfigPath: defaultConfigPath}
+	if configuration != nil {
+		if err := frameworkruntime.DecodeInto(configuration, &args); err != nil {
+			return nil, fmt.Errorf("%s argümanları çözülemedi: %v", Name, err)
+		}
+	}
+
+	config, err := loadConfig(args.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	k8sClient, err := types.NewK8sClient(&config.Kubernetes)
+	if err != nil {
+		return nil, fmt.Errorf("Kubernetes client oluşturulamadı: %v", err)
+	}
+
+	ctx, cancel := handleContext(handle)
+	dataCollector := collector.NewDataCollector(k8sClient, &config.Metrics)
+	sharedCache := false
+	if config.Metrics.SharedCache.Enabled {
+		podCache, err := store.NewRedisPodCache(&config.Metrics.SharedCache, config.Metrics.CollectionInterval)
+		if err != nil {
+			logrus.Warnf("Paylaşılan pod metrik cache'i açılamadı, yerel cache kullanılıyor: %v", err)
+		} else {
+			dataCollector.SetPodCache(podCache)
+			go podCache.Start(ctx)
+			sharedCache = true
+		}
+	}
+	aiScheduler := scheduler.NewAIScheduler(k8sClient, dataCollector, &config.Scheduler)
+	aiScheduler.SetFeatureGate(features.NewGate(config.Features))
+	aiScheduler.SetPlatformResolver(platform.NewResolver(&config.Scheduler.Platform))
+	if config.Metrics.Persistence.Enabled {
+		historyStore, err := store.Open(&config.HistoryStore)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("geçmiş deposu açılamadı: %v", err)
+		}
+		dataCollector.SetHistoryStore(historyStore)
+	}
+	// Paylaşılan cache geçmişi Redis'ten aldığı için snapshot yüklenmez
+	if !sharedCache {
+		if samples, err := dataCollector.RestorePodCache(ctx); err != nil {
+			logrus.Warnf("Pod metrik cache'i geçmiş deposundan yüklenemedi, boş cache'le başlanıyor: %v", err)
+		} else if samples > 0 {
+			logrus.Infof("Pod metrik cache'i geçmiş deposundan yüklendi: %d örnek", samples)
+		}
+	}
+	go dataCollector.Start(ctx)
+	go aiScheduler.Start(ctx)
+
+	logrus.Infof("%s plugin'i başlatıldı (konfigürasyon: %s)", Name, args.ConfigPath)
+	return &Plugin{scheduler: aiScheduler}, nil
+}
+
+// handleContext framework handle'ının ömrüne bağlı context döndürür. kube-scheduler 1.28 plugin fabrikasına context
+// vermez; handle'ın informer fabrikası scheduler'ın çalışma context'iyle başlatılıp durdurulduğundan scheduler'ın
+// kullandığı pod informer'ı durduğunda context iptal olur
+func handleContext(handle framework.Handle) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if handle == nil || handle.SharedInformerFactory() == nil {
+		return ctx, cancel
+	}
+	informer := handle.SharedInformerFactory().Core().V1().Pods().Informer()
+	go func() {
+		ticker := time.NewTicker(handleCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if informer.IsStopped() {
+					logrus.Infof("%s plugin'i durduruluyor: framework handle'ı kapandı", Name)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return ctx, cancel
+}
+
+// loadConfig dizindeki config.yaml'ı ai-scheduler ile aynı şekilde (ortam değişkenleri dahil) yükler
+func loadConfig(path string) (*types.Config, error) {
+	v := viper.New()
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(path)
+	v.AutomaticEnv()
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("config dosyası okunamadı: %v", err)
+	}
+
+	var config types.Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("konfigürasyon parse edilemedi: %v", err)
+	}
+	return &config, nil
+}
+
+// Name plugin adını döndürür
+func (p *Plugin) Name() string {
+	return Name
+}
+
+// Filter pod'u node için AI scheduler'ın filtre hattından geçirir. Node nesnesine bağlı kısıtlar preemption ile
+// çözülemez (UnschedulableAndUnresolvable) sayılır. Kapsam dışındaki pod'lar ve AI scheduler'ın henüz görmediği
+// node'lar elenmez
+func (p *Plugin) Filter(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node bulunamadı")
+	}
+	if !p.scheduler.InScope(pod) {
+		return nil
+	}
+
+	result, err := p.scheduler.FilterNode(pod, node.Name)
+	switch {
+	case errors.Is(err, types.ErrNodeNotFound):
+		return nil
+	case err != nil:
+		return framework.AsStatus(err)
+	case result.Reason == "":
+		return nil
+	case result.Unresolvable:
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, result.Message)
+	default:
+		return framework.NewStatus(framework.Unschedulable, result.Message)
+	}
+}
+
+// Score node'un heuristik skorunu döndürür, skorlar NormalizeScore'da 0-MaxNodeScore aralığına ölçeklenir.
+// Kapsam dışındaki pod'lar ve AI scheduler'ın görmediği node'lar 0 alır
+func (p *Plugin) Score(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeName string) (int64, *framework.Status) {
+	if !p.scheduler.InScope(pod) {
+		return 0, nil
+	}
+
+	score, err := p.scheduler.ScoreNode(ctx, pod, nodeName)
+	switch {
+	case errors.Is(err, types.ErrNodeNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, framework.AsStatus(err)
+	}
+	return int64(math.Round(score * scorePrecision)), nil
+}
+
+// ScoreExtensions skorların normalleştirilmesini plugin'in kendisine bırakır
+func (p *Plugin) ScoreExtensions() framework.ScoreExtensions {
+	return p
+}
+
+// NormalizeScore skorları en iyi node MaxNodeScore, en kötüsü 0 olacak şekilde ölçekler. Tüm skorlar eşitse
+// sıralama diğer plugin'lere kalır
+func (p *Plugin) NormalizeScore(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, scores framework.NodeScoreList) *framework.Status {
+	if len(scores) == 0 {
+		return nil
+	}
+	low, high := scores[0].Score, scores[0].Score
+	for _, score := range scores {
+		low, high = min(low, score.Score), max(high, score.Score)
+	}
+	for i := range scores {
+		if high == low {
+			scores[i].Score = 0
+			continue
+		}
+		scores[i].Score = (scores[i].Score - low) * framework.MaxNodeScore / (high - low)
+	}
+	return nil
+}